@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+)
+
+func RegisterAudit(parentCmd *cobra.Command, logger *slog.Logger) {
+	auditCmd := &cobra.Command{
+		Use:               "audit",
+		Short:             "Run commands to interact with audit logs",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
+	}
+
+	registerAuditVerify(auditCmd)
+
+	parentCmd.AddCommand(auditCmd)
+}
+
+func registerAuditVerify(parentCmd *cobra.Command) {
+	var publicKeyPath string
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <file>",
+		Short: "Verifies the hash chain of an audit log file written by the file auditor. Returns a non-zero exit code at the first record which breaks the chain.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(currCmd *cobra.Command, args []string) error {
+			return runAuditVerify(currCmd.OutOrStdout(), args[0], publicKeyPath)
+		},
+	}
+
+	verifyCmd.Flags().StringVar(&publicKeyPath, "public-key", "", "path to the PEM encoded ed25519 public key of the signing key, checkpoint signatures are not verified without it")
+	parentCmd.AddCommand(verifyCmd)
+}
+
+func runAuditVerify(out io.Writer, path string, publicKeyPath string) error {
+	var key ed25519.PublicKey
+	if publicKeyPath != "" {
+		pemKey, err := os.ReadFile(publicKeyPath)
+		if err != nil {
+			return err
+		}
+
+		key, err = audittypes.NewChainVerifyingKeyFromPEM(pemKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	verification, err := audittypes.VerifyChain(file, key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "chain is intact: %d batches, %d checkpoints\n", verification.Batches, verification.Checkpoints)
+	if key == nil {
+		fmt.Fprintln(out, "checkpoint signatures were not verified, pass --public-key to verify them")
+	}
+	if verification.Unsigned > 0 {
+		fmt.Fprintf(out, "the last %d batches are after the last checkpoint and are not covered by a signature\n", verification.Unsigned)
+	}
+
+	return nil
+}
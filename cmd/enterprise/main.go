@@ -15,6 +15,7 @@ func main() {
 	registerServer(cmd.RootCmd, logger)
 	cmd.RegisterGenerate(cmd.RootCmd, logger)
 	cmd.RegisterMetastore(cmd.RootCmd, logger, sqlstoreProviderFactories, sqlschemaProviderFactories)
+	cmd.RegisterAudit(cmd.RootCmd, logger)
 
 	cmd.Execute(logger)
 }
@@ -0,0 +1,267 @@
+package anomaly
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	// historySeasons is the number of seasons before the current period the
+	// forecasting providers learn from, the same span the seasonal providers
+	// look back over.
+	historySeasons = uint64(4)
+
+	// minScale keeps the anomaly score finite for series which never vary.
+	minScale = 1e-9
+)
+
+// forecaster predicts the values of a series past its history.
+type forecaster interface {
+	// forecast takes the history of a series, one value per step with NaN
+	// where the series has no point, and the length of a season in steps. It
+	// returns the predictions for the given horizons, in steps past the end of
+	// the history, and the scale of the residuals of the history.
+	forecast(history []float64, period int, horizons []int) ([]float64, float64)
+}
+
+// getHistoryQuery returns the query for the seasons before the current period.
+func (p *BaseSeasonalProvider) getHistoryQuery(req *AnomaliesRequest) qbtypes.QueryRangeRequest {
+	return qbtypes.QueryRangeRequest{
+		Start:          req.Params.Start - historySeasons*req.Seasonality.Offset(),
+		End:            req.Params.Start,
+		RequestType:    qbtypes.RequestTypeTimeSeries,
+		CompositeQuery: req.Params.CompositeQuery,
+		NoCache:        false,
+	}
+}
+
+// getZScoreThreshold returns the z_score_threshold argument of the anomaly
+// function of the query.
+func (p *BaseSeasonalProvider) getZScoreThreshold(ctx context.Context, funcs []qbtypes.Function) float64 {
+	var zScoreThreshold float64
+	for _, f := range funcs {
+		if f.Name == qbtypes.FunctionNameAnomaly {
+			for _, arg := range f.Args {
+				if arg.Name != "z_score_threshold" {
+					continue
+				}
+				value, ok := arg.Value.(float64)
+				if ok {
+					zScoreThreshold = value
+				} else {
+					p.logger.InfoContext(ctx, "z_score_threshold not provided, defaulting")
+					zScoreThreshold = 3
+				}
+				break
+			}
+		}
+	}
+	return zScoreThreshold
+}
+
+// getForecastAnomalies scores the current period against the predictions of
+// the forecaster, trained on the seasons before it.
+func (p *BaseSeasonalProvider) getForecastAnomalies(ctx context.Context, orgID valuer.UUID, req *AnomaliesRequest, f forecaster) (*AnomaliesResponse, error) {
+	if !req.Seasonality.IsValid() {
+		req.Seasonality = SeasonalityDaily
+	}
+
+	currentPeriodQuery := qbtypes.QueryRangeRequest{
+		Start:          req.Params.Start,
+		End:            req.Params.End,
+		RequestType:    qbtypes.RequestTypeTimeSeries,
+		CompositeQuery: req.Params.CompositeQuery,
+		NoCache:        false,
+	}
+	historyQuery := p.getHistoryQuery(req)
+
+	p.logger.InfoContext(ctx, "fetching results for current period", slog.Any("anomaly_current_period_query", currentPeriodQuery))
+	currentPeriodResp, err := p.querier.QueryRange(ctx, orgID, &currentPeriodQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.InfoContext(ctx, "fetching results for history", slog.Any("anomaly_history_query", historyQuery))
+	historyResp, err := p.querier.QueryRange(ctx, orgID, &historyQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	historyResults := make(map[string]*qbtypes.TimeSeriesData)
+	for _, result := range p.toTSResults(ctx, historyResp) {
+		historyResults[result.QueryName] = result
+	}
+
+	results := p.toTSResults(ctx, currentPeriodResp)
+	for _, result := range results {
+		zScoreThreshold := p.getZScoreThreshold(ctx, req.Params.FuncsForQuery(result.QueryName))
+
+		historyResult, ok := historyResults[result.QueryName]
+		if !ok {
+			continue
+		}
+
+		// no data;
+		if len(result.Aggregations) == 0 {
+			continue
+		}
+
+		aggOfInterest := result.Aggregations[0]
+
+		for _, series := range aggOfInterest.Series {
+			historySeries := p.getMatchingSeries(ctx, historyResult, series)
+
+			predictedSeries, scale, ok := p.getForecastSeries(series, historySeries, req.Params.Start, req.Seasonality, f)
+			if !ok {
+				p.logger.InfoContext(ctx, "not enough history to forecast series", slog.Any("anomaly_labels", series.Labels))
+				continue
+			}
+			p.logger.InfoContext(ctx, "calculated residual scale for series", slog.Float64("anomaly_scale", scale), slog.Any("anomaly_labels", series.Labels))
+
+			upperBoundSeries := &qbtypes.TimeSeries{Labels: series.Labels, Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values))}
+			lowerBoundSeries := &qbtypes.TimeSeries{Labels: series.Labels, Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values))}
+			anomalyScoreSeries := &qbtypes.TimeSeries{Labels: series.Labels, Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values))}
+			for idx, curr := range series.Values {
+				predicted := predictedSeries.Values[idx].Value
+				upperBoundSeries.Values = append(upperBoundSeries.Values, &qbtypes.TimeSeriesValue{
+					Timestamp: curr.Timestamp,
+					Value:     predicted + zScoreThreshold*scale,
+				})
+				lowerBoundSeries.Values = append(lowerBoundSeries.Values, &qbtypes.TimeSeriesValue{
+					Timestamp: curr.Timestamp,
+					Value:     math.Max(predicted-zScoreThreshold*scale, 0),
+				})
+				anomalyScoreSeries.Values = append(anomalyScoreSeries.Values, &qbtypes.TimeSeriesValue{
+					Timestamp: curr.Timestamp,
+					Value:     (curr.Value - predicted) / scale,
+				})
+			}
+
+			aggOfInterest.PredictedSeries = append(aggOfInterest.PredictedSeries, predictedSeries)
+			aggOfInterest.UpperBoundSeries = append(aggOfInterest.UpperBoundSeries, upperBoundSeries)
+			aggOfInterest.LowerBoundSeries = append(aggOfInterest.LowerBoundSeries, lowerBoundSeries)
+			aggOfInterest.AnomalyScores = append(aggOfInterest.AnomalyScores, anomalyScoreSeries)
+		}
+	}
+
+	return &AnomaliesResponse{
+		Results: results,
+	}, nil
+}
+
+// getForecastSeries lays the history of the series on a grid of its step and
+// predicts every point of the series with the forecaster. It returns false
+// when the history is too short to tell the step or to predict from.
+func (p *BaseSeasonalProvider) getForecastSeries(series, historySeries *qbtypes.TimeSeries, start uint64, seasonality Seasonality, f forecaster) (*qbtypes.TimeSeries, float64, bool) {
+	if historySeries == nil || len(historySeries.Values) == 0 {
+		return nil, 0, false
+	}
+
+	step := getStep(historySeries)
+	if step <= 0 {
+		return nil, 0, false
+	}
+
+	origin := historySeries.Values[0].Timestamp
+	n := int((int64(start)-origin)/step) + 1
+	for n > 0 && origin+int64(n-1)*step >= int64(start) {
+		n--
+	}
+	if n <= 0 {
+		return nil, 0, false
+	}
+
+	history := make([]float64, n)
+	for i := range history {
+		history[i] = math.NaN()
+	}
+	for _, value := range historySeries.Values {
+		offset := value.Timestamp - origin
+		if offset%step != 0 || offset/step >= int64(n) || math.IsNaN(value.Value) || math.IsInf(value.Value, 0) {
+			continue
+		}
+		history[offset/step] = value.Value
+	}
+
+	horizons := make([]int, len(series.Values))
+	for idx, curr := range series.Values {
+		horizons[idx] = max(1, int(math.Round(float64(curr.Timestamp-origin)/float64(step)))-n+1)
+	}
+
+	period := max(1, int(math.Round(float64(seasonality.Offset())/float64(step))))
+	predictions, scale := f.forecast(history, period, horizons)
+	if slices.ContainsFunc(predictions, math.IsNaN) {
+		return nil, 0, false
+	}
+
+	predictedSeries := &qbtypes.TimeSeries{
+		Labels: series.Labels,
+		Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values)),
+	}
+	for idx, curr := range series.Values {
+		predictedSeries.Values = append(predictedSeries.Values, &qbtypes.TimeSeriesValue{
+			Timestamp: curr.Timestamp,
+			Value:     predictions[idx],
+		})
+	}
+
+	return predictedSeries, math.Max(scale, minScale), true
+}
+
+// getStep returns the smallest gap between two points of the series in
+// milliseconds, zero when the series has less than two points.
+func getStep(series *qbtypes.TimeSeries) int64 {
+	var step int64
+	for i := 1; i < len(series.Values); i++ {
+		gap := series.Values[i].Timestamp - series.Values[i-1].Timestamp
+		if gap > 0 && (step == 0 || gap < step) {
+			step = gap
+		}
+	}
+	return step
+}
+
+// median returns the median of the values which are not NaN, NaN when there
+// are none. The values are reordered.
+func median(values []float64) float64 {
+	values = slices.DeleteFunc(values, math.IsNaN)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+
+	slices.Sort(values)
+	mid := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[mid]
+	}
+	return (values[mid-1] + values[mid]) / 2
+}
+
+// robustScale estimates the standard deviation of the residuals from their
+// median absolute deviation, which a few spikes do not inflate. When most of
+// the residuals are equal, as in sparse series, the mean absolute deviation
+// is used instead.
+func robustScale(residuals []float64) float64 {
+	residuals = slices.DeleteFunc(slices.Clone(residuals), math.IsNaN)
+	if len(residuals) == 0 {
+		return 0
+	}
+
+	center := median(slices.Clone(residuals))
+	deviations := make([]float64, len(residuals))
+	var sum float64
+	for i, r := range residuals {
+		deviations[i] = math.Abs(r - center)
+		sum += deviations[i]
+	}
+
+	if mad := median(deviations); mad > 0 {
+		return 1.4826 * mad
+	}
+	return 1.2533 * sum / float64(len(residuals))
+}
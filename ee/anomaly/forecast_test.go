@@ -0,0 +1,231 @@
+package anomaly
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// syntheticSeries returns a seasonal series with a slow trend and noise, along
+// with the indexes of the anomalies injected into it. When spiky, the noise
+// has heavy tails, as the latency of a service with occasional slow requests.
+func syntheticSeries(r *rand.Rand, period, seasons int, spiky bool, anomalies int) ([]float64, map[int]bool) {
+	n := period * seasons
+	series := make([]float64, n)
+	for i := range series {
+		series[i] = 100 + 30*math.Sin(2*math.Pi*float64(i)/float64(period)) + 0.02*float64(i) + r.NormFloat64()*2
+		if spiky && r.Float64() < 0.03 {
+			series[i] += 6 + r.Float64()*4
+		}
+	}
+
+	// Anomalies are injected in the last season only, the history before it
+	// stays as the forecasters would normally see it.
+	injected := map[int]bool{}
+	for len(injected) < anomalies {
+		i := n - period + r.Intn(period)
+		if injected[i] {
+			continue
+		}
+		injected[i] = true
+		if r.Intn(2) == 0 {
+			series[i] += 40
+		} else {
+			series[i] -= 40
+		}
+	}
+
+	return series, injected
+}
+
+// backtest walks over the last season of the series, predicts every point
+// from all the points before it, and returns the precision and the recall of
+// flagging the points more than threshold residual scales away from their
+// predictions against the injected anomalies.
+func backtest(f forecaster, series []float64, period int, injected map[int]bool, threshold float64) (float64, float64) {
+	var truePositives, falsePositives, falseNegatives int
+	for i := len(series) - period; i < len(series); i++ {
+		predictions, scale := f.forecast(series[:i], period, []int{1})
+		flagged := math.Abs(series[i]-predictions[0])/math.Max(scale, minScale) > threshold
+
+		switch {
+		case flagged && injected[i]:
+			truePositives++
+		case flagged:
+			falsePositives++
+		case injected[i]:
+			falseNegatives++
+		}
+	}
+
+	precision := float64(truePositives) / math.Max(1, float64(truePositives+falsePositives))
+	recall := float64(truePositives) / math.Max(1, float64(truePositives+falseNegatives))
+	return precision, recall
+}
+
+func TestForecastersBacktest(t *testing.T) {
+	const seeds = 10
+
+	forecasters := map[string]forecaster{
+		"robust":       robust{},
+		"holt_winters": holtWinters{alpha: 0.2, beta: 0.01, gamma: 0.2},
+		"stl":          stl{},
+	}
+
+	for name, f := range forecasters {
+		for _, spiky := range []bool{false, true} {
+			t.Run(name, func(t *testing.T) {
+				var precision, recall float64
+				for seed := range int64(seeds) {
+					series, injected := syntheticSeries(rand.New(rand.NewSource(seed)), 60, 5, spiky, 6)
+					p, r := backtest(f, series, 60, injected, 4)
+					precision += p / seeds
+					recall += r / seeds
+				}
+
+				assert.GreaterOrEqual(t, precision, 0.9, "spiky=%v", spiky)
+				assert.GreaterOrEqual(t, recall, 0.99, "spiky=%v", spiky)
+			})
+		}
+	}
+}
+
+func TestForecastersMissingPoints(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	series, _ := syntheticSeries(r, 60, 5, false, 0)
+	for i := range series {
+		if r.Float64() < 0.2 {
+			series[i] = math.NaN()
+		}
+	}
+
+	for name, f := range map[string]forecaster{"robust": robust{}, "holt_winters": holtWinters{alpha: 0.2, beta: 0.01, gamma: 0.2}, "stl": stl{}} {
+		t.Run(name, func(t *testing.T) {
+			predictions, scale := f.forecast(series, 60, []int{1, 2, 3})
+			for _, p := range predictions {
+				assert.False(t, math.IsNaN(p))
+			}
+			assert.Greater(t, scale, 0.0)
+			assert.Less(t, scale, 6.0)
+		})
+	}
+}
+
+func TestForecastersShortHistory(t *testing.T) {
+	history := []float64{10, 12, 11, 13, 10}
+
+	for name, f := range map[string]forecaster{"robust": robust{}, "holt_winters": holtWinters{alpha: 0.2, beta: 0.01, gamma: 0.2}, "stl": stl{}} {
+		t.Run(name, func(t *testing.T) {
+			predictions, scale := f.forecast(history, 60, []int{1})
+			require.Len(t, predictions, 1)
+			assert.InDelta(t, 11, predictions[0], 2)
+			assert.Greater(t, scale, 0.0)
+		})
+	}
+}
+
+func TestRobustScale(t *testing.T) {
+	assert.InDelta(t, 1.4826, robustScale([]float64{-1, 0, 1, math.NaN(), 100, -100, 0.5, -0.5, 1, -1}), 1e-9)
+	// mostly zero residuals fall back to the mean absolute deviation
+	assert.InDelta(t, 1.2533*10/8, robustScale([]float64{0, 0, 0, 0, 0, 0, 0, 10}), 1e-9)
+	assert.Equal(t, 0.0, robustScale(nil))
+}
+
+type fakeQuerier struct {
+	responses map[uint64]*qbtypes.QueryRangeResponse
+}
+
+func (q *fakeQuerier) QueryRange(_ context.Context, _ valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	return q.responses[req.Start], nil
+}
+
+func (q *fakeQuerier) QueryRawStream(context.Context, valuer.UUID, *qbtypes.QueryRangeRequest, *qbtypes.RawStream) {
+}
+
+func (q *fakeQuerier) PurgeCache(context.Context, valuer.UUID, *qbtypes.PostableCachePurge) error {
+	return nil
+}
+
+func TestForecastProvidersGetAnomalies(t *testing.T) {
+	step := int64(60_000)
+	period := int(oneHourOffset) / int(step)
+
+	r := rand.New(rand.NewSource(1))
+	series, _ := syntheticSeries(r, period, 5, false, 0)
+	series[len(series)-1] += 60
+
+	start := uint64(1_700_000_000_000) - uint64(period)*uint64(step)
+	historyStart := start - historySeasons*oneHourOffset
+	toResponse := func(values []float64, from uint64) *qbtypes.QueryRangeResponse {
+		ts := &qbtypes.TimeSeries{Labels: []*qbtypes.Label{}}
+		for i, v := range values {
+			ts.Values = append(ts.Values, &qbtypes.TimeSeriesValue{Timestamp: int64(from) + int64(i)*step, Value: v})
+		}
+		return &qbtypes.QueryRangeResponse{
+			Data: qbtypes.QueryData{Results: []any{&qbtypes.TimeSeriesData{
+				QueryName:    "A",
+				Aggregations: []*qbtypes.AggregationBucket{{Series: []*qbtypes.TimeSeries{ts}}},
+			}}},
+		}
+	}
+	q := &fakeQuerier{responses: map[uint64]*qbtypes.QueryRangeResponse{
+		historyStart: toResponse(series[:len(series)-period], historyStart),
+	}}
+
+	providers := map[string]Provider{
+		"robust":       NewRobustProvider(WithQuerier[*RobustProvider](q), WithLogger[*RobustProvider](slog.New(slog.DiscardHandler))),
+		"holt_winters": NewHoltWintersProvider(WithQuerier[*HoltWintersProvider](q), WithLogger[*HoltWintersProvider](slog.New(slog.DiscardHandler))),
+		"stl":          NewSTLProvider(WithQuerier[*STLProvider](q), WithLogger[*STLProvider](slog.New(slog.DiscardHandler))),
+	}
+
+	for name, provider := range providers {
+		t.Run(name, func(t *testing.T) {
+			// results are annotated in place, every run gets a fresh one
+			q.responses[start] = toResponse(series[len(series)-period:], start)
+
+			resp, err := provider.GetAnomalies(context.Background(), valuer.GenerateUUID(), &AnomaliesRequest{
+				Params: &qbtypes.QueryRangeRequest{
+					Start: start,
+					End:   start + uint64(period)*uint64(step),
+					CompositeQuery: qbtypes.CompositeQuery{Queries: []qbtypes.QueryEnvelope{{
+						Type: qbtypes.QueryTypeBuilder,
+						Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+							Name:      "A",
+							Functions: []qbtypes.Function{{Name: qbtypes.FunctionNameAnomaly, Args: []qbtypes.FunctionArg{{Name: "z_score_threshold", Value: 3.0}}}},
+						},
+					}}},
+				},
+				Seasonality: SeasonalityHourly,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Results, 1)
+
+			agg := resp.Results[0].Aggregations[0]
+			require.Len(t, agg.PredictedSeries, 1)
+			require.Len(t, agg.UpperBoundSeries, 1)
+			require.Len(t, agg.LowerBoundSeries, 1)
+			require.Len(t, agg.AnomalyScores, 1)
+
+			scores := agg.AnomalyScores[0].Values
+			require.Len(t, scores, period)
+			assert.Greater(t, scores[period-1].Value, 3.0)
+
+			var flagged int
+			for _, score := range scores {
+				if math.Abs(score.Value) > 3 {
+					flagged++
+				}
+			}
+			assert.LessOrEqual(t, flagged, 3)
+			assert.Less(t, agg.LowerBoundSeries[0].Values[0].Value, agg.UpperBoundSeries[0].Values[0].Value)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package anomaly
+
+import (
+	"context"
+	"math"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// HoltWintersProvider predicts with additive triple exponential smoothing. It
+// keeps a level, a trend and a seasonal component per time of the season
+// which are updated with every point of the history, so the predictions
+// follow gradual growth of the series rather than lagging behind it.
+type HoltWintersProvider struct {
+	BaseSeasonalProvider
+}
+
+var _ BaseProvider = (*HoltWintersProvider)(nil)
+
+func (hp *HoltWintersProvider) GetBaseSeasonalProvider() *BaseSeasonalProvider {
+	return &hp.BaseSeasonalProvider
+}
+
+func NewHoltWintersProvider(opts ...GenericProviderOption[*HoltWintersProvider]) *HoltWintersProvider {
+	hp := &HoltWintersProvider{
+		BaseSeasonalProvider: BaseSeasonalProvider{},
+	}
+
+	for _, opt := range opts {
+		opt(hp)
+	}
+
+	return hp
+}
+
+func (p *HoltWintersProvider) GetAnomalies(ctx context.Context, orgID valuer.UUID, req *AnomaliesRequest) (*AnomaliesResponse, error) {
+	return p.getForecastAnomalies(ctx, orgID, req, holtWinters{alpha: 0.2, beta: 0.01, gamma: 0.2})
+}
+
+var (
+	// holtWintersClip is how many residual scales away from the prediction a
+	// point may be before it is clipped while smoothing, to keep past
+	// anomalies out of the components.
+	holtWintersClip = 3.0
+)
+
+type holtWinters struct {
+	// alpha is the smoothing factor of the level.
+	alpha float64
+	// beta is the smoothing factor of the trend.
+	beta float64
+	// gamma is the smoothing factor of the seasonal components.
+	gamma float64
+}
+
+func (hw holtWinters) forecast(history []float64, period int, horizons []int) ([]float64, float64) {
+	// Without two seasons to initialise the seasonal components from, the
+	// series is smoothed without seasonality.
+	if period < 2 || len(history) < 2*period {
+		period = 1
+	}
+
+	// The first pass estimates the scale of the residuals, the second one
+	// clips the points beyond it so spikes do not leak into the components.
+	_, residuals := hw.smooth(history, period, math.Inf(1))
+	scale := robustScale(residuals)
+	state, residuals := hw.smooth(history, period, holtWintersClip*math.Max(scale, minScale))
+
+	predictions := make([]float64, len(horizons))
+	for idx, h := range horizons {
+		predictions[idx] = state.predict(len(history)-1, h)
+	}
+
+	return predictions, robustScale(residuals)
+}
+
+type holtWintersState struct {
+	level    float64
+	trend    float64
+	seasonal []float64
+}
+
+// predict returns the prediction h steps after the point i.
+func (s *holtWintersState) predict(i, h int) float64 {
+	return s.level + float64(h)*s.trend + s.seasonal[(i+h)%len(s.seasonal)]
+}
+
+// smooth runs the smoothing over the history and returns the final state
+// along with the one step ahead residuals past the first season. Points
+// further than clip from their prediction are taken to be at clip, missing
+// points at their prediction.
+func (hw holtWinters) smooth(history []float64, period int, clip float64) (*holtWintersState, []float64) {
+	state := &holtWintersState{seasonal: make([]float64, period)}
+
+	first := seasonMean(history, 0, period)
+	state.level = first
+	if period > 1 {
+		state.trend = (seasonMean(history, period, period) - first) / float64(period)
+		for i := range state.seasonal {
+			if !math.IsNaN(history[i]) {
+				state.seasonal[i] = history[i] - first
+			}
+		}
+	}
+	if math.IsNaN(state.level) {
+		state.level = 0
+	}
+	if math.IsNaN(state.trend) {
+		state.trend = 0
+	}
+
+	residuals := make([]float64, 0, len(history))
+	for i := period; i < len(history); i++ {
+		predicted := state.predict(i-1, 1)
+
+		observed := history[i]
+		if math.IsNaN(observed) {
+			observed = predicted
+		} else {
+			if i >= 2*period || period == 1 {
+				residuals = append(residuals, observed-predicted)
+			}
+			observed = math.Max(predicted-clip, math.Min(predicted+clip, observed))
+		}
+
+		phase := i % period
+		level := hw.alpha*(observed-state.seasonal[phase]) + (1-hw.alpha)*(state.level+state.trend)
+		state.trend = hw.beta*(level-state.level) + (1-hw.beta)*state.trend
+		if period > 1 {
+			state.seasonal[phase] = hw.gamma*(observed-level) + (1-hw.gamma)*state.seasonal[phase]
+		}
+		state.level = level
+	}
+
+	return state, residuals
+}
+
+// seasonMean returns the mean of the points of the history from start on for
+// a season, NaN when there are none.
+func seasonMean(history []float64, start, period int) float64 {
+	var sum float64
+	var count int
+	for i := start; i < start+period && i < len(history); i++ {
+		if !math.IsNaN(history[i]) {
+			sum += history[i]
+			count++
+		}
+	}
+	if count == 0 {
+		return math.NaN()
+	}
+	return sum / float64(count)
+}
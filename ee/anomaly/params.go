@@ -31,6 +31,18 @@ func (s Seasonality) IsValid() bool {
 	}
 }
 
+// Offset returns the length of a season in milliseconds.
+func (s Seasonality) Offset() uint64 {
+	switch s {
+	case SeasonalityHourly:
+		return oneHourOffset
+	case SeasonalityWeekly:
+		return oneWeekOffset
+	default:
+		return oneDayOffset
+	}
+}
+
 type AnomaliesRequest struct {
 	Params      *qbtypes.QueryRangeRequest
 	Seasonality Seasonality
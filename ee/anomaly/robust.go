@@ -0,0 +1,114 @@
+package anomaly
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// RobustProvider predicts a point with the median of the points around the
+// same time in past seasons, adjusted by the growth since, and scores it with
+// the median absolute deviation of the history from those predictions.
+// Unlike averages and standard deviations, neither moves much when a spiky
+// series has a few large spikes.
+type RobustProvider struct {
+	BaseSeasonalProvider
+}
+
+var _ BaseProvider = (*RobustProvider)(nil)
+
+func (rp *RobustProvider) GetBaseSeasonalProvider() *BaseSeasonalProvider {
+	return &rp.BaseSeasonalProvider
+}
+
+func NewRobustProvider(opts ...GenericProviderOption[*RobustProvider]) *RobustProvider {
+	rp := &RobustProvider{
+		BaseSeasonalProvider: BaseSeasonalProvider{},
+	}
+
+	for _, opt := range opts {
+		opt(rp)
+	}
+
+	return rp
+}
+
+func (p *RobustProvider) GetAnomalies(ctx context.Context, orgID valuer.UUID, req *AnomaliesRequest) (*AnomaliesResponse, error) {
+	return p.getForecastAnomalies(ctx, orgID, req, robust{})
+}
+
+var (
+	// robustNeighbours is the number of points on each side of the same time
+	// in a past season which are part of its median, to absorb some jitter.
+	robustNeighbours = 2
+)
+
+type robust struct{}
+
+func (robust) forecast(history []float64, period int, horizons []int) ([]float64, float64) {
+	n := len(history)
+
+	residuals := make([]float64, 0, n)
+	for i := period; i < n; i++ {
+		if math.IsNaN(history[i]) {
+			continue
+		}
+		residuals = append(residuals, history[i]-seasonalMedian(history, period, i, n))
+	}
+
+	// The median residual of the last season is the growth of the series
+	// since the seasons the medians are taken over.
+	growth := median(slices.Clone(residuals[max(0, len(residuals)-period):]))
+	if math.IsNaN(growth) {
+		growth = 0
+	}
+
+	predictions := make([]float64, len(horizons))
+	for idx, h := range horizons {
+		predictions[idx] = seasonalMedian(history, period, n-1+h, n) + growth
+	}
+
+	// Without a full season of history there is nothing to compare against,
+	// the history is taken to be flat around its median.
+	if len(residuals) == 0 {
+		level := median(cloneFinite(history))
+		for i := range predictions {
+			predictions[i] = level
+		}
+		for _, v := range history {
+			residuals = append(residuals, v-level)
+		}
+	}
+
+	return predictions, robustScale(residuals)
+}
+
+// seasonalMedian returns the median of the points of the first n points of the
+// history around the same time as i in the seasons before it. It falls back
+// to the median of the history before i when no season before it is known.
+func seasonalMedian(history []float64, period, i, n int) float64 {
+	peers := make([]float64, 0, (2*robustNeighbours+1)*(i/period))
+	for base := i - period; base >= 0; base -= period {
+		for j := max(0, base-robustNeighbours); j <= base+robustNeighbours && j < min(i, n); j++ {
+			peers = append(peers, history[j])
+		}
+	}
+
+	if m := median(peers); !math.IsNaN(m) {
+		return m
+	}
+	return median(cloneFinite(history[:min(i, n)]))
+}
+
+// cloneFinite returns a copy of the values without NaN.
+func cloneFinite(values []float64) []float64 {
+	finite := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			finite = append(finite, v)
+		}
+	}
+	return finite
+}
@@ -355,26 +355,7 @@ func (p *BaseSeasonalProvider) getAnomalies(ctx context.Context, orgID valuer.UU
 	}
 
 	for _, result := range currentPeriodResults {
-		funcs := req.Params.FuncsForQuery(result.QueryName)
-
-		var zScoreThreshold float64
-		for _, f := range funcs {
-			if f.Name == qbtypes.FunctionNameAnomaly {
-				for _, arg := range f.Args {
-					if arg.Name != "z_score_threshold" {
-						continue
-					}
-					value, ok := arg.Value.(float64)
-					if ok {
-						zScoreThreshold = value
-					} else {
-						p.logger.InfoContext(ctx, "z_score_threshold not provided, defaulting")
-						zScoreThreshold = 3
-					}
-					break
-				}
-			}
-		}
+		zScoreThreshold := p.getZScoreThreshold(ctx, req.Params.FuncsForQuery(result.QueryName))
 
 		pastPeriodResult, ok := pastPeriodResults[result.QueryName]
 		if !ok {
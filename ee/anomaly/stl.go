@@ -0,0 +1,200 @@
+package anomaly
+
+import (
+	"context"
+	"math"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// STLProvider predicts from a seasonal-trend decomposition of the history in
+// the spirit of STL: the trend is a moving average over a season, the
+// seasonal component the median of the detrended points at each time of the
+// season, and what remains the residual. Points with large residuals are left
+// out and the decomposition redone, so past anomalies shape neither
+// component. The prediction carries the trend of the last season forward and
+// adds the seasonal component back.
+type STLProvider struct {
+	BaseSeasonalProvider
+}
+
+var _ BaseProvider = (*STLProvider)(nil)
+
+func (sp *STLProvider) GetBaseSeasonalProvider() *BaseSeasonalProvider {
+	return &sp.BaseSeasonalProvider
+}
+
+func NewSTLProvider(opts ...GenericProviderOption[*STLProvider]) *STLProvider {
+	sp := &STLProvider{
+		BaseSeasonalProvider: BaseSeasonalProvider{},
+	}
+
+	for _, opt := range opts {
+		opt(sp)
+	}
+
+	return sp
+}
+
+func (p *STLProvider) GetAnomalies(ctx context.Context, orgID valuer.UUID, req *AnomaliesRequest) (*AnomaliesResponse, error) {
+	return p.getForecastAnomalies(ctx, orgID, req, stl{})
+}
+
+var (
+	// stlOutlier is how many residual scales away from the decomposition a
+	// point is left out of the second decomposition.
+	stlOutlier = 4.0
+)
+
+type stl struct{}
+
+func (stl) forecast(history []float64, period int, horizons []int) ([]float64, float64) {
+	// A decomposition needs at least two seasons.
+	if period < 2 || len(history) < 2*period {
+		return robust{}.forecast(history, period, horizons)
+	}
+
+	predict, residuals := fitSTL(history, period)
+
+	predictions := make([]float64, len(horizons))
+	for idx, h := range horizons {
+		predictions[idx] = predict(h)
+	}
+
+	// The residuals of the decomposition understate how far the points land
+	// from predictions made ahead of them, the decomposition has seen them.
+	// When there is history to spare, the last season of it is predicted from
+	// the seasons before and those residuals are used instead.
+	n := len(history)
+	if n >= 3*period {
+		predictHeldOut, _ := fitSTL(history[:n-period], period)
+		residuals = make([]float64, period)
+		for h := 1; h <= period; h++ {
+			residuals[h-1] = history[n-period-1+h] - predictHeldOut(h)
+		}
+	}
+
+	return predictions, robustScale(residuals)
+}
+
+// fitSTL decomposes the history and returns the prediction h steps past its
+// end along with the residuals of the decomposition.
+func fitSTL(history []float64, period int) (func(h int) float64, []float64) {
+	trend, seasonal, residuals := decompose(history, period)
+
+	scale := robustScale(residuals)
+	cleaned := make([]float64, len(history))
+	for i, r := range residuals {
+		cleaned[i] = history[i]
+		if math.Abs(r) > stlOutlier*math.Max(scale, minScale) {
+			cleaned[i] = math.NaN()
+		}
+	}
+	trend, seasonal, _ = decompose(cleaned, period)
+
+	// The residuals are measured against the history as is, the points left
+	// out are anomalies the scale has to account for.
+	for i := range residuals {
+		residuals[i] = history[i] - trend[i] - seasonal[i%period]
+	}
+
+	// The level of the last season sits at its middle, the drift is the
+	// change of level from the season before it.
+	deseasonalized := make([]float64, len(cleaned))
+	for i, v := range cleaned {
+		deseasonalized[i] = v - seasonal[i%period]
+	}
+	n := len(history)
+	level := seasonMean(deseasonalized, n-period, period)
+	if math.IsNaN(level) {
+		level = trend[n-1]
+	}
+	drift := (level - seasonMean(deseasonalized, n-2*period, period)) / float64(period)
+	if math.IsNaN(drift) {
+		drift = 0
+	}
+
+	return func(h int) float64 {
+		return level + drift*(float64(period-1)/2+float64(h)) + seasonal[(n-1+h)%period]
+	}, residuals
+}
+
+// decompose splits the history into a trend, a seasonal component per time of
+// the season and residuals. Missing points have NaN residuals.
+func decompose(history []float64, period int) ([]float64, []float64, []float64) {
+	trend := movingAverage(history, period)
+
+	seasonal := make([]float64, period)
+	for phase := range seasonal {
+		detrended := make([]float64, 0, len(history)/period+1)
+		for i := phase; i < len(history); i += period {
+			detrended = append(detrended, history[i]-trend[i])
+		}
+		seasonal[phase] = median(detrended)
+		if math.IsNaN(seasonal[phase]) {
+			seasonal[phase] = 0
+		}
+	}
+
+	var mean float64
+	for _, s := range seasonal {
+		mean += s
+	}
+	mean /= float64(period)
+	for phase := range seasonal {
+		seasonal[phase] -= mean
+	}
+
+	deseasonalized := make([]float64, len(history))
+	for i, v := range history {
+		deseasonalized[i] = v - seasonal[i%period]
+	}
+	trend = movingAverage(deseasonalized, period)
+
+	residuals := make([]float64, len(history))
+	for i, v := range history {
+		residuals[i] = v - trend[i] - seasonal[i%period]
+	}
+
+	return trend, seasonal, residuals
+}
+
+// movingAverage returns the centered moving average over window points of the
+// values, skipping NaN. At the edges the window is shifted inwards, and where
+// it holds no values the average of the closest window that does is used.
+func movingAverage(values []float64, window int) []float64 {
+	sums := make([]float64, len(values)+1)
+	counts := make([]int, len(values)+1)
+	for i, v := range values {
+		sums[i+1], counts[i+1] = sums[i], counts[i]
+		if !math.IsNaN(v) {
+			sums[i+1] += v
+			counts[i+1]++
+		}
+	}
+
+	averages := make([]float64, len(values))
+	for i := range values {
+		lo := max(0, i-window/2)
+		hi := min(len(values), lo+window)
+		lo = max(0, hi-window)
+		if count := counts[hi] - counts[lo]; count > 0 {
+			averages[i] = (sums[hi] - sums[lo]) / float64(count)
+		} else {
+			averages[i] = math.NaN()
+		}
+	}
+
+	for i := 1; i < len(averages); i++ {
+		if math.IsNaN(averages[i]) {
+			averages[i] = averages[i-1]
+		}
+	}
+	for i := len(averages) - 2; i >= 0; i-- {
+		if math.IsNaN(averages[i]) {
+			averages[i] = averages[i+1]
+		}
+	}
+
+	return averages
+}
@@ -0,0 +1,131 @@
+package fileauditor
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"io"
+	"os"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/auditor"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const lastLineReadSize int64 = 64 * 1024
+
+// chain links each batch written to the file to the line before it.
+type chain struct {
+	key                ed25519.PrivateKey
+	checkpointInterval time.Duration
+
+	// next is the link of the next batch, nil until the first batch of a new file.
+	next           *audittypes.ChainLink
+	lastCheckpoint time.Time
+}
+
+// newChain resumes the chain of the file from its last line, so that restarts
+// do not break it.
+func newChain(config auditor.ChainConfig, path string) (*chain, error) {
+	pemKey, err := os.ReadFile(config.SigningKeyPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, auditor.ErrCodeAuditExportFailed, "failed to read audit signing key %q", config.SigningKeyPath)
+	}
+
+	key, err := audittypes.NewChainSigningKeyFromPEM(pemKey)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := &chain{key: key, checkpointInterval: config.CheckpointInterval}
+
+	lastLine, err := readLastLine(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lastLine) == 0 {
+		return chain, nil
+	}
+
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(lastLine)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, auditor.ErrCodeAuditExportFailed, "failed to read the last record of audit file %q", path)
+	}
+
+	last, ok := audittypes.NewChainLinkFromPLogs(logs)
+	if !ok {
+		return nil, errors.Newf(errors.TypeInvalidInput, auditor.ErrCodeAuditExportFailed, "audit file %q has records which are not chained, chain to a new file instead", path)
+	}
+
+	next := audittypes.NextChainLink(last, lastLine)
+	chain.next = &next
+	return chain, nil
+}
+
+// link sets the link of the batch about to be written, signing it if the last
+// checkpoint is older than the checkpoint interval.
+func (chain *chain) link(logs plog.Logs, now time.Time) error {
+	link := audittypes.ChainLink{}
+	if chain.next != nil {
+		link = *chain.next
+	}
+
+	if now.Sub(chain.lastCheckpoint) >= chain.checkpointInterval {
+		_, err := link.Sign(chain.key, logs)
+		return err
+	}
+
+	link.Put(logs)
+	return nil
+}
+
+// advance moves the chain past a batch once its line has been written.
+func (chain *chain) advance(logs plog.Logs, line []byte, now time.Time) {
+	link, _ := audittypes.NewChainLinkFromPLogs(logs)
+	if link.IsCheckpoint() {
+		chain.lastCheckpoint = now
+	}
+
+	next := audittypes.NextChainLink(link, line)
+	chain.next = &next
+}
+
+// readLastLine returns the last complete line of the file, nil if the file
+// does not exist or is empty.
+func readLastLine(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read backwards from the end until the newline before the last line is found.
+	var tail []byte
+	for offset := size; offset > 0; {
+		readSize := min(lastLineReadSize, offset)
+		offset -= readSize
+
+		chunk := make([]byte, readSize)
+		if _, err := file.ReadAt(chunk, offset); err != nil {
+			return nil, err
+		}
+		tail = append(chunk, tail...)
+
+		trimmed := bytes.TrimSuffix(tail, []byte{'\n'})
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+	}
+
+	return bytes.TrimSuffix(tail, []byte{'\n'}), nil
+}
@@ -0,0 +1,200 @@
+package fileauditor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/auditor"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/SigNoz/signoz/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(t *testing.T) (auditor.Config, ed25519.PublicKey) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "signing.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	return auditor.Config{
+		BufferSize:    10,
+		BatchSize:     10,
+		FlushInterval: time.Second,
+		File: auditor.FileConfig{
+			Path: filepath.Join(dir, "audit.log"),
+			Chain: auditor.ChainConfig{
+				Enabled:            true,
+				SigningKeyPath:     keyPath,
+				CheckpointInterval: time.Hour,
+			},
+		},
+	}, publicKey
+}
+
+func newTestProvider(t *testing.T, config auditor.Config) *provider {
+	t.Helper()
+
+	p, err := newProvider(context.Background(), factorytest.NewSettings(), config, nil, version.Info)
+	require.NoError(t, err)
+	return p.(*provider)
+}
+
+func newTestEvents() []audittypes.AuditEvent {
+	kind := coretypes.MustNewKind("dashboard")
+	return []audittypes.AuditEvent{{
+		Timestamp: time.Now(),
+		Body:      "dashboard updated",
+		EventName: audittypes.NewEventName(kind, coretypes.VerbUpdate),
+		AuditAttributes: audittypes.AuditAttributes{
+			Action:  coretypes.VerbUpdate,
+			Outcome: audittypes.OutcomeSuccess,
+		},
+		ResourceAttributes: audittypes.NewResourceAttributes("dashboard-1", kind),
+	}}
+}
+
+func verifyTestFile(t *testing.T, path string, key ed25519.PublicKey) (*audittypes.ChainVerification, error) {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	return audittypes.VerifyChain(file, key)
+}
+
+func TestExportChainsAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	config, publicKey := newTestConfig(t)
+
+	first := newTestProvider(t, config)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, first.export(ctx, newTestEvents()))
+	}
+	require.NoError(t, first.file.Close())
+
+	// Only the first batch is signed within the checkpoint interval.
+	verification, err := verifyTestFile(t, config.File.Path, publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, &audittypes.ChainVerification{Batches: 3, Checkpoints: 1, Unsigned: 2}, verification)
+
+	// A restarted auditor continues the chain and signs its first batch.
+	second := newTestProvider(t, config)
+	require.NoError(t, second.export(ctx, newTestEvents()))
+	require.NoError(t, second.file.Close())
+
+	verification, err = verifyTestFile(t, config.File.Path, publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, &audittypes.ChainVerification{Batches: 4, Checkpoints: 2, Unsigned: 0}, verification)
+}
+
+// tornFile writes half of the line once and fails, as a full disk would.
+type tornFile struct {
+	*os.File
+	torn          bool
+	truncateError error
+}
+
+func (file *tornFile) Write(p []byte) (int, error) {
+	if file.torn {
+		return file.File.Write(p)
+	}
+
+	file.torn = true
+	n, _ := file.File.Write(p[:len(p)/2])
+	return n, errors.New(errors.TypeInternal, errors.CodeInternal, "no space left on device")
+}
+
+func (file *tornFile) Truncate(size int64) error {
+	if file.truncateError != nil {
+		return file.truncateError
+	}
+
+	return file.File.Truncate(size)
+}
+
+func TestExportTruncatesPartialRecord(t *testing.T) {
+	ctx := context.Background()
+	config, publicKey := newTestConfig(t)
+
+	p := newTestProvider(t, config)
+	require.NoError(t, p.export(ctx, newTestEvents()))
+
+	p.file = &tornFile{File: p.file.(*os.File)}
+	assert.Error(t, p.export(ctx, newTestEvents()))
+	require.NoError(t, p.export(ctx, newTestEvents()))
+	require.NoError(t, p.file.Close())
+
+	verification, err := verifyTestFile(t, config.File.Path, publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, 2, verification.Batches)
+}
+
+func TestExportFailsClosedOnPartialRecord(t *testing.T) {
+	ctx := context.Background()
+	config, _ := newTestConfig(t)
+
+	p := newTestProvider(t, config)
+	p.file = &tornFile{File: p.file.(*os.File), truncateError: errors.New(errors.TypeInternal, errors.CodeInternal, "read-only file system")}
+	assert.Error(t, p.export(ctx, newTestEvents()))
+
+	// Nothing is appended to the partial record.
+	info, err := os.Stat(config.File.Path)
+	require.NoError(t, err)
+	err = p.export(ctx, newTestEvents())
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, auditor.ErrCodeAuditExportFailed))
+	after, err := os.Stat(config.File.Path)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), after.Size())
+	require.NoError(t, p.file.Close())
+}
+
+func TestNewProviderRejectsUnchainedFile(t *testing.T) {
+	ctx := context.Background()
+	config, _ := newTestConfig(t)
+
+	config.File.Chain.Enabled = false
+	unchained := newTestProvider(t, config)
+	require.NoError(t, unchained.export(ctx, newTestEvents()))
+	require.NoError(t, unchained.file.Close())
+
+	config.File.Chain.Enabled = true
+	_, err := newProvider(ctx, factorytest.NewSettings(), config, nil, version.Info)
+	assert.ErrorContains(t, err, "not chained")
+}
+
+func TestReadLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	line, err := readLastLine(path)
+	require.NoError(t, err)
+	assert.Nil(t, line)
+
+	long := make([]byte, lastLineReadSize*2)
+	for i := range long {
+		long[i] = 'a'
+	}
+	require.NoError(t, os.WriteFile(path, append(append([]byte("first\n"), long...), '\n'), 0o600))
+
+	line, err = readLastLine(path)
+	require.NoError(t, err)
+	assert.Equal(t, long, line)
+}
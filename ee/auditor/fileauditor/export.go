@@ -2,8 +2,11 @@ package fileauditor
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"time"
 
+	"github.com/SigNoz/signoz/pkg/auditor"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/audittypes"
 )
@@ -11,6 +14,22 @@ import (
 func (provider *provider) export(ctx context.Context, events []audittypes.AuditEvent) error {
 	logs := audittypes.NewPLogsFromAuditEvents(events, "signoz", provider.build.Version(), "signoz.audit")
 
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if provider.brokenErr != nil {
+		provider.settings.Logger().ErrorContext(ctx, "audit export failed, audit file has a partial record", errors.Attr(provider.brokenErr), slog.Int("dropped_log_records", len(events)))
+		return provider.brokenErr
+	}
+
+	// The batch is linked under the lock so that lines are written in chain order.
+	now := time.Now()
+	if provider.chain != nil {
+		if err := provider.chain.link(logs, now); err != nil {
+			return err
+		}
+	}
+
 	payload, err := provider.marshaler.MarshalLogs(logs)
 	if err != nil {
 		return err
@@ -21,13 +40,25 @@ func (provider *provider) export(ctx context.Context, events []audittypes.AuditE
 	// line or nothing, never a torn JSON object.
 	payload = append(payload, '\n')
 
-	provider.mu.Lock()
-	defer provider.mu.Unlock()
+	offset, err := provider.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
 
 	if _, err := provider.file.Write(payload); err != nil {
 		provider.settings.Logger().ErrorContext(ctx, "audit export failed", errors.Attr(err), slog.Int("dropped_log_records", len(events)))
+
+		// A partial line would break the chain for every later batch, so it is
+		// truncated away. If that fails too, no more lines are written after it.
+		if truncateErr := provider.file.Truncate(offset); truncateErr != nil {
+			provider.brokenErr = errors.Wrapf(truncateErr, errors.TypeInternal, auditor.ErrCodeAuditExportFailed, "failed to truncate partial record at offset %d of audit file %q", offset, provider.config.File.Path)
+		}
 		return err
 	}
 
+	if provider.chain != nil {
+		provider.chain.advance(logs, payload, now)
+	}
+
 	return provider.file.Sync()
 }
@@ -2,6 +2,7 @@ package fileauditor
 
 import (
 	"context"
+	"io"
 	"os"
 	"sync"
 
@@ -17,6 +18,14 @@ import (
 
 var _ auditor.Auditor = (*provider)(nil)
 
+// auditFile is the part of *os.File the provider writes the audit file with.
+type auditFile interface {
+	io.WriteSeeker
+	Truncate(size int64) error
+	Sync() error
+	Close() error
+}
+
 type provider struct {
 	settings  factory.ScopedProviderSettings
 	config    auditor.Config
@@ -24,8 +33,12 @@ type provider struct {
 	build     version.Build
 	server    *auditorserver.Server
 	marshaler plog.JSONMarshaler
-	file      *os.File
+	file      auditFile
+	chain     *chain
 	mu        sync.Mutex
+	// brokenErr is set when a partial record could not be removed from the
+	// file, nothing is written after it.
+	brokenErr error
 }
 
 func NewFactory(licensing licensing.Licensing, build version.Build) factory.ProviderFactory[auditor.Auditor, auditor.Config] {
@@ -37,6 +50,15 @@ func NewFactory(licensing licensing.Licensing, build version.Build) factory.Prov
 func newProvider(_ context.Context, providerSettings factory.ProviderSettings, config auditor.Config, licensing licensing.Licensing, build version.Build) (auditor.Auditor, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/ee/auditor/fileauditor")
 
+	var fileChain *chain
+	if config.File.Chain.Enabled {
+		var err error
+		fileChain, err = newChain(config.File.Chain, config.File.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	file, err := os.OpenFile(config.File.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
 	if err != nil {
 		return nil, errors.Wrapf(err, errors.TypeInvalidInput, auditor.ErrCodeAuditExportFailed, "failed to open audit file %q", config.File.Path)
@@ -49,6 +71,7 @@ func newProvider(_ context.Context, providerSettings factory.ProviderSettings, c
 		build:     build,
 		marshaler: plog.JSONMarshaler{},
 		file:      file,
+		chain:     fileChain,
 	}
 
 	server, err := auditorserver.New(settings,
@@ -229,6 +229,22 @@ func (module *module) PatchV2(ctx context.Context, orgID valuer.UUID, id valuer.
 	return module.pkgDashboardModule.PatchV2(ctx, orgID, id, updatedBy, patch)
 }
 
+func (module *module) ListRevisionsV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID) ([]*dashboardtypes.DashboardRevision, error) {
+	return module.pkgDashboardModule.ListRevisionsV2(ctx, orgID, id)
+}
+
+func (module *module) GetRevisionV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int) (*dashboardtypes.DashboardRevision, error) {
+	return module.pkgDashboardModule.GetRevisionV2(ctx, orgID, id, version)
+}
+
+func (module *module) DiffRevisionsV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, from int, to int) ([]dashboardtypes.JSONPatchOperation, error) {
+	return module.pkgDashboardModule.DiffRevisionsV2(ctx, orgID, id, from, to)
+}
+
+func (module *module) RestoreRevisionV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int, updatedBy string) (*dashboardtypes.DashboardV2, error) {
+	return module.pkgDashboardModule.RestoreRevisionV2(ctx, orgID, id, version, updatedBy)
+}
+
 func (module *module) LockUnlockV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, isAdmin bool, lock bool) error {
 	return module.pkgDashboardModule.LockUnlockV2(ctx, orgID, id, updatedBy, isAdmin, lock)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
@@ -105,6 +106,10 @@ func (h *handler) QueryRawStream(rw http.ResponseWriter, req *http.Request) {
 	h.community.QueryRawStream(rw, req)
 }
 
+func (h *handler) PurgeCache(rw http.ResponseWriter, req *http.Request) {
+	h.community.PurgeCache(rw, req)
+}
+
 func (h *handler) ReplaceVariables(rw http.ResponseWriter, req *http.Request) {
 	h.community.ReplaceVariables(rw, req)
 }
@@ -129,7 +134,42 @@ func extractSeasonality(anomalyQuery *qbtypes.QueryBuilderQuery[qbtypes.MetricAg
 	return anomalyV2.SeasonalityDaily // default
 }
 
-func (h *handler) createAnomalyProvider(seasonality anomalyV2.Seasonality) anomalyV2.Provider {
+func extractAlgorithm(anomalyQuery *qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]) ruletypes.AnomalyAlgorithm {
+	for _, fn := range anomalyQuery.Functions {
+		if fn.Name == qbtypes.FunctionNameAnomaly {
+			for _, arg := range fn.Args {
+				if arg.Name == "algorithm" {
+					if algorithmStr, ok := arg.Value.(string); ok {
+						if algorithm := (ruletypes.AnomalyAlgorithm{String: valuer.NewString(algorithmStr)}); algorithm.Validate() == nil {
+							return algorithm
+						}
+					}
+				}
+			}
+		}
+	}
+	return ruletypes.AnomalyAlgorithmStandard // default
+}
+
+func (h *handler) createAnomalyProvider(algorithm ruletypes.AnomalyAlgorithm, seasonality anomalyV2.Seasonality) anomalyV2.Provider {
+	switch algorithm {
+	case ruletypes.AnomalyAlgorithmRobust:
+		return anomalyV2.NewRobustProvider(
+			anomalyV2.WithQuerier[*anomalyV2.RobustProvider](h.querier),
+			anomalyV2.WithLogger[*anomalyV2.RobustProvider](h.set.Logger),
+		)
+	case ruletypes.AnomalyAlgorithmHoltWinters:
+		return anomalyV2.NewHoltWintersProvider(
+			anomalyV2.WithQuerier[*anomalyV2.HoltWintersProvider](h.querier),
+			anomalyV2.WithLogger[*anomalyV2.HoltWintersProvider](h.set.Logger),
+		)
+	case ruletypes.AnomalyAlgorithmSTL:
+		return anomalyV2.NewSTLProvider(
+			anomalyV2.WithQuerier[*anomalyV2.STLProvider](h.querier),
+			anomalyV2.WithLogger[*anomalyV2.STLProvider](h.set.Logger),
+		)
+	}
+
 	switch seasonality {
 	case anomalyV2.SeasonalityWeekly:
 		return anomalyV2.NewWeeklyProvider(
@@ -151,7 +191,7 @@ func (h *handler) createAnomalyProvider(seasonality anomalyV2.Seasonality) anoma
 
 func (h *handler) handleAnomalyQuery(ctx context.Context, orgID valuer.UUID, anomalyQuery *qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation], queryRangeRequest *qbtypes.QueryRangeRequest) (*anomalyV2.AnomaliesResponse, error) {
 	seasonality := extractSeasonality(anomalyQuery)
-	provider := h.createAnomalyProvider(seasonality)
+	provider := h.createAnomalyProvider(extractAlgorithm(anomalyQuery), seasonality)
 
-	return provider.GetAnomalies(ctx, orgID, &anomalyV2.AnomaliesRequest{Params: queryRangeRequest})
+	return provider.GetAnomalies(ctx, orgID, &anomalyV2.AnomaliesRequest{Params: queryRangeRequest, Seasonality: seasonality})
 }
@@ -96,7 +96,9 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 	logParsingPipelineController, err := logparsingpipeline.NewLogParsingPipelinesController(
 		signoz.SQLStore,
 		integrationsController.GetPipelinesForInstalledIntegrations,
+		signoz.Modules.LookupTable.ListSnapshotEntries,
 		reader,
+		signoz.Querier,
 		signoz.Flagger,
 	)
 	if err != nil {
@@ -106,7 +108,7 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 	// initiate agent config handler
 	agentConfMgr, err := agentConf.Initiate(&agentConf.ManagerOptions{
 		Store:         signoz.SQLStore,
-		AgentFeatures: []agentConf.AgentFeature{logParsingPipelineController},
+		AgentFeatures: append([]agentConf.AgentFeature{logParsingPipelineController, signoz.Modules.LookupTable.AgentFeature()}, signoz.Modules.IngestionRule.AgentFeatures()...),
 	})
 	if err != nil {
 		return nil, err
@@ -80,6 +80,28 @@ func NewAnomalyRule(
 
 	r.logger.Info("using seasonality", slog.String("rule.seasonality", r.seasonality.StringValue()))
 
+	switch p.RuleCondition.Algorithm {
+	case ruletypes.AnomalyAlgorithmRobust:
+		r.provider = anomaly.NewRobustProvider(
+			anomaly.WithQuerier[*anomaly.RobustProvider](querier),
+			anomaly.WithLogger[*anomaly.RobustProvider](r.logger),
+		)
+	case ruletypes.AnomalyAlgorithmHoltWinters:
+		r.provider = anomaly.NewHoltWintersProvider(
+			anomaly.WithQuerier[*anomaly.HoltWintersProvider](querier),
+			anomaly.WithLogger[*anomaly.HoltWintersProvider](r.logger),
+		)
+	case ruletypes.AnomalyAlgorithmSTL:
+		r.provider = anomaly.NewSTLProvider(
+			anomaly.WithQuerier[*anomaly.STLProvider](querier),
+			anomaly.WithLogger[*anomaly.STLProvider](r.logger),
+		)
+	}
+	if r.provider != nil {
+		r.logger.Info("using algorithm", slog.String("rule.algorithm", p.RuleCondition.Algorithm.StringValue()))
+		return &r, nil
+	}
+
 	if r.seasonality == anomaly.SeasonalityHourly {
 		r.provider = anomaly.NewHourlyProvider(
 			anomaly.WithQuerier[*anomaly.HourlyProvider](querier),
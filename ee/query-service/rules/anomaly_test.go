@@ -268,6 +268,66 @@ func TestAnomalyRule_NoData_AbsentFor(t *testing.T) {
 	}
 }
 
+func TestAnomalyRule_Algorithm(t *testing.T) {
+	target := 3.0
+
+	cases := []struct {
+		algorithm ruletypes.AnomalyAlgorithm
+		expected  anomaly.Provider
+	}{
+		{algorithm: ruletypes.AnomalyAlgorithm{}, expected: &anomaly.DailyProvider{}},
+		{algorithm: ruletypes.AnomalyAlgorithmStandard, expected: &anomaly.DailyProvider{}},
+		{algorithm: ruletypes.AnomalyAlgorithmRobust, expected: &anomaly.RobustProvider{}},
+		{algorithm: ruletypes.AnomalyAlgorithmHoltWinters, expected: &anomaly.HoltWintersProvider{}},
+		{algorithm: ruletypes.AnomalyAlgorithmSTL, expected: &anomaly.STLProvider{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.algorithm.StringValue(), func(t *testing.T) {
+			postableRule := ruletypes.PostableRule{
+				AlertName: "Test anomaly algorithm",
+				AlertType: ruletypes.AlertTypeMetric,
+				RuleType:  ruletypes.RuleTypeAnomaly,
+				Evaluation: &ruletypes.EvaluationEnvelope{Kind: ruletypes.RollingEvaluation, Spec: ruletypes.RollingWindow{
+					EvalWindow: valuer.MustParseTextDuration("5m"),
+					Frequency:  valuer.MustParseTextDuration("1m"),
+				}},
+				RuleCondition: &ruletypes.RuleCondition{
+					CompareOperator: ruletypes.ValueIsAbove,
+					MatchType:       ruletypes.AtleastOnce,
+					Target:          &target,
+					CompositeQuery: &ruletypes.AlertCompositeQuery{
+						QueryType: ruletypes.QueryTypeBuilder,
+						Queries: []qbtypes.QueryEnvelope{{
+							Type: qbtypes.QueryTypeBuilder,
+							Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+								Name:   "A",
+								Signal: telemetrytypes.SignalMetrics,
+							},
+						}},
+					},
+					SelectedQuery: "A",
+					Algorithm:     c.algorithm,
+					Seasonality:   ruletypes.SeasonalityDaily,
+					Thresholds: &ruletypes.RuleThresholdData{
+						Kind: ruletypes.BasicThresholdKind,
+						Spec: ruletypes.BasicRuleThresholds{{
+							Name:            "Test anomaly algorithm",
+							TargetValue:     &target,
+							MatchType:       ruletypes.AtleastOnce,
+							CompareOperator: ruletypes.ValueIsAbove,
+						}},
+					},
+				},
+			}
+
+			rule, err := NewAnomalyRule("test-anomaly-rule", valuer.GenerateUUID(), &postableRule, nil, instrumentationtest.New().Logger(), mustParseURL(t, "http://localhost:8000"))
+			require.NoError(t, err)
+			assert.IsType(t, c.expected, rule.provider)
+		})
+	}
+}
+
 func mustParseURL(t *testing.T, raw string) *url.URL {
 	t.Helper()
 	u, err := url.Parse(raw)
@@ -100,8 +100,56 @@ func PrepareTaskFunc(opts baserules.PrepareTaskOptions) (baserules.Task, error)
 		// create anomaly rule task for evaluation
 		task = newTask(baserules.TaskTypeCh, opts.TaskName, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc)
 
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeComposite {
+		// create composite rule
+		cr, err := baserules.NewCompositeRule(
+			ruleID,
+			opts.OrgID,
+			opts.Rule,
+			opts.LookupRule,
+			opts.Logger,
+			opts.ManagerOpts.Alertmanager.Config().ExternalURL,
+			baserules.WithSQLStore(opts.SQLStore),
+			baserules.WithRuleStateHistoryModule(opts.ManagerOpts.RuleStateHistoryModule),
+		)
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, cr)
+
+		// composite rules run no queries, the ch task just drives evaluation
+		task = newTask(baserules.TaskTypeCh, opts.TaskName, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc)
+
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeRecording {
+		// create recording rule
+		rr, err := baserules.NewRecordingRule(
+			ruleID,
+			opts.OrgID,
+			opts.Rule,
+			opts.Querier,
+			opts.ManagerOpts.Prometheus,
+			opts.ManagerOpts.TelemetryStore,
+			opts.Logger,
+			opts.ManagerOpts.Alertmanager.Config().ExternalURL,
+			baserules.WithEvalDelay(opts.ManagerOpts.EvalDelay),
+			baserules.WithSQLStore(opts.SQLStore),
+			baserules.WithRuleStateHistoryModule(opts.ManagerOpts.RuleStateHistoryModule),
+		)
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, rr)
+
+		var taskType baserules.TaskType = baserules.TaskTypeCh
+		if opts.Rule.RuleCondition.CompositeQuery.QueryType == ruletypes.QueryTypePromQL {
+			taskType = baserules.TaskTypeProm
+		}
+		task = newTask(taskType, opts.TaskName, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc)
+
 	} else {
-		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported rule type %s. Supported types: %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold)
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported rule type %s. Supported types: %s, %s, %s, %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold, ruletypes.RuleTypeAnomaly, ruletypes.RuleTypeComposite, ruletypes.RuleTypeRecording)
 	}
 
 	return task, nil
@@ -197,6 +245,25 @@ func TestNotification(opts baserules.PrepareTestRuleOptions) (int, error) {
 			slog.Error("failed to prepare a new anomaly rule for test", "name", alertname, errors.Attr(err))
 			return 0, err
 		}
+	} else if parsedRule.RuleType == ruletypes.RuleTypeComposite {
+		// create composite rule over the current state of its children
+		rule, err = baserules.NewCompositeRule(
+			alertname,
+			opts.OrgID,
+			parsedRule,
+			opts.LookupRule,
+			opts.Logger,
+			opts.ManagerOpts.Alertmanager.Config().ExternalURL,
+			baserules.WithSendAlways(),
+			baserules.WithSendUnmatched(),
+			baserules.WithSQLStore(opts.SQLStore),
+		)
+		if err != nil {
+			slog.Error("failed to prepare a new composite rule for test", "name", alertname, errors.Attr(err))
+			return 0, err
+		}
+	} else if parsedRule.RuleType == ruletypes.RuleTypeRecording {
+		return 0, errors.NewInvalidInputf(errors.CodeInvalidInput, "recording rules send no notifications")
 	} else {
 		return 0, errors.NewInvalidInputf(errors.CodeInvalidInput, "failed to derive ruletype with given information")
 	}
@@ -0,0 +1,334 @@
+/**
+ * ! Do not edit manually
+ * * The file has been auto-generated using Orval for SigNoz
+ * * regenerate with 'pnpm generate:api'
+ * SigNoz
+ */
+import { useQuery } from 'react-query';
+import type {
+	InvalidateOptions,
+	QueryClient,
+	QueryFunction,
+	QueryKey,
+	UseQueryOptions,
+	UseQueryResult,
+} from 'react-query';
+
+import type {
+	ExportAuditEventsParams,
+	GetAuditResourceTimeline200,
+	GetAuditResourceTimelineParams,
+	GetAuditResourceTimelinePathParameters,
+	ListAuditEvents200,
+	ListAuditEventsParams,
+	RenderErrorResponseDTO,
+} from '../sigNoz.schemas';
+
+import { GeneratedAPIInstance } from '../../../generatedAPIInstance';
+import type { ErrorType } from '../../../generatedAPIInstance';
+
+/**
+ * This endpoint searches the audit events of the organization by principal, resource, action category, outcome and time, most recent first
+ * @summary List audit events
+ */
+export const listAuditEvents = (
+	params: ListAuditEventsParams,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<ListAuditEvents200>({
+		url: `/api/v1/audit/events`,
+		method: 'GET',
+		params,
+		signal,
+	});
+};
+
+export const getListAuditEventsQueryKey = (params?: ListAuditEventsParams) => {
+	return [`/api/v1/audit/events`, ...(params ? [params] : [])] as const;
+};
+
+export const getListAuditEventsQueryOptions = <
+	TData = Awaited<ReturnType<typeof listAuditEvents>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	params: ListAuditEventsParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listAuditEvents>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey = queryOptions?.queryKey ?? getListAuditEventsQueryKey(params);
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof listAuditEvents>>> = ({
+		signal,
+	}) => listAuditEvents(params, signal);
+
+	return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+		Awaited<ReturnType<typeof listAuditEvents>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ListAuditEventsQueryResult = NonNullable<
+	Awaited<ReturnType<typeof listAuditEvents>>
+>;
+export type ListAuditEventsQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary List audit events
+ */
+
+export function useListAuditEvents<
+	TData = Awaited<ReturnType<typeof listAuditEvents>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	params: ListAuditEventsParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listAuditEvents>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getListAuditEventsQueryOptions(params, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary List audit events
+ */
+export const invalidateListAuditEvents = async (
+	queryClient: QueryClient,
+	params: ListAuditEventsParams,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getListAuditEventsQueryKey(params) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * This endpoint downloads the audit events of the organization matching the filter as CSV or JSONL, most recent first. The X-Response-Complete header is false if the export was capped
+ * @summary Export audit events
+ */
+export const exportAuditEvents = (
+	params: ExportAuditEventsParams,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<string>({
+		url: `/api/v1/audit/events/export`,
+		method: 'GET',
+		params,
+		signal,
+	});
+};
+
+export const getExportAuditEventsQueryKey = (params?: ExportAuditEventsParams) => {
+	return [`/api/v1/audit/events/export`, ...(params ? [params] : [])] as const;
+};
+
+export const getExportAuditEventsQueryOptions = <
+	TData = Awaited<ReturnType<typeof exportAuditEvents>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	params: ExportAuditEventsParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof exportAuditEvents>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey = queryOptions?.queryKey ?? getExportAuditEventsQueryKey(params);
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof exportAuditEvents>>> = ({
+		signal,
+	}) => exportAuditEvents(params, signal);
+
+	return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+		Awaited<ReturnType<typeof exportAuditEvents>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ExportAuditEventsQueryResult = NonNullable<
+	Awaited<ReturnType<typeof exportAuditEvents>>
+>;
+export type ExportAuditEventsQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Export audit events
+ */
+
+export function useExportAuditEvents<
+	TData = Awaited<ReturnType<typeof exportAuditEvents>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	params: ExportAuditEventsParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof exportAuditEvents>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getExportAuditEventsQueryOptions(params, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Export audit events
+ */
+export const invalidateExportAuditEvents = async (
+	queryClient: QueryClient,
+	params: ExportAuditEventsParams,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getExportAuditEventsQueryKey(params) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * This endpoint returns the audit events of a single resource, oldest first, to show how it changed over time
+ * @summary Get audit timeline of a resource
+ */
+export const getAuditResourceTimeline = (
+	{ kind, id }: GetAuditResourceTimelinePathParameters,
+	params: GetAuditResourceTimelineParams,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetAuditResourceTimeline200>({
+		url: `/api/v1/audit/resources/${kind}/${id}/timeline`,
+		method: 'GET',
+		params,
+		signal,
+	});
+};
+
+export const getGetAuditResourceTimelineQueryKey = (
+	{ kind, id }: GetAuditResourceTimelinePathParameters,
+	params?: GetAuditResourceTimelineParams,
+) => {
+	return [
+		`/api/v1/audit/resources/${kind}/${id}/timeline`,
+		...(params ? [params] : []),
+	] as const;
+};
+
+export const getGetAuditResourceTimelineQueryOptions = <
+	TData = Awaited<ReturnType<typeof getAuditResourceTimeline>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind, id }: GetAuditResourceTimelinePathParameters,
+	params: GetAuditResourceTimelineParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getAuditResourceTimeline>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetAuditResourceTimelineQueryKey({ kind, id }, params);
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getAuditResourceTimeline>>
+	> = ({ signal }) => getAuditResourceTimeline({ kind, id }, params, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!(kind && id),
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getAuditResourceTimeline>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetAuditResourceTimelineQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getAuditResourceTimeline>>
+>;
+export type GetAuditResourceTimelineQueryError =
+	ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get audit timeline of a resource
+ */
+
+export function useGetAuditResourceTimeline<
+	TData = Awaited<ReturnType<typeof getAuditResourceTimeline>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind, id }: GetAuditResourceTimelinePathParameters,
+	params: GetAuditResourceTimelineParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getAuditResourceTimeline>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetAuditResourceTimelineQueryOptions(
+		{ kind, id },
+		params,
+		options,
+	);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get audit timeline of a resource
+ */
+export const invalidateGetAuditResourceTimeline = async (
+	queryClient: QueryClient,
+	{ kind, id }: GetAuditResourceTimelinePathParameters,
+	params: GetAuditResourceTimelineParams,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetAuditResourceTimelineQueryKey({ kind, id }, params) },
+		options,
+	);
+
+	return queryClient;
+};
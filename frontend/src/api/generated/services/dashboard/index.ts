@@ -21,12 +21,18 @@ import type {
 	CreateDashboardV2201,
 	CreatePublicDashboard201,
 	CreatePublicDashboardPathParameters,
+	DashboardtypesGrafanaDashboardDTO,
 	DashboardtypesPatchableDashboardV2DTO,
 	DashboardtypesPostableDashboardV2DTO,
 	DashboardtypesPostablePublicDashboardDTO,
 	DashboardtypesUpdatableDashboardV2DTO,
 	DashboardtypesUpdatablePublicDashboardDTO,
 	DeletePublicDashboardPathParameters,
+	DiffDashboardRevisionsV2200,
+	DiffDashboardRevisionsV2Params,
+	DiffDashboardRevisionsV2PathParameters,
+	GetDashboardRevisionV2200,
+	GetDashboardRevisionV2PathParameters,
 	GetDashboardV2200,
 	GetDashboardV2PathParameters,
 	GetPublicDashboard200,
@@ -35,10 +41,15 @@ import type {
 	GetPublicDashboardPathParameters,
 	GetPublicDashboardWidgetQueryRange200,
 	GetPublicDashboardWidgetQueryRangePathParameters,
+	ImportGrafanaDashboardV2201,
+	ListDashboardRevisionsV2200,
+	ListDashboardRevisionsV2PathParameters,
 	LockDashboardV2PathParameters,
 	PatchDashboardV2200,
 	PatchDashboardV2PathParameters,
 	RenderErrorResponseDTO,
+	RestoreDashboardRevisionV2200,
+	RestoreDashboardRevisionV2PathParameters,
 	UnlockDashboardV2PathParameters,
 	UpdateDashboardV2200,
 	UpdateDashboardV2PathParameters,
@@ -1181,3 +1192,486 @@ export const useLockDashboardV2 = <
 > => {
 	return useMutation(getLockDashboardV2MutationOptions(options));
 };
+/**
+ * This endpoint lists the revisions of a dashboard, latest first. A revision is recorded every time the dashboard is created, updated, patched, or restored, through the v1 or the v2 endpoints, and only the latest revisions are kept.
+ * @summary List dashboard revisions (v2)
+ */
+export const listDashboardRevisionsV2 = (
+	{ id }: ListDashboardRevisionsV2PathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<ListDashboardRevisionsV2200>({
+		url: `/api/v2/dashboards/${id}/revisions`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getListDashboardRevisionsV2QueryKey = ({
+	id,
+}: ListDashboardRevisionsV2PathParameters) => {
+	return [`/api/v2/dashboards/${id}/revisions`] as const;
+};
+
+export const getListDashboardRevisionsV2QueryOptions = <
+	TData = Awaited<ReturnType<typeof listDashboardRevisionsV2>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: ListDashboardRevisionsV2PathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listDashboardRevisionsV2>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getListDashboardRevisionsV2QueryKey({ id });
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof listDashboardRevisionsV2>>> = ({
+		signal,
+	}) => listDashboardRevisionsV2({ id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof listDashboardRevisionsV2>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ListDashboardRevisionsV2QueryResult = NonNullable<
+	Awaited<ReturnType<typeof listDashboardRevisionsV2>>
+>;
+export type ListDashboardRevisionsV2QueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary List dashboard revisions (v2)
+ */
+
+export function useListDashboardRevisionsV2<
+	TData = Awaited<ReturnType<typeof listDashboardRevisionsV2>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: ListDashboardRevisionsV2PathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listDashboardRevisionsV2>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getListDashboardRevisionsV2QueryOptions(
+		{ id },
+		options,
+	);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary List dashboard revisions (v2)
+ */
+export const invalidateListDashboardRevisionsV2 = async (
+	queryClient: QueryClient,
+	{ id }: ListDashboardRevisionsV2PathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getListDashboardRevisionsV2QueryKey({ id }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * This endpoint returns a revision of a dashboard along with the dashboard as it was at that revision.
+ * @summary Get dashboard revision (v2)
+ */
+export const getDashboardRevisionV2 = (
+	{ id, version }: GetDashboardRevisionV2PathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetDashboardRevisionV2200>({
+		url: `/api/v2/dashboards/${id}/revisions/${version}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetDashboardRevisionV2QueryKey = ({
+	id, version,
+}: GetDashboardRevisionV2PathParameters) => {
+	return [`/api/v2/dashboards/${id}/revisions/${version}`] as const;
+};
+
+export const getGetDashboardRevisionV2QueryOptions = <
+	TData = Awaited<ReturnType<typeof getDashboardRevisionV2>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id, version }: GetDashboardRevisionV2PathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getDashboardRevisionV2>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetDashboardRevisionV2QueryKey({ id, version });
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof getDashboardRevisionV2>>> = ({
+		signal,
+	}) => getDashboardRevisionV2({ id, version }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!(id && version),
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getDashboardRevisionV2>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetDashboardRevisionV2QueryResult = NonNullable<
+	Awaited<ReturnType<typeof getDashboardRevisionV2>>
+>;
+export type GetDashboardRevisionV2QueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get dashboard revision (v2)
+ */
+
+export function useGetDashboardRevisionV2<
+	TData = Awaited<ReturnType<typeof getDashboardRevisionV2>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id, version }: GetDashboardRevisionV2PathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getDashboardRevisionV2>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetDashboardRevisionV2QueryOptions(
+		{ id, version },
+		options,
+	);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get dashboard revision (v2)
+ */
+export const invalidateGetDashboardRevisionV2 = async (
+	queryClient: QueryClient,
+	{ id, version }: GetDashboardRevisionV2PathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetDashboardRevisionV2QueryKey({ id, version }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * This endpoint restores a v2-shape dashboard to a revision. The restore is recorded as a new revision, so it can be undone by restoring the revision before it. Locked dashboards are rejected. A v1 dashboard is restored by updating it with the data of the revision.
+ * @summary Restore dashboard revision (v2)
+ */
+export const restoreDashboardRevisionV2 = (
+	{ id, version }: RestoreDashboardRevisionV2PathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<RestoreDashboardRevisionV2200>({
+		url: `/api/v2/dashboards/${id}/revisions/${version}/restore`,
+		method: 'POST',
+		signal,
+	});
+};
+
+export const getRestoreDashboardRevisionV2MutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof restoreDashboardRevisionV2>>,
+		TError,
+		{ pathParams: RestoreDashboardRevisionV2PathParameters },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof restoreDashboardRevisionV2>>,
+	TError,
+	{ pathParams: RestoreDashboardRevisionV2PathParameters },
+	TContext
+> => {
+	const mutationKey = ['restoreDashboardRevisionV2'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof restoreDashboardRevisionV2>>,
+		{ pathParams: RestoreDashboardRevisionV2PathParameters }
+	> = (props) => {
+		const { pathParams } = props ?? {};
+
+		return restoreDashboardRevisionV2(pathParams);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type RestoreDashboardRevisionV2MutationResult = NonNullable<
+	Awaited<ReturnType<typeof restoreDashboardRevisionV2>>
+>;
+
+export type RestoreDashboardRevisionV2MutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Restore dashboard revision (v2)
+ */
+export const useRestoreDashboardRevisionV2 = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof restoreDashboardRevisionV2>>,
+		TError,
+		{ pathParams: RestoreDashboardRevisionV2PathParameters },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof restoreDashboardRevisionV2>>,
+	TError,
+	{ pathParams: RestoreDashboardRevisionV2PathParameters },
+	TContext
+> => {
+	return useMutation(getRestoreDashboardRevisionV2MutationOptions(options));
+};
+/**
+ * This endpoint returns the RFC 6902 JSON Patch operations which turn one revision of a dashboard into another. For a v2-shape dashboard the operations are relative to the postable view of the dashboard (metadata, data, tags), the same document the patch endpoint applies to, and for a v1 dashboard to its data.
+ * @summary Diff dashboard revisions (v2)
+ */
+export const diffDashboardRevisionsV2 = (
+	{ id }: DiffDashboardRevisionsV2PathParameters,
+	params: DiffDashboardRevisionsV2Params,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<DiffDashboardRevisionsV2200>({
+		url: `/api/v2/dashboards/${id}/revisions/diff`,
+		method: 'GET',
+		params,
+		signal,
+	});
+};
+
+export const getDiffDashboardRevisionsV2QueryKey = (
+	{ id }: DiffDashboardRevisionsV2PathParameters,
+	params?: DiffDashboardRevisionsV2Params,
+) => {
+	return [`/api/v2/dashboards/${id}/revisions/diff`, ...(params ? [params] : [])] as const;
+};
+
+export const getDiffDashboardRevisionsV2QueryOptions = <
+	TData = Awaited<ReturnType<typeof diffDashboardRevisionsV2>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: DiffDashboardRevisionsV2PathParameters,
+	params: DiffDashboardRevisionsV2Params,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof diffDashboardRevisionsV2>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getDiffDashboardRevisionsV2QueryKey({ id }, params);
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof diffDashboardRevisionsV2>>> = ({
+		signal,
+	}) => diffDashboardRevisionsV2({ id }, params, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof diffDashboardRevisionsV2>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type DiffDashboardRevisionsV2QueryResult = NonNullable<
+	Awaited<ReturnType<typeof diffDashboardRevisionsV2>>
+>;
+export type DiffDashboardRevisionsV2QueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Diff dashboard revisions (v2)
+ */
+
+export function useDiffDashboardRevisionsV2<
+	TData = Awaited<ReturnType<typeof diffDashboardRevisionsV2>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: DiffDashboardRevisionsV2PathParameters,
+	params: DiffDashboardRevisionsV2Params,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof diffDashboardRevisionsV2>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getDiffDashboardRevisionsV2QueryOptions(
+		{ id },
+		params, options,
+	);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Diff dashboard revisions (v2)
+ */
+export const invalidateDiffDashboardRevisionsV2 = async (
+	queryClient: QueryClient,
+	{ id }: DiffDashboardRevisionsV2PathParameters,
+	params: DiffDashboardRevisionsV2Params,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getDiffDashboardRevisionsV2QueryKey({ id }, params) },
+		options,
+	);
+
+	return queryClient;
+};
+/**
+ * This endpoint creates a v2 dashboard from a Grafana dashboard JSON. PromQL targets become PromQL queries, template variables become dashboard variables, and rows become layout sections. The Grafana panels, variables, and tags that could not be converted are left out of the dashboard and listed in the report.
+ * @summary Import Grafana dashboard (v2)
+ */
+export const importGrafanaDashboardV2 = (
+	dashboardtypesGrafanaDashboardDTO?: BodyType<DashboardtypesGrafanaDashboardDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<ImportGrafanaDashboardV2201>({
+		url: `/api/v2/dashboards/import/grafana`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: dashboardtypesGrafanaDashboardDTO,
+		signal,
+	});
+};
+
+export const getImportGrafanaDashboardV2MutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof importGrafanaDashboardV2>>,
+		TError,
+		{ data?: BodyType<DashboardtypesGrafanaDashboardDTO> },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof importGrafanaDashboardV2>>,
+	TError,
+	{ data?: BodyType<DashboardtypesGrafanaDashboardDTO> },
+	TContext
+> => {
+	const mutationKey = ['importGrafanaDashboardV2'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof importGrafanaDashboardV2>>,
+		{ data?: BodyType<DashboardtypesGrafanaDashboardDTO> }
+	> = (props) => {
+		const { data } = props ?? {};
+
+		return importGrafanaDashboardV2(data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type ImportGrafanaDashboardV2MutationResult = NonNullable<
+	Awaited<ReturnType<typeof importGrafanaDashboardV2>>
+>;
+export type ImportGrafanaDashboardV2MutationBody =
+	| BodyType<DashboardtypesGrafanaDashboardDTO>
+	| undefined;
+export type ImportGrafanaDashboardV2MutationError =
+	ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Import Grafana dashboard (v2)
+ */
+export const useImportGrafanaDashboardV2 = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof importGrafanaDashboardV2>>,
+		TError,
+		{ data?: BodyType<DashboardtypesGrafanaDashboardDTO> },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof importGrafanaDashboardV2>>,
+	TError,
+	{ data?: BodyType<DashboardtypesGrafanaDashboardDTO> },
+	TContext
+> => {
+	return useMutation(getImportGrafanaDashboardV2MutationOptions(options));
+};
@@ -0,0 +1,710 @@
+/**
+ * ! Do not edit manually
+ * * The file has been auto-generated using Orval for SigNoz
+ * * regenerate with 'pnpm generate:api'
+ * SigNoz
+ */
+import { useMutation, useQuery } from 'react-query';
+import type {
+	InvalidateOptions,
+	MutationFunction,
+	QueryClient,
+	QueryFunction,
+	QueryKey,
+	UseMutationOptions,
+	UseMutationResult,
+	UseQueryOptions,
+	UseQueryResult,
+} from 'react-query';
+
+import type {
+	CreateIngestionRule201,
+	CreateIngestionRulePathParameters,
+	DeleteIngestionRulePathParameters,
+	GetIngestionRule200,
+	GetIngestionRulePathParameters,
+	GetIngestionRulesVersion200,
+	GetIngestionRulesVersionPathParameters,
+	IngestionruletypesPostableIngestionRuleDTO,
+	IngestionruletypesPostableIngestionRulesPreviewDTO,
+	ListIngestionRules200,
+	ListIngestionRulesPathParameters,
+	PreviewIngestionRules200,
+	PreviewIngestionRulesPathParameters,
+	RenderErrorResponseDTO,
+	UpdateIngestionRule200,
+	UpdateIngestionRulePathParameters,
+} from '../sigNoz.schemas';
+
+import { GeneratedAPIInstance } from '../../../generatedAPIInstance';
+import type { ErrorType, BodyType } from '../../../generatedAPIInstance';
+
+/**
+ * Returns the sampling or drop rules of the authenticated org, depending on the kind, along with the latest config version they were rolled out with and the history of config versions.
+ * @summary List ingestion rules
+ */
+export const listIngestionRules = (
+	{ kind }: ListIngestionRulesPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<ListIngestionRules200>({
+		url: `/api/v1/ingestion_rules/${kind}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getListIngestionRulesQueryKey = ({ kind }: ListIngestionRulesPathParameters) => {
+	return [`/api/v1/ingestion_rules/${kind}`] as const;
+};
+
+export const getListIngestionRulesQueryOptions = <
+	TData = Awaited<ReturnType<typeof listIngestionRules>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind }: ListIngestionRulesPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listIngestionRules>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getListIngestionRulesQueryKey({ kind });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof listIngestionRules>>
+	> = ({ signal }) => listIngestionRules({ kind }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!kind,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof listIngestionRules>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ListIngestionRulesQueryResult = NonNullable<
+	Awaited<ReturnType<typeof listIngestionRules>>
+>;
+export type ListIngestionRulesQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary List ingestion rules
+ */
+
+export function useListIngestionRules<
+	TData = Awaited<ReturnType<typeof listIngestionRules>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind }: ListIngestionRulesPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listIngestionRules>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getListIngestionRulesQueryOptions({ kind }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary List ingestion rules
+ */
+export const invalidateListIngestionRules = async (
+	queryClient: QueryClient,
+	{ kind }: ListIngestionRulesPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getListIngestionRulesQueryKey({ kind }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Creates a tail sampling policy or a drop filter and starts a new config version of the kind, which is rolled out to the collectors via OpAMP. Traces not sampled by any enabled sampling policy are dropped.
+ * @summary Create an ingestion rule
+ */
+export const createIngestionRule = (
+	{ kind }: CreateIngestionRulePathParameters,
+	ingestionruletypesPostableIngestionRuleDTO?: BodyType<IngestionruletypesPostableIngestionRuleDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<CreateIngestionRule201>({
+		url: `/api/v1/ingestion_rules/${kind}`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: ingestionruletypesPostableIngestionRuleDTO,
+		signal,
+	});
+};
+
+export const getCreateIngestionRuleMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createIngestionRule>>,
+		TError,
+		{
+			pathParams: CreateIngestionRulePathParameters;
+			data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+		},
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof createIngestionRule>>,
+	TError,
+	{
+		pathParams: CreateIngestionRulePathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+	},
+	TContext
+> => {
+	const mutationKey = ['createIngestionRule'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof createIngestionRule>>,
+		{
+		pathParams: CreateIngestionRulePathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+	}
+	> = (props) => {
+		const { pathParams, data } = props ?? {};
+
+		return createIngestionRule(pathParams, data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type CreateIngestionRuleMutationResult = NonNullable<
+	Awaited<ReturnType<typeof createIngestionRule>>
+>;
+export type CreateIngestionRuleMutationBody = BodyType<IngestionruletypesPostableIngestionRuleDTO> | undefined;
+export type CreateIngestionRuleMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Create an ingestion rule
+ */
+export const useCreateIngestionRule = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createIngestionRule>>,
+		TError,
+		{
+			pathParams: CreateIngestionRulePathParameters;
+			data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+		},
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof createIngestionRule>>,
+	TError,
+	{
+		pathParams: CreateIngestionRulePathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+	},
+	TContext
+> => {
+	return useMutation(getCreateIngestionRuleMutationOptions(options));
+};
+/**
+ * Deletes a sampling or drop rule and starts a new config version of the kind, which is rolled out to the collectors via OpAMP.
+ * @summary Delete an ingestion rule
+ */
+export const deleteIngestionRule = (
+	{ kind, id }: DeleteIngestionRulePathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<void>({
+		url: `/api/v1/ingestion_rules/${kind}/${id}`,
+		method: 'DELETE',
+		signal,
+	});
+};
+
+export const getDeleteIngestionRuleMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof deleteIngestionRule>>,
+		TError,
+		{ pathParams: DeleteIngestionRulePathParameters },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof deleteIngestionRule>>,
+	TError,
+	{ pathParams: DeleteIngestionRulePathParameters },
+	TContext
+> => {
+	const mutationKey = ['deleteIngestionRule'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof deleteIngestionRule>>,
+		{ pathParams: DeleteIngestionRulePathParameters }
+	> = (props) => {
+		const { pathParams } = props ?? {};
+
+		return deleteIngestionRule(pathParams);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type DeleteIngestionRuleMutationResult = NonNullable<
+	Awaited<ReturnType<typeof deleteIngestionRule>>
+>;
+
+export type DeleteIngestionRuleMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Delete an ingestion rule
+ */
+export const useDeleteIngestionRule = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof deleteIngestionRule>>,
+		TError,
+		{ pathParams: DeleteIngestionRulePathParameters },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof deleteIngestionRule>>,
+	TError,
+	{ pathParams: DeleteIngestionRulePathParameters },
+	TContext
+> => {
+	return useMutation(getDeleteIngestionRuleMutationOptions(options));
+};
+/**
+ * Returns a single sampling or drop rule by ID.
+ * @summary Get an ingestion rule
+ */
+export const getIngestionRule = (
+	{ kind, id }: GetIngestionRulePathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetIngestionRule200>({
+		url: `/api/v1/ingestion_rules/${kind}/${id}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetIngestionRuleQueryKey = ({ kind, id }: GetIngestionRulePathParameters) => {
+	return [`/api/v1/ingestion_rules/${kind}/${id}`] as const;
+};
+
+export const getGetIngestionRuleQueryOptions = <
+	TData = Awaited<ReturnType<typeof getIngestionRule>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind, id }: GetIngestionRulePathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getIngestionRule>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetIngestionRuleQueryKey({ kind, id });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getIngestionRule>>
+	> = ({ signal }) => getIngestionRule({ kind, id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!kind && !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getIngestionRule>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetIngestionRuleQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getIngestionRule>>
+>;
+export type GetIngestionRuleQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get an ingestion rule
+ */
+
+export function useGetIngestionRule<
+	TData = Awaited<ReturnType<typeof getIngestionRule>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind, id }: GetIngestionRulePathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getIngestionRule>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetIngestionRuleQueryOptions({ kind, id }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get an ingestion rule
+ */
+export const invalidateGetIngestionRule = async (
+	queryClient: QueryClient,
+	{ kind, id }: GetIngestionRulePathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetIngestionRuleQueryKey({ kind, id }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Replaces a sampling or drop rule and starts a new config version of the kind, which is rolled out to the collectors via OpAMP.
+ * @summary Update an ingestion rule
+ */
+export const updateIngestionRule = (
+	{ kind, id }: UpdateIngestionRulePathParameters,
+	ingestionruletypesPostableIngestionRuleDTO?: BodyType<IngestionruletypesPostableIngestionRuleDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<UpdateIngestionRule200>({
+		url: `/api/v1/ingestion_rules/${kind}/${id}`,
+		method: 'PUT',
+		headers: { 'Content-Type': 'application/json' },
+		data: ingestionruletypesPostableIngestionRuleDTO,
+		signal,
+	});
+};
+
+export const getUpdateIngestionRuleMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof updateIngestionRule>>,
+		TError,
+		{
+			pathParams: UpdateIngestionRulePathParameters;
+			data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+		},
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof updateIngestionRule>>,
+	TError,
+	{
+		pathParams: UpdateIngestionRulePathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+	},
+	TContext
+> => {
+	const mutationKey = ['updateIngestionRule'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof updateIngestionRule>>,
+		{
+		pathParams: UpdateIngestionRulePathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+	}
+	> = (props) => {
+		const { pathParams, data } = props ?? {};
+
+		return updateIngestionRule(pathParams, data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type UpdateIngestionRuleMutationResult = NonNullable<
+	Awaited<ReturnType<typeof updateIngestionRule>>
+>;
+export type UpdateIngestionRuleMutationBody = BodyType<IngestionruletypesPostableIngestionRuleDTO> | undefined;
+export type UpdateIngestionRuleMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Update an ingestion rule
+ */
+export const useUpdateIngestionRule = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof updateIngestionRule>>,
+		TError,
+		{
+			pathParams: UpdateIngestionRulePathParameters;
+			data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+		},
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof updateIngestionRule>>,
+	TError,
+	{
+		pathParams: UpdateIngestionRulePathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRuleDTO>;
+	},
+	TContext
+> => {
+	return useMutation(getUpdateIngestionRuleMutationOptions(options));
+};
+/**
+ * Returns the collector config the given rules would be rolled out as, applied to the given collector config or to a minimal one. Nothing is stored.
+ * @summary Preview ingestion rules
+ */
+export const previewIngestionRules = (
+	{ kind }: PreviewIngestionRulesPathParameters,
+	ingestionruletypesPostableIngestionRulesPreviewDTO?: BodyType<IngestionruletypesPostableIngestionRulesPreviewDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<PreviewIngestionRules200>({
+		url: `/api/v1/ingestion_rules/${kind}/preview`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: ingestionruletypesPostableIngestionRulesPreviewDTO,
+		signal,
+	});
+};
+
+export const getPreviewIngestionRulesMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof previewIngestionRules>>,
+		TError,
+		{
+			pathParams: PreviewIngestionRulesPathParameters;
+			data?: BodyType<IngestionruletypesPostableIngestionRulesPreviewDTO>;
+		},
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof previewIngestionRules>>,
+	TError,
+	{
+		pathParams: PreviewIngestionRulesPathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRulesPreviewDTO>;
+	},
+	TContext
+> => {
+	const mutationKey = ['previewIngestionRules'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof previewIngestionRules>>,
+		{
+		pathParams: PreviewIngestionRulesPathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRulesPreviewDTO>;
+	}
+	> = (props) => {
+		const { pathParams, data } = props ?? {};
+
+		return previewIngestionRules(pathParams, data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type PreviewIngestionRulesMutationResult = NonNullable<
+	Awaited<ReturnType<typeof previewIngestionRules>>
+>;
+export type PreviewIngestionRulesMutationBody = BodyType<IngestionruletypesPostableIngestionRulesPreviewDTO> | undefined;
+export type PreviewIngestionRulesMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Preview ingestion rules
+ */
+export const usePreviewIngestionRules = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof previewIngestionRules>>,
+		TError,
+		{
+			pathParams: PreviewIngestionRulesPathParameters;
+			data?: BodyType<IngestionruletypesPostableIngestionRulesPreviewDTO>;
+		},
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof previewIngestionRules>>,
+	TError,
+	{
+		pathParams: PreviewIngestionRulesPathParameters;
+		data?: BodyType<IngestionruletypesPostableIngestionRulesPreviewDTO>;
+	},
+	TContext
+> => {
+	return useMutation(getPreviewIngestionRulesMutationOptions(options));
+};
+/**
+ * Returns the rules of the kind as they were in the config version, along with its deploy status and the history of config versions.
+ * @summary Get a config version of ingestion rules
+ */
+export const getIngestionRulesVersion = (
+	{ kind, version }: GetIngestionRulesVersionPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetIngestionRulesVersion200>({
+		url: `/api/v1/ingestion_rules/${kind}/versions/${version}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetIngestionRulesVersionQueryKey = ({ kind, version }: GetIngestionRulesVersionPathParameters) => {
+	return [`/api/v1/ingestion_rules/${kind}/versions/${version}`] as const;
+};
+
+export const getGetIngestionRulesVersionQueryOptions = <
+	TData = Awaited<ReturnType<typeof getIngestionRulesVersion>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind, version }: GetIngestionRulesVersionPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getIngestionRulesVersion>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetIngestionRulesVersionQueryKey({ kind, version });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getIngestionRulesVersion>>
+	> = ({ signal }) => getIngestionRulesVersion({ kind, version }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!kind && !!version,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getIngestionRulesVersion>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetIngestionRulesVersionQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getIngestionRulesVersion>>
+>;
+export type GetIngestionRulesVersionQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get a config version of ingestion rules
+ */
+
+export function useGetIngestionRulesVersion<
+	TData = Awaited<ReturnType<typeof getIngestionRulesVersion>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ kind, version }: GetIngestionRulesVersionPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getIngestionRulesVersion>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetIngestionRulesVersionQueryOptions({ kind, version }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get a config version of ingestion rules
+ */
+export const invalidateGetIngestionRulesVersion = async (
+	queryClient: QueryClient,
+	{ kind, version }: GetIngestionRulesVersionPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetIngestionRulesVersionQueryKey({ kind, version }) },
+		options,
+	);
+
+	return queryClient;
+};
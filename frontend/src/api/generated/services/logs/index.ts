@@ -18,7 +18,15 @@ import type {
 } from 'react-query';
 
 import type {
+	CancelExportJob200,
+	CancelExportJobPathParameters,
+	CreateExportJob202,
+	CreateExportJobParams,
+	DownloadExportJobResultPathParameters,
+	GetExportJob200,
+	GetExportJobPathParameters,
 	HandleExportRawDataPOSTParams,
+	ListExportJobs200,
 	ListPromotedAndIndexedPaths200,
 	PromotetypesPromotePathDTO,
 	Querybuildertypesv5QueryRangeRequestDTO,
@@ -28,6 +36,471 @@ import type {
 import { GeneratedAPIInstance } from '../../../generatedAPIInstance';
 import type { ErrorType, BodyType } from '../../../generatedAPIInstance';
 
+/**
+ * This endpoint lists the export jobs created by the user, or all the export jobs of the org for an admin
+ * @summary List export jobs
+ */
+export const listExportJobs = (signal?: AbortSignal) => {
+	return GeneratedAPIInstance<ListExportJobs200>({
+		url: `/api/v1/export_jobs`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getListExportJobsQueryKey = () => {
+	return [`/api/v1/export_jobs`] as const;
+};
+
+export const getListExportJobsQueryOptions = <
+	TData = Awaited<ReturnType<typeof listExportJobs>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(options?: {
+	query?: UseQueryOptions<
+		Awaited<ReturnType<typeof listExportJobs>>,
+		TError,
+		TData
+	>;
+}) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey = queryOptions?.queryKey ?? getListExportJobsQueryKey();
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof listExportJobs>>> = ({
+		signal,
+	}) => listExportJobs(signal);
+
+	return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+		Awaited<ReturnType<typeof listExportJobs>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ListExportJobsQueryResult = NonNullable<
+	Awaited<ReturnType<typeof listExportJobs>>
+>;
+export type ListExportJobsQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary List export jobs
+ */
+
+export function useListExportJobs<
+	TData = Awaited<ReturnType<typeof listExportJobs>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(options?: {
+	query?: UseQueryOptions<
+		Awaited<ReturnType<typeof listExportJobs>>,
+		TError,
+		TData
+	>;
+}): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getListExportJobsQueryOptions(options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary List export jobs
+ */
+export const invalidateListExportJobs = async (
+	queryClient: QueryClient,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getListExportJobsQueryKey() },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * This endpoint creates a job which exports raw data for traces and logs in the background, the result is downloaded once the job succeeds
+ * @summary Create export job
+ */
+export const createExportJob = (
+	querybuildertypesv5QueryRangeRequestDTO?: BodyType<Querybuildertypesv5QueryRangeRequestDTO>,
+	params?: CreateExportJobParams,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<CreateExportJob202>({
+		url: `/api/v1/export_jobs`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: querybuildertypesv5QueryRangeRequestDTO,
+		params,
+		signal,
+	});
+};
+
+export const getCreateExportJobMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createExportJob>>,
+		TError,
+		{
+			data?: BodyType<Querybuildertypesv5QueryRangeRequestDTO>;
+			params?: CreateExportJobParams;
+		},
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof createExportJob>>,
+	TError,
+	{
+		data?: BodyType<Querybuildertypesv5QueryRangeRequestDTO>;
+		params?: CreateExportJobParams;
+	},
+	TContext
+> => {
+	const mutationKey = ['createExportJob'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof createExportJob>>,
+		{
+			data?: BodyType<Querybuildertypesv5QueryRangeRequestDTO>;
+			params?: CreateExportJobParams;
+		}
+	> = (props) => {
+		const { data, params } = props ?? {};
+
+		return createExportJob(data, params);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type CreateExportJobMutationResult = NonNullable<
+	Awaited<ReturnType<typeof createExportJob>>
+>;
+export type CreateExportJobMutationBody =
+	| BodyType<Querybuildertypesv5QueryRangeRequestDTO>
+	| undefined;
+export type CreateExportJobMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Create export job
+ */
+export const useCreateExportJob = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createExportJob>>,
+		TError,
+		{
+			data?: BodyType<Querybuildertypesv5QueryRangeRequestDTO>;
+			params?: CreateExportJobParams;
+		},
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof createExportJob>>,
+	TError,
+	{
+		data?: BodyType<Querybuildertypesv5QueryRangeRequestDTO>;
+		params?: CreateExportJobParams;
+	},
+	TContext
+> => {
+	return useMutation(getCreateExportJobMutationOptions(options));
+};
+/**
+ * This endpoint returns the status and progress of an export job
+ * @summary Get export job
+ */
+export const getExportJob = (
+	{ id }: GetExportJobPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetExportJob200>({
+		url: `/api/v1/export_jobs/${id}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetExportJobQueryKey = ({ id }: GetExportJobPathParameters) => {
+	return [`/api/v1/export_jobs/${id}`] as const;
+};
+
+export const getGetExportJobQueryOptions = <
+	TData = Awaited<ReturnType<typeof getExportJob>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetExportJobPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getExportJob>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey = queryOptions?.queryKey ?? getGetExportJobQueryKey({ id });
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof getExportJob>>> = ({
+		signal,
+	}) => getExportJob({ id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getExportJob>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetExportJobQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getExportJob>>
+>;
+export type GetExportJobQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get export job
+ */
+
+export function useGetExportJob<
+	TData = Awaited<ReturnType<typeof getExportJob>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetExportJobPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getExportJob>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetExportJobQueryOptions({ id }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get export job
+ */
+export const invalidateGetExportJob = async (
+	queryClient: QueryClient,
+	{ id }: GetExportJobPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetExportJobQueryKey({ id }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * This endpoint cancels a queued or running export job
+ * @summary Cancel export job
+ */
+export const cancelExportJob = (
+	{ id }: CancelExportJobPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<CancelExportJob200>({
+		url: `/api/v1/export_jobs/${id}/cancel`,
+		method: 'POST',
+		signal,
+	});
+};
+
+export const getCancelExportJobMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof cancelExportJob>>,
+		TError,
+		{ pathParams: CancelExportJobPathParameters },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof cancelExportJob>>,
+	TError,
+	{ pathParams: CancelExportJobPathParameters },
+	TContext
+> => {
+	const mutationKey = ['cancelExportJob'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof cancelExportJob>>,
+		{ pathParams: CancelExportJobPathParameters }
+	> = (props) => {
+		const { pathParams } = props ?? {};
+
+		return cancelExportJob(pathParams);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type CancelExportJobMutationResult = NonNullable<
+	Awaited<ReturnType<typeof cancelExportJob>>
+>;
+
+export type CancelExportJobMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Cancel export job
+ */
+export const useCancelExportJob = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof cancelExportJob>>,
+		TError,
+		{ pathParams: CancelExportJobPathParameters },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof cancelExportJob>>,
+	TError,
+	{ pathParams: CancelExportJobPathParameters },
+	TContext
+> => {
+	return useMutation(getCancelExportJobMutationOptions(options));
+};
+/**
+ * This endpoint downloads the result of a succeeded export job, it supports range requests to resume an interrupted download
+ * @summary Download export job result
+ */
+export const downloadExportJobResult = (
+	{ id }: DownloadExportJobResultPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<string>({
+		url: `/api/v1/export_jobs/${id}/download`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getDownloadExportJobResultQueryKey = ({
+	id,
+}: DownloadExportJobResultPathParameters) => {
+	return [`/api/v1/export_jobs/${id}/download`] as const;
+};
+
+export const getDownloadExportJobResultQueryOptions = <
+	TData = Awaited<ReturnType<typeof downloadExportJobResult>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: DownloadExportJobResultPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof downloadExportJobResult>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getDownloadExportJobResultQueryKey({ id });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof downloadExportJobResult>>
+	> = ({ signal }) => downloadExportJobResult({ id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof downloadExportJobResult>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type DownloadExportJobResultQueryResult = NonNullable<
+	Awaited<ReturnType<typeof downloadExportJobResult>>
+>;
+export type DownloadExportJobResultQueryError =
+	ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Download export job result
+ */
+
+export function useDownloadExportJobResult<
+	TData = Awaited<ReturnType<typeof downloadExportJobResult>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: DownloadExportJobResultPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof downloadExportJobResult>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getDownloadExportJobResultQueryOptions({ id }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Download export job result
+ */
+export const invalidateDownloadExportJobResult = async (
+	queryClient: QueryClient,
+	{ id }: DownloadExportJobResultPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getDownloadExportJobResultQueryKey({ id }) },
+		options,
+	);
+
+	return queryClient;
+};
+
 /**
  * This endpoints allows complex query exporting raw data for traces and logs
  * @summary Export raw data
@@ -0,0 +1,763 @@
+/**
+ * ! Do not edit manually
+ * * The file has been auto-generated using Orval for SigNoz
+ * * regenerate with 'pnpm generate:api'
+ * SigNoz
+ */
+import { useMutation, useQuery } from 'react-query';
+import type {
+	InvalidateOptions,
+	MutationFunction,
+	QueryClient,
+	QueryFunction,
+	QueryKey,
+	UseMutationOptions,
+	UseMutationResult,
+	UseQueryOptions,
+	UseQueryResult,
+} from 'react-query';
+
+import type {
+	CreateLookupTable201,
+	CreateLookupTableVersion201,
+	CreateLookupTableVersionPathParameters,
+	DeleteLookupTablePathParameters,
+	GetLookupTable200,
+	GetLookupTablePathParameters,
+	GetLookupTableVersion200,
+	GetLookupTableVersionPathParameters,
+	ListLookupTableVersions200,
+	ListLookupTableVersionsPathParameters,
+	ListLookupTables200,
+	LookuptabletypesPostableLookupTableDTO,
+	LookuptabletypesPostableLookupTablePreviewDTO,
+	LookuptabletypesPostableLookupTableVersionDTO,
+	PreviewLookupTable200,
+	RenderErrorResponseDTO,
+} from '../sigNoz.schemas';
+
+import { GeneratedAPIInstance } from '../../../generatedAPIInstance';
+import type { ErrorType, BodyType } from '../../../generatedAPIInstance';
+
+/**
+ * Returns the lookup tables of the authenticated org along with the latest config version they were rolled out with and the history of config versions.
+ * @summary List lookup tables
+ */
+export const listLookupTables = (signal?: AbortSignal) => {
+	return GeneratedAPIInstance<ListLookupTables200>({
+		url: `/api/v1/lookup_tables`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getListLookupTablesQueryKey = () => {
+	return [`/api/v1/lookup_tables`] as const;
+};
+
+export const getListLookupTablesQueryOptions = <
+	TData = Awaited<ReturnType<typeof listLookupTables>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(options?: {
+	query?: UseQueryOptions<
+		Awaited<ReturnType<typeof listLookupTables>>,
+		TError,
+		TData
+	>;
+}) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey = queryOptions?.queryKey ?? getListLookupTablesQueryKey();
+
+	const queryFn: QueryFunction<Awaited<ReturnType<typeof listLookupTables>>> = ({
+		signal,
+	}) => listLookupTables(signal);
+
+	return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+		Awaited<ReturnType<typeof listLookupTables>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ListLookupTablesQueryResult = NonNullable<
+	Awaited<ReturnType<typeof listLookupTables>>
+>;
+export type ListLookupTablesQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary List lookup tables
+ */
+
+export function useListLookupTables<
+	TData = Awaited<ReturnType<typeof listLookupTables>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(options?: {
+	query?: UseQueryOptions<
+		Awaited<ReturnType<typeof listLookupTables>>,
+		TError,
+		TData
+	>;
+}): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getListLookupTablesQueryOptions(options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary List lookup tables
+ */
+export const invalidateListLookupTables = async (
+	queryClient: QueryClient,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getListLookupTablesQueryKey() },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Creates a lookup table from an uploaded CSV, whose first row names the columns, and starts a new config version which is rolled out to the collectors via OpAMP. Log pipeline lookup operators and span mappers refer to the table by name.
+ * @summary Create a lookup table
+ */
+export const createLookupTable = (
+	lookuptabletypesPostableLookupTableDTO?: BodyType<LookuptabletypesPostableLookupTableDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<CreateLookupTable201>({
+		url: `/api/v1/lookup_tables`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: lookuptabletypesPostableLookupTableDTO,
+		signal,
+	});
+};
+
+export const getCreateLookupTableMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createLookupTable>>,
+		TError,
+		{ data?: BodyType<LookuptabletypesPostableLookupTableDTO> },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof createLookupTable>>,
+	TError,
+	{ data?: BodyType<LookuptabletypesPostableLookupTableDTO> },
+	TContext
+> => {
+	const mutationKey = ['createLookupTable'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof createLookupTable>>,
+		{ data?: BodyType<LookuptabletypesPostableLookupTableDTO> }
+	> = (props) => {
+		const { data } = props ?? {};
+
+		return createLookupTable(data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type CreateLookupTableMutationResult = NonNullable<
+	Awaited<ReturnType<typeof createLookupTable>>
+>;
+export type CreateLookupTableMutationBody = BodyType<LookuptabletypesPostableLookupTableDTO> | undefined;
+export type CreateLookupTableMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Create a lookup table
+ */
+export const useCreateLookupTable = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createLookupTable>>,
+		TError,
+		{ data?: BodyType<LookuptabletypesPostableLookupTableDTO> },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof createLookupTable>>,
+	TError,
+	{ data?: BodyType<LookuptabletypesPostableLookupTableDTO> },
+	TContext
+> => {
+	return useMutation(getCreateLookupTableMutationOptions(options));
+};
+/**
+ * Deletes a lookup table along with all its versions and starts a new config version which is rolled out to the collectors via OpAMP. Operators and span mappers still referring to the table stop enriching.
+ * @summary Delete a lookup table
+ */
+export const deleteLookupTable = (
+	{ id }: DeleteLookupTablePathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<void>({
+		url: `/api/v1/lookup_tables/${id}`,
+		method: 'DELETE',
+		signal,
+	});
+};
+
+export const getDeleteLookupTableMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof deleteLookupTable>>,
+		TError,
+		{ pathParams: DeleteLookupTablePathParameters },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof deleteLookupTable>>,
+	TError,
+	{ pathParams: DeleteLookupTablePathParameters },
+	TContext
+> => {
+	const mutationKey = ['deleteLookupTable'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof deleteLookupTable>>,
+		{ pathParams: DeleteLookupTablePathParameters }
+	> = (props) => {
+		const { pathParams } = props ?? {};
+
+		return deleteLookupTable(pathParams);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type DeleteLookupTableMutationResult = NonNullable<
+	Awaited<ReturnType<typeof deleteLookupTable>>
+>;
+
+export type DeleteLookupTableMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Delete a lookup table
+ */
+export const useDeleteLookupTable = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof deleteLookupTable>>,
+		TError,
+		{ pathParams: DeleteLookupTablePathParameters },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof deleteLookupTable>>,
+	TError,
+	{ pathParams: DeleteLookupTablePathParameters },
+	TContext
+> => {
+	return useMutation(getDeleteLookupTableMutationOptions(options));
+};
+/**
+ * Returns a single lookup table by ID, along with the columns and row count of its latest version.
+ * @summary Get a lookup table
+ */
+export const getLookupTable = (
+	{ id }: GetLookupTablePathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetLookupTable200>({
+		url: `/api/v1/lookup_tables/${id}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetLookupTableQueryKey = ({ id }: GetLookupTablePathParameters) => {
+	return [`/api/v1/lookup_tables/${id}`] as const;
+};
+
+export const getGetLookupTableQueryOptions = <
+	TData = Awaited<ReturnType<typeof getLookupTable>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetLookupTablePathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getLookupTable>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetLookupTableQueryKey({ id });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getLookupTable>>
+	> = ({ signal }) => getLookupTable({ id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getLookupTable>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetLookupTableQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getLookupTable>>
+>;
+export type GetLookupTableQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get a lookup table
+ */
+
+export function useGetLookupTable<
+	TData = Awaited<ReturnType<typeof getLookupTable>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetLookupTablePathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getLookupTable>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetLookupTableQueryOptions({ id }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get a lookup table
+ */
+export const invalidateGetLookupTable = async (
+	queryClient: QueryClient,
+	{ id }: GetLookupTablePathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetLookupTableQueryKey({ id }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Returns the versions of a lookup table, latest first and without their rows.
+ * @summary List the versions of a lookup table
+ */
+export const listLookupTableVersions = (
+	{ id }: ListLookupTableVersionsPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<ListLookupTableVersions200>({
+		url: `/api/v1/lookup_tables/${id}/versions`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getListLookupTableVersionsQueryKey = ({ id }: ListLookupTableVersionsPathParameters) => {
+	return [`/api/v1/lookup_tables/${id}/versions`] as const;
+};
+
+export const getListLookupTableVersionsQueryOptions = <
+	TData = Awaited<ReturnType<typeof listLookupTableVersions>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: ListLookupTableVersionsPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listLookupTableVersions>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getListLookupTableVersionsQueryKey({ id });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof listLookupTableVersions>>
+	> = ({ signal }) => listLookupTableVersions({ id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof listLookupTableVersions>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ListLookupTableVersionsQueryResult = NonNullable<
+	Awaited<ReturnType<typeof listLookupTableVersions>>
+>;
+export type ListLookupTableVersionsQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary List the versions of a lookup table
+ */
+
+export function useListLookupTableVersions<
+	TData = Awaited<ReturnType<typeof listLookupTableVersions>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: ListLookupTableVersionsPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listLookupTableVersions>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getListLookupTableVersionsQueryOptions({ id }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary List the versions of a lookup table
+ */
+export const invalidateListLookupTableVersions = async (
+	queryClient: QueryClient,
+	{ id }: ListLookupTableVersionsPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getListLookupTableVersionsQueryKey({ id }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Replaces the content of a lookup table with an uploaded CSV as its next version and starts a new config version which is rolled out to the collectors via OpAMP.
+ * @summary Upload a new version of a lookup table
+ */
+export const createLookupTableVersion = (
+	{ id }: CreateLookupTableVersionPathParameters,
+	lookuptabletypesPostableLookupTableVersionDTO?: BodyType<LookuptabletypesPostableLookupTableVersionDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<CreateLookupTableVersion201>({
+		url: `/api/v1/lookup_tables/${id}/versions`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: lookuptabletypesPostableLookupTableVersionDTO,
+		signal,
+	});
+};
+
+export const getCreateLookupTableVersionMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createLookupTableVersion>>,
+		TError,
+		{
+			pathParams: CreateLookupTableVersionPathParameters;
+			data?: BodyType<LookuptabletypesPostableLookupTableVersionDTO>;
+		},
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof createLookupTableVersion>>,
+	TError,
+	{
+		pathParams: CreateLookupTableVersionPathParameters;
+		data?: BodyType<LookuptabletypesPostableLookupTableVersionDTO>;
+	},
+	TContext
+> => {
+	const mutationKey = ['createLookupTableVersion'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof createLookupTableVersion>>,
+		{
+		pathParams: CreateLookupTableVersionPathParameters;
+		data?: BodyType<LookuptabletypesPostableLookupTableVersionDTO>;
+	}
+	> = (props) => {
+		const { pathParams, data } = props ?? {};
+
+		return createLookupTableVersion(pathParams, data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type CreateLookupTableVersionMutationResult = NonNullable<
+	Awaited<ReturnType<typeof createLookupTableVersion>>
+>;
+export type CreateLookupTableVersionMutationBody = BodyType<LookuptabletypesPostableLookupTableVersionDTO> | undefined;
+export type CreateLookupTableVersionMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Upload a new version of a lookup table
+ */
+export const useCreateLookupTableVersion = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createLookupTableVersion>>,
+		TError,
+		{
+			pathParams: CreateLookupTableVersionPathParameters;
+			data?: BodyType<LookuptabletypesPostableLookupTableVersionDTO>;
+		},
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof createLookupTableVersion>>,
+	TError,
+	{
+		pathParams: CreateLookupTableVersionPathParameters;
+		data?: BodyType<LookuptabletypesPostableLookupTableVersionDTO>;
+	},
+	TContext
+> => {
+	return useMutation(getCreateLookupTableVersionMutationOptions(options));
+};
+/**
+ * Returns a version of a lookup table along with its rows.
+ * @summary Get a version of a lookup table
+ */
+export const getLookupTableVersion = (
+	{ id, version }: GetLookupTableVersionPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetLookupTableVersion200>({
+		url: `/api/v1/lookup_tables/${id}/versions/${version}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetLookupTableVersionQueryKey = ({ id, version }: GetLookupTableVersionPathParameters) => {
+	return [`/api/v1/lookup_tables/${id}/versions/${version}`] as const;
+};
+
+export const getGetLookupTableVersionQueryOptions = <
+	TData = Awaited<ReturnType<typeof getLookupTableVersion>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id, version }: GetLookupTableVersionPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getLookupTableVersion>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetLookupTableVersionQueryKey({ id, version });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getLookupTableVersion>>
+	> = ({ signal }) => getLookupTableVersion({ id, version }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id && !!version,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getLookupTableVersion>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetLookupTableVersionQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getLookupTableVersion>>
+>;
+export type GetLookupTableVersionQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get a version of a lookup table
+ */
+
+export function useGetLookupTableVersion<
+	TData = Awaited<ReturnType<typeof getLookupTableVersion>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id, version }: GetLookupTableVersionPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getLookupTableVersion>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetLookupTableVersionQueryOptions({ id, version }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get a version of a lookup table
+ */
+export const invalidateGetLookupTableVersion = async (
+	queryClient: QueryClient,
+	{ id, version }: GetLookupTableVersionPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetLookupTableVersionQueryKey({ id, version }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Parses an uploaded CSV and returns its first rows along with the columns enriched for each of the given keys. Nothing is stored.
+ * @summary Preview a lookup table
+ */
+export const previewLookupTable = (
+	lookuptabletypesPostableLookupTablePreviewDTO?: BodyType<LookuptabletypesPostableLookupTablePreviewDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<PreviewLookupTable200>({
+		url: `/api/v1/lookup_tables/preview`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: lookuptabletypesPostableLookupTablePreviewDTO,
+		signal,
+	});
+};
+
+export const getPreviewLookupTableMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof previewLookupTable>>,
+		TError,
+		{ data?: BodyType<LookuptabletypesPostableLookupTablePreviewDTO> },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof previewLookupTable>>,
+	TError,
+	{ data?: BodyType<LookuptabletypesPostableLookupTablePreviewDTO> },
+	TContext
+> => {
+	const mutationKey = ['previewLookupTable'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof previewLookupTable>>,
+		{ data?: BodyType<LookuptabletypesPostableLookupTablePreviewDTO> }
+	> = (props) => {
+		const { data } = props ?? {};
+
+		return previewLookupTable(data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type PreviewLookupTableMutationResult = NonNullable<
+	Awaited<ReturnType<typeof previewLookupTable>>
+>;
+export type PreviewLookupTableMutationBody = BodyType<LookuptabletypesPostableLookupTablePreviewDTO> | undefined;
+export type PreviewLookupTableMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Preview a lookup table
+ */
+export const usePreviewLookupTable = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof previewLookupTable>>,
+		TError,
+		{ data?: BodyType<LookuptabletypesPostableLookupTablePreviewDTO> },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof previewLookupTable>>,
+	TError,
+	{ data?: BodyType<LookuptabletypesPostableLookupTablePreviewDTO> },
+	TContext
+> => {
+	return useMutation(getPreviewLookupTableMutationOptions(options));
+};
@@ -13,6 +13,7 @@ import type {
 
 import type {
 	QueryRangeV5200,
+	Querybuildertypesv5PostableCachePurgeDTO,
 	Querybuildertypesv5QueryRangeRequestDTO,
 	RenderErrorResponseDTO,
 	ReplaceVariables200,
@@ -21,6 +22,89 @@ import type {
 import { GeneratedAPIInstance } from '../../../generatedAPIInstance';
 import type { ErrorType, BodyType } from '../../../generatedAPIInstance';
 
+/**
+ * Drops the cached query results of the org for a signal and time range, e.g. after backfilling data. The next queries over the range fetch it again.
+ * @summary Purge query cache
+ */
+export const purgeQueryCache = (
+	querybuildertypesv5PostableCachePurgeDTO?: BodyType<Querybuildertypesv5PostableCachePurgeDTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<void>({
+		url: `/api/v5/query_cache/purge`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: querybuildertypesv5PostableCachePurgeDTO,
+		signal,
+	});
+};
+
+export const getPurgeQueryCacheMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof purgeQueryCache>>,
+		TError,
+		{ data?: BodyType<Querybuildertypesv5PostableCachePurgeDTO> },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof purgeQueryCache>>,
+	TError,
+	{ data?: BodyType<Querybuildertypesv5PostableCachePurgeDTO> },
+	TContext
+> => {
+	const mutationKey = ['purgeQueryCache'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof purgeQueryCache>>,
+		{ data?: BodyType<Querybuildertypesv5PostableCachePurgeDTO> }
+	> = (props) => {
+		const { data } = props ?? {};
+
+		return purgeQueryCache(data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type PurgeQueryCacheMutationResult = NonNullable<
+	Awaited<ReturnType<typeof purgeQueryCache>>
+>;
+export type PurgeQueryCacheMutationBody =
+	| BodyType<Querybuildertypesv5PostableCachePurgeDTO>
+	| undefined;
+export type PurgeQueryCacheMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Purge query cache
+ */
+export const usePurgeQueryCache = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof purgeQueryCache>>,
+		TError,
+		{ data?: BodyType<Querybuildertypesv5PostableCachePurgeDTO> },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof purgeQueryCache>>,
+	TError,
+	{ data?: BodyType<Querybuildertypesv5PostableCachePurgeDTO> },
+	TContext
+> => {
+	return useMutation(getPurgeQueryCacheMutationOptions(options));
+};
 /**
  * Execute a composite query over a time range. Supports builder queries (traces, logs, metrics), formulas, trace operators, PromQL, and ClickHouse SQL.
  * @summary Query range
@@ -368,6 +368,43 @@ export interface ConfigSecretURLDTO {
 	[key: string]: unknown;
 }
 
+export interface AlertmanagertypesDiscordReceiverConfigDTO {
+	/**
+	 * @type string
+	 */
+	avatar_url?: string;
+	/**
+	 * @type string
+	 */
+	content?: string;
+	/**
+	 * @type boolean
+	 */
+	edit_messages?: boolean;
+	http_config?: ConfigHTTPClientConfigDTO;
+	/**
+	 * @type string
+	 */
+	message?: string;
+	/**
+	 * @type boolean
+	 */
+	send_resolved?: boolean;
+	/**
+	 * @type string
+	 */
+	thread_id?: string;
+	/**
+	 * @type string
+	 */
+	title?: string;
+	/**
+	 * @type string
+	 */
+	username?: string;
+	webhook_url?: ConfigSecretURLDTO;
+}
+
 export interface AlertmanagertypesGoogleChatReceiverConfigDTO {
 	http_config?: ConfigHTTPClientConfigDTO;
 	/**
@@ -394,6 +431,40 @@ export enum AlertmanagertypesMaintenanceStatusDTO {
 	upcoming = 'upcoming',
 	expired = 'expired',
 }
+
+export interface AlertmanagertypesMattermostReceiverConfigDTO {
+	/**
+	 * @type string
+	 */
+	channel?: string;
+	http_config?: ConfigHTTPClientConfigDTO;
+	/**
+	 * @type string
+	 */
+	icon_emoji?: string;
+	/**
+	 * @type string
+	 */
+	icon_url?: string;
+	/**
+	 * @type boolean
+	 */
+	send_resolved?: boolean;
+	/**
+	 * @type string
+	 */
+	text?: string;
+	/**
+	 * @type string
+	 */
+	title?: string;
+	/**
+	 * @type string
+	 */
+	username?: string;
+	webhook_url?: ConfigSecretURLDTO;
+}
+
 export enum AlertmanagertypesRepeatOnDTO {
 	sunday = 'sunday',
 	monday = 'monday',
@@ -1515,6 +1586,48 @@ export interface ConfigTelegramConfigDTO {
 	token_file?: string;
 }
 
+export interface AlertmanagertypesTelegramReceiverConfigDTO {
+	api_url?: ConfigURLType2DTO;
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	chat?: number;
+	/**
+	 * @type boolean
+	 */
+	disable_notifications?: boolean;
+	/**
+	 * @type boolean
+	 */
+	edit_messages?: boolean;
+	http_config?: ConfigHTTPClientConfigDTO;
+	/**
+	 * @type string
+	 */
+	message?: string;
+	/**
+	 * @type integer
+	 */
+	message_thread_id?: number;
+	/**
+	 * @type string
+	 */
+	parse_mode?: string;
+	/**
+	 * @type boolean
+	 */
+	send_resolved?: boolean;
+	/**
+	 * @type string
+	 */
+	title?: string;
+	/**
+	 * @type string
+	 */
+	token?: string;
+}
+
 export type ConfigVictorOpsConfigDTOCustomFields = { [key: string]: string };
 
 export interface ConfigVictorOpsConfigDTO {
@@ -1646,7 +1759,7 @@ export type AlertmanagertypesPostableChannelDTO = unknown & {
 	/**
 	 * @type array
 	 */
-	discord_configs?: ConfigDiscordConfigDTO[];
+	discord_configs?: AlertmanagertypesDiscordReceiverConfigDTO[];
 	/**
 	 * @type array
 	 */
@@ -1666,7 +1779,7 @@ export type AlertmanagertypesPostableChannelDTO = unknown & {
 	/**
 	 * @type array
 	 */
-	mattermost_configs?: ConfigMattermostConfigDTO[];
+	mattermost_configs?: AlertmanagertypesMattermostReceiverConfigDTO[];
 	/**
 	 * @type array
 	 */
@@ -1706,7 +1819,7 @@ export type AlertmanagertypesPostableChannelDTO = unknown & {
 	/**
 	 * @type array
 	 */
-	telegram_configs?: ConfigTelegramConfigDTO[];
+	telegram_configs?: AlertmanagertypesTelegramReceiverConfigDTO[];
 	/**
 	 * @type array
 	 */
@@ -1773,7 +1886,7 @@ export interface AlertmanagertypesReceiverDTO {
 	/**
 	 * @type array
 	 */
-	discord_configs?: ConfigDiscordConfigDTO[];
+	discord_configs?: AlertmanagertypesDiscordReceiverConfigDTO[];
 	/**
 	 * @type array
 	 */
@@ -1793,7 +1906,7 @@ export interface AlertmanagertypesReceiverDTO {
 	/**
 	 * @type array
 	 */
-	mattermost_configs?: ConfigMattermostConfigDTO[];
+	mattermost_configs?: AlertmanagertypesMattermostReceiverConfigDTO[];
 	/**
 	 * @type array
 	 */
@@ -1833,7 +1946,7 @@ export interface AlertmanagertypesReceiverDTO {
 	/**
 	 * @type array
 	 */
-	telegram_configs?: ConfigTelegramConfigDTO[];
+	telegram_configs?: AlertmanagertypesTelegramReceiverConfigDTO[];
 	/**
 	 * @type array
 	 */
@@ -1852,6 +1965,100 @@ export interface AlertmanagertypesReceiverDTO {
 	wechat_configs?: ConfigWechatConfigDTO[];
 }
 
+export enum AudittypesActionCategoryDTO {
+	access_control = 'access_control',
+	configuration_change = 'configuration_change',
+	data_access = 'data_access',
+	system_event = 'system_event',
+}
+export enum AudittypesAuditExportFormatDTO {
+	csv = 'csv',
+	jsonl = 'jsonl',
+}
+export interface AudittypesGettableAuditEventDTO {
+	/**
+	 * @type string
+	 */
+	action: string;
+	/**
+	 * @type string
+	 */
+	actionCategory: string;
+	/**
+	 * @type string
+	 */
+	body: string;
+	/**
+	 * @type string
+	 */
+	errorCode?: string;
+	/**
+	 * @type string
+	 */
+	errorType?: string;
+	/**
+	 * @type string
+	 */
+	eventName: string;
+	/**
+	 * @type string
+	 */
+	id: string;
+	/**
+	 * @type string
+	 */
+	outcome: string;
+	/**
+	 * @type string
+	 */
+	principalEmail: string;
+	/**
+	 * @type string
+	 */
+	principalId: string;
+	/**
+	 * @type string
+	 */
+	principalType: string;
+	/**
+	 * @type string
+	 */
+	resourceId: string;
+	/**
+	 * @type string
+	 */
+	resourceKind: string;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	timestamp: string;
+	/**
+	 * @type string
+	 */
+	traceId?: string;
+}
+export interface AudittypesGettableAuditEventsDTO {
+	/**
+	 * @type array,null
+	 */
+	events: AudittypesGettableAuditEventDTO[] | null;
+	/**
+	 * @type boolean
+	 */
+	hasMore: boolean;
+}
+export enum AudittypesOutcomeDTO {
+	success = 'success',
+	failure = 'failure',
+}
+export enum AudittypesPrincipalTypeDTO {
+	user = 'user',
+	service_account = 'service_account',
+	system = 'system',
+	anonymous = 'anonymous',
+}
+
 export interface AuthtypesAttributeMappingDTO {
 	/**
 	 * @type string
@@ -3367,6 +3574,7 @@ export enum TelemetrytypesFieldContextDTO {
 	resource = 'resource',
 	attribute = 'attribute',
 	body = 'body',
+	lookup = 'lookup',
 }
 export enum TelemetrytypesFieldDataTypeDTO {
 	string = 'string',
@@ -3494,6 +3702,14 @@ export interface TelemetrytypesTelemetryFieldKeyDTO {
 export enum TelemetrytypesSourceDTO {
 	meter = 'meter',
 }
+export interface Querybuildertypesv5LookupJoinDTO {
+	key?: TelemetrytypesTelemetryFieldKeyDTO;
+	/**
+	 * @type string
+	 */
+	table?: string;
+}
+
 export interface Querybuildertypesv5QueryBuilderQueryGithubComSigNozSignozPkgTypesQuerybuildertypesQuerybuildertypesv5LogAggregationDTO {
 	/**
 	 * @type array
@@ -3526,6 +3742,10 @@ export interface Querybuildertypesv5QueryBuilderQueryGithubComSigNozSignozPkgTyp
 	 */
 	limit?: number;
 	limitBy?: Querybuildertypesv5LimitByDTO;
+	/**
+	 * @type array
+	 */
+	lookups?: Querybuildertypesv5LookupJoinDTO[];
 	/**
 	 * @type string
 	 */
@@ -3549,6 +3769,10 @@ export interface Querybuildertypesv5QueryBuilderQueryGithubComSigNozSignozPkgTyp
 	signal?: TelemetrytypesSignalDTO;
 	source?: TelemetrytypesSourceDTO;
 	stepInterval?: Querybuildertypesv5StepDTO;
+	/**
+	 * @type array
+	 */
+	subQueryFilters?: Querybuildertypesv5SubQueryFilterDTO[];
 }
 
 export interface MetrictypesComparisonSpaceAggregationParamDTO {
@@ -3644,6 +3868,10 @@ export interface Querybuildertypesv5QueryBuilderQueryGithubComSigNozSignozPkgTyp
 	 */
 	limit?: number;
 	limitBy?: Querybuildertypesv5LimitByDTO;
+	/**
+	 * @type array
+	 */
+	lookups?: Querybuildertypesv5LookupJoinDTO[];
 	/**
 	 * @type string
 	 */
@@ -3667,6 +3895,10 @@ export interface Querybuildertypesv5QueryBuilderQueryGithubComSigNozSignozPkgTyp
 	signal?: TelemetrytypesSignalDTO;
 	source?: TelemetrytypesSourceDTO;
 	stepInterval?: Querybuildertypesv5StepDTO;
+	/**
+	 * @type array
+	 */
+	subQueryFilters?: Querybuildertypesv5SubQueryFilterDTO[];
 }
 
 export interface Querybuildertypesv5TraceAggregationDTO {
@@ -3712,6 +3944,10 @@ export interface Querybuildertypesv5QueryBuilderQueryGithubComSigNozSignozPkgTyp
 	 */
 	limit?: number;
 	limitBy?: Querybuildertypesv5LimitByDTO;
+	/**
+	 * @type array
+	 */
+	lookups?: Querybuildertypesv5LookupJoinDTO[];
 	/**
 	 * @type string
 	 */
@@ -3735,6 +3971,10 @@ export interface Querybuildertypesv5QueryBuilderQueryGithubComSigNozSignozPkgTyp
 	signal?: TelemetrytypesSignalDTO;
 	source?: TelemetrytypesSourceDTO;
 	stepInterval?: Querybuildertypesv5StepDTO;
+	/**
+	 * @type array
+	 */
+	subQueryFilters?: Querybuildertypesv5SubQueryFilterDTO[];
 }
 
 export type DashboardtypesBuilderQuerySpecDTO =
@@ -4133,6 +4373,8 @@ export enum DashboardtypesQueryPluginVariantGithubComSigNozSignozPkgTypesQuerybu
 export enum Querybuildertypesv5QueryTypeDTO {
 	builder_query = 'builder_query',
 	builder_formula = 'builder_formula',
+	builder_sub_query = 'builder_sub_query',
+	builder_join = 'builder_join',
 	builder_trace_operator = 'builder_trace_operator',
 	clickhouse_sql = 'clickhouse_sql',
 	promql = 'promql',
@@ -4189,6 +4431,73 @@ export interface Querybuildertypesv5QueryEnvelopeFormulaDTO {
 	type?: Querybuildertypesv5QueryTypeDTO;
 }
 
+export interface Querybuildertypesv5QueryRefDTO {
+	/**
+	 * @type string
+	 */
+	name?: string;
+}
+
+export enum Querybuildertypesv5JoinTypeDTO {
+	inner = 'inner',
+	left = 'left',
+	right = 'right',
+	full = 'full',
+	cross = 'cross',
+}
+export interface Querybuildertypesv5QueryBuilderJoinDTO {
+	/**
+	 * @type array
+	 */
+	aggregations?: unknown[];
+	/**
+	 * @type boolean
+	 */
+	disabled?: boolean;
+	filter?: Querybuildertypesv5FilterDTO;
+	/**
+	 * @type array
+	 */
+	functions?: Querybuildertypesv5FunctionDTO[];
+	/**
+	 * @type array
+	 */
+	groupBy?: Querybuildertypesv5GroupByKeyDTO[];
+	having?: Querybuildertypesv5HavingDTO;
+	left?: Querybuildertypesv5QueryRefDTO;
+	/**
+	 * @type integer
+	 */
+	limit?: number;
+	/**
+	 * @type string
+	 */
+	name?: string;
+	/**
+	 * @type string
+	 */
+	on?: string;
+	/**
+	 * @type array
+	 */
+	order?: Querybuildertypesv5OrderByDTO[];
+	right?: Querybuildertypesv5QueryRefDTO;
+	/**
+	 * @type array
+	 */
+	secondaryAggregations?: Querybuildertypesv5SecondaryAggregationDTO[];
+	/**
+	 * @type array
+	 */
+	selectFields?: TelemetrytypesTelemetryFieldKeyDTO[];
+	type?: Querybuildertypesv5JoinTypeDTO;
+}
+
+export interface Querybuildertypesv5QueryEnvelopeJoinDTO {
+	spec?: Querybuildertypesv5QueryBuilderJoinDTO;
+	type?: Querybuildertypesv5QueryTypeDTO;
+}
+
 export interface Querybuildertypesv5QueryBuilderTraceOperatorDTO {
 	/**
 	 * @type array
@@ -4252,11 +4561,25 @@ export interface Querybuildertypesv5QueryEnvelopeTraceOperatorDTO {
 	type?: Querybuildertypesv5QueryTypeDTO;
 }
 
-export interface Querybuildertypesv5PromQueryDTO {
+export interface Querybuildertypesv5PostableCachePurgeDTO {
 	/**
-	 * @type boolean
+	 * @type integer
+	 * @minimum 0
 	 */
-	disabled?: boolean;
+	end: number;
+	signal: TelemetrytypesSignalDTO;
+	/**
+	 * @type integer
+	 * @minimum 0
+	 */
+	start: number;
+}
+
+export interface Querybuildertypesv5PromQueryDTO {
+	/**
+	 * @type boolean
+	 */
+	disabled?: boolean;
 	/**
 	 * @type string
 	 */
@@ -4322,6 +4645,10 @@ export type Querybuildertypesv5QueryEnvelopeDTO =
 			spec?: unknown;
 			type?: Querybuildertypesv5QueryTypeDTO;
 	  })
+	| (Querybuildertypesv5QueryEnvelopeJoinDTO & {
+			spec?: unknown;
+			type?: Querybuildertypesv5QueryTypeDTO;
+	  })
 	| (Querybuildertypesv5QueryEnvelopeTraceOperatorDTO & {
 			spec?: unknown;
 			type?: Querybuildertypesv5QueryTypeDTO;
@@ -4669,16 +4996,417 @@ export interface DashboardtypesGettableDashboardV2DTO {
 	/**
 	 * @type array,null
 	 */
-	tags: TagtypesPostableTagDTO[] | null;
+	tags: TagtypesPostableTagDTO[] | null;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	updatedAt?: string;
+	/**
+	 * @type string
+	 */
+	updatedBy?: string;
+}
+
+export interface DashboardtypesUnconvertedGrafanaPanelDTO {
+	/**
+	 * @type integer
+	 */
+	id: number;
+	/**
+	 * @type string
+	 */
+	reason: string;
+	/**
+	 * @type string
+	 */
+	title: string;
+	/**
+	 * @type string
+	 */
+	type: string;
+}
+
+export interface DashboardtypesUnconvertedGrafanaVariableDTO {
+	/**
+	 * @type string
+	 */
+	name: string;
+	/**
+	 * @type string
+	 */
+	reason: string;
+	/**
+	 * @type string
+	 */
+	type: string;
+}
+
+export interface DashboardtypesGrafanaImportReportDTO {
+	/**
+	 * @type array,null
+	 */
+	unconvertedPanels: DashboardtypesUnconvertedGrafanaPanelDTO[] | null;
+	/**
+	 * @type array,null
+	 */
+	unconvertedTags: string[] | null;
+	/**
+	 * @type array,null
+	 */
+	unconvertedVariables: DashboardtypesUnconvertedGrafanaVariableDTO[] | null;
+}
+
+export interface DashboardtypesGettableGrafanaDashboardImportDTO {
+	dashboard: DashboardtypesGettableDashboardV2DTO;
+	report: DashboardtypesGrafanaImportReportDTO;
+}
+
+export interface DashboardtypesGrafanaStackingDTO {
+	/**
+	 * @type string
+	 */
+	mode?: string;
+}
+
+export interface DashboardtypesGrafanaThresholdsStyleDTO {
+	/**
+	 * @type string
+	 */
+	mode?: string;
+}
+
+export interface DashboardtypesGrafanaCustomDTO {
+	/**
+	 * @type string
+	 */
+	drawStyle?: string;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	fillOpacity?: number;
+	/**
+	 * @type string
+	 */
+	lineInterpolation?: string;
+	stacking?: DashboardtypesGrafanaStackingDTO;
+	thresholdsStyle?: DashboardtypesGrafanaThresholdsStyleDTO;
+}
+
+export interface DashboardtypesGrafanaDatasourceDTO {
+	/**
+	 * @type string
+	 */
+	type?: string;
+	/**
+	 * @type string
+	 */
+	uid?: string;
+}
+
+export interface DashboardtypesGrafanaThresholdStepDTO {
+	/**
+	 * @type string
+	 */
+	color?: string;
+	/**
+	 * @type number,null
+	 */
+	value?: number | null;
+}
+
+export interface DashboardtypesGrafanaThresholdsDTO {
+	/**
+	 * @type string
+	 */
+	mode?: string;
+	/**
+	 * @type array,null
+	 */
+	steps?: DashboardtypesGrafanaThresholdStepDTO[] | null;
+}
+
+export interface DashboardtypesGrafanaFieldDefaultsDTO {
+	custom?: DashboardtypesGrafanaCustomDTO;
+	/**
+	 * @type integer,null
+	 */
+	decimals?: number | null;
+	/**
+	 * @type number,null
+	 */
+	max?: number | null;
+	/**
+	 * @type number,null
+	 */
+	min?: number | null;
+	thresholds?: DashboardtypesGrafanaThresholdsDTO;
+	/**
+	 * @type string
+	 */
+	unit?: string;
+}
+
+export interface DashboardtypesGrafanaFieldConfigDTO {
+	defaults?: DashboardtypesGrafanaFieldDefaultsDTO;
+}
+
+export interface DashboardtypesGrafanaGridPosDTO {
+	/**
+	 * @type integer
+	 */
+	h?: number;
+	/**
+	 * @type integer
+	 */
+	w?: number;
+	/**
+	 * @type integer
+	 */
+	x?: number;
+	/**
+	 * @type integer
+	 */
+	y?: number;
+}
+
+export interface DashboardtypesGrafanaLegendDTO {
+	/**
+	 * @type string
+	 */
+	placement?: string;
+}
+
+export interface DashboardtypesGrafanaOptionsDTO {
+	/**
+	 * @type string
+	 */
+	colorMode?: string;
+	legend?: DashboardtypesGrafanaLegendDTO;
+}
+
+export interface DashboardtypesGrafanaTargetDTO {
+	datasource?: DashboardtypesGrafanaDatasourceDTO;
+	/**
+	 * @type string
+	 */
+	expr?: string;
+	/**
+	 * @type boolean
+	 */
+	hide?: boolean;
+	/**
+	 * @type string
+	 */
+	interval?: string;
+	/**
+	 * @type string
+	 */
+	legendFormat?: string;
+	/**
+	 * @type string
+	 */
+	refId?: string;
+}
+
+export interface DashboardtypesGrafanaPanelDTO {
+	/**
+	 * @type boolean
+	 */
+	collapsed?: boolean;
+	datasource?: DashboardtypesGrafanaDatasourceDTO;
+	/**
+	 * @type string
+	 */
+	description?: string;
+	fieldConfig?: DashboardtypesGrafanaFieldConfigDTO;
+	gridPos?: DashboardtypesGrafanaGridPosDTO;
+	/**
+	 * @type integer
+	 */
+	id?: number;
+	options?: DashboardtypesGrafanaOptionsDTO;
+	/**
+	 * @type array
+	 */
+	panels?: DashboardtypesGrafanaPanelDTO[];
+	/**
+	 * @type array
+	 */
+	targets?: DashboardtypesGrafanaTargetDTO[];
+	/**
+	 * @type string
+	 */
+	title?: string;
+	/**
+	 * @type string
+	 */
+	type?: string;
+}
+
+export interface DashboardtypesGrafanaVariableCurrentDTO {
+	value?: unknown;
+}
+
+export interface DashboardtypesGrafanaVariableDTO {
+	/**
+	 * @type string
+	 */
+	allValue?: string;
+	current?: DashboardtypesGrafanaVariableCurrentDTO;
+	datasource?: DashboardtypesGrafanaDatasourceDTO;
+	/**
+	 * @type string
+	 */
+	description?: string;
+	/**
+	 * @type integer
+	 */
+	hide?: number;
+	/**
+	 * @type boolean
+	 */
+	includeAll?: boolean;
+	/**
+	 * @type string
+	 */
+	label?: string;
+	/**
+	 * @type boolean
+	 */
+	multi?: boolean;
+	/**
+	 * @type string
+	 */
+	name?: string;
+	/**
+	 * @type string
+	 */
+	query?: string;
+	/**
+	 * @type string
+	 */
+	regex?: string;
+	/**
+	 * @type integer
+	 */
+	sort?: number;
+	/**
+	 * @type string
+	 */
+	type?: string;
+}
+
+export interface DashboardtypesGrafanaTemplatingDTO {
+	/**
+	 * @type array,null
+	 */
+	list?: DashboardtypesGrafanaVariableDTO[] | null;
+}
+
+export interface DashboardtypesGrafanaTimeRangeDTO {
+	/**
+	 * @type string
+	 */
+	from?: string;
+	/**
+	 * @type string
+	 */
+	to?: string;
+}
+
+export interface DashboardtypesGrafanaDashboardDTO {
+	/**
+	 * @type string
+	 */
+	description?: string;
+	/**
+	 * @type array,null
+	 */
+	panels?: DashboardtypesGrafanaPanelDTO[] | null;
+	/**
+	 * @type array
+	 */
+	tags?: string[];
+	templating?: DashboardtypesGrafanaTemplatingDTO;
+	time?: DashboardtypesGrafanaTimeRangeDTO;
+	/**
+	 * @type string
+	 */
+	title: string;
+}
+
+export enum DashboardtypesDashboardRevisionKindDTO {
+	create = 'create',
+	update = 'update',
+	patch = 'patch',
+	restore = 'restore',
+}
+export interface DashboardtypesGettableDashboardRevisionDTO {
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt: string;
+	/**
+	 * @type string
+	 */
+	createdBy: string;
+	/**
+	 * @type string
+	 */
+	dashboardId: string;
+	/**
+	 * @type string
+	 */
+	id: string;
+	kind: DashboardtypesDashboardRevisionKindDTO;
+	/**
+	 * @type integer
+	 */
+	version: number;
+}
+
+export interface DashboardtypesGettableDashboardRevisionDiffDTO {
+	/**
+	 * @type integer
+	 */
+	from: number;
+	/**
+	 * @type array
+	 */
+	ops: DashboardtypesJSONPatchOperationDTO[];
+	/**
+	 * @type integer
+	 */
+	to: number;
+}
+
+export interface DashboardtypesGettableDashboardRevisionWithDataDTO {
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt: string;
+	/**
+	 * @type string
+	 */
+	createdBy: string;
 	/**
 	 * @type string
-	 * @format date-time
 	 */
-	updatedAt?: string;
+	dashboardId: string;
+	data: DashboardtypesStorableDashboardDataDTO;
 	/**
 	 * @type string
 	 */
-	updatedBy?: string;
+	id: string;
+	kind: DashboardtypesDashboardRevisionKindDTO;
+	/**
+	 * @type integer
+	 */
+	version: number;
 }
 
 export interface DashboardtypesGettablePublicDasbhboardDTO {
@@ -4822,6 +5550,92 @@ export enum DashboardtypesVariablePluginKindDTO {
 	'signoz/QueryVariable' = 'signoz/QueryVariable',
 	'signoz/CustomVariable' = 'signoz/CustomVariable',
 }
+export interface ExporttypesExportJobDTO {
+	/**
+	 * @type boolean
+	 */
+	complete: boolean;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt?: string;
+	/**
+	 * @type string
+	 */
+	createdBy?: string;
+	/**
+	 * @type string
+	 */
+	error?: string;
+	/**
+	 * @type string,null
+	 * @format date-time
+	 */
+	expiresAt?: string | null;
+	/**
+	 * @type string,null
+	 * @format date-time
+	 */
+	finishedAt?: string | null;
+	/**
+	 * @type string
+	 */
+	format: string;
+	/**
+	 * @type string
+	 */
+	id: string;
+	/**
+	 * @type string
+	 */
+	orgId: string;
+	request: Querybuildertypesv5QueryRangeRequestDTO;
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	rowCount: number;
+	/**
+	 * @type integer
+	 */
+	rowLimit: number;
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	size: number;
+	/**
+	 * @type string,null
+	 * @format date-time
+	 */
+	startedAt?: string | null;
+	status: ExporttypesExportJobStatusDTO;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	updatedAt?: string;
+	/**
+	 * @type string
+	 */
+	updatedBy?: string;
+}
+
+export enum ExporttypesExportJobStatusDTO {
+	queued = 'queued',
+	running = 'running',
+	succeeded = 'succeeded',
+	failed = 'failed',
+	cancelled = 'cancelled',
+}
+export interface ExporttypesGettableExportJobsDTO {
+	/**
+	 * @type array,null
+	 */
+	jobs: ExporttypesExportJobDTO[] | null;
+}
+
 export type FactoryResponseDTOServicesAnyOf = { [key: string]: string[] };
 
 /**
@@ -6146,45 +6960,276 @@ export interface InframonitoringtypesVolumeRecordDTO {
 	 * @type number
 	 * @format double
 	 */
-	volumeCapacity: number;
+	volumeCapacity: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	volumeInodes: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	volumeInodesFree: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	volumeInodesUsed: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	volumeUsage: number;
+}
+
+export interface InframonitoringtypesVolumesDTO {
+	/**
+	 * @type boolean
+	 */
+	endTimeBeforeRetention: boolean;
+	/**
+	 * @type array
+	 */
+	records: InframonitoringtypesVolumeRecordDTO[];
+	requiredMetricsCheck: InframonitoringtypesRequiredMetricsCheckDTO;
+	/**
+	 * @type integer
+	 */
+	total: number;
+	type: InframonitoringtypesResponseTypeDTO;
+	warning?: Querybuildertypesv5QueryWarnDataDTO;
+}
+
+export interface IngestionruletypesAttributeConditionDTO {
+	/**
+	 * @type boolean
+	 */
+	invert?: boolean;
+	/**
+	 * @type string
+	 */
+	key: string;
+	/**
+	 * @type integer,null
+	 */
+	maxValue?: number | null;
+	/**
+	 * @type integer,null
+	 */
+	minValue?: number | null;
+	/**
+	 * @type boolean
+	 */
+	regex?: boolean;
+	/**
+	 * @type array
+	 */
+	values?: string[];
+}
+
+export interface IngestionruletypesDropFilterDTO {
+	/**
+	 * @type array,null
+	 */
+	conditions: string[] | null;
+	signal: TelemetrytypesSignalDTO;
+}
+
+export type OpamptypesAgentConfigVersionDTOAnyOf = {
+	/**
+	 * @type string
+	 */
+	config?: string;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt?: string;
+	/**
+	 * @type string
+	 */
+	createdBy?: string;
+	/**
+	 * @type string
+	 */
+	createdByName?: string;
+	/**
+	 * @type string
+	 */
+	deployResult?: string;
+	/**
+	 * @type integer
+	 */
+	deploySequence?: number;
+	/**
+	 * @type string
+	 */
+	deployStatus?: string;
+	/**
+	 * @type string
+	 */
+	elementType?: string;
+	/**
+	 * @type string
+	 */
+	id: string;
+	/**
+	 * @type string
+	 */
+	lastHash?: string;
+	/**
+	 * @type string
+	 */
+	orgId?: string;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	updatedAt?: string;
+	/**
+	 * @type string
+	 */
+	updatedBy?: string;
+	/**
+	 * @type integer
+	 */
+	version?: number;
+};
+
+/**
+ * @nullable
+ */
+export type OpamptypesAgentConfigVersionDTO =
+	OpamptypesAgentConfigVersionDTOAnyOf | null;
+
+export enum IngestionruletypesKindDTO {
+	sampling = 'sampling',
+	drop = 'drop',
+}
+export interface IngestionruletypesLatencyConditionDTO {
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	thresholdMs: number;
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	upperThresholdMs?: number;
+}
+
+export enum IngestionruletypesSamplingPolicyTypeDTO {
+	latency = 'latency',
+	status_code = 'status_code',
+	attribute = 'attribute',
+	probabilistic = 'probabilistic',
+}
+export interface IngestionruletypesSamplingPolicyDTO {
+	attribute?: IngestionruletypesAttributeConditionDTO;
+	latency?: IngestionruletypesLatencyConditionDTO;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	samplingPercentage: number;
+	/**
+	 * @type array
+	 */
+	statusCodes?: string[];
+	type: IngestionruletypesSamplingPolicyTypeDTO;
+}
+
+export interface IngestionruletypesIngestionRuleDTO {
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt?: string;
+	/**
+	 * @type string
+	 */
+	createdBy?: string;
+	/**
+	 * @type string
+	 */
+	description?: string;
+	drop?: IngestionruletypesDropFilterDTO;
+	/**
+	 * @type boolean
+	 */
+	enabled: boolean;
+	/**
+	 * @type string
+	 */
+	id: string;
+	kind: IngestionruletypesKindDTO;
+	/**
+	 * @type string
+	 */
+	name: string;
+	/**
+	 * @type string
+	 */
+	orgId: string;
+	sampling?: IngestionruletypesSamplingPolicyDTO;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	updatedAt?: string;
 	/**
-	 * @type number
-	 * @format double
+	 * @type string
 	 */
-	volumeInodes: number;
+	updatedBy?: string;
+}
+
+export interface IngestionruletypesGettableIngestionRulesDTO {
 	/**
-	 * @type number
-	 * @format double
+	 * @type array,null
 	 */
-	volumeInodesFree: number;
+	history: OpamptypesAgentConfigVersionDTO[] | null;
 	/**
-	 * @type number
-	 * @format double
+	 * @type array,null
 	 */
-	volumeInodesUsed: number;
+	rules: IngestionruletypesIngestionRuleDTO[] | null;
+	version: OpamptypesAgentConfigVersionDTO | null;
+}
+
+export interface IngestionruletypesGettableIngestionRulesPreviewDTO {
 	/**
-	 * @type number
-	 * @format double
+	 * @type string
 	 */
-	volumeUsage: number;
+	collectorConfig: string;
 }
 
-export interface InframonitoringtypesVolumesDTO {
+export interface IngestionruletypesPostableIngestionRuleDTO {
+	/**
+	 * @type string
+	 */
+	description?: string;
+	drop?: IngestionruletypesDropFilterDTO;
 	/**
 	 * @type boolean
 	 */
-	endTimeBeforeRetention: boolean;
+	enabled: boolean;
 	/**
-	 * @type array
+	 * @type string
 	 */
-	records: InframonitoringtypesVolumeRecordDTO[];
-	requiredMetricsCheck: InframonitoringtypesRequiredMetricsCheckDTO;
+	name: string;
+	sampling?: IngestionruletypesSamplingPolicyDTO;
+}
+
+export interface IngestionruletypesPostableIngestionRulesPreviewDTO {
 	/**
-	 * @type integer
+	 * @type string
 	 */
-	total: number;
-	type: InframonitoringtypesResponseTypeDTO;
-	warning?: Querybuildertypesv5QueryWarnDataDTO;
+	collectorConfig?: string;
+	/**
+	 * @type array,null
+	 */
+	rules: IngestionruletypesPostableIngestionRuleDTO[] | null;
 }
 
 /**
@@ -6344,6 +7389,199 @@ export interface LlmpricingruletypesUpdatableLLMPricingRulesDTO {
 	rules: LlmpricingruletypesUpdatableLLMPricingRuleDTO[] | null;
 }
 
+export interface LookuptabletypesGettableLookupTablePreviewDTO {
+	/**
+	 * @type array,null
+	 */
+	columns: string[] | null;
+	/**
+	 * @type string
+	 */
+	keyColumn: string;
+	/**
+	 * @type object,null
+	 */
+	matches: LookuptabletypesGettableLookupTablePreviewDTOMatches;
+	/**
+	 * @type integer
+	 */
+	rowCount: number;
+	/**
+	 * @type array,null
+	 */
+	rows: string[][] | null;
+}
+
+/**
+ * @nullable
+ */
+export type LookuptabletypesGettableLookupTablePreviewDTOMatches = {
+	[key: string]: {
+		[key: string]: string;
+	};
+} | null;
+
+export interface LookuptabletypesLookupTableVersionDTO {
+	/**
+	 * @type array,null
+	 */
+	columns: string[] | null;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt: string;
+	/**
+	 * @type string
+	 */
+	createdBy: string;
+	/**
+	 * @type string
+	 */
+	id: string;
+	/**
+	 * @type string
+	 */
+	keyColumn: string;
+	/**
+	 * @type string
+	 */
+	orgId: string;
+	/**
+	 * @type integer
+	 */
+	rowCount: number;
+	/**
+	 * @type array
+	 */
+	rows?: string[][];
+	/**
+	 * @type string
+	 */
+	tableId: string;
+	/**
+	 * @type integer
+	 */
+	version: number;
+}
+
+export interface LookuptabletypesGettableLookupTableVersionsDTO {
+	/**
+	 * @type array,null
+	 */
+	versions: LookuptabletypesLookupTableVersionDTO[] | null;
+}
+
+export interface LookuptabletypesLookupTableDTO {
+	/**
+	 * @type array,null
+	 */
+	columns: string[] | null;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt?: string;
+	/**
+	 * @type string
+	 */
+	createdBy?: string;
+	/**
+	 * @type string
+	 */
+	description?: string;
+	/**
+	 * @type string
+	 */
+	id: string;
+	/**
+	 * @type string
+	 */
+	keyColumn: string;
+	/**
+	 * @type string
+	 */
+	name: string;
+	/**
+	 * @type string
+	 */
+	orgId: string;
+	/**
+	 * @type integer
+	 */
+	rowCount: number;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	updatedAt?: string;
+	/**
+	 * @type string
+	 */
+	updatedBy?: string;
+	/**
+	 * @type integer
+	 */
+	version: number;
+}
+
+export interface LookuptabletypesGettableLookupTablesDTO {
+	/**
+	 * @type array,null
+	 */
+	history: OpamptypesAgentConfigVersionDTO[] | null;
+	/**
+	 * @type array,null
+	 */
+	tables: LookuptabletypesLookupTableDTO[] | null;
+	version: OpamptypesAgentConfigVersionDTO | null;
+}
+
+export interface LookuptabletypesPostableLookupTableDTO {
+	/**
+	 * @type string
+	 */
+	csv: string;
+	/**
+	 * @type string
+	 */
+	description?: string;
+	/**
+	 * @type string
+	 */
+	keyColumn: string;
+	/**
+	 * @type string
+	 */
+	name: string;
+}
+
+export interface LookuptabletypesPostableLookupTablePreviewDTO {
+	/**
+	 * @type string
+	 */
+	csv: string;
+	/**
+	 * @type string
+	 */
+	keyColumn: string;
+	/**
+	 * @type array
+	 */
+	keys?: string[];
+}
+
+export interface LookuptabletypesPostableLookupTableVersionDTO {
+	/**
+	 * @type string
+	 */
+	csv: string;
+	/**
+	 * @type string
+	 */
+	keyColumn: string;
+}
+
 export interface MetricsexplorertypesInspectMetricsRequestDTO {
 	/**
 	 * @type integer
@@ -6397,6 +7635,22 @@ export interface Querybuildertypesv5TimeSeriesValueDTO {
 	values?: number[];
 }
 
+export interface Querybuildertypesv5SubQueryFilterDTO {
+	/**
+	 * @type string
+	 */
+	column?: string;
+	key?: TelemetrytypesTelemetryFieldKeyDTO;
+	/**
+	 * @type boolean
+	 */
+	not?: boolean;
+	/**
+	 * @type string
+	 */
+	query?: string;
+}
+
 export interface Querybuildertypesv5TimeSeriesDTO {
 	/**
 	 * @type array
@@ -7172,6 +8426,17 @@ export enum RuletypesQueryTypeDTO {
 	clickhouse_sql = 'clickhouse_sql',
 	promql = 'promql',
 }
+export interface RuletypesAbsentDataConditionDTO {
+	/**
+	 * @type string
+	 */
+	for: string;
+	/**
+	 * @type string
+	 */
+	lookback: string;
+}
+
 export interface RuletypesAlertCompositeQueryDTO {
 	panelType: RuletypesPanelTypeDTO;
 	/**
@@ -7191,6 +8456,12 @@ export enum RuletypesAlertTypeDTO {
 	LOGS_BASED_ALERT = 'LOGS_BASED_ALERT',
 	EXCEPTIONS_BASED_ALERT = 'EXCEPTIONS_BASED_ALERT',
 }
+export enum RuletypesAnomalyAlgorithmDTO {
+	standard = 'standard',
+	robust = 'robust',
+	holt_winters = 'holt_winters',
+	stl = 'stl',
+}
 export enum RuletypesMatchTypeDTO {
 	at_least_once = 'at_least_once',
 	all_the_times = 'all_the_times',
@@ -7329,6 +8600,17 @@ export interface RuletypesGettableTestRuleDTO {
 	message?: string;
 }
 
+export interface RuletypesRecordingConditionDTO {
+	/**
+	 * @type string
+	 */
+	backfill?: string;
+	/**
+	 * @type string
+	 */
+	metricName: string;
+}
+
 export interface RuletypesRenotifyDTO {
 	/**
 	 * @type array
@@ -7383,7 +8665,34 @@ export interface RuletypesThresholdBasicDTO {
 
 export type RuletypesRuleThresholdDataDTO = RuletypesThresholdBasicDTO;
 
+export interface RuletypesCompositeRuleRefDTO {
+	/**
+	 * @type string
+	 */
+	alias: string;
+	/**
+	 * @type string
+	 */
+	ruleId: string;
+}
+
+export interface RuletypesCompositeConditionDTO {
+	/**
+	 * @type string
+	 */
+	expression: string;
+	/**
+	 * @type array
+	 */
+	matchLabels?: string[];
+	/**
+	 * @type array,null
+	 */
+	rules: RuletypesCompositeRuleRefDTO[] | null;
+}
+
 export interface RuletypesRuleConditionDTO {
+	absentData?: RuletypesAbsentDataConditionDTO;
 	/**
 	 * @type integer
 	 * @minimum 0
@@ -7393,13 +8702,12 @@ export interface RuletypesRuleConditionDTO {
 	 * @type boolean
 	 */
 	alertOnAbsent?: boolean;
-	/**
-	 * @type string
-	 */
-	algorithm?: string;
+	algorithm?: RuletypesAnomalyAlgorithmDTO;
+	composite?: RuletypesCompositeConditionDTO;
 	compositeQuery: RuletypesAlertCompositeQueryDTO;
 	matchType?: RuletypesMatchTypeDTO;
 	op?: RuletypesCompareOperatorDTO;
+	recording?: RuletypesRecordingConditionDTO;
 	/**
 	 * @type boolean
 	 */
@@ -7428,6 +8736,8 @@ export enum RuletypesRuleTypeDTO {
 	threshold_rule = 'threshold_rule',
 	promql_rule = 'promql_rule',
 	anomaly_rule = 'anomaly_rule',
+	composite_rule = 'composite_rule',
+	recording_rule = 'recording_rule',
 }
 export interface RuletypesPostableRuleDTO {
 	/**
@@ -7743,6 +9053,180 @@ export interface ServiceaccounttypesUpdatableFactorAPIKeyDTO {
 	name: string;
 }
 
+export interface SlotypesAlertingDTO {
+	/**
+	 * @type array
+	 */
+	channels?: string[];
+	/**
+	 * @type boolean
+	 */
+	enabled: boolean;
+}
+
+export interface SlotypesBurnRateDTO {
+	/**
+	 * @type number
+	 * @format double
+	 */
+	burnRate: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	good: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	total: number;
+	/**
+	 * @type string
+	 */
+	window: string;
+}
+
+export interface SlotypesGettableBurnRatesDTO {
+	/**
+	 * @type array,null
+	 */
+	burnRates: SlotypesBurnRateDTO[] | null;
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	end: number;
+}
+
+export interface SlotypesGettableErrorBudgetDTO {
+	/**
+	 * @type number
+	 * @format double
+	 */
+	consumed: number;
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	end: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	good: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	remaining: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	sli: number;
+	/**
+	 * @type integer
+	 * @format int64
+	 */
+	start: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	target: number;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	total: number;
+}
+
+export interface SlotypesIndicatorDTO {
+	good: Querybuildertypesv5QueryEnvelopeDTO;
+	total: Querybuildertypesv5QueryEnvelopeDTO;
+}
+
+export interface SlotypesGettableSLOsDTO {
+	/**
+	 * @type array,null
+	 */
+	slos: SlotypesSLODTO[] | null;
+}
+
+export interface SlotypesSLODTO {
+	alerting: SlotypesAlertingDTO;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	createdAt?: string;
+	/**
+	 * @type string
+	 */
+	createdBy?: string;
+	/**
+	 * @type string
+	 */
+	description?: string;
+	/**
+	 * @type string
+	 */
+	id: string;
+	indicator: SlotypesIndicatorDTO;
+	/**
+	 * @type string
+	 */
+	name: string;
+	/**
+	 * @type string
+	 */
+	orgId: string;
+	/**
+	 * @type array,null
+	 */
+	ruleIds: string[] | null;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	target: number;
+	/**
+	 * @type string
+	 * @format date-time
+	 */
+	updatedAt?: string;
+	/**
+	 * @type string
+	 */
+	updatedBy?: string;
+	/**
+	 * @type string
+	 */
+	window: string;
+}
+
+export interface SlotypesPostableSLODTO {
+	alerting: SlotypesAlertingDTO;
+	/**
+	 * @type string
+	 */
+	description?: string;
+	indicator: SlotypesIndicatorDTO;
+	/**
+	 * @type string
+	 */
+	name: string;
+	/**
+	 * @type number
+	 * @format double
+	 */
+	target: number;
+	/**
+	 * @type string
+	 */
+	window: string;
+}
+
 export type SpantypesEventDTOAttributeMap = { [key: string]: unknown };
 
 export interface SpantypesEventDTO {
@@ -8087,7 +9571,24 @@ export interface SpantypesSpanMapperSourceDTO {
 	priority: number;
 }
 
+export interface SpantypesSpanMapperLookupDTO {
+	/**
+	 * @type string
+	 */
+	column: string;
+	context: SpantypesFieldContextDTO;
+	/**
+	 * @type string
+	 */
+	key: string;
+	/**
+	 * @type string
+	 */
+	table: string;
+}
+
 export interface SpantypesSpanMapperConfigDTO {
+	lookup?: SpantypesSpanMapperLookupDTO;
 	/**
 	 * @type array,null
 	 */
@@ -8608,9 +10109,162 @@ export interface ZeustypesPostableProfileDTO {
 
 export type GetAlerts200 = {
 	/**
-	 * @type array
+	 * @type array
+	 */
+	data: AlertmanagertypesDeprecatedGettableAlertDTO[];
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type ListAuditEventsParams = {
+	/**
+	 * @type integer
+	 * @format int64
+	 * @description Start of the time range in epoch milliseconds.
+	 */
+	start: number;
+	/**
+	 * @type integer
+	 * @format int64
+	 * @description End of the time range in epoch milliseconds.
+	 */
+	end: number;
+	/**
+	 * @type string
+	 * @description ID of the user or service account which performed the action.
+	 */
+	principalId?: string;
+	/**
+	 * @type string
+	 * @description Email of the principal which performed the action.
+	 */
+	principalEmail?: string;
+	/**
+	 * @description Type of the principal which performed the action.
+	 */
+	principalType?: AudittypesPrincipalTypeDTO;
+	/**
+	 * @type string
+	 * @description Kind of the resource acted on, for example dashboard.
+	 */
+	resourceKind?: string;
+	/**
+	 * @type string
+	 * @description ID of the resource acted on.
+	 */
+	resourceId?: string;
+	/**
+	 * @description Category of the action.
+	 */
+	actionCategory?: AudittypesActionCategoryDTO;
+	/**
+	 * @description Outcome of the action.
+	 */
+	outcome?: AudittypesOutcomeDTO;
+	/**
+	 * @type integer
+	 * @description Maximum number of events to return, defaults to 100 and cannot exceed 1000.
+	 */
+	limit?: number;
+	/**
+	 * @type integer
+	 * @description Number of events to skip.
+	 */
+	offset?: number;
+};
+
+export type ListAuditEvents200 = {
+	data: AudittypesGettableAuditEventsDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type ExportAuditEventsParams = {
+	/**
+	 * @type integer
+	 * @format int64
+	 * @description Start of the time range in epoch milliseconds.
+	 */
+	start: number;
+	/**
+	 * @type integer
+	 * @format int64
+	 * @description End of the time range in epoch milliseconds.
+	 */
+	end: number;
+	/**
+	 * @type string
+	 * @description ID of the user or service account which performed the action.
+	 */
+	principalId?: string;
+	/**
+	 * @type string
+	 * @description Email of the principal which performed the action.
+	 */
+	principalEmail?: string;
+	/**
+	 * @description Type of the principal which performed the action.
+	 */
+	principalType?: AudittypesPrincipalTypeDTO;
+	/**
+	 * @type string
+	 * @description Kind of the resource acted on, for example dashboard.
+	 */
+	resourceKind?: string;
+	/**
+	 * @type string
+	 * @description ID of the resource acted on.
+	 */
+	resourceId?: string;
+	/**
+	 * @description Category of the action.
+	 */
+	actionCategory?: AudittypesActionCategoryDTO;
+	/**
+	 * @description Outcome of the action.
+	 */
+	outcome?: AudittypesOutcomeDTO;
+	/**
+	 * @description Format of the export, defaults to csv.
+	 */
+	format?: AudittypesAuditExportFormatDTO;
+};
+
+export type GetAuditResourceTimelinePathParameters = {
+	kind: string;
+	id: string;
+};
+export type GetAuditResourceTimelineParams = {
+	/**
+	 * @type integer
+	 * @format int64
+	 * @description Start of the time range in epoch milliseconds.
+	 */
+	start: number;
+	/**
+	 * @type integer
+	 * @format int64
+	 * @description End of the time range in epoch milliseconds.
+	 */
+	end: number;
+	/**
+	 * @type integer
+	 * @description Maximum number of events to return, defaults to 100 and cannot exceed 1000.
 	 */
-	data: AlertmanagertypesDeprecatedGettableAlertDTO[];
+	limit?: number;
+	/**
+	 * @type integer
+	 * @description Number of events to skip.
+	 */
+	offset?: number;
+};
+
+export type GetAuditResourceTimeline200 = {
+	data: AudittypesGettableAuditEventsDTO;
 	/**
 	 * @type string
 	 */
@@ -8969,9 +10623,65 @@ export type GetDowntimeScheduleByID200 = {
 export type UpdateDowntimeScheduleByIDPathParameters = {
 	id: string;
 };
+export type ListExportJobs200 = {
+	data: ExporttypesGettableExportJobsDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type CreateExportJobParams = {
+	/**
+	 * @enum csv,jsonl,parquet,arrow
+	 * @type string
+	 * @description The output format for the export.
+	 */
+	format?: CreateExportJobFormat;
+};
+
+export enum CreateExportJobFormat {
+	csv = 'csv',
+	jsonl = 'jsonl',
+	parquet = 'parquet',
+	arrow = 'arrow',
+}
+export type CreateExportJob202 = {
+	data: ExporttypesExportJobDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type GetExportJobPathParameters = {
+	id: string;
+};
+export type GetExportJob200 = {
+	data: ExporttypesExportJobDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type CancelExportJobPathParameters = {
+	id: string;
+};
+export type CancelExportJob200 = {
+	data: ExporttypesExportJobDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type DownloadExportJobResultPathParameters = {
+	id: string;
+};
 export type HandleExportRawDataPOSTParams = {
 	/**
-	 * @enum csv,jsonl
+	 * @enum csv,jsonl,parquet,arrow
 	 * @type string
 	 * @description The output format for the export.
 	 */
@@ -8981,6 +10691,8 @@ export type HandleExportRawDataPOSTParams = {
 export enum HandleExportRawDataPOSTFormat {
 	csv = 'csv',
 	jsonl = 'jsonl',
+	parquet = 'parquet',
+	arrow = 'arrow',
 }
 export type GetFieldsKeysParams = {
 	/**
@@ -9129,6 +10841,79 @@ export type GetGlobalConfig200 = {
 	status: string;
 };
 
+export type ListIngestionRulesPathParameters = {
+	kind: string;
+};
+export type ListIngestionRules200 = {
+	data: IngestionruletypesGettableIngestionRulesDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type CreateIngestionRulePathParameters = {
+	kind: string;
+};
+export type CreateIngestionRule201 = {
+	data: IngestionruletypesIngestionRuleDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type DeleteIngestionRulePathParameters = {
+	kind: string;
+	id: string;
+};
+export type GetIngestionRulePathParameters = {
+	kind: string;
+	id: string;
+};
+export type GetIngestionRule200 = {
+	data: IngestionruletypesIngestionRuleDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type UpdateIngestionRulePathParameters = {
+	kind: string;
+	id: string;
+};
+export type UpdateIngestionRule200 = {
+	data: IngestionruletypesIngestionRuleDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type PreviewIngestionRulesPathParameters = {
+	kind: string;
+};
+export type PreviewIngestionRules200 = {
+	data: IngestionruletypesGettableIngestionRulesPreviewDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type GetIngestionRulesVersionPathParameters = {
+	kind: string;
+	version: string;
+};
+export type GetIngestionRulesVersion200 = {
+	data: IngestionruletypesGettableIngestionRulesDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
 export type CreateInvite201 = {
 	data: TypesInviteDTO;
 	/**
@@ -9183,6 +10968,78 @@ export type ListPromotedAndIndexedPaths200 = {
 	status: string;
 };
 
+export type ListLookupTables200 = {
+	data: LookuptabletypesGettableLookupTablesDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type CreateLookupTable201 = {
+	data: LookuptabletypesLookupTableDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type DeleteLookupTablePathParameters = {
+	id: string;
+};
+export type GetLookupTablePathParameters = {
+	id: string;
+};
+export type GetLookupTable200 = {
+	data: LookuptabletypesLookupTableDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type ListLookupTableVersionsPathParameters = {
+	id: string;
+};
+export type ListLookupTableVersions200 = {
+	data: LookuptabletypesGettableLookupTableVersionsDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type CreateLookupTableVersionPathParameters = {
+	id: string;
+};
+export type CreateLookupTableVersion201 = {
+	data: LookuptabletypesLookupTableVersionDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type GetLookupTableVersionPathParameters = {
+	id: string;
+	version: string;
+};
+export type GetLookupTableVersion200 = {
+	data: LookuptabletypesLookupTableVersionDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type PreviewLookupTable200 = {
+	data: LookuptabletypesGettableLookupTablePreviewDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
 export type ListOrgPreferences200 = {
 	/**
 	 * @type array
@@ -9436,6 +11293,77 @@ export type GetMyServiceAccount200 = {
 	status: string;
 };
 
+export type ListSLOs200 = {
+	data: SlotypesGettableSLOsDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type CreateSLO201 = {
+	data: SlotypesSLODTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type DeleteSLOPathParameters = {
+	id: string;
+};
+export type GetSLOPathParameters = {
+	id: string;
+};
+export type GetSLO200 = {
+	data: SlotypesSLODTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type UpdateSLOPathParameters = {
+	id: string;
+};
+export type UpdateSLO200 = {
+	data: SlotypesSLODTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type GetSLOBurnRatesPathParameters = {
+	id: string;
+};
+export type GetSLOBurnRatesParams = {
+	/**
+	 * @type string
+	 * @description undefined
+	 */
+	window?: string;
+};
+
+export type GetSLOBurnRates200 = {
+	data: SlotypesGettableBurnRatesDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type GetSLOErrorBudgetPathParameters = {
+	id: string;
+};
+export type GetSLOErrorBudget200 = {
+	data: SlotypesGettableErrorBudgetDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
 export type ListSpanMapperGroupsParams = {
 	/**
 	 * @type boolean,null
@@ -9623,6 +11551,76 @@ export type UnlockDashboardV2PathParameters = {
 export type LockDashboardV2PathParameters = {
 	id: string;
 };
+export type ListDashboardRevisionsV2PathParameters = {
+	id: string;
+};
+export type ListDashboardRevisionsV2200 = {
+	/**
+	 * @type array
+	 */
+	data: DashboardtypesGettableDashboardRevisionDTO[];
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type GetDashboardRevisionV2PathParameters = {
+	id: string;
+	version: string;
+};
+export type GetDashboardRevisionV2200 = {
+	data: DashboardtypesGettableDashboardRevisionWithDataDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type RestoreDashboardRevisionV2PathParameters = {
+	id: string;
+	version: string;
+};
+export type RestoreDashboardRevisionV2200 = {
+	data: DashboardtypesGettableDashboardV2DTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type DiffDashboardRevisionsV2PathParameters = {
+	id: string;
+};
+export type DiffDashboardRevisionsV2Params = {
+	/**
+	 * @type integer
+	 * @description Version of the revision the diff starts from.
+	 */
+	from: number;
+	/**
+	 * @type integer
+	 * @description Version of the revision the diff leads to.
+	 */
+	to: number;
+};
+
+export type DiffDashboardRevisionsV2200 = {
+	data: DashboardtypesGettableDashboardRevisionDiffDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
+export type ImportGrafanaDashboardV2201 = {
+	data: DashboardtypesGettableGrafanaDashboardImportDTO;
+	/**
+	 * @type string
+	 */
+	status: string;
+};
+
 export type GetFeatures200 = {
 	/**
 	 * @type array
@@ -0,0 +1,694 @@
+/**
+ * ! Do not edit manually
+ * * The file has been auto-generated using Orval for SigNoz
+ * * regenerate with 'pnpm generate:api'
+ * SigNoz
+ */
+import { useMutation, useQuery } from 'react-query';
+import type {
+	InvalidateOptions,
+	MutationFunction,
+	QueryClient,
+	QueryFunction,
+	QueryKey,
+	UseMutationOptions,
+	UseMutationResult,
+	UseQueryOptions,
+	UseQueryResult,
+} from 'react-query';
+
+import type {
+	CreateSLO201,
+	DeleteSLOPathParameters,
+	GetSLO200,
+	GetSLOBurnRates200,
+	GetSLOBurnRatesParams,
+	GetSLOBurnRatesPathParameters,
+	GetSLOErrorBudget200,
+	GetSLOErrorBudgetPathParameters,
+	GetSLOPathParameters,
+	ListSLOs200,
+	RenderErrorResponseDTO,
+	SlotypesPostableSLODTO,
+	UpdateSLO200,
+	UpdateSLOPathParameters,
+} from '../sigNoz.schemas';
+
+import { GeneratedAPIInstance } from '../../../generatedAPIInstance';
+import type { ErrorType, BodyType } from '../../../generatedAPIInstance';
+
+/**
+ * Returns all the SLOs of the authenticated org.
+ * @summary List SLOs
+ */
+export const listSLOs = (
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<ListSLOs200>({
+		url: `/api/v1/slos`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getListSLOsQueryKey = () => {
+	return [`/api/v1/slos`] as const;
+};
+
+export const getListSLOsQueryOptions = <
+	TData = Awaited<ReturnType<typeof listSLOs>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listSLOs>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getListSLOsQueryKey();
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof listSLOs>>
+	> = ({ signal }) => listSLOs(signal);
+
+	return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+		Awaited<ReturnType<typeof listSLOs>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type ListSLOsQueryResult = NonNullable<
+	Awaited<ReturnType<typeof listSLOs>>
+>;
+export type ListSLOsQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary List SLOs
+ */
+
+export function useListSLOs<
+	TData = Awaited<ReturnType<typeof listSLOs>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof listSLOs>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getListSLOsQueryOptions(options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary List SLOs
+ */
+export const invalidateListSLOs = async (
+	queryClient: QueryClient,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getListSLOsQueryKey() },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Creates an SLO over a good events query and a total events query. When alerting is enabled, multi-window burn rate alert rules are generated for it: a threshold rule per window and a composite rule per long/short window pair that notifies the given channels.
+ * @summary Create an SLO
+ */
+export const createSLO = (
+	slotypesPostableSLODTO?: BodyType<SlotypesPostableSLODTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<CreateSLO201>({
+		url: `/api/v1/slos`,
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		data: slotypesPostableSLODTO,
+		signal,
+	});
+};
+
+export const getCreateSLOMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createSLO>>,
+		TError,
+		{ data?: BodyType<SlotypesPostableSLODTO> },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof createSLO>>,
+	TError,
+	{ data?: BodyType<SlotypesPostableSLODTO> },
+	TContext
+> => {
+	const mutationKey = ['createSLO'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof createSLO>>,
+		{ data?: BodyType<SlotypesPostableSLODTO> }
+	> = (props) => {
+		const { data } = props ?? {};
+
+		return createSLO(data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type CreateSLOMutationResult = NonNullable<
+	Awaited<ReturnType<typeof createSLO>>
+>;
+export type CreateSLOMutationBody = BodyType<SlotypesPostableSLODTO> | undefined;
+export type CreateSLOMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Create an SLO
+ */
+export const useCreateSLO = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof createSLO>>,
+		TError,
+		{ data?: BodyType<SlotypesPostableSLODTO> },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof createSLO>>,
+	TError,
+	{ data?: BodyType<SlotypesPostableSLODTO> },
+	TContext
+> => {
+	return useMutation(getCreateSLOMutationOptions(options));
+};
+/**
+ * Deletes an SLO along with its burn rate alert rules.
+ * @summary Delete an SLO
+ */
+export const deleteSLO = (
+	{ id }: DeleteSLOPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<void>({
+		url: `/api/v1/slos/${id}`,
+		method: 'DELETE',
+		signal,
+	});
+};
+
+export const getDeleteSLOMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof deleteSLO>>,
+		TError,
+		{ pathParams: DeleteSLOPathParameters },
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof deleteSLO>>,
+	TError,
+	{ pathParams: DeleteSLOPathParameters },
+	TContext
+> => {
+	const mutationKey = ['deleteSLO'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof deleteSLO>>,
+		{ pathParams: DeleteSLOPathParameters }
+	> = (props) => {
+		const { pathParams } = props ?? {};
+
+		return deleteSLO(pathParams);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type DeleteSLOMutationResult = NonNullable<
+	Awaited<ReturnType<typeof deleteSLO>>
+>;
+
+export type DeleteSLOMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Delete an SLO
+ */
+export const useDeleteSLO = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof deleteSLO>>,
+		TError,
+		{ pathParams: DeleteSLOPathParameters },
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof deleteSLO>>,
+	TError,
+	{ pathParams: DeleteSLOPathParameters },
+	TContext
+> => {
+	return useMutation(getDeleteSLOMutationOptions(options));
+};
+/**
+ * Returns a single SLO by ID.
+ * @summary Get an SLO
+ */
+export const getSLO = (
+	{ id }: GetSLOPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetSLO200>({
+		url: `/api/v1/slos/${id}`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetSLOQueryKey = ({ id }: GetSLOPathParameters) => {
+	return [`/api/v1/slos/${id}`] as const;
+};
+
+export const getGetSLOQueryOptions = <
+	TData = Awaited<ReturnType<typeof getSLO>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetSLOPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getSLO>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetSLOQueryKey({ id });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getSLO>>
+	> = ({ signal }) => getSLO({ id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getSLO>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetSLOQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getSLO>>
+>;
+export type GetSLOQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get an SLO
+ */
+
+export function useGetSLO<
+	TData = Awaited<ReturnType<typeof getSLO>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetSLOPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getSLO>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetSLOQueryOptions({ id }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get an SLO
+ */
+export const invalidateGetSLO = async (
+	queryClient: QueryClient,
+	{ id }: GetSLOPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetSLOQueryKey({ id }) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Replaces an SLO and regenerates its burn rate alert rules.
+ * @summary Update an SLO
+ */
+export const updateSLO = (
+	{ id }: UpdateSLOPathParameters,
+	slotypesPostableSLODTO?: BodyType<SlotypesPostableSLODTO>,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<UpdateSLO200>({
+		url: `/api/v1/slos/${id}`,
+		method: 'PUT',
+		headers: { 'Content-Type': 'application/json' },
+		data: slotypesPostableSLODTO,
+		signal,
+	});
+};
+
+export const getUpdateSLOMutationOptions = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof updateSLO>>,
+		TError,
+		{
+			pathParams: UpdateSLOPathParameters;
+			data?: BodyType<SlotypesPostableSLODTO>;
+		},
+		TContext
+	>;
+}): UseMutationOptions<
+	Awaited<ReturnType<typeof updateSLO>>,
+	TError,
+	{
+		pathParams: UpdateSLOPathParameters;
+		data?: BodyType<SlotypesPostableSLODTO>;
+	},
+	TContext
+> => {
+	const mutationKey = ['updateSLO'];
+	const { mutation: mutationOptions } = options
+		? options.mutation &&
+			'mutationKey' in options.mutation &&
+			options.mutation.mutationKey
+			? options
+			: { ...options, mutation: { ...options.mutation, mutationKey } }
+		: { mutation: { mutationKey } };
+
+	const mutationFn: MutationFunction<
+		Awaited<ReturnType<typeof updateSLO>>,
+		{
+			pathParams: UpdateSLOPathParameters;
+			data?: BodyType<SlotypesPostableSLODTO>;
+		}
+	> = (props) => {
+		const { pathParams, data } = props ?? {};
+
+		return updateSLO(pathParams, data);
+	};
+
+	return { mutationFn, ...mutationOptions };
+};
+
+export type UpdateSLOMutationResult = NonNullable<
+	Awaited<ReturnType<typeof updateSLO>>
+>;
+export type UpdateSLOMutationBody = BodyType<SlotypesPostableSLODTO> | undefined;
+export type UpdateSLOMutationError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Update an SLO
+ */
+export const useUpdateSLO = <
+	TError = ErrorType<RenderErrorResponseDTO>,
+	TContext = unknown,
+>(options?: {
+	mutation?: UseMutationOptions<
+		Awaited<ReturnType<typeof updateSLO>>,
+		TError,
+		{
+			pathParams: UpdateSLOPathParameters;
+			data?: BodyType<SlotypesPostableSLODTO>;
+		},
+		TContext
+	>;
+}): UseMutationResult<
+	Awaited<ReturnType<typeof updateSLO>>,
+	TError,
+	{
+		pathParams: UpdateSLOPathParameters;
+		data?: BodyType<SlotypesPostableSLODTO>;
+	},
+	TContext
+> => {
+	return useMutation(getUpdateSLOMutationOptions(options));
+};
+/**
+ * Returns the rate the error budget of an SLO is burning at over windows ending now, where 1 uses up exactly the whole budget over the SLO window. Defaults to the windows of the burn rate alert policy.
+ * @summary Get the burn rates of an SLO
+ */
+export const getSLOBurnRates = (
+	{ id }: GetSLOBurnRatesPathParameters,
+	params?: GetSLOBurnRatesParams,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetSLOBurnRates200>({
+		url: `/api/v1/slos/${id}/burn_rate`,
+		method: 'GET',
+		params,
+		signal,
+	});
+};
+
+export const getGetSLOBurnRatesQueryKey = (
+	{ id }: GetSLOBurnRatesPathParameters,
+	params?: GetSLOBurnRatesParams,
+) => {
+	return [`/api/v1/slos/${id}/burn_rate`, ...(params ? [params] : [])] as const;
+};
+
+export const getGetSLOBurnRatesQueryOptions = <
+	TData = Awaited<ReturnType<typeof getSLOBurnRates>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetSLOBurnRatesPathParameters,
+	params?: GetSLOBurnRatesParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getSLOBurnRates>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetSLOBurnRatesQueryKey({ id }, params);
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getSLOBurnRates>>
+	> = ({ signal }) => getSLOBurnRates({ id }, params, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getSLOBurnRates>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetSLOBurnRatesQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getSLOBurnRates>>
+>;
+export type GetSLOBurnRatesQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get the burn rates of an SLO
+ */
+
+export function useGetSLOBurnRates<
+	TData = Awaited<ReturnType<typeof getSLOBurnRates>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetSLOBurnRatesPathParameters,
+	params?: GetSLOBurnRatesParams,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getSLOBurnRates>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetSLOBurnRatesQueryOptions({ id }, params, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get the burn rates of an SLO
+ */
+export const invalidateGetSLOBurnRates = async (
+	queryClient: QueryClient,
+	{ id }: GetSLOBurnRatesPathParameters,
+	params?: GetSLOBurnRatesParams,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetSLOBurnRatesQueryKey({ id }, params) },
+		options,
+	);
+
+	return queryClient;
+};
+
+/**
+ * Counts the good and total events of an SLO over its window ending now and returns the SLI along with the fraction of the error budget consumed and remaining.
+ * @summary Get the error budget of an SLO
+ */
+export const getSLOErrorBudget = (
+	{ id }: GetSLOErrorBudgetPathParameters,
+	signal?: AbortSignal,
+) => {
+	return GeneratedAPIInstance<GetSLOErrorBudget200>({
+		url: `/api/v1/slos/${id}/error_budget`,
+		method: 'GET',
+		signal,
+	});
+};
+
+export const getGetSLOErrorBudgetQueryKey = ({ id }: GetSLOErrorBudgetPathParameters) => {
+	return [`/api/v1/slos/${id}/error_budget`] as const;
+};
+
+export const getGetSLOErrorBudgetQueryOptions = <
+	TData = Awaited<ReturnType<typeof getSLOErrorBudget>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetSLOErrorBudgetPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getSLOErrorBudget>>,
+			TError,
+			TData
+		>;
+	},
+) => {
+	const { query: queryOptions } = options ?? {};
+
+	const queryKey =
+		queryOptions?.queryKey ?? getGetSLOErrorBudgetQueryKey({ id });
+
+	const queryFn: QueryFunction<
+		Awaited<ReturnType<typeof getSLOErrorBudget>>
+	> = ({ signal }) => getSLOErrorBudget({ id }, signal);
+
+	return {
+		queryKey,
+		queryFn,
+		enabled: !!id,
+		...queryOptions,
+	} as UseQueryOptions<
+		Awaited<ReturnType<typeof getSLOErrorBudget>>,
+		TError,
+		TData
+	> & { queryKey: QueryKey };
+};
+
+export type GetSLOErrorBudgetQueryResult = NonNullable<
+	Awaited<ReturnType<typeof getSLOErrorBudget>>
+>;
+export type GetSLOErrorBudgetQueryError = ErrorType<RenderErrorResponseDTO>;
+
+/**
+ * @summary Get the error budget of an SLO
+ */
+
+export function useGetSLOErrorBudget<
+	TData = Awaited<ReturnType<typeof getSLOErrorBudget>>,
+	TError = ErrorType<RenderErrorResponseDTO>,
+>(
+	{ id }: GetSLOErrorBudgetPathParameters,
+	options?: {
+		query?: UseQueryOptions<
+			Awaited<ReturnType<typeof getSLOErrorBudget>>,
+			TError,
+			TData
+		>;
+	},
+): UseQueryResult<TData, TError> & { queryKey: QueryKey } {
+	const queryOptions = getGetSLOErrorBudgetQueryOptions({ id }, options);
+
+	const query = useQuery(queryOptions) as UseQueryResult<TData, TError> & {
+		queryKey: QueryKey;
+	};
+
+	return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * @summary Get the error budget of an SLO
+ */
+export const invalidateGetSLOErrorBudget = async (
+	queryClient: QueryClient,
+	{ id }: GetSLOErrorBudgetPathParameters,
+	options?: InvalidateOptions,
+): Promise<QueryClient> => {
+	await queryClient.invalidateQueries(
+		{ queryKey: getGetSLOErrorBudgetQueryKey({ id }) },
+		options,
+	);
+
+	return queryClient;
+};
@@ -0,0 +1,65 @@
+import axios from 'api';
+import { ILog } from 'types/api/logs/log';
+import { PipelineData } from 'types/api/pipeline/def';
+
+export interface PipelineDryRunRequest {
+	pipelines: PipelineData[];
+	filter: {
+		expression: string;
+	};
+	start: number;
+	end: number;
+	limit?: number;
+}
+
+export interface PipelineDryRunFieldChange {
+	field: string;
+	before: unknown;
+	after: unknown;
+}
+
+export interface PipelineDryRunOperatorDiff {
+	pipelineId: string;
+	operatorId: string;
+	changes: PipelineDryRunFieldChange[];
+	dropped: boolean;
+}
+
+export interface PipelineDryRunLog {
+	input: ILog;
+	output: ILog | null;
+	dropped: boolean;
+	operators: PipelineDryRunOperatorDiff[];
+}
+
+export interface PipelineDryRunOperatorStats {
+	id: string;
+	name: string;
+	type: string;
+	changedLogs: number;
+	droppedLogs: number;
+}
+
+export interface PipelineDryRunPipelineStats {
+	id: string;
+	name: string;
+	totalLogs: number;
+	matchedLogs: number;
+	matchRate: number;
+	operators: PipelineDryRunOperatorStats[];
+}
+
+export interface PipelineDryRunResponse {
+	logs: PipelineDryRunLog[];
+	pipelines: PipelineDryRunPipelineStats[];
+	collectorLogs: string[];
+}
+
+const dryRunPipelines = async (
+	requestBody: PipelineDryRunRequest,
+): Promise<PipelineDryRunResponse> =>
+	axios
+		.post('/logs/pipelines/dryrun', requestBody)
+		.then((res) => res.data.data);
+
+export default dryRunPipelines;
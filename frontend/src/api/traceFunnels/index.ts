@@ -5,8 +5,11 @@ import {
 	CreateFunnelPayload,
 	CreateFunnelResponse,
 	FunnelData,
+	FunnelSource,
 	FunnelStepData,
+	LatencyOptionsType,
 } from 'types/api/traceFunnels';
+import { TelemetryFieldKey } from 'types/api/v5/queryRange';
 
 const FUNNELS_BASE_PATH = '/trace-funnels';
 
@@ -94,7 +97,7 @@ export const deleteFunnel = async (
 	};
 };
 
-export interface UpdateFunnelStepsPayload {
+export interface UpdateFunnelStepsPayload extends FunnelSource {
 	funnel_id: string;
 	steps: FunnelStepData[];
 	timestamp: number;
@@ -116,7 +119,7 @@ export const updateFunnelSteps = async (
 	};
 };
 
-export interface ValidateFunnelPayload {
+export interface ValidateFunnelPayload extends FunnelSource {
 	start_time: number;
 	end_time: number;
 	steps: FunnelStepData[];
@@ -357,3 +360,50 @@ export const getFunnelStepsOverview = async (
 		payload: response.data,
 	};
 };
+
+export interface FunnelBreakdownPayload extends FunnelSource {
+	start_time: number;
+	end_time: number;
+	group_by?: TelemetryFieldKey[];
+	limit?: number;
+	steps: FunnelStepData[];
+}
+
+export interface FunnelStepStats {
+	step_order: number;
+	traces: number;
+	errored_traces: number;
+	conversion_rate: number;
+	step_conversion_rate: number;
+	avg_conversion_time_ms: number;
+	conversion_time_ms: number;
+	latency_type: LatencyOptionsType;
+}
+
+export interface FunnelBreakdownResponse {
+	status: string;
+	data: {
+		groups: Array<{
+			labels?: Record<string, string>;
+			steps: FunnelStepStats[];
+		}>;
+	};
+}
+
+export const getFunnelBreakdown = async (
+	payload: FunnelBreakdownPayload,
+	signal?: AbortSignal,
+): Promise<SuccessResponse<FunnelBreakdownResponse> | ErrorResponse> => {
+	const response = await axios.post(
+		`${FUNNELS_BASE_PATH}/analytics/breakdown`,
+		payload,
+		{ signal },
+	);
+
+	return {
+		statusCode: 200,
+		error: null,
+		message: '',
+		payload: response.data,
+	};
+};
@@ -67,6 +67,8 @@ export default function DownloadOptionsMenu({
 					<RadioGroup value={exportFormat} onChange={setExportFormat}>
 						<RadioGroupItem value={DownloadFormats.CSV}>csv</RadioGroupItem>
 						<RadioGroupItem value={DownloadFormats.JSONL}>jsonl</RadioGroupItem>
+						<RadioGroupItem value={DownloadFormats.PARQUET}>parquet</RadioGroupItem>
+						<RadioGroupItem value={DownloadFormats.ARROW}>arrow</RadioGroupItem>
 					</RadioGroup>
 				</div>
 
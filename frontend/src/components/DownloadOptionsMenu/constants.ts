@@ -1,6 +1,8 @@
 export const DownloadFormats = {
 	CSV: 'csv',
 	JSONL: 'jsonl',
+	PARQUET: 'parquet',
+	ARROW: 'arrow',
 };
 
 export const DownloadColumnsScopes = {
@@ -210,6 +210,9 @@ function RuleOptions({
 			onChange={onChangeAlgorithm}
 		>
 			<Select.Option value="standard">Standard</Select.Option>
+			<Select.Option value="robust">Robust (median/MAD)</Select.Option>
+			<Select.Option value="holt_winters">Holt-Winters</Select.Option>
+			<Select.Option value="stl">Seasonal decomposition (STL)</Select.Option>
 		</InlineSelect>
 	);
 
@@ -38,6 +38,9 @@ export interface ProcessorData {
 	path_prefix?: string;
 	enable_mapping?: boolean;
 	mapping?: Record<string, string[]>;
+
+	// lookup fields
+	lookup_table?: string;
 }
 
 export interface PipelineData {
@@ -1,4 +1,5 @@
 import { TagFilter } from '../queryBuilder/queryBuilderData';
+import { TelemetryFieldKey } from '../v5/queryRange';
 
 export enum LatencyOptions {
 	P99 = 'p99',
@@ -18,9 +19,20 @@ export interface FunnelStepData {
 	has_errors: boolean;
 	name?: string;
 	description?: string;
+	filter?: { expression: string };
+	max_conversion_time_ms?: number;
+	optional?: boolean;
+	unordered?: boolean;
 }
 
-export interface FunnelData {
+export type FunnelSignal = 'traces' | 'logs';
+
+export interface FunnelSource {
+	signal?: FunnelSignal;
+	correlation_key?: TelemetryFieldKey;
+}
+
+export interface FunnelData extends FunnelSource {
 	funnel_id: string;
 	funnel_name: string;
 	created_at: number;
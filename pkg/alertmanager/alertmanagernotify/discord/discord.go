@@ -0,0 +1,385 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/messagestore"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/ratelimit"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/relatedlinks"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/templating/markdownrenderer"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	Integration = "discord"
+	colorRed    = 0x992D22
+	colorGreen  = 0x2ECC71
+)
+
+// https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	maxTitleLenRunes       = 256
+	maxDescriptionLenRunes = 4096
+	maxContentLenRunes     = 2000
+	maxEmbeds              = 10
+)
+
+// Notifier implements a Notifier for Discord notifications.
+type Notifier struct {
+	conf      *alertmanagertypes.DiscordReceiverConfig
+	tmpl      *template.Template
+	logger    *slog.Logger
+	client    *http.Client
+	retrier   *notify.Retrier
+	templater alertmanagertypes.Templater
+
+	// messages holds the id of the message posted for each alert group, used
+	// to edit it when edit_messages is set.
+	messages *messagestore.Store[string]
+
+	sendJSONFunc func(ctx context.Context, client *http.Client, method, url string, body io.Reader) (*http.Response, error)
+}
+
+// https://discord.com/developers/docs/resources/webhook#execute-webhook
+type webhook struct {
+	Content   string  `json:"content,omitempty"`
+	Embeds    []embed `json:"embeds"`
+	Username  string  `json:"username,omitempty"`
+	AvatarURL string  `json:"avatar_url,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/message#embed-object
+type embed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color"`
+}
+
+// New returns a new Discord notification handler.
+func New(c *alertmanagertypes.DiscordReceiverConfig, t *template.Template, l *slog.Logger, templater alertmanagertypes.Templater, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	if c.WebhookURL == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "webhook_url is required for discord")
+	}
+
+	httpConfig := commoncfg.HTTPClientConfig{}
+	if c.HTTPConfig != nil {
+		httpConfig = *c.HTTPConfig
+	}
+
+	client, err := notify.NewClientWithTracing(httpConfig, Integration, httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		client: client,
+		// https://discord.com/developers/docs/topics/rate-limits
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}, CustomDetailsFunc: errDetails},
+		templater:    templater,
+		messages:     messagestore.New[string](messagestore.DefaultTTL),
+		sendJSONFunc: sendJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	logger := n.logger.With(slog.Any("group_key", key))
+	logger.DebugContext(ctx, "extracted group key")
+
+	var (
+		data     = notify.GetTemplateData(ctx, n.tmpl, as, logger)
+		tmplText = notify.TmplText(n.tmpl, data, &err)
+	)
+
+	embeds, err := n.prepareContent(ctx, as)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "failed to prepare notification content", errors.Attr(err))
+		return false, err
+	}
+
+	msg := webhook{
+		Content:   tmplText(n.conf.Content),
+		Embeds:    embeds,
+		Username:  tmplText(n.conf.Username),
+		AvatarURL: tmplText(n.conf.AvatarURL),
+	}
+	if err != nil {
+		return false, err
+	}
+
+	content, truncated := notify.TruncateInRunes(msg.Content, maxContentLenRunes)
+	if truncated {
+		logger.WarnContext(ctx, "Truncated content", slog.Int("max_runes", maxContentLenRunes))
+	}
+	msg.Content = content
+
+	resolved := !types.Alerts(as...).HasFiring()
+
+	if n.conf.EditMessages {
+		if messageID, ok := n.messages.Get(key); ok {
+			retry, err := n.edit(ctx, messageID, msg)
+			if err == nil && resolved {
+				n.messages.Delete(key)
+			}
+			if err == nil || !errors.Is(err, errMessageNotFound) {
+				return retry, err
+			}
+			logger.InfoContext(ctx, "message to edit was deleted, posting a new one", slog.String("message_id", messageID))
+		}
+	}
+
+	messageID, retry, err := n.post(ctx, msg)
+	if err != nil {
+		return retry, err
+	}
+
+	if n.conf.EditMessages && !resolved && messageID != "" {
+		n.messages.Set(key, messageID)
+	}
+
+	return false, nil
+}
+
+var errMessageNotFound = errors.New(errors.TypeNotFound, errors.CodeNotFound, "discord message not found")
+
+// post executes the webhook and returns the id of the posted message.
+func (n *Notifier) post(ctx context.Context, msg webhook) (string, bool, error) {
+	u, err := n.webhookURL("")
+	if err != nil {
+		return "", false, err
+	}
+	// wait=true makes Discord return the message, whose id is needed to edit it.
+	query := u.Query()
+	query.Set("wait", "true")
+	u.RawQuery = query.Encode()
+
+	resp, retry, err := n.send(ctx, http.MethodPost, u.String(), msg)
+	if err != nil {
+		return "", retry, err
+	}
+	defer notify.Drain(resp)
+
+	var posted struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&posted); err != nil {
+		n.logger.WarnContext(ctx, "failed to decode the posted discord message", errors.Attr(err))
+	}
+
+	return posted.ID, false, nil
+}
+
+// edit replaces the embeds and content of a message posted earlier.
+// https://discord.com/developers/docs/resources/webhook#edit-webhook-message
+func (n *Notifier) edit(ctx context.Context, messageID string, msg webhook) (bool, error) {
+	u, err := n.webhookURL(messageID)
+	if err != nil {
+		return false, err
+	}
+
+	// The author of a message cannot be changed.
+	msg.Username, msg.AvatarURL = "", ""
+
+	resp, retry, err := n.send(ctx, http.MethodPatch, u.String(), msg)
+	if err != nil {
+		return retry, err
+	}
+	notify.Drain(resp)
+
+	return false, nil
+}
+
+// send sends the message, waiting out short rate limits, and checks the
+// response. The returned response is only set when the request succeeded.
+func (n *Notifier) send(ctx context.Context, method, url string, msg webhook) (*http.Response, bool, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return nil, false, err
+	}
+
+	resp, err := ratelimit.Do(ctx, func(ctx context.Context) (*http.Response, error) {
+		return n.sendJSONFunc(ctx, n.client, method, url, bytes.NewReader(buf.Bytes())) //nolint:bodyclose
+	}, retryAfter)
+	if err != nil {
+		return nil, true, notify.RedactURL(err)
+	}
+
+	if method == http.MethodPatch && resp.StatusCode == http.StatusNotFound {
+		notify.Drain(resp)
+		return nil, false, errMessageNotFound
+	}
+
+	retry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		notify.Drain(resp)
+		return nil, retry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return resp, false, nil
+}
+
+// webhookURL returns the URL to execute the webhook, or to edit one of its
+// messages, in the configured thread.
+func (n *Notifier) webhookURL(messageID string) (*url.URL, error) {
+	u, err := url.Parse(n.conf.WebhookURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if messageID != "" {
+		u = u.JoinPath("messages", messageID)
+	}
+
+	if n.conf.ThreadID != "" {
+		query := u.Query()
+		query.Set("thread_id", n.conf.ThreadID)
+		u.RawQuery = query.Encode()
+	}
+
+	return u, nil
+}
+
+// prepareContent expands the alert templates into embeds. The default body is
+// rendered as a single embed; a custom body gets one embed per alert so that
+// each alert carries its own color and related links.
+func (n *Notifier) prepareContent(ctx context.Context, alerts []*types.Alert) ([]embed, error) {
+	customTitle, customBody := alertmanagertemplate.ExtractTemplatesFromAnnotations(alerts)
+	result, err := n.templater.Expand(ctx, alertmanagertypes.ExpandRequest{
+		TitleTemplate:        customTitle,
+		BodyTemplate:         customBody,
+		DefaultTitleTemplate: n.conf.Title,
+		DefaultBodyTemplate:  n.conf.Message,
+	}, alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	title, truncated := notify.TruncateInRunes(result.Title, maxTitleLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "Truncated title", slog.Int("max_runes", maxTitleLenRunes))
+	}
+
+	color := colorRed
+	if !types.Alerts(alerts...).HasFiring() {
+		color = colorGreen
+	}
+
+	if result.IsDefaultBody {
+		description := ""
+		if len(result.Body) > 0 {
+			description = n.truncateDescription(ctx, result.Body[0])
+		}
+		return []embed{{Title: title, Description: description, Color: color}}, nil
+	}
+
+	// The title goes on the first embed only.
+	var embeds []embed
+	for i, body := range result.Body {
+		if body == "" || i >= len(alerts) {
+			continue
+		}
+
+		if len(embeds) == maxEmbeds {
+			n.logger.WarnContext(ctx, "Dropped alerts beyond the embed limit", slog.Int("max_embeds", maxEmbeds))
+			break
+		}
+
+		// Custom bodies are authored in markdown, which Discord understands
+		// except for tables and deep headings.
+		rendered, err := markdownrenderer.RenderDiscordMarkdown(body)
+		if err != nil {
+			return nil, err
+		}
+		if links := relatedlinks.Format(alerts[i], relatedlinks.Markdown); links != "" {
+			rendered += "\n\n" + links
+		}
+
+		e := embed{Description: n.truncateDescription(ctx, rendered), Color: colorRed}
+		if alerts[i].Resolved() {
+			e.Color = colorGreen
+		}
+		if len(embeds) == 0 {
+			e.Title = title
+		}
+		embeds = append(embeds, e)
+	}
+
+	if len(embeds) == 0 {
+		embeds = append(embeds, embed{Title: title, Color: color})
+	}
+
+	return embeds, nil
+}
+
+func (n *Notifier) truncateDescription(ctx context.Context, description string) string {
+	description, truncated := notify.TruncateInRunes(description, maxDescriptionLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "Truncated description", slog.Int("max_runes", maxDescriptionLenRunes))
+	}
+	return description
+}
+
+func sendJSON(ctx context.Context, client *http.Client, method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Content-Type", "application/json")
+
+	return client.Do(req)
+}
+
+// retryAfter reads the delay of a rate limited request from the body of the
+// response, falling back to the Retry-After header.
+// https://discord.com/developers/docs/topics/rate-limits#exceeding-a-rate-limit
+func retryAfter(header http.Header, body []byte) time.Duration {
+	var resp struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if err := json.Unmarshal(body, &resp); err == nil && resp.RetryAfter > 0 {
+		return ratelimit.Seconds(resp.RetryAfter)
+	}
+
+	return ratelimit.RetryAfter(header, body)
+}
+
+// errDetails extracts the error message from a Discord error response.
+func errDetails(_ int, body io.Reader) string {
+	if body == nil {
+		return ""
+	}
+
+	var resp struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil || resp.Message == "" {
+		return ""
+	}
+
+	return resp.Message
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestTemplater(tmpl *template.Template) alertmanagertypes.Templater {
+	return alertmanagertemplate.New(tmpl, slog.New(slog.DiscardHandler))
+}
+
+func newTestNotifier(t *testing.T, u *url.URL, modify func(*alertmanagertypes.DiscordReceiverConfig)) *Notifier {
+	t.Helper()
+
+	conf := alertmanagertypes.DefaultDiscordReceiverConfig
+	conf.WebhookURL = &config.SecretURL{URL: u}
+	conf.HTTPConfig = &commoncfg.HTTPClientConfig{}
+	if modify != nil {
+		modify(&conf)
+	}
+
+	tmpl := test.CreateTmpl(t)
+	notifier, err := New(&conf, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.NoError(t, err)
+	return notifier
+}
+
+func firingAlert(name string) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name), "severity": "critical"},
+			Annotations: model.LabelSet{"summary": "disk is full"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func resolvedAlert(name string) *types.Alert {
+	alert := firingAlert(name)
+	alert.StartsAt = time.Now().Add(-time.Hour)
+	alert.EndsAt = time.Now().Add(-time.Minute)
+	return alert
+}
+
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   webhook
+}
+
+// newTestServer records the requests it receives and answers them with the
+// given status, or with a posted message when the status is 200.
+func newTestServer(t *testing.T, status func(r *http.Request) int) (*httptest.Server, func() []request) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body webhook
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mu.Lock()
+		requests = append(requests, request{method: r.Method, path: r.URL.Path, query: r.URL.Query(), body: body})
+		mu.Unlock()
+
+		code := http.StatusOK
+		if status != nil {
+			code = status(r)
+		}
+		w.WriteHeader(code)
+		if code == http.StatusOK {
+			_, _ = w.Write([]byte(`{"id":"1001","channel_id":"42"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []request {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]request(nil), requests...)
+	}
+}
+
+func TestDiscordRetry(t *testing.T) {
+	u, _ := url.Parse("https://discord.com/api/webhooks/1/token")
+	notifier := newTestNotifier(t, u, nil)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestDiscordNotify(t *testing.T) {
+	srv, requests := newTestServer(t, nil)
+
+	u, _ := url.Parse(srv.URL + "/api/webhooks/1/token")
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.DiscordReceiverConfig) {
+		c.ThreadID = "555"
+		c.Username = "SigNoz"
+	})
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	got := requests()
+	require.Len(t, got, 1)
+	assert.Equal(t, http.MethodPost, got[0].method)
+	assert.Equal(t, "/api/webhooks/1/token", got[0].path)
+	assert.Equal(t, "true", got[0].query.Get("wait"))
+	assert.Equal(t, "555", got[0].query.Get("thread_id"))
+	assert.Equal(t, "SigNoz", got[0].body.Username)
+	require.Len(t, got[0].body.Embeds, 1)
+	assert.Equal(t, "[FIRING:1] DiskFull", got[0].body.Embeds[0].Title)
+	assert.Equal(t, "**Alert:** DiskFull (critical)\n**Summary:** disk is full\n", got[0].body.Embeds[0].Description)
+	assert.Equal(t, colorRed, got[0].body.Embeds[0].Color)
+
+	// Without edit_messages every notification is a new message.
+	_, ok := notifier.messages.Get("1")
+	assert.False(t, ok)
+}
+
+func TestDiscordNotifyEditsMessage(t *testing.T) {
+	srv, requests := newTestServer(t, nil)
+
+	u, _ := url.Parse(srv.URL + "/api/webhooks/1/token")
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.DiscordReceiverConfig) {
+		c.EditMessages = true
+		c.Username = "SigNoz"
+	})
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+
+	_, err = notifier.Notify(ctx, resolvedAlert("DiskFull"))
+	require.NoError(t, err)
+
+	got := requests()
+	require.Len(t, got, 2)
+	assert.Equal(t, http.MethodPost, got[0].method)
+	assert.Equal(t, http.MethodPatch, got[1].method)
+	assert.Equal(t, "/api/webhooks/1/token/messages/1001", got[1].path)
+	assert.Empty(t, got[1].body.Username)
+	require.Len(t, got[1].body.Embeds, 1)
+	assert.Equal(t, colorGreen, got[1].body.Embeds[0].Color)
+
+	// The group is forgotten once it resolves, the next firing posts a new message.
+	_, ok := notifier.messages.Get("1")
+	assert.False(t, ok)
+}
+
+func TestDiscordNotifyPostsWhenEditedMessageIsGone(t *testing.T) {
+	srv, requests := newTestServer(t, func(r *http.Request) int {
+		if r.Method == http.MethodPatch {
+			return http.StatusNotFound
+		}
+		return http.StatusOK
+	})
+
+	u, _ := url.Parse(srv.URL + "/api/webhooks/1/token")
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.DiscordReceiverConfig) { c.EditMessages = true })
+	notifier.messages.Set("1", "999")
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	got := requests()
+	require.Len(t, got, 2)
+	assert.Equal(t, http.MethodPatch, got[0].method)
+	assert.Equal(t, http.MethodPost, got[1].method)
+
+	messageID, ok := notifier.messages.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, "1001", messageID)
+}
+
+func TestDiscordNotifyWaitsOutRateLimit(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		first := attempts == 1
+		mu.Unlock()
+
+		if first {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.01,"global":false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1001"}`))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	notifier := newTestNotifier(t, u, nil)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestDiscordNotifyWithReason(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		statusCode     int
+		body           string
+		retry          bool
+		expectedReason notify.Reason
+		errMsg         string
+	}{
+		{
+			name:           "rate limited beyond the wait",
+			statusCode:     http.StatusTooManyRequests,
+			body:           `{"message":"You are being rate limited.","retry_after":60,"global":true}`,
+			retry:          true,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "You are being rate limited.",
+		},
+		{
+			name:           "bad request",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"code":50035,"message":"Invalid Form Body"}`,
+			retry:          false,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "Invalid Form Body",
+		},
+		{
+			name:           "server error",
+			statusCode:     http.StatusServiceUnavailable,
+			retry:          true,
+			expectedReason: notify.ServerErrorReason,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			u, _ := url.Parse(srv.URL)
+			notifier := newTestNotifier(t, u, nil)
+
+			ctx := notify.WithGroupKey(context.Background(), "1")
+			retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+			require.Equal(t, tc.retry, retry)
+
+			var reasonError *notify.ErrorWithReason
+			require.ErrorAs(t, err, &reasonError)
+			require.Equal(t, tc.expectedReason, reasonError.Reason)
+			if tc.errMsg != "" {
+				require.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestDiscordRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	u.Path = "/api/webhooks/1/" + secret
+	notifier := newTestNotifier(t, u, nil)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
+
+func TestDiscordNewWithoutWebhookURL(t *testing.T) {
+	tmpl := test.CreateTmpl(t)
+	_, err := New(&alertmanagertypes.DiscordReceiverConfig{}, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.Error(t, err)
+}
+
+func TestPrepareContent(t *testing.T) {
+	u, _ := url.Parse("https://discord.com/api/webhooks/1/token")
+	notifier := newTestNotifier(t, u, nil)
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	t.Run("custom template - per-alert embeds", func(t *testing.T) {
+		firing := firingAlert("test1")
+		firing.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom Title"
+		firing.Annotations[ruletypes.AnnotationBodyTemplate] = "# $alertname\n#### is _firing_"
+		firing.Annotations[ruletypes.AnnotationRelatedLogs] = "https://signoz.example.com/logs"
+
+		resolved := resolvedAlert("test2")
+		resolved.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom Title"
+		resolved.Annotations[ruletypes.AnnotationBodyTemplate] = "# $alertname\n#### is _firing_"
+
+		embeds, err := notifier.prepareContent(ctx, []*types.Alert{firing, resolved})
+		require.NoError(t, err)
+		require.Len(t, embeds, 2)
+
+		assert.Equal(t, "Custom Title", embeds[0].Title)
+		assert.Equal(t, "# test1\n\n### is *firing*\n\n[View Related Logs](https://signoz.example.com/logs)", embeds[0].Description)
+		assert.Equal(t, colorRed, embeds[0].Color)
+
+		assert.Empty(t, embeds[1].Title)
+		assert.Equal(t, "# test2\n\n### is *firing*", embeds[1].Description)
+		assert.Equal(t, colorGreen, embeds[1].Color)
+	})
+
+	t.Run("custom template - embed limit", func(t *testing.T) {
+		alerts := make([]*types.Alert, maxEmbeds+2)
+		for i := range alerts {
+			alerts[i] = firingAlert("test")
+			alerts[i].Labels["instance"] = model.LabelValue(string(rune('a' + i)))
+			alerts[i].Annotations[ruletypes.AnnotationBodyTemplate] = "$alertname is firing"
+		}
+
+		embeds, err := notifier.prepareContent(ctx, alerts)
+		require.NoError(t, err)
+		assert.Len(t, embeds, maxEmbeds)
+	})
+
+	t.Run("title with templating errors", func(t *testing.T) {
+		conf := *notifier.conf
+		conf.Title = "{{ "
+		broken := &Notifier{conf: &conf, logger: notifier.logger, templater: notifier.templater}
+
+		_, err := broken.prepareContent(ctx, []*types.Alert{firingAlert("test")})
+		require.Error(t, err)
+	})
+}
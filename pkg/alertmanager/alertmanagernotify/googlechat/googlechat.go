@@ -0,0 +1,281 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package googlechat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/relatedlinks"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/templating/markdownrenderer"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	Integration = "googlechat"
+	cardID      = "signoz-alert"
+	colorRed    = "#D93025"
+	colorGreen  = "#188038"
+)
+
+// https://developers.google.com/workspace/chat/api/reference/rest/v1/cards#CardHeader - no limit is documented,
+// longer titles are cut off by the client.
+const maxTitleLenRunes = 256
+
+// Notifier implements a Notifier for Google Chat notifications.
+type Notifier struct {
+	conf      *alertmanagertypes.GoogleChatReceiverConfig
+	tmpl      *template.Template
+	logger    *slog.Logger
+	client    *http.Client
+	retrier   *notify.Retrier
+	templater alertmanagertypes.Templater
+
+	postJSONFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// message is a Google Chat message with a single card.
+// https://developers.google.com/workspace/chat/api/reference/rest/v1/spaces.messages#Message
+type message struct {
+	CardsV2 []cardWithID `json:"cardsV2"`
+}
+
+type cardWithID struct {
+	CardID string `json:"cardId"`
+	Card   card   `json:"card"`
+}
+
+// https://developers.google.com/workspace/chat/api/reference/rest/v1/cards#Card
+type card struct {
+	Header   header    `json:"header"`
+	Sections []section `json:"sections"`
+}
+
+type header struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type section struct {
+	Widgets []widget `json:"widgets"`
+}
+
+type widget struct {
+	TextParagraph *textParagraph `json:"textParagraph,omitempty"`
+	ButtonList    *buttonList    `json:"buttonList,omitempty"`
+}
+
+type textParagraph struct {
+	Text string `json:"text"`
+}
+
+type buttonList struct {
+	Buttons []button `json:"buttons"`
+}
+
+type button struct {
+	Text    string  `json:"text"`
+	OnClick onClick `json:"onClick"`
+}
+
+type onClick struct {
+	OpenLink openLink `json:"openLink"`
+}
+
+type openLink struct {
+	URL string `json:"url"`
+}
+
+// New returns a new Google Chat notification handler.
+func New(c *alertmanagertypes.GoogleChatReceiverConfig, t *template.Template, l *slog.Logger, templater alertmanagertypes.Templater, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	if c.WebhookURL == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "webhook_url is required for googlechat")
+	}
+
+	httpConfig := commoncfg.HTTPClientConfig{}
+	if c.HTTPConfig != nil {
+		httpConfig = *c.HTTPConfig
+	}
+
+	client, err := notify.NewClientWithTracing(httpConfig, Integration, httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		client: client,
+		// https://developers.google.com/workspace/chat/limits - incoming webhooks are rate limited per space.
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}, CustomDetailsFunc: errDetails},
+		templater:    templater,
+		postJSONFunc: notify.PostJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	n.logger.DebugContext(ctx, "extracted group key", slog.String("key", string(key)))
+
+	card, err := n.prepareContent(ctx, as)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "failed to prepare notification content", errors.Attr(err))
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(message{CardsV2: []cardWithID{{CardID: cardID, Card: card}}}); err != nil {
+		return false, err
+	}
+
+	resp, err := n.postJSONFunc(ctx, n.client, n.conf.WebhookURL.String(), &buf) //nolint:bodyclose
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp)
+
+	retry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return retry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return retry, nil
+}
+
+// prepareContent expands the alert templates into a card. The default body is
+// rendered as a single section; a custom body gets one section per alert so
+// that each alert can carry its own color and related-link buttons.
+func (n *Notifier) prepareContent(ctx context.Context, alerts []*types.Alert) (card, error) {
+	customTitle, customBody := alertmanagertemplate.ExtractTemplatesFromAnnotations(alerts)
+	result, err := n.templater.Expand(ctx, alertmanagertypes.ExpandRequest{
+		TitleTemplate:        customTitle,
+		BodyTemplate:         customBody,
+		DefaultTitleTemplate: n.conf.Title,
+		DefaultBodyTemplate:  n.conf.Text,
+	}, alerts)
+	if err != nil {
+		return card{}, err
+	}
+
+	title, truncated := notify.TruncateInRunes(result.Title, maxTitleLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "Truncated title", slog.Int("max_runes", maxTitleLenRunes))
+	}
+
+	c := card{Header: header{Title: title, Subtitle: subtitle(alerts)}}
+
+	if result.IsDefaultBody {
+		if len(result.Body) > 0 && result.Body[0] != "" {
+			c.Sections = append(c.Sections, section{Widgets: []widget{{TextParagraph: &textParagraph{Text: formatText(result.Body[0])}}}})
+		}
+		return c, nil
+	}
+
+	for i, body := range result.Body {
+		if body == "" || i >= len(alerts) {
+			continue
+		}
+
+		// Custom bodies are authored in markdown. Google Chat text follows the
+		// same conventions as Slack's mrkdwn, which formatText turns into the
+		// HTML subset supported by cards.
+		rendered, err := markdownrenderer.RenderSlackMrkdwn(body)
+		if err != nil {
+			return card{}, err
+		}
+
+		color := colorRed
+		if alerts[i].Resolved() {
+			color = colorGreen
+		}
+
+		widgets := []widget{{TextParagraph: &textParagraph{Text: `<font color="` + color + `">●</font> ` + formatText(rendered)}}}
+		if links := relatedlinks.Links(alerts[i]); len(links) > 0 {
+			buttons := make([]button, 0, len(links))
+			for _, link := range links {
+				buttons = append(buttons, button{Text: link.Text, OnClick: onClick{OpenLink: openLink{URL: link.URL}}})
+			}
+			widgets = append(widgets, widget{ButtonList: &buttonList{Buttons: buttons}})
+		}
+		c.Sections = append(c.Sections, section{Widgets: widgets})
+	}
+
+	return c, nil
+}
+
+// subtitle summarizes the number of firing and resolved alerts.
+func subtitle(alerts []*types.Alert) string {
+	firing := 0
+	for _, alert := range alerts {
+		if !alert.Resolved() {
+			firing++
+		}
+	}
+
+	switch {
+	case firing == 0:
+		return "Resolved: " + strconv.Itoa(len(alerts))
+	case firing == len(alerts):
+		return "Firing: " + strconv.Itoa(firing)
+	default:
+		return "Firing: " + strconv.Itoa(firing) + ", Resolved: " + strconv.Itoa(len(alerts)-firing)
+	}
+}
+
+var (
+	linkRegex   = regexp.MustCompile(`&lt;((?:https?|mailto):[^|\s]+?)\|(.+?)&gt;`)
+	boldRegex   = regexp.MustCompile(`\*([^*\n]+)\*`)
+	italicRegex = regexp.MustCompile(`(^|[\s(])_([^_\n]+)_($|[\s).,:;!?])`)
+	strikeRegex = regexp.MustCompile(`~([^~\n]+)~`)
+)
+
+// formatText converts Google Chat text formatting (*bold*, _italic_, ~strike~
+// and <url|label> links) into the HTML subset supported by card text widgets.
+// https://developers.google.com/workspace/chat/format-messages#card-formatting
+func formatText(text string) string {
+	text = html.EscapeString(strings.TrimSpace(text))
+	text = linkRegex.ReplaceAllString(text, `<a href="$1">$2</a>`)
+	text = boldRegex.ReplaceAllString(text, `<b>$1</b>`)
+	text = italicRegex.ReplaceAllString(text, `$1<i>$2</i>$3`)
+	text = strikeRegex.ReplaceAllString(text, `<s>$1</s>`)
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// errDetails extracts the error message from a Google Chat error response.
+func errDetails(_ int, body io.Reader) string {
+	if body == nil {
+		return ""
+	}
+
+	var resp struct {
+		Error struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil || resp.Error.Message == "" {
+		return ""
+	}
+
+	return resp.Error.Status + ": " + resp.Error.Message
+}
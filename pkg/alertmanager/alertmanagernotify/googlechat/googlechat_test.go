@@ -0,0 +1,236 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package googlechat
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestTemplater(tmpl *template.Template) alertmanagertypes.Templater {
+	return alertmanagertemplate.New(tmpl, slog.New(slog.DiscardHandler))
+}
+
+func newTestNotifier(t *testing.T, u *url.URL) *Notifier {
+	t.Helper()
+
+	conf := alertmanagertypes.DefaultGoogleChatReceiverConfig
+	conf.WebhookURL = &config.SecretURL{URL: u}
+	conf.HTTPConfig = &commoncfg.HTTPClientConfig{}
+
+	tmpl := test.CreateTmpl(t)
+	notifier, err := New(&conf, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.NoError(t, err)
+	return notifier
+}
+
+func firingAlert(name string) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name), "severity": "critical"},
+			Annotations: model.LabelSet{"summary": "disk is full"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestGoogleChatRetry(t *testing.T) {
+	u, _ := url.Parse("https://chat.googleapis.com/v1/spaces/test/messages")
+	notifier := newTestNotifier(t, u)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestGoogleChatNotify(t *testing.T) {
+	var got message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key=secret", r.URL.RawQuery)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"name":"spaces/test/messages/1"}`))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL + "?key=secret")
+	notifier := newTestNotifier(t, u)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	require.Len(t, got.CardsV2, 1)
+	assert.Equal(t, cardID, got.CardsV2[0].CardID)
+	assert.Equal(t, "[FIRING:1] DiskFull", got.CardsV2[0].Card.Header.Title)
+	assert.Equal(t, "Firing: 1", got.CardsV2[0].Card.Header.Subtitle)
+	require.Len(t, got.CardsV2[0].Card.Sections, 1)
+	require.Len(t, got.CardsV2[0].Card.Sections[0].Widgets, 1)
+	assert.Equal(t,
+		"<b>Alert:</b> DiskFull (critical)<br><b>Summary:</b> disk is full",
+		got.CardsV2[0].Card.Sections[0].Widgets[0].TextParagraph.Text,
+	)
+}
+
+func TestGoogleChatNotifyWithReason(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		statusCode     int
+		body           string
+		retry          bool
+		expectedReason notify.Reason
+		errMsg         string
+	}{
+		{
+			name:           "rate limited",
+			statusCode:     http.StatusTooManyRequests,
+			body:           `{"error":{"code":429,"message":"Resource has been exhausted","status":"RESOURCE_EXHAUSTED"}}`,
+			retry:          true,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "RESOURCE_EXHAUSTED: Resource has been exhausted",
+		},
+		{
+			name:           "bad request",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"error":{"code":400,"message":"Invalid JSON payload","status":"INVALID_ARGUMENT"}}`,
+			retry:          false,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "INVALID_ARGUMENT: Invalid JSON payload",
+		},
+		{
+			name:           "server error",
+			statusCode:     http.StatusServiceUnavailable,
+			retry:          true,
+			expectedReason: notify.ServerErrorReason,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			u, _ := url.Parse(srv.URL)
+			notifier := newTestNotifier(t, u)
+
+			ctx := notify.WithGroupKey(context.Background(), "1")
+			retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+			require.Equal(t, tc.retry, retry)
+
+			var reasonError *notify.ErrorWithReason
+			require.ErrorAs(t, err, &reasonError)
+			require.Equal(t, tc.expectedReason, reasonError.Reason)
+			if tc.errMsg != "" {
+				require.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestGoogleChatRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	u.RawQuery = "key=" + secret
+	notifier := newTestNotifier(t, u)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
+
+func TestGoogleChatNewWithoutWebhookURL(t *testing.T) {
+	tmpl := test.CreateTmpl(t)
+	_, err := New(&alertmanagertypes.GoogleChatReceiverConfig{}, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.Error(t, err)
+}
+
+func TestPrepareContent(t *testing.T) {
+	u, _ := url.Parse("https://chat.googleapis.com/v1/spaces/test/messages")
+	notifier := newTestNotifier(t, u)
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	t.Run("custom template - per-alert sections", func(t *testing.T) {
+		firing := firingAlert("test1")
+		firing.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom Title"
+		firing.Annotations[ruletypes.AnnotationBodyTemplate] = "**$alertname** is firing"
+		firing.Annotations[ruletypes.AnnotationRelatedLogs] = "https://signoz.example.com/logs"
+
+		resolved := firingAlert("test2")
+		resolved.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom Title"
+		resolved.Annotations[ruletypes.AnnotationBodyTemplate] = "**$alertname** is firing"
+		resolved.StartsAt = time.Now().Add(-time.Hour)
+		resolved.EndsAt = time.Now().Add(-time.Minute)
+
+		card, err := notifier.prepareContent(ctx, []*types.Alert{firing, resolved})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Custom Title", card.Header.Title)
+		assert.Equal(t, "Firing: 1, Resolved: 1", card.Header.Subtitle)
+		require.Len(t, card.Sections, 2)
+
+		require.Len(t, card.Sections[0].Widgets, 2)
+		assert.Equal(t, `<font color="`+colorRed+`">●</font> <b>test1</b> is firing`, card.Sections[0].Widgets[0].TextParagraph.Text)
+		require.Len(t, card.Sections[0].Widgets[1].ButtonList.Buttons, 1)
+		assert.Equal(t, "View Related Logs", card.Sections[0].Widgets[1].ButtonList.Buttons[0].Text)
+		assert.Equal(t, "https://signoz.example.com/logs", card.Sections[0].Widgets[1].ButtonList.Buttons[0].OnClick.OpenLink.URL)
+
+		require.Len(t, card.Sections[1].Widgets, 1)
+		assert.Equal(t, `<font color="`+colorGreen+`">●</font> <b>test2</b> is firing`, card.Sections[1].Widgets[0].TextParagraph.Text)
+	})
+
+	t.Run("title with templating errors", func(t *testing.T) {
+		conf := *notifier.conf
+		conf.Title = "{{ "
+		broken := &Notifier{conf: &conf, logger: notifier.logger, templater: notifier.templater}
+
+		_, err := broken.prepareContent(ctx, []*types.Alert{firingAlert("test")})
+		require.Error(t, err)
+	})
+}
+
+func TestFormatText(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain", input: "disk is full", expected: "disk is full"},
+		{name: "bold", input: "*Alert:* high latency", expected: "<b>Alert:</b> high latency"},
+		{name: "italic", input: "a _slow_ query", expected: "a <i>slow</i> query"},
+		{name: "underscore in a word", input: "high_latency_alert", expected: "high_latency_alert"},
+		{name: "strike", input: "~old~ new", expected: "<s>old</s> new"},
+		{name: "link", input: "see <https://signoz.io/a?b=1&c=2|the docs>", expected: `see <a href="https://signoz.io/a?b=1&amp;c=2">the docs</a>`},
+		{name: "html is escaped", input: "<script>x</script>", expected: "&lt;script&gt;x&lt;/script&gt;"},
+		{name: "newlines", input: "line1\nline2\n", expected: "line1<br>line2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, formatText(tc.input))
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/ratelimit"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/relatedlinks"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	Integration = "mattermost"
+	colorRed    = "#D93025"
+	colorGreen  = "#188038"
+)
+
+// https://developers.mattermost.com/integrate/reference/message-attachments/ - posts
+// are limited to 16383 characters, shared by all attachments.
+const (
+	maxTitleLenRunes = 256
+	maxTextLenRunes  = 16383
+)
+
+// Notifier implements a Notifier for Mattermost notifications.
+//
+// Incoming webhooks can neither reply to nor edit a post, so every
+// notification is a new post.
+type Notifier struct {
+	conf      *alertmanagertypes.MattermostReceiverConfig
+	tmpl      *template.Template
+	logger    *slog.Logger
+	client    *http.Client
+	retrier   *notify.Retrier
+	templater alertmanagertypes.Templater
+
+	postJSONFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// https://developers.mattermost.com/integrate/webhooks/incoming/#parameters
+type request struct {
+	Channel     string       `json:"channel,omitempty"`
+	Username    string       `json:"username,omitempty"`
+	IconURL     string       `json:"icon_url,omitempty"`
+	IconEmoji   string       `json:"icon_emoji,omitempty"`
+	Attachments []attachment `json:"attachments"`
+}
+
+// https://developers.mattermost.com/integrate/reference/message-attachments/
+type attachment struct {
+	Fallback string `json:"fallback"`
+	Color    string `json:"color"`
+	Title    string `json:"title,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// New returns a new Mattermost notification handler.
+func New(c *alertmanagertypes.MattermostReceiverConfig, t *template.Template, l *slog.Logger, templater alertmanagertypes.Templater, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	if c.WebhookURL == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "webhook_url is required for mattermost")
+	}
+
+	httpConfig := commoncfg.HTTPClientConfig{}
+	if c.HTTPConfig != nil {
+		httpConfig = *c.HTTPConfig
+	}
+
+	client, err := notify.NewClientWithTracing(httpConfig, Integration, httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		client: client,
+		// https://docs.mattermost.com/configure/rate-limiting-configuration-settings.html
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}, CustomDetailsFunc: errDetails},
+		templater:    templater,
+		postJSONFunc: notify.PostJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	logger := n.logger.With(slog.Any("group_key", key))
+	logger.DebugContext(ctx, "extracted group key")
+
+	var (
+		data     = notify.GetTemplateData(ctx, n.tmpl, as, logger)
+		tmplText = notify.TmplText(n.tmpl, data, &err)
+	)
+
+	attachments, err := n.prepareContent(ctx, as)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "failed to prepare notification content", errors.Attr(err))
+		return false, err
+	}
+
+	req := request{
+		Channel:     tmplText(n.conf.Channel),
+		Username:    tmplText(n.conf.Username),
+		IconURL:     tmplText(n.conf.IconURL),
+		IconEmoji:   tmplText(n.conf.IconEmoji),
+		Attachments: attachments,
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(req); err != nil {
+		return false, err
+	}
+
+	resp, err := ratelimit.Do(ctx, func(ctx context.Context) (*http.Response, error) {
+		return n.postJSONFunc(ctx, n.client, n.conf.WebhookURL.String(), bytes.NewReader(buf.Bytes())) //nolint:bodyclose
+	}, ratelimit.RetryAfter)
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp)
+
+	retry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return retry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return retry, nil
+}
+
+// prepareContent expands the alert templates into attachments. The default
+// body is rendered as a single attachment; a custom body gets one attachment
+// per alert so that each alert carries its own color and related links.
+// Mattermost renders markdown natively, so bodies are sent as they are.
+func (n *Notifier) prepareContent(ctx context.Context, alerts []*types.Alert) ([]attachment, error) {
+	customTitle, customBody := alertmanagertemplate.ExtractTemplatesFromAnnotations(alerts)
+	result, err := n.templater.Expand(ctx, alertmanagertypes.ExpandRequest{
+		TitleTemplate:        customTitle,
+		BodyTemplate:         customBody,
+		DefaultTitleTemplate: n.conf.Title,
+		DefaultBodyTemplate:  n.conf.Text,
+	}, alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	title, truncated := notify.TruncateInRunes(result.Title, maxTitleLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "Truncated title", slog.Int("max_runes", maxTitleLenRunes))
+	}
+
+	if result.IsDefaultBody {
+		color := colorRed
+		if !types.Alerts(alerts...).HasFiring() {
+			color = colorGreen
+		}
+
+		text := ""
+		if len(result.Body) > 0 {
+			text = strings.TrimSpace(result.Body[0])
+		}
+		return []attachment{{Fallback: title, Color: color, Title: title, Text: n.truncateText(ctx, text)}}, nil
+	}
+
+	// The title goes on the first attachment only.
+	var attachments []attachment
+	for i, body := range result.Body {
+		if body == "" || i >= len(alerts) {
+			continue
+		}
+
+		text := strings.TrimSpace(body)
+		if links := relatedlinks.Format(alerts[i], relatedlinks.Markdown); links != "" {
+			text += "\n\n" + links
+		}
+
+		a := attachment{Fallback: title, Color: colorRed, Text: n.truncateText(ctx, text)}
+		if alerts[i].Resolved() {
+			a.Color = colorGreen
+		}
+		if len(attachments) == 0 {
+			a.Title = title
+		}
+		attachments = append(attachments, a)
+	}
+
+	return attachments, nil
+}
+
+func (n *Notifier) truncateText(ctx context.Context, text string) string {
+	text, truncated := notify.TruncateInRunes(text, maxTextLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "Truncated text", slog.Int("max_runes", maxTextLenRunes))
+	}
+	return text
+}
+
+// errDetails extracts the error message from a Mattermost error response.
+func errDetails(_ int, body io.Reader) string {
+	if body == nil {
+		return ""
+	}
+
+	var resp struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil || resp.Message == "" {
+		return ""
+	}
+
+	return resp.Message
+}
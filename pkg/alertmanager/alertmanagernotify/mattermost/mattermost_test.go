@@ -0,0 +1,219 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestTemplater(tmpl *template.Template) alertmanagertypes.Templater {
+	return alertmanagertemplate.New(tmpl, slog.New(slog.DiscardHandler))
+}
+
+func newTestNotifier(t *testing.T, u *url.URL) *Notifier {
+	t.Helper()
+
+	conf := alertmanagertypes.DefaultMattermostReceiverConfig
+	conf.WebhookURL = &config.SecretURL{URL: u}
+	conf.HTTPConfig = &commoncfg.HTTPClientConfig{}
+	conf.Channel = "alerts"
+
+	tmpl := test.CreateTmpl(t)
+	notifier, err := New(&conf, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.NoError(t, err)
+	return notifier
+}
+
+func firingAlert(name string) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name), "severity": "critical"},
+			Annotations: model.LabelSet{"summary": "disk is full"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestMattermostRetry(t *testing.T) {
+	u, _ := url.Parse("https://mattermost.example.com/hooks/test")
+	notifier := newTestNotifier(t, u)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestMattermostNotify(t *testing.T) {
+	var got request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL + "/hooks/test")
+	notifier := newTestNotifier(t, u)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	assert.Equal(t, "alerts", got.Channel)
+	require.Len(t, got.Attachments, 1)
+	assert.Equal(t, "[FIRING:1] DiskFull", got.Attachments[0].Title)
+	assert.Equal(t, "[FIRING:1] DiskFull", got.Attachments[0].Fallback)
+	assert.Equal(t, colorRed, got.Attachments[0].Color)
+	assert.Equal(t, "**Alert:** DiskFull (critical)\n**Summary:** disk is full", got.Attachments[0].Text)
+}
+
+func TestMattermostNotifyWaitsOutRateLimit(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	notifier := newTestNotifier(t, u)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestMattermostNotifyWithReason(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		statusCode     int
+		body           string
+		retry          bool
+		expectedReason notify.Reason
+		errMsg         string
+	}{
+		{
+			name:           "rate limited",
+			statusCode:     http.StatusTooManyRequests,
+			body:           `{"id":"api.context.rate_limit","message":"Too many requests"}`,
+			retry:          true,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "Too many requests",
+		},
+		{
+			name:           "bad request",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"id":"web.incoming_webhook.invalid.app_error","message":"Invalid webhook."}`,
+			retry:          false,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "Invalid webhook.",
+		},
+		{
+			name:           "server error",
+			statusCode:     http.StatusInternalServerError,
+			retry:          true,
+			expectedReason: notify.ServerErrorReason,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			u, _ := url.Parse(srv.URL)
+			notifier := newTestNotifier(t, u)
+
+			ctx := notify.WithGroupKey(context.Background(), "1")
+			retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+			require.Equal(t, tc.retry, retry)
+
+			var reasonError *notify.ErrorWithReason
+			require.ErrorAs(t, err, &reasonError)
+			require.Equal(t, tc.expectedReason, reasonError.Reason)
+			if tc.errMsg != "" {
+				require.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestMattermostRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	u.Path = "/hooks/" + secret
+	notifier := newTestNotifier(t, u)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
+
+func TestMattermostNewWithoutWebhookURL(t *testing.T) {
+	tmpl := test.CreateTmpl(t)
+	_, err := New(&alertmanagertypes.MattermostReceiverConfig{}, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.Error(t, err)
+}
+
+func TestPrepareContent(t *testing.T) {
+	u, _ := url.Parse("https://mattermost.example.com/hooks/test")
+	notifier := newTestNotifier(t, u)
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	firing := firingAlert("test1")
+	firing.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom Title"
+	firing.Annotations[ruletypes.AnnotationBodyTemplate] = "**$alertname** is firing"
+	firing.Annotations[ruletypes.AnnotationRelatedTraces] = "https://signoz.example.com/traces"
+
+	resolved := firingAlert("test2")
+	resolved.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom Title"
+	resolved.Annotations[ruletypes.AnnotationBodyTemplate] = "**$alertname** is firing"
+	resolved.StartsAt = time.Now().Add(-time.Hour)
+	resolved.EndsAt = time.Now().Add(-time.Minute)
+
+	attachments, err := notifier.prepareContent(ctx, []*types.Alert{firing, resolved})
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+
+	assert.Equal(t, "Custom Title", attachments[0].Title)
+	assert.Equal(t, colorRed, attachments[0].Color)
+	assert.Equal(t, "**test1** is firing\n\n[View Related Traces](https://signoz.example.com/traces)", attachments[0].Text)
+
+	assert.Empty(t, attachments[1].Title)
+	assert.Equal(t, colorGreen, attachments[1].Color)
+	assert.Equal(t, "**test2** is firing", attachments[1].Text)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package messagestore remembers the chat message sent for each alert group,
+// so that later notifications of the group can edit or reply to it.
+//
+// The store lives in memory with its notifier. A restart or a change to the
+// receiver starts the groups over with a new message.
+package messagestore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+)
+
+// DefaultTTL is how long a message is remembered after the last notification
+// of its group.
+const DefaultTTL = 24 * time.Hour
+
+type entry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// Store maps alert groups to the messages sent for them.
+type Store[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[notify.Key]entry[T]
+	now     func() time.Time
+}
+
+// New returns a store whose entries expire ttl after they were last set.
+func New[T any](ttl time.Duration) *Store[T] {
+	return &Store[T]{
+		ttl:     ttl,
+		entries: make(map[notify.Key]entry[T]),
+		now:     time.Now,
+	}
+}
+
+// Get returns the message of the group, if one was sent and has not expired.
+func (s *Store[T]) Get(key notify.Key) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || s.now().After(e.expiresAt) {
+		var zero T
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+// Set remembers the message of the group and drops the expired entries.
+func (s *Store[T]) Set(key notify.Key, value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, e := range s.entries {
+		if now.After(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = entry[T]{value: value, expiresAt: now.Add(s.ttl)}
+}
+
+// Delete forgets the message of the group.
+func (s *Store[T]) Delete(key notify.Key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package messagestore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	store := New[string](time.Hour)
+	store.now = func() time.Time { return now }
+
+	_, ok := store.Get("group")
+	assert.False(t, ok)
+
+	store.Set("group", "message-1")
+	got, ok := store.Get("group")
+	assert.True(t, ok)
+	assert.Equal(t, "message-1", got)
+
+	// Entries expire ttl after they were last set and are dropped on the next Set.
+	now = now.Add(2 * time.Hour)
+	_, ok = store.Get("group")
+	assert.False(t, ok)
+
+	store.Set("other", "message-2")
+	assert.Len(t, store.entries, 1)
+
+	store.Delete("other")
+	_, ok = store.Get("other")
+	assert.False(t, ok)
+}
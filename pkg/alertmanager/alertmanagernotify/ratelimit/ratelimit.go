@@ -0,0 +1,96 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit waits out the short rate limits of chat APIs so that a
+// notification is not deferred to the next retry of the notification pipeline.
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxAttempts is the number of requests Do sends before it hands a 429
+	// back to the caller.
+	maxAttempts = 3
+
+	// maxWait is the longest delay Do waits for in place. Longer rate limits
+	// are left to the retries of the notification pipeline.
+	maxWait = 10 * time.Second
+
+	// maxBodySize bounds how much of a 429 response is read to find the delay.
+	maxBodySize = 64 * 1024
+)
+
+// DelayFunc returns how long the server asked to wait before the next
+// request, or zero if the response does not say.
+type DelayFunc func(header http.Header, body []byte) time.Duration
+
+// Do sends a request and sends it again while the server responds with
+// 429 Too Many Requests and asks for a delay short enough to wait for. send
+// must build a new request body on every call. The last response is returned
+// with its body still readable.
+func Do(ctx context.Context, send func(ctx context.Context) (*http.Response, error), delay DelayFunc) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := send(ctx)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt == maxAttempts {
+			return resp, err
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return resp, nil
+		}
+
+		wait := delay(resp.Header, body)
+		if wait <= 0 || wait > maxWait {
+			return resp, nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, nil
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryAfter reads the standard Retry-After header, given either in seconds
+// or as an HTTP date.
+func RetryAfter(header http.Header, _ []byte) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return Seconds(seconds)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+// Seconds converts a delay given in (fractional) seconds into a duration.
+func Seconds(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		retryAfter       string
+		timeout          time.Duration
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{
+			name:             "waits out a short rate limit",
+			retryAfter:       "0.01",
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "hands back a rate limit without a delay",
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "hands back a rate limit longer than the maximum wait",
+			retryAfter:       "60",
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "hands back a rate limit longer than the context",
+			retryAfter:       "1",
+			timeout:          100 * time.Millisecond,
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedAttempts: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					if tc.retryAfter != "" {
+						w.Header().Set("Retry-After", tc.retryAfter)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					_, _ = w.Write([]byte("slow down"))
+					return
+				}
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer srv.Close()
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			resp, err := Do(ctx, func(ctx context.Context) (*http.Response, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, nil)
+				if err != nil {
+					return nil, err
+				}
+				return http.DefaultClient.Do(req)
+			}, RetryAfter)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedAttempts, attempts.Load())
+
+			// The body of the last response is still readable.
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.NotEmpty(t, body)
+		})
+	}
+}
+
+func TestDoGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "0.001")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+		return http.DefaultClient.Do(req)
+	}, RetryAfter)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(maxAttempts), attempts.Load())
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), RetryAfter(http.Header{}, nil))
+	assert.Equal(t, 2*time.Second, RetryAfter(http.Header{"Retry-After": []string{"2"}}, nil))
+	assert.Equal(t, 1500*time.Millisecond, RetryAfter(http.Header{"Retry-After": []string{"1.5"}}, nil))
+	assert.Equal(t, time.Duration(0), RetryAfter(http.Header{"Retry-After": []string{"soon"}}, nil))
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, float64(time.Minute), float64(RetryAfter(http.Header{"Retry-After": []string{date}}, nil)), float64(2*time.Second))
+}
@@ -4,11 +4,15 @@ import (
 	"log/slog"
 	"slices"
 
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/discord"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/email"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/googlechat"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/mattermost"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/msteamsv2"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/opsgenie"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/pagerduty"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/slack"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/telegram"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/webhook"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/prometheus/alertmanager/config/receiver"
@@ -24,6 +28,9 @@ var customNotifierIntegrations = []string{
 	opsgenie.Integration,
 	slack.Integration,
 	msteamsv2.Integration,
+	discord.Integration,
+	telegram.Integration,
+	mattermost.Integration,
 }
 
 func NewReceiverIntegrations(nc *alertmanagertypes.Receiver, tmpl *template.Template, logger *slog.Logger, templater alertmanagertypes.Templater) ([]notify.Integration, error) {
@@ -74,6 +81,18 @@ func NewReceiverIntegrations(nc *alertmanagertypes.Receiver, tmpl *template.Temp
 			return msteamsv2.New(c, tmpl, `{{ template "msteamsv2.default.titleLink" . }}`, l, templater)
 		})
 	}
+	for i, c := range nc.GoogleChatConfigs {
+		add(googlechat.Integration, i, c, func(l *slog.Logger) (notify.Notifier, error) { return googlechat.New(c, tmpl, l, templater) })
+	}
+	for i, c := range nc.DiscordConfigs {
+		add(discord.Integration, i, c, func(l *slog.Logger) (notify.Notifier, error) { return discord.New(c, tmpl, l, templater) })
+	}
+	for i, c := range nc.TelegramConfigs {
+		add(telegram.Integration, i, c, func(l *slog.Logger) (notify.Notifier, error) { return telegram.New(c, tmpl, l, templater) })
+	}
+	for i, c := range nc.MattermostConfigs {
+		add(mattermost.Integration, i, c, func(l *slog.Logger) (notify.Notifier, error) { return mattermost.New(c, tmpl, l, templater) })
+	}
 
 	if errs.Len() > 0 {
 		return nil, &errs
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package relatedlinks builds the "View Related Logs/Traces" links that the
+// rules attach to alerts as annotations.
+package relatedlinks
+
+import (
+	"html"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Link is a related link of an alert.
+type Link struct {
+	Text string
+	URL  string
+}
+
+// Formatter renders a link in the markup of a notifier.
+type Formatter func(link Link) string
+
+// Markdown renders a link as [text](url).
+func Markdown(link Link) string {
+	return "[" + link.Text + "](" + link.URL + ")"
+}
+
+// HTML renders a link as an anchor.
+func HTML(link Link) string {
+	return `<a href="` + html.EscapeString(link.URL) + `">` + html.EscapeString(link.Text) + `</a>`
+}
+
+// Links returns the related links of an alert, or nil when no related-link
+// annotations are present.
+func Links(alert *types.Alert) []Link {
+	var links []Link
+	if url := alert.Annotations[ruletypes.AnnotationRelatedLogs]; url != "" {
+		links = append(links, Link{Text: "View Related Logs", URL: string(url)})
+	}
+	if url := alert.Annotations[ruletypes.AnnotationRelatedTraces]; url != "" {
+		links = append(links, Link{Text: "View Related Traces", URL: string(url)})
+	}
+	return links
+}
+
+// Format returns the related links of an alert rendered by format and
+// separated by " | ", or an empty string when there are none.
+func Format(alert *types.Alert, format Formatter) string {
+	links := Links(alert)
+	rendered := make([]string, 0, len(links))
+	for _, link := range links {
+		rendered = append(rendered, format(link))
+	}
+	return strings.Join(rendered, " | ")
+}
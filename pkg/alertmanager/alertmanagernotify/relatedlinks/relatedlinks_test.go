@@ -0,0 +1,26 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relatedlinks
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	alert := &types.Alert{Alert: model.Alert{Annotations: model.LabelSet{
+		ruletypes.AnnotationRelatedLogs:   "https://signoz.io/logs?a=1&b=2",
+		ruletypes.AnnotationRelatedTraces: "https://signoz.io/traces",
+	}}}
+
+	assert.Equal(t, "[View Related Logs](https://signoz.io/logs?a=1&b=2) | [View Related Traces](https://signoz.io/traces)", Format(alert, Markdown))
+	assert.Equal(t, `<a href="https://signoz.io/logs?a=1&amp;b=2">View Related Logs</a> | <a href="https://signoz.io/traces">View Related Traces</a>`, Format(alert, HTML))
+
+	assert.Empty(t, Format(&types.Alert{}, Markdown))
+	assert.Nil(t, Links(&types.Alert{}))
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/messagestore"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/ratelimit"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/relatedlinks"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/templating/markdownrenderer"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	Integration = "telegram"
+
+	// defaultAPIURL is used when neither the config nor the global config set one.
+	defaultAPIURL = "https://api.telegram.org"
+)
+
+// https://core.telegram.org/bots/api#sendmessage - the text is limited to 4096 characters.
+const maxMessageLenRunes = 4096
+
+// Notifier implements a Notifier for Telegram notifications.
+type Notifier struct {
+	conf      *alertmanagertypes.TelegramReceiverConfig
+	tmpl      *template.Template
+	logger    *slog.Logger
+	client    *http.Client
+	retrier   *notify.Retrier
+	templater alertmanagertypes.Templater
+
+	// messages holds the id of the first message sent for each alert group.
+	// Later notifications of the group edit it when edit_messages is set,
+	// and reply to it otherwise.
+	messages *messagestore.Store[int64]
+
+	postJSONFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// https://core.telegram.org/bots/api#sendmessage
+type sendMessage struct {
+	ChatID              int64            `json:"chat_id"`
+	MessageThreadID     int              `json:"message_thread_id,omitempty"`
+	Text                string           `json:"text"`
+	ParseMode           string           `json:"parse_mode,omitempty"`
+	DisableNotification bool             `json:"disable_notification,omitempty"`
+	ReplyParameters     *replyParameters `json:"reply_parameters,omitempty"`
+}
+
+// https://core.telegram.org/bots/api#replyparameters
+type replyParameters struct {
+	MessageID                int64 `json:"message_id"`
+	AllowSendingWithoutReply bool  `json:"allow_sending_without_reply"`
+}
+
+// https://core.telegram.org/bots/api#editmessagetext
+type editMessageText struct {
+	ChatID    int64  `json:"chat_id"`
+	MessageID int64  `json:"message_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode,omitempty"`
+}
+
+// response is the envelope of every Bot API response.
+// https://core.telegram.org/bots/api#making-requests
+type response struct {
+	OK          bool            `json:"ok"`
+	Description string          `json:"description"`
+	Result      json.RawMessage `json:"result"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// New returns a new Telegram notification handler.
+func New(c *alertmanagertypes.TelegramReceiverConfig, t *template.Template, l *slog.Logger, templater alertmanagertypes.Templater, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	if c.BotToken == "" {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "bot_token is required for telegram")
+	}
+
+	httpConfig := commoncfg.HTTPClientConfig{}
+	if c.HTTPConfig != nil {
+		httpConfig = *c.HTTPConfig
+	}
+
+	client, err := notify.NewClientWithTracing(httpConfig, Integration, httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		client: client,
+		// https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}, CustomDetailsFunc: errDetails},
+		templater:    templater,
+		messages:     messagestore.New[int64](messagestore.DefaultTTL),
+		postJSONFunc: notify.PostJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	logger := n.logger.With(slog.Any("group_key", key))
+	logger.DebugContext(ctx, "extracted group key")
+
+	text, parseMode, err := n.prepareContent(ctx, as)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "failed to prepare notification content", errors.Attr(err))
+		return false, err
+	}
+
+	resolved := !types.Alerts(as...).HasFiring()
+
+	messageID, ok := n.messages.Get(key)
+	if ok && n.conf.EditMessages {
+		retry, err := n.call(ctx, "editMessageText", editMessageText{
+			ChatID:    n.conf.ChatID,
+			MessageID: messageID,
+			Text:      text,
+			ParseMode: parseMode,
+		}, nil)
+		if err == nil && resolved {
+			n.messages.Delete(key)
+		}
+		if err == nil || !strings.Contains(err.Error(), "message to edit not found") {
+			return retry, err
+		}
+		logger.InfoContext(ctx, "message to edit was deleted, sending a new one", slog.Int64("message_id", messageID))
+		ok = false
+	}
+
+	msg := sendMessage{
+		ChatID:              n.conf.ChatID,
+		MessageThreadID:     n.conf.MessageThreadID,
+		Text:                text,
+		ParseMode:           parseMode,
+		DisableNotification: n.conf.DisableNotifications,
+	}
+	if ok {
+		// Keep the notifications of a group together as a thread of replies.
+		msg.ReplyParameters = &replyParameters{MessageID: messageID, AllowSendingWithoutReply: true}
+	}
+
+	var sent struct {
+		MessageID int64 `json:"message_id"`
+	}
+	retry, err := n.call(ctx, "sendMessage", msg, &sent)
+	if err != nil {
+		return retry, err
+	}
+
+	switch {
+	case resolved:
+		n.messages.Delete(key)
+	case !ok && sent.MessageID != 0:
+		n.messages.Set(key, sent.MessageID)
+	}
+
+	return false, nil
+}
+
+// call invokes a Bot API method, waiting out short rate limits, and decodes
+// its result into result when it is not nil.
+func (n *Notifier) call(ctx context.Context, method string, params any, result any) (bool, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(params); err != nil {
+		return false, err
+	}
+
+	url := n.apiURL() + "/bot" + string(n.conf.BotToken) + "/" + method
+	resp, err := ratelimit.Do(ctx, func(ctx context.Context) (*http.Response, error) {
+		return n.postJSONFunc(ctx, n.client, url, bytes.NewReader(buf.Bytes())) //nolint:bodyclose
+	}, retryAfter)
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, err
+	}
+
+	// Editing a message with the text it already has is not an error for us.
+	if method == "editMessageText" && resp.StatusCode == http.StatusBadRequest && strings.Contains(string(body), "message is not modified") {
+		return false, nil
+	}
+
+	retry, err := n.retrier.Check(resp.StatusCode, bytes.NewReader(body))
+	if err != nil {
+		return retry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	if result == nil {
+		return false, nil
+	}
+
+	var r response
+	if err := json.Unmarshal(body, &r); err != nil || !r.OK {
+		n.logger.WarnContext(ctx, "failed to decode the telegram response", slog.String("method", method))
+		return false, nil
+	}
+	if err := json.Unmarshal(r.Result, result); err != nil {
+		n.logger.WarnContext(ctx, "failed to decode the telegram result", slog.String("method", method), errors.Attr(err))
+	}
+
+	return false, nil
+}
+
+func (n *Notifier) apiURL() string {
+	if n.conf.APIURL == nil {
+		return defaultAPIURL
+	}
+	return strings.TrimSuffix(n.conf.APIURL.String(), "/")
+}
+
+// prepareContent expands the alert templates into the message text and
+// returns it with the parse mode to send it with. The default body uses the
+// configured parse mode. A custom body is authored in markdown and is always
+// sent as HTML, with one paragraph per alert.
+func (n *Notifier) prepareContent(ctx context.Context, alerts []*types.Alert) (string, string, error) {
+	customTitle, customBody := alertmanagertemplate.ExtractTemplatesFromAnnotations(alerts)
+	result, err := n.templater.Expand(ctx, alertmanagertypes.ExpandRequest{
+		TitleTemplate:        customTitle,
+		BodyTemplate:         customBody,
+		DefaultTitleTemplate: n.conf.Title,
+		DefaultBodyTemplate:  n.conf.Message,
+	}, alerts)
+	if err != nil {
+		return "", "", err
+	}
+
+	parseMode := n.conf.ParseMode
+	var parts []string
+
+	if result.IsDefaultBody {
+		if title := formatTitle(result.Title, parseMode); title != "" {
+			parts = append(parts, title)
+		}
+		if len(result.Body) > 0 && strings.TrimSpace(result.Body[0]) != "" {
+			parts = append(parts, strings.TrimSpace(result.Body[0]))
+		}
+	} else {
+		parseMode = alertmanagertypes.TelegramParseModeHTML
+		if title := formatTitle(result.Title, parseMode); title != "" {
+			parts = append(parts, title)
+		}
+
+		for i, body := range result.Body {
+			if body == "" || i >= len(alerts) {
+				continue
+			}
+
+			rendered, err := markdownrenderer.RenderTelegramHTML(body)
+			if err != nil {
+				return "", "", err
+			}
+
+			status := "🔴"
+			if alerts[i].Resolved() {
+				status = "🟢"
+			}
+
+			part := status + " " + rendered
+			if links := relatedlinks.Format(alerts[i], relatedlinks.HTML); links != "" {
+				part += "\n" + links
+			}
+			parts = append(parts, part)
+		}
+	}
+
+	text, truncated := notify.TruncateInRunes(strings.Join(parts, "\n\n"), maxMessageLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "Truncated message", slog.Int("max_runes", maxMessageLenRunes))
+	}
+
+	return text, parseMode, nil
+}
+
+// markdownV2Escaper escapes the characters reserved by MarkdownV2.
+// https://core.telegram.org/bots/api#markdownv2-style
+var markdownV2Escaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "~", `\~`, "`", "\\`",
+	">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// markdownEscaper escapes the characters reserved by the legacy Markdown mode.
+// https://core.telegram.org/bots/api#markdown-style
+var markdownEscaper = strings.NewReplacer("_", `\_`, "*", `\*`, "`", "\\`", "[", `\[`)
+
+// formatTitle renders the title in bold for the parse mode.
+func formatTitle(title, parseMode string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return ""
+	}
+
+	switch parseMode {
+	case alertmanagertypes.TelegramParseModeHTML:
+		return "<b>" + html.EscapeString(title) + "</b>"
+	case alertmanagertypes.TelegramParseModeMarkdownV2:
+		return "*" + markdownV2Escaper.Replace(title) + "*"
+	case alertmanagertypes.TelegramParseModeMarkdown:
+		return "*" + markdownEscaper.Replace(title) + "*"
+	default:
+		return title
+	}
+}
+
+// retryAfter reads the delay of a rate limited request from the response.
+func retryAfter(header http.Header, body []byte) time.Duration {
+	var r response
+	if err := json.Unmarshal(body, &r); err == nil && r.Parameters.RetryAfter > 0 {
+		return time.Duration(r.Parameters.RetryAfter) * time.Second
+	}
+
+	return ratelimit.RetryAfter(header, body)
+}
+
+// errDetails extracts the error description from a Bot API error response.
+func errDetails(_ int, body io.Reader) string {
+	if body == nil {
+		return ""
+	}
+
+	var r response
+	if err := json.NewDecoder(body).Decode(&r); err != nil {
+		return ""
+	}
+
+	return r.Description
+}
@@ -0,0 +1,375 @@
+// Copyright (c) 2026 SigNoz, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertemplate"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestTemplater(tmpl *template.Template) alertmanagertypes.Templater {
+	return alertmanagertemplate.New(tmpl, slog.New(slog.DiscardHandler))
+}
+
+func newTestNotifier(t *testing.T, u *url.URL, modify func(*alertmanagertypes.TelegramReceiverConfig)) *Notifier {
+	t.Helper()
+
+	conf := alertmanagertypes.DefaultTelegramReceiverConfig
+	conf.APIURL = &config.URL{URL: u}
+	conf.BotToken = "bot-token"
+	conf.ChatID = 42
+	conf.HTTPConfig = &commoncfg.HTTPClientConfig{}
+	if modify != nil {
+		modify(&conf)
+	}
+
+	tmpl := test.CreateTmpl(t)
+	notifier, err := New(&conf, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.NoError(t, err)
+	return notifier
+}
+
+func firingAlert(name string) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name), "severity": "critical"},
+			Annotations: model.LabelSet{"summary": "disk <sda> is full"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func resolvedAlert(name string) *types.Alert {
+	alert := firingAlert(name)
+	alert.StartsAt = time.Now().Add(-time.Hour)
+	alert.EndsAt = time.Now().Add(-time.Minute)
+	return alert
+}
+
+type request struct {
+	path   string
+	params map[string]any
+}
+
+// newTestServer records the Bot API calls it receives. Messages get
+// increasing ids starting at 100.
+func newTestServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request) bool) (*httptest.Server, func() []request) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+
+		mu.Lock()
+		requests = append(requests, request{path: r.URL.Path, params: params})
+		id := 99 + len(requests)
+		mu.Unlock()
+
+		if handle != nil && handle(w, r) {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{"message_id": id}})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []request {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]request(nil), requests...)
+	}
+}
+
+func TestTelegramRetry(t *testing.T) {
+	u, _ := url.Parse("https://api.telegram.org")
+	notifier := newTestNotifier(t, u, nil)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestTelegramNotifyRepliesWithinGroup(t *testing.T) {
+	srv, requests := newTestServer(t, nil)
+
+	u, _ := url.Parse(srv.URL)
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) {
+		c.MessageThreadID = 7
+		c.DisableNotifications = true
+	})
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	_, err = notifier.Notify(ctx, resolvedAlert("DiskFull"))
+	require.NoError(t, err)
+
+	got := requests()
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "/botbot-token/sendMessage", got[0].path)
+	assert.Equal(t, float64(42), got[0].params["chat_id"])
+	assert.Equal(t, float64(7), got[0].params["message_thread_id"])
+	assert.Equal(t, "HTML", got[0].params["parse_mode"])
+	assert.Equal(t, true, got[0].params["disable_notification"])
+	assert.Equal(t, "<b>[FIRING:1] DiskFull</b>\n\n<b>Alert:</b> DiskFull (critical)\n<b>Summary:</b> disk &lt;sda&gt; is full", got[0].params["text"])
+	assert.Nil(t, got[0].params["reply_parameters"])
+
+	// The resolved notification replies to the first message of the group.
+	assert.Equal(t, "/botbot-token/sendMessage", got[1].path)
+	assert.Equal(t, map[string]any{"message_id": float64(100), "allow_sending_without_reply": true}, got[1].params["reply_parameters"])
+
+	_, ok := notifier.messages.Get("1")
+	assert.False(t, ok)
+}
+
+func TestTelegramNotifyEditsMessage(t *testing.T) {
+	srv, requests := newTestServer(t, nil)
+
+	u, _ := url.Parse(srv.URL)
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) { c.EditMessages = true })
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+
+	_, err = notifier.Notify(ctx, resolvedAlert("DiskFull"))
+	require.NoError(t, err)
+
+	got := requests()
+	require.Len(t, got, 2)
+	assert.Equal(t, "/botbot-token/sendMessage", got[0].path)
+	assert.Equal(t, "/botbot-token/editMessageText", got[1].path)
+	assert.Equal(t, float64(100), got[1].params["message_id"])
+	assert.Contains(t, got[1].params["text"], "[RESOLVED] DiskFull")
+
+	_, ok := notifier.messages.Get("1")
+	assert.False(t, ok)
+}
+
+func TestTelegramNotifySendsWhenEditedMessageIsGone(t *testing.T) {
+	srv, requests := newTestServer(t, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != "/botbot-token/editMessageText" {
+			return false
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message to edit not found"}`))
+		return true
+	})
+
+	u, _ := url.Parse(srv.URL)
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) { c.EditMessages = true })
+	notifier.messages.Set("1", 5)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	got := requests()
+	require.Len(t, got, 2)
+	assert.Equal(t, "/botbot-token/editMessageText", got[0].path)
+	assert.Equal(t, "/botbot-token/sendMessage", got[1].path)
+	assert.Nil(t, got[1].params["reply_parameters"])
+
+	messageID, ok := notifier.messages.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, int64(101), messageID)
+}
+
+func TestTelegramNotifyIgnoresUnmodifiedMessage(t *testing.T) {
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) bool {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message is not modified"}`))
+		return true
+	})
+
+	u, _ := url.Parse(srv.URL)
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) { c.EditMessages = true })
+	notifier.messages.Set("1", 5)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+}
+
+func TestTelegramNotifyWaitsOutRateLimit(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts > 1 {
+			return false
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+		return true
+	})
+
+	u, _ := url.Parse(srv.URL)
+	notifier := newTestNotifier(t, u, nil)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+	require.NoError(t, err)
+	assert.False(t, retry)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestTelegramNotifyWithReason(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		statusCode     int
+		body           string
+		retry          bool
+		expectedReason notify.Reason
+		errMsg         string
+	}{
+		{
+			name:           "rate limited beyond the wait",
+			statusCode:     http.StatusTooManyRequests,
+			body:           `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 60","parameters":{"retry_after":60}}`,
+			retry:          true,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "Too Many Requests: retry after 60",
+		},
+		{
+			name:           "bad request",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`,
+			retry:          false,
+			expectedReason: notify.ClientErrorReason,
+			errMsg:         "Bad Request: chat not found",
+		},
+		{
+			name:           "server error",
+			statusCode:     http.StatusBadGateway,
+			retry:          true,
+			expectedReason: notify.ServerErrorReason,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			u, _ := url.Parse(srv.URL)
+			notifier := newTestNotifier(t, u, nil)
+
+			ctx := notify.WithGroupKey(context.Background(), "1")
+			retry, err := notifier.Notify(ctx, firingAlert("DiskFull"))
+			require.Equal(t, tc.retry, retry)
+
+			var reasonError *notify.ErrorWithReason
+			require.ErrorAs(t, err, &reasonError)
+			require.Equal(t, tc.expectedReason, reasonError.Reason)
+			if tc.errMsg != "" {
+				require.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestTelegramRedactedToken(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret-token"
+	notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) { c.BotToken = config.Secret(secret) })
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
+
+func TestTelegramNewWithoutBotToken(t *testing.T) {
+	tmpl := test.CreateTmpl(t)
+	_, err := New(&alertmanagertypes.TelegramReceiverConfig{ChatID: 42}, tmpl, promslog.NewNopLogger(), newTestTemplater(tmpl))
+	require.Error(t, err)
+}
+
+func TestPrepareContent(t *testing.T) {
+	u, _ := url.Parse("https://api.telegram.org")
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	t.Run("custom template - rendered as html", func(t *testing.T) {
+		notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) {
+			c.ParseMode = alertmanagertypes.TelegramParseModeMarkdownV2
+		})
+
+		firing := firingAlert("test1")
+		firing.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom <Title>"
+		firing.Annotations[ruletypes.AnnotationBodyTemplate] = "**$alertname** is `a < b`"
+		firing.Annotations[ruletypes.AnnotationRelatedLogs] = "https://signoz.example.com/logs?a=1&b=2"
+
+		resolved := resolvedAlert("test2")
+		resolved.Annotations[ruletypes.AnnotationTitleTemplate] = "Custom <Title>"
+		resolved.Annotations[ruletypes.AnnotationBodyTemplate] = "**$alertname** is `a < b`"
+
+		text, parseMode, err := notifier.prepareContent(ctx, []*types.Alert{firing, resolved})
+		require.NoError(t, err)
+
+		assert.Equal(t, alertmanagertypes.TelegramParseModeHTML, parseMode)
+		assert.Equal(t,
+			"<b>Custom &lt;Title&gt;</b>\n\n"+
+				"🔴 <b>test1</b> is <code>a &lt; b</code>\n<a href=\"https://signoz.example.com/logs?a=1&amp;b=2\">View Related Logs</a>\n\n"+
+				"🟢 <b>test2</b> is <code>a &lt; b</code>",
+			text,
+		)
+	})
+
+	t.Run("default template - configured parse mode", func(t *testing.T) {
+		notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) {
+			c.ParseMode = alertmanagertypes.TelegramParseModeMarkdownV2
+			c.Message = "disk is full"
+		})
+
+		text, parseMode, err := notifier.prepareContent(ctx, []*types.Alert{firingAlert("Disk-Full")})
+		require.NoError(t, err)
+
+		assert.Equal(t, alertmanagertypes.TelegramParseModeMarkdownV2, parseMode)
+		assert.Equal(t, "*\\[FIRING:1\\] Disk\\-Full*\n\ndisk is full", text)
+	})
+
+	t.Run("title with templating errors", func(t *testing.T) {
+		notifier := newTestNotifier(t, u, func(c *alertmanagertypes.TelegramReceiverConfig) { c.Title = "{{ " })
+
+		_, _, err := notifier.prepareContent(ctx, []*types.Alert{firingAlert("test")})
+		require.Error(t, err)
+	})
+}
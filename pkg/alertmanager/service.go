@@ -75,7 +75,10 @@ func (service *Service) SyncServers(ctx context.Context) error {
 	}
 
 	service.serversMtx.Lock()
+	owned := make(map[string]struct{}, len(orgs))
 	for _, org := range orgs {
+		owned[org.ID.StringValue()] = struct{}{}
+
 		config, _, err := service.getConfig(ctx, org.ID.StringValue())
 		if err != nil {
 			service.settings.Logger().ErrorContext(ctx, "failed to get alertmanager config for org", slog.String("org_id", org.ID.StringValue()), errors.Attr(err))
@@ -104,6 +107,18 @@ func (service *Service) SyncServers(ctx context.Context) error {
 			continue
 		}
 	}
+
+	// Stop the servers of the orgs which another instance owns now.
+	for orgID, server := range service.servers {
+		if _, ok := owned[orgID]; ok {
+			continue
+		}
+
+		if err := server.Stop(ctx); err != nil {
+			service.settings.Logger().ErrorContext(ctx, "failed to stop alertmanager server", slog.String("org_id", orgID), errors.Attr(err))
+		}
+		delete(service.servers, orgID)
+	}
 	service.serversMtx.Unlock()
 
 	return nil
@@ -0,0 +1,81 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addAuditRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/audit/events", handler.New(provider.authzMiddleware.Check(provider.auditHandler.ListEvents, authtypes.Relation{Verb: coretypes.VerbRead}, coretypes.ResourceTelemetryResourceAuditLogs, auditLogsSelectorCallback, []string{
+		authtypes.SigNozAdminRoleName,
+	}), handler.OpenAPIDef{
+		ID:                  "ListAuditEvents",
+		Tags:                []string{"audit"},
+		Summary:             "List audit events",
+		Description:         "This endpoint searches the audit events of the organization by principal, resource, action category, outcome and time, most recent first",
+		Request:             nil,
+		RequestContentType:  "",
+		RequestQuery:        new(audittypes.ListAuditEventsParams),
+		Response:            new(audittypes.GettableAuditEvents),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		Deprecated:          false,
+		SecuritySchemes:     newScopedSecuritySchemes([]string{coretypes.ResourceTelemetryResourceAuditLogs.Scope(coretypes.VerbRead)}),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/audit/events/export", handler.New(provider.authzMiddleware.Check(provider.auditHandler.ExportEvents, authtypes.Relation{Verb: coretypes.VerbRead}, coretypes.ResourceTelemetryResourceAuditLogs, auditLogsSelectorCallback, []string{
+		authtypes.SigNozAdminRoleName,
+	}), handler.OpenAPIDef{
+		ID:                  "ExportAuditEvents",
+		Tags:                []string{"audit"},
+		Summary:             "Export audit events",
+		Description:         "This endpoint downloads the audit events of the organization matching the filter as CSV or JSONL, most recent first. The X-Response-Complete header is false if the export was capped",
+		Request:             nil,
+		RequestContentType:  "",
+		RequestQuery:        new(audittypes.ExportAuditEventsParams),
+		Response:            nil,
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		Deprecated:          false,
+		SecuritySchemes:     newScopedSecuritySchemes([]string{coretypes.ResourceTelemetryResourceAuditLogs.Scope(coretypes.VerbRead)}),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/audit/resources/{kind}/{id}/timeline", handler.New(provider.authzMiddleware.Check(provider.auditHandler.GetResourceTimeline, authtypes.Relation{Verb: coretypes.VerbRead}, coretypes.ResourceTelemetryResourceAuditLogs, auditLogsSelectorCallback, []string{
+		authtypes.SigNozAdminRoleName,
+	}), handler.OpenAPIDef{
+		ID:                  "GetAuditResourceTimeline",
+		Tags:                []string{"audit"},
+		Summary:             "Get audit timeline of a resource",
+		Description:         "This endpoint returns the audit events of a single resource, oldest first, to show how it changed over time",
+		Request:             nil,
+		RequestContentType:  "",
+		RequestQuery:        new(audittypes.GetAuditResourceTimelineParams),
+		Response:            new(audittypes.GettableAuditEvents),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		Deprecated:          false,
+		SecuritySchemes:     newScopedSecuritySchemes([]string{coretypes.ResourceTelemetryResourceAuditLogs.Scope(coretypes.VerbRead)}),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func auditLogsSelectorCallback(_ *http.Request, _ authtypes.Claims) ([]coretypes.Selector, error) {
+	return []coretypes.Selector{
+		coretypes.TypeTelemetryResource.MustSelector(coretypes.WildCardSelectorString),
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/http/handler"
 	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/types/coretypes"
 	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
@@ -32,6 +33,23 @@ func (provider *provider) addDashboardRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v2/dashboards/import/grafana", handler.New(provider.authzMiddleware.EditAccess(provider.dashboardHandler.ImportGrafanaV2), handler.OpenAPIDef{
+		ID:                  "ImportGrafanaDashboardV2",
+		Tags:                []string{"dashboard"},
+		Summary:             "Import Grafana dashboard (v2)",
+		Description:         "This endpoint creates a v2 dashboard from a Grafana dashboard JSON. PromQL targets become PromQL queries, template variables become dashboard variables, and rows become layout sections. The Grafana panels, variables, and tags that could not be converted are left out of the dashboard and listed in the report.",
+		Request:             new(dashboardtypes.GrafanaDashboard),
+		RequestContentType:  "application/json",
+		Response:            new(dashboardtypes.GettableGrafanaDashboardImport),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusCreated,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		Deprecated:          false,
+		SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
+	})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	if err := router.Handle("/api/v2/dashboards/{id}", handler.New(provider.authzMiddleware.ViewAccess(provider.dashboardHandler.GetV2), handler.OpenAPIDef{
 		ID:                  "GetDashboardV2",
 		Tags:                []string{"dashboard"},
@@ -59,7 +77,7 @@ func (provider *provider) addDashboardRoutes(router *mux.Router) error {
 		Response:            new(dashboardtypes.GettableDashboardV2),
 		ResponseContentType: "application/json",
 		SuccessStatusCode:   http.StatusOK,
-		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
 		Deprecated:          false,
 		SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
 	})).Methods(http.MethodPut).GetError(); err != nil {
@@ -82,7 +100,7 @@ func (provider *provider) addDashboardRoutes(router *mux.Router) error {
 		Response:            new(dashboardtypes.GettableDashboardV2),
 		ResponseContentType: "application/json",
 		SuccessStatusCode:   http.StatusOK,
-		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
 		Deprecated:          false,
 		SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
 	})).Methods(http.MethodPatch).GetError(); err != nil {
@@ -123,6 +141,81 @@ func (provider *provider) addDashboardRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v2/dashboards/{id}/revisions", handler.New(provider.authzMiddleware.ViewAccess(provider.dashboardHandler.ListRevisionsV2), handler.OpenAPIDef{
+		ID:                  "ListDashboardRevisionsV2",
+		Tags:                []string{"dashboard"},
+		Summary:             "List dashboard revisions (v2)",
+		Description:         "This endpoint lists the revisions of a dashboard, latest first. A revision is recorded every time the dashboard is created, updated, patched, or restored, through the v1 or the v2 endpoints, and only the latest revisions are kept.",
+		Request:             nil,
+		RequestContentType:  "",
+		Response:            make([]dashboardtypes.GettableDashboardRevision, 0),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+		Deprecated:          false,
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	// Registered before /revisions/{version} so that `diff` is not matched as a version.
+	if err := router.Handle("/api/v2/dashboards/{id}/revisions/diff", handler.New(provider.authzMiddleware.ViewAccess(provider.dashboardHandler.DiffRevisionsV2), handler.OpenAPIDef{
+		ID:                  "DiffDashboardRevisionsV2",
+		Tags:                []string{"dashboard"},
+		Summary:             "Diff dashboard revisions (v2)",
+		Description:         "This endpoint returns the RFC 6902 JSON Patch operations which turn one revision of a dashboard into another. For a v2-shape dashboard the operations are relative to the postable view of the dashboard (metadata, data, tags), the same document the patch endpoint applies to, and for a v1 dashboard to its data.",
+		Request:             nil,
+		RequestQuery:        new(dashboardtypes.DashboardRevisionDiffParams),
+		RequestContentType:  "",
+		Response:            new(dashboardtypes.GettableDashboardRevisionDiff),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+		Deprecated:          false,
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/dashboards/{id}/revisions/{version}", handler.New(provider.authzMiddleware.ViewAccess(provider.dashboardHandler.GetRevisionV2), handler.OpenAPIDef{
+		ID:                  "GetDashboardRevisionV2",
+		Tags:                []string{"dashboard"},
+		Summary:             "Get dashboard revision (v2)",
+		Description:         "This endpoint returns a revision of a dashboard along with the dashboard as it was at that revision.",
+		Request:             nil,
+		RequestContentType:  "",
+		Response:            new(dashboardtypes.GettableDashboardRevisionWithData),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+		Deprecated:          false,
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/dashboards/{id}/revisions/{version}/restore", handler.New(provider.authzMiddleware.EditAccess(provider.dashboardHandler.RestoreRevisionV2), handler.OpenAPIDef{
+		ID:                  "RestoreDashboardRevisionV2",
+		Tags:                []string{"dashboard"},
+		Summary:             "Restore dashboard revision (v2)",
+		Description:         "This endpoint restores a v2-shape dashboard to a revision. The restore is recorded as a new revision, so it can be undone by restoring the revision before it. Locked dashboards are rejected. A v1 dashboard is restored by updating it with the data of the revision.",
+		Request:             nil,
+		RequestContentType:  "",
+		Response:            new(dashboardtypes.GettableDashboardV2),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+		Deprecated:          false,
+		SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
+	}, handler.WithAuditDef(handler.AuditDef{
+		ResourceKind:    coretypes.KindDashboard,
+		Action:          coretypes.VerbUpdate,
+		Category:        audittypes.ActionCategoryConfigurationChange,
+		ResourceIDParam: "id",
+	}))).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	if err := router.Handle("/api/v1/dashboards/{id}/public", handler.New(provider.authzMiddleware.AdminAccess(provider.dashboardHandler.CreatePublic), handler.OpenAPIDef{
 		ID:                  "CreatePublicDashboard",
 		Tags:                []string{"dashboard"},
@@ -0,0 +1,154 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/ingestionruletypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addIngestionRuleRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/ingestion_rules/{kind}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.ingestionRuleHandler.List),
+		handler.OpenAPIDef{
+			ID:                  "ListIngestionRules",
+			Tags:                []string{"ingestionrules"},
+			Summary:             "List ingestion rules",
+			Description:         "Returns the sampling or drop rules of the authenticated org, depending on the kind, along with the latest config version they were rolled out with and the history of config versions.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(ingestionruletypes.GettableIngestionRules),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/ingestion_rules/{kind}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.ingestionRuleHandler.Create),
+		handler.OpenAPIDef{
+			ID:                  "CreateIngestionRule",
+			Tags:                []string{"ingestionrules"},
+			Summary:             "Create an ingestion rule",
+			Description:         "Creates a tail sampling policy or a drop filter and starts a new config version of the kind, which is rolled out to the collectors via OpAMP. Traces not sampled by any enabled sampling policy are dropped.",
+			Request:             new(ingestionruletypes.PostableIngestionRule),
+			RequestContentType:  "application/json",
+			Response:            new(ingestionruletypes.GettableIngestionRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/ingestion_rules/{kind}/preview", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.ingestionRuleHandler.Preview),
+		handler.OpenAPIDef{
+			ID:                  "PreviewIngestionRules",
+			Tags:                []string{"ingestionrules"},
+			Summary:             "Preview ingestion rules",
+			Description:         "Returns the collector config the given rules would be rolled out as, applied to the given collector config or to a minimal one. Nothing is stored.",
+			Request:             new(ingestionruletypes.PostableIngestionRulesPreview),
+			RequestContentType:  "application/json",
+			Response:            new(ingestionruletypes.GettableIngestionRulesPreview),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/ingestion_rules/{kind}/versions/{version}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.ingestionRuleHandler.GetVersion),
+		handler.OpenAPIDef{
+			ID:                  "GetIngestionRulesVersion",
+			Tags:                []string{"ingestionrules"},
+			Summary:             "Get a config version of ingestion rules",
+			Description:         "Returns the rules of the kind as they were in the config version, along with its deploy status and the history of config versions.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(ingestionruletypes.GettableIngestionRules),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/ingestion_rules/{kind}/{id}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.ingestionRuleHandler.Get),
+		handler.OpenAPIDef{
+			ID:                  "GetIngestionRule",
+			Tags:                []string{"ingestionrules"},
+			Summary:             "Get an ingestion rule",
+			Description:         "Returns a single sampling or drop rule by ID.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(ingestionruletypes.GettableIngestionRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/ingestion_rules/{kind}/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.ingestionRuleHandler.Update),
+		handler.OpenAPIDef{
+			ID:                  "UpdateIngestionRule",
+			Tags:                []string{"ingestionrules"},
+			Summary:             "Update an ingestion rule",
+			Description:         "Replaces a sampling or drop rule and starts a new config version of the kind, which is rolled out to the collectors via OpAMP.",
+			Request:             new(ingestionruletypes.PostableIngestionRule),
+			RequestContentType:  "application/json",
+			Response:            new(ingestionruletypes.GettableIngestionRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPut).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/ingestion_rules/{kind}/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.ingestionRuleHandler.Delete),
+		handler.OpenAPIDef{
+			ID:                  "DeleteIngestionRule",
+			Tags:                []string{"ingestionrules"},
+			Summary:             "Delete an ingestion rule",
+			Description:         "Deletes a sampling or drop rule and starts a new config version of the kind, which is rolled out to the collectors via OpAMP.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            nil,
+			ResponseContentType: "",
+			SuccessStatusCode:   http.StatusNoContent,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodDelete).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addLookupTableRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/lookup_tables", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.lookupTableHandler.List),
+		handler.OpenAPIDef{
+			ID:                  "ListLookupTables",
+			Tags:                []string{"lookuptables"},
+			Summary:             "List lookup tables",
+			Description:         "Returns the lookup tables of the authenticated org along with the latest config version they were rolled out with and the history of config versions.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(lookuptabletypes.GettableLookupTables),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/lookup_tables", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.lookupTableHandler.Create),
+		handler.OpenAPIDef{
+			ID:                  "CreateLookupTable",
+			Tags:                []string{"lookuptables"},
+			Summary:             "Create a lookup table",
+			Description:         "Creates a lookup table from an uploaded CSV, whose first row names the columns, and starts a new config version which is rolled out to the collectors via OpAMP. Log pipeline lookup operators and span mappers refer to the table by name. A table has at most 1000 rows and 64 columns.",
+			Request:             new(lookuptabletypes.PostableLookupTable),
+			RequestContentType:  "application/json",
+			Response:            new(lookuptabletypes.GettableLookupTable),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/lookup_tables/preview", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.lookupTableHandler.Preview),
+		handler.OpenAPIDef{
+			ID:                  "PreviewLookupTable",
+			Tags:                []string{"lookuptables"},
+			Summary:             "Preview a lookup table",
+			Description:         "Parses an uploaded CSV and returns its first rows along with the columns enriched for each of the given keys. Nothing is stored.",
+			Request:             new(lookuptabletypes.PostableLookupTablePreview),
+			RequestContentType:  "application/json",
+			Response:            new(lookuptabletypes.GettableLookupTablePreview),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/lookup_tables/{id}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.lookupTableHandler.Get),
+		handler.OpenAPIDef{
+			ID:                  "GetLookupTable",
+			Tags:                []string{"lookuptables"},
+			Summary:             "Get a lookup table",
+			Description:         "Returns a single lookup table by ID, along with the columns and row count of its latest version.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(lookuptabletypes.GettableLookupTable),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/lookup_tables/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.lookupTableHandler.Delete),
+		handler.OpenAPIDef{
+			ID:                  "DeleteLookupTable",
+			Tags:                []string{"lookuptables"},
+			Summary:             "Delete a lookup table",
+			Description:         "Deletes a lookup table along with all its versions and starts a new config version which is rolled out to the collectors via OpAMP. Operators and span mappers still referring to the table stop enriching.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            nil,
+			ResponseContentType: "",
+			SuccessStatusCode:   http.StatusNoContent,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodDelete).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/lookup_tables/{id}/versions", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.lookupTableHandler.ListVersions),
+		handler.OpenAPIDef{
+			ID:                  "ListLookupTableVersions",
+			Tags:                []string{"lookuptables"},
+			Summary:             "List the versions of a lookup table",
+			Description:         "Returns the versions of a lookup table, latest first and without their rows.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(lookuptabletypes.GettableLookupTableVersions),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/lookup_tables/{id}/versions", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.lookupTableHandler.CreateVersion),
+		handler.OpenAPIDef{
+			ID:                  "CreateLookupTableVersion",
+			Tags:                []string{"lookuptables"},
+			Summary:             "Upload a new version of a lookup table",
+			Description:         "Replaces the content of a lookup table with an uploaded CSV as its next version and starts a new config version which is rolled out to the collectors via OpAMP.",
+			Request:             new(lookuptabletypes.PostableLookupTableVersion),
+			RequestContentType:  "application/json",
+			Response:            new(lookuptabletypes.GettableLookupTableVersion),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/lookup_tables/{id}/versions/{version}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.lookupTableHandler.GetVersion),
+		handler.OpenAPIDef{
+			ID:                  "GetLookupTableVersion",
+			Tags:                []string{"lookuptables"},
+			Summary:             "Get a version of a lookup table",
+			Description:         "Returns a version of a lookup table along with its rows.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(lookuptabletypes.GettableLookupTableVersion),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
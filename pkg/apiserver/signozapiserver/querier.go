@@ -467,5 +467,21 @@ func (provider *provider) addQuerierRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v5/query_cache/purge", handler.New(provider.authzMiddleware.AdminAccess(provider.querierHandler.PurgeCache), handler.OpenAPIDef{
+		ID:                  "PurgeQueryCache",
+		Tags:                []string{"querier"},
+		Summary:             "Purge query cache",
+		Description:         "Drops the cached query results of the org for a signal and time range, e.g. after backfilling data. The next queries over the range fetch it again.",
+		Request:             new(qbtypes.PostableCachePurge),
+		RequestContentType:  "application/json",
+		Response:            nil,
+		ResponseContentType: "",
+		SuccessStatusCode:   http.StatusNoContent,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+	})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	return nil
 }
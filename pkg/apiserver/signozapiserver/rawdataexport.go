@@ -29,5 +29,86 @@ func (provider *provider) addRawDataExportRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v1/export_jobs", handler.New(provider.authzMiddleware.ViewAccess(provider.rawDataExportHandler.CreateJob), handler.OpenAPIDef{
+		ID:                  "CreateExportJob",
+		Tags:                []string{"logs", "traces"},
+		Summary:             "Create export job",
+		Description:         "This endpoint creates a job which exports raw data for traces and logs in the background, the result is downloaded once the job succeeds",
+		Request:             new(v5.QueryRangeRequest),
+		RequestQuery:        new(exporttypes.ExportRawDataFormatQueryParam),
+		RequestContentType:  "application/json",
+		Response:            new(exporttypes.GettableExportJob),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusAccepted,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/export_jobs", handler.New(provider.authzMiddleware.ViewAccess(provider.rawDataExportHandler.ListJobs), handler.OpenAPIDef{
+		ID:                  "ListExportJobs",
+		Tags:                []string{"logs", "traces"},
+		Summary:             "List export jobs",
+		Description:         "This endpoint lists the export jobs created by the user, or all the export jobs of the org for an admin",
+		Request:             nil,
+		RequestContentType:  "",
+		Response:            new(exporttypes.GettableExportJobs),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{},
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/export_jobs/{id}", handler.New(provider.authzMiddleware.ViewAccess(provider.rawDataExportHandler.GetJob), handler.OpenAPIDef{
+		ID:                  "GetExportJob",
+		Tags:                []string{"logs", "traces"},
+		Summary:             "Get export job",
+		Description:         "This endpoint returns the status and progress of an export job",
+		Request:             nil,
+		RequestContentType:  "",
+		Response:            new(exporttypes.GettableExportJob),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/export_jobs/{id}/cancel", handler.New(provider.authzMiddleware.EditAccess(provider.rawDataExportHandler.CancelJob), handler.OpenAPIDef{
+		ID:                  "CancelExportJob",
+		Tags:                []string{"logs", "traces"},
+		Summary:             "Cancel export job",
+		Description:         "This endpoint cancels a queued or running export job",
+		Request:             nil,
+		RequestContentType:  "",
+		Response:            new(exporttypes.GettableExportJob),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
+	})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/export_jobs/{id}/download", handler.New(provider.authzMiddleware.ViewAccess(provider.rawDataExportHandler.DownloadJobResult), handler.OpenAPIDef{
+		ID:                  "DownloadExportJobResult",
+		Tags:                []string{"logs", "traces"},
+		Summary:             "Download export job result",
+		Description:         "This endpoint downloads the result of a succeeded export job, it supports range requests to resume an interrupted download",
+		Request:             nil,
+		RequestContentType:  "",
+		Response:            nil,
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
 	return nil
 }
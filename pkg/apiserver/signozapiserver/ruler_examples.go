@@ -273,6 +273,35 @@ func postableRuleExamples() []handler.OpenAPIExample {
 				},
 			},
 		},
+		{
+			Name:        "composite",
+			Summary:     "Composite rule over other rules (v1 only)",
+			Description: "Fires per service when both child rules fire for it and no deploy is in progress. Child alerts are joined on `service.name`; a child alert without that label, such as a global deploy marker, matches every service. The rule runs no query and routes through `preferredChannels` and the `severity` label.",
+			Value: map[string]any{
+				"alert":       "Checkout degraded",
+				"description": "High latency and a rising error rate outside of a deploy",
+				"ruleType":    "composite_rule",
+				"version":     "v5",
+				"frequency":   "1m",
+				"condition": map[string]any{
+					"composite": map[string]any{
+						"expression": "high_latency AND error_rate_up AND NOT deploy_in_progress",
+						"rules": []any{
+							map[string]any{"alias": "high_latency", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a01"},
+							map[string]any{"alias": "error_rate_up", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a02"},
+							map[string]any{"alias": "deploy_in_progress", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a03"},
+						},
+						"matchLabels": []any{"service.name"},
+					},
+				},
+				"labels":            map[string]any{"severity": "critical"},
+				"preferredChannels": []any{"pagerduty-checkout"},
+				"annotations": map[string]any{
+					"description": "{{$labels.service.name}} is degraded: {{$value}}.",
+					"summary":     "Checkout degraded",
+				},
+			},
+		},
 		{
 			Name:        "logs_threshold",
 			Summary:     "Logs threshold count() over filter",
@@ -332,6 +361,64 @@ func postableRuleExamples() []handler.OpenAPIExample {
 				},
 			},
 		},
+		{
+			Name:        "logs_absent_data",
+			Summary:     "Logs absent-data (heartbeat) per service",
+			Description: "Fires for every service that logged within the last 24h but has not logged for 15 minutes, and resolves when it logs again. Thresholds are not compared in absent-data mode; the single threshold only names the severity and channels.",
+			Value: map[string]any{
+				"alert":         "Service stopped logging",
+				"alertType":     "LOGS_BASED_ALERT",
+				"description":   "A service that was sending logs has gone quiet",
+				"ruleType":      "threshold_rule",
+				"version":       "v5",
+				"schemaVersion": "v2alpha1",
+				"condition": map[string]any{
+					"compositeQuery": map[string]any{
+						"queryType": "builder",
+						"panelType": "graph",
+						"queries": []any{
+							map[string]any{
+								"type": "builder_query",
+								"spec": map[string]any{
+									"name":         "A",
+									"signal":       "logs",
+									"stepInterval": 60,
+									"aggregations": []any{map[string]any{"expression": "count()"}},
+									"filter":       map[string]any{"expression": "deployment.environment = 'production'"},
+									"groupBy": []any{
+										map[string]any{"name": "service.name", "fieldContext": "resource", "fieldDataType": "string"},
+									},
+								},
+							},
+						},
+					},
+					"selectedQueryName": "A",
+					"absentData":        map[string]any{"for": "15m", "lookback": "24h"},
+					"thresholds": map[string]any{
+						"kind": "basic",
+						"spec": []any{
+							map[string]any{
+								"name":      "critical",
+								"op":        "above",
+								"matchType": "at_least_once",
+								"target":    0,
+								"channels":  []any{"pagerduty-platform"},
+							},
+						},
+					},
+				},
+				"evaluation": rolling("5m", "1m"),
+				"notificationSettings": map[string]any{
+					"groupBy":  []any{"service.name"},
+					"renotify": renotify("1h", "nodata"),
+				},
+				"labels": map[string]any{"severity": "critical", "team": "platform"},
+				"annotations": map[string]any{
+					"description": "{{$service.name}} has not logged since {{$labels.lastSeen}}.",
+					"summary":     "Service stopped logging",
+				},
+			},
+		},
 		{
 			Name:        "logs_error_rate_formula",
 			Summary:     "Logs error rate error count / total count × 100",
@@ -0,0 +1,155 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addSLORoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/slos", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.sloHandler.List),
+		handler.OpenAPIDef{
+			ID:                  "ListSLOs",
+			Tags:                []string{"slos"},
+			Summary:             "List SLOs",
+			Description:         "Returns all the SLOs of the authenticated org.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(slotypes.GettableSLOs),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/slos", handler.New(
+		provider.authzMiddleware.EditAccess(provider.sloHandler.Create),
+		handler.OpenAPIDef{
+			ID:                  "CreateSLO",
+			Tags:                []string{"slos"},
+			Summary:             "Create an SLO",
+			Description:         "Creates an SLO over a good events query and a total events query. When alerting is enabled, multi-window burn rate alert rules are generated for it: a threshold rule per window and a composite rule per long/short window pair that notifies the given channels.",
+			Request:             new(slotypes.PostableSLO),
+			RequestContentType:  "application/json",
+			Response:            new(slotypes.GettableSLO),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/slos/{id}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.sloHandler.Get),
+		handler.OpenAPIDef{
+			ID:                  "GetSLO",
+			Tags:                []string{"slos"},
+			Summary:             "Get an SLO",
+			Description:         "Returns a single SLO by ID.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(slotypes.GettableSLO),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/slos/{id}", handler.New(
+		provider.authzMiddleware.EditAccess(provider.sloHandler.Update),
+		handler.OpenAPIDef{
+			ID:                  "UpdateSLO",
+			Tags:                []string{"slos"},
+			Summary:             "Update an SLO",
+			Description:         "Replaces an SLO and regenerates its burn rate alert rules.",
+			Request:             new(slotypes.PostableSLO),
+			RequestContentType:  "application/json",
+			Response:            new(slotypes.GettableSLO),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
+		},
+	)).Methods(http.MethodPut).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/slos/{id}", handler.New(
+		provider.authzMiddleware.EditAccess(provider.sloHandler.Delete),
+		handler.OpenAPIDef{
+			ID:                  "DeleteSLO",
+			Tags:                []string{"slos"},
+			Summary:             "Delete an SLO",
+			Description:         "Deletes an SLO along with its burn rate alert rules.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            nil,
+			ResponseContentType: "",
+			SuccessStatusCode:   http.StatusNoContent,
+			ErrorStatusCodes:    []int{http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
+		},
+	)).Methods(http.MethodDelete).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/slos/{id}/error_budget", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.sloHandler.GetErrorBudget),
+		handler.OpenAPIDef{
+			ID:                  "GetSLOErrorBudget",
+			Tags:                []string{"slos"},
+			Summary:             "Get the error budget of an SLO",
+			Description:         "Counts the good and total events of an SLO over its window ending now and returns the SLI along with the fraction of the error budget consumed and remaining.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(slotypes.GettableErrorBudget),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/slos/{id}/burn_rate", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.sloHandler.GetBurnRates),
+		handler.OpenAPIDef{
+			ID:                  "GetSLOBurnRates",
+			Tags:                []string{"slos"},
+			Summary:             "Get the burn rates of an SLO",
+			Description:         "Returns the rate the error budget of an SLO is burning at over windows ending now, where 1 uses up exactly the whole budget over the SLO window. Defaults to the windows of the burn rate alert policy.",
+			Request:             nil,
+			RequestContentType:  "",
+			RequestQuery:        new(slotypes.BurnRateQuery),
+			Response:            new(slotypes.GettableBurnRates),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -52,6 +52,25 @@ type FileConfig struct {
 	// Path is the absolute path to the audit log file. The file is opened with
 	// O_APPEND|O_CREATE|O_WRONLY; existing contents are preserved across runs.
 	Path string `mapstructure:"path"`
+
+	// Chain makes the file tamper-evident.
+	Chain ChainConfig `mapstructure:"chain"`
+}
+
+// ChainConfig configures the hash chain of the file provider. Each batch
+// carries the SHA-256 of the line before it, and batches are periodically
+// signed so that the chain cannot be rewritten without the signing key.
+type ChainConfig struct {
+	// Enabled controls whether batches are chained. A chained file must not
+	// contain records written before the chain was enabled.
+	Enabled bool `mapstructure:"enabled"`
+
+	// SigningKeyPath is the path to the PEM encoded PKCS #8 ed25519 private
+	// key which signs the checkpoints.
+	SigningKeyPath string `mapstructure:"signing_key_path"`
+
+	// CheckpointInterval is the minimum time between signed batches.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
 }
 
 // RetryConfig configures exponential backoff for the OTLP HTTP exporter.
@@ -89,6 +108,11 @@ func newConfig() factory.Config {
 				MaxElapsedTime:  time.Minute,
 			},
 		},
+		File: FileConfig{
+			Chain: ChainConfig{
+				CheckpointInterval: 5 * time.Minute,
+			},
+		},
 	}
 }
 
@@ -123,6 +147,16 @@ func (c Config) Validate() error {
 		if c.File.Path == "" {
 			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "auditor::file::path must be set when provider is file")
 		}
+
+		if c.File.Chain.Enabled {
+			if c.File.Chain.SigningKeyPath == "" {
+				return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "auditor::file::chain::signing_key_path must be set when the chain is enabled")
+			}
+
+			if c.File.Chain.CheckpointInterval <= 0 {
+				return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "auditor::file::chain::checkpoint_interval must be greater than 0")
+			}
+		}
 	}
 
 	return nil
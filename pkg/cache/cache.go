@@ -13,6 +13,10 @@ type Cache interface {
 	// Set sets the cacheable entity in cache.
 	Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error
 
+	// Add sets the cacheable entity in cache if the key is not set yet and
+	// returns an already exists error otherwise.
+	Add(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error
+
 	// Get gets the cacheble entity in the dest entity passed.
 	Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable) error
 
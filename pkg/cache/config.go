@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
@@ -16,10 +19,23 @@ type Redis struct {
 	DB       int    `mapstructure:"db"`
 }
 
+// Tiered configures the tiered provider, which serves reads from an in-process
+// cache (configured by Memory) in front of redis (configured by Redis).
+type Tiered struct {
+	// L1TTL caps how long an entry is served from the in-process cache before
+	// it is read again from redis.
+	L1TTL time.Duration `mapstructure:"l1_ttl"`
+
+	// Channel is the redis pub/sub channel on which deletes are fanned out to
+	// the in-process caches of the other instances.
+	Channel string `mapstructure:"channel"`
+}
+
 type Config struct {
 	Provider string `mapstructure:"provider"`
 	Memory   Memory `mapstructure:"memory"`
 	Redis    Redis  `mapstructure:"redis"`
+	Tiered   Tiered `mapstructure:"tiered"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -39,10 +55,26 @@ func newConfig() factory.Config {
 			Password: "",
 			DB:       0,
 		},
+		Tiered: Tiered{
+			L1TTL:   time.Minute,
+			Channel: "signoz:cache:invalidate",
+		},
 	}
 
 }
 
 func (c Config) Validate() error {
+	if c.Provider != "tiered" {
+		return nil
+	}
+
+	if c.Tiered.L1TTL <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::tiered::l1_ttl must be greater than 0")
+	}
+
+	if c.Tiered.Channel == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::tiered::channel must not be empty")
+	}
+
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/cache"
@@ -22,6 +23,8 @@ type provider struct {
 	cc       *ristretto.Cache[string, any]
 	config   cache.Config
 	settings factory.ScopedProviderSettings
+	// addMu makes the check and the write of Add one step.
+	addMu sync.Mutex
 }
 
 func NewFactory() factory.ProviderFactory[cache.Cache, cache.Config] {
@@ -145,6 +148,17 @@ func (provider *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey s
 	return nil
 }
 
+func (provider *provider) Add(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	provider.addMu.Lock()
+	defer provider.addMu.Unlock()
+
+	if _, found := provider.cc.Get(strings.Join([]string{orgID.StringValue(), cacheKey}, "::")); found {
+		return errors.Newf(errors.TypeAlreadyExists, errors.CodeAlreadyExists, "key exists")
+	}
+
+	return provider.Set(ctx, orgID, cacheKey, data, ttl)
+}
+
 func (provider *provider) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable) error {
 	ctx, span := provider.settings.Tracer().Start(ctx, "memory.get", trace.WithAttributes(
 		attribute.String(semconv.AttributeDBSystem, "memory"),
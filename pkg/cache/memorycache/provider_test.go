@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory/factorytest"
 	"github.com/SigNoz/signoz/pkg/types/cachetypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
@@ -137,6 +138,24 @@ func TestCacheableSetGet(t *testing.T) {
 	assert.NotSame(t, cacheable, cached)
 }
 
+func TestAdd(t *testing.T) {
+	cache, err := New(context.Background(), factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: cache.Memory{
+		NumCounters: 10 * 1000,
+		MaxCost:     1 << 26,
+	}})
+	require.NoError(t, err)
+
+	orgID := valuer.GenerateUUID()
+	assert.NoError(t, cache.Add(context.Background(), orgID, "key", &CloneableA{Key: "first", Value: 1}, 10*time.Second))
+
+	err = cache.Add(context.Background(), orgID, "key", &CloneableA{Key: "second", Value: 2}, 10*time.Second)
+	assert.True(t, errors.Ast(err, errors.TypeAlreadyExists))
+
+	cached := new(CloneableA)
+	assert.NoError(t, cache.Get(context.Background(), orgID, "key", cached))
+	assert.Equal(t, "first", cached.Key)
+}
+
 func TestGetWithNilPointer(t *testing.T) {
 	cache, err := New(context.Background(), factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: cache.Memory{
 		NumCounters: 10 * 1000,
@@ -53,6 +53,19 @@ func (c *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey string,
 	return c.client.Set(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), data, ttl).Err()
 }
 
+func (c *provider) Add(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	added, err := c.client.SetNX(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), data, ttl).Result()
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return errors.Newf(errors.TypeAlreadyExists, errors.CodeAlreadyExists, "key exists")
+	}
+
+	return nil
+}
+
 func (c *provider) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable) error {
 	err := c.client.Get(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::")).Scan(dest)
 	if err != nil {
@@ -0,0 +1,239 @@
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/memorycache"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	tierL1 = metric.WithAttributes(attribute.String("tier", "l1"))
+	tierL2 = metric.WithAttributes(attribute.String("tier", "l2"))
+)
+
+// provider reads through an in-process ristretto cache (L1) to redis (L2).
+// Writes go to both tiers, with the L1 TTL capped by the tiered config. Deletes
+// are published on a redis channel so that every instance drops the keys from
+// its L1; the cap bounds how long an instance can serve a value which was
+// overwritten by another instance or whose invalidation it missed.
+type provider struct {
+	l1        cache.Cache
+	client    *redis.Client
+	config    cache.Config
+	origin    valuer.UUID
+	telemetry *telemetry
+	settings  factory.ScopedProviderSettings
+}
+
+// invalidation is the message published on the channel for deleted keys.
+type invalidation struct {
+	Origin    valuer.UUID `json:"origin"`
+	OrgID     valuer.UUID `json:"orgId"`
+	CacheKeys []string    `json:"cacheKeys"`
+}
+
+func NewFactory() factory.ProviderFactory[cache.Cache, cache.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("tiered"), New)
+}
+
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config cache.Config) (cache.Cache, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/cache/tieredcache")
+
+	l1, err := memorycache.New(ctx, providerSettings, config)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     strings.Join([]string{config.Redis.Host, fmt.Sprint(config.Redis.Port)}, ":"),
+		Password: config.Redis.Password,
+		DB:       config.Redis.DB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	if err := redisotel.InstrumentTracing(client, redisotel.WithTracerProvider(providerSettings.TracerProvider), redisotel.WithDBStatement(true)); err != nil {
+		return nil, err
+	}
+
+	if err := redisotel.InstrumentMetrics(client, redisotel.WithMeterProvider(providerSettings.MeterProvider)); err != nil {
+		return nil, err
+	}
+
+	telemetry, err := newMetrics(settings.Meter())
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &provider{
+		l1:        l1,
+		client:    client,
+		config:    config,
+		origin:    valuer.GenerateUUID(),
+		telemetry: telemetry,
+		settings:  settings,
+	}
+
+	// Wait for the subscription to be confirmed so that no delete published
+	// after New returns is missed.
+	pubsub := client.Subscribe(ctx, config.Tiered.Channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	go provider.invalidate(pubsub.Channel())
+
+	return provider, nil
+}
+
+func (provider *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	err := cachetypes.CheckCacheablePointer(data)
+	if err != nil {
+		return err
+	}
+
+	if err := provider.client.Set(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), data, ttl).Err(); err != nil {
+		return err
+	}
+
+	provider.setL1(ctx, orgID, cacheKey, data, ttl)
+	return nil
+}
+
+func (provider *provider) Add(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	err := cachetypes.CheckCacheablePointer(data)
+	if err != nil {
+		return err
+	}
+
+	added, err := provider.client.SetNX(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), data, ttl).Result()
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return errors.Newf(errors.TypeAlreadyExists, errors.CodeAlreadyExists, "key exists")
+	}
+
+	provider.setL1(ctx, orgID, cacheKey, data, ttl)
+	return nil
+}
+
+func (provider *provider) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable) error {
+	err := provider.l1.Get(ctx, orgID, cacheKey, dest)
+	if err == nil {
+		provider.telemetry.hits.Add(ctx, 1, tierL1)
+		return nil
+	}
+
+	if !errors.Ast(err, errors.TypeNotFound) {
+		return err
+	}
+	provider.telemetry.misses.Add(ctx, 1, tierL1)
+
+	key := strings.Join([]string{orgID.StringValue(), cacheKey}, "::")
+	pipe := provider.client.TxPipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	if err := get.Scan(dest); err != nil {
+		if errors.Is(err, redis.Nil) {
+			provider.telemetry.misses.Add(ctx, 1, tierL2)
+			return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "key miss")
+		}
+
+		return err
+	}
+	provider.telemetry.hits.Add(ctx, 1, tierL2)
+
+	// The entry must not outlive its remaining time in redis; PTTL is negative
+	// when the key has no expiry.
+	provider.setL1(ctx, orgID, cacheKey, dest, max(pttl.Val(), 0))
+	return nil
+}
+
+func (provider *provider) Delete(ctx context.Context, orgID valuer.UUID, cacheKey string) {
+	provider.DeleteMany(ctx, orgID, []string{cacheKey})
+}
+
+func (provider *provider) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) {
+	if len(cacheKeys) == 0 {
+		return
+	}
+
+	provider.l1.DeleteMany(ctx, orgID, cacheKeys)
+
+	updatedCacheKeys := []string{}
+	for _, cacheKey := range cacheKeys {
+		updatedCacheKeys = append(updatedCacheKeys, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"))
+	}
+
+	if err := provider.client.Del(ctx, updatedCacheKeys...).Err(); err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "error deleting cache keys", slog.Any("cache_keys", cacheKeys), errors.Attr(err))
+	}
+
+	message, err := json.Marshal(invalidation{Origin: provider.origin, OrgID: orgID, CacheKeys: cacheKeys})
+	if err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "error marshalling cache invalidation", slog.Any("cache_keys", cacheKeys), errors.Attr(err))
+		return
+	}
+
+	if err := provider.client.Publish(ctx, provider.config.Tiered.Channel, message).Err(); err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "error publishing cache invalidation", slog.Any("cache_keys", cacheKeys), errors.Attr(err))
+	}
+}
+
+// setL1 writes the entry to the in-process cache with its TTL capped. A write
+// rejected by ristretto drops the key instead, so that a previous value is not
+// served in its place.
+func (provider *provider) setL1(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) {
+	if ttl <= 0 || ttl > provider.config.Tiered.L1TTL {
+		ttl = provider.config.Tiered.L1TTL
+	}
+
+	if err := provider.l1.Set(ctx, orgID, cacheKey, data, ttl); err != nil {
+		provider.settings.Logger().DebugContext(ctx, "error writing to l1 cache", slog.String("cache_key", cacheKey), errors.Attr(err))
+		provider.l1.Delete(ctx, orgID, cacheKey)
+	}
+}
+
+// invalidate drops the keys deleted by other instances from the in-process
+// cache until the subscription is closed.
+func (provider *provider) invalidate(messages <-chan *redis.Message) {
+	ctx := context.Background()
+	for message := range messages {
+		var invalidation invalidation
+		if err := json.Unmarshal([]byte(message.Payload), &invalidation); err != nil {
+			provider.settings.Logger().ErrorContext(ctx, "error unmarshalling cache invalidation", errors.Attr(err))
+			continue
+		}
+
+		if invalidation.Origin == provider.origin {
+			continue
+		}
+
+		provider.l1.DeleteMany(ctx, invalidation.OrgID, invalidation.CacheKeys)
+		provider.telemetry.invalidations.Add(ctx, int64(len(invalidation.CacheKeys)))
+	}
+}
@@ -0,0 +1,191 @@
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type CloneableA struct {
+	Key    string
+	Value  int
+	Expiry time.Duration
+}
+
+func (cloneable *CloneableA) Clone() cachetypes.Cacheable {
+	return &CloneableA{
+		Key:    cloneable.Key,
+		Value:  cloneable.Value,
+		Expiry: cloneable.Expiry,
+	}
+}
+
+func (cloneable *CloneableA) Cost() int64 {
+	return int64(len(cloneable.Key)) + 16
+}
+
+func (cloneable *CloneableA) MarshalBinary() ([]byte, error) {
+	return json.Marshal(cloneable)
+}
+
+func (cloneable *CloneableA) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, cloneable)
+}
+
+func isKeyMiss(err error) bool {
+	return err != nil && errors.Ast(err, errors.TypeNotFound)
+}
+
+func newTestConfig(t *testing.T, server *miniredis.Miniredis) cache.Config {
+	t.Helper()
+
+	port, err := strconv.Atoi(server.Port())
+	require.NoError(t, err)
+
+	return cache.Config{
+		Provider: "tiered",
+		Memory:   cache.Memory{NumCounters: 10 * 1000, MaxCost: 1 << 26},
+		Redis:    cache.Redis{Host: server.Host(), Port: port},
+		Tiered:   cache.Tiered{L1TTL: time.Minute, Channel: "signoz:cache:invalidate"},
+	}
+}
+
+func newTestProvider(t *testing.T, config cache.Config) *provider {
+	t.Helper()
+
+	c, err := New(context.Background(), factorytest.NewSettings(), config)
+	require.NoError(t, err)
+	return c.(*provider)
+}
+
+func TestGetReadsThroughToRedis(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	config := newTestConfig(t, server)
+	writer := newTestProvider(t, config)
+	reader := newTestProvider(t, config)
+	orgID := valuer.GenerateUUID()
+
+	require.NoError(t, writer.Set(ctx, orgID, "key", &CloneableA{Key: "some-random-key", Value: 1}, 0))
+	assert.True(t, server.Exists(strings.Join([]string{orgID.StringValue(), "key"}, "::")))
+
+	// The reader only has the entry in redis, the first read fills its L1.
+	assert.True(t, isKeyMiss(reader.l1.Get(ctx, orgID, "key", &CloneableA{})))
+	actual := new(CloneableA)
+	require.NoError(t, reader.Get(ctx, orgID, "key", actual))
+	assert.Equal(t, &CloneableA{Key: "some-random-key", Value: 1}, actual)
+
+	// Served from L1 once redis no longer has it.
+	server.FlushAll()
+	actual = new(CloneableA)
+	require.NoError(t, reader.Get(ctx, orgID, "key", actual))
+	assert.Equal(t, 1, actual.Value)
+
+	err := reader.Get(ctx, orgID, "missing", new(CloneableA))
+	assert.True(t, isKeyMiss(err))
+}
+
+func TestAddKeepsExistingEntry(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	config := newTestConfig(t, server)
+	first := newTestProvider(t, config)
+	second := newTestProvider(t, config)
+	orgID := valuer.GenerateUUID()
+
+	require.NoError(t, first.Add(ctx, orgID, "key", &CloneableA{Key: "first", Value: 1}, 0))
+
+	// Only one instance adds the key, the entry in redis is left as is.
+	err := second.Add(ctx, orgID, "key", &CloneableA{Key: "second", Value: 2}, 0)
+	assert.True(t, errors.Ast(err, errors.TypeAlreadyExists))
+
+	actual := new(CloneableA)
+	require.NoError(t, second.Get(ctx, orgID, "key", actual))
+	assert.Equal(t, "first", actual.Key)
+}
+
+func TestGetCapsL1TTL(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	config := newTestConfig(t, server)
+	config.Tiered.L1TTL = 500 * time.Millisecond
+	c := newTestProvider(t, config)
+	orgID := valuer.GenerateUUID()
+
+	require.NoError(t, c.Set(ctx, orgID, "key", &CloneableA{Key: "some-random-key", Value: 1}, time.Hour))
+	server.FlushAll()
+
+	require.NoError(t, c.Get(ctx, orgID, "key", new(CloneableA)))
+	assert.Eventually(t, func() bool {
+		return isKeyMiss(c.Get(ctx, orgID, "key", new(CloneableA)))
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestGetKeepsRedisTTL(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	config := newTestConfig(t, server)
+	writer := newTestProvider(t, config)
+	reader := newTestProvider(t, config)
+	orgID := valuer.GenerateUUID()
+
+	require.NoError(t, writer.Set(ctx, orgID, "key", &CloneableA{Key: "some-random-key", Value: 1}, 500*time.Millisecond))
+	require.NoError(t, reader.Get(ctx, orgID, "key", new(CloneableA)))
+
+	// The L1 entry of the reader expires with the redis entry, not after the cap.
+	assert.Eventually(t, func() bool {
+		return isKeyMiss(reader.l1.Get(ctx, orgID, "key", new(CloneableA)))
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestDeleteManyFansOut(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	config := newTestConfig(t, server)
+	first := newTestProvider(t, config)
+	second := newTestProvider(t, config)
+	orgID := valuer.GenerateUUID()
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, first.Set(ctx, orgID, key, &CloneableA{Key: key, Value: 1}, 0))
+		require.NoError(t, second.Get(ctx, orgID, key, new(CloneableA)))
+	}
+
+	first.DeleteMany(ctx, orgID, []string{"a", "b"})
+	first.Delete(ctx, valuer.GenerateUUID(), "c")
+
+	assert.True(t, isKeyMiss(first.l1.Get(ctx, orgID, "a", new(CloneableA))))
+	assert.False(t, server.Exists(strings.Join([]string{orgID.StringValue(), "a"}, "::")))
+	assert.Eventually(t, func() bool {
+		return isKeyMiss(second.l1.Get(ctx, orgID, "a", new(CloneableA))) &&
+			isKeyMiss(second.l1.Get(ctx, orgID, "b", new(CloneableA)))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Keys of another org are left alone.
+	require.NoError(t, second.l1.Get(ctx, orgID, "c", new(CloneableA)))
+}
+
+func TestConfigValidate(t *testing.T) {
+	server := miniredis.RunT(t)
+	config := newTestConfig(t, server)
+	assert.NoError(t, config.Validate())
+
+	config.Tiered.L1TTL = 0
+	assert.Error(t, config.Validate())
+
+	config.Provider = "memory"
+	assert.NoError(t, config.Validate())
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Module queries the audit events stored in the audit logs of an organization.
+type Module interface {
+	// ListEvents returns a page of the events matching the params, most recent first.
+	ListEvents(context.Context, valuer.UUID, *audittypes.ListAuditEventsParams) (*audittypes.GettableAuditEvents, error)
+
+	// GetResourceTimeline returns a page of the events of a single resource, oldest first.
+	GetResourceTimeline(context.Context, valuer.UUID, coretypes.Kind, string, *audittypes.GetAuditResourceTimelineParams) (*audittypes.GettableAuditEvents, error)
+
+	// ExportEvents returns the events matching the filter, most recent first, up to
+	// audittypes.MaxExportedAuditEvents. The bool is false if there were more events.
+	ExportEvents(context.Context, valuer.UUID, *audittypes.AuditEventsFilter) ([]*audittypes.GettableAuditEvent, bool, error)
+}
+
+// Handler defines the HTTP handler methods for the audit trail API endpoints.
+type Handler interface {
+	// ListEvents handles requests to search audit events.
+	ListEvents(http.ResponseWriter, *http.Request)
+
+	// GetResourceTimeline handles requests for the change timeline of a resource.
+	GetResourceTimeline(http.ResponseWriter, *http.Request)
+
+	// ExportEvents handles requests to download the audit events as CSV or JSONL.
+	ExportEvents(http.ResponseWriter, *http.Request)
+}
@@ -0,0 +1,181 @@
+package implaudit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/binding"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/modules/audit"
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	module audit.Module
+}
+
+func NewHandler(module audit.Module) audit.Handler {
+	return &handler{module: module}
+}
+
+func (handler *handler) ListEvents(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var params audittypes.ListAuditEventsParams
+	if err := binding.Query.BindQuery(r.URL.Query(), &params); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	events, err := handler.module.ListEvents(r.Context(), valuer.MustNewUUID(claims.OrgID), &params)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, events)
+}
+
+func (handler *handler) GetResourceTimeline(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	kind, err := coretypes.NewKind(mux.Vars(r)["kind"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "id is missing from the path"))
+		return
+	}
+
+	var params audittypes.GetAuditResourceTimelineParams
+	if err := binding.Query.BindQuery(r.URL.Query(), &params); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	events, err := handler.module.GetResourceTimeline(r.Context(), valuer.MustNewUUID(claims.OrgID), kind, id, &params)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, events)
+}
+
+func (handler *handler) ExportEvents(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var params audittypes.ExportAuditEventsParams
+	if err := binding.Query.BindQuery(r.URL.Query(), &params); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	events, isComplete, err := handler.module.ExportEvents(r.Context(), valuer.MustNewUUID(claims.OrgID), &params.AuditEventsFilter)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	filename := fmt.Sprintf("audit_events_%s.%s", time.Now().Format("2006-01-02_150405"), params.Format.StringValue())
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	rw.Header().Set("Access-Control-Expose-Headers", "Content-Disposition, X-Response-Complete")
+	rw.Header().Set("X-Response-Complete", strconv.FormatBool(isComplete))
+
+	switch params.Format {
+	case audittypes.AuditExportFormatJSONL:
+		rw.Header().Set("Content-Type", "application/x-ndjson")
+		rw.WriteHeader(http.StatusOK)
+		_ = writeJSONL(rw, events)
+	default:
+		rw.Header().Set("Content-Type", "text/csv")
+		rw.WriteHeader(http.StatusOK)
+		_ = writeCSV(rw, events)
+	}
+}
+
+func writeCSV(writer io.Writer, events []*audittypes.GettableAuditEvent) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(audittypes.AuditEventCSVHeader); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		record := event.CSVRecord()
+		for i := range record {
+			record[i] = sanitizeForCSV(record[i])
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func writeJSONL(writer io.Writer, events []*audittypes.GettableAuditEvent) error {
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sanitizeForCSV prefixes values which spreadsheets would evaluate as formulas
+// with a single quote. Audit events carry user input such as emails and
+// resource IDs.
+func sanitizeForCSV(value string) string {
+	trimmed := strings.TrimLeft(value, " \t\r\n")
+	if trimmed != "" && strings.ContainsRune("=+-@", rune(trimmed[0])) {
+		return "'" + value
+	}
+
+	return value
+}
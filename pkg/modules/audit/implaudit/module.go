@@ -0,0 +1,120 @@
+package implaudit
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/audit"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// exportChunkSize is the number of events fetched per query while exporting.
+const exportChunkSize = audittypes.MaxAuditEventsLimit
+
+type module struct {
+	querier querier.Querier
+}
+
+func NewModule(querier querier.Querier) audit.Module {
+	return &module{querier: querier}
+}
+
+func (m *module) ListEvents(ctx context.Context, orgID valuer.UUID, params *audittypes.ListAuditEventsParams) (*audittypes.GettableAuditEvents, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "audit",
+		instrumentationtypes.CodeFunctionName: "ListEvents",
+	})
+
+	return m.listEvents(ctx, orgID, &params.AuditEventsFilter, qbtypes.OrderDirectionDesc, params.Limit, params.Offset)
+}
+
+func (m *module) GetResourceTimeline(ctx context.Context, orgID valuer.UUID, kind coretypes.Kind, id string, params *audittypes.GetAuditResourceTimelineParams) (*audittypes.GettableAuditEvents, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "audit",
+		instrumentationtypes.CodeFunctionName: "GetResourceTimeline",
+	})
+
+	filter := audittypes.NewAuditResourceTimelineFilter(kind, id, params)
+	return m.listEvents(ctx, orgID, &filter, qbtypes.OrderDirectionAsc, params.Limit, params.Offset)
+}
+
+func (m *module) ExportEvents(ctx context.Context, orgID valuer.UUID, filter *audittypes.AuditEventsFilter) ([]*audittypes.GettableAuditEvent, bool, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "audit",
+		instrumentationtypes.CodeFunctionName: "ExportEvents",
+	})
+
+	events := make([]*audittypes.GettableAuditEvent, 0)
+	for {
+		limit := min(exportChunkSize, audittypes.MaxExportedAuditEvents-len(events))
+		page, err := m.listEvents(ctx, orgID, filter, qbtypes.OrderDirectionDesc, limit, len(events))
+		if err != nil {
+			return nil, false, err
+		}
+
+		events = append(events, page.Events...)
+		if !page.HasMore {
+			return events, true, nil
+		}
+
+		if len(events) >= audittypes.MaxExportedAuditEvents {
+			return events, false, nil
+		}
+	}
+}
+
+// listEvents fetches one more event than the limit to tell whether there are more.
+func (m *module) listEvents(ctx context.Context, orgID valuer.UUID, filter *audittypes.AuditEventsFilter, direction qbtypes.OrderDirection, limit int, offset int) (*audittypes.GettableAuditEvents, error) {
+	response, err := m.querier.QueryRange(ctx, orgID, newQueryRangeRequest(orgID, filter, direction, limit+1, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*audittypes.GettableAuditEvent, 0, limit)
+	for _, result := range response.Data.Results {
+		rawData, ok := result.(*qbtypes.RawData)
+		if !ok {
+			return nil, errors.NewInternalf(errors.CodeInternal, "expected RawData, got %T", result)
+		}
+
+		for _, row := range rawData.Rows {
+			events = append(events, audittypes.NewGettableAuditEventFromRawRow(row))
+		}
+	}
+
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+
+	return &audittypes.GettableAuditEvents{Events: events, HasMore: hasMore}, nil
+}
+
+func newQueryRangeRequest(orgID valuer.UUID, filter *audittypes.AuditEventsFilter, direction qbtypes.OrderDirection, limit int, offset int) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:       uint64(filter.Start),
+		End:         uint64(filter.End),
+		RequestType: qbtypes.RequestTypeRaw,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{{
+				Type: qbtypes.QueryTypeBuilder,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+					Name:   "A",
+					Signal: telemetrytypes.SignalLogs,
+					Source: telemetrytypes.SourceAudit,
+					Filter: &qbtypes.Filter{Expression: filter.NewFilterExpression(orgID)},
+					Order:  qbtypes.LogsListOrder(direction),
+					Limit:  limit,
+					Offset: offset,
+				},
+			}},
+		},
+	}
+}
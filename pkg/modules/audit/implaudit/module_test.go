@@ -0,0 +1,115 @@
+package implaudit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/audittypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuerier returns rows rows, paged by the limit and offset of the first
+// query, and records the requests it receives.
+type fakeQuerier struct {
+	rows     int
+	requests []*qbtypes.QueryRangeRequest
+}
+
+func (q *fakeQuerier) QueryRange(_ context.Context, _ valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	q.requests = append(q.requests, req)
+
+	query := req.CompositeQuery.Queries[0]
+	rows := make([]*qbtypes.RawRow, 0)
+	for idx := query.GetOffset(); idx < min(q.rows, query.GetOffset()+query.GetLimit()); idx++ {
+		rows = append(rows, &qbtypes.RawRow{Data: map[string]any{"id": fmt.Sprintf("%d", idx)}})
+	}
+
+	return &qbtypes.QueryRangeResponse{Data: qbtypes.QueryData{Results: []any{&qbtypes.RawData{Rows: rows}}}}, nil
+}
+
+func (q *fakeQuerier) QueryRawStream(context.Context, valuer.UUID, *qbtypes.QueryRangeRequest, *qbtypes.RawStream) {
+}
+
+func (q *fakeQuerier) PurgeCache(context.Context, valuer.UUID, *qbtypes.PostableCachePurge) error {
+	return nil
+}
+
+func TestListEvents(t *testing.T) {
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+
+	querier := &fakeQuerier{rows: 5}
+	module := NewModule(querier)
+
+	params := &audittypes.ListAuditEventsParams{
+		AuditEventsFilter: audittypes.AuditEventsFilter{Start: 1000, End: 2000, Outcome: audittypes.OutcomeFailure},
+		Limit:             3,
+	}
+
+	events, err := module.ListEvents(ctx, orgID, params)
+	require.NoError(t, err)
+	assert.Len(t, events.Events, 3)
+	assert.True(t, events.HasMore)
+
+	params.Offset = 3
+	events, err = module.ListEvents(ctx, orgID, params)
+	require.NoError(t, err)
+	assert.Len(t, events.Events, 2)
+	assert.False(t, events.HasMore)
+
+	require.Len(t, querier.requests, 2)
+	request := querier.requests[0]
+	assert.Equal(t, uint64(1000), request.Start)
+	assert.Equal(t, uint64(2000), request.End)
+	assert.Equal(t, qbtypes.RequestTypeRaw, request.RequestType)
+
+	spec, ok := request.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation])
+	require.True(t, ok)
+	assert.Equal(t, telemetrytypes.SourceAudit, spec.Source)
+	assert.Equal(t, 4, spec.Limit)
+	assert.Equal(t, fmt.Sprintf("attribute.signoz.audit.principal.org_id = '%s' AND attribute.signoz.audit.outcome = 'failure'", orgID.StringValue()), spec.Filter.Expression)
+	assert.Equal(t, qbtypes.OrderDirectionDesc, spec.Order[0].Direction)
+}
+
+func TestGetResourceTimeline(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	querier := &fakeQuerier{rows: 1}
+
+	_, err := NewModule(querier).GetResourceTimeline(context.Background(), orgID, coretypes.MustNewKind("dashboard"), "dashboard-1", &audittypes.GetAuditResourceTimelineParams{Start: 1000, End: 2000, Limit: 10})
+	require.NoError(t, err)
+
+	spec := querier.requests[0].CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation])
+	assert.Equal(t, fmt.Sprintf("attribute.signoz.audit.principal.org_id = '%s' AND resource.signoz.audit.resource.kind = 'dashboard' AND resource.signoz.audit.resource.id = 'dashboard-1'", orgID.StringValue()), spec.Filter.Expression)
+	assert.Equal(t, qbtypes.OrderDirectionAsc, spec.Order[0].Direction)
+}
+
+func TestExportEvents(t *testing.T) {
+	testCases := []struct {
+		name       string
+		rows       int
+		events     int
+		isComplete bool
+	}{
+		{name: "Empty", rows: 0, events: 0, isComplete: true},
+		{name: "SeveralChunks", rows: 2500, events: 2500, isComplete: true},
+		{name: "ExactlyAtCap", rows: audittypes.MaxExportedAuditEvents, events: audittypes.MaxExportedAuditEvents, isComplete: true},
+		{name: "Capped", rows: audittypes.MaxExportedAuditEvents + 1, events: audittypes.MaxExportedAuditEvents, isComplete: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			module := NewModule(&fakeQuerier{rows: testCase.rows})
+
+			events, isComplete, err := module.ExportEvents(context.Background(), valuer.GenerateUUID(), &audittypes.AuditEventsFilter{Start: 1000, End: 2000})
+			require.NoError(t, err)
+			assert.Len(t, events, testCase.events)
+			assert.Equal(t, testCase.isComplete, isComplete)
+		})
+	}
+}
@@ -66,6 +66,17 @@ type Module interface {
 	LockUnlockV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, isAdmin bool, lock bool) error
 
 	PatchV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, patch dashboardtypes.PatchableDashboardV2) (*dashboardtypes.DashboardV2, error)
+
+	// lists the revisions of the dashboard, latest first
+	ListRevisionsV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID) ([]*dashboardtypes.DashboardRevision, error)
+
+	GetRevisionV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int) (*dashboardtypes.DashboardRevision, error)
+
+	// computes the JSON patch operations which turn one revision of the dashboard into another
+	DiffRevisionsV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, from int, to int) ([]dashboardtypes.JSONPatchOperation, error)
+
+	// restores the dashboard to the given revision, recording the restore as a new revision
+	RestoreRevisionV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int, updatedBy string) (*dashboardtypes.DashboardV2, error)
 }
 
 type Handler interface {
@@ -103,4 +114,14 @@ type Handler interface {
 	UnlockV2(http.ResponseWriter, *http.Request)
 
 	PatchV2(http.ResponseWriter, *http.Request)
+
+	ImportGrafanaV2(http.ResponseWriter, *http.Request)
+
+	ListRevisionsV2(http.ResponseWriter, *http.Request)
+
+	GetRevisionV2(http.ResponseWriter, *http.Request)
+
+	DiffRevisionsV2(http.ResponseWriter, *http.Request)
+
+	RestoreRevisionV2(http.ResponseWriter, *http.Request)
 }
@@ -19,6 +19,10 @@ import (
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
+// maxRevisionAttempts is the number of times a change is tried when concurrent
+// changes keep taking the version of its revision.
+const maxRevisionAttempts = 3
+
 type module struct {
 	store       dashboardtypes.Store
 	settings    factory.ScopedProviderSettings
@@ -51,7 +55,13 @@ func (module *module) Create(ctx context.Context, orgID valuer.UUID, createdBy s
 		return nil, err
 	}
 
-	err = module.store.Create(ctx, storableDashboard)
+	err = module.store.RunInTx(ctx, func(ctx context.Context) error {
+		if err := module.store.Create(ctx, storableDashboard); err != nil {
+			return err
+		}
+
+		return module.createRevision(ctx, nil, dashboardtypes.NewDashboardRevisionFromStorableDashboard(storableDashboard, dashboardtypes.DashboardRevisionKindCreate))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -88,26 +98,40 @@ func (module *module) List(ctx context.Context, orgID valuer.UUID) ([]*dashboard
 }
 
 func (module *module) Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, updatableDashboard dashboardtypes.UpdatableDashboard, diff int) (*dashboardtypes.Dashboard, error) {
-	dashboard, err := module.Get(ctx, orgID, id)
-	if err != nil {
-		return nil, err
-	}
+	var (
+		existing          *dashboardtypes.StorableDashboard
+		dashboard         *dashboardtypes.Dashboard
+		storableDashboard *dashboardtypes.StorableDashboard
+	)
+
+	load := func(ctx context.Context) error {
+		var err error
+		existing, err = module.store.Get(ctx, orgID, id)
+		if err != nil {
+			return err
+		}
 
-	if err := dashboard.ErrIfNotMutable(); err != nil {
-		return nil, err
-	}
+		dashboard = dashboardtypes.NewDashboardFromStorableDashboard(existing)
+		if err := dashboard.ErrIfNotMutable(); err != nil {
+			return err
+		}
 
-	err = dashboard.Update(ctx, updatableDashboard, updatedBy, diff)
-	if err != nil {
-		return nil, err
-	}
+		if err := dashboard.Update(ctx, updatableDashboard, updatedBy, diff); err != nil {
+			return err
+		}
 
-	storableDashboard, err := dashboardtypes.NewStorableDashboardFromDashboard(dashboard)
-	if err != nil {
-		return nil, err
+		storableDashboard, err = dashboardtypes.NewStorableDashboardFromDashboard(dashboard)
+		return err
 	}
 
-	err = module.store.Update(ctx, orgID, storableDashboard)
+	err := module.runInRevisionTx(ctx, load, func(ctx context.Context) error {
+		if err := module.store.Update(ctx, orgID, storableDashboard); err != nil {
+			return err
+		}
+
+		baseline := dashboardtypes.NewBaselineDashboardRevisionFromStorableDashboard(existing)
+		return module.createRevision(ctx, baseline, dashboardtypes.NewDashboardRevisionFromStorableDashboard(storableDashboard, dashboardtypes.DashboardRevisionKindUpdate))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -375,3 +399,53 @@ func (module *module) checkPromQLQueriesForMetricNames(ctx context.Context, quer
 		}
 	}
 }
+
+// runInRevisionTx loads the dashboard and applies the change with load, then
+// runs the callback, which records a revision, in a transaction. A concurrent
+// change to the dashboard can take the version given to the revision, the
+// dashboard is then loaded again and the change applied on top of that change.
+func (module *module) runInRevisionTx(ctx context.Context, load func(ctx context.Context) error, cb func(ctx context.Context) error) error {
+	var err error
+	for range maxRevisionAttempts {
+		if err := load(ctx); err != nil {
+			return err
+		}
+
+		err = module.store.RunInTx(ctx, cb)
+		if err == nil || !errors.Asc(err, dashboardtypes.ErrCodeDashboardRevisionConflict) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// createRevision records the revision as the next one of its dashboard and prunes the revisions past the retention limit.
+// When the dashboard has no history yet, the baseline, its state before the change, is recorded first so that it can be restored.
+func (module *module) createRevision(ctx context.Context, baseline *dashboardtypes.DashboardRevision, revision *dashboardtypes.DashboardRevision) error {
+	revision.Version = 1
+	latest, err := module.store.GetLatestRevision(ctx, revision.OrgID, revision.DashboardID)
+	switch {
+	case err == nil:
+		revision.Version = latest.Version + 1
+	case errors.Ast(err, errors.TypeNotFound):
+		if baseline != nil {
+			if err := module.store.CreateRevision(ctx, baseline.ToStorableDashboardRevision()); err != nil {
+				return err
+			}
+			revision.Version = 2
+		}
+	default:
+		return err
+	}
+
+	if err := module.store.CreateRevision(ctx, revision.ToStorableDashboardRevision()); err != nil {
+		return err
+	}
+
+	if revision.Version > dashboardtypes.MaxRevisionsPerDashboard {
+		return module.store.DeleteRevisionsBefore(ctx, revision.OrgID, revision.DashboardID, revision.Version-dashboardtypes.MaxRevisionsPerDashboard+1)
+	}
+
+	return nil
+}
@@ -182,31 +182,131 @@ func (store *store) UpdatePublic(ctx context.Context, storable *dashboardtypes.S
 }
 
 func (store *store) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	return store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		_, err := store.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model(new(dashboardtypes.StorableDashboardRevision)).
+			Where("dashboard_id = ?", id).
+			Where("org_id = ?", orgID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = store.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model(new(dashboardtypes.StorableDashboard)).
+			Where("id = ?", id).
+			Where("org_id = ?", orgID).
+			Exec(ctx)
+		if err != nil {
+			return store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "dashboard with id %s doesn't exist", id)
+		}
+
+		return nil
+	})
+}
+
+func (store *store) DeletePublic(ctx context.Context, dashboardID string) error {
 	_, err := store.
 		sqlstore.
 		BunDBCtx(ctx).
 		NewDelete().
-		Model(new(dashboardtypes.StorableDashboard)).
-		Where("id = ?", id).
-		Where("org_id = ?", orgID).
+		Model(new(dashboardtypes.StorablePublicDashboard)).
+		Where("dashboard_id = ?", dashboardID).
 		Exec(ctx)
 	if err != nil {
-		return store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "dashboard with id %s doesn't exist", id)
+		return store.sqlstore.WrapNotFoundErrf(err, dashboardtypes.ErrCodePublicDashboardNotFound, "dashboard with id %s isn't public", dashboardID)
 	}
 
 	return nil
 }
 
-func (store *store) DeletePublic(ctx context.Context, dashboardID string) error {
+func (store *store) CreateRevision(ctx context.Context, storable *dashboardtypes.StorableDashboardRevision) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(storable).
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, dashboardtypes.ErrCodeDashboardRevisionConflict, "dashboard with id %s was changed concurrently, please retry", storable.DashboardID)
+	}
+
+	return nil
+}
+
+func (store *store) GetRevision(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID, version int) (*dashboardtypes.StorableDashboardRevision, error) {
+	storable := new(dashboardtypes.StorableDashboardRevision)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(storable).
+		Where("dashboard_id = ?", dashboardID).
+		Where("org_id = ?", orgID).
+		Where("version = ?", version).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, dashboardtypes.ErrCodeDashboardRevisionNotFound, "revision %d of dashboard with id %s doesn't exist", version, dashboardID)
+	}
+
+	return storable, nil
+}
+
+func (store *store) GetLatestRevision(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) (*dashboardtypes.StorableDashboardRevision, error) {
+	storable := new(dashboardtypes.StorableDashboardRevision)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(storable).
+		Where("dashboard_id = ?", dashboardID).
+		Where("org_id = ?", orgID).
+		Order("version DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, dashboardtypes.ErrCodeDashboardRevisionNotFound, "dashboard with id %s has no revisions", dashboardID)
+	}
+
+	return storable, nil
+}
+
+func (store *store) ListRevisions(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) ([]*dashboardtypes.StorableDashboardRevision, error) {
+	storables := make([]*dashboardtypes.StorableDashboardRevision, 0)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&storables).
+		Where("dashboard_id = ?", dashboardID).
+		Where("org_id = ?", orgID).
+		Order("version DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return storables, nil
+}
+
+func (store *store) DeleteRevisionsBefore(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID, version int) error {
 	_, err := store.
 		sqlstore.
 		BunDBCtx(ctx).
 		NewDelete().
-		Model(new(dashboardtypes.StorablePublicDashboard)).
+		Model(new(dashboardtypes.StorableDashboardRevision)).
 		Where("dashboard_id = ?", dashboardID).
+		Where("org_id = ?", orgID).
+		Where("version < ?", version).
 		Exec(ctx)
 	if err != nil {
-		return store.sqlstore.WrapNotFoundErrf(err, dashboardtypes.ErrCodePublicDashboardNotFound, "dashboard with id %s isn't public", dashboardID)
+		return err
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package impldashboard
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
@@ -205,3 +206,185 @@ func (handler *handler) PatchV2(rw http.ResponseWriter, r *http.Request) {
 
 	render.Success(rw, http.StatusOK, dashboard.ToGettableDashboardV2())
 }
+
+func (handler *handler) ImportGrafanaV2(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID := valuer.MustNewUUID(claims.OrgID)
+
+	req := dashboardtypes.GrafanaDashboard{}
+	if err := binding.JSON.BindBody(r.Body, &req); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable, report, err := req.ToPostableDashboardV2()
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	dashboard, err := handler.module.CreateV2(ctx, orgID, claims.Email, valuer.MustNewUUID(claims.IdentityID()), dashboardtypes.SourceUser, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, dashboardtypes.GettableGrafanaDashboardImport{Dashboard: dashboard.ToGettableDashboardV2(), Report: *report})
+}
+
+func (handler *handler) ListRevisionsV2(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID := valuer.MustNewUUID(claims.OrgID)
+
+	dashboardID, err := dashboardIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	revisions, err := handler.module.ListRevisionsV2(ctx, orgID, dashboardID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	gettable := make([]dashboardtypes.GettableDashboardRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		gettable = append(gettable, revision.ToGettableDashboardRevision())
+	}
+
+	render.Success(rw, http.StatusOK, gettable)
+}
+
+func (handler *handler) GetRevisionV2(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID := valuer.MustNewUUID(claims.OrgID)
+
+	dashboardID, err := dashboardIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	version, err := revisionVersionFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	revision, err := handler.module.GetRevisionV2(ctx, orgID, dashboardID, version)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, revision.ToGettableDashboardRevisionWithData())
+}
+
+func (handler *handler) DiffRevisionsV2(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID := valuer.MustNewUUID(claims.OrgID)
+
+	dashboardID, err := dashboardIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var params dashboardtypes.DashboardRevisionDiffParams
+	if err := binding.Query.BindQuery(r.URL.Query(), &params); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	ops, err := handler.module.DiffRevisionsV2(ctx, orgID, dashboardID, params.From, params.To)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, dashboardtypes.GettableDashboardRevisionDiff{From: params.From, To: params.To, Ops: ops})
+}
+
+func (handler *handler) RestoreRevisionV2(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID := valuer.MustNewUUID(claims.OrgID)
+
+	dashboardID, err := dashboardIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	version, err := revisionVersionFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	dashboard, err := handler.module.RestoreRevisionV2(ctx, orgID, dashboardID, version, claims.Email)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, dashboard.ToGettableDashboardV2())
+}
+
+func dashboardIDFromPath(r *http.Request) (valuer.UUID, error) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		return valuer.UUID{}, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is missing in the path")
+	}
+
+	return valuer.NewUUID(id)
+}
+
+func revisionVersionFromPath(r *http.Request) (int, error) {
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil || version < 1 {
+		return 0, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "version must be a positive integer")
+	}
+
+	return version, nil
+}
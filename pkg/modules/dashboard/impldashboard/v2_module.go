@@ -32,7 +32,16 @@ func (m *module) CreateV2(ctx context.Context, orgID valuer.UUID, createdBy stri
 			return err
 		}
 		storableDashboard = storable
-		return m.store.Create(ctx, storable)
+		if err := m.store.Create(ctx, storable); err != nil {
+			return err
+		}
+
+		revision, err := dashboardtypes.NewDashboardRevision(dashboard, dashboardtypes.DashboardRevisionKindCreate)
+		if err != nil {
+			return err
+		}
+
+		return m.createRevision(ctx, nil, revision)
 	})
 	if err != nil {
 		return nil, err
@@ -61,62 +70,114 @@ func (module *module) UpdateV2(ctx context.Context, orgID valuer.UUID, id valuer
 		return nil, err
 	}
 
-	existing, err := module.GetV2(ctx, orgID, id)
-	if err != nil {
+	return module.updateV2(ctx, orgID, id, updatedBy, dashboardtypes.DashboardRevisionKindUpdate, func(*dashboardtypes.DashboardV2) (*dashboardtypes.UpdatableDashboardV2, error) {
+		return &updatable, nil
+	})
+}
+
+func (module *module) PatchV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, patch dashboardtypes.PatchableDashboardV2) (*dashboardtypes.DashboardV2, error) {
+	return module.updateV2(ctx, orgID, id, updatedBy, dashboardtypes.DashboardRevisionKindPatch, patch.Apply)
+}
+
+func (module *module) ListRevisionsV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID) ([]*dashboardtypes.DashboardRevision, error) {
+	// Resolves the dashboard first so that a missing dashboard is reported as such.
+	if _, err := module.store.Get(ctx, orgID, id); err != nil {
 		return nil, err
 	}
-	// Locked-dashboard / state gate — independent of tags, so run it before the tx.
-	if err := existing.CanUpdate(); err != nil {
+
+	storables, err := module.store.ListRevisions(ctx, orgID, id)
+	if err != nil {
 		return nil, err
 	}
 
-	err = module.store.RunInTx(ctx, func(ctx context.Context) error {
-		resolvedTags, err := module.tagModule.SyncTags(ctx, orgID, coretypes.KindDashboard, id, updatable.Tags)
-		if err != nil {
-			return err
-		}
-
-		err = existing.Update(updatable, updatedBy, resolvedTags)
-		if err != nil {
-			return err
-		}
+	revisions := make([]*dashboardtypes.DashboardRevision, 0, len(storables))
+	for _, storable := range storables {
+		revisions = append(revisions, storable.ToDashboardRevision())
+	}
 
-		storable, err := existing.ToStorableDashboard()
-		if err != nil {
-			return err
-		}
+	return revisions, nil
+}
 
-		return module.store.Update(ctx, orgID, storable)
-	})
+func (module *module) GetRevisionV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int) (*dashboardtypes.DashboardRevision, error) {
+	storable, err := module.store.GetRevision(ctx, orgID, id, version)
 	if err != nil {
 		return nil, err
 	}
 
-	return existing, nil
+	return storable.ToDashboardRevision(), nil
 }
 
-func (module *module) PatchV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, patch dashboardtypes.PatchableDashboardV2) (*dashboardtypes.DashboardV2, error) {
-	existing, err := module.GetV2(ctx, orgID, id)
+func (module *module) DiffRevisionsV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, from int, to int) ([]dashboardtypes.JSONPatchOperation, error) {
+	fromRevision, err := module.GetRevisionV2(ctx, orgID, id, from)
 	if err != nil {
 		return nil, err
 	}
-	// Locked-dashboard / state gate — independent of tags, so run it before the tx.
-	if err := existing.CanUpdate(); err != nil {
-		return nil, err
-	}
 
-	updateable, err := patch.Apply(existing)
+	toRevision, err := module.GetRevisionV2(ctx, orgID, id, to)
 	if err != nil {
 		return nil, err
 	}
 
-	err = module.store.RunInTx(ctx, func(ctx context.Context) error {
-		resolvedTags, err := module.tagModule.SyncTags(ctx, orgID, coretypes.KindDashboard, id, updateable.Tags)
+	return fromRevision.Diff(toRevision)
+}
+
+func (module *module) RestoreRevisionV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int, updatedBy string) (*dashboardtypes.DashboardV2, error) {
+	return module.updateV2(ctx, orgID, id, updatedBy, dashboardtypes.DashboardRevisionKindRestore, func(*dashboardtypes.DashboardV2) (*dashboardtypes.UpdatableDashboardV2, error) {
+		revision, err := module.GetRevisionV2(ctx, orgID, id, version)
+		if err != nil {
+			return nil, err
+		}
+
+		updatable, err := revision.UpdatableDashboardV2()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := updatable.Validate(); err != nil {
+			return nil, err
+		}
+
+		return &updatable, nil
+	})
+}
+
+// updateV2 applies the updatable returned for the existing dashboard and records the result as a new revision.
+// The dashboard is loaded again, and the updatable asked for again, whenever a concurrent change wins the revision.
+func (module *module) updateV2(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, kind dashboardtypes.DashboardRevisionKind, updatableFor func(*dashboardtypes.DashboardV2) (*dashboardtypes.UpdatableDashboardV2, error)) (*dashboardtypes.DashboardV2, error) {
+	var (
+		existing  *dashboardtypes.DashboardV2
+		updatable *dashboardtypes.UpdatableDashboardV2
+		baseline  *dashboardtypes.DashboardRevision
+	)
+
+	load := func(ctx context.Context) error {
+		var err error
+		existing, err = module.GetV2(ctx, orgID, id)
 		if err != nil {
 			return err
 		}
 
-		err = existing.Update(*updateable, updatedBy, resolvedTags)
+		// Locked-dashboard / state gate — independent of tags, so run it before the tx.
+		if err := existing.CanUpdate(); err != nil {
+			return err
+		}
+
+		updatable, err = updatableFor(existing)
+		if err != nil {
+			return err
+		}
+
+		baseline, err = dashboardtypes.NewBaselineDashboardRevision(existing)
+		return err
+	}
+
+	err := module.runInRevisionTx(ctx, load, func(ctx context.Context) error {
+		resolvedTags, err := module.tagModule.SyncTags(ctx, existing.OrgID, coretypes.KindDashboard, existing.ID, updatable.Tags)
+		if err != nil {
+			return err
+		}
+
+		err = existing.Update(*updatable, updatedBy, resolvedTags)
 		if err != nil {
 			return err
 		}
@@ -126,7 +187,16 @@ func (module *module) PatchV2(ctx context.Context, orgID valuer.UUID, id valuer.
 			return err
 		}
 
-		return module.store.Update(ctx, orgID, storable)
+		if err := module.store.Update(ctx, existing.OrgID, storable); err != nil {
+			return err
+		}
+
+		revision, err := dashboardtypes.NewDashboardRevision(existing, kind)
+		if err != nil {
+			return err
+		}
+
+		return module.createRevision(ctx, baseline, revision)
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,348 @@
+package impldashboard
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz/pkg/analytics"
+	"github.com/SigNoz/signoz/pkg/analytics/noopanalytics"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard"
+	"github.com/SigNoz/signoz/pkg/modules/tag/impltag"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	"github.com/SigNoz/signoz/pkg/types/tagtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const testPostableDashboardJSON = `{
+	"schemaVersion": "v6",
+	"name": "service-overview",
+	"tags": [{"key": "team", "value": "alpha"}],
+	"spec": {
+		"display": {"name": "Service overview"},
+		"panels": {
+			"p1": {
+				"kind": "Panel",
+				"spec": {
+					"plugin": {"kind": "signoz/TimeSeriesPanel", "spec": {}},
+					"queries": [
+						{
+							"kind": "time_series",
+							"spec": {"plugin": {"kind": "signoz/PromQLQuery", "spec": {"name": "A", "query": "up"}}}
+						}
+					]
+				}
+			}
+		},
+		"layouts": [
+			{
+				"kind": "Grid",
+				"spec": {
+					"items": [
+						{"x": 0, "y": 0, "width": 6, "height": 6, "content": {"$ref": "#/spec/panels/p1"}}
+					]
+				}
+			}
+		],
+		"duration": "1h"
+	}
+}`
+
+func newTestModule(t *testing.T) (dashboard.Module, sqlstore.SQLStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	store, err := sqlitesqlstore.New(ctx, factorytest.NewSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Connection: sqlstore.ConnectionConfig{
+			MaxOpenConns: 1,
+		},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:            filepath.Join(t.TempDir(), "test.db"),
+			Mode:            "wal",
+			BusyTimeout:     5 * time.Second,
+			TransactionMode: "deferred",
+		},
+	})
+	require.NoError(t, err)
+
+	for _, model := range []any{(*dashboardtypes.StorableDashboard)(nil), (*dashboardtypes.StorableDashboardRevision)(nil), (*tagtypes.Tag)(nil), (*tagtypes.TagRelation)(nil)} {
+		_, err = store.BunDB().NewCreateTable().Model(model).IfNotExists().Exec(ctx)
+		require.NoError(t, err)
+	}
+	for _, index := range []string{
+		`CREATE UNIQUE INDEX uq_tag_org_kind_lower_key_lower_value ON tag (org_id, kind, LOWER(key), LOWER(value))`,
+		`CREATE UNIQUE INDEX uq_tag_relation_kind_resource_id_tag_id ON tag_relation (kind, resource_id, tag_id)`,
+		`CREATE UNIQUE INDEX uq_dashboard_revision_dashboard_id_version ON dashboard_revision (dashboard_id, version)`,
+	} {
+		_, err = store.BunDB().ExecContext(ctx, index)
+		require.NoError(t, err)
+	}
+
+	analytics, err := noopanalytics.New(ctx, factorytest.NewSettings(), analytics.Config{})
+	require.NoError(t, err)
+
+	return NewModule(NewStore(store), factorytest.NewSettings(), analytics, nil, nil, impltag.NewModule(impltag.NewStore(store))), store
+}
+
+func newTestDashboard(t *testing.T, module dashboard.Module, orgID valuer.UUID) *dashboardtypes.DashboardV2 {
+	t.Helper()
+
+	var postable dashboardtypes.PostableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(testPostableDashboardJSON), &postable))
+
+	dashboard, err := module.CreateV2(context.Background(), orgID, "creator@signoz.io", valuer.GenerateUUID(), dashboardtypes.SourceUser, postable)
+	require.NoError(t, err)
+	return dashboard
+}
+
+func renameDashboard(t *testing.T, module dashboard.Module, orgID valuer.UUID, id valuer.UUID, displayName string) {
+	t.Helper()
+
+	var patch dashboardtypes.PatchableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(`[{"op": "replace", "path": "/spec/display/name", "value": "`+displayName+`"}]`), &patch))
+
+	_, err := module.PatchV2(context.Background(), orgID, id, "editor@signoz.io", patch)
+	require.NoError(t, err)
+}
+
+func TestModuleRevisionsV2(t *testing.T) {
+	ctx := context.Background()
+	module, _ := newTestModule(t)
+	orgID := valuer.GenerateUUID()
+	created := newTestDashboard(t, module, orgID)
+
+	renameDashboard(t, module, orgID, created.ID, "Service overview v2")
+
+	var updatable dashboardtypes.UpdatableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(`{"schemaVersion": "v6", "name": "service-overview", "tags": [{"key": "team", "value": "beta"}], "spec": {"display": {"name": "Broken"}}}`), &updatable))
+	_, err := module.UpdateV2(ctx, orgID, created.ID, "breaker@signoz.io", updatable)
+	require.NoError(t, err)
+
+	revisions, err := module.ListRevisionsV2(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	assert.Equal(t, 3, revisions[0].Version)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindUpdate, revisions[0].Kind)
+	assert.Equal(t, "breaker@signoz.io", revisions[0].CreatedBy)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindPatch, revisions[1].Kind)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindCreate, revisions[2].Kind)
+	assert.Equal(t, "creator@signoz.io", revisions[2].CreatedBy)
+
+	ops, err := module.DiffRevisionsV2(ctx, orgID, created.ID, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []dashboardtypes.JSONPatchOperation{{Op: dashboardtypes.PatchOpReplace, Path: "/spec/display/name", Value: "Service overview v2"}}, ops)
+
+	restored, err := module.RestoreRevisionV2(ctx, orgID, created.ID, 2, "fixer@signoz.io")
+	require.NoError(t, err)
+	assert.Equal(t, "Service overview v2", restored.Spec.Display.Name)
+	assert.Len(t, restored.Spec.Panels, 1)
+	assert.Equal(t, "fixer@signoz.io", restored.UpdatedBy)
+
+	actual, err := module.GetV2(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, actual.Tags, 1)
+	assert.Equal(t, "alpha", actual.Tags[0].Value)
+
+	latest, err := module.GetRevisionV2(ctx, orgID, created.ID, 4)
+	require.NoError(t, err)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindRestore, latest.Kind)
+
+	ops, err = module.DiffRevisionsV2(ctx, orgID, created.ID, 2, 4)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+
+	_, err = module.GetRevisionV2(ctx, orgID, created.ID, 42)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	_, err = module.ListRevisionsV2(ctx, valuer.GenerateUUID(), created.ID)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
+
+func TestModuleRevisionsV2Retention(t *testing.T) {
+	ctx := context.Background()
+	module, _ := newTestModule(t)
+	orgID := valuer.GenerateUUID()
+	created := newTestDashboard(t, module, orgID)
+
+	for i := 0; i < dashboardtypes.MaxRevisionsPerDashboard+5; i++ {
+		renameDashboard(t, module, orgID, created.ID, "Service overview "+valuer.GenerateUUID().StringValue())
+	}
+
+	revisions, err := module.ListRevisionsV2(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, dashboardtypes.MaxRevisionsPerDashboard)
+	assert.Equal(t, dashboardtypes.MaxRevisionsPerDashboard+6, revisions[0].Version)
+	assert.Equal(t, 7, revisions[len(revisions)-1].Version)
+}
+
+func TestModuleRevisionsV2Baseline(t *testing.T) {
+	ctx := context.Background()
+	module, store := newTestModule(t)
+	orgID := valuer.GenerateUUID()
+	created := newTestDashboard(t, module, orgID)
+
+	// The dashboard was created before revisions were recorded.
+	_, err := store.BunDB().NewDelete().Model((*dashboardtypes.StorableDashboardRevision)(nil)).Where("dashboard_id = ?", created.ID).Exec(ctx)
+	require.NoError(t, err)
+
+	renameDashboard(t, module, orgID, created.ID, "Service overview v2")
+
+	revisions, err := module.ListRevisionsV2(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindCreate, revisions[1].Kind)
+	baseline, err := revisions[1].UpdatableDashboardV2()
+	require.NoError(t, err)
+	assert.Equal(t, "Service overview", baseline.Spec.Display.Name)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindPatch, revisions[0].Kind)
+	latest, err := revisions[0].UpdatableDashboardV2()
+	require.NoError(t, err)
+	assert.Equal(t, "Service overview v2", latest.Spec.Display.Name)
+}
+
+func TestModuleRevisionsV1(t *testing.T) {
+	ctx := context.Background()
+	module, store := newTestModule(t)
+	orgID := valuer.GenerateUUID()
+
+	created, err := module.Create(ctx, orgID, "creator@signoz.io", valuer.GenerateUUID(), dashboardtypes.SourceUser, dashboardtypes.PostableDashboard{"title": "Service overview"})
+	require.NoError(t, err)
+	id := valuer.MustNewUUID(created.ID)
+
+	_, err = module.Update(ctx, orgID, id, "breaker@signoz.io", dashboardtypes.UpdatableDashboard{"title": "Broken"}, 0)
+	require.NoError(t, err)
+
+	revisions, err := module.ListRevisionsV2(ctx, orgID, id)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindUpdate, revisions[0].Kind)
+	assert.Equal(t, "breaker@signoz.io", revisions[0].CreatedBy)
+	assert.Equal(t, dashboardtypes.DashboardRevisionKindCreate, revisions[1].Kind)
+
+	ops, err := module.DiffRevisionsV2(ctx, orgID, id, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []dashboardtypes.JSONPatchOperation{{Op: dashboardtypes.PatchOpReplace, Path: "/title", Value: "Service overview"}}, ops)
+
+	// v1 dashboards cannot be restored through the v2 dashboard.
+	_, err = module.RestoreRevisionV2(ctx, orgID, id, 1, "fixer@signoz.io")
+	assert.True(t, errors.Ast(err, errors.TypeUnsupported))
+
+	// The dashboard was last changed before revisions were recorded.
+	_, err = store.BunDB().NewDelete().Model((*dashboardtypes.StorableDashboardRevision)(nil)).Where("dashboard_id = ?", id).Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = module.Update(ctx, orgID, id, "fixer@signoz.io", dashboardtypes.UpdatableDashboard{"title": "Service overview"}, 0)
+	require.NoError(t, err)
+
+	revisions, err = module.ListRevisionsV2(ctx, orgID, id)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, "Broken", revisions[1].Data["title"])
+	assert.Equal(t, "Service overview", revisions[0].Data["title"])
+}
+
+// conflictingStore records a revision of the version the module is about to
+// record, as a concurrent change would.
+type conflictingStore struct {
+	dashboardtypes.Store
+
+	conflicts int
+
+	// concurrently is a concurrent change committed right after the dashboard
+	// is read.
+	concurrently func(ctx context.Context) error
+}
+
+func (store *conflictingStore) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*dashboardtypes.StorableDashboard, error) {
+	storable, err := store.Store.Get(ctx, orgID, id)
+	if err != nil || store.concurrently == nil {
+		return storable, err
+	}
+
+	concurrently := store.concurrently
+	store.concurrently = nil
+	return storable, concurrently(ctx)
+}
+
+func (store *conflictingStore) CreateRevision(ctx context.Context, storable *dashboardtypes.StorableDashboardRevision) error {
+	if store.conflicts > 0 {
+		store.conflicts--
+		concurrent := *storable
+		concurrent.ID = valuer.GenerateUUID()
+		if err := store.Store.CreateRevision(ctx, &concurrent); err != nil {
+			return err
+		}
+	}
+
+	return store.Store.CreateRevision(ctx, storable)
+}
+
+func TestModuleRevisionsV2Conflict(t *testing.T) {
+	ctx := context.Background()
+	module, sqlStore := newTestModule(t)
+	orgID := valuer.GenerateUUID()
+	created := newTestDashboard(t, module, orgID)
+
+	store := &conflictingStore{Store: NewStore(sqlStore)}
+	analytics, err := noopanalytics.New(ctx, factorytest.NewSettings(), analytics.Config{})
+	require.NoError(t, err)
+	module = NewModule(store, factorytest.NewSettings(), analytics, nil, nil, impltag.NewModule(impltag.NewStore(sqlStore)))
+
+	// The change is made again on top of the concurrent one.
+	store.conflicts = 1
+	renameDashboard(t, module, orgID, created.ID, "Service overview v2")
+
+	revisions, err := module.ListRevisionsV2(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Version)
+
+	store.conflicts = maxRevisionAttempts
+	var patch dashboardtypes.PatchableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(`[{"op": "replace", "path": "/spec/display/name", "value": "Service overview v3"}]`), &patch))
+	_, err = module.PatchV2(ctx, orgID, created.ID, "editor@signoz.io", patch)
+	assert.True(t, errors.Asc(err, dashboardtypes.ErrCodeDashboardRevisionConflict))
+	assert.True(t, errors.Ast(err, errors.TypeAlreadyExists))
+
+	actual, err := module.GetV2(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Service overview v2", actual.Spec.Display.Name)
+
+	// The dashboard is loaded again, the concurrent lock is not overwritten.
+	store.conflicts = 1
+	store.concurrently = func(ctx context.Context) error {
+		_, err := sqlStore.BunDB().NewUpdate().Model((*dashboardtypes.StorableDashboard)(nil)).Set("locked = ?", true).Where("id = ?", created.ID).Exec(ctx)
+		return err
+	}
+	_, err = module.PatchV2(ctx, orgID, created.ID, "editor@signoz.io", patch)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+
+	actual, err = module.GetV2(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	assert.True(t, actual.Locked)
+	assert.Equal(t, "Service overview v2", actual.Spec.Display.Name)
+}
+
+func TestModuleRevisionsV2DeletedWithDashboard(t *testing.T) {
+	ctx := context.Background()
+	module, store := newTestModule(t)
+	orgID := valuer.GenerateUUID()
+	created := newTestDashboard(t, module, orgID)
+	renameDashboard(t, module, orgID, created.ID, "Service overview v2")
+
+	require.NoError(t, module.DeleteUnsafe(ctx, orgID, created.ID))
+
+	count, err := store.BunDB().NewSelect().Model((*dashboardtypes.StorableDashboardRevision)(nil)).Where("dashboard_id = ?", created.ID).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
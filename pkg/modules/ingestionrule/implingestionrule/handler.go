@@ -0,0 +1,257 @@
+package implingestionrule
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/binding"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/modules/ingestionrule"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/ingestionruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	module ingestionrule.Module
+}
+
+func NewHandler(module ingestionrule.Module) ingestionrule.Handler {
+	return &handler{module: module}
+}
+
+// List handles GET /api/v1/ingestion_rules/{kind}.
+func (h *handler) List(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	kind, err := ingestionruletypes.NewKind(mux.Vars(r)["kind"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	rules, err := h.module.List(ctx, valuer.MustNewUUID(claims.OrgID), kind)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, rules)
+}
+
+// GetVersion handles GET /api/v1/ingestion_rules/{kind}/versions/{version}.
+func (h *handler) GetVersion(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	kind, err := ingestionruletypes.NewKind(mux.Vars(r)["kind"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, ingestionruletypes.ErrCodeIngestionRuleInvalidInput, "version is not a valid number"))
+		return
+	}
+
+	rules, err := h.module.GetVersion(ctx, valuer.MustNewUUID(claims.OrgID), kind, version)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, rules)
+}
+
+// Get handles GET /api/v1/ingestion_rules/{kind}/{id}.
+func (h *handler) Get(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	kind, id, err := kindAndIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	rule, err := h.module.Get(ctx, valuer.MustNewUUID(claims.OrgID), kind, id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, rule)
+}
+
+// Create handles POST /api/v1/ingestion_rules/{kind}.
+func (h *handler) Create(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	userID, err := valuer.NewUUID(claims.UserID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	kind, err := ingestionruletypes.NewKind(mux.Vars(r)["kind"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable := new(ingestionruletypes.PostableIngestionRule)
+	if err := binding.JSON.BindBody(r.Body, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	rule, err := h.module.Create(ctx, valuer.MustNewUUID(claims.OrgID), userID, claims.Email, kind, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, rule)
+}
+
+// Update handles PUT /api/v1/ingestion_rules/{kind}/{id}.
+func (h *handler) Update(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	userID, err := valuer.NewUUID(claims.UserID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	kind, id, err := kindAndIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable := new(ingestionruletypes.PostableIngestionRule)
+	if err := binding.JSON.BindBody(r.Body, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	rule, err := h.module.Update(ctx, valuer.MustNewUUID(claims.OrgID), userID, claims.Email, kind, id, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, rule)
+}
+
+// Delete handles DELETE /api/v1/ingestion_rules/{kind}/{id}.
+func (h *handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	userID, err := valuer.NewUUID(claims.UserID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	kind, id, err := kindAndIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := h.module.Delete(ctx, valuer.MustNewUUID(claims.OrgID), userID, kind, id); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// Preview handles POST /api/v1/ingestion_rules/{kind}/preview.
+func (h *handler) Preview(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	kind, err := ingestionruletypes.NewKind(mux.Vars(r)["kind"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	preview := new(ingestionruletypes.PostableIngestionRulesPreview)
+	if err := binding.JSON.BindBody(r.Body, preview); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	out, err := h.module.Preview(ctx, kind, preview)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, out)
+}
+
+// kindAndIDFromPath extracts and validates the {kind} and {id} path variables.
+func kindAndIDFromPath(r *http.Request) (ingestionruletypes.Kind, valuer.UUID, error) {
+	kind, err := ingestionruletypes.NewKind(mux.Vars(r)["kind"])
+	if err != nil {
+		return ingestionruletypes.Kind{}, valuer.UUID{}, err
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		return ingestionruletypes.Kind{}, valuer.UUID{}, errors.Wrapf(err, errors.TypeInvalidInput, ingestionruletypes.ErrCodeIngestionRuleInvalidInput, "id is not a valid uuid")
+	}
+
+	return kind, id, nil
+}
@@ -0,0 +1,176 @@
+package implingestionrule
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SigNoz/signoz/pkg/modules/ingestionrule"
+	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/types/ingestionruletypes"
+	"github.com/SigNoz/signoz/pkg/types/opamptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	store ingestionruletypes.Store
+}
+
+func NewModule(store ingestionruletypes.Store) ingestionrule.Module {
+	return &module{store: store}
+}
+
+func (module *module) AgentFeatures() []agentConf.AgentFeature {
+	return []agentConf.AgentFeature{
+		&agentFeature{kind: ingestionruletypes.KindSampling},
+		&agentFeature{kind: ingestionruletypes.KindDrop},
+	}
+}
+
+func (module *module) List(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind) (*ingestionruletypes.GettableIngestionRules, error) {
+	rules, err := module.store.List(ctx, orgID, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	version, history, err := agentConf.GetLatestSnapshotVersion(ctx, orgID, kind.ElementType())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingestionruletypes.GettableIngestionRules{Rules: rules, Version: version, History: history}, nil
+}
+
+func (module *module) GetVersion(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind, version int) (*ingestionruletypes.GettableIngestionRules, error) {
+	rules := make([]*ingestionruletypes.IngestionRule, 0)
+	configVersion, err := agentConf.GetSnapshot(ctx, orgID, kind.ElementType(), version, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := agentConf.GetSnapshotHistory(ctx, orgID, kind.ElementType())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingestionruletypes.GettableIngestionRules{Rules: rules, Version: configVersion, History: history}, nil
+}
+
+func (module *module) Get(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind, id valuer.UUID) (*ingestionruletypes.IngestionRule, error) {
+	return module.store.Get(ctx, orgID, kind, id)
+}
+
+func (module *module) Create(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, createdBy string, kind ingestionruletypes.Kind, postable *ingestionruletypes.PostableIngestionRule) (*ingestionruletypes.IngestionRule, error) {
+	if err := postable.Validate(kind); err != nil {
+		return nil, err
+	}
+
+	rule := ingestionruletypes.NewIngestionRule(orgID, kind, createdBy, postable)
+	err := agentConf.StartNewSnapshotVersion(ctx, orgID, userID, kind.ElementType(), func(ctx context.Context) error {
+		return module.store.Create(ctx, rule)
+	}, module.snapshot(orgID, kind))
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (module *module) Update(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, updatedBy string, kind ingestionruletypes.Kind, id valuer.UUID, postable *ingestionruletypes.PostableIngestionRule) (*ingestionruletypes.IngestionRule, error) {
+	if err := postable.Validate(kind); err != nil {
+		return nil, err
+	}
+
+	var rule *ingestionruletypes.IngestionRule
+	err := agentConf.StartNewSnapshotVersion(ctx, orgID, userID, kind.ElementType(), func(ctx context.Context) error {
+		var err error
+		rule, err = module.store.Get(ctx, orgID, kind, id)
+		if err != nil {
+			return err
+		}
+
+		rule.Update(updatedBy, postable)
+		return module.store.Update(ctx, rule)
+	}, module.snapshot(orgID, kind))
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (module *module) Delete(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, kind ingestionruletypes.Kind, id valuer.UUID) error {
+	return agentConf.StartNewSnapshotVersion(ctx, orgID, userID, kind.ElementType(), func(ctx context.Context) error {
+		return module.store.Delete(ctx, orgID, kind, id)
+	}, module.snapshot(orgID, kind))
+}
+
+func (module *module) Preview(_ context.Context, kind ingestionruletypes.Kind, preview *ingestionruletypes.PostableIngestionRulesPreview) (*ingestionruletypes.GettableIngestionRulesPreview, error) {
+	if err := preview.Validate(kind); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*ingestionruletypes.IngestionRule, 0, len(preview.Rules))
+	for _, postable := range preview.Rules {
+		rules = append(rules, ingestionruletypes.NewIngestionRule(valuer.UUID{}, kind, "", postable))
+	}
+
+	collectorConfig := preview.CollectorConfig
+	if collectorConfig == "" {
+		collectorConfig = ingestionruletypes.DefaultCollectorConfig
+	}
+
+	out, err := ingestionruletypes.GenerateCollectorConfig([]byte(collectorConfig), kind, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingestionruletypes.GettableIngestionRulesPreview{CollectorConfig: string(out)}, nil
+}
+
+// snapshot lists the rules of the kind, which are updated in place, for a new
+// config version.
+func (module *module) snapshot(orgID valuer.UUID, kind ingestionruletypes.Kind) agentConf.Snapshot {
+	return func(ctx context.Context) ([]string, any, error) {
+		rules, err := module.store.List(ctx, orgID, kind)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		elements := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			elements = append(elements, rule.ID.StringValue())
+		}
+
+		return elements, rules, nil
+	}
+}
+
+// agentFeature rolls out the rules of a kind as of their latest config version.
+type agentFeature struct {
+	kind ingestionruletypes.Kind
+}
+
+func (feature *agentFeature) AgentFeatureType() agentConf.AgentFeatureType {
+	return feature.kind.FeatureType()
+}
+
+func (feature *agentFeature) RecommendAgentConfig(orgID valuer.UUID, currentConfYaml []byte, configVersion *opamptypes.AgentConfigVersion) ([]byte, string, error) {
+	rules := make([]*ingestionruletypes.IngestionRule, 0)
+	if configVersion != nil {
+		if _, err := agentConf.GetSnapshot(context.Background(), orgID, feature.kind.ElementType(), configVersion.Version, &rules); err != nil {
+			return nil, "", err
+		}
+	}
+
+	updatedConf, err := ingestionruletypes.GenerateCollectorConfig(currentConfYaml, feature.kind, rules)
+	if err != nil {
+		return nil, "", err
+	}
+
+	serialized, err := json.Marshal(rules)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updatedConf, string(serialized), nil
+}
@@ -0,0 +1,118 @@
+package implingestionrule
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	"github.com/SigNoz/signoz/pkg/types/ingestionruletypes"
+	"github.com/SigNoz/signoz/pkg/types/opamptypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestModule(t *testing.T) (*module, sqlstore.SQLStore) {
+	t.Helper()
+
+	sqlStore, err := sqlitesqlstore.New(context.Background(), factorytest.NewSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Connection: sqlstore.ConnectionConfig{
+			MaxOpenConns: 1,
+		},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:            filepath.Join(t.TempDir(), "test.db"),
+			Mode:            "wal",
+			BusyTimeout:     5 * time.Second,
+			TransactionMode: "deferred",
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, model := range []any{(*ingestionruletypes.IngestionRule)(nil), (*opamptypes.AgentConfigVersion)(nil), (*opamptypes.AgentConfigElement)(nil)} {
+		_, err = sqlStore.BunDB().NewCreateTable().Model(model).IfNotExists().Exec(ctx)
+		require.NoError(t, err)
+	}
+	_, err = sqlStore.BunDB().ExecContext(ctx, "CREATE TABLE users (id TEXT PRIMARY KEY, display_name TEXT)")
+	require.NoError(t, err)
+
+	module := NewModule(NewStore(sqlStore)).(*module)
+	_, err = agentConf.Initiate(&agentConf.ManagerOptions{Store: sqlStore, AgentFeatures: module.AgentFeatures()})
+	require.NoError(t, err)
+
+	return module, sqlStore
+}
+
+func newDropRule(name string) *ingestionruletypes.PostableIngestionRule {
+	return &ingestionruletypes.PostableIngestionRule{
+		Name:    name,
+		Enabled: true,
+		Drop: &ingestionruletypes.DropFilter{
+			Signal:     telemetrytypes.SignalTraces,
+			Conditions: []string{`attributes["http.route"] == "/health"`},
+		},
+	}
+}
+
+func TestModuleVersionsRules(t *testing.T) {
+	ctx := context.Background()
+	orgID, userID := valuer.GenerateUUID(), valuer.GenerateUUID()
+	module, _ := newTestModule(t)
+
+	rule, err := module.Create(ctx, orgID, userID, "user@signoz.io", ingestionruletypes.KindDrop, newDropRule("health"))
+	require.NoError(t, err)
+
+	_, err = module.Update(ctx, orgID, userID, "user@signoz.io", ingestionruletypes.KindDrop, rule.ID, newDropRule("health checks"))
+	require.NoError(t, err)
+
+	require.NoError(t, module.Delete(ctx, orgID, userID, ingestionruletypes.KindDrop, rule.ID))
+
+	rules, err := module.List(ctx, orgID, ingestionruletypes.KindDrop)
+	require.NoError(t, err)
+	assert.Empty(t, rules.Rules)
+	assert.Equal(t, 3, rules.Version.Version)
+
+	updated, err := module.GetVersion(ctx, orgID, ingestionruletypes.KindDrop, 2)
+	require.NoError(t, err)
+	require.Len(t, updated.Rules, 1)
+	assert.Equal(t, "health checks", updated.Rules[0].Name)
+}
+
+// The rules are changed along with their config version, a change whose
+// version can not be inserted is rolled back.
+func TestModuleRollsBackRulesWithoutVersion(t *testing.T) {
+	ctx := context.Background()
+	orgID, userID := valuer.GenerateUUID(), valuer.GenerateUUID()
+	module, sqlStore := newTestModule(t)
+
+	rule, err := module.Create(ctx, orgID, userID, "user@signoz.io", ingestionruletypes.KindDrop, newDropRule("health"))
+	require.NoError(t, err)
+
+	other, err := module.Create(ctx, orgID, userID, "user@signoz.io", ingestionruletypes.KindDrop, newDropRule("debug"))
+	require.NoError(t, err)
+
+	_, err = sqlStore.BunDB().NewDropTable().Model((*opamptypes.AgentConfigElement)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = module.Create(ctx, orgID, userID, "user@signoz.io", ingestionruletypes.KindDrop, newDropRule("ready"))
+	require.Error(t, err)
+
+	_, err = module.Update(ctx, orgID, userID, "user@signoz.io", ingestionruletypes.KindDrop, rule.ID, newDropRule("health checks"))
+	require.Error(t, err)
+
+	require.Error(t, module.Delete(ctx, orgID, userID, ingestionruletypes.KindDrop, other.ID))
+
+	rules, err := module.List(ctx, orgID, ingestionruletypes.KindDrop)
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 2)
+	assert.Equal(t, "health", rules.Rules[0].Name)
+	assert.Equal(t, "debug", rules.Rules[1].Name)
+	assert.Equal(t, 2, rules.Version.Version)
+}
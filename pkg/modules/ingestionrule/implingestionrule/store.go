@@ -0,0 +1,116 @@
+package implingestionrule
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/ingestionruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) ingestionruletypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (store *store) List(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind) ([]*ingestionruletypes.IngestionRule, error) {
+	rules := make([]*ingestionruletypes.IngestionRule, 0)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&rules).
+		Where("org_id = ?", orgID).
+		Where("kind = ?", kind).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (store *store) Get(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind, id valuer.UUID) (*ingestionruletypes.IngestionRule, error) {
+	rule := new(ingestionruletypes.IngestionRule)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(rule).
+		Where("org_id = ?", orgID).
+		Where("kind = ?", kind).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, ingestionruletypes.ErrCodeIngestionRuleNotFound, "%s rule %s not found in the org", kind.StringValue(), id)
+	}
+
+	return rule, nil
+}
+
+func (store *store) Create(ctx context.Context, rule *ingestionruletypes.IngestionRule) error {
+	_, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(rule).
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, ingestionruletypes.ErrCodeIngestionRuleAlreadyExists, "%s rule with name %s already exists", rule.Kind.StringValue(), rule.Name)
+	}
+
+	return nil
+}
+
+func (store *store) Update(ctx context.Context, rule *ingestionruletypes.IngestionRule) error {
+	res, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(rule).
+		Where("org_id = ?", rule.OrgID).
+		Where("kind = ?", rule.Kind).
+		Where("id = ?", rule.ID).
+		ExcludeColumn("id", "org_id", "kind", "created_at", "created_by").
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, ingestionruletypes.ErrCodeIngestionRuleAlreadyExists, "%s rule with name %s already exists", rule.Kind.StringValue(), rule.Name)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, ingestionruletypes.ErrCodeIngestionRuleNotFound, "%s rule %s not found in the org", rule.Kind.StringValue(), rule.ID)
+	}
+
+	return nil
+}
+
+func (store *store) Delete(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind, id valuer.UUID) error {
+	res, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model((*ingestionruletypes.IngestionRule)(nil)).
+		Where("org_id = ?", orgID).
+		Where("kind = ?", kind).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, ingestionruletypes.ErrCodeIngestionRuleNotFound, "%s rule %s not found in the org", kind.StringValue(), id)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package ingestionrule
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/types/ingestionruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Module interface {
+	// AgentFeatures returns an agent feature per kind of rule, they roll the
+	// rules out to collectors via OpAMP.
+	AgentFeatures() []agentConf.AgentFeature
+
+	// List returns the rules of the kind along with the latest config version
+	// and the history of the config versions.
+	List(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind) (*ingestionruletypes.GettableIngestionRules, error)
+
+	// GetVersion returns the rules of the kind as they were in the config version.
+	GetVersion(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind, version int) (*ingestionruletypes.GettableIngestionRules, error)
+
+	Get(ctx context.Context, orgID valuer.UUID, kind ingestionruletypes.Kind, id valuer.UUID) (*ingestionruletypes.IngestionRule, error)
+
+	// Create, Update and Delete start a new config version of the kind which
+	// gets rolled out to the collectors.
+	Create(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, createdBy string, kind ingestionruletypes.Kind, postable *ingestionruletypes.PostableIngestionRule) (*ingestionruletypes.IngestionRule, error)
+	Update(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, updatedBy string, kind ingestionruletypes.Kind, id valuer.UUID, postable *ingestionruletypes.PostableIngestionRule) (*ingestionruletypes.IngestionRule, error)
+	Delete(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, kind ingestionruletypes.Kind, id valuer.UUID) error
+
+	// Preview returns the collector config the rules would be rolled out as,
+	// without storing them.
+	Preview(ctx context.Context, kind ingestionruletypes.Kind, preview *ingestionruletypes.PostableIngestionRulesPreview) (*ingestionruletypes.GettableIngestionRulesPreview, error)
+}
+
+type Handler interface {
+	List(rw http.ResponseWriter, r *http.Request)
+	GetVersion(rw http.ResponseWriter, r *http.Request)
+	Get(rw http.ResponseWriter, r *http.Request)
+	Create(rw http.ResponseWriter, r *http.Request)
+	Update(rw http.ResponseWriter, r *http.Request)
+	Delete(rw http.ResponseWriter, r *http.Request)
+	Preview(rw http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,33 @@
+package impllookuptable
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type getter struct {
+	store lookuptabletypes.Store
+}
+
+// NewGetter creates a lookup table getter backed by the lookup table store.
+func NewGetter(store lookuptabletypes.Store) lookuptable.Getter {
+	return &getter{
+		store: store,
+	}
+}
+
+func (getter *getter) List(ctx context.Context, orgID valuer.UUID) ([]*lookuptabletypes.LookupTable, error) {
+	return getter.store.List(ctx, orgID)
+}
+
+func (getter *getter) GetLatestVersionByName(ctx context.Context, orgID valuer.UUID, name string) (*lookuptabletypes.LookupTableVersion, error) {
+	table, err := getter.store.GetByName(ctx, orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return getter.store.GetVersion(ctx, orgID, table.ID, table.Version)
+}
@@ -0,0 +1,260 @@
+package impllookuptable
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/binding"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	module lookuptable.Module
+}
+
+func NewHandler(module lookuptable.Module) lookuptable.Handler {
+	return &handler{module: module}
+}
+
+// List handles GET /api/v1/lookup_tables.
+func (h *handler) List(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	tables, err := h.module.List(ctx, valuer.MustNewUUID(claims.OrgID))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, tables)
+}
+
+// Get handles GET /api/v1/lookup_tables/{id}.
+func (h *handler) Get(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := idFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	table, err := h.module.Get(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, table)
+}
+
+// Create handles POST /api/v1/lookup_tables.
+func (h *handler) Create(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	userID, err := valuer.NewUUID(claims.UserID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable := new(lookuptabletypes.PostableLookupTable)
+	if err := binding.JSON.BindBody(r.Body, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	table, err := h.module.Create(ctx, valuer.MustNewUUID(claims.OrgID), userID, claims.Email, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, table)
+}
+
+// Delete handles DELETE /api/v1/lookup_tables/{id}.
+func (h *handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	userID, err := valuer.NewUUID(claims.UserID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := idFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := h.module.Delete(ctx, valuer.MustNewUUID(claims.OrgID), userID, id); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// ListVersions handles GET /api/v1/lookup_tables/{id}/versions.
+func (h *handler) ListVersions(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := idFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	versions, err := h.module.ListVersions(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, versions)
+}
+
+// CreateVersion handles POST /api/v1/lookup_tables/{id}/versions.
+func (h *handler) CreateVersion(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	userID, err := valuer.NewUUID(claims.UserID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := idFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable := new(lookuptabletypes.PostableLookupTableVersion)
+	if err := binding.JSON.BindBody(r.Body, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	version, err := h.module.CreateVersion(ctx, valuer.MustNewUUID(claims.OrgID), userID, claims.Email, id, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, version)
+}
+
+// GetVersion handles GET /api/v1/lookup_tables/{id}/versions/{version}.
+func (h *handler) GetVersion(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := idFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, lookuptabletypes.ErrCodeLookupTableInvalidInput, "version is not a valid number"))
+		return
+	}
+
+	tableVersion, err := h.module.GetVersion(ctx, valuer.MustNewUUID(claims.OrgID), id, version)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, tableVersion)
+}
+
+// Preview handles POST /api/v1/lookup_tables/preview.
+func (h *handler) Preview(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	preview := new(lookuptabletypes.PostableLookupTablePreview)
+	if err := binding.JSON.BindBody(r.Body, preview); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	out, err := h.module.Preview(ctx, preview)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, out)
+}
+
+// idFromPath extracts and validates the {id} path variable.
+func idFromPath(r *http.Request) (valuer.UUID, error) {
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		return valuer.UUID{}, errors.Wrapf(err, errors.TypeInvalidInput, lookuptabletypes.ErrCodeLookupTableInvalidInput, "id is not a valid uuid")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,176 @@
+package impllookuptable
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
+	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/SigNoz/signoz/pkg/types/opamptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	store lookuptabletypes.Store
+}
+
+func NewModule(store lookuptabletypes.Store) lookuptable.Module {
+	return &module{store: store}
+}
+
+func (module *module) AgentFeature() agentConf.AgentFeature {
+	return &agentFeature{}
+}
+
+func (module *module) List(ctx context.Context, orgID valuer.UUID) (*lookuptabletypes.GettableLookupTables, error) {
+	tables, err := module.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, history, err := agentConf.GetLatestSnapshotVersion(ctx, orgID, opamptypes.ElementTypeLookupTables)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lookuptabletypes.GettableLookupTables{Tables: tables, Version: version, History: history}, nil
+}
+
+func (module *module) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*lookuptabletypes.LookupTable, error) {
+	return module.store.Get(ctx, orgID, id)
+}
+
+func (module *module) Create(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, createdBy string, postable *lookuptabletypes.PostableLookupTable) (*lookuptabletypes.LookupTable, error) {
+	table, version, err := lookuptabletypes.NewLookupTable(orgID, createdBy, postable)
+	if err != nil {
+		return nil, err
+	}
+
+	err = agentConf.StartNewSnapshotVersion(ctx, orgID, userID, opamptypes.ElementTypeLookupTables, func(ctx context.Context) error {
+		return module.store.Create(ctx, table, version)
+	}, module.snapshot(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+func (module *module) CreateVersion(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, createdBy string, id valuer.UUID, postable *lookuptabletypes.PostableLookupTableVersion) (*lookuptabletypes.LookupTableVersion, error) {
+	table, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := table.NewVersion(createdBy, postable)
+	if err != nil {
+		return nil, err
+	}
+
+	err = agentConf.StartNewSnapshotVersion(ctx, orgID, userID, opamptypes.ElementTypeLookupTables, func(ctx context.Context) error {
+		return module.store.CreateVersion(ctx, table, version)
+	}, module.snapshot(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+func (module *module) Delete(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, id valuer.UUID) error {
+	return agentConf.StartNewSnapshotVersion(ctx, orgID, userID, opamptypes.ElementTypeLookupTables, func(ctx context.Context) error {
+		return module.store.Delete(ctx, orgID, id)
+	}, module.snapshot(orgID))
+}
+
+func (module *module) ListVersions(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*lookuptabletypes.GettableLookupTableVersions, error) {
+	if _, err := module.store.Get(ctx, orgID, id); err != nil {
+		return nil, err
+	}
+
+	versions, err := module.store.ListVersions(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lookuptabletypes.GettableLookupTableVersions{Versions: versions}, nil
+}
+
+func (module *module) GetVersion(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int) (*lookuptabletypes.LookupTableVersion, error) {
+	return module.store.GetVersion(ctx, orgID, id, version)
+}
+
+func (module *module) Preview(_ context.Context, preview *lookuptabletypes.PostableLookupTablePreview) (*lookuptabletypes.GettableLookupTablePreview, error) {
+	return lookuptabletypes.NewGettableLookupTablePreview(preview)
+}
+
+func (module *module) ListSnapshotEntries(ctx context.Context, orgID valuer.UUID) (map[string]lookuptabletypes.Entries, error) {
+	versions := map[string]int{}
+	if err := agentConf.GetLatestSnapshot(ctx, orgID, opamptypes.ElementTypeLookupTables, &versions); err != nil {
+		return nil, err
+	}
+
+	tables, err := module.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]lookuptabletypes.Entries, len(versions))
+	for _, table := range tables {
+		version, ok := versions[table.Name]
+		if !ok {
+			continue
+		}
+
+		tableVersion, err := module.store.GetVersion(ctx, orgID, table.ID, version)
+		if err != nil {
+			return nil, err
+		}
+		entries[table.Name] = tableVersion.Entries()
+	}
+
+	return entries, nil
+}
+
+// snapshot lists the version of every table, by name, for a new config
+// version. The log pipelines and span mappers roll out the rows of these
+// versions, and bumping it changes the config id recommended to the agents,
+// which makes them re-apply the pipelines and mappers with the new rows.
+func (module *module) snapshot(orgID valuer.UUID) agentConf.Snapshot {
+	return func(ctx context.Context) ([]string, any, error) {
+		tables, err := module.store.List(ctx, orgID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		elements := make([]string, 0, len(tables))
+		versions := make(map[string]int, len(tables))
+		for _, table := range tables {
+			elements = append(elements, table.ID.StringValue())
+			versions[table.Name] = table.Version
+		}
+
+		return elements, versions, nil
+	}
+}
+
+// agentFeature leaves the collector config as is, the rows are rolled out by
+// the log pipelines and span mappers which reference the tables.
+type agentFeature struct{}
+
+func (feature *agentFeature) AgentFeatureType() agentConf.AgentFeatureType {
+	return lookuptabletypes.LookupTablesFeatureType
+}
+
+func (feature *agentFeature) RecommendAgentConfig(orgID valuer.UUID, currentConfYaml []byte, configVersion *opamptypes.AgentConfigVersion) ([]byte, string, error) {
+	if configVersion == nil {
+		return currentConfYaml, "{}", nil
+	}
+
+	version, err := agentConf.GetConfigVersion(context.Background(), orgID, opamptypes.ElementTypeLookupTables, configVersion.Version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return currentConfYaml, version.Config, nil
+}
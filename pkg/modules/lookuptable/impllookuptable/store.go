@@ -0,0 +1,201 @@
+package impllookuptable
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) lookuptabletypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (store *store) List(ctx context.Context, orgID valuer.UUID) ([]*lookuptabletypes.LookupTable, error) {
+	tables := make([]*lookuptabletypes.LookupTable, 0)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&tables).
+		Where("org_id = ?", orgID).
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+func (store *store) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*lookuptabletypes.LookupTable, error) {
+	table := new(lookuptabletypes.LookupTable)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(table).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, lookuptabletypes.ErrCodeLookupTableNotFound, "lookup table %s not found in the org", id)
+	}
+
+	return table, nil
+}
+
+func (store *store) GetByName(ctx context.Context, orgID valuer.UUID, name string) (*lookuptabletypes.LookupTable, error) {
+	table := new(lookuptabletypes.LookupTable)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(table).
+		Where("org_id = ?", orgID).
+		Where("name = ?", name).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, lookuptabletypes.ErrCodeLookupTableNotFound, "lookup table with name %s not found in the org", name)
+	}
+
+	return table, nil
+}
+
+func (store *store) Create(ctx context.Context, table *lookuptabletypes.LookupTable, version *lookuptabletypes.LookupTableVersion) error {
+	return store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		_, err := store.sqlstore.
+			BunDBCtx(ctx).
+			NewInsert().
+			Model(table).
+			Exec(ctx)
+		if err != nil {
+			return store.sqlstore.WrapAlreadyExistsErrf(err, lookuptabletypes.ErrCodeLookupTableAlreadyExists, "lookup table with name %s already exists", table.Name)
+		}
+
+		_, err = store.sqlstore.
+			BunDBCtx(ctx).
+			NewInsert().
+			Model(version).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (store *store) CreateVersion(ctx context.Context, table *lookuptabletypes.LookupTable, version *lookuptabletypes.LookupTableVersion) error {
+	return store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		_, err := store.sqlstore.
+			BunDBCtx(ctx).
+			NewInsert().
+			Model(version).
+			Exec(ctx)
+		if err != nil {
+			return store.sqlstore.WrapAlreadyExistsErrf(err, lookuptabletypes.ErrCodeLookupTableAlreadyExists, "version %d of lookup table %s already exists", version.Version, table.Name)
+		}
+
+		res, err := store.sqlstore.
+			BunDBCtx(ctx).
+			NewUpdate().
+			Model(table).
+			Where("org_id = ?", table.OrgID).
+			Where("id = ?", table.ID).
+			ExcludeColumn("id", "org_id", "name", "created_at", "created_by").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return errors.Newf(errors.TypeNotFound, lookuptabletypes.ErrCodeLookupTableNotFound, "lookup table %s not found in the org", table.ID)
+		}
+
+		return nil
+	})
+}
+
+func (store *store) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	return store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		_, err := store.sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model((*lookuptabletypes.LookupTableVersion)(nil)).
+			Where("org_id = ?", orgID).
+			Where("table_id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		res, err := store.sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model((*lookuptabletypes.LookupTable)(nil)).
+			Where("org_id = ?", orgID).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return errors.Newf(errors.TypeNotFound, lookuptabletypes.ErrCodeLookupTableNotFound, "lookup table %s not found in the org", id)
+		}
+
+		return nil
+	})
+}
+
+func (store *store) ListVersions(ctx context.Context, orgID valuer.UUID, tableID valuer.UUID) ([]*lookuptabletypes.LookupTableVersion, error) {
+	versions := make([]*lookuptabletypes.LookupTableVersion, 0)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&versions).
+		ExcludeColumn("rows").
+		Where("org_id = ?", orgID).
+		Where("table_id = ?", tableID).
+		Order("version DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (store *store) GetVersion(ctx context.Context, orgID valuer.UUID, tableID valuer.UUID, version int) (*lookuptabletypes.LookupTableVersion, error) {
+	tableVersion := new(lookuptabletypes.LookupTableVersion)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(tableVersion).
+		Where("org_id = ?", orgID).
+		Where("table_id = ?", tableID).
+		Where("version = ?", version).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, lookuptabletypes.ErrCodeLookupTableNotFound, "version %d of lookup table %s not found in the org", version, tableID)
+	}
+
+	return tableVersion, nil
+}
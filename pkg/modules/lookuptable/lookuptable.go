@@ -0,0 +1,61 @@
+package lookuptable
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Getter resolves lookup tables for read paths.
+type Getter interface {
+	// List returns the tables of the org, by name.
+	List(ctx context.Context, orgID valuer.UUID) ([]*lookuptabletypes.LookupTable, error)
+
+	// GetLatestVersionByName returns the latest version of the named table
+	// along with its rows.
+	GetLatestVersionByName(ctx context.Context, orgID valuer.UUID, name string) (*lookuptabletypes.LookupTableVersion, error)
+}
+
+type Module interface {
+	// AgentFeature rolls out a new agent config whenever the tables change, so
+	// that the log pipelines and span mappers referencing them get re-applied.
+	AgentFeature() agentConf.AgentFeature
+
+	// List returns the tables of the org along with the latest config version
+	// and the history of the config versions.
+	List(ctx context.Context, orgID valuer.UUID) (*lookuptabletypes.GettableLookupTables, error)
+
+	Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*lookuptabletypes.LookupTable, error)
+
+	// Create, CreateVersion and Delete start a new config version which gets
+	// rolled out to the collectors.
+	Create(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, createdBy string, postable *lookuptabletypes.PostableLookupTable) (*lookuptabletypes.LookupTable, error)
+	CreateVersion(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, createdBy string, id valuer.UUID, postable *lookuptabletypes.PostableLookupTableVersion) (*lookuptabletypes.LookupTableVersion, error)
+	Delete(ctx context.Context, orgID valuer.UUID, userID valuer.UUID, id valuer.UUID) error
+
+	// ListVersions returns the versions of the table, latest first and without
+	// their rows.
+	ListVersions(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*lookuptabletypes.GettableLookupTableVersions, error)
+	GetVersion(ctx context.Context, orgID valuer.UUID, id valuer.UUID, version int) (*lookuptabletypes.LookupTableVersion, error)
+
+	// Preview parses an upload and looks keys up in it, without storing it.
+	Preview(ctx context.Context, preview *lookuptabletypes.PostableLookupTablePreview) (*lookuptabletypes.GettableLookupTablePreview, error)
+
+	// ListSnapshotEntries returns the entries of the tables of the org, by
+	// name, at the versions pinned by the latest config version.
+	ListSnapshotEntries(ctx context.Context, orgID valuer.UUID) (map[string]lookuptabletypes.Entries, error)
+}
+
+type Handler interface {
+	List(rw http.ResponseWriter, r *http.Request)
+	Get(rw http.ResponseWriter, r *http.Request)
+	Create(rw http.ResponseWriter, r *http.Request)
+	Delete(rw http.ResponseWriter, r *http.Request)
+	ListVersions(rw http.ResponseWriter, r *http.Request)
+	CreateVersion(rw http.ResponseWriter, r *http.Request)
+	GetVersion(rw http.ResponseWriter, r *http.Request)
+	Preview(rw http.ResponseWriter, r *http.Request)
+}
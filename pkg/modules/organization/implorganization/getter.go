@@ -51,12 +51,12 @@ func (module *getter) GetByIDOrName(ctx context.Context, id valuer.UUID, name st
 }
 
 func (module *getter) ListByOwnedKeyRange(ctx context.Context) ([]*types.Organization, error) {
-	start, end, err := module.sharder.GetMyOwnedKeyRange(ctx)
+	keyRanges, err := module.sharder.GetMyOwnedKeyRanges(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return module.store.ListByKeyRange(ctx, start, end)
+	return module.store.ListByKeyRanges(ctx, keyRanges)
 }
 
 func (module *getter) GetByName(ctx context.Context, name string) (*types.Organization, error) {
@@ -4,9 +4,11 @@ import (
 	"context"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
 )
 
 type store struct {
@@ -109,15 +111,23 @@ func (store *store) Delete(ctx context.Context, id valuer.UUID) error {
 	return nil
 }
 
-func (store *store) ListByKeyRange(ctx context.Context, start, end uint32) ([]*types.Organization, error) {
+func (store *store) ListByKeyRanges(ctx context.Context, keyRanges []sharder.KeyRange) ([]*types.Organization, error) {
 	organizations := make([]*types.Organization, 0)
+	if len(keyRanges) == 0 {
+		return organizations, nil
+	}
+
 	err := store.
 		sqlstore.
 		BunDB().
 		NewSelect().
 		Model(&organizations).
-		Where("key >= ?", start).
-		Where("key <= ?", end).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, keyRange := range keyRanges {
+				q = q.WhereOr("key BETWEEN ? AND ?", keyRange.Start, keyRange.End)
+			}
+			return q
+		}).
 		Scan(ctx)
 	if err != nil {
 		return nil, err
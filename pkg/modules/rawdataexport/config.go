@@ -0,0 +1,75 @@
+package rawdataexport
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	Jobs JobsConfig `mapstructure:"jobs"`
+}
+
+type JobsConfig struct {
+	// MaxConcurrent is the number of export jobs run at the same time, the other jobs are queued.
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+	// MaxRows is the maximum number of rows an export job can export.
+	MaxRows int `mapstructure:"max_rows"`
+	// Timeout is the maximum duration of an export job.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Retention is the duration for which the result of a finished export job is kept.
+	Retention time.Duration `mapstructure:"retention"`
+	// Local configures the local disk sink the results of export jobs are written to. A job is run by the
+	// replica it was created on and its result is downloaded from any replica, so with more than one
+	// replica the directory must be shared by all of them, e.g. a network volume.
+	Local LocalSinkConfig `mapstructure:"local"`
+}
+
+type LocalSinkConfig struct {
+	// Directory is the directory the results are written to.
+	Directory string `mapstructure:"directory"`
+	// ChunkSize is the maximum size in bytes of a file the result is split into.
+	ChunkSize int64 `mapstructure:"chunk_size"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("rawdataexport"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return Config{
+		Jobs: JobsConfig{
+			MaxConcurrent: 2,
+			MaxRows:       1_000_000,
+			Timeout:       time.Hour,
+			Retention:     24 * time.Hour,
+			Local: LocalSinkConfig{
+				Directory: "/var/lib/signoz/exports",
+				ChunkSize: 64 * 1024 * 1024,
+			},
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Jobs.MaxConcurrent <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "rawdataexport.jobs.max_concurrent must be positive, got %d", c.Jobs.MaxConcurrent)
+	}
+	if c.Jobs.MaxRows <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "rawdataexport.jobs.max_rows must be positive, got %d", c.Jobs.MaxRows)
+	}
+	if c.Jobs.Timeout <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "rawdataexport.jobs.timeout must be positive, got %s", c.Jobs.Timeout)
+	}
+	if c.Jobs.Retention <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "rawdataexport.jobs.retention must be positive, got %s", c.Jobs.Retention)
+	}
+	if c.Jobs.Local.Directory == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "rawdataexport.jobs.local.directory cannot be empty")
+	}
+	if c.Jobs.Local.ChunkSize <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "rawdataexport.jobs.local.chunk_size must be positive, got %d", c.Jobs.Local.ChunkSize)
+	}
+	return nil
+}
@@ -0,0 +1,421 @@
+package implrawdataexport
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// recordBatchWriter writes record batches to the export in a columnar format.
+type recordBatchWriter interface {
+	Write(arrow.RecordBatch) error
+	Close() error
+}
+
+type newRecordBatchWriterFunc func(schema *arrow.Schema, writer io.Writer) (recordBatchWriter, error)
+
+// newParquetWriter writes every record batch as a row group of a parquet file.
+func newParquetWriter(schema *arrow.Schema, writer io.Writer) (recordBatchWriter, error) {
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Zstd),
+		parquet.WithMaxRowGroupLength(ColumnarRowGroupSize),
+	)
+	return pqarrow.NewFileWriter(schema, writer, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+}
+
+// newArrowWriter writes the record batches in the arrow IPC streaming format.
+func newArrowWriter(schema *arrow.Schema, writer io.Writer) (recordBatchWriter, error) {
+	return ipc.NewWriter(writer, ipc.WithSchema(schema)), nil
+}
+
+// exportRawDataColumnar streams the rows to the writer in batches of ColumnarRowGroupSize rows.
+// The schema is derived from the select fields and the rows of the first batch, and is not
+// changed afterwards.
+func exportRawDataColumnar(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, selectFields []telemetrytypes.TelemetryFieldKey, newWriter newRecordBatchWriterFunc, writer io.Writer) (isComplete bool, err error) {
+	countingWriter := &countingWriter{writer: writer}
+
+	var (
+		builder      *array.RecordBuilder
+		recordWriter recordBatchWriter
+		columns      []string
+		pending      []map[string]any
+		rows         int
+	)
+
+	// the writer is closed on every path so that the export is never left without its footer
+	defer func() {
+		if builder != nil {
+			builder.Release()
+		}
+		if recordWriter != nil {
+			if closeErr := recordWriter.Close(); closeErr != nil {
+				err = errors.Join(err, errors.WrapInternalf(closeErr, errors.CodeInternal, "error closing export writer"))
+			}
+		}
+	}()
+
+	start := func() error {
+		var schema *arrow.Schema
+		schema, columns = exportSchema(selectFields, pending)
+
+		var err error
+		recordWriter, err = newWriter(schema, countingWriter)
+		if err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "error creating export writer")
+		}
+		builder = array.NewRecordBuilder(memory.DefaultAllocator, schema)
+
+		for _, data := range pending {
+			appendRow(builder, columns, data)
+		}
+		rows, pending = len(pending), nil
+		return nil
+	}
+
+	flush := func() error {
+		if builder == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if rows == 0 {
+			return nil
+		}
+		rows = 0
+		record := builder.NewRecordBatch()
+		defer record.Release()
+		if err := recordWriter.Write(record); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "error writing record batch")
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case row, ok := <-rowChan:
+			if !ok {
+				// writes a valid file without rows when none were received
+				if err := flush(); err != nil {
+					return false, err
+				}
+				return true, nil
+			}
+
+			if builder == nil {
+				pending = append(pending, row.Data)
+			} else {
+				appendRow(builder, columns, row.Data)
+				rows++
+			}
+
+			if len(pending)+rows >= ColumnarRowGroupSize {
+				if err := flush(); err != nil {
+					return false, err
+				}
+				if countingWriter.count > MaxExportBytesLimit {
+					return false, nil
+				}
+			}
+		case err := <-errChan:
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+func appendRow(builder *array.RecordBuilder, columns []string, data map[string]any) {
+	for idx, column := range columns {
+		appendValue(builder.Field(idx), data[column])
+	}
+}
+
+// exportSchema derives the schema of the export from the select fields and the rows of the
+// first batch. The selected fields are typed from their data type and exported as strings
+// when it is not known, the other columns are typed from their first non null value.
+// Columns are ordered as the priority columns, the selected fields and the remaining columns
+// of the rows sorted by name.
+func exportSchema(selectFields []telemetrytypes.TelemetryFieldKey, rows []map[string]any) (*arrow.Schema, []string) {
+	values := make(map[string]any)
+	for _, data := range rows {
+		for column, value := range data {
+			if current, ok := values[column]; !ok || dereference(current) == nil {
+				values[column] = value
+			}
+		}
+	}
+
+	columns := make([]string, 0, len(values)+len(selectFields))
+	for _, column := range priorityColumns {
+		if _, ok := values[column]; ok {
+			columns = append(columns, column)
+		}
+	}
+
+	dataTypes := make(map[string]telemetrytypes.FieldDataType, len(selectFields))
+	for _, field := range selectFields {
+		if !slices.Contains(columns, field.Name) {
+			columns = append(columns, field.Name)
+		}
+		dataTypes[field.Name] = field.FieldDataType
+	}
+
+	remaining := make([]string, 0, len(values))
+	for column := range values {
+		if !slices.Contains(columns, column) {
+			remaining = append(remaining, column)
+		}
+	}
+	slices.Sort(remaining)
+	columns = append(columns, remaining...)
+
+	fields := make([]arrow.Field, len(columns))
+	for idx, column := range columns {
+		dataType, ok := arrowTypeForFieldDataType(dataTypes[column])
+		switch {
+		case column == "timestamp":
+			dataType = arrow.FixedWidthTypes.Timestamp_ns
+		case ok:
+		case slices.ContainsFunc(selectFields, func(field telemetrytypes.TelemetryFieldKey) bool { return field.Name == column }):
+			// the values of a field may have different types across rows
+			dataType = arrow.BinaryTypes.String
+		default:
+			dataType = arrowTypeForValue(values[column])
+		}
+		fields[idx] = arrow.Field{Name: column, Type: dataType, Nullable: true}
+	}
+
+	return arrow.NewSchema(fields, nil), columns
+}
+
+func arrowTypeForFieldDataType(dataType telemetrytypes.FieldDataType) (arrow.DataType, bool) {
+	switch dataType {
+	case telemetrytypes.FieldDataTypeString:
+		return arrow.BinaryTypes.String, true
+	case telemetrytypes.FieldDataTypeBool:
+		return arrow.FixedWidthTypes.Boolean, true
+	// int64 and number are synonyms for float64
+	case telemetrytypes.FieldDataTypeFloat64, telemetrytypes.FieldDataTypeInt64, telemetrytypes.FieldDataTypeNumber:
+		return arrow.PrimitiveTypes.Float64, true
+	case telemetrytypes.FieldDataTypeArrayString:
+		return arrow.ListOf(arrow.BinaryTypes.String), true
+	case telemetrytypes.FieldDataTypeArrayBool:
+		return arrow.ListOf(arrow.FixedWidthTypes.Boolean), true
+	case telemetrytypes.FieldDataTypeArrayFloat64, telemetrytypes.FieldDataTypeArrayInt64, telemetrytypes.FieldDataTypeArrayNumber:
+		return arrow.ListOf(arrow.PrimitiveTypes.Float64), true
+	}
+	return nil, false
+}
+
+func arrowTypeForValue(value any) arrow.DataType {
+	switch dereference(value).(type) {
+	case bool:
+		return arrow.FixedWidthTypes.Boolean
+	case int, int8, int16, int32, int64:
+		return arrow.PrimitiveTypes.Int64
+	case uint, uint8, uint16, uint32, uint64:
+		return arrow.PrimitiveTypes.Uint64
+	case float32, float64:
+		return arrow.PrimitiveTypes.Float64
+	case time.Time:
+		return arrow.FixedWidthTypes.Timestamp_ns
+	case []string:
+		return arrow.ListOf(arrow.BinaryTypes.String)
+	case []float64:
+		return arrow.ListOf(arrow.PrimitiveTypes.Float64)
+	case []bool:
+		return arrow.ListOf(arrow.FixedWidthTypes.Boolean)
+	case map[string]string:
+		return arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+	case map[string]float64:
+		return arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Float64)
+	case map[string]bool:
+		return arrow.MapOf(arrow.BinaryTypes.String, arrow.FixedWidthTypes.Boolean)
+	default:
+		// everything else is exported the same way as in CSV
+		return arrow.BinaryTypes.String
+	}
+}
+
+// appendValue appends the value to the builder, converting it to the type of the column.
+// Values which cannot be converted are appended as null.
+func appendValue(builder array.Builder, value any) {
+	value = dereference(value)
+	if value == nil {
+		builder.AppendNull()
+		return
+	}
+
+	switch builder := builder.(type) {
+	case *array.StringBuilder:
+		builder.Append(formatValue(value))
+	case *array.BooleanBuilder:
+		if v, ok := toBool(value); ok {
+			builder.Append(v)
+			return
+		}
+		builder.AppendNull()
+	case *array.Int64Builder:
+		if v, ok := toInt64(value); ok {
+			builder.Append(v)
+			return
+		}
+		builder.AppendNull()
+	case *array.Uint64Builder:
+		if v, ok := toUint64(value); ok {
+			builder.Append(v)
+			return
+		}
+		builder.AppendNull()
+	case *array.Float64Builder:
+		if v, ok := toFloat64(value); ok {
+			builder.Append(v)
+			return
+		}
+		builder.AppendNull()
+	case *array.TimestampBuilder:
+		if v, ok := toTime(value); ok {
+			builder.Append(arrow.Timestamp(v.UnixNano()))
+			return
+		}
+		builder.AppendNull()
+	case *array.MapBuilder:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+			builder.AppendNull()
+			return
+		}
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		builder.Append(true)
+		for _, key := range keys {
+			builder.KeyBuilder().(*array.StringBuilder).Append(key.String())
+			appendValue(builder.ItemBuilder(), rv.MapIndex(key).Interface())
+		}
+	case *array.ListBuilder:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			builder.AppendNull()
+			return
+		}
+		builder.Append(true)
+		for i := range rv.Len() {
+			appendValue(builder.ValueBuilder(), rv.Index(i).Interface())
+		}
+	default:
+		builder.AppendNull()
+	}
+}
+
+// dereference returns the value pointed to by the pointers of nullable columns.
+func dereference(value any) any {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+func toBool(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+func toInt64(value any) (int64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), rv.Uint() <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		return int64(rv.Float()), true
+	case reflect.String:
+		i, err := strconv.ParseInt(rv.String(), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func toUint64(value any) (uint64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(rv.Int()), rv.Int() >= 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return uint64(rv.Float()), rv.Float() >= 0
+	case reflect.String:
+		u, err := strconv.ParseUint(rv.String(), 10, 64)
+		return u, err == nil
+	}
+	return 0, false
+}
+
+func toFloat64(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case uint64: // epoch-ns stored as integer
+		return time.Unix(0, int64(v)), true
+	case int64:
+		return time.Unix(0, v), true
+	}
+	return time.Time{}, false
+}
+
+// countingWriter counts the bytes written to the export.
+type countingWriter struct {
+	writer io.Writer
+	count  uint64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += uint64(n)
+	return n, err
+}
@@ -0,0 +1,224 @@
+package implrawdataexport
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rowsChan(rows ...map[string]any) (chan *qbtypes.RawRow, chan error) {
+	rowChan := make(chan *qbtypes.RawRow, len(rows))
+	errChan := make(chan error, 1)
+	for _, data := range rows {
+		rowChan <- &qbtypes.RawRow{Data: data}
+	}
+	close(rowChan)
+	return rowChan, errChan
+}
+
+func TestExportSchema(t *testing.T) {
+	selectFields := []telemetrytypes.TelemetryFieldKey{
+		{Name: "service.name", FieldDataType: telemetrytypes.FieldDataTypeString},
+		{Name: "http.status_code", FieldDataType: telemetrytypes.FieldDataTypeNumber},
+		{Name: "missing"},
+	}
+	severity := "INFO"
+	data := map[string]any{
+		"service.name":      "frontend",
+		"http.status_code":  "200",
+		"id":                "abc",
+		"timestamp":         uint64(1640995200000000000),
+		"severity_text":     &severity,
+		"attributes_string": map[string]string{"a": "b"},
+		"attributes_bool":   map[string]bool{},
+		"duration_nano":     uint64(10),
+	}
+
+	schema, columns := exportSchema(selectFields, []map[string]any{data})
+
+	assert.Equal(t, []string{"timestamp", "id", "service.name", "http.status_code", "missing", "attributes_bool", "attributes_string", "duration_nano", "severity_text"}, columns)
+	expected := []arrow.DataType{
+		arrow.FixedWidthTypes.Timestamp_ns,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.String,
+		arrow.PrimitiveTypes.Float64,
+		arrow.BinaryTypes.String,
+		arrow.MapOf(arrow.BinaryTypes.String, arrow.FixedWidthTypes.Boolean),
+		arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String),
+		arrow.PrimitiveTypes.Uint64,
+		arrow.BinaryTypes.String,
+	}
+	for idx, dataType := range expected {
+		assert.True(t, arrow.TypeEqual(dataType, schema.Field(idx).Type), "column %s: expected %s, got %s", columns[idx], dataType, schema.Field(idx).Type)
+	}
+}
+
+func TestExportSchemaFromRows(t *testing.T) {
+	selectFields := []telemetrytypes.TelemetryFieldKey{{Name: "http.status_code"}}
+	var missing *string
+	rows := []map[string]any{
+		{"timestamp": uint64(1640995200000000000), "http.status_code": float64(200), "severity_text": missing},
+		{"timestamp": uint64(1640995201000000000), "http.status_code": "OK", "severity_text": "INFO", "duration_nano": uint64(10)},
+	}
+
+	schema, columns := exportSchema(selectFields, rows)
+
+	assert.Equal(t, []string{"timestamp", "http.status_code", "duration_nano", "severity_text"}, columns)
+	expected := []arrow.DataType{
+		arrow.FixedWidthTypes.Timestamp_ns,
+		arrow.BinaryTypes.String,
+		arrow.PrimitiveTypes.Uint64,
+		arrow.BinaryTypes.String,
+	}
+	for idx, dataType := range expected {
+		assert.True(t, arrow.TypeEqual(dataType, schema.Field(idx).Type), "column %s: expected %s, got %s", columns[idx], dataType, schema.Field(idx).Type)
+	}
+}
+
+func TestExportRawDataParquet(t *testing.T) {
+	selectFields := []telemetrytypes.TelemetryFieldKey{
+		{Name: "service.name", FieldDataType: telemetrytypes.FieldDataTypeString},
+		{Name: "duration", FieldDataType: telemetrytypes.FieldDataTypeFloat64},
+	}
+	ts := time.Unix(1640995200, 0).UTC()
+	rowChan, errChan := rowsChan(
+		map[string]any{"timestamp": ts, "service.name": "frontend", "duration": float64(12.5), "tags": map[string]string{"env": "prod"}},
+		map[string]any{"timestamp": ts.Add(time.Second), "service.name": "cart", "duration": nil, "tags": map[string]string{}},
+	)
+
+	var buf bytes.Buffer
+	isComplete, err := exportRawDataColumnar(rowChan, errChan, selectFields, newParquetWriter, &buf)
+	require.NoError(t, err)
+	assert.True(t, isComplete)
+
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer reader.Close()
+
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+
+	table, err := fileReader.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer table.Release()
+
+	assert.Equal(t, int64(2), table.NumRows())
+	assert.Equal(t, []string{"timestamp", "service.name", "duration", "tags"}, fieldNames(table.Schema()))
+
+	names := table.Column(1).Data().Chunk(0).(*array.String)
+	assert.Equal(t, "frontend", names.Value(0))
+	assert.Equal(t, "cart", names.Value(1))
+
+	durations := table.Column(2).Data().Chunk(0).(*array.Float64)
+	assert.Equal(t, 12.5, durations.Value(0))
+	assert.True(t, durations.IsNull(1))
+
+	timestamps := table.Column(0).Data().Chunk(0).(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(ts.UnixNano()), timestamps.Value(0))
+}
+
+func TestExportRawDataArrow(t *testing.T) {
+	rowChan, errChan := rowsChan(
+		map[string]any{"timestamp": uint64(1640995200000000000), "id": "a", "attributes_number": map[string]float64{"code": 200}},
+		map[string]any{"timestamp": uint64(1640995201000000000), "id": "b", "attributes_number": map[string]float64{}},
+	)
+
+	var buf bytes.Buffer
+	isComplete, err := exportRawDataColumnar(rowChan, errChan, nil, newArrowWriter, &buf)
+	require.NoError(t, err)
+	assert.True(t, isComplete)
+
+	reader, err := ipc.NewReader(&buf)
+	require.NoError(t, err)
+	defer reader.Release()
+
+	assert.Equal(t, []string{"timestamp", "id", "attributes_number"}, fieldNames(reader.Schema()))
+
+	require.True(t, reader.Next())
+	record := reader.RecordBatch()
+	assert.Equal(t, int64(2), record.NumRows())
+	assert.Equal(t, arrow.Timestamp(1640995200000000000), record.Column(0).(*array.Timestamp).Value(0))
+	assert.Equal(t, "b", record.Column(1).(*array.String).Value(1))
+
+	attributes := record.Column(2).(*array.Map)
+	assert.Equal(t, "code", attributes.Keys().(*array.String).Value(0))
+	assert.Equal(t, float64(200), attributes.Items().(*array.Float64).Value(0))
+	assert.False(t, reader.Next())
+}
+
+func TestExportRawDataColumnarEmpty(t *testing.T) {
+	rowChan, errChan := rowsChan()
+	selectFields := []telemetrytypes.TelemetryFieldKey{{Name: "service.name", FieldDataType: telemetrytypes.FieldDataTypeString}}
+
+	var buf bytes.Buffer
+	isComplete, err := exportRawDataColumnar(rowChan, errChan, selectFields, newParquetWriter, &buf)
+	require.NoError(t, err)
+	assert.True(t, isComplete)
+
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, int64(0), reader.NumRows())
+}
+
+func TestExportRawDataColumnarError(t *testing.T) {
+	rowChan := make(chan *qbtypes.RawRow)
+	errChan := make(chan error, 1)
+	errChan <- context.DeadlineExceeded
+
+	var buf bytes.Buffer
+	isComplete, err := exportRawDataColumnar(rowChan, errChan, nil, newArrowWriter, &buf)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, isComplete)
+}
+
+func TestExportRawDataColumnarErrorAfterRows(t *testing.T) {
+	rowChan := make(chan *qbtypes.RawRow, ColumnarRowGroupSize)
+	for idx := range ColumnarRowGroupSize {
+		rowChan <- &qbtypes.RawRow{Data: map[string]any{"timestamp": uint64(1640995200000000000 + idx), "id": "a"}}
+	}
+	errChan := make(chan error, 1)
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		isComplete, err := exportRawDataColumnar(rowChan, errChan, nil, newArrowWriter, &buf)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, isComplete)
+	}()
+	for len(rowChan) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	errChan <- context.DeadlineExceeded
+	<-done
+
+	// the stream is closed, so the rows written before the error can be read
+	reader, err := ipc.NewReader(&buf)
+	require.NoError(t, err)
+	defer reader.Release()
+	require.True(t, reader.Next())
+	assert.Equal(t, int64(ColumnarRowGroupSize), reader.RecordBatch().NumRows())
+	assert.False(t, reader.Next())
+	assert.NoError(t, reader.Err())
+}
+
+func fieldNames(schema *arrow.Schema) []string {
+	names := make([]string, 0, schema.NumFields())
+	for _, field := range schema.Fields() {
+		names = append(names, field.Name)
+	}
+	return names
+}
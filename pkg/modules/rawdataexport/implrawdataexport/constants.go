@@ -12,8 +12,18 @@ const (
 	// Data Limits.
 	MaxExportBytesLimit = 10 * 1024 * 1024 * 1024 // 10 GB
 
+	// Columnar Limits.
+	ColumnarRowGroupSize = 10_000 // 10k
+
 	// Query Limits.
 	ChunkSize                         = 5_000 // 5k
 	ClickhouseExportRawDataMaxThreads = 2
 	ClickhouseExportRawDataTimeout    = 10 * time.Minute
+
+	// Job Limits.
+	JobProgressInterval = 5 * time.Second
+	// JobLeaseDuration is how long a job is held by its instance without renewal, the lease is renewed
+	// every JobProgressInterval.
+	JobLeaseDuration   = time.Minute
+	JobCleanupInterval = 10 * time.Minute
 )
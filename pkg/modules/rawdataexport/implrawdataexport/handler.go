@@ -1,6 +1,7 @@
 package implrawdataexport
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -12,22 +13,27 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/SigNoz/signoz/pkg/authz"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/http/binding"
 	"github.com/SigNoz/signoz/pkg/http/render"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
 	"github.com/SigNoz/signoz/pkg/types/exporttypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
 )
 
 type handler struct {
 	module rawdataexport.Module
+	authz  authz.AuthZ
 }
 
-func NewHandler(module rawdataexport.Module) rawdataexport.Handler {
-	return &handler{module: module}
+func NewHandler(module rawdataexport.Module, authz authz.AuthZ) rawdataexport.Handler {
+	return &handler{module: module, authz: authz}
 }
 
 func (handler *handler) ExportRawData(rw http.ResponseWriter, r *http.Request) {
@@ -39,6 +45,11 @@ func (handler *handler) ExportRawData(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 	format := formatParam.Format
+	if err := validateExportFormat(format); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
 	if err := binding.JSON.BindBody(r.Body, &queryRangeRequest); err != nil {
 		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid request body: %v", err))
 		return
@@ -70,7 +81,8 @@ func (handler *handler) ExportRawData(rw http.ResponseWriter, r *http.Request) {
 	defer close(doneChan)
 	rowChan, errChan := handler.module.ExportRawData(r.Context(), orgID, &queryRangeRequest, doneChan)
 
-	isComplete, err := handler.executeExport(rowChan, errChan, format, rw)
+	rw.Header().Set("Content-Type", exportContentType(format))
+	isComplete, err := writeExport(rowChan, errChan, format, exportSelectFields(&queryRangeRequest), rw)
 	if err != nil {
 		render.Error(rw, err)
 		return
@@ -78,6 +90,177 @@ func (handler *handler) ExportRawData(rw http.ResponseWriter, r *http.Request) {
 	rw.Header().Set("X-Response-Complete", strconv.FormatBool(isComplete))
 }
 
+// CreateJob handles POST /api/v1/export_jobs.
+func (handler *handler) CreateJob(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	var formatParam exporttypes.ExportRawDataFormatQueryParam
+	if err := binding.Query.BindQuery(r.URL.Query(), &formatParam); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var queryRangeRequest qbtypes.QueryRangeRequest
+	if err := binding.JSON.BindBody(r.Body, &queryRangeRequest); err != nil {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid request body: %v", err))
+		return
+	}
+
+	if err := validateSpecForExport(&queryRangeRequest); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	job, err := handler.module.CreateJob(ctx, valuer.MustNewUUID(claims.OrgID), claims.Email, formatParam.Format, &queryRangeRequest)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusAccepted, job)
+}
+
+// GetJob handles GET /api/v1/export_jobs/{id}.
+func (handler *handler) GetJob(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := jobIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	job, err := handler.module.GetJob(ctx, valuer.MustNewUUID(claims.OrgID), id, claims.Email, handler.isAdmin(ctx, claims))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, job)
+}
+
+// ListJobs handles GET /api/v1/export_jobs.
+func (handler *handler) ListJobs(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	jobs, err := handler.module.ListJobs(ctx, valuer.MustNewUUID(claims.OrgID), claims.Email, handler.isAdmin(ctx, claims))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, &exporttypes.GettableExportJobs{Jobs: jobs})
+}
+
+// CancelJob handles POST /api/v1/export_jobs/{id}/cancel.
+func (handler *handler) CancelJob(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := jobIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	job, err := handler.module.CancelJob(ctx, valuer.MustNewUUID(claims.OrgID), id, claims.Email, handler.isAdmin(ctx, claims))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, job)
+}
+
+// DownloadJobResult handles GET /api/v1/export_jobs/{id}/download. It supports range requests
+// so that an interrupted download can be resumed.
+func (handler *handler) DownloadJobResult(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := jobIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	job, object, err := handler.module.OpenJobResult(r.Context(), valuer.MustNewUUID(claims.OrgID), id, claims.Email, handler.isAdmin(r.Context(), claims))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer object.Close()
+
+	rw.Header().Set("Content-Type", exportContentType(job.Format))
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", job.Filename()))
+	rw.Header().Set("Access-Control-Expose-Headers", "Content-Disposition, Content-Range, Accept-Ranges, ETag, X-Response-Complete")
+	rw.Header().Set("X-Response-Complete", strconv.FormatBool(job.Complete))
+	// the result of a job never changes, its id identifies it for If-Range.
+	rw.Header().Set("ETag", fmt.Sprintf("\"%s\"", job.ID.StringValue()))
+
+	var modTime time.Time
+	if job.FinishedAt != nil {
+		modTime = *job.FinishedAt
+	}
+
+	http.ServeContent(rw, r, job.Filename(), modTime, io.NewSectionReader(object, 0, object.Size()))
+}
+
+// isAdmin reports whether the user can access the export jobs created by the other users of the org.
+func (handler *handler) isAdmin(ctx context.Context, claims authtypes.Claims) bool {
+	selectors := []coretypes.Selector{
+		coretypes.TypeRole.MustSelector(authtypes.SigNozAdminRoleName),
+	}
+
+	return handler.authz.CheckWithTupleCreation(
+		ctx,
+		claims,
+		valuer.MustNewUUID(claims.OrgID),
+		authtypes.Relation{Verb: coretypes.VerbAssignee},
+		coretypes.NewResourceRole(),
+		selectors,
+		selectors,
+	) == nil
+}
+
+func jobIDFromPath(r *http.Request) (valuer.UUID, error) {
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		return valuer.UUID{}, errors.Wrapf(err, errors.TypeInvalidInput, exporttypes.ErrCodeExportJobInvalidInput, "id is not a valid uuid")
+	}
+	return id, nil
+}
+
 // validateSpecForExport validates query specs.
 func validateSpecForExport(req *qbtypes.QueryRangeRequest) error {
 
@@ -103,15 +286,33 @@ func validateSpecForExport(req *qbtypes.QueryRangeRequest) error {
 	return req.Validate(opts...)
 }
 
+// exportSelectFields returns the select fields of the exported query.
+func exportSelectFields(req *qbtypes.QueryRangeRequest) []telemetrytypes.TelemetryFieldKey {
+	queries := req.CompositeQuery.Queries
+	if len(queries) == 0 {
+		return nil
+	}
+	if idx := req.TraceOperatorQueryIndex(); idx > -1 {
+		return queries[idx].GetSelectFields()
+	}
+	return queries[0].GetSelectFields()
+}
+
 func validateAndApplyDefaultExportLimits(queries []qbtypes.QueryEnvelope) error {
+	return validateAndApplyExportLimits(queries, MaxExportRowCountLimit)
+}
+
+// validateAndApplyExportLimits applies the default limit to the queries without one
+// and validates the limit of the others against maxLimit.
+func validateAndApplyExportLimits(queries []qbtypes.QueryEnvelope, maxLimit int) error {
 	for idx := range queries {
 		limit := queries[idx].GetLimit()
 		if limit == 0 {
 			limit = DefaultExportRowCountLimit
 		} else if limit < 0 {
 			return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be positive")
-		} else if limit > MaxExportRowCountLimit {
-			return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit cannot be more than %d", MaxExportRowCountLimit)
+		} else if limit > maxLimit {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit cannot be more than %d", maxLimit)
 		}
 		queries[idx].SetLimit(limit)
 	}
@@ -129,28 +330,54 @@ func setExportResponseHeaders(rw http.ResponseWriter, format string) {
 	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
 }
 
-// executeExport streams data from rowChan to the response writer in the specified format.
-func (handler *handler) executeExport(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, format string, rw http.ResponseWriter) (bool, error) {
+// exportContentType returns the content type of an export in the specified format.
+func exportContentType(format string) string {
+	switch format {
+	case "csv", "":
+		return "text/csv"
+	case "jsonl":
+		return "application/x-ndjson"
+	case "parquet":
+		return "application/vnd.apache.parquet"
+	case "arrow":
+		return "application/vnd.apache.arrow.stream"
+	default:
+		return ""
+	}
+}
+
+// validateExportFormat returns an error if the format is not supported.
+func validateExportFormat(format string) error {
+	if exportContentType(format) == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format: must be one of csv, jsonl, parquet or arrow")
+	}
+	return nil
+}
+
+// writeExport streams data from rowChan to the writer in the specified format.
+func writeExport(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, format string, selectFields []telemetrytypes.TelemetryFieldKey, writer io.Writer) (bool, error) {
 	switch format {
 	case "csv", "":
-		rw.Header().Set("Content-Type", "text/csv")
-		csvWriter := csv.NewWriter(rw)
-		isComplete, err := handler.exportRawDataCSV(rowChan, errChan, csvWriter)
+		csvWriter := csv.NewWriter(writer)
+		isComplete, err := exportRawDataCSV(rowChan, errChan, csvWriter)
 		if err != nil {
 			return false, err
 		}
 		csvWriter.Flush()
-		return isComplete, nil
+		return isComplete, csvWriter.Error()
 	case "jsonl":
-		rw.Header().Set("Content-Type", "application/x-ndjson")
-		return handler.exportRawDataJSONL(rowChan, errChan, rw)
+		return exportRawDataJSONL(rowChan, errChan, writer)
+	case "parquet":
+		return exportRawDataColumnar(rowChan, errChan, selectFields, newParquetWriter, writer)
+	case "arrow":
+		return exportRawDataColumnar(rowChan, errChan, selectFields, newArrowWriter, writer)
 	default:
-		return false, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format: must be csv or jsonl")
+		return false, validateExportFormat(format)
 	}
 }
 
 // exportRawDataCSV is a generic CSV export function that works with any raw data (logs, traces, etc.)
-func (handler *handler) exportRawDataCSV(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, csvWriter *csv.Writer) (bool, error) {
+func exportRawDataCSV(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, csvWriter *csv.Writer) (bool, error) {
 
 	var header []string
 	headerToIndexMapping := make(map[string]int)
@@ -192,7 +419,7 @@ func (handler *handler) exportRawDataCSV(rowChan <-chan *qbtypes.RawRow, errChan
 }
 
 // exportRawDataJSONL is a generic JSONL export function that works with any raw data (logs, traces, etc.)
-func (handler *handler) exportRawDataJSONL(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, writer io.Writer) (bool, error) {
+func exportRawDataJSONL(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, writer io.Writer) (bool, error) {
 	totalBytes := uint64(0)
 	for {
 		select {
@@ -280,49 +507,52 @@ func constructCSVRecordFromQueryResponse(data map[string]any, headerToIndexMappi
 
 	for key, value := range data {
 		if index, exists := headerToIndexMapping[key]; exists && value != nil {
+			record[index] = sanitizeForCSV(formatValue(value))
+		}
+	}
+	return record
+}
 
-			var valueStr string
-			switch v := value.(type) {
-			case string:
-				valueStr = v
-			case int:
-				valueStr = strconv.FormatInt(int64(v), 10)
-			case int32:
-				valueStr = strconv.FormatInt(int64(v), 10)
-			case int64:
-				valueStr = strconv.FormatInt(v, 10)
-			case uint:
-				valueStr = strconv.FormatUint(uint64(v), 10)
-			case uint32:
-				valueStr = strconv.FormatUint(uint64(v), 10)
-			case uint64:
-				valueStr = strconv.FormatUint(v, 10)
-			case float32:
-				valueStr = strconv.FormatFloat(float64(v), 'f', -1, 32)
-			case float64:
-				valueStr = strconv.FormatFloat(v, 'f', -1, 64)
-			case bool:
-				valueStr = strconv.FormatBool(v)
-			case time.Time:
-				valueStr = v.Format(time.RFC3339Nano)
-			case []byte:
-				valueStr = string(v)
-			case fmt.Stringer:
-				valueStr = v.String()
-
-			default:
-				jsonBytes, err := json.Marshal(v)
-				if err != nil {
-					valueStr = fmt.Sprintf("%v", v)
-				} else {
-					valueStr = string(jsonBytes)
-				}
-			}
+// formatValue formats a value of a raw row as a string.
+func formatValue(value any) string {
+	var valueStr string
+	switch v := value.(type) {
+	case string:
+		valueStr = v
+	case int:
+		valueStr = strconv.FormatInt(int64(v), 10)
+	case int32:
+		valueStr = strconv.FormatInt(int64(v), 10)
+	case int64:
+		valueStr = strconv.FormatInt(v, 10)
+	case uint:
+		valueStr = strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		valueStr = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		valueStr = strconv.FormatUint(v, 10)
+	case float32:
+		valueStr = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		valueStr = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		valueStr = strconv.FormatBool(v)
+	case time.Time:
+		valueStr = v.Format(time.RFC3339Nano)
+	case []byte:
+		valueStr = string(v)
+	case fmt.Stringer:
+		valueStr = v.String()
 
-			record[index] = sanitizeForCSV(valueStr)
+	default:
+		jsonBytes, err := json.Marshal(v)
+		if err != nil {
+			valueStr = fmt.Sprintf("%v", v)
+		} else {
+			valueStr = string(jsonBytes)
 		}
 	}
-	return record
+	return valueStr
 }
 
 func getsizeOfStringSlice(slice []string) uint64 {
@@ -0,0 +1,350 @@
+package implrawdataexport
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func (m *Module) CreateJob(ctx context.Context, orgID valuer.UUID, createdBy string, format string, rangeRequest *qbtypes.QueryRangeRequest) (*exporttypes.ExportJob, error) {
+	if err := validateExportFormat(format); err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = "csv"
+	}
+
+	if err := validateAndApplyExportLimits(rangeRequest.CompositeQuery.Queries, m.config.MaxRows); err != nil {
+		return nil, err
+	}
+
+	rangeRequest.UseDefaultOrderBy()
+
+	job := exporttypes.NewExportJob(orgID, createdBy, format, *rangeRequest, exportRowLimit(rangeRequest))
+	job.Lease(m.owner, JobLeaseDuration)
+	if err := m.store.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// the job outlives the request, it keeps the values of the request context but not its cancellation.
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	jobCtx = ctxtypes.NewContextWithCommentVals(jobCtx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "rawdataexport",
+		instrumentationtypes.CodeFunctionName: "runJob",
+	})
+
+	m.mu.Lock()
+	m.cancels[job.ID] = cancel
+	m.mu.Unlock()
+
+	// the export updates the limit and offset of the queries as it pages through the rows.
+	runnable := *job
+	runnable.Request.CompositeQuery.Queries = slices.Clone(job.Request.CompositeQuery.Queries)
+	go m.runJob(jobCtx, cancel, &runnable)
+
+	return job, nil
+}
+
+func (m *Module) GetJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID, accessedBy string, isAdmin bool) (*exporttypes.ExportJob, error) {
+	job, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := job.CanAccess(accessedBy, isAdmin); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (m *Module) ListJobs(ctx context.Context, orgID valuer.UUID, accessedBy string, isAdmin bool) ([]*exporttypes.ExportJob, error) {
+	jobs, err := m.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isAdmin {
+		return jobs, nil
+	}
+
+	accessible := make([]*exporttypes.ExportJob, 0, len(jobs))
+	for _, job := range jobs {
+		if job.CanAccess(accessedBy, isAdmin) == nil {
+			accessible = append(accessible, job)
+		}
+	}
+
+	return accessible, nil
+}
+
+func (m *Module) CancelJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, isAdmin bool) (*exporttypes.ExportJob, error) {
+	job, err := m.GetJob(ctx, orgID, id, updatedBy, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status.IsFinished() {
+		return nil, errors.Newf(errors.TypeInvalidInput, exporttypes.ErrCodeExportJobInvalidInput, "export job %s has already finished with status %s", id, job.Status.StringValue())
+	}
+
+	job.Finish(exporttypes.ExportJobStatusCancelled, nil, m.config.Retention)
+	job.UpdatedBy = updatedBy
+
+	cancelled, err := m.store.Update(ctx, job, exporttypes.ExportJobStatusQueued, exporttypes.ExportJobStatusRunning)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, errors.Newf(errors.TypeInvalidInput, exporttypes.ErrCodeExportJobInvalidInput, "export job %s has already finished", id)
+	}
+
+	// jobs running in other processes notice the cancellation when they next report their progress.
+	m.mu.Lock()
+	if cancel, ok := m.cancels[id]; ok {
+		cancel()
+	}
+	m.mu.Unlock()
+
+	return job, nil
+}
+
+func (m *Module) OpenJobResult(ctx context.Context, orgID valuer.UUID, id valuer.UUID, accessedBy string, isAdmin bool) (*exporttypes.ExportJob, rawdataexport.Object, error) {
+	job, err := m.GetJob(ctx, orgID, id, accessedBy, isAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if job.Status != exporttypes.ExportJobStatusSucceeded {
+		return nil, nil, errors.Newf(errors.TypeInvalidInput, exporttypes.ErrCodeExportJobNotFinished, "export job %s has not succeeded, its status is %s", id, job.Status.StringValue())
+	}
+
+	object, err := m.sink.Open(ctx, orgID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, object, nil
+}
+
+func (m *Module) runJob(ctx context.Context, cancel context.CancelFunc, job *exporttypes.ExportJob) {
+	defer func() {
+		m.mu.Lock()
+		delete(m.cancels, job.ID)
+		m.mu.Unlock()
+		cancel()
+	}()
+
+	logger := m.settings.Logger().With(slog.String("job_id", job.ID.StringValue()), slog.String("org_id", job.OrgID.StringValue()))
+
+	if !m.waitForSlot(ctx, job) {
+		return
+	}
+	defer func() { <-m.slots }()
+
+	job.Start()
+	job.Lease(m.owner, JobLeaseDuration)
+	started, err := m.store.Update(ctx, job, exporttypes.ExportJobStatusQueued)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to start export job", errors.Attr(err))
+		return
+	}
+	if !started {
+		return
+	}
+
+	isComplete, err := m.writeJob(ctx, cancel, job)
+	if err != nil {
+		if deleteErr := m.sink.Delete(context.WithoutCancel(ctx), job.OrgID, job.ID); deleteErr != nil {
+			logger.ErrorContext(ctx, "failed to delete the result of export job", errors.Attr(deleteErr))
+		}
+
+		// a cancelled job has already been marked as cancelled
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.ErrorContext(ctx, "export job failed", errors.Attr(err))
+		m.finishJob(ctx, job, exporttypes.ExportJobStatusFailed, err)
+		return
+	}
+
+	job.Complete = isComplete
+	m.finishJob(ctx, job, exporttypes.ExportJobStatusSucceeded, nil)
+}
+
+// waitForSlot waits until the job can run while it renews the lease of the queued job. It returns
+// false if the job was cancelled while it was queued, here or in another process.
+func (m *Module) waitForSlot(ctx context.Context, job *exporttypes.ExportJob) bool {
+	ticker := time.NewTicker(JobProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case m.slots <- struct{}{}:
+			return true
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			job.Lease(m.owner, JobLeaseDuration)
+			queued, err := m.store.Update(ctx, job, exporttypes.ExportJobStatusQueued)
+			if err != nil {
+				m.settings.Logger().WarnContext(ctx, "failed to renew the lease of export job", errors.Attr(err), slog.String("job_id", job.ID.StringValue()))
+				continue
+			}
+			if !queued {
+				return false
+			}
+		}
+	}
+}
+
+// writeJob exports the rows of the job to the sink while it periodically reports the progress.
+func (m *Module) writeJob(ctx context.Context, cancel context.CancelFunc, job *exporttypes.ExportJob) (bool, error) {
+	writer, err := m.sink.Create(ctx, job.OrgID, job.ID)
+	if err != nil {
+		return false, err
+	}
+
+	progress := &jobProgress{writer: writer}
+
+	var wg sync.WaitGroup
+	stopC := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.reportProgress(ctx, cancel, job, progress, stopC)
+	}()
+
+	// the export pages through the queries of the request, read the select fields before it starts.
+	selectFields := exportSelectFields(&job.Request)
+
+	doneChan := make(chan any)
+	rowChan, errChan := m.exportRawData(ctx, job.OrgID, &job.Request, doneChan, m.config.Timeout)
+
+	isComplete, err := writeExport(progress.countRows(rowChan, doneChan), errChan, job.Format, selectFields, progress)
+	if err == nil && isComplete {
+		// the rows are drained, the export can still have failed after its last row was read.
+		err = <-errChan
+	}
+	close(doneChan)
+	close(stopC)
+	wg.Wait()
+
+	closeErr := writer.Close()
+	if err != nil {
+		return false, err
+	}
+	if closeErr != nil {
+		return false, errors.WrapInternalf(closeErr, errors.CodeInternal, "failed to store the result of the export job")
+	}
+
+	job.RowCount = progress.rows.Load()
+	job.Size = progress.bytes.Load()
+	return isComplete, nil
+}
+
+// reportProgress persists the progress of the job and renews its lease until stopC is closed. It cancels the job
+// if it is no longer running, e.g. when it was cancelled from another process.
+func (m *Module) reportProgress(ctx context.Context, cancel context.CancelFunc, job *exporttypes.ExportJob, progress *jobProgress, stopC <-chan struct{}) {
+	ticker := time.NewTicker(JobProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopC:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snapshot := *job
+			snapshot.RowCount = progress.rows.Load()
+			snapshot.Size = progress.bytes.Load()
+			snapshot.UpdatedAt = time.Now()
+			snapshot.Lease(m.owner, JobLeaseDuration)
+
+			running, err := m.store.Update(ctx, &snapshot, exporttypes.ExportJobStatusRunning)
+			if err != nil {
+				m.settings.Logger().WarnContext(ctx, "failed to report the progress of export job", errors.Attr(err), slog.String("job_id", job.ID.StringValue()))
+				continue
+			}
+			if !running {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func (m *Module) finishJob(ctx context.Context, job *exporttypes.ExportJob, status exporttypes.ExportJobStatus, cause error) {
+	// the job context can be done by now, the final status is persisted regardless.
+	ctx = context.WithoutCancel(ctx)
+
+	job.Finish(status, cause, m.config.Retention)
+	finished, err := m.store.Update(ctx, job, exporttypes.ExportJobStatusRunning)
+	if err != nil {
+		m.settings.Logger().ErrorContext(ctx, "failed to finish export job", errors.Attr(err), slog.String("job_id", job.ID.StringValue()))
+		return
+	}
+
+	if !finished && status == exporttypes.ExportJobStatusSucceeded {
+		// the job was cancelled just as it finished, its result is not kept.
+		if err := m.sink.Delete(ctx, job.OrgID, job.ID); err != nil {
+			m.settings.Logger().ErrorContext(ctx, "failed to delete the result of export job", errors.Attr(err), slog.String("job_id", job.ID.StringValue()))
+		}
+	}
+}
+
+// exportRowLimit returns the limit of the exported query.
+func exportRowLimit(req *qbtypes.QueryRangeRequest) int {
+	queries := req.CompositeQuery.Queries
+	if len(queries) == 0 {
+		return 0
+	}
+	if idx := req.TraceOperatorQueryIndex(); idx > -1 {
+		return queries[idx].GetLimit()
+	}
+	return queries[0].GetLimit()
+}
+
+// jobProgress counts the rows and bytes written by an export job.
+type jobProgress struct {
+	writer io.Writer
+	rows   atomic.Int64
+	bytes  atomic.Int64
+}
+
+func (p *jobProgress) Write(b []byte) (int, error) {
+	n, err := p.writer.Write(b)
+	p.bytes.Add(int64(n))
+	return n, err
+}
+
+// countRows forwards the rows of rowChan and counts them as they are consumed.
+func (p *jobProgress) countRows(rowChan <-chan *qbtypes.RawRow, doneChan <-chan any) <-chan *qbtypes.RawRow {
+	countedChan := make(chan *qbtypes.RawRow)
+	go func() {
+		defer close(countedChan)
+		for row := range rowChan {
+			select {
+			case countedChan <- row:
+				p.rows.Add(1)
+			case <-doneChan:
+				return
+			}
+		}
+	}()
+	return countedChan
+}
@@ -0,0 +1,290 @@
+package implrawdataexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/authz"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuerier returns rows rows, paged by the limit and offset of the first query.
+type fakeQuerier struct {
+	rows int
+	// blockC blocks the queries until it is closed or the context is done.
+	blockC chan struct{}
+}
+
+func (q *fakeQuerier) QueryRange(ctx context.Context, _ valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	if q.blockC != nil {
+		select {
+		case <-q.blockC:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	query := req.CompositeQuery.Queries[0]
+	rows := make([]*qbtypes.RawRow, 0)
+	for idx := query.GetOffset(); idx < min(q.rows, query.GetOffset()+query.GetLimit()); idx++ {
+		rows = append(rows, &qbtypes.RawRow{Data: map[string]any{"id": fmt.Sprintf("%d", idx)}})
+	}
+
+	return &qbtypes.QueryRangeResponse{Data: qbtypes.QueryData{Results: []any{&qbtypes.RawData{Rows: rows}}}}, nil
+}
+
+func (q *fakeQuerier) QueryRawStream(context.Context, valuer.UUID, *qbtypes.QueryRangeRequest, *qbtypes.RawStream) {
+}
+
+func (q *fakeQuerier) PurgeCache(context.Context, valuer.UUID, *qbtypes.PostableCachePurge) error {
+	return nil
+}
+
+// fakeAuthZ grants the admin role to the users in admins.
+type fakeAuthZ struct {
+	authz.AuthZ
+	admins []string
+}
+
+func (a *fakeAuthZ) CheckWithTupleCreation(_ context.Context, claims authtypes.Claims, _ valuer.UUID, _ authtypes.Relation, _ coretypes.Resource, _ []coretypes.Selector, _ []coretypes.Selector) error {
+	if slices.Contains(a.admins, claims.Email) {
+		return nil
+	}
+	return errors.New(errors.TypeForbidden, errors.CodeForbidden, "not an admin")
+}
+
+func newTestModule(t *testing.T, querier *fakeQuerier) *Module {
+	t.Helper()
+
+	sqlStore, err := sqlitesqlstore.New(context.Background(), factorytest.NewSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Connection: sqlstore.ConnectionConfig{
+			MaxOpenConns: 1,
+		},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:            filepath.Join(t.TempDir(), "test.db"),
+			Mode:            "wal",
+			BusyTimeout:     5 * time.Second,
+			TransactionMode: "deferred",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = sqlStore.BunDB().NewCreateTable().Model((*exporttypes.ExportJob)(nil)).IfNotExists().Exec(context.Background())
+	require.NoError(t, err)
+
+	config := rawdataexport.Config{
+		Jobs: rawdataexport.JobsConfig{
+			MaxConcurrent: 1,
+			MaxRows:       100,
+			Timeout:       time.Minute,
+			Retention:     time.Hour,
+			Local:         rawdataexport.LocalSinkConfig{Directory: t.TempDir(), ChunkSize: 16},
+		},
+	}
+
+	return NewModule(querier, NewStore(sqlStore), NewLocalSink(config.Jobs.Local), factorytest.NewSettings(), config).(*Module)
+}
+
+func newJobRequest(limit int) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:       1000000000000,
+		End:         1000003600000,
+		RequestType: qbtypes.RequestTypeRaw,
+		CompositeQuery: qbtypes.CompositeQuery{Queries: []qbtypes.QueryEnvelope{{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", Signal: telemetrytypes.SignalLogs, Limit: limit},
+		}}},
+	}
+}
+
+func waitForJobStatus(t *testing.T, module *Module, orgID valuer.UUID, id valuer.UUID, status exporttypes.ExportJobStatus) *exporttypes.ExportJob {
+	t.Helper()
+
+	var job *exporttypes.ExportJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = module.GetJob(context.Background(), orgID, id, "", true)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestCreateJob(t *testing.T) {
+	ctx := context.Background()
+	module := newTestModule(t, &fakeQuerier{rows: 5})
+	orgID := valuer.GenerateUUID()
+
+	created, err := module.CreateJob(ctx, orgID, "viewer@signoz.io", "jsonl", newJobRequest(10))
+	require.NoError(t, err)
+	assert.Equal(t, exporttypes.ExportJobStatusQueued, created.Status)
+	assert.Equal(t, 10, created.RowLimit)
+	assert.Equal(t, module.owner, created.Owner)
+	require.NotNil(t, created.LeaseExpiresAt)
+	assert.True(t, created.LeaseExpiresAt.After(time.Now()))
+
+	job := waitForJobStatus(t, module, orgID, created.ID, exporttypes.ExportJobStatusSucceeded)
+	assert.Equal(t, int64(5), job.RowCount)
+	assert.True(t, job.Complete)
+	assert.NotNil(t, job.ExpiresAt)
+	assert.Equal(t, "viewer@signoz.io", job.CreatedBy)
+	require.Len(t, job.Request.CompositeQuery.Queries, 1)
+	assert.Equal(t, 10, job.Request.CompositeQuery.Queries[0].GetLimit())
+
+	jobs, err := module.ListJobs(ctx, orgID, "viewer@signoz.io", false)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	_, object, err := module.OpenJobResult(ctx, orgID, created.ID, "viewer@signoz.io", false)
+	require.NoError(t, err)
+	defer object.Close()
+
+	result, err := io.ReadAll(io.NewSectionReader(object, 0, object.Size()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(result)), job.Size)
+	assert.Equal(t, 5, strings.Count(string(result), "\n"))
+
+	// jobs are scoped to the org
+	_, err = module.GetJob(ctx, valuer.GenerateUUID(), created.ID, "viewer@signoz.io", false)
+	assert.Error(t, err)
+}
+
+func TestJobAccess(t *testing.T) {
+	ctx := context.Background()
+	module := newTestModule(t, &fakeQuerier{rows: 1})
+	orgID := valuer.GenerateUUID()
+
+	created, err := module.CreateJob(ctx, orgID, "viewer@signoz.io", "jsonl", newJobRequest(10))
+	require.NoError(t, err)
+	waitForJobStatus(t, module, orgID, created.ID, exporttypes.ExportJobStatusSucceeded)
+
+	_, err = module.CreateJob(ctx, orgID, "other@signoz.io", "jsonl", newJobRequest(10))
+	require.NoError(t, err)
+
+	// other users of the org can neither see nor download the job
+	_, err = module.GetJob(ctx, orgID, created.ID, "other@signoz.io", false)
+	assert.True(t, errors.Ast(err, errors.TypeForbidden))
+
+	_, _, err = module.OpenJobResult(ctx, orgID, created.ID, "other@signoz.io", false)
+	assert.True(t, errors.Ast(err, errors.TypeForbidden))
+
+	_, err = module.CancelJob(ctx, orgID, created.ID, "other@signoz.io", false)
+	assert.True(t, errors.Ast(err, errors.TypeForbidden))
+
+	jobs, err := module.ListJobs(ctx, orgID, "other@signoz.io", false)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "other@signoz.io", jobs[0].CreatedBy)
+
+	// admins can access the jobs of every user of the org
+	_, object, err := module.OpenJobResult(ctx, orgID, created.ID, "admin@signoz.io", true)
+	require.NoError(t, err)
+	require.NoError(t, object.Close())
+
+	jobs, err = module.ListJobs(ctx, orgID, "admin@signoz.io", true)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+}
+
+func TestCreateJobValidation(t *testing.T) {
+	module := newTestModule(t, &fakeQuerier{})
+
+	_, err := module.CreateJob(context.Background(), valuer.GenerateUUID(), "", "xml", newJobRequest(10))
+	assert.ErrorContains(t, err, "invalid format")
+
+	_, err = module.CreateJob(context.Background(), valuer.GenerateUUID(), "", "csv", newJobRequest(101))
+	assert.ErrorContains(t, err, "limit cannot be more than 100")
+}
+
+func TestCancelJob(t *testing.T) {
+	ctx := context.Background()
+	querier := &fakeQuerier{rows: 5, blockC: make(chan struct{})}
+	module := newTestModule(t, querier)
+	orgID := valuer.GenerateUUID()
+
+	running, err := module.CreateJob(ctx, orgID, "", "csv", newJobRequest(10))
+	require.NoError(t, err)
+	waitForJobStatus(t, module, orgID, running.ID, exporttypes.ExportJobStatusRunning)
+
+	// only one job runs at a time, the second one stays queued
+	queued, err := module.CreateJob(ctx, orgID, "", "csv", newJobRequest(10))
+	require.NoError(t, err)
+
+	for _, id := range []valuer.UUID{running.ID, queued.ID} {
+		job, err := module.CancelJob(ctx, orgID, id, "editor@signoz.io", true)
+		require.NoError(t, err)
+		assert.Equal(t, exporttypes.ExportJobStatusCancelled, job.Status)
+	}
+
+	require.Eventually(t, func() bool {
+		module.mu.Lock()
+		defer module.mu.Unlock()
+		return len(module.cancels) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	job := waitForJobStatus(t, module, orgID, running.ID, exporttypes.ExportJobStatusCancelled)
+	assert.Equal(t, "editor@signoz.io", job.UpdatedBy)
+
+	_, err = module.CancelJob(ctx, orgID, running.ID, "", false)
+	assert.ErrorContains(t, err, "already finished")
+
+	_, _, err = module.OpenJobResult(ctx, orgID, running.ID, "", false)
+	assert.ErrorContains(t, err, "has not succeeded")
+}
+
+func TestDownloadJobResult(t *testing.T) {
+	module := newTestModule(t, &fakeQuerier{rows: 3})
+	orgID := valuer.GenerateUUID()
+
+	created, err := module.CreateJob(context.Background(), orgID, "viewer@signoz.io", "jsonl", newJobRequest(10))
+	require.NoError(t, err)
+	waitForJobStatus(t, module, orgID, created.ID, exporttypes.ExportJobStatusSucceeded)
+
+	download := func(email string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/export_jobs/"+created.ID.StringValue()+"/download", nil)
+		req.Header = header
+		req = mux.SetURLVars(req, map[string]string{"id": created.ID.StringValue()})
+		req = req.WithContext(authtypes.NewContextWithClaims(req.Context(), authtypes.Claims{OrgID: orgID.StringValue(), Email: email}))
+
+		rec := httptest.NewRecorder()
+		NewHandler(module, &fakeAuthZ{admins: []string{"admin@signoz.io"}}).DownloadJobResult(rec, req)
+		return rec
+	}
+
+	full := download("viewer@signoz.io", http.Header{})
+	require.Equal(t, http.StatusOK, full.Code)
+	assert.Equal(t, "application/x-ndjson", full.Header().Get("Content-Type"))
+	assert.Equal(t, "bytes", full.Header().Get("Accept-Ranges"))
+	assert.Equal(t, "{\"id\":\"0\"}\n{\"id\":\"1\"}\n{\"id\":\"2\"}\n", full.Body.String())
+
+	// resume the download from the second row
+	partial := download("viewer@signoz.io", http.Header{"Range": []string{"bytes=11-"}, "If-Range": []string{full.Header().Get("ETag")}})
+	require.Equal(t, http.StatusPartialContent, partial.Code)
+	assert.Equal(t, "bytes 11-32/33", partial.Header().Get("Content-Range"))
+	assert.Equal(t, "{\"id\":\"1\"}\n{\"id\":\"2\"}\n", partial.Body.String())
+
+	assert.Equal(t, http.StatusForbidden, download("other@signoz.io", http.Header{}).Code)
+	assert.Equal(t, http.StatusOK, download("admin@signoz.io", http.Header{}).Code)
+}
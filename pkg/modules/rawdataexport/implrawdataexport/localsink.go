@@ -0,0 +1,248 @@
+package implrawdataexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	localChunkPrefix     = "chunk-"
+	localPartialSuffix   = ".partial"
+	localDirPermissions  = 0o750
+	localFilePermissions = 0o640
+)
+
+// localSink stores the result of every job as a directory of chunk files on the local disk.
+// Results are written to a partial directory which is renamed once the writer is closed,
+// so that a result is only ever opened once it is complete.
+type localSink struct {
+	config rawdataexport.LocalSinkConfig
+}
+
+func NewLocalSink(config rawdataexport.LocalSinkConfig) rawdataexport.Sink {
+	return &localSink{config: config}
+}
+
+func (sink *localSink) Create(ctx context.Context, orgID valuer.UUID, jobID valuer.UUID) (io.WriteCloser, error) {
+	dir := sink.dir(orgID, jobID)
+	partialDir := dir + localPartialSuffix
+
+	if err := sink.Delete(ctx, orgID, jobID); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(partialDir, localDirPermissions); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to create export result directory")
+	}
+
+	return &localChunkWriter{dir: dir, partialDir: partialDir, chunkSize: sink.config.ChunkSize}, nil
+}
+
+func (sink *localSink) Open(_ context.Context, orgID valuer.UUID, jobID valuer.UUID) (rawdataexport.Object, error) {
+	dir := sink.dir(orgID, jobID)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Newf(errors.TypeNotFound, exporttypes.ErrCodeExportJobNotFound, "result of export job %s not found", jobID)
+		}
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to read export result directory")
+	}
+
+	chunks := make([]localChunk, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to stat export result chunk")
+		}
+		chunks = append(chunks, localChunk{path: filepath.Join(dir, entry.Name()), size: info.Size()})
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].path < chunks[j].path })
+
+	var offset int64
+	for idx := range chunks {
+		chunks[idx].offset = offset
+		offset += chunks[idx].size
+	}
+
+	return &localObject{chunks: chunks, files: make(map[int]*os.File), size: offset}, nil
+}
+
+func (sink *localSink) Delete(_ context.Context, orgID valuer.UUID, jobID valuer.UUID) error {
+	dir := sink.dir(orgID, jobID)
+	if err := os.RemoveAll(dir + localPartialSuffix); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to delete partial export result")
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to delete export result")
+	}
+	return nil
+}
+
+func (sink *localSink) dir(orgID valuer.UUID, jobID valuer.UUID) string {
+	return filepath.Join(sink.config.Directory, orgID.StringValue(), jobID.StringValue())
+}
+
+// localChunkWriter writes to numbered chunk files, starting a new one every chunkSize bytes.
+type localChunkWriter struct {
+	dir        string
+	partialDir string
+	chunkSize  int64
+	file       *os.File
+	written    int64
+	index      int
+}
+
+func (w *localChunkWriter) Write(p []byte) (int, error) {
+	total := 0
+	for len(p) > 0 {
+		if w.file == nil || w.written >= w.chunkSize {
+			if err := w.rotate(); err != nil {
+				return total, err
+			}
+		}
+
+		n := int64(len(p))
+		if remaining := w.chunkSize - w.written; n > remaining {
+			n = remaining
+		}
+
+		written, err := w.file.Write(p[:n])
+		total += written
+		w.written += int64(written)
+		if err != nil {
+			return total, err
+		}
+		p = p[written:]
+	}
+	return total, nil
+}
+
+func (w *localChunkWriter) Close() error {
+	if w.file == nil {
+		// an empty result is stored as a single empty chunk
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(w.partialDir, w.dir)
+}
+
+func (w *localChunkWriter) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(filepath.Join(w.partialDir, fmt.Sprintf("%s%05d", localChunkPrefix, w.index)), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, localFilePermissions)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.written = 0
+	w.index++
+	return nil
+}
+
+type localChunk struct {
+	path   string
+	offset int64
+	size   int64
+}
+
+// localObject reads across the chunk files of a result, opening them as they are read.
+type localObject struct {
+	mu     sync.Mutex
+	chunks []localChunk
+	files  map[int]*os.File
+	size   int64
+}
+
+func (object *localObject) Size() int64 {
+	return object.size
+}
+
+func (object *localObject) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.NewInvalidInputf(errors.CodeInvalidInput, "negative offset %d", off)
+	}
+
+	total := 0
+	for len(p) > 0 {
+		if off >= object.size {
+			return total, io.EOF
+		}
+
+		idx := sort.Search(len(object.chunks), func(i int) bool {
+			return object.chunks[i].offset+object.chunks[i].size > off
+		})
+		chunk := object.chunks[idx]
+
+		file, err := object.file(idx)
+		if err != nil {
+			return total, err
+		}
+
+		n := int64(len(p))
+		if remaining := chunk.offset + chunk.size - off; n > remaining {
+			n = remaining
+		}
+
+		read, err := file.ReadAt(p[:n], off-chunk.offset)
+		total += read
+		off += int64(read)
+		p = p[read:]
+		if err != nil && (err != io.EOF || int64(read) < n) {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func (object *localObject) Close() error {
+	object.mu.Lock()
+	defer object.mu.Unlock()
+
+	var errs []error
+	for idx, file := range object.files {
+		if err := file.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(object.files, idx)
+	}
+	return errors.Join(errs...)
+}
+
+func (object *localObject) file(idx int) (*os.File, error) {
+	object.mu.Lock()
+	defer object.mu.Unlock()
+
+	if file, ok := object.files[idx]; ok {
+		return file, nil
+	}
+
+	file, err := os.Open(object.chunks[idx].path)
+	if err != nil {
+		return nil, err
+	}
+	object.files[idx] = file
+	return file, nil
+}
@@ -0,0 +1,82 @@
+package implrawdataexport
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalSink(t *testing.T) {
+	ctx := context.Background()
+	sink := NewLocalSink(rawdataexport.LocalSinkConfig{Directory: t.TempDir(), ChunkSize: 10})
+	orgID, jobID := valuer.GenerateUUID(), valuer.GenerateUUID()
+
+	writer, err := sink.Create(ctx, orgID, jobID)
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("0123456789abcdef"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("ghijklmno"))
+	require.NoError(t, err)
+
+	// the result cannot be opened before it is complete
+	_, err = sink.Open(ctx, orgID, jobID)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	require.NoError(t, writer.Close())
+
+	entries, err := os.ReadDir(sink.(*localSink).dir(orgID, jobID))
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	object, err := sink.Open(ctx, orgID, jobID)
+	require.NoError(t, err)
+	defer object.Close()
+
+	assert.Equal(t, int64(25), object.Size())
+
+	all, err := io.ReadAll(io.NewSectionReader(object, 0, object.Size()))
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdefghijklmno", string(all))
+
+	// a read spanning all the chunks
+	buf := make([]byte, 14)
+	n, err := object.ReadAt(buf, 8)
+	require.NoError(t, err)
+	assert.Equal(t, "89abcdefghijkl", string(buf[:n]))
+
+	// a read past the end
+	n, err = object.ReadAt(buf, 20)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, "klmno", string(buf[:n]))
+
+	require.NoError(t, sink.Delete(ctx, orgID, jobID))
+	_, err = sink.Open(ctx, orgID, jobID)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
+
+func TestLocalSinkEmptyResult(t *testing.T) {
+	ctx := context.Background()
+	sink := NewLocalSink(rawdataexport.LocalSinkConfig{Directory: t.TempDir(), ChunkSize: 10})
+	orgID, jobID := valuer.GenerateUUID(), valuer.GenerateUUID()
+
+	writer, err := sink.Create(ctx, orgID, jobID)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	object, err := sink.Open(ctx, orgID, jobID)
+	require.NoError(t, err)
+	defer object.Close()
+
+	assert.Equal(t, int64(0), object.Size())
+	n, err := object.ReadAt(make([]byte, 1), 0)
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -2,23 +2,45 @@ package implrawdataexport
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
 	"github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
 	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type Module struct {
-	querier querier.Querier
+	querier  querier.Querier
+	store    exporttypes.ExportJobStore
+	sink     rawdataexport.Sink
+	settings factory.ScopedProviderSettings
+	config   rawdataexport.JobsConfig
+	// slots limits the number of export jobs running at the same time.
+	slots chan struct{}
+	// cancels holds the cancel functions of the export jobs queued or running in this process.
+	cancels map[valuer.UUID]context.CancelFunc
+	mu      sync.Mutex
+	// owner identifies this process as the holder of the export jobs it queues or runs.
+	owner string
 }
 
-func NewModule(querier querier.Querier) rawdataexport.Module {
+func NewModule(querier querier.Querier, store exporttypes.ExportJobStore, sink rawdataexport.Sink, providerSettings factory.ProviderSettings, config rawdataexport.Config) rawdataexport.Module {
 	return &Module{
-		querier: querier,
+		querier:  querier,
+		store:    store,
+		sink:     sink,
+		settings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/rawdataexport/implrawdataexport"),
+		config:   config.Jobs,
+		slots:    make(chan struct{}, config.Jobs.MaxConcurrent),
+		cancels:  make(map[valuer.UUID]context.CancelFunc),
+		owner:    valuer.GenerateUUID().StringValue(),
 	}
 }
 
@@ -28,6 +50,11 @@ func (m *Module) ExportRawData(ctx context.Context, orgID valuer.UUID, rangeRequ
 		instrumentationtypes.CodeFunctionName: "ExportRawData",
 	})
 
+	return m.exportRawData(ctx, orgID, rangeRequest, doneChan, ClickhouseExportRawDataTimeout)
+}
+
+func (m *Module) exportRawData(ctx context.Context, orgID valuer.UUID, rangeRequest *qbtypes.QueryRangeRequest, doneChan chan any, timeout time.Duration) (chan *qbtypes.RawRow, chan error) {
+
 	traceOperatorQueryIndex := rangeRequest.TraceOperatorQueryIndex()
 
 	queries := rangeRequest.CompositeQuery.Queries
@@ -48,7 +75,7 @@ func (m *Module) ExportRawData(ctx context.Context, orgID valuer.UUID, rangeRequ
 		// Set clickhouse max threads
 		ctx := ctxtypes.SetClickhouseMaxThreads(ctx, ClickhouseExportRawDataMaxThreads)
 		// Set clickhouse timeout
-		contextWithTimeout, cancel := context.WithTimeout(ctx, ClickhouseExportRawDataTimeout)
+		contextWithTimeout, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 		defer close(errChan)
 		defer close(rowChan)
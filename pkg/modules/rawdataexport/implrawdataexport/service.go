@@ -0,0 +1,116 @@
+package implrawdataexport
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
+)
+
+type service struct {
+	settings factory.ScopedProviderSettings
+	store    exporttypes.ExportJobStore
+	sink     rawdataexport.Sink
+	config   rawdataexport.JobsConfig
+	stopC    chan struct{}
+	healthyC chan struct{}
+}
+
+func NewService(providerSettings factory.ProviderSettings, store exporttypes.ExportJobStore, sink rawdataexport.Sink, config rawdataexport.Config) rawdataexport.Service {
+	return &service{
+		settings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/rawdataexport/implrawdataexport"),
+		store:    store,
+		sink:     sink,
+		config:   config.Jobs,
+		stopC:    make(chan struct{}),
+		healthyC: make(chan struct{}),
+	}
+}
+
+func (s *service) Start(ctx context.Context) error {
+	close(s.healthyC)
+
+	ticker := time.NewTicker(JobCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.failInterrupted(ctx); err != nil {
+			s.settings.Logger().WarnContext(ctx, "failed to fail interrupted export jobs", errors.Attr(err))
+		}
+
+		if err := s.deleteExpired(ctx); err != nil {
+			s.settings.Logger().WarnContext(ctx, "failed to delete expired export jobs", errors.Attr(err))
+		}
+
+		select {
+		case <-s.stopC:
+			return nil
+		case <-ticker.C:
+			continue
+		}
+	}
+}
+
+func (s *service) Healthy() <-chan struct{} {
+	return s.healthyC
+}
+
+func (s *service) Stop(ctx context.Context) error {
+	close(s.stopC)
+	return nil
+}
+
+// failInterrupted fails the queued and running jobs whose lease expired, the process holding them
+// stopped before it finished them. The jobs of live processes, this one or other replicas, are left
+// alone as their lease is renewed.
+func (s *service) failInterrupted(ctx context.Context) error {
+	jobs, err := s.store.ListLeaseExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		owner := job.Owner
+		job.Finish(exporttypes.ExportJobStatusFailed, errors.NewInternalf(errors.CodeInternal, "export job was interrupted, the process running it stopped"), s.config.Retention)
+		failed, err := s.store.Update(ctx, job, exporttypes.ExportJobStatusQueued, exporttypes.ExportJobStatusRunning)
+		if err != nil {
+			return err
+		}
+		if !failed {
+			// the job finished since it was listed
+			continue
+		}
+
+		if err := s.sink.Delete(ctx, job.OrgID, job.ID); err != nil {
+			return err
+		}
+
+		s.settings.Logger().InfoContext(ctx, "failed interrupted export job", slog.String("job_id", job.ID.StringValue()), slog.String("owner", owner))
+	}
+
+	return nil
+}
+
+// deleteExpired deletes the finished jobs and their results once the retention is over.
+func (s *service) deleteExpired(ctx context.Context) error {
+	jobs, err := s.store.ListExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := s.sink.Delete(ctx, job.OrgID, job.ID); err != nil {
+			return err
+		}
+
+		if err := s.store.Delete(ctx, job.OrgID, job.ID); err != nil && !errors.Ast(err, errors.TypeNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package implrawdataexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailInterrupted(t *testing.T) {
+	ctx := context.Background()
+	module := newTestModule(t, &fakeQuerier{})
+	svc := NewService(factorytest.NewSettings(), module.store, module.sink, rawdataexport.Config{Jobs: module.config}).(*service)
+	orgID := valuer.GenerateUUID()
+
+	newJob := func(status exporttypes.ExportJobStatus, lease time.Duration) *exporttypes.ExportJob {
+		job := exporttypes.NewExportJob(orgID, "viewer@signoz.io", "csv", *newJobRequest(10), 10)
+		job.Status = status
+		if lease != 0 {
+			job.Lease("other-replica", lease)
+		}
+		require.NoError(t, module.store.Create(ctx, job))
+		return job
+	}
+
+	// Jobs held by a live replica keep running, jobs whose lease expired or
+	// which have none are failed.
+	live := newJob(exporttypes.ExportJobStatusRunning, time.Minute)
+	expired := newJob(exporttypes.ExportJobStatusRunning, -time.Minute)
+	queued := newJob(exporttypes.ExportJobStatusQueued, -time.Minute)
+	unleased := newJob(exporttypes.ExportJobStatusRunning, 0)
+	finished := newJob(exporttypes.ExportJobStatusSucceeded, -time.Minute)
+
+	require.NoError(t, svc.failInterrupted(ctx))
+
+	for job, status := range map[*exporttypes.ExportJob]exporttypes.ExportJobStatus{
+		live:     exporttypes.ExportJobStatusRunning,
+		expired:  exporttypes.ExportJobStatusFailed,
+		queued:   exporttypes.ExportJobStatusFailed,
+		unleased: exporttypes.ExportJobStatusFailed,
+		finished: exporttypes.ExportJobStatusSucceeded,
+	} {
+		stored, err := module.store.Get(ctx, orgID, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, status, stored.Status, job.ID.StringValue())
+	}
+}
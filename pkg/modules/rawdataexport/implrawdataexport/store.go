@@ -0,0 +1,148 @@
+package implrawdataexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) exporttypes.ExportJobStore {
+	return &store{sqlstore: sqlstore}
+}
+
+func (store *store) Create(ctx context.Context, job *exporttypes.ExportJob) error {
+	_, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(job).
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, exporttypes.ErrCodeExportJobAlreadyExists, "export job %s already exists", job.ID)
+	}
+
+	return nil
+}
+
+func (store *store) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*exporttypes.ExportJob, error) {
+	job := new(exporttypes.ExportJob)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(job).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, exporttypes.ErrCodeExportJobNotFound, "export job %s not found in the org", id)
+	}
+
+	return job, nil
+}
+
+func (store *store) List(ctx context.Context, orgID valuer.UUID) ([]*exporttypes.ExportJob, error) {
+	jobs := make([]*exporttypes.ExportJob, 0)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&jobs).
+		Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (store *store) ListLeaseExpired(ctx context.Context, before time.Time) ([]*exporttypes.ExportJob, error) {
+	jobs := make([]*exporttypes.ExportJob, 0)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&jobs).
+		Where("status IN (?)", bun.In([]exporttypes.ExportJobStatus{exporttypes.ExportJobStatusQueued, exporttypes.ExportJobStatusRunning})).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("lease_expires_at IS NULL").WhereOr("lease_expires_at < ?", before)
+		}).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (store *store) ListExpired(ctx context.Context, before time.Time) ([]*exporttypes.ExportJob, error) {
+	jobs := make([]*exporttypes.ExportJob, 0)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&jobs).
+		Where("expires_at IS NOT NULL").
+		Where("expires_at < ?", before).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (store *store) Update(ctx context.Context, job *exporttypes.ExportJob, from ...exporttypes.ExportJobStatus) (bool, error) {
+	res, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(job).
+		Where("org_id = ?", job.OrgID).
+		Where("id = ?", job.ID).
+		Where("status IN (?)", bun.In(from)).
+		ExcludeColumn("id", "org_id", "created_at", "created_by", "format", "request", "row_limit").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (store *store) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	res, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model((*exporttypes.ExportJob)(nil)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, exporttypes.ErrCodeExportJobNotFound, "export job %s not found in the org", id)
+	}
+
+	return nil
+}
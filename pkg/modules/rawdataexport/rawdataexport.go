@@ -2,16 +2,70 @@ package rawdataexport
 
 import (
 	"context"
+	"io"
 	"net/http"
 
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/exporttypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type Module interface {
 	ExportRawData(ctx context.Context, orgID valuer.UUID, rangeRequest *qbtypes.QueryRangeRequest, doneChan chan any) (chan *qbtypes.RawRow, chan error)
+
+	// CreateJob persists an export job and runs it in the background.
+	CreateJob(ctx context.Context, orgID valuer.UUID, createdBy string, format string, rangeRequest *qbtypes.QueryRangeRequest) (*exporttypes.ExportJob, error)
+
+	// GetJob returns an export job. Only its creator or an admin can access it.
+	GetJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID, accessedBy string, isAdmin bool) (*exporttypes.ExportJob, error)
+
+	// ListJobs lists the export jobs created by the user, or all the export jobs of the org for an admin.
+	ListJobs(ctx context.Context, orgID valuer.UUID, accessedBy string, isAdmin bool) ([]*exporttypes.ExportJob, error)
+
+	// CancelJob cancels a queued or running export job. Only its creator or an admin can cancel it.
+	CancelJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, isAdmin bool) (*exporttypes.ExportJob, error)
+
+	// OpenJobResult opens the result of a succeeded export job. Only its creator or an admin can open it.
+	// The caller must close the returned object.
+	OpenJobResult(ctx context.Context, orgID valuer.UUID, id valuer.UUID, accessedBy string, isAdmin bool) (*exporttypes.ExportJob, Object, error)
 }
 
 type Handler interface {
 	ExportRawData(http.ResponseWriter, *http.Request)
+
+	CreateJob(http.ResponseWriter, *http.Request)
+
+	GetJob(http.ResponseWriter, *http.Request)
+
+	ListJobs(http.ResponseWriter, *http.Request)
+
+	CancelJob(http.ResponseWriter, *http.Request)
+
+	DownloadJobResult(http.ResponseWriter, *http.Request)
+}
+
+// Service fails the export jobs whose process stopped holding them and deletes the expired ones.
+type Service interface {
+	factory.ServiceWithHealthy
+}
+
+// Sink stores the results of export jobs.
+type Sink interface {
+	// Create returns a writer for the result of the job. The result is stored once the writer is closed.
+	Create(ctx context.Context, orgID valuer.UUID, jobID valuer.UUID) (io.WriteCloser, error)
+
+	// Open opens the stored result of the job.
+	Open(ctx context.Context, orgID valuer.UUID, jobID valuer.UUID) (Object, error)
+
+	// Delete deletes the result of the job. It does not fail if there is no result.
+	Delete(ctx context.Context, orgID valuer.UUID, jobID valuer.UUID) error
+}
+
+// Object is the stored result of an export job.
+type Object interface {
+	io.ReaderAt
+	io.Closer
+
+	Size() int64
 }
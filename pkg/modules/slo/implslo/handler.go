@@ -0,0 +1,225 @@
+package implslo
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/binding"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/modules/slo"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	module slo.Module
+}
+
+func NewHandler(module slo.Module) slo.Handler {
+	return &handler{module: module}
+}
+
+// List handles GET /api/v1/slos.
+func (h *handler) List(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	slos, err := h.module.List(ctx, valuer.MustNewUUID(claims.OrgID))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, &slotypes.GettableSLOs{SLOs: slos})
+}
+
+// Get handles GET /api/v1/slos/{id}.
+func (h *handler) Get(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := sloIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	slo, err := h.module.Get(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, slo)
+}
+
+// Create handles POST /api/v1/slos.
+func (h *handler) Create(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable := new(slotypes.PostableSLO)
+	if err := binding.JSON.BindBody(r.Body, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	slo, err := h.module.Create(ctx, valuer.MustNewUUID(claims.OrgID), claims.Email, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, slo)
+}
+
+// Update handles PUT /api/v1/slos/{id}.
+func (h *handler) Update(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := sloIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable := new(slotypes.PostableSLO)
+	if err := binding.JSON.BindBody(r.Body, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	slo, err := h.module.Update(ctx, valuer.MustNewUUID(claims.OrgID), id, claims.Email, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, slo)
+}
+
+// Delete handles DELETE /api/v1/slos/{id}.
+func (h *handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := sloIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := h.module.Delete(ctx, valuer.MustNewUUID(claims.OrgID), id); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// GetErrorBudget handles GET /api/v1/slos/{id}/error_budget.
+func (h *handler) GetErrorBudget(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := sloIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	errorBudget, err := h.module.GetErrorBudget(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, errorBudget)
+}
+
+// GetBurnRates handles GET /api/v1/slos/{id}/burn_rate.
+func (h *handler) GetBurnRates(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := sloIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var q slotypes.BurnRateQuery
+	if err := binding.Query.BindQuery(r.URL.Query(), &q); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var windows []valuer.TextDuration
+	if !q.Window.IsZero() {
+		windows = append(windows, q.Window)
+	}
+
+	burnRates, err := h.module.GetBurnRates(ctx, valuer.MustNewUUID(claims.OrgID), id, windows)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, burnRates)
+}
+
+// sloIDFromPath extracts and validates the {id} path variable.
+func sloIDFromPath(r *http.Request) (valuer.UUID, error) {
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		return valuer.UUID{}, errors.Wrapf(err, errors.TypeInvalidInput, slotypes.ErrCodeSLOInvalidInput, "id is not a valid uuid")
+	}
+	return id, nil
+}
@@ -0,0 +1,321 @@
+package implslo
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/slo"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/ruler"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	store    slotypes.Store
+	querier  querier.Querier
+	ruler    ruler.Ruler
+	settings factory.ScopedProviderSettings
+}
+
+func NewModule(store slotypes.Store, querier querier.Querier, ruler ruler.Ruler, providerSettings factory.ProviderSettings) slo.Module {
+	return &module{
+		store:    store,
+		querier:  querier,
+		ruler:    ruler,
+		settings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/slo/implslo"),
+	}
+}
+
+func (module *module) List(ctx context.Context, orgID valuer.UUID) ([]*slotypes.SLO, error) {
+	return module.store.List(ctx, orgID)
+}
+
+func (module *module) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.SLO, error) {
+	return module.store.Get(ctx, orgID, id)
+}
+
+func (module *module) Create(ctx context.Context, orgID valuer.UUID, createdBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	slo := slotypes.NewSLO(orgID, createdBy, postable)
+
+	ruleIDs, err := module.createRules(ctx, slo)
+	if err != nil {
+		return nil, err
+	}
+	slo.RuleIDs = ruleIDs
+
+	if err := module.store.Create(ctx, slo); err != nil {
+		module.deleteRules(ctx, ruleIDs)
+		return nil, err
+	}
+
+	return slo, nil
+}
+
+func (module *module) Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	slo, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *slo
+	slo.Update(updatedBy, postable)
+
+	// The rules are edited in place so that they keep their alert state. They
+	// are only regenerated when alerting is turned on or when the SLO was
+	// created with a different policy.
+	if slo.Alerting.Enabled && len(previous.RuleIDs) == 3*len(slotypes.BurnRatePolicy) {
+		if err := module.editRules(ctx, slo); err != nil {
+			module.restoreRules(ctx, &previous)
+			return nil, err
+		}
+
+		if err := module.store.Update(ctx, slo); err != nil {
+			module.restoreRules(ctx, &previous)
+			return nil, err
+		}
+
+		return slo, nil
+	}
+
+	ruleIDs, err := module.createRules(ctx, slo)
+	if err != nil {
+		return nil, err
+	}
+	slo.RuleIDs = ruleIDs
+
+	if err := module.store.Update(ctx, slo); err != nil {
+		module.deleteRules(ctx, ruleIDs)
+		return nil, err
+	}
+
+	module.deleteRules(ctx, previous.RuleIDs)
+	return slo, nil
+}
+
+func (module *module) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	slo, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := module.store.Delete(ctx, orgID, id); err != nil {
+		return err
+	}
+
+	module.deleteRules(ctx, slo.RuleIDs)
+	return nil
+}
+
+func (module *module) GetErrorBudget(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.GettableErrorBudget, error) {
+	slo, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	start := end.Add(-slo.Window.Duration())
+
+	good, total, err := module.countEvents(ctx, orgID, slo, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return slotypes.NewGettableErrorBudget(slo, start, end, good, total), nil
+}
+
+func (module *module) GetBurnRates(ctx context.Context, orgID valuer.UUID, id valuer.UUID, windows []valuer.TextDuration) (*slotypes.GettableBurnRates, error) {
+	slo, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(windows) == 0 {
+		windows = slotypes.BurnRateWindows()
+	}
+
+	end := time.Now()
+	burnRates := make([]*slotypes.BurnRate, 0, len(windows))
+	for _, window := range windows {
+		if !window.IsPositive() || window.Duration() > slo.Window.Duration() {
+			return nil, errors.NewInvalidInputf(slotypes.ErrCodeSLOInvalidInput, "window: must be a positive duration no longer than the slo window %s, got %s", slo.Window.StringValue(), window.StringValue())
+		}
+
+		good, total, err := module.countEvents(ctx, orgID, slo, end.Add(-window.Duration()), end)
+		if err != nil {
+			return nil, err
+		}
+		burnRates = append(burnRates, slotypes.NewBurnRate(slo, window, good, total))
+	}
+
+	return &slotypes.GettableBurnRates{End: end.UnixMilli(), BurnRates: burnRates}, nil
+}
+
+// createRules creates the rules of the burn rate policy for the SLO and
+// returns their ids. Nothing is left behind when it fails.
+func (module *module) createRules(ctx context.Context, slo *slotypes.SLO) ([]string, error) {
+	ruleIDs := []string{}
+	if !slo.Alerting.Enabled {
+		return ruleIDs, nil
+	}
+
+	create := func(rule *ruletypes.PostableRule) (string, error) {
+		data, err := json.Marshal(rule)
+		if err != nil {
+			return "", err
+		}
+
+		created, err := module.ruler.CreateRule(ctx, string(data))
+		if err != nil {
+			return "", err
+		}
+
+		ruleIDs = append(ruleIDs, created.Id)
+		return created.Id, nil
+	}
+
+	for _, alert := range slotypes.BurnRatePolicy {
+		longRuleID, err := create(alert.NewBurnRateRule(slo, alert.Long))
+		if err != nil {
+			module.deleteRules(ctx, ruleIDs)
+			return nil, err
+		}
+
+		shortRuleID, err := create(alert.NewBurnRateRule(slo, alert.Short))
+		if err != nil {
+			module.deleteRules(ctx, ruleIDs)
+			return nil, err
+		}
+
+		if _, err := create(alert.NewCompositeRule(slo, longRuleID, shortRuleID)); err != nil {
+			module.deleteRules(ctx, ruleIDs)
+			return nil, err
+		}
+	}
+
+	return ruleIDs, nil
+}
+
+// editRules updates the rules of the burn rate policy of the SLO, which are
+// listed in the order createRules creates them.
+func (module *module) editRules(ctx context.Context, slo *slotypes.SLO) error {
+	edit := func(rule *ruletypes.PostableRule, id string) error {
+		ruleID, err := valuer.NewUUID(id)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+
+		return module.ruler.EditRule(ctx, string(data), ruleID)
+	}
+
+	for i, alert := range slotypes.BurnRatePolicy {
+		longRuleID, shortRuleID, compositeRuleID := slo.RuleIDs[3*i], slo.RuleIDs[3*i+1], slo.RuleIDs[3*i+2]
+		if err := edit(alert.NewBurnRateRule(slo, alert.Long), longRuleID); err != nil {
+			return err
+		}
+
+		if err := edit(alert.NewBurnRateRule(slo, alert.Short), shortRuleID); err != nil {
+			return err
+		}
+
+		if err := edit(alert.NewCompositeRule(slo, longRuleID, shortRuleID), compositeRuleID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreRules edits the rules back to the previous definition of the SLO
+// after a failed update. Failures are logged, the next update edits them again.
+func (module *module) restoreRules(ctx context.Context, previous *slotypes.SLO) {
+	if err := module.editRules(ctx, previous); err != nil {
+		module.settings.Logger().ErrorContext(ctx, "failed to restore slo burn rate rules", slog.String("slo_id", previous.ID.StringValue()), errors.Attr(err))
+	}
+}
+
+// deleteRules deletes the rules, composite rules ahead of the rules they
+// combine. Failures are logged, a rule left behind can be deleted by hand.
+func (module *module) deleteRules(ctx context.Context, ruleIDs []string) {
+	for _, id := range slices.Backward(ruleIDs) {
+		if err := module.ruler.DeleteRule(ctx, id); err != nil && !errors.Ast(err, errors.TypeNotFound) {
+			module.settings.Logger().ErrorContext(ctx, "failed to delete slo burn rate rule", slog.String("rule_id", id), errors.Attr(err))
+		}
+	}
+}
+
+// countEvents returns the number of good and total events of the SLO between
+// start and end.
+func (module *module) countEvents(ctx context.Context, orgID valuer.UUID, slo *slotypes.SLO, start, end time.Time) (float64, float64, error) {
+	resp, err := module.querier.QueryRange(ctx, orgID, slo.NewQueryRangeRequest(start, end))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var good, total float64
+	for _, result := range resp.Data.Results {
+		data, ok := result.(*qbtypes.ScalarData)
+		if !ok || data == nil {
+			continue
+		}
+
+		switch data.QueryName {
+		case slotypes.GoodQueryName:
+			good = scalarValue(data)
+		case slotypes.TotalQueryName:
+			total = scalarValue(data)
+		}
+	}
+
+	return good, total, nil
+}
+
+// scalarValue returns the value of the aggregation of a result with a single
+// row, zero when there is no data.
+func scalarValue(data *qbtypes.ScalarData) float64 {
+	if len(data.Data) == 0 {
+		return 0
+	}
+
+	for i, column := range data.Columns {
+		if column.Type != qbtypes.ColumnTypeAggregation || i >= len(data.Data[0]) {
+			continue
+		}
+
+		switch v := data.Data[0][i].(type) {
+		case float64:
+			return v
+		case float32:
+			return float64(v)
+		case int64:
+			return float64(v)
+		case uint64:
+			return float64(v)
+		case int:
+			return float64(v)
+		}
+		return 0
+	}
+
+	return 0
+}
@@ -0,0 +1,307 @@
+package implslo
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/ruler"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRuler keeps the created rules in memory.
+type fakeRuler struct {
+	ruler.Ruler
+
+	mu    sync.Mutex
+	rules map[string]*ruletypes.PostableRule
+	// failAfter makes CreateRule fail once that many rules were created.
+	failAfter int
+	created   int
+	// failEditAfter makes EditRule fail once that many rules were edited.
+	failEditAfter int
+	edited        int
+}
+
+func (r *fakeRuler) CreateRule(_ context.Context, ruleStr string) (*ruletypes.GettableRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failAfter > 0 && r.created == r.failAfter {
+		return nil, errors.New(errors.TypeInternal, errors.CodeInternal, "failed to create rule")
+	}
+
+	rule := new(ruletypes.PostableRule)
+	if err := json.Unmarshal([]byte(ruleStr), rule); err != nil {
+		return nil, err
+	}
+
+	id := valuer.GenerateUUID().StringValue()
+	r.rules[id] = rule
+	r.created++
+	return &ruletypes.GettableRule{Id: id}, nil
+}
+
+func (r *fakeRuler) EditRule(_ context.Context, ruleStr string, id valuer.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failEditAfter > 0 && r.edited == r.failEditAfter {
+		r.failEditAfter = 0
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "failed to edit rule")
+	}
+
+	if _, ok := r.rules[id.StringValue()]; !ok {
+		return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "rule %s not found", id)
+	}
+
+	rule := new(ruletypes.PostableRule)
+	if err := json.Unmarshal([]byte(ruleStr), rule); err != nil {
+		return err
+	}
+
+	r.rules[id.StringValue()] = rule
+	r.edited++
+	return nil
+}
+
+func (r *fakeRuler) DeleteRule(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "rule %s not found", id)
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+func (r *fakeRuler) ids() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0, len(r.rules))
+	for id := range r.rules {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// fakeQuerier returns the good and total counts of the window the request spans.
+type fakeQuerier struct {
+	counts map[time.Duration][2]float64
+}
+
+func (q *fakeQuerier) QueryRange(_ context.Context, _ valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	counts := q.counts[time.Duration(req.End-req.Start)*time.Millisecond]
+
+	results := make([]any, 0, 2)
+	for i, name := range []string{slotypes.GoodQueryName, slotypes.TotalQueryName} {
+		results = append(results, &qbtypes.ScalarData{
+			QueryName: name,
+			Columns:   []*qbtypes.ColumnDescriptor{{QueryName: name, Type: qbtypes.ColumnTypeAggregation}},
+			Data:      [][]any{{counts[i]}},
+		})
+	}
+
+	return &qbtypes.QueryRangeResponse{Data: qbtypes.QueryData{Results: results}}, nil
+}
+
+func (q *fakeQuerier) QueryRawStream(context.Context, valuer.UUID, *qbtypes.QueryRangeRequest, *qbtypes.RawStream) {
+}
+
+func (q *fakeQuerier) PurgeCache(context.Context, valuer.UUID, *qbtypes.PostableCachePurge) error {
+	return nil
+}
+
+func newTestModule(t *testing.T, querier *fakeQuerier, ruler *fakeRuler) *module {
+	t.Helper()
+
+	sqlStore, err := sqlitesqlstore.New(context.Background(), factorytest.NewSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Connection: sqlstore.ConnectionConfig{
+			MaxOpenConns: 1,
+		},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:            filepath.Join(t.TempDir(), "test.db"),
+			Mode:            "wal",
+			BusyTimeout:     5 * time.Second,
+			TransactionMode: "deferred",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = sqlStore.BunDB().NewCreateTable().Model((*slotypes.SLO)(nil)).IfNotExists().Exec(context.Background())
+	require.NoError(t, err)
+
+	if ruler.rules == nil {
+		ruler.rules = map[string]*ruletypes.PostableRule{}
+	}
+
+	return NewModule(NewStore(sqlStore), querier, ruler, factorytest.NewSettings()).(*module)
+}
+
+func newPostableSLO(t *testing.T, alerting bool) *slotypes.PostableSLO {
+	t.Helper()
+
+	j := `{
+		"name": "Checkout availability",
+		"indicator": {
+			"good": {"type": "builder_query", "spec": {"name": "good", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "service.name = 'checkout' AND has_error = false"}}},
+			"total": {"type": "builder_query", "spec": {"name": "total", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "service.name = 'checkout'"}}}
+		},
+		"target": 0.99,
+		"window": "720h",
+		"alerting": {"enabled": false, "channels": ["oncall"]}
+	}`
+
+	postable := new(slotypes.PostableSLO)
+	require.NoError(t, json.Unmarshal([]byte(j), postable))
+	postable.Alerting.Enabled = alerting
+	return postable
+}
+
+func TestModuleCRUD(t *testing.T) {
+	ctx := context.Background()
+	ruler := &fakeRuler{}
+	module := newTestModule(t, &fakeQuerier{}, ruler)
+	orgID := valuer.GenerateUUID()
+
+	created, err := module.Create(ctx, orgID, "user@signoz.io", newPostableSLO(t, true))
+	require.NoError(t, err)
+	// a long, a short and a composite rule per alert of the policy
+	require.Len(t, created.RuleIDs, 3*len(slotypes.BurnRatePolicy))
+	assert.ElementsMatch(t, created.RuleIDs, ruler.ids())
+
+	got, err := module.Get(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Checkout availability", got.Name)
+	assert.Equal(t, created.RuleIDs, got.RuleIDs)
+
+	slos, err := module.List(ctx, orgID)
+	require.NoError(t, err)
+	assert.Len(t, slos, 1)
+
+	_, err = module.Get(ctx, valuer.GenerateUUID(), created.ID)
+	assert.Error(t, err)
+
+	// updating the slo edits its rules in place, every rule notifies the
+	// channels of the slo
+	postable := newPostableSLO(t, true)
+	postable.Name = "Checkout success"
+	postable.Alerting.Channels = []string{"checkout"}
+	updated, err := module.Update(ctx, orgID, created.ID, "editor@signoz.io", postable)
+	require.NoError(t, err)
+	assert.Equal(t, "Checkout success", updated.Name)
+	assert.Equal(t, created.RuleIDs, updated.RuleIDs)
+	assert.ElementsMatch(t, updated.RuleIDs, ruler.ids())
+	assert.Equal(t, len(created.RuleIDs), ruler.created)
+	for _, id := range updated.RuleIDs {
+		assert.Contains(t, ruler.rules[id].AlertName, "Checkout success")
+		assert.Equal(t, []string{"checkout"}, ruler.rules[id].PreferredChannels)
+	}
+
+	// disabling alerting drops the rules
+	_, err = module.Update(ctx, orgID, created.ID, "editor@signoz.io", newPostableSLO(t, false))
+	require.NoError(t, err)
+	assert.Empty(t, ruler.ids())
+
+	// enabling it again creates them
+	reenabled, err := module.Update(ctx, orgID, created.ID, "editor@signoz.io", newPostableSLO(t, true))
+	require.NoError(t, err)
+	require.Len(t, reenabled.RuleIDs, 3*len(slotypes.BurnRatePolicy))
+	assert.ElementsMatch(t, reenabled.RuleIDs, ruler.ids())
+
+	require.NoError(t, module.Delete(ctx, orgID, created.ID))
+	_, err = module.Get(ctx, orgID, created.ID)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
+
+func TestModuleCreateCleansUpRules(t *testing.T) {
+	ctx := context.Background()
+	ruler := &fakeRuler{failAfter: 4}
+	module := newTestModule(t, &fakeQuerier{}, ruler)
+	orgID := valuer.GenerateUUID()
+
+	_, err := module.Create(ctx, orgID, "user@signoz.io", newPostableSLO(t, true))
+	require.Error(t, err)
+	assert.Empty(t, ruler.ids())
+
+	slos, err := module.List(ctx, orgID)
+	require.NoError(t, err)
+	assert.Empty(t, slos)
+}
+
+func TestModuleUpdateRestoresRules(t *testing.T) {
+	ctx := context.Background()
+	ruler := &fakeRuler{}
+	module := newTestModule(t, &fakeQuerier{}, ruler)
+	orgID := valuer.GenerateUUID()
+
+	created, err := module.Create(ctx, orgID, "user@signoz.io", newPostableSLO(t, true))
+	require.NoError(t, err)
+
+	ruler.failEditAfter = 4
+	postable := newPostableSLO(t, true)
+	postable.Name = "Checkout success"
+	_, err = module.Update(ctx, orgID, created.ID, "editor@signoz.io", postable)
+	require.Error(t, err)
+
+	// the rules edited before the failure are back to the stored slo
+	got, err := module.Get(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Checkout availability", got.Name)
+	assert.ElementsMatch(t, created.RuleIDs, ruler.ids())
+	for _, id := range created.RuleIDs {
+		assert.Contains(t, ruler.rules[id].AlertName, "Checkout availability")
+	}
+}
+
+func TestModuleErrorBudgetAndBurnRates(t *testing.T) {
+	ctx := context.Background()
+	querier := &fakeQuerier{counts: map[time.Duration][2]float64{
+		720 * time.Hour: {9950, 10000},
+		time.Hour:       {90, 100},
+		5 * time.Minute: {0, 0},
+	}}
+	module := newTestModule(t, querier, &fakeRuler{})
+	orgID := valuer.GenerateUUID()
+
+	created, err := module.Create(ctx, orgID, "user@signoz.io", newPostableSLO(t, false))
+	require.NoError(t, err)
+
+	budget, err := module.GetErrorBudget(ctx, orgID, created.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.995, budget.SLI, 1e-9)
+	assert.InDelta(t, 0.5, budget.Consumed, 1e-9)
+	assert.InDelta(t, 0.5, budget.Remaining, 1e-9)
+
+	burnRates, err := module.GetBurnRates(ctx, orgID, created.ID, []valuer.TextDuration{valuer.MustParseTextDuration("1h"), valuer.MustParseTextDuration("5m")})
+	require.NoError(t, err)
+	require.Len(t, burnRates.BurnRates, 2)
+	// 10% of the events failed against a 1% error budget
+	assert.InDelta(t, 10, burnRates.BurnRates[0].BurnRate, 1e-9)
+	// no events burn no budget
+	assert.Zero(t, burnRates.BurnRates[1].BurnRate)
+
+	all, err := module.GetBurnRates(ctx, orgID, created.ID, nil)
+	require.NoError(t, err)
+	assert.Len(t, all.BurnRates, len(slotypes.BurnRateWindows()))
+
+	_, err = module.GetBurnRates(ctx, orgID, created.ID, []valuer.TextDuration{valuer.MustParseTextDuration("1000h")})
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
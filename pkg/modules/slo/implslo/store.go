@@ -0,0 +1,112 @@
+package implslo
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) slotypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (store *store) List(ctx context.Context, orgID valuer.UUID) ([]*slotypes.SLO, error) {
+	slos := make([]*slotypes.SLO, 0)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&slos).
+		Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slos, nil
+}
+
+func (store *store) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.SLO, error) {
+	slo := new(slotypes.SLO)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(slo).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, slotypes.ErrCodeSLONotFound, "slo %s not found in the org", id)
+	}
+
+	return slo, nil
+}
+
+func (store *store) Create(ctx context.Context, slo *slotypes.SLO) error {
+	_, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(slo).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (store *store) Update(ctx context.Context, slo *slotypes.SLO) error {
+	res, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(slo).
+		Where("org_id = ?", slo.OrgID).
+		Where("id = ?", slo.ID).
+		ExcludeColumn("id", "org_id", "created_at", "created_by").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, slotypes.ErrCodeSLONotFound, "slo %s not found in the org", slo.ID)
+	}
+
+	return nil
+}
+
+func (store *store) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	res, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model((*slotypes.SLO)(nil)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, slotypes.ErrCodeSLONotFound, "slo %s not found in the org", id)
+	}
+
+	return nil
+}
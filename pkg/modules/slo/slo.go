@@ -0,0 +1,41 @@
+package slo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Module interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*slotypes.SLO, error)
+	Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.SLO, error)
+
+	// Create stores the SLO and generates its burn rate alert rules when
+	// alerting is enabled.
+	Create(ctx context.Context, orgID valuer.UUID, createdBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error)
+
+	// Update replaces the SLO and updates its burn rate alert rules in place,
+	// creating or deleting them when alerting is turned on or off.
+	Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error)
+
+	// Delete removes the SLO along with its burn rate alert rules.
+	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+
+	// GetErrorBudget computes the error budget of the SLO over its window ending now.
+	GetErrorBudget(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.GettableErrorBudget, error)
+
+	// GetBurnRates computes the burn rate of the SLO over each of the windows ending now.
+	GetBurnRates(ctx context.Context, orgID valuer.UUID, id valuer.UUID, windows []valuer.TextDuration) (*slotypes.GettableBurnRates, error)
+}
+
+type Handler interface {
+	List(rw http.ResponseWriter, r *http.Request)
+	Get(rw http.ResponseWriter, r *http.Request)
+	Create(rw http.ResponseWriter, r *http.Request)
+	Update(rw http.ResponseWriter, r *http.Request)
+	Delete(rw http.ResponseWriter, r *http.Request)
+	GetErrorBudget(rw http.ResponseWriter, r *http.Request)
+	GetBurnRates(rw http.ResponseWriter, r *http.Request)
+}
@@ -4,19 +4,22 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
 	"github.com/SigNoz/signoz/pkg/modules/spanmapper"
 	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
 	"github.com/SigNoz/signoz/pkg/types/opamptypes"
 	"github.com/SigNoz/signoz/pkg/types/spantypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type module struct {
-	store spantypes.SpanMapperStore
+	store       spantypes.SpanMapperStore
+	lookupTable lookuptable.Module
 }
 
-func NewModule(store spantypes.SpanMapperStore) spanmapper.Module {
-	return &module{store: store}
+func NewModule(store spantypes.SpanMapperStore, lookupTable lookuptable.Module) spanmapper.Module {
+	return &module{store: store, lookupTable: lookupTable}
 }
 
 func (module *module) ListGroups(ctx context.Context, orgID valuer.UUID, q *spantypes.ListSpanMapperGroupsQuery) ([]*spantypes.SpanMapperGroup, error) {
@@ -114,7 +117,12 @@ func (module *module) RecommendAgentConfig(orgID valuer.UUID, currentConfYaml []
 		return nil, "", err
 	}
 
-	updatedConf, err := spantypes.GenerateCollectorConfigWithSpanMapperProcessor(currentConfYaml, enabled)
+	lookupTables, err := module.listLookupTables(ctx, orgID, enabled)
+	if err != nil {
+		return nil, "", err
+	}
+
+	updatedConf, err := spantypes.GenerateCollectorConfigWithSpanMapperProcessor(currentConfYaml, enabled, lookupTables)
 	if err != nil {
 		return nil, "", err
 	}
@@ -154,3 +162,17 @@ func (module *module) listEnabledGroupsWithMappers(ctx context.Context, orgID va
 	}
 	return out, nil
 }
+
+// listLookupTables returns the entries of the lookup tables of the org, only
+// when one of the mappers looks its target up.
+func (module *module) listLookupTables(ctx context.Context, orgID valuer.UUID, groups []*spantypes.SpanMapperGroupWithMappers) (map[string]lookuptabletypes.Entries, error) {
+	for _, g := range groups {
+		for _, m := range g.Mappers {
+			if m.Config.Lookup != nil {
+				return module.lookupTable.ListSnapshotEntries(ctx, orgID)
+			}
+		}
+	}
+
+	return nil, nil
+}
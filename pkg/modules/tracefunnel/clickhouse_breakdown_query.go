@@ -0,0 +1,348 @@
+package tracefunnel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
+)
+
+// BreakdownStep is a funnel step whose condition has been compiled to a
+// ClickHouse boolean expression over the columns of the funnel's table.
+type BreakdownStep struct {
+	Step      *tracefunneltypes.FunnelStep
+	Condition string
+	Args      []any
+}
+
+// BreakdownColumn is a compiled correlation or group by expression.
+type BreakdownColumn struct {
+	Expr string
+	Args []any
+}
+
+// breakdownTable describes how the breakdown query reads a signal's table.
+type breakdownTable struct {
+	name        string
+	columns     []string
+	timeFilter  string
+	endTime     string
+	errorExpr   string
+	nanoseconds string
+}
+
+func newBreakdownTable(signal telemetrytypes.Signal, startTs int64, endTs int64) breakdownTable {
+	startBucket := startTs/querybuilder.NsToSeconds - querybuilder.BucketAdjustment
+	endBucket := endTs / querybuilder.NsToSeconds
+
+	if signal == telemetrytypes.SignalLogs {
+		return breakdownTable{
+			name:        fmt.Sprintf("%s.%s", telemetrylogs.DBName, telemetrylogs.LogsV2TableName),
+			columns:     []string{"timestamp", "severity_number"},
+			timeFilter:  fmt.Sprintf("timestamp BETWEEN %d AND %d\n                AND ts_bucket_start BETWEEN %d AND %d", startTs, endTs, startBucket, endBucket),
+			endTime:     "timestamp",
+			errorExpr:   "severity_number >= 17",
+			nanoseconds: "toInt64(%s)",
+		}
+	}
+
+	return breakdownTable{
+		name:        "signoz_traces.distributed_signoz_index_v3",
+		columns:     []string{"timestamp", "duration_nano", "has_error"},
+		timeFilter:  fmt.Sprintf("timestamp BETWEEN toDateTime64(%d/1e9, 9) AND toDateTime64(%d/1e9, 9)\n                AND ts_bucket_start BETWEEN %d AND %d", startTs, endTs, startBucket, endBucket),
+		endTime:     "timestamp + toIntervalNanosecond(duration_nano)",
+		errorExpr:   "has_error = true",
+		nanoseconds: "toUnixTimestamp64Nano(%s)",
+	}
+}
+
+// stepTimeNanos returns, in nanoseconds, the time a journey matched the
+// 1-based step.
+func (table breakdownTable) stepTimeNanos(step int) string {
+	return fmt.Sprintf(table.nanoseconds, fmt.Sprintf("t%d_time", step))
+}
+
+func breakdownStepAnchors(steps []BreakdownStep) []int {
+	funnelSteps := make([]*tracefunneltypes.FunnelStep, len(steps))
+	for i, step := range steps {
+		funnelSteps[i] = step.Step
+	}
+
+	return tracefunneltypes.FunnelStepAnchors(funnelSteps)
+}
+
+// buildFunnelJourneysQuery builds a query returning, per journey, whether
+// each step was reached (s<n>_reached), when (t<n>_time), whether it errored
+// (s<n>_error), how long it took from its anchor (s<n>_conversion_ms) and
+// the number of records matching any step (record_count).
+//
+// A journey is the set of spans or log records sharing the correlation
+// expression (trace_id for trace funnels). It enters the funnel when it has
+// a record matching the first step. A later step is reached when its anchor
+// (the closest previous required step) was reached, a matching record
+// exists, it happened after the anchor unless the step is unordered, and it
+// happened within the step's max conversion time. Optional steps never act
+// as anchors, so skipping them does not stop a journey from progressing.
+func buildFunnelJourneysQuery(table breakdownTable, correlation BreakdownColumn, steps []BreakdownStep, groupBy []BreakdownColumn) (string, []any) {
+	anchors := breakdownStepAnchors(steps)
+
+	args := []any{}
+
+	// Innermost: evaluate the correlation, each step condition and group by
+	// expression once per record.
+	recordFields := append([]string{fmt.Sprintf("%s AS correlation_id", correlation.Expr)}, table.columns...)
+	args = append(args, correlation.Args...)
+	matches := make([]string, len(steps))
+	for i, step := range steps {
+		condition := step.Condition
+		if step.Step.HasErrors {
+			condition = fmt.Sprintf("(%s) AND %s", condition, table.errorExpr)
+		}
+		recordFields = append(recordFields, fmt.Sprintf("(%s) AS m%d", condition, i+1))
+		args = append(args, step.Args...)
+		matches[i] = fmt.Sprintf("m%d", i+1)
+	}
+	for i, column := range groupBy {
+		recordFields = append(recordFields, fmt.Sprintf("toString(%s) AS gv%d", column.Expr, i+1))
+		args = append(args, column.Args...)
+	}
+
+	// Per journey: first match time, presence and error per step.
+	journeyFields := []string{"correlation_id", "count() AS record_count"}
+	for i := range groupBy {
+		journeyFields = append(journeyFields, fmt.Sprintf("anyIf(gv%d, m1) AS g%d", i+1, i+1))
+	}
+	for i, step := range steps {
+		timestamp := "timestamp"
+		if strings.ToLower(step.Step.LatencyPointer) == "end" {
+			timestamp = table.endTime
+		}
+		journeyFields = append(journeyFields,
+			fmt.Sprintf("countIf(m%d) > 0 AS s%d_present", i+1, i+1),
+			fmt.Sprintf("minIf(%s, m%d) AS t%d_time", timestamp, i+1, i+1),
+			fmt.Sprintf("toUInt8(maxIf(%s, m%d)) AS s%d_error", table.errorExpr, i+1, i+1),
+		)
+	}
+
+	// Per journey: whether each step was reached and how long it took from its anchor.
+	reachFields := []string{"*"}
+	for i, step := range steps {
+		if anchors[i] < 0 {
+			reachFields = append(reachFields, fmt.Sprintf("s%d_present AS s%d_reached", i+1, i+1))
+			continue
+		}
+
+		a := anchors[i] + 1
+		elapsed := fmt.Sprintf("(%s - %s)", table.stepTimeNanos(i+1), table.stepTimeNanos(a))
+		if step.Step.Unordered {
+			elapsed = fmt.Sprintf("abs%s", elapsed)
+		}
+		reachFields = append(reachFields, fmt.Sprintf("%s / 1e6 AS s%d_conversion_ms", elapsed, i+1))
+
+		conditions := []string{fmt.Sprintf("s%d_reached", a), fmt.Sprintf("s%d_present", i+1)}
+		if !step.Step.Unordered {
+			conditions = append(conditions, fmt.Sprintf("t%d_time > t%d_time", i+1, a))
+		}
+		if step.Step.MaxConversionTimeMs > 0 {
+			conditions = append(conditions, fmt.Sprintf("s%d_conversion_ms <= %d", i+1, step.Step.MaxConversionTimeMs))
+		}
+		reachFields = append(reachFields, fmt.Sprintf("%s AS s%d_reached", strings.Join(conditions, " AND "), i+1))
+	}
+
+	queryTemplate := `
+    SELECT
+        %s
+    FROM (
+        SELECT
+            %s
+        FROM (
+            SELECT
+                %s
+            FROM %s
+            WHERE
+                %s
+        )
+        WHERE (%s) AND correlation_id != ''
+        GROUP BY correlation_id
+        HAVING s1_present
+    )`
+
+	query := fmt.Sprintf(queryTemplate,
+		strings.Join(reachFields, ",\n        "),
+		strings.Join(journeyFields, ",\n            "),
+		strings.Join(recordFields, ",\n                "),
+		table.name,
+		table.timeFilter,
+		strings.Join(matches, " OR "),
+	)
+
+	return query, args
+}
+
+// BuildFunnelBreakdownQuery builds a query returning, per group, arrays of
+// per-step journey counts, errored journey counts and conversion times.
+// Journeys are described by buildFunnelJourneysQuery.
+func BuildFunnelBreakdownQuery(signal telemetrytypes.Signal, correlation BreakdownColumn, steps []BreakdownStep, groupBy []BreakdownColumn, startTs int64, endTs int64, limit int) (string, []any) {
+	journeys, args := buildFunnelJourneysQuery(newBreakdownTable(signal, startTs, endTs), correlation, steps, groupBy)
+	anchors := breakdownStepAnchors(steps)
+
+	traces := make([]string, len(steps))
+	erroredTraces := make([]string, len(steps))
+	avgConversionTimes := make([]string, len(steps))
+	conversionTimes := make([]string, len(steps))
+	for i, step := range steps {
+		traces[i] = fmt.Sprintf("countIf(s%d_reached)", i+1)
+		erroredTraces[i] = fmt.Sprintf("countIf(s%d_reached AND s%d_error = 1)", i+1, i+1)
+		if anchors[i] < 0 {
+			avgConversionTimes[i] = "toFloat64(0)"
+			conversionTimes[i] = "toFloat64(0)"
+			continue
+		}
+		_, quantile := step.Step.LatencyQuantile()
+		avgConversionTimes[i] = fmt.Sprintf("ifNotFinite(avgIf(s%d_conversion_ms, s%d_reached), 0)", i+1, i+1)
+		conversionTimes[i] = fmt.Sprintf("ifNotFinite(quantileIf(%g)(s%d_conversion_ms, s%d_reached), 0)", quantile, i+1, i+1)
+	}
+
+	labels := "CAST([], 'Array(String)')"
+	groupClause := ""
+	if len(groupBy) > 0 {
+		groupColumns := make([]string, len(groupBy))
+		for i := range groupBy {
+			groupColumns[i] = fmt.Sprintf("g%d", i+1)
+		}
+		labels = fmt.Sprintf("[%s]", strings.Join(groupColumns, ", "))
+		groupClause = fmt.Sprintf("\nGROUP BY %s\nORDER BY traces[1] DESC\nLIMIT %d", strings.Join(groupColumns, ", "), limit)
+	}
+
+	queryTemplate := `
+SELECT
+    %s AS labels,
+    [%s] AS traces,
+    [%s] AS errored_traces,
+    [%s] AS avg_conversion_time_ms,
+    [%s] AS conversion_time_ms
+FROM (%s
+)%s`
+
+	query := fmt.Sprintf(queryTemplate,
+		labels,
+		strings.Join(traces, ", "),
+		strings.Join(erroredTraces, ", "),
+		strings.Join(avgConversionTimes, ", "),
+		strings.Join(conversionTimes, ", "),
+		journeys,
+		groupClause,
+	)
+
+	return query, args
+}
+
+// BuildFunnelTransitionQuery builds a query returning the conversion rate,
+// rate, errors, average duration and latency of the journeys from step
+// stepStart to step stepEnd, both 1-based, in the columns of the overview
+// analytics. Journeys are described by buildFunnelJourneysQuery.
+func BuildFunnelTransitionQuery(signal telemetrytypes.Signal, correlation BreakdownColumn, steps []BreakdownStep, startTs int64, endTs int64, stepStart int64, stepEnd int64) (string, []any, error) {
+	if err := validateStepRange(steps, stepStart, stepEnd); err != nil {
+		return "", nil, err
+	}
+
+	table := newBreakdownTable(signal, startTs, endTs)
+	journeys, args := buildFunnelJourneysQuery(table, correlation, steps, nil)
+
+	errored := []string{}
+	for i := stepStart; i <= stepEnd; i++ {
+		errored = append(errored, fmt.Sprintf("countIf(s%d_reached AND s%d_error = 1)", i, i))
+	}
+
+	converted := fmt.Sprintf("s%d_reached AND s%d_reached", stepStart, stepEnd)
+	duration := fmt.Sprintf("(%s - %s) / 1e6", table.stepTimeNanos(int(stepEnd)), table.stepTimeNanos(int(stepStart)))
+	_, quantile := steps[stepEnd-1].Step.LatencyQuantile()
+
+	queryTemplate := `
+SELECT
+    round(if(countIf(s%d_reached) > 0, countIf(%s) * 100.0 / countIf(s%d_reached), 0), 2) AS conversion_rate,
+    countIf(%s) / %g AS avg_rate,
+    greatest(%s) AS errors,
+    ifNotFinite(avgIf(%s, %s), 0) AS avg_duration,
+    ifNotFinite(quantileIf(%g)(%s, %s), 0) AS latency
+FROM (%s
+)`
+
+	query := fmt.Sprintf(queryTemplate,
+		stepStart, converted, stepStart,
+		converted, float64(endTs-startTs)/1e9,
+		strings.Join(errored, ", "),
+		duration, converted,
+		quantile, duration, converted,
+		journeys,
+	)
+
+	return query, args, nil
+}
+
+// BuildFunnelSlowestJourneysQuery builds a query returning the five slowest
+// journeys from step stepStart to step stepEnd, both 1-based, in the columns
+// of the slowest traces analytics. With erroredOnly, only the journeys which
+// errored in either step are returned. Journeys are described by
+// buildFunnelJourneysQuery.
+func BuildFunnelSlowestJourneysQuery(signal telemetrytypes.Signal, correlation BreakdownColumn, steps []BreakdownStep, startTs int64, endTs int64, stepStart int64, stepEnd int64, erroredOnly bool) (string, []any, error) {
+	if err := validateStepRange(steps, stepStart, stepEnd); err != nil {
+		return "", nil, err
+	}
+
+	table := newBreakdownTable(signal, startTs, endTs)
+	journeys, args := buildFunnelJourneysQuery(table, correlation, steps, nil)
+
+	converted := fmt.Sprintf("s%d_reached AND s%d_reached", stepStart, stepEnd)
+	if erroredOnly {
+		converted = fmt.Sprintf("%s AND (s%d_error = 1 OR s%d_error = 1)", converted, stepStart, stepEnd)
+	}
+
+	queryTemplate := `
+SELECT
+    correlation_id AS trace_id,
+    (%s - %s) / 1e6 AS duration_ms,
+    record_count AS span_count
+FROM (%s
+)
+WHERE %s
+ORDER BY duration_ms DESC
+LIMIT 5`
+
+	query := fmt.Sprintf(queryTemplate,
+		table.stepTimeNanos(int(stepEnd)), table.stepTimeNanos(int(stepStart)),
+		journeys,
+		converted,
+	)
+
+	return query, args, nil
+}
+
+// BuildFunnelValidationJourneysQuery builds a query returning the first five
+// journeys entering the funnel, in the columns of the traces validation.
+// Journeys are described by buildFunnelJourneysQuery.
+func BuildFunnelValidationJourneysQuery(signal telemetrytypes.Signal, correlation BreakdownColumn, steps []BreakdownStep, startTs int64, endTs int64) (string, []any) {
+	journeys, args := buildFunnelJourneysQuery(newBreakdownTable(signal, startTs, endTs), correlation, steps, nil)
+
+	queryTemplate := `
+SELECT
+    correlation_id AS trace_id
+FROM (%s
+)
+ORDER BY t1_time
+LIMIT 5`
+
+	return fmt.Sprintf(queryTemplate, journeys), args
+}
+
+func validateStepRange(steps []BreakdownStep, stepStart int64, stepEnd int64) error {
+	if stepStart < 1 || stepEnd > int64(len(steps)) || stepStart >= stepEnd {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "step_start and step_end must be ordered steps between 1 and %d, got %d and %d", len(steps), stepStart, stepEnd)
+	}
+
+	return nil
+}
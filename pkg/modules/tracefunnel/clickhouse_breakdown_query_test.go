@@ -0,0 +1,218 @@
+package tracefunnel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFunnelBreakdownQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		signal         telemetrytypes.Signal
+		correlation    BreakdownColumn
+		steps          []BreakdownStep
+		groupBy        []BreakdownColumn
+		wantContains   []string
+		wantNotContain []string
+		wantArgs       []any
+	}{
+		{
+			name:        "ordered steps without group by",
+			signal:      telemetrytypes.SignalTraces,
+			correlation: BreakdownColumn{Expr: "trace_id"},
+			steps: []BreakdownStep{
+				{Step: &tracefunneltypes.FunnelStep{}, Condition: "name = ?", Args: []any{"checkout"}},
+				{Step: &tracefunneltypes.FunnelStep{LatencyType: "p95", HasErrors: true}, Condition: "name = ?", Args: []any{"pay"}},
+			},
+			wantContains: []string{
+				"(name = ?) AS m1",
+				"((name = ?) AND has_error = true) AS m2",
+				"countIf(m1) > 0 AS s1_present",
+				"minIf(timestamp, m2) AS t2_time",
+				"s1_present AS s1_reached",
+				"(toUnixTimestamp64Nano(t2_time) - toUnixTimestamp64Nano(t1_time)) / 1e6 AS s2_conversion_ms",
+				"s1_reached AND s2_present AND t2_time > t1_time AS s2_reached",
+				"ifNotFinite(quantileIf(0.95)(s2_conversion_ms, s2_reached), 0)",
+				"CAST([], 'Array(String)') AS labels",
+				"trace_id AS correlation_id",
+				"WHERE (m1 OR m2) AND correlation_id != ''",
+				"GROUP BY correlation_id",
+				"HAVING s1_present",
+				"timestamp BETWEEN toDateTime64(1700000000000000000/1e9, 9) AND toDateTime64(1700003600000000000/1e9, 9)",
+				"ts_bucket_start BETWEEN 1699998200 AND 1700003600",
+			},
+			wantNotContain: []string{"GROUP BY g1", "LIMIT"},
+			wantArgs:       []any{"checkout", "pay"},
+		},
+		{
+			name:        "optional, unordered and windowed steps with group by",
+			signal:      telemetrytypes.SignalTraces,
+			correlation: BreakdownColumn{Expr: "trace_id"},
+			steps: []BreakdownStep{
+				{Step: &tracefunneltypes.FunnelStep{}, Condition: "name = ?", Args: []any{"checkout"}},
+				{Step: &tracefunneltypes.FunnelStep{Optional: true}, Condition: "name = ?", Args: []any{"coupon"}},
+				{Step: &tracefunneltypes.FunnelStep{Unordered: true, LatencyPointer: "end"}, Condition: "name = ?", Args: []any{"fraud_check"}},
+				{Step: &tracefunneltypes.FunnelStep{LatencyType: "p90", MaxConversionTimeMs: 30000}, Condition: "name = ?", Args: []any{"pay"}},
+			},
+			groupBy: []BreakdownColumn{{Expr: "resources_string[?]", Args: []any{"deployment.environment"}}},
+			wantContains: []string{
+				"toString(resources_string[?]) AS gv1",
+				"anyIf(gv1, m1) AS g1",
+				"s1_reached AND s2_present AND t2_time > t1_time AS s2_reached",
+				// step 3 is anchored on step 1 because step 2 is optional
+				"abs(toUnixTimestamp64Nano(t3_time) - toUnixTimestamp64Nano(t1_time)) / 1e6 AS s3_conversion_ms",
+				"s1_reached AND s3_present AS s3_reached",
+				"minIf(timestamp + toIntervalNanosecond(duration_nano), m3) AS t3_time",
+				"s3_reached AND s4_present AND t4_time > t3_time AND s4_conversion_ms <= 30000 AS s4_reached",
+				"ifNotFinite(quantileIf(0.9)(s4_conversion_ms, s4_reached), 0)",
+				"[g1] AS labels",
+				"GROUP BY g1\nORDER BY traces[1] DESC\nLIMIT 10",
+			},
+			wantArgs: []any{"checkout", "coupon", "fraud_check", "pay", "deployment.environment"},
+		},
+		{
+			name:        "log steps correlated by an attribute",
+			signal:      telemetrytypes.SignalLogs,
+			correlation: BreakdownColumn{Expr: "toString(attributes_string[?])", Args: []any{"session.id"}},
+			steps: []BreakdownStep{
+				{Step: &tracefunneltypes.FunnelStep{}, Condition: "body = ?", Args: []any{"cart_viewed"}},
+				{Step: &tracefunneltypes.FunnelStep{HasErrors: true, MaxConversionTimeMs: 600000}, Condition: "body = ?", Args: []any{"order_placed"}},
+			},
+			wantContains: []string{
+				"toString(attributes_string[?]) AS correlation_id",
+				"FROM signoz_logs.distributed_logs_v2",
+				"timestamp BETWEEN 1700000000000000000 AND 1700003600000000000",
+				"((body = ?) AND severity_number >= 17) AS m2",
+				"toUInt8(maxIf(severity_number >= 17, m1)) AS s1_error",
+				"(toInt64(t2_time) - toInt64(t1_time)) / 1e6 AS s2_conversion_ms",
+				"s1_reached AND s2_present AND t2_time > t1_time AND s2_conversion_ms <= 600000 AS s2_reached",
+			},
+			wantNotContain: []string{"duration_nano", "has_error", "toUnixTimestamp64Nano"},
+			wantArgs:       []any{"session.id", "cart_viewed", "order_placed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := BuildFunnelBreakdownQuery(tt.signal, tt.correlation, tt.steps, tt.groupBy, 1700000000000000000, 1700003600000000000, 10)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, query, want)
+			}
+			for _, notWant := range tt.wantNotContain {
+				assert.NotContains(t, query, notWant)
+			}
+			assert.Equal(t, tt.wantArgs, args)
+			assert.Equal(t, len(tt.wantArgs), strings.Count(query, "?"))
+		})
+	}
+}
+
+func TestBuildFunnelTransitionQuery(t *testing.T) {
+	steps := []BreakdownStep{
+		{Step: &tracefunneltypes.FunnelStep{}, Condition: "body = ?", Args: []any{"cart_viewed"}},
+		{Step: &tracefunneltypes.FunnelStep{Optional: true}, Condition: "body = ?", Args: []any{"coupon"}},
+		{Step: &tracefunneltypes.FunnelStep{LatencyType: "p90", HasErrors: true}, Condition: "body = ?", Args: []any{"order_placed"}},
+	}
+	correlation := BreakdownColumn{Expr: "toString(attributes_string[?])", Args: []any{"session.id"}}
+
+	query, args, err := BuildFunnelTransitionQuery(telemetrytypes.SignalLogs, correlation, steps, 1700000000000000000, 1700003600000000000, 1, 3)
+	require.NoError(t, err)
+	for _, want := range []string{
+		"round(if(countIf(s1_reached) > 0, countIf(s1_reached AND s3_reached) * 100.0 / countIf(s1_reached), 0), 2) AS conversion_rate",
+		"countIf(s1_reached AND s3_reached) / 3600 AS avg_rate",
+		"greatest(countIf(s1_reached AND s1_error = 1), countIf(s2_reached AND s2_error = 1), countIf(s3_reached AND s3_error = 1)) AS errors",
+		"ifNotFinite(avgIf((toInt64(t3_time) - toInt64(t1_time)) / 1e6, s1_reached AND s3_reached), 0) AS avg_duration",
+		"ifNotFinite(quantileIf(0.9)((toInt64(t3_time) - toInt64(t1_time)) / 1e6, s1_reached AND s3_reached), 0) AS latency",
+		// step 3 is anchored on step 1 because step 2 is optional
+		"s1_reached AND s3_present AND t3_time > t1_time AS s3_reached",
+		"FROM signoz_logs.distributed_logs_v2",
+	} {
+		assert.Contains(t, query, want)
+	}
+	assert.Equal(t, []any{"session.id", "cart_viewed", "coupon", "order_placed"}, args)
+
+	for _, stepRange := range [][2]int64{{0, 2}, {2, 2}, {3, 1}, {1, 4}} {
+		_, _, err := BuildFunnelTransitionQuery(telemetrytypes.SignalLogs, correlation, steps, 1700000000000000000, 1700003600000000000, stepRange[0], stepRange[1])
+		assert.Error(t, err)
+	}
+}
+
+func TestBuildFunnelSlowestJourneysQuery(t *testing.T) {
+	steps := []BreakdownStep{
+		{Step: &tracefunneltypes.FunnelStep{}, Condition: "body = ?", Args: []any{"cart_viewed"}},
+		{Step: &tracefunneltypes.FunnelStep{}, Condition: "body = ?", Args: []any{"order_placed"}},
+	}
+	correlation := BreakdownColumn{Expr: "toString(attributes_string[?])", Args: []any{"session.id"}}
+
+	query, args, err := BuildFunnelSlowestJourneysQuery(telemetrytypes.SignalLogs, correlation, steps, 1700000000000000000, 1700003600000000000, 1, 2, true)
+	require.NoError(t, err)
+	for _, want := range []string{
+		"correlation_id AS trace_id",
+		"(toInt64(t2_time) - toInt64(t1_time)) / 1e6 AS duration_ms",
+		"record_count AS span_count",
+		"WHERE s1_reached AND s2_reached AND (s1_error = 1 OR s2_error = 1)",
+		"ORDER BY duration_ms DESC",
+		"FROM signoz_logs.distributed_logs_v2",
+	} {
+		assert.Contains(t, query, want)
+	}
+	assert.Equal(t, []any{"session.id", "cart_viewed", "order_placed"}, args)
+
+	query, _, err = BuildFunnelSlowestJourneysQuery(telemetrytypes.SignalLogs, correlation, steps, 1700000000000000000, 1700003600000000000, 1, 2, false)
+	require.NoError(t, err)
+	assert.NotContains(t, query, "s1_error = 1 OR")
+
+	_, _, err = BuildFunnelSlowestJourneysQuery(telemetrytypes.SignalLogs, correlation, steps, 1700000000000000000, 1700003600000000000, 2, 1, false)
+	assert.Error(t, err)
+}
+
+func TestBuildFunnelValidationJourneysQuery(t *testing.T) {
+	steps := []BreakdownStep{
+		{Step: &tracefunneltypes.FunnelStep{}, Condition: "body = ?", Args: []any{"cart_viewed"}},
+		{Step: &tracefunneltypes.FunnelStep{}, Condition: "body = ?", Args: []any{"order_placed"}},
+	}
+	correlation := BreakdownColumn{Expr: "toString(attributes_string[?])", Args: []any{"session.id"}}
+
+	query, args := BuildFunnelValidationJourneysQuery(telemetrytypes.SignalLogs, correlation, steps, 1700000000000000000, 1700003600000000000)
+	assert.Contains(t, query, "correlation_id AS trace_id")
+	assert.Contains(t, query, "ORDER BY t1_time")
+	assert.Equal(t, []any{"session.id", "cart_viewed", "order_placed"}, args)
+	assert.Equal(t, len(args), strings.Count(query, "?"))
+}
+
+func TestLegacyStepCondition(t *testing.T) {
+	condition, args, err := LegacyStepCondition(&tracefunneltypes.FunnelStep{ServiceName: "frontend", SpanName: "GET /checkout"})
+	require.NoError(t, err)
+	assert.Equal(t, "resource_string_service$$name = ? AND name = ?", condition)
+	assert.Equal(t, []any{"frontend", "GET /checkout"}, args)
+}
+
+func TestAnalyticsRejectBreakdownOnlySteps(t *testing.T) {
+	funnel := &tracefunneltypes.StorableFunnel{
+		Steps: []*tracefunneltypes.FunnelStep{
+			{Order: 1, ServiceName: "frontend", SpanName: "checkout"},
+			{Order: 2, ServiceName: "payment", SpanName: "charge", MaxConversionTimeMs: 1000},
+		},
+	}
+
+	_, err := GetFunnelAnalytics(funnel, tracefunneltypes.TimeRange{StartTime: 1, EndTime: 2})
+	assert.Error(t, err)
+
+	_, err = ValidateTraces(funnel, tracefunneltypes.TimeRange{StartTime: 1, EndTime: 2})
+	assert.Error(t, err)
+
+	logFunnel := &tracefunneltypes.StorableFunnel{
+		Steps: []*tracefunneltypes.FunnelStep{
+			{Order: 1, ServiceName: "frontend", SpanName: "checkout"},
+			{Order: 2, ServiceName: "payment", SpanName: "charge"},
+		},
+		FunnelSource: tracefunneltypes.FunnelSource{Signal: telemetrytypes.SignalLogs},
+	}
+	_, err = GetFunnelAnalytics(logFunnel, tracefunneltypes.TimeRange{StartTime: 1, EndTime: 2})
+	assert.Error(t, err)
+}
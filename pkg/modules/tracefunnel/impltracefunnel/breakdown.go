@@ -0,0 +1,297 @@
+package impltracefunnel
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/tracefunnel"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	traceFunnels "github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// GetBreakdown computes per-step conversion and latency for the steps,
+// optionally broken down by the request's group by keys.
+func (module *module) GetBreakdown(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, req *traceFunnels.FunnelBreakdownRequest) (*traceFunnels.GettableFunnelBreakdown, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := traceFunnels.ValidateFunnelStepsForSource(source, steps); err != nil {
+		return nil, err
+	}
+	if err := traceFunnels.ValidateFunnelSteps(steps); err != nil {
+		return nil, err
+	}
+	steps = traceFunnels.NormalizeFunnelSteps(steps)
+
+	correlation, breakdownSteps, groupBy, err := module.compileFunnel(ctx, source, steps, req.GroupBy, req.TimeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := tracefunnel.BuildFunnelBreakdownQuery(source.Signal, correlation, breakdownSteps, groupBy, req.StartTime, req.EndTime, req.Limit)
+
+	rows, err := module.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to query funnel breakdown")
+	}
+	defer rows.Close()
+
+	breakdown := &traceFunnels.GettableFunnelBreakdown{Groups: []*traceFunnels.FunnelBreakdownGroup{}}
+	for rows.Next() {
+		var (
+			labels              []string
+			traces              []uint64
+			erroredTraces       []uint64
+			avgConversionTimeMs []float64
+			conversionTimeMs    []float64
+		)
+		if err := rows.Scan(&labels, &traces, &erroredTraces, &avgConversionTimeMs, &conversionTimeMs); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan funnel breakdown")
+		}
+
+		group, err := traceFunnels.NewFunnelBreakdownGroup(steps, req.GroupBy, labels, traces, erroredTraces, avgConversionTimeMs, conversionTimeMs)
+		if err != nil {
+			return nil, err
+		}
+		breakdown.Groups = append(breakdown.Groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to read funnel breakdown")
+	}
+
+	return breakdown, nil
+}
+
+// GetTransition computes the conversion from step stepStart to step stepEnd,
+// from the first to the last step for the funnel overview.
+func (module *module) GetTransition(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64) (*traceFunnels.GettableFunnelTransition, error) {
+	correlation, transitionSteps, err := module.compileJourneys(ctx, source, steps, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := tracefunnel.BuildFunnelTransitionQuery(source.Signal, correlation, transitionSteps, timeRange.StartTime, timeRange.EndTime, stepStart, stepEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	transition := &traceFunnels.GettableFunnelTransition{}
+	if err := module.telemetryStore.ClickhouseDB().QueryRow(ctx, query, args...).Scan(&transition.ConversionRate, &transition.AvgRate, &transition.Errors, &transition.AvgDuration, &transition.Latency); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to query funnel transition")
+	}
+
+	return transition, nil
+}
+
+// GetSlowestJourneys returns the slowest journeys from step stepStart to step
+// stepEnd, the ones which errored in either step with erroredOnly.
+func (module *module) GetSlowestJourneys(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64, erroredOnly bool) ([]*traceFunnels.GettableFunnelJourney, error) {
+	correlation, journeySteps, err := module.compileJourneys(ctx, source, steps, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := tracefunnel.BuildFunnelSlowestJourneysQuery(source.Signal, correlation, journeySteps, timeRange.StartTime, timeRange.EndTime, stepStart, stepEnd, erroredOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := module.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to query funnel journeys")
+	}
+	defer rows.Close()
+
+	journeys := []*traceFunnels.GettableFunnelJourney{}
+	for rows.Next() {
+		journey := &traceFunnels.GettableFunnelJourney{}
+		if err := rows.Scan(&journey.TraceID, &journey.DurationMs, &journey.SpanCount); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan funnel journeys")
+		}
+		journeys = append(journeys, journey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to read funnel journeys")
+	}
+
+	return journeys, nil
+}
+
+// ValidateJourneys returns the first journeys entering the funnel, only their
+// correlation key values are set.
+func (module *module) ValidateJourneys(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange) ([]*traceFunnels.GettableFunnelJourney, error) {
+	correlation, journeySteps, err := module.compileJourneys(ctx, source, steps, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := tracefunnel.BuildFunnelValidationJourneysQuery(source.Signal, correlation, journeySteps, timeRange.StartTime, timeRange.EndTime)
+
+	rows, err := module.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to query funnel journeys")
+	}
+	defer rows.Close()
+
+	journeys := []*traceFunnels.GettableFunnelJourney{}
+	for rows.Next() {
+		journey := &traceFunnels.GettableFunnelJourney{}
+		if err := rows.Scan(&journey.TraceID); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan funnel journeys")
+		}
+		journeys = append(journeys, journey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to read funnel journeys")
+	}
+
+	return journeys, nil
+}
+
+// compileJourneys validates the funnel and compiles its correlation key and
+// the conditions of its steps over the time range, without group by keys.
+func (module *module) compileJourneys(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange) (tracefunnel.BreakdownColumn, []tracefunnel.BreakdownStep, error) {
+	if err := source.Validate(); err != nil {
+		return tracefunnel.BreakdownColumn{}, nil, err
+	}
+	if err := timeRange.Validate(); err != nil {
+		return tracefunnel.BreakdownColumn{}, nil, err
+	}
+	if err := traceFunnels.ValidateFunnelStepsForSource(source, steps); err != nil {
+		return tracefunnel.BreakdownColumn{}, nil, err
+	}
+	if err := traceFunnels.ValidateFunnelSteps(steps); err != nil {
+		return tracefunnel.BreakdownColumn{}, nil, err
+	}
+	steps = traceFunnels.NormalizeFunnelSteps(steps)
+
+	correlation, journeySteps, _, err := module.compileFunnel(ctx, source, steps, nil, timeRange)
+	return correlation, journeySteps, err
+}
+
+// compileFunnel compiles the correlation key, the conditions of the steps and
+// the group by keys of a funnel over the time range.
+func (module *module) compileFunnel(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, groupByKeys []qbtypes.GroupByKey, timeRange traceFunnels.TimeRange) (tracefunnel.BreakdownColumn, []tracefunnel.BreakdownStep, []tracefunnel.BreakdownColumn, error) {
+	startNs, endNs := uint64(timeRange.StartTime), uint64(timeRange.EndTime)
+	fieldMapper, condBuilder := module.mappersFor(source.Signal)
+
+	keys := map[string][]*telemetrytypes.TelemetryFieldKey{}
+	if keySelectors := breakdownKeySelectors(source, steps, groupByKeys); len(keySelectors) > 0 {
+		var err error
+		keys, _, err = module.telemetryMetadataStore.GetKeysMulti(ctx, keySelectors)
+		if err != nil {
+			return tracefunnel.BreakdownColumn{}, nil, nil, err
+		}
+	}
+
+	breakdownSteps := make([]tracefunnel.BreakdownStep, len(steps))
+	for i, step := range steps {
+		condition, args, err := module.stepCondition(ctx, source.Signal, step, keys, startNs, endNs)
+		if err != nil {
+			return tracefunnel.BreakdownColumn{}, nil, nil, errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "step %d: invalid condition", step.Order)
+		}
+
+		breakdownSteps[i] = tracefunnel.BreakdownStep{Step: step, Condition: condition, Args: args}
+	}
+
+	correlation := tracefunnel.BreakdownColumn{Expr: "trace_id"}
+	if source.CorrelationKey != nil {
+		expr, args, err := querybuilder.CollisionHandledFinalExpr(ctx, startNs, endNs, source.CorrelationKey, fieldMapper, condBuilder, keys, telemetrytypes.FieldDataTypeString, nil, false)
+		if err != nil {
+			return tracefunnel.BreakdownColumn{}, nil, nil, errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "invalid correlation key")
+		}
+		correlation = tracefunnel.BreakdownColumn{Expr: "toString(" + expr + ")", Args: args}
+	}
+
+	groupBy := make([]tracefunnel.BreakdownColumn, len(groupByKeys))
+	for i, key := range groupByKeys {
+		expr, args, err := querybuilder.CollisionHandledFinalExpr(ctx, startNs, endNs, &key.TelemetryFieldKey, fieldMapper, condBuilder, keys, telemetrytypes.FieldDataTypeString, nil, false)
+		if err != nil {
+			return tracefunnel.BreakdownColumn{}, nil, nil, err
+		}
+		groupBy[i] = tracefunnel.BreakdownColumn{Expr: expr, Args: args}
+	}
+
+	return correlation, breakdownSteps, groupBy, nil
+}
+
+func (module *module) mappersFor(signal telemetrytypes.Signal) (qbtypes.FieldMapper, qbtypes.ConditionBuilder) {
+	if signal == telemetrytypes.SignalLogs {
+		return module.logFieldMapper, module.logCondBuilder
+	}
+
+	return module.traceFieldMapper, module.traceCondBuilder
+}
+
+// stepCondition compiles the step's filter expression, or its service, span
+// and v3 filters when it has none.
+func (module *module) stepCondition(ctx context.Context, signal telemetrytypes.Signal, step *traceFunnels.FunnelStep, keys map[string][]*telemetrytypes.TelemetryFieldKey, startNs uint64, endNs uint64) (string, []any, error) {
+	if !step.HasFilterExpression() {
+		return tracefunnel.LegacyStepCondition(step)
+	}
+
+	fieldMapper, condBuilder := module.mappersFor(signal)
+	opts := querybuilder.FilterExprVisitorOpts{
+		Context:          ctx,
+		Logger:           module.logger,
+		FieldMapper:      fieldMapper,
+		ConditionBuilder: condBuilder,
+		FieldKeys:        keys,
+		StartNs:          startNs,
+		EndNs:            endNs,
+	}
+	if signal == telemetrytypes.SignalLogs {
+		opts.FullTextColumn = telemetrylogs.DefaultFullTextColumn
+	}
+
+	prepared, err := querybuilder.PrepareWhereClause(step.Filter.Expression, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	if prepared.IsEmpty() {
+		return "", nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "filter %q does not select any records", step.Filter.Expression)
+	}
+
+	condition, args := prepared.WhereClause.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return strings.TrimPrefix(condition, "WHERE "), args, nil
+}
+
+func breakdownKeySelectors(source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, groupBy []qbtypes.GroupByKey) []*telemetrytypes.FieldKeySelector {
+	var keySelectors []*telemetrytypes.FieldKeySelector
+	for _, step := range steps {
+		if step.HasFilterExpression() {
+			keySelectors = append(keySelectors, querybuilder.QueryStringToKeysSelectors(step.Filter.Expression)...)
+		}
+	}
+
+	if source.CorrelationKey != nil {
+		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
+			Name:          source.CorrelationKey.Name,
+			FieldContext:  source.CorrelationKey.FieldContext,
+			FieldDataType: source.CorrelationKey.FieldDataType,
+		})
+	}
+
+	for _, key := range groupBy {
+		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
+			Name:          key.Name,
+			FieldContext:  key.FieldContext,
+			FieldDataType: key.FieldDataType,
+		})
+	}
+
+	for idx := range keySelectors {
+		keySelectors[idx].Signal = source.Signal
+		keySelectors[idx].SelectorMatchType = telemetrytypes.FieldSelectorMatchTypeExact
+	}
+
+	return keySelectors
+}
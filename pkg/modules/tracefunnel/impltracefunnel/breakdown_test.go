@@ -0,0 +1,250 @@
+package impltracefunnel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	cmock "github.com/SigNoz/clickhouse-go-mock"
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/telemetrytraces"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	traceFunnels "github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBreakdownTestModule(t *testing.T) (*module, cmock.ClickConnMockCommon) {
+	t.Helper()
+
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, sqlmock.QueryMatcherRegexp)
+
+	metadataStore := telemetrytypestest.NewMockMetadataStore()
+	metadataStore.SetStaticFields(telemetrytraces.IntrinsicFields)
+	metadataStore.KeysMap["session.id"] = []*telemetrytypes.TelemetryFieldKey{{
+		Name:          "session.id",
+		Signal:        telemetrytypes.SignalLogs,
+		FieldContext:  telemetrytypes.FieldContextAttribute,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	}}
+	metadataStore.KeysMap["event.name"] = []*telemetrytypes.TelemetryFieldKey{{
+		Name:          "event.name",
+		Signal:        telemetrytypes.SignalLogs,
+		FieldContext:  telemetrytypes.FieldContextAttribute,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	}}
+	metadataStore.KeysMap["deployment.environment"] = []*telemetrytypes.TelemetryFieldKey{{
+		Name:          "deployment.environment",
+		Signal:        telemetrytypes.SignalTraces,
+		FieldContext:  telemetrytypes.FieldContextResource,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	}}
+	metadataStore.KeysMap["http.route"] = []*telemetrytypes.TelemetryFieldKey{{
+		Name:          "http.route",
+		Signal:        telemetrytypes.SignalTraces,
+		FieldContext:  telemetrytypes.FieldContextAttribute,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	}}
+
+	m := NewModule(nil, telemetryStore, metadataStore, flaggertest.New(t), instrumentationtest.New().ToProviderSettings()).(*module)
+	return m, telemetryStore.Mock()
+}
+
+func TestGetBreakdown(t *testing.T) {
+	m, mock := newBreakdownTestModule(t)
+
+	steps := []*traceFunnels.FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "http.route = '/checkout'"}},
+		{Order: 2, ServiceName: "payment", SpanName: "charge", MaxConversionTimeMs: 60000},
+	}
+	req := &traceFunnels.FunnelBreakdownRequest{
+		TimeRange: traceFunnels.TimeRange{StartTime: 1700000000000000000, EndTime: 1700003600000000000},
+		GroupBy:   []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "deployment.environment"}}},
+	}
+
+	cols := []cmock.ColumnType{
+		{Name: "labels", Type: "Array(String)"},
+		{Name: "traces", Type: "Array(UInt64)"},
+		{Name: "errored_traces", Type: "Array(UInt64)"},
+		{Name: "avg_conversion_time_ms", Type: "Array(Float64)"},
+		{Name: "conversion_time_ms", Type: "Array(Float64)"},
+	}
+	values := [][]any{
+		{[]string{"prod"}, []uint64{100, 40}, []uint64{2, 1}, []float64{0, 1500}, []float64{0, 4000}},
+		{[]string{"staging"}, []uint64{10, 10}, []uint64{0, 0}, []float64{0, 250}, []float64{0, 300}},
+	}
+	mock.ExpectQuery(`GROUP BY g1\s+ORDER BY traces\[1\] DESC\s+LIMIT 20`).
+		WithArgs("/checkout", true, "payment", "charge", true).
+		WillReturnRows(cmock.NewRows(cols, values))
+
+	breakdown, err := m.GetBreakdown(context.Background(), traceFunnels.FunnelSource{}, steps, req)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, breakdown.Groups, 2)
+	assert.Equal(t, map[string]string{"deployment.environment": "prod"}, breakdown.Groups[0].Labels)
+	assert.Equal(t, uint64(40), breakdown.Groups[0].Steps[1].Traces)
+	assert.Equal(t, float64(40), breakdown.Groups[0].Steps[1].ConversionRate)
+	assert.Equal(t, float64(4000), breakdown.Groups[0].Steps[1].ConversionTimeMs)
+	assert.Equal(t, float64(100), breakdown.Groups[1].Steps[1].StepConversionRate)
+}
+
+func TestGetBreakdownRejectsInvalidFilter(t *testing.T) {
+	m, _ := newBreakdownTestModule(t)
+
+	steps := []*traceFunnels.FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "http.route = "}},
+		{Order: 2, ServiceName: "payment", SpanName: "charge"},
+	}
+	req := &traceFunnels.FunnelBreakdownRequest{
+		TimeRange: traceFunnels.TimeRange{StartTime: 1700000000000000000, EndTime: 1700003600000000000},
+	}
+
+	_, err := m.GetBreakdown(context.Background(), traceFunnels.FunnelSource{}, steps, req)
+	assert.Error(t, err)
+}
+
+func TestGetBreakdownForLogFunnel(t *testing.T) {
+	m, mock := newBreakdownTestModule(t)
+
+	source := traceFunnels.FunnelSource{
+		Signal:         telemetrytypes.SignalLogs,
+		CorrelationKey: &telemetrytypes.TelemetryFieldKey{Name: "session.id"},
+	}
+	steps := []*traceFunnels.FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "event.name = 'cart_viewed'"}},
+		{Order: 2, Filter: &qbtypes.Filter{Expression: "event.name = 'order_placed'"}},
+	}
+	req := &traceFunnels.FunnelBreakdownRequest{
+		TimeRange: traceFunnels.TimeRange{StartTime: 1700000000000000000, EndTime: 1700003600000000000},
+	}
+
+	cols := []cmock.ColumnType{
+		{Name: "labels", Type: "Array(String)"},
+		{Name: "traces", Type: "Array(UInt64)"},
+		{Name: "errored_traces", Type: "Array(UInt64)"},
+		{Name: "avg_conversion_time_ms", Type: "Array(Float64)"},
+		{Name: "conversion_time_ms", Type: "Array(Float64)"},
+	}
+	values := [][]any{
+		{[]string{}, []uint64{50, 20}, []uint64{0, 0}, []float64{0, 90000}, []float64{0, 120000}},
+	}
+	mock.ExpectQuery(`(?s)AS correlation_id.*FROM signoz_logs\.distributed_logs_v2`).
+		WithArgs(true, "cart_viewed", true, "order_placed", true).
+		WillReturnRows(cmock.NewRows(cols, values))
+
+	breakdown, err := m.GetBreakdown(context.Background(), source, steps, req)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, breakdown.Groups, 1)
+	assert.Equal(t, uint64(20), breakdown.Groups[0].Steps[1].Traces)
+	assert.Equal(t, float64(40), breakdown.Groups[0].Steps[1].ConversionRate)
+}
+
+func TestGetBreakdownRejectsLogFunnelWithoutCorrelationKey(t *testing.T) {
+	m, _ := newBreakdownTestModule(t)
+
+	steps := []*traceFunnels.FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "event.name = 'cart_viewed'"}},
+		{Order: 2, Filter: &qbtypes.Filter{Expression: "event.name = 'order_placed'"}},
+	}
+	req := &traceFunnels.FunnelBreakdownRequest{
+		TimeRange: traceFunnels.TimeRange{StartTime: 1700000000000000000, EndTime: 1700003600000000000},
+	}
+
+	_, err := m.GetBreakdown(context.Background(), traceFunnels.FunnelSource{Signal: telemetrytypes.SignalLogs}, steps, req)
+	assert.Error(t, err)
+}
+
+func TestGetTransitionForLogFunnel(t *testing.T) {
+	m, mock := newBreakdownTestModule(t)
+
+	source := traceFunnels.FunnelSource{
+		Signal:         telemetrytypes.SignalLogs,
+		CorrelationKey: &telemetrytypes.TelemetryFieldKey{Name: "session.id"},
+	}
+	steps := []*traceFunnels.FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "event.name = 'cart_viewed'"}},
+		{Order: 2, Filter: &qbtypes.Filter{Expression: "event.name = 'checkout'"}, Optional: true},
+		{Order: 3, Filter: &qbtypes.Filter{Expression: "event.name = 'order_placed'"}},
+	}
+	timeRange := traceFunnels.TimeRange{StartTime: 1700000000000000000, EndTime: 1700003600000000000}
+
+	cols := []cmock.ColumnType{
+		{Name: "conversion_rate", Type: "Float64"},
+		{Name: "avg_rate", Type: "Float64"},
+		{Name: "errors", Type: "UInt64"},
+		{Name: "avg_duration", Type: "Float64"},
+		{Name: "latency", Type: "Float64"},
+	}
+	mock.ExpectQueryRow(`(?s)countIf\(s1_reached AND s3_reached\) \* 100\.0 / countIf\(s1_reached\).*FROM signoz_logs\.distributed_logs_v2`).
+		WillReturnRow(cmock.NewRow(cols, []any{float64(40), float64(0.005), uint64(3), float64(90000), float64(120000)}))
+
+	transition, err := m.GetTransition(context.Background(), source, steps, timeRange, 1, 3)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, &traceFunnels.GettableFunnelTransition{ConversionRate: 40, AvgRate: 0.005, Errors: 3, AvgDuration: 90000, Latency: 120000}, transition)
+
+	_, err = m.GetTransition(context.Background(), source, steps, timeRange, 2, 2)
+	assert.Error(t, err)
+}
+
+func TestGetSlowestJourneysForLogFunnel(t *testing.T) {
+	m, mock := newBreakdownTestModule(t)
+
+	source := traceFunnels.FunnelSource{
+		Signal:         telemetrytypes.SignalLogs,
+		CorrelationKey: &telemetrytypes.TelemetryFieldKey{Name: "session.id"},
+	}
+	steps := []*traceFunnels.FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "event.name = 'cart_viewed'"}},
+		{Order: 2, Filter: &qbtypes.Filter{Expression: "event.name = 'order_placed'"}},
+	}
+	timeRange := traceFunnels.TimeRange{StartTime: 1700000000000000000, EndTime: 1700003600000000000}
+
+	cols := []cmock.ColumnType{
+		{Name: "trace_id", Type: "String"},
+		{Name: "duration_ms", Type: "Float64"},
+		{Name: "span_count", Type: "UInt64"},
+	}
+	mock.ExpectQuery(`(?s)correlation_id AS trace_id.*FROM signoz_logs\.distributed_logs_v2.*WHERE s1_reached AND s2_reached AND \(s1_error = 1 OR s2_error = 1\)`).
+		WithArgs(true, "cart_viewed", true, "order_placed", true).
+		WillReturnRows(cmock.NewRows(cols, [][]any{{"session-1", float64(90000), uint64(4)}}))
+
+	journeys, err := m.GetSlowestJourneys(context.Background(), source, steps, timeRange, 1, 2, true)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []*traceFunnels.GettableFunnelJourney{{TraceID: "session-1", DurationMs: 90000, SpanCount: 4}}, journeys)
+
+	_, err = m.GetSlowestJourneys(context.Background(), source, steps, timeRange, 1, 3, false)
+	assert.Error(t, err)
+}
+
+func TestValidateJourneysForLogFunnel(t *testing.T) {
+	m, mock := newBreakdownTestModule(t)
+
+	source := traceFunnels.FunnelSource{
+		Signal:         telemetrytypes.SignalLogs,
+		CorrelationKey: &telemetrytypes.TelemetryFieldKey{Name: "session.id"},
+	}
+	steps := []*traceFunnels.FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "event.name = 'cart_viewed'"}},
+		{Order: 2, Filter: &qbtypes.Filter{Expression: "event.name = 'order_placed'"}},
+	}
+	timeRange := traceFunnels.TimeRange{StartTime: 1700000000000000000, EndTime: 1700003600000000000}
+
+	mock.ExpectQuery(`(?s)correlation_id AS trace_id.*ORDER BY t1_time`).
+		WithArgs(true, "cart_viewed", true, "order_placed", true).
+		WillReturnRows(cmock.NewRows([]cmock.ColumnType{{Name: "trace_id", Type: "String"}}, [][]any{{"session-1"}, {"session-2"}}))
+
+	journeys, err := m.ValidateJourneys(context.Background(), source, steps, timeRange)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []*traceFunnels.GettableFunnelJourney{{TraceID: "session-1"}, {TraceID: "session-2"}}, journeys)
+}
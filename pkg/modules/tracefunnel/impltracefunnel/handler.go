@@ -73,6 +73,19 @@ func (handler *handler) UpdateSteps(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	source := funnel.FunnelSource
+	if !req.Signal.IsZero() || req.CorrelationKey != nil {
+		source = req.FunnelSource
+	}
+	if err := source.Validate(); err != nil {
+		render.Error(rw, err)
+		return
+	}
+	if err := tf.ValidateFunnelStepsForSource(source, req.Steps); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
 	steps, err := tf.ProcessFunnelSteps(req.Steps)
 	if err != nil {
 		render.Error(rw, err)
@@ -80,6 +93,7 @@ func (handler *handler) UpdateSteps(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	funnel.Steps = steps
+	funnel.FunnelSource = source
 	funnel.UpdatedAt = updatedAt
 	funnel.UpdatedBy = claims.UserID
 
@@ -233,3 +247,52 @@ func (handler *handler) Delete(rw http.ResponseWriter, r *http.Request) {
 
 	render.Success(rw, http.StatusOK, nil)
 }
+
+func (handler *handler) Breakdown(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	funnelID := vars["funnel_id"]
+
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	funnel, err := handler.module.Get(r.Context(), valuer.MustNewUUID(funnelID), valuer.MustNewUUID(claims.OrgID))
+	if err != nil {
+		render.Error(rw, errors.Newf(errors.TypeNotFound,
+			errors.CodeNotFound,
+			"funnel not found: %v", err))
+		return
+	}
+
+	var req tf.FunnelBreakdownRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "failed to decode request body"))
+		return
+	}
+
+	breakdown, err := handler.module.GetBreakdown(r.Context(), funnel.FunnelSource, funnel.Steps, &req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, breakdown)
+}
+
+func (handler *handler) BreakdownWithPayload(rw http.ResponseWriter, r *http.Request) {
+	var req tf.PostableFunnelBreakdown
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "failed to decode request body"))
+		return
+	}
+
+	breakdown, err := handler.module.GetBreakdown(r.Context(), req.FunnelSource, req.Steps, &req.FunnelBreakdownRequest)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, breakdown)
+}
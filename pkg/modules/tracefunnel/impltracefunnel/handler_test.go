@@ -55,6 +55,26 @@ func (m *MockModule) GetFunnelMetadata(ctx context.Context, funnelID valuer.UUID
 	return args.Get(0).(int64), args.Get(1).(int64), args.String(2), args.Error(3)
 }
 
+func (m *MockModule) GetBreakdown(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, req *traceFunnels.FunnelBreakdownRequest) (*traceFunnels.GettableFunnelBreakdown, error) {
+	args := m.Called(ctx, source, steps, req)
+	return args.Get(0).(*traceFunnels.GettableFunnelBreakdown), args.Error(1)
+}
+
+func (m *MockModule) GetTransition(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64) (*traceFunnels.GettableFunnelTransition, error) {
+	args := m.Called(ctx, source, steps, timeRange, stepStart, stepEnd)
+	return args.Get(0).(*traceFunnels.GettableFunnelTransition), args.Error(1)
+}
+
+func (m *MockModule) GetSlowestJourneys(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64, erroredOnly bool) ([]*traceFunnels.GettableFunnelJourney, error) {
+	args := m.Called(ctx, source, steps, timeRange, stepStart, stepEnd, erroredOnly)
+	return args.Get(0).([]*traceFunnels.GettableFunnelJourney), args.Error(1)
+}
+
+func (m *MockModule) ValidateJourneys(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange) ([]*traceFunnels.GettableFunnelJourney, error) {
+	args := m.Called(ctx, source, steps, timeRange)
+	return args.Get(0).([]*traceFunnels.GettableFunnelJourney), args.Error(1)
+}
+
 func TestHandler_List(t *testing.T) {
 	mockModule := new(MockModule)
 	handler := NewHandler(mockModule)
@@ -2,22 +2,46 @@ package impltracefunnel
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/flagger"
 	"github.com/SigNoz/signoz/pkg/modules/tracefunnel"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrytraces"
 	"github.com/SigNoz/signoz/pkg/types"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	traceFunnels "github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type module struct {
-	store traceFunnels.FunnelStore
+	store                  traceFunnels.FunnelStore
+	telemetryStore         telemetrystore.TelemetryStore
+	telemetryMetadataStore telemetrytypes.MetadataStore
+	traceFieldMapper       qbtypes.FieldMapper
+	traceCondBuilder       qbtypes.ConditionBuilder
+	logFieldMapper         qbtypes.FieldMapper
+	logCondBuilder         qbtypes.ConditionBuilder
+	logger                 *slog.Logger
 }
 
-func NewModule(store traceFunnels.FunnelStore) tracefunnel.Module {
+func NewModule(store traceFunnels.FunnelStore, telemetryStore telemetrystore.TelemetryStore, telemetryMetadataStore telemetrytypes.MetadataStore, flagger flagger.Flagger, providerSettings factory.ProviderSettings) tracefunnel.Module {
+	traceFieldMapper := telemetrytraces.NewFieldMapper()
+	logFieldMapper := telemetrylogs.NewFieldMapper(flagger)
 	return &module{
-		store: store,
+		store:                  store,
+		telemetryStore:         telemetryStore,
+		telemetryMetadataStore: telemetryMetadataStore,
+		traceFieldMapper:       traceFieldMapper,
+		traceCondBuilder:       telemetrytraces.NewConditionBuilder(traceFieldMapper),
+		logFieldMapper:         logFieldMapper,
+		logCondBuilder:         telemetrylogs.NewConditionBuilder(logFieldMapper, flagger),
+		logger:                 providerSettings.Logger,
 	}
 }
 
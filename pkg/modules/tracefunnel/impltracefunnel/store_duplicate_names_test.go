@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
 	traceFunnels "github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +17,7 @@ import (
 // Test that Create method properly validates duplicate names.
 func TestModule_Create_DuplicateNameValidation(t *testing.T) {
 	mockStore := new(MockStore)
-	module := NewModule(mockStore)
+	module := NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	timestamp := int64(1234567890)
@@ -40,7 +42,7 @@ func TestModule_Create_DuplicateNameValidation(t *testing.T) {
 // Test that Update method properly validates duplicate names.
 func TestModule_Update_DuplicateNameValidation(t *testing.T) {
 	mockStore := new(MockStore)
-	module := NewModule(mockStore)
+	module := NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	userID := valuer.GenerateUUID()
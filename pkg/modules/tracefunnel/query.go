@@ -21,8 +21,45 @@ func sanitizeClause(clause string) string {
 	return "AND " + clause
 }
 
+// requireLegacyFunnel rejects funnels that the fixed-shape analytics queries
+// cannot evaluate; those funnels are served by the breakdown analytics.
+func requireLegacyFunnel(funnel *tracefunneltypes.StorableFunnel) error {
+	if !funnel.IsTraceCorrelated() {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "funnels over logs or correlated by a custom key are only supported by the breakdown analytics")
+	}
+
+	for _, step := range funnel.Steps {
+		if !step.IsLegacy() {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d uses filter expressions, conversion windows or optional/unordered steps; use the breakdown analytics instead", step.Order)
+		}
+	}
+
+	return nil
+}
+
+// IsLegacyFunnel reports whether the fixed-shape analytics queries can
+// evaluate the funnel.
+func IsLegacyFunnel(funnel *tracefunneltypes.StorableFunnel) bool {
+	return requireLegacyFunnel(funnel) == nil
+}
+
+// LegacyStepCondition compiles the service, span and v3 filter conditions of
+// a step into a condition for BuildFunnelBreakdownQuery.
+func LegacyStepCondition(step *tracefunneltypes.FunnelStep) (string, []any, error) {
+	clause, err := tracev4.BuildTracesFilterQuery(step.Filters, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	condition := strings.TrimSpace("resource_string_service$$name = ? AND name = ? " + sanitizeClause(clause))
+	return condition, []any{step.ServiceName, step.SpanName}, nil
+}
+
 func ValidateTraces(funnel *tracefunneltypes.StorableFunnel, timeRange tracefunneltypes.TimeRange) (*v3.ClickHouseQuery, error) {
 	funnelSteps := funnel.Steps
+	if err := requireLegacyFunnel(funnel); err != nil {
+		return nil, err
+	}
 
 	// Build step data for the dynamic query builder
 	steps := make([]struct {
@@ -65,6 +102,9 @@ func ValidateTraces(funnel *tracefunneltypes.StorableFunnel, timeRange tracefunn
 
 func GetFunnelAnalytics(funnel *tracefunneltypes.StorableFunnel, timeRange tracefunneltypes.TimeRange) (*v3.ClickHouseQuery, error) {
 	funnelSteps := funnel.Steps
+	if err := requireLegacyFunnel(funnel); err != nil {
+		return nil, err
+	}
 
 	// Build step data for the dynamic query builder
 	steps := make([]struct {
@@ -117,6 +157,9 @@ func GetFunnelStepAnalytics(funnel *tracefunneltypes.StorableFunnel, timeRange t
 	}
 
 	funnelSteps := funnel.Steps
+	if err := requireLegacyFunnel(funnel); err != nil {
+		return nil, err
+	}
 
 	// Build step data for the dynamic query builder
 	steps := make([]struct {
@@ -168,6 +211,9 @@ func GetFunnelStepAnalytics(funnel *tracefunneltypes.StorableFunnel, timeRange t
 
 func GetStepAnalytics(funnel *tracefunneltypes.StorableFunnel, timeRange tracefunneltypes.TimeRange) (*v3.ClickHouseQuery, error) {
 	funnelSteps := funnel.Steps
+	if err := requireLegacyFunnel(funnel); err != nil {
+		return nil, err
+	}
 
 	// Build step data for the dynamic query builder
 	steps := make([]struct {
@@ -210,6 +256,9 @@ func GetStepAnalytics(funnel *tracefunneltypes.StorableFunnel, timeRange tracefu
 
 func GetSlowestTraces(funnel *tracefunneltypes.StorableFunnel, timeRange tracefunneltypes.TimeRange, stepStart, stepEnd int64) (*v3.ClickHouseQuery, error) {
 	funnelSteps := funnel.Steps
+	if err := requireLegacyFunnel(funnel); err != nil {
+		return nil, err
+	}
 	containsErrorT1 := 0
 	containsErrorT2 := 0
 	stepStartOrder := 0
@@ -270,6 +319,9 @@ func GetSlowestTraces(funnel *tracefunneltypes.StorableFunnel, timeRange tracefu
 // TODO: Showing traces with error which are slow makes little sense as a product. We should show the error spans directly in the funnel chart. Rather showing traces which has drop between steps will be more relevant.
 func GetErroredTraces(funnel *tracefunneltypes.StorableFunnel, timeRange tracefunneltypes.TimeRange, stepStart, stepEnd int64) (*v3.ClickHouseQuery, error) {
 	funnelSteps := funnel.Steps
+	if err := requireLegacyFunnel(funnel); err != nil {
+		return nil, err
+	}
 	containsErrorT1 := 0
 	containsErrorT2 := 0
 	stepStartOrder := 0
@@ -22,6 +22,18 @@ type Module interface {
 	Delete(ctx context.Context, funnelID valuer.UUID, orgID valuer.UUID) error
 
 	GetFunnelMetadata(ctx context.Context, funnelID valuer.UUID, orgID valuer.UUID) (int64, int64, string, error)
+
+	// GetBreakdown returns per-step conversion and latency for the steps, optionally broken down by group by keys.
+	GetBreakdown(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, req *traceFunnels.FunnelBreakdownRequest) (*traceFunnels.GettableFunnelBreakdown, error)
+
+	// GetTransition returns the conversion from step stepStart to step stepEnd for funnels the legacy analytics cannot evaluate.
+	GetTransition(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64) (*traceFunnels.GettableFunnelTransition, error)
+
+	// GetSlowestJourneys returns the slowest journeys from step stepStart to step stepEnd, only those which errored in either step with erroredOnly, for funnels the legacy analytics cannot evaluate.
+	GetSlowestJourneys(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64, erroredOnly bool) ([]*traceFunnels.GettableFunnelJourney, error)
+
+	// ValidateJourneys returns the first journeys entering the funnel for funnels the legacy analytics cannot evaluate.
+	ValidateJourneys(ctx context.Context, source traceFunnels.FunnelSource, steps []*traceFunnels.FunnelStep, timeRange traceFunnels.TimeRange) ([]*traceFunnels.GettableFunnelJourney, error)
 }
 
 type Handler interface {
@@ -36,4 +48,8 @@ type Handler interface {
 	Get(http.ResponseWriter, *http.Request)
 
 	Delete(http.ResponseWriter, *http.Request)
+
+	Breakdown(http.ResponseWriter, *http.Request)
+
+	BreakdownWithPayload(http.ResponseWriter, *http.Request)
 }
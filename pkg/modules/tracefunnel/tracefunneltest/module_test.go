@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
 	"github.com/SigNoz/signoz/pkg/modules/tracefunnel/impltracefunnel"
 	"github.com/SigNoz/signoz/pkg/types"
 	traceFunnels "github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
@@ -44,7 +46,7 @@ func (m *MockStore) Delete(ctx context.Context, uuid valuer.UUID, orgID valuer.U
 
 func TestModule_Create(t *testing.T) {
 	mockStore := new(MockStore)
-	module := impltracefunnel.NewModule(mockStore)
+	module := impltracefunnel.NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	timestamp := time.Now().UnixMilli()
@@ -75,7 +77,7 @@ func TestModule_Create(t *testing.T) {
 
 func TestModule_Get(t *testing.T) {
 	mockStore := new(MockStore)
-	module := impltracefunnel.NewModule(mockStore)
+	module := impltracefunnel.NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	funnelID := valuer.GenerateUUID()
@@ -95,7 +97,7 @@ func TestModule_Get(t *testing.T) {
 
 func TestModule_Update(t *testing.T) {
 	mockStore := new(MockStore)
-	module := impltracefunnel.NewModule(mockStore)
+	module := impltracefunnel.NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	userID := valuer.GenerateUUID()
@@ -114,7 +116,7 @@ func TestModule_Update(t *testing.T) {
 
 func TestModule_List(t *testing.T) {
 	mockStore := new(MockStore)
-	module := impltracefunnel.NewModule(mockStore)
+	module := impltracefunnel.NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	orgID := valuer.GenerateUUID()
@@ -141,7 +143,7 @@ func TestModule_List(t *testing.T) {
 
 func TestModule_Delete(t *testing.T) {
 	mockStore := new(MockStore)
-	module := impltracefunnel.NewModule(mockStore)
+	module := impltracefunnel.NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	funnelID := valuer.GenerateUUID()
@@ -157,7 +159,7 @@ func TestModule_Delete(t *testing.T) {
 
 func TestModule_GetFunnelMetadata(t *testing.T) {
 	mockStore := new(MockStore)
-	module := impltracefunnel.NewModule(mockStore)
+	module := impltracefunnel.NewModule(mockStore, nil, nil, flaggertest.New(t), instrumentationtest.New().ToProviderSettings())
 
 	ctx := context.Background()
 	funnelID := valuer.GenerateUUID()
@@ -235,6 +235,40 @@ func (handler *handler) ReplaceVariables(rw http.ResponseWriter, req *http.Reque
 	render.Success(rw, http.StatusOK, queryRangeRequest)
 }
 
+func (handler *handler) PurgeCache(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var purge qbtypes.PostableCachePurge
+	if err := json.NewDecoder(req.Body).Decode(&purge); err != nil {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "failed to decode request body: %v", err))
+		return
+	}
+
+	if err := purge.Validate(); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := handler.querier.PurgeCache(ctx, orgID, &purge); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
 func (handler *handler) logEvent(ctx context.Context, referrer string, event *qbtypes.QBEvent) {
 	claims, err := authtypes.ClaimsFromContext(ctx)
 	if err != nil {
@@ -6,34 +6,66 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
+// invalidationSlots is the number of slots the cache invalidations of an org
+// are spread over within the cache TTL. Each invalidation is added under its
+// own key, v5:invalidations:<slot start>:<index>, so that instances sharing the
+// cache never overwrite each other's invalidations.
+const invalidationSlots = 24
+
 // bucketCache implements the BucketCache interface.
 type bucketCache struct {
 	cache        cache.Cache
 	logger       *slog.Logger
 	cacheTTL     time.Duration
 	fluxInterval time.Duration
+	// lateData is nil when the telemetry store is not probed for late data.
+	lateData *lateDataTracker
+
+	// mu guards the org invalidations, which are read from the cache at most
+	// once per flux interval.
+	mu            sync.Mutex
+	invalidations map[valuer.UUID]*orgInvalidations
+}
+
+// orgInvalidations are the cache invalidations of an org as last read, along
+// with the key of the next invalidation to read.
+type orgInvalidations struct {
+	invalidations []*qbtypes.CachedInvalidation
+	readAtMs      uint64
+	nextSlotMs    uint64
+	nextIndex     int
 }
 
 var _ BucketCache = (*bucketCache)(nil)
 
-// NewBucketCache creates a new BucketCache implementation.
-func NewBucketCache(settings factory.ProviderSettings, cache cache.Cache, cacheTTL time.Duration, fluxInterval time.Duration) BucketCache {
+// NewBucketCache creates a new BucketCache implementation. Cached buckets which
+// receive late data are fetched again if a late data probe is given.
+func NewBucketCache(settings factory.ProviderSettings, cache cache.Cache, cacheTTL time.Duration, fluxInterval time.Duration, lateDataProbe LateDataProbe, lateDataProbeInterval time.Duration) BucketCache {
 	cacheSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier/bucket_cache")
-	return &bucketCache{
-		cache:        cache,
-		logger:       cacheSettings.Logger(),
-		cacheTTL:     cacheTTL,
-		fluxInterval: fluxInterval,
+	bc := &bucketCache{
+		cache:         cache,
+		logger:        cacheSettings.Logger(),
+		cacheTTL:      cacheTTL,
+		fluxInterval:  fluxInterval,
+		invalidations: map[valuer.UUID]*orgInvalidations{},
 	}
+
+	if lateDataProbe != nil {
+		bc.lateData = newLateDataTracker(lateDataProbe, bc.logger, lateDataProbeInterval, cacheTTL)
+	}
+
+	return bc
 }
 
 // GetMissRanges returns cached data and missing time ranges.
@@ -66,6 +98,9 @@ func (bc *bucketCache) GetMissRanges(
 		return nil, missing
 	}
 
+	// Drop buckets which received late data so that they are fetched again
+	data.Buckets = bc.dropStaleBuckets(ctx, orgID, q, data.Buckets)
+
 	// Extract step interval if this is a builder query
 	stepMs := uint64(step.Milliseconds())
 
@@ -128,6 +163,7 @@ func (bc *bucketCache) Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Que
 	if err := bc.cache.Get(ctx, orgID, cacheKey, &existingData); err != nil {
 		existingData = qbtypes.CachedData{}
 	}
+	existingData.Buckets = bc.dropStaleBuckets(ctx, orgID, q, existingData.Buckets)
 
 	// Trim the result to exclude data within flux interval
 	trimmedResult := bc.trimResultToFluxBoundary(fresh, cachableEndMs)
@@ -166,6 +202,12 @@ func (bc *bucketCache) Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Que
 
 	// Convert trimmed result to buckets with adjusted boundaries
 	freshBuckets := bc.resultToBuckets(ctx, trimmedResult, cachableStartMs, cachableEndMs)
+	for _, bucket := range freshBuckets {
+		bucket.CachedAtMs = currentMs
+	}
+	if bc.lateData != nil && len(freshBuckets) > 0 {
+		bc.lateData.Observe(q, cachableStartMs, currentMs)
+	}
 
 	// If no fresh buckets and no existing data, don't cache
 	if len(freshBuckets) == 0 && len(existingData.Buckets) == 0 {
@@ -191,6 +233,155 @@ func (bc *bucketCache) Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Que
 	}
 }
 
+// Invalidate marks the cached buckets of the org which overlap the time range of
+// the signal as stale. The invalidation is kept in the cache so that every
+// instance sharing it drops the buckets.
+func (bc *bucketCache) Invalidate(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal, tr qbtypes.TimeRange) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	nowMs := uint64(time.Now().UnixMilli())
+	invalidation := &qbtypes.CachedInvalidation{
+		Signal:          signal,
+		StartMs:         tr.From,
+		EndMs:           tr.To,
+		InvalidatedAtMs: nowMs,
+	}
+
+	// The invalidations of the slot are added from the first unread index, an
+	// index taken by another instance is read along with the others below.
+	slotMs := bc.invalidationSlotStartMs(nowMs)
+	index := 0
+	if org, ok := bc.invalidations[orgID]; ok && org.nextSlotMs == slotMs {
+		index = org.nextIndex
+	}
+
+	for ; ; index++ {
+		err := bc.cache.Add(ctx, orgID, invalidationCacheKey(slotMs, index), invalidation, bc.invalidationTTL(slotMs, nowMs))
+		if err == nil {
+			break
+		}
+
+		if !errors.Ast(err, errors.TypeAlreadyExists) {
+			return err
+		}
+	}
+
+	bc.readInvalidations(ctx, orgID, nowMs)
+	return nil
+}
+
+// cachedInvalidations returns the cache invalidations of the org. They are read
+// from the cache again once the flux interval has passed, so the invalidations
+// of other instances are applied within the flux interval.
+func (bc *bucketCache) cachedInvalidations(ctx context.Context, orgID valuer.UUID) []*qbtypes.CachedInvalidation {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	nowMs := uint64(time.Now().UnixMilli())
+	if org, ok := bc.invalidations[orgID]; ok && nowMs < org.readAtMs+uint64(bc.fluxInterval.Milliseconds()) {
+		return org.invalidations
+	}
+
+	return bc.readInvalidations(ctx, orgID, nowMs).invalidations
+}
+
+// readInvalidations reads the invalidations of the org added since the last
+// read, from the slots within the cache TTL on the first read. The keys of a
+// slot expire together, so the invalidations of a slot are read up to the first
+// missing index. It must be called with mu held.
+func (bc *bucketCache) readInvalidations(ctx context.Context, orgID valuer.UUID, nowMs uint64) *orgInvalidations {
+	org, ok := bc.invalidations[orgID]
+	if !ok {
+		org = &orgInvalidations{nextSlotMs: bc.invalidationSlotStartMs(max(nowMs, uint64(bc.cacheTTL.Milliseconds())) - uint64(bc.cacheTTL.Milliseconds()))}
+	}
+
+	// Copied rather than appended in place, callers may hold the previous slice.
+	invalidations := pruneInvalidations(org.invalidations, nowMs, bc.cacheTTL)
+	slotMs, index := org.nextSlotMs, org.nextIndex
+	lastSlotMs := bc.invalidationSlotStartMs(nowMs)
+	for {
+		invalidation := new(qbtypes.CachedInvalidation)
+		err := bc.cache.Get(ctx, orgID, invalidationCacheKey(slotMs, index), invalidation)
+		if err == nil {
+			invalidations = append(invalidations, invalidation)
+			index++
+			continue
+		}
+
+		if !errors.Ast(err, errors.TypeNotFound) {
+			// The remaining invalidations are read next time.
+			bc.logger.ErrorContext(ctx, "error getting cache invalidation", errors.Attr(err))
+			break
+		}
+
+		if slotMs >= lastSlotMs {
+			break
+		}
+		slotMs, index = slotMs+bc.invalidationSlotMs(), 0
+	}
+
+	org = &orgInvalidations{invalidations: invalidations, readAtMs: nowMs, nextSlotMs: slotMs, nextIndex: index}
+	bc.invalidations[orgID] = org
+	return org
+}
+
+// invalidationSlotMs is the length of a slot of cache invalidations.
+func (bc *bucketCache) invalidationSlotMs() uint64 {
+	return max(uint64(bc.cacheTTL.Milliseconds())/invalidationSlots, 1)
+}
+
+// invalidationSlotStartMs returns the start of the slot of the invalidations
+// added at ms.
+func (bc *bucketCache) invalidationSlotStartMs(ms uint64) uint64 {
+	return ms - ms%bc.invalidationSlotMs()
+}
+
+// invalidationTTL returns the TTL of an invalidation added to the slot at
+// nowMs. Every key of the slot expires once the last bucket an invalidation of
+// the slot could apply to has expired.
+func (bc *bucketCache) invalidationTTL(slotMs uint64, nowMs uint64) time.Duration {
+	return time.Duration(slotMs+bc.invalidationSlotMs()-nowMs)*time.Millisecond + bc.cacheTTL
+}
+
+// invalidationCacheKey is the key of the index-th invalidation of the slot.
+func invalidationCacheKey(slotMs uint64, index int) string {
+	return fmt.Sprintf("v5:invalidations:%d:%d", slotMs, index)
+}
+
+// dropStaleBuckets removes the buckets which were cached before late data
+// arrived for their time range or before their org purged it.
+func (bc *bucketCache) dropStaleBuckets(ctx context.Context, orgID valuer.UUID, q qbtypes.Query, buckets []*qbtypes.CachedBucket) []*qbtypes.CachedBucket {
+	if len(buckets) == 0 {
+		return buckets
+	}
+
+	var invalidations []*qbtypes.CachedInvalidation
+	if bc.lateData != nil {
+		invalidations = append(invalidations, bc.lateData.Invalidations(ctx)...)
+	}
+	invalidations = append(invalidations, bc.cachedInvalidations(ctx, orgID)...)
+
+	if len(invalidations) == 0 {
+		return buckets
+	}
+
+	signals := querySignals(q)
+	fresh := make([]*qbtypes.CachedBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		stale := slices.ContainsFunc(invalidations, func(invalidation *qbtypes.CachedInvalidation) bool {
+			return invalidation.Invalidates(signals, bucket)
+		})
+		if stale {
+			bc.logger.DebugContext(ctx, "dropping stale bucket", slog.Uint64("start", bucket.StartMs), slog.Uint64("end", bucket.EndMs), slog.Uint64("cached_at", bucket.CachedAtMs))
+			continue
+		}
+		fresh = append(fresh, bucket)
+	}
+
+	return fresh
+}
+
 // generateCacheKey creates a unique cache key based on query fingerprint.
 func (bc *bucketCache) generateCacheKey(q qbtypes.Query) string {
 	fingerprint := q.Fingerprint()
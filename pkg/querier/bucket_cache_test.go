@@ -70,7 +70,7 @@ func (m *mockQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
 // createTestBucketCache creates a test bucket cache.
 func createTestBucketCache(t *testing.T) *bucketCache {
 	memCache := createTestCache(t)
-	return NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval).(*bucketCache)
+	return NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0).(*bucketCache)
 }
 
 func createTestTimeSeries(queryName string, startMs, endMs uint64, step uint64) *qbtypes.TimeSeriesData {
@@ -115,7 +115,7 @@ func createTestTimeSeries(queryName string, startMs, endMs uint64, step uint64)
 
 func TestBucketCache_GetMissRanges_EmptyCache(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	query := &mockQuery{
 		fingerprint: "test-query",
@@ -133,7 +133,7 @@ func TestBucketCache_GetMissRanges_EmptyCache(t *testing.T) {
 
 func TestBucketCache_Put_And_Get(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// Create a query and result
 	query := &mockQuery{
@@ -175,7 +175,7 @@ func TestBucketCache_Put_And_Get(t *testing.T) {
 
 func TestBucketCache_PartialHit(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// First query: cache data for 1000-3000ms
 	query1 := &mockQuery{
@@ -212,7 +212,7 @@ func TestBucketCache_PartialHit(t *testing.T) {
 
 func TestBucketCache_MultipleBuckets(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// Cache multiple non-contiguous ranges
 	query1 := &mockQuery{
@@ -262,7 +262,7 @@ func TestBucketCache_MultipleBuckets(t *testing.T) {
 
 func TestBucketCache_FluxInterval(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// Try to cache data too close to current time
 	currentMs := uint64(time.Now().UnixMilli())
@@ -293,7 +293,7 @@ func TestBucketCache_FluxInterval(t *testing.T) {
 
 func TestBucketCache_MergeTimeSeriesResults(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// Create time series with same labels but different time ranges
 	series1 := &qbtypes.TimeSeries{
@@ -405,7 +405,7 @@ func TestBucketCache_MergeTimeSeriesResults(t *testing.T) {
 
 func TestBucketCache_RawData(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// Test with raw data type
 	query := &mockQuery{
@@ -453,7 +453,7 @@ func TestBucketCache_RawData(t *testing.T) {
 
 func TestBucketCache_ScalarData(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	query := &mockQuery{
 		fingerprint: "test-query",
@@ -493,7 +493,7 @@ func TestBucketCache_ScalarData(t *testing.T) {
 
 func TestBucketCache_EmptyFingerprint(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// Query with empty fingerprint should generate a fallback key
 	query := &mockQuery{
@@ -518,7 +518,7 @@ func TestBucketCache_EmptyFingerprint(t *testing.T) {
 
 func TestBucketCache_FindMissingRanges_EdgeCases(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval).(*bucketCache)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0).(*bucketCache)
 
 	// Test with buckets that have gaps and overlaps
 	buckets := []*qbtypes.CachedBucket{
@@ -545,7 +545,7 @@ func TestBucketCache_FindMissingRanges_EdgeCases(t *testing.T) {
 
 func TestBucketCache_ConcurrentAccess(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, nil, 0)
 
 	// Test concurrent puts and gets
 	done := make(chan bool)
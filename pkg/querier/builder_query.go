@@ -64,6 +64,12 @@ func (q *builderQuery[T]) Fingerprint() string {
 		return ""
 	}
 
+	// Sub queries are evaluated over the whole request window, so the cached
+	// buckets would be filtered by the values of a window that moves along.
+	if len(q.spec.SubQueryFilters) > 0 {
+		return ""
+	}
+
 	// Create a deterministic fingerprint for builder queries
 	// This needs to include all fields that affect the query results
 	parts := []string{"builder"}
@@ -149,6 +155,15 @@ func (q *builderQuery[T]) Fingerprint() string {
 		parts = append(parts, fmt.Sprintf("shiftby=%d", q.spec.ShiftBy))
 	}
 
+	// Add lookups along with the version of the joined table
+	if len(q.spec.Lookups) > 0 {
+		lookupParts := []string{}
+		for _, l := range q.spec.Lookups {
+			lookupParts = append(lookupParts, fmt.Sprintf("%s:%s:%s", l.Table, fingerprintFieldKey(l.Key), l.VersionID.StringValue()))
+		}
+		parts = append(parts, fmt.Sprintf("lookups=[%s]", strings.Join(lookupParts, ",")))
+	}
+
 	return strings.Join(parts, "&")
 }
 
@@ -173,6 +188,21 @@ func (q *builderQuery[T]) Window() (uint64, uint64) {
 	return q.fromMS, q.toMS
 }
 
+func (q *builderQuery[T]) signals() []telemetrytypes.Signal {
+	return []telemetrytypes.Signal{q.spec.Signal}
+}
+
+func (q *builderQuery[T]) metricNames() []string {
+	var names []string
+	for _, agg := range q.spec.Aggregations {
+		if a, ok := any(agg).(qbtypes.MetricAggregation); ok && a.MetricName != "" {
+			names = append(names, a.MetricName)
+		}
+	}
+
+	return names
+}
+
 // must be a single query, ordered by timestamp (logs need an id tie-break).
 func (q *builderQuery[T]) isWindowList() bool {
 	if len(q.spec.Order) == 0 {
@@ -330,6 +360,8 @@ func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string,
 		elapsed += p.Elapsed
 	}))
 
+	ctx = withMaxQuerySize(ctx, query, args)
+
 	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
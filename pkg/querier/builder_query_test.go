@@ -7,6 +7,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/querybuilder"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,6 +95,50 @@ func TestBuilderQueryFingerprint(t *testing.T) {
 	}
 }
 
+// The sub query is evaluated over the whole request window, so the buckets of
+// the referencing query can't be cached.
+func TestBuilderQueryFingerprintSubQueryFilters(t *testing.T) {
+	query := &builderQuery[qbtypes.LogAggregation]{
+		kind: qbtypes.RequestTypeTimeSeries,
+		spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+			Signal: telemetrytypes.SignalLogs,
+			SubQueryFilters: []qbtypes.SubQueryFilter{
+				{
+					Key:   telemetrytypes.TelemetryFieldKey{Name: "service.name"},
+					Query: "B",
+				},
+			},
+		},
+	}
+
+	assert.Empty(t, query.Fingerprint())
+}
+
+func TestBuilderQueryFingerprintLookups(t *testing.T) {
+	newQuery := func(versionID valuer.UUID) *builderQuery[qbtypes.LogAggregation] {
+		return &builderQuery[qbtypes.LogAggregation]{
+			kind: qbtypes.RequestTypeTimeSeries,
+			spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal: telemetrytypes.SignalLogs,
+				Lookups: []qbtypes.LookupJoin{
+					{
+						Table:     "tenants",
+						Key:       telemetrytypes.TelemetryFieldKey{Name: "customer_id"},
+						VersionID: versionID,
+					},
+				},
+			},
+		}
+	}
+
+	versionID := valuer.GenerateUUID()
+	fingerprint := newQuery(versionID).Fingerprint()
+	assert.Contains(t, fingerprint, "lookups=[tenants:customer_id---:"+versionID.StringValue()+"]")
+
+	// a new version of the table is not served from the cache
+	assert.NotEqual(t, fingerprint, newQuery(valuer.GenerateUUID()).Fingerprint())
+}
+
 func TestMakeBucketsOrder(t *testing.T) {
 	// Test that makeBuckets returns buckets in reverse chronological order by default
 	// Using milliseconds as input - need > 1 hour range to get multiple buckets
@@ -13,6 +13,19 @@ type SkipResourceFingerprint struct {
 	Threshold uint64 `yaml:"threshold" mapstructure:"threshold"`
 }
 
+// LateData configures how the bucket cache finds data which was ingested after
+// the flux interval of its time range had passed.
+type LateData struct {
+	// Enabled turns on probing the telemetry store for late data.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// ProbeInterval is the minimum interval between two probes.
+	ProbeInterval time.Duration `yaml:"probe_interval" mapstructure:"probe_interval"`
+	// Lookback bounds how far back in event time late data is looked for, on
+	// top of the start of the cached buckets. Every probe scans the lookback of
+	// the logs and samples ingested since the previous one.
+	Lookback time.Duration `yaml:"lookback" mapstructure:"lookback"`
+}
+
 // Config represents the configuration for the querier.
 type Config struct {
 	// CacheTTL is the TTL for cached query results
@@ -23,6 +36,8 @@ type Config struct {
 	MaxConcurrentQueries int `yaml:"max_concurrent_queries" mapstructure:"max_concurrent_queries"`
 	// SkipResourceFingerprint configures when the resource fingerprint subquery is skipped in favor of main-table filtering.
 	SkipResourceFingerprint SkipResourceFingerprint `yaml:"skip_resource_fingerprint" mapstructure:"skip_resource_fingerprint"`
+	// LateData configures the invalidation of cached results which received late data.
+	LateData LateData `yaml:"late_data" mapstructure:"late_data"`
 }
 
 // NewConfigFactory creates a new config factory for querier.
@@ -40,6 +55,11 @@ func newConfig() factory.Config {
 			Enabled:   false,
 			Threshold: 100000,
 		},
+		LateData: LateData{
+			Enabled:       false,
+			ProbeInterval: time.Minute,
+			Lookback:      3 * time.Hour,
+		},
 	}
 }
 
@@ -57,6 +77,12 @@ func (c Config) Validate() error {
 	if c.SkipResourceFingerprint.Enabled && c.SkipResourceFingerprint.Threshold == 0 {
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "skip_resource_fingerprint.threshold must be > 0 when enabled")
 	}
+	if c.LateData.Enabled && c.LateData.ProbeInterval <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "late_data.probe_interval must be positive when enabled, got %v", c.LateData.ProbeInterval)
+	}
+	if c.LateData.Enabled && c.LateData.Lookback <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "late_data.lookback must be positive when enabled, got %v", c.LateData.Lookback)
+	}
 	return nil
 }
 
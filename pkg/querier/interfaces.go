@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
@@ -12,6 +13,7 @@ import (
 type Querier interface {
 	QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error)
 	QueryRawStream(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, client *qbtypes.RawStream)
+	PurgeCache(ctx context.Context, orgID valuer.UUID, req *qbtypes.PostableCachePurge) error
 }
 
 // BucketCache is the interface for bucket-based caching.
@@ -20,10 +22,13 @@ type BucketCache interface {
 	GetMissRanges(ctx context.Context, orgID valuer.UUID, q qbtypes.Query, step qbtypes.Step) (cached *qbtypes.Result, missing []*qbtypes.TimeRange)
 	// store fresh buckets for future hits
 	Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Query, step qbtypes.Step, fresh *qbtypes.Result)
+	// mark cached buckets of a signal overlapping the time range as stale
+	Invalidate(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal, tr qbtypes.TimeRange) error
 }
 
 type Handler interface {
 	QueryRange(rw http.ResponseWriter, req *http.Request)
 	QueryRawStream(rw http.ResponseWriter, req *http.Request)
 	ReplaceVariables(rw http.ResponseWriter, req *http.Request)
+	PurgeCache(rw http.ResponseWriter, req *http.Request)
 }
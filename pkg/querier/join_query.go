@@ -0,0 +1,195 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+type joinQuery struct {
+	telemetryStore telemetrystore.TelemetryStore
+	stmtBuilder    qbtypes.JoinStatementBuilder
+	spec           qbtypes.QueryBuilderJoin
+	compositeQuery *qbtypes.CompositeQuery
+	variables      map[string]qbtypes.VariableItem
+	step           qbtypes.Step
+	fromMS         uint64
+	toMS           uint64
+	kind           qbtypes.RequestType
+}
+
+var _ qbtypes.Query = (*joinQuery)(nil)
+
+func (q *joinQuery) Fingerprint() string {
+	if q.kind != qbtypes.RequestTypeTimeSeries {
+		// No caching for non-timeseries queries
+		return ""
+	}
+
+	// the result of a join depends on both sides along with the join spec
+	left := q.sideFingerprint(q.spec.Left.Name)
+	right := q.sideFingerprint(q.spec.Right.Name)
+	if left == "" || right == "" {
+		return ""
+	}
+
+	parts := []string{
+		"join",
+		fmt.Sprintf("left={%s}", left),
+		fmt.Sprintf("right={%s}", right),
+		fmt.Sprintf("type=%s", q.spec.Type.StringValue()),
+		fmt.Sprintf("on=%s", q.spec.On),
+		fmt.Sprintf("step=%s", q.step.String()),
+	}
+
+	if len(q.spec.Aggregations) > 0 {
+		aggParts := []string{}
+		for _, agg := range q.spec.Aggregations {
+			aggParts = append(aggParts, fmt.Sprintf("%v", agg))
+		}
+		parts = append(parts, fmt.Sprintf("aggs=[%s]", strings.Join(aggParts, ",")))
+	}
+
+	if len(q.spec.SelectFields) > 0 {
+		selectParts := []string{}
+		for _, field := range q.spec.SelectFields {
+			selectParts = append(selectParts, fingerprintFieldKey(field))
+		}
+		parts = append(parts, fmt.Sprintf("select=[%s]", strings.Join(selectParts, ",")))
+	}
+
+	if q.spec.Filter != nil && q.spec.Filter.Expression != "" {
+		parts = append(parts, fmt.Sprintf("filter=%s", q.spec.Filter.Expression))
+
+		for name, item := range q.variables {
+			if strings.Contains(q.spec.Filter.Expression, "$"+name) {
+				parts = append(parts, fmt.Sprintf("%s=%s", name, fmt.Sprint(item.Value)))
+			}
+		}
+	}
+
+	if len(q.spec.GroupBy) > 0 {
+		groupByParts := []string{}
+		for _, gb := range q.spec.GroupBy {
+			groupByParts = append(groupByParts, fingerprintGroupByKey(gb))
+		}
+		parts = append(parts, fmt.Sprintf("groupby=[%s]", strings.Join(groupByParts, ",")))
+	}
+
+	if len(q.spec.Order) > 0 {
+		orderParts := []string{}
+		for _, o := range q.spec.Order {
+			orderParts = append(orderParts, fingerprintOrderBy(o))
+		}
+		parts = append(parts, fmt.Sprintf("order=[%s]", strings.Join(orderParts, ",")))
+	}
+
+	if q.spec.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit=%d", q.spec.Limit))
+	}
+
+	if q.spec.Having != nil && q.spec.Having.Expression != "" {
+		parts = append(parts, fmt.Sprintf("having=%s", q.spec.Having.Expression))
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// sideFingerprint returns the fingerprint of the builder query referenced by a
+// side of the join, or an empty string if it is not cacheable.
+func (q *joinQuery) sideFingerprint(ref string) string {
+	if q.compositeQuery == nil {
+		return ""
+	}
+
+	timeRange := qbtypes.TimeRange{From: q.fromMS, To: q.toMS}
+	for _, envelope := range q.compositeQuery.Queries {
+		if envelope.Type != qbtypes.QueryTypeBuilder || envelope.GetQueryName() != ref {
+			continue
+		}
+
+		switch spec := envelope.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			return newBuilderQuery[qbtypes.TraceAggregation](nil, nil, nil, spec, timeRange, q.kind, q.variables).Fingerprint()
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			return newBuilderQuery[qbtypes.LogAggregation](nil, nil, nil, spec, timeRange, q.kind, q.variables).Fingerprint()
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			return newBuilderQuery[qbtypes.MetricAggregation](nil, nil, nil, spec, timeRange, q.kind, q.variables).Fingerprint()
+		}
+	}
+
+	return ""
+}
+
+func (q *joinQuery) Window() (uint64, uint64) {
+	return q.fromMS, q.toMS
+}
+
+func (q *joinQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
+	stmt, err := q.stmtBuilder.Build(
+		ctx,
+		q.fromMS,
+		q.toMS,
+		q.kind,
+		q.spec,
+		q.compositeQuery,
+		q.variables,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := q.executeWithContext(ctx, stmt.Query, stmt.Args)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = stmt.Warnings
+	result.WarningsDocURL = stmt.WarningsDocURL
+	return result, nil
+}
+
+func (q *joinQuery) executeWithContext(ctx context.Context, query string, args []any) (*qbtypes.Result, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.QueryDuration: instrumentationtypes.DurationBucket(q.fromMS, q.toMS),
+	})
+
+	totalRows := uint64(0)
+	totalBytes := uint64(0)
+	elapsed := time.Duration(0)
+
+	ctx = clickhouse.Context(ctx, clickhouse.WithProgress(func(p *clickhouse.Progress) {
+		totalRows += p.Rows
+		totalBytes += p.Bytes
+		elapsed += p.Elapsed
+	}))
+
+	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	queryWindow := &qbtypes.TimeRange{From: q.fromMS, To: q.toMS}
+
+	payload, err := consume(rows, q.kind, queryWindow, q.step, q.spec.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &qbtypes.Result{
+		Type:  q.kind,
+		Value: payload,
+		Stats: qbtypes.ExecStats{
+			RowsScanned:  totalRows,
+			BytesScanned: totalBytes,
+			DurationMS:   uint64(elapsed.Milliseconds()),
+		},
+	}, nil
+}
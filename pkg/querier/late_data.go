@@ -0,0 +1,328 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	"github.com/SigNoz/signoz/pkg/telemetrymetrics"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// lateDataGranularityMs is the granularity of the event time ranges reported
+// by the probe.
+const lateDataGranularityMs = uint64(time.Hour / time.Millisecond)
+
+// LateDataProbe finds data which was ingested after the flux interval of its
+// event time had passed, i.e. after the bucket cache may have cached it as final.
+type LateDataProbe interface {
+	// Signals returns the signals the probe can find late data for.
+	Signals() []telemetrytypes.Signal
+	// Probe returns the event time ranges from cachedFromMs on of the data of
+	// the signal which was ingested late within [ingestedFromMs, ingestedToMs).
+	// Metrics are probed for the given metric names only.
+	Probe(ctx context.Context, signal telemetrytypes.Signal, metricNames []string, cachedFromMs uint64, ingestedFromMs uint64, ingestedToMs uint64) ([]*qbtypes.TimeRange, error)
+}
+
+type telemetryStoreLateDataProbe struct {
+	telemetryStore telemetrystore.TelemetryStore
+	fluxInterval   time.Duration
+	lookback       time.Duration
+}
+
+// NewLateDataProbe returns a probe which uses the ingestion timestamps of the
+// telemetry store: inserted_at_unix_milli for metric samples and
+// observed_timestamp for logs. Spans have no ingestion timestamp, so traces are
+// not probed.
+func NewLateDataProbe(telemetryStore telemetrystore.TelemetryStore, fluxInterval time.Duration, lookback time.Duration) LateDataProbe {
+	return &telemetryStoreLateDataProbe{
+		telemetryStore: telemetryStore,
+		fluxInterval:   fluxInterval,
+		lookback:       lookback,
+	}
+}
+
+func (probe *telemetryStoreLateDataProbe) Signals() []telemetrytypes.Signal {
+	return []telemetrytypes.Signal{telemetrytypes.SignalLogs, telemetrytypes.SignalMetrics}
+}
+
+func (probe *telemetryStoreLateDataProbe) Probe(ctx context.Context, signal telemetrytypes.Signal, metricNames []string, cachedFromMs uint64, ingestedFromMs uint64, ingestedToMs uint64) ([]*qbtypes.TimeRange, error) {
+	fluxMs := uint64(probe.fluxInterval.Milliseconds())
+	lookbackMs := uint64(probe.lookback.Milliseconds())
+	if ingestedToMs <= fluxMs || ingestedFromMs >= ingestedToMs {
+		return nil, nil
+	}
+
+	// Late data is older than the flux interval when it is ingested, so its
+	// event time lies within [ingestedFromMs-lookback, ingestedToMs-flux). Data
+	// older than the cached buckets invalidates nothing and is not scanned.
+	eventFromMs := cachedFromMs
+	if ingestedFromMs > lookbackMs {
+		eventFromMs = max(eventFromMs, ingestedFromMs-lookbackMs)
+	}
+	eventToMs := ingestedToMs - fluxMs
+	if eventFromMs >= eventToMs {
+		return nil, nil
+	}
+
+	var query string
+	var args []any
+	switch signal {
+	case telemetrytypes.SignalMetrics:
+		// metric_name leads the sorting key of the samples, without it every
+		// sample of the window would be scanned.
+		if len(metricNames) == 0 {
+			return nil, nil
+		}
+
+		query = fmt.Sprintf(
+			"SELECT DISTINCT intDiv(unix_milli, %d) * %d AS bucket_start FROM %s.%s "+
+				"WHERE metric_name IN ? AND unix_milli >= ? AND unix_milli < ? AND inserted_at_unix_milli >= ? AND inserted_at_unix_milli < ? AND unix_milli < inserted_at_unix_milli - ? "+
+				"ORDER BY bucket_start",
+			lateDataGranularityMs, lateDataGranularityMs, telemetrymetrics.DBName, telemetrymetrics.SamplesV4TableName,
+		)
+		args = []any{metricNames, eventFromMs, eventToMs, ingestedFromMs, ingestedToMs, fluxMs}
+	case telemetrytypes.SignalLogs:
+		// ts_bucket_start is in seconds and lags the timestamp by up to 30 minutes.
+		query = fmt.Sprintf(
+			"SELECT DISTINCT toUInt64(intDiv(%s, %d) * %d) AS bucket_start FROM %s.%s "+
+				"WHERE %s >= ? AND %s <= ? AND %s >= ? AND %s < ? AND %s >= ? AND %s < ? AND %s < %s - ? "+
+				"ORDER BY bucket_start",
+			telemetrylogs.LogsV2TimestampColumn, lateDataGranularityMs*1000000, lateDataGranularityMs, telemetrylogs.DBName, telemetrylogs.LogsV2TableName,
+			telemetrylogs.LogsV2TimestampBucketStartColumn, telemetrylogs.LogsV2TimestampBucketStartColumn,
+			telemetrylogs.LogsV2TimestampColumn, telemetrylogs.LogsV2TimestampColumn,
+			telemetrylogs.LogsV2ObservedTimestampColumn, telemetrylogs.LogsV2ObservedTimestampColumn,
+			telemetrylogs.LogsV2TimestampColumn, telemetrylogs.LogsV2ObservedTimestampColumn,
+		)
+		args = []any{
+			max(eventFromMs/1000, 1800) - 1800, eventToMs / 1000,
+			eventFromMs * 1000000, eventToMs * 1000000,
+			ingestedFromMs * 1000000, ingestedToMs * 1000000,
+			fluxMs * 1000000,
+		}
+	default:
+		return nil, errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "late data probe does not support signal %q", signal.StringValue())
+	}
+
+	rows, err := probe.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranges []*qbtypes.TimeRange
+	for rows.Next() {
+		var bucketStart uint64
+		if err := rows.Scan(&bucketStart); err != nil {
+			return nil, err
+		}
+
+		// Adjacent buckets are reported as one range.
+		if len(ranges) > 0 && ranges[len(ranges)-1].To == bucketStart {
+			ranges[len(ranges)-1].To = bucketStart + lateDataGranularityMs
+			continue
+		}
+		ranges = append(ranges, &qbtypes.TimeRange{From: bucketStart, To: bucketStart + lateDataGranularityMs})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ranges, nil
+}
+
+// lateDataTracker keeps the ranges which received late data, probing for new
+// ones at most once per probe interval. It is shared by all orgs as the
+// telemetry store is.
+type lateDataTracker struct {
+	probe         LateDataProbe
+	logger        *slog.Logger
+	probeInterval time.Duration
+	retention     time.Duration
+
+	mu            sync.Mutex
+	probing       bool
+	probedUntilMs uint64
+	invalidations []*qbtypes.CachedInvalidation
+	// metricNames holds when each metric was last cached, only the metrics
+	// with cached buckets are probed.
+	metricNames map[string]uint64
+	// cachedFrom holds when buckets starting in each hour were last cached
+	// for a signal, only the event time from the earliest of them is probed.
+	cachedFrom map[telemetrytypes.Signal]map[uint64]uint64
+}
+
+func newLateDataTracker(probe LateDataProbe, logger *slog.Logger, probeInterval time.Duration, retention time.Duration) *lateDataTracker {
+	return &lateDataTracker{
+		probe:         probe,
+		logger:        logger,
+		probeInterval: probeInterval,
+		retention:     retention,
+		probedUntilMs: uint64(time.Now().UnixMilli()),
+		metricNames:   map[string]uint64{},
+		cachedFrom:    map[telemetrytypes.Signal]map[uint64]uint64{},
+	}
+}
+
+// Observe records the signals and metrics read by the query as cached from
+// startMs at nowMs. A query with no known signals is recorded for every signal.
+func (tracker *lateDataTracker) Observe(q qbtypes.Query, startMs uint64, nowMs uint64) {
+	signals := querySignals(q)
+	if len(signals) == 0 {
+		signals = tracker.probe.Signals()
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	for _, name := range queryMetricNames(q) {
+		tracker.metricNames[name] = nowMs
+	}
+
+	hourMs := startMs - startMs%lateDataGranularityMs
+	for _, signal := range signals {
+		if _, ok := tracker.cachedFrom[signal]; !ok {
+			tracker.cachedFrom[signal] = map[uint64]uint64{}
+		}
+		tracker.cachedFrom[signal][hourMs] = nowMs
+	}
+}
+
+// Invalidations returns the invalidations found so far and starts a probe in
+// the background if the last one is older than the probe interval.
+func (tracker *lateDataTracker) Invalidations(ctx context.Context) []*qbtypes.CachedInvalidation {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	nowMs := uint64(time.Now().UnixMilli())
+	if !tracker.probing && nowMs >= tracker.probedUntilMs+uint64(tracker.probeInterval.Milliseconds()) {
+		tracker.probing = true
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tracker.probeInterval)
+			defer cancel()
+			tracker.refresh(ctx, nowMs)
+		}()
+	}
+
+	return tracker.invalidations
+}
+
+// refresh probes every signal with cached buckets for data ingested between the
+// last probe and nowMs. The window is probed again next time if any signal fails.
+func (tracker *lateDataTracker) refresh(ctx context.Context, nowMs uint64) {
+	retentionMs := uint64(tracker.retention.Milliseconds())
+	tracker.mu.Lock()
+	fromMs := tracker.probedUntilMs
+	metricNames := make([]string, 0, len(tracker.metricNames))
+	for name, cachedAtMs := range tracker.metricNames {
+		if cachedAtMs+retentionMs <= nowMs {
+			delete(tracker.metricNames, name)
+			continue
+		}
+		metricNames = append(metricNames, name)
+	}
+
+	cachedFromMs := map[telemetrytypes.Signal]uint64{}
+	for signal, hours := range tracker.cachedFrom {
+		for hourMs, cachedAtMs := range hours {
+			if cachedAtMs+retentionMs <= nowMs {
+				delete(hours, hourMs)
+				continue
+			}
+			if earliestMs, ok := cachedFromMs[signal]; !ok || hourMs < earliestMs {
+				cachedFromMs[signal] = hourMs
+			}
+		}
+	}
+	tracker.mu.Unlock()
+	slices.Sort(metricNames)
+
+	found := []*qbtypes.CachedInvalidation{}
+	var err error
+	for _, signal := range tracker.probe.Signals() {
+		// Nothing is cached for the signal, so no late data can make it stale.
+		signalFromMs, ok := cachedFromMs[signal]
+		if !ok {
+			continue
+		}
+
+		var ranges []*qbtypes.TimeRange
+		ranges, err = tracker.probe.Probe(ctx, signal, metricNames, signalFromMs, fromMs, nowMs)
+		if err != nil {
+			tracker.logger.ErrorContext(ctx, "error probing for late data", slog.String("signal", signal.StringValue()), errors.Attr(err))
+			break
+		}
+
+		for _, tr := range ranges {
+			found = append(found, &qbtypes.CachedInvalidation{Signal: signal, StartMs: tr.From, EndMs: tr.To, InvalidatedAtMs: nowMs})
+		}
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.probing = false
+	if err != nil {
+		return
+	}
+
+	if len(found) > 0 {
+		tracker.logger.InfoContext(ctx, "found late data", slog.Int("ranges", len(found)), slog.Uint64("ingested_from", fromMs), slog.Uint64("ingested_to", nowMs))
+	}
+
+	// Copied rather than appended in place, callers may hold the previous slice.
+	invalidations := make([]*qbtypes.CachedInvalidation, 0, len(tracker.invalidations)+len(found))
+	invalidations = append(invalidations, pruneInvalidations(tracker.invalidations, nowMs, tracker.retention)...)
+	tracker.invalidations = append(invalidations, found...)
+	tracker.probedUntilMs = nowMs
+}
+
+// pruneInvalidations drops the invalidations older than the retention, every
+// bucket they could apply to has expired by then.
+func pruneInvalidations(invalidations []*qbtypes.CachedInvalidation, nowMs uint64, retention time.Duration) []*qbtypes.CachedInvalidation {
+	retentionMs := uint64(retention.Milliseconds())
+	pruned := make([]*qbtypes.CachedInvalidation, 0, len(invalidations))
+	for _, invalidation := range invalidations {
+		if invalidation.InvalidatedAtMs+retentionMs > nowMs {
+			pruned = append(pruned, invalidation)
+		}
+	}
+
+	return pruned
+}
+
+// signalQuery is implemented by the queries which know the signals they read.
+type signalQuery interface {
+	signals() []telemetrytypes.Signal
+}
+
+// querySignals returns the signals read by the query, nil if they are unknown
+// as for ClickHouse SQL.
+func querySignals(q qbtypes.Query) []telemetrytypes.Signal {
+	if sq, ok := q.(signalQuery); ok {
+		return sq.signals()
+	}
+
+	return nil
+}
+
+// metricQuery is implemented by the queries which know the metrics they read.
+type metricQuery interface {
+	metricNames() []string
+}
+
+// queryMetricNames returns the metrics read by the query, nil if they are
+// unknown or the query reads no metrics.
+func queryMetricNames(q qbtypes.Query) []string {
+	if mq, ok := q.(metricQuery); ok {
+		return mq.metricNames()
+	}
+
+	return nil
+}
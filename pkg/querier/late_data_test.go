@@ -0,0 +1,219 @@
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signalMockQuery struct {
+	*mockQuery
+	signal  telemetrytypes.Signal
+	metrics []string
+}
+
+func (m *signalMockQuery) signals() []telemetrytypes.Signal {
+	return []telemetrytypes.Signal{m.signal}
+}
+
+func (m *signalMockQuery) metricNames() []string {
+	return m.metrics
+}
+
+type probeCall struct {
+	signal         telemetrytypes.Signal
+	metricNames    []string
+	cachedFromMs   uint64
+	ingestedFromMs uint64
+	ingestedToMs   uint64
+}
+
+type fakeLateDataProbe struct {
+	ranges map[telemetrytypes.Signal][]*qbtypes.TimeRange
+	err    error
+	calls  []probeCall
+}
+
+func (probe *fakeLateDataProbe) Signals() []telemetrytypes.Signal {
+	return []telemetrytypes.Signal{telemetrytypes.SignalLogs, telemetrytypes.SignalMetrics}
+}
+
+func (probe *fakeLateDataProbe) Probe(_ context.Context, signal telemetrytypes.Signal, metricNames []string, cachedFromMs uint64, ingestedFromMs uint64, ingestedToMs uint64) ([]*qbtypes.TimeRange, error) {
+	probe.calls = append(probe.calls, probeCall{signal: signal, metricNames: metricNames, cachedFromMs: cachedFromMs, ingestedFromMs: ingestedFromMs, ingestedToMs: ingestedToMs})
+	if probe.err != nil {
+		return nil, probe.err
+	}
+
+	return probe.ranges[signal], nil
+}
+
+func putTestSeries(t *testing.T, bc BucketCache, orgID valuer.UUID, query qbtypes.Query) {
+	t.Helper()
+
+	// Invalidations apply to buckets cached up to the same millisecond, so the
+	// bucket is cached after and invalidated before the next one.
+	time.Sleep(2 * time.Millisecond)
+	defer time.Sleep(2 * time.Millisecond)
+
+	startMs, endMs := query.Window()
+	bc.Put(context.Background(), orgID, query, qbtypes.Step{Duration: time.Second}, &qbtypes.Result{
+		Type:  qbtypes.RequestTypeTimeSeries,
+		Value: createTestTimeSeries("A", startMs, endMs, 1000),
+	})
+
+	cached, missing := bc.GetMissRanges(context.Background(), orgID, query, qbtypes.Step{Duration: time.Second})
+	require.NotNil(t, cached)
+	require.Empty(t, missing)
+}
+
+func TestBucketCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	bc := createTestBucketCache(t)
+	orgID := valuer.GenerateUUID()
+	query := &signalMockQuery{mockQuery: &mockQuery{fingerprint: "logs-query", startMs: 1000, endMs: 5000}, signal: telemetrytypes.SignalLogs}
+	putTestSeries(t, bc, orgID, query)
+
+	// Other signals, other time ranges and other orgs leave the bucket alone.
+	require.NoError(t, bc.Invalidate(ctx, orgID, telemetrytypes.SignalMetrics, qbtypes.TimeRange{From: 2000, To: 3000}))
+	require.NoError(t, bc.Invalidate(ctx, orgID, telemetrytypes.SignalLogs, qbtypes.TimeRange{From: 5000, To: 8000}))
+	require.NoError(t, bc.Invalidate(ctx, valuer.GenerateUUID(), telemetrytypes.SignalLogs, qbtypes.TimeRange{From: 2000, To: 3000}))
+	cached, missing := bc.GetMissRanges(ctx, orgID, query, qbtypes.Step{Duration: time.Second})
+	assert.NotNil(t, cached)
+	assert.Empty(t, missing)
+
+	require.NoError(t, bc.Invalidate(ctx, orgID, telemetrytypes.SignalLogs, qbtypes.TimeRange{From: 2000, To: 3000}))
+	cached, missing = bc.GetMissRanges(ctx, orgID, query, qbtypes.Step{Duration: time.Second})
+	assert.Nil(t, cached)
+	assert.Equal(t, []*qbtypes.TimeRange{{From: 1000, To: 5000}}, missing)
+
+	// Buckets cached after the invalidation are served again.
+	putTestSeries(t, bc, orgID, query)
+
+	// Queries with unknown signals are invalidated by every signal.
+	unknown := &mockQuery{fingerprint: "sql-query", startMs: 1000, endMs: 5000}
+	putTestSeries(t, bc, orgID, unknown)
+	require.NoError(t, bc.Invalidate(ctx, orgID, telemetrytypes.SignalMetrics, qbtypes.TimeRange{From: 4000, To: 4500}))
+	cached, _ = bc.GetMissRanges(ctx, orgID, unknown, qbtypes.Step{Duration: time.Second})
+	assert.Nil(t, cached)
+}
+
+func TestBucketCacheInvalidateSharedCache(t *testing.T) {
+	ctx := context.Background()
+	sharedCache := createTestCache(t)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), sharedCache, cacheTTL, defaultFluxInterval, nil, 0).(*bucketCache)
+	other := NewBucketCache(instrumentationtest.New().ToProviderSettings(), sharedCache, cacheTTL, defaultFluxInterval, nil, 0).(*bucketCache)
+	orgID := valuer.GenerateUUID()
+	query := &signalMockQuery{mockQuery: &mockQuery{fingerprint: "logs-query", startMs: 1000, endMs: 5000}, signal: telemetrytypes.SignalLogs}
+	putTestSeries(t, bc, orgID, query)
+
+	// The invalidations of another instance are read once the flux interval
+	// has passed since the last read.
+	require.NoError(t, other.Invalidate(ctx, orgID, telemetrytypes.SignalLogs, qbtypes.TimeRange{From: 2000, To: 3000}))
+	cached, _ := bc.GetMissRanges(ctx, orgID, query, qbtypes.Step{Duration: time.Second})
+	assert.NotNil(t, cached)
+
+	bc.invalidations[orgID].readAtMs -= uint64(defaultFluxInterval.Milliseconds())
+	cached, _ = bc.GetMissRanges(ctx, orgID, query, qbtypes.Step{Duration: time.Second})
+	assert.Nil(t, cached)
+}
+
+func TestBucketCacheInvalidateKeepsOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	sharedCache := createTestCache(t)
+	first := NewBucketCache(instrumentationtest.New().ToProviderSettings(), sharedCache, cacheTTL, defaultFluxInterval, nil, 0).(*bucketCache)
+	second := NewBucketCache(instrumentationtest.New().ToProviderSettings(), sharedCache, cacheTTL, defaultFluxInterval, nil, 0).(*bucketCache)
+	orgID := valuer.GenerateUUID()
+
+	// Both instances add to the same slot without having read each other's
+	// invalidations, neither is overwritten.
+	require.NoError(t, first.Invalidate(ctx, orgID, telemetrytypes.SignalLogs, qbtypes.TimeRange{From: 1000, To: 2000}))
+	second.invalidations[orgID] = &orgInvalidations{nextSlotMs: first.invalidations[orgID].nextSlotMs}
+	require.NoError(t, second.Invalidate(ctx, orgID, telemetrytypes.SignalMetrics, qbtypes.TimeRange{From: 3000, To: 4000}))
+
+	reader := NewBucketCache(instrumentationtest.New().ToProviderSettings(), sharedCache, cacheTTL, defaultFluxInterval, nil, 0).(*bucketCache)
+	invalidations := reader.cachedInvalidations(ctx, orgID)
+	require.Len(t, invalidations, 2)
+	assert.Equal(t, telemetrytypes.SignalLogs, invalidations[0].Signal)
+	assert.Equal(t, telemetrytypes.SignalMetrics, invalidations[1].Signal)
+	assert.Len(t, second.invalidations[orgID].invalidations, 2)
+}
+
+func TestBucketCacheLateData(t *testing.T) {
+	ctx := context.Background()
+	probe := &fakeLateDataProbe{ranges: map[telemetrytypes.Signal][]*qbtypes.TimeRange{
+		telemetrytypes.SignalMetrics: {{From: 3000, To: 4000}},
+	}}
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), createTestCache(t), cacheTTL, defaultFluxInterval, probe, time.Hour).(*bucketCache)
+	orgID := valuer.GenerateUUID()
+
+	metrics := &signalMockQuery{mockQuery: &mockQuery{fingerprint: "metrics-query", startMs: 1000, endMs: 5000}, signal: telemetrytypes.SignalMetrics, metrics: []string{"http_requests_total"}}
+	logs := &signalMockQuery{mockQuery: &mockQuery{fingerprint: "logs-query", startMs: 1000, endMs: 5000}, signal: telemetrytypes.SignalLogs}
+	putTestSeries(t, bc, orgID, metrics)
+	putTestSeries(t, bc, orgID, logs)
+
+	probe.calls = nil
+	bc.lateData.refresh(ctx, uint64(time.Now().UnixMilli()))
+	require.Len(t, probe.calls, 2)
+	assert.Equal(t, []string{"http_requests_total"}, probe.calls[1].metricNames)
+
+	cached, missing := bc.GetMissRanges(ctx, orgID, metrics, qbtypes.Step{Duration: time.Second})
+	assert.Nil(t, cached)
+	assert.Equal(t, []*qbtypes.TimeRange{{From: 1000, To: 5000}}, missing)
+
+	cached, _ = bc.GetMissRanges(ctx, orgID, logs, qbtypes.Step{Duration: time.Second})
+	assert.NotNil(t, cached)
+}
+
+func TestLateDataTrackerRefresh(t *testing.T) {
+	ctx := context.Background()
+	probe := &fakeLateDataProbe{ranges: map[telemetrytypes.Signal][]*qbtypes.TimeRange{
+		telemetrytypes.SignalLogs: {{From: 1000, To: 2000}},
+	}}
+	tracker := newLateDataTracker(probe, instrumentationtest.New().Logger(), time.Minute, time.Hour)
+	startMs := tracker.probedUntilMs
+
+	// Nothing is probed before anything is cached.
+	tracker.refresh(ctx, startMs)
+	assert.Empty(t, probe.calls)
+
+	// A failed probe is retried over the same ingestion window.
+	hourMs := uint64(time.Hour.Milliseconds())
+	tracker.Observe(&signalMockQuery{mockQuery: &mockQuery{}, signal: telemetrytypes.SignalMetrics, metrics: []string{"b", "a"}}, 5*hourMs+1000, startMs)
+	probe.err = errors.New(errors.TypeInternal, errors.CodeInternal, "probe failed")
+	tracker.refresh(ctx, startMs+1000)
+	assert.Empty(t, tracker.Invalidations(ctx))
+	assert.Equal(t, startMs, tracker.probedUntilMs)
+
+	// Only the cached signals and metrics are probed, from the earliest hour
+	// cached. Queries with unknown signals are cached for every signal.
+	tracker.Observe(&signalMockQuery{mockQuery: &mockQuery{}, signal: telemetrytypes.SignalMetrics, metrics: []string{"a"}}, 3*hourMs+1000, startMs)
+	tracker.Observe(&mockQuery{}, 4*hourMs, startMs)
+	probe.err = nil
+	probe.calls = nil
+	tracker.refresh(ctx, startMs+2000)
+	assert.Equal(t, []probeCall{
+		{signal: telemetrytypes.SignalLogs, metricNames: []string{"a", "b"}, cachedFromMs: 4 * hourMs, ingestedFromMs: startMs, ingestedToMs: startMs + 2000},
+		{signal: telemetrytypes.SignalMetrics, metricNames: []string{"a", "b"}, cachedFromMs: 3 * hourMs, ingestedFromMs: startMs, ingestedToMs: startMs + 2000},
+	}, probe.calls)
+	assert.Equal(t, []*qbtypes.CachedInvalidation{
+		{Signal: telemetrytypes.SignalLogs, StartMs: 1000, EndMs: 2000, InvalidatedAtMs: startMs + 2000},
+	}, tracker.invalidations)
+
+	// Invalidations, metrics and signals are dropped once every bucket they
+	// apply to has expired, nothing is probed then.
+	probe.ranges = nil
+	probe.calls = nil
+	tracker.refresh(ctx, startMs+2000+uint64(time.Hour.Milliseconds()))
+	assert.Empty(t, tracker.invalidations)
+	assert.Empty(t, tracker.metricNames)
+	assert.Empty(t, probe.calls)
+	assert.Equal(t, startMs+2000+uint64(time.Hour.Milliseconds()), tracker.probedUntilMs)
+}
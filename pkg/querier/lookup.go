@@ -0,0 +1,107 @@
+package querier
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// defaultMaxQuerySize is the default max_query_size of ClickHouse.
+	defaultMaxQuerySize = 262144
+)
+
+// resolveLookups attaches the latest version of the lookup tables joined by
+// the builder and sub queries to their lookups. Every table is read once per
+// request.
+func (q *querier) resolveLookups(ctx context.Context, orgID valuer.UUID, queries []qbtypes.QueryEnvelope) error {
+	versions := make(map[string]*lookuptabletypes.LookupTableVersion)
+
+	for idx := range queries {
+		if queries[idx].Type != qbtypes.QueryTypeBuilder && queries[idx].Type != qbtypes.QueryTypeSubQuery {
+			continue
+		}
+
+		switch spec := queries[idx].Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			lookups, err := q.resolveLookupJoins(ctx, orgID, spec.Lookups, versions)
+			if err != nil {
+				return err
+			}
+			spec.Lookups = lookups
+			queries[idx].Spec = spec
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			lookups, err := q.resolveLookupJoins(ctx, orgID, spec.Lookups, versions)
+			if err != nil {
+				return err
+			}
+			spec.Lookups = lookups
+			queries[idx].Spec = spec
+		}
+	}
+
+	return nil
+}
+
+func (q *querier) resolveLookupJoins(
+	ctx context.Context,
+	orgID valuer.UUID,
+	lookups []qbtypes.LookupJoin,
+	versions map[string]*lookuptabletypes.LookupTableVersion,
+) ([]qbtypes.LookupJoin, error) {
+	if len(lookups) == 0 {
+		return lookups, nil
+	}
+
+	if q.lookupTableGetter == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "lookup tables are not available")
+	}
+
+	resolved := make([]qbtypes.LookupJoin, len(lookups))
+	for idx, lookup := range lookups {
+		version, ok := versions[lookup.Table]
+		if !ok {
+			var err error
+			version, err = q.lookupTableGetter.GetLatestVersionByName(ctx, orgID, lookup.Table)
+			if err != nil {
+				return nil, err
+			}
+			versions[lookup.Table] = version
+		}
+
+		lookup.KeyColumn = version.KeyColumn
+		lookup.Columns = version.Columns
+		lookup.Rows = version.Rows
+		lookup.VersionID = version.ID
+		resolved[idx] = lookup
+	}
+
+	return resolved, nil
+}
+
+// withMaxQuerySize raises max_query_size for statements larger than the
+// ClickHouse default once their args are bound, such as the ones inlining the
+// rows of lookup tables.
+func withMaxQuerySize(ctx context.Context, query string, args []any) context.Context {
+	size := len(query)
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case string:
+			size += len(arg)
+		case []string:
+			for _, value := range arg {
+				// quotes, separator and escaping
+				size += len(value) + 4
+			}
+		}
+	}
+
+	if size < defaultMaxQuerySize {
+		return ctx
+	}
+
+	return context.WithValue(ctx, "max_query_size", 2*size)
+}
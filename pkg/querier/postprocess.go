@@ -40,6 +40,8 @@ func getqueryInfo(spec any) queryInfo {
 		return queryInfo{Name: s.Name, Disabled: s.Disabled, Step: s.StepInterval}
 	case qbtypes.QueryBuilderFormula:
 		return queryInfo{Name: s.Name, Disabled: s.Disabled}
+	case qbtypes.QueryBuilderJoin:
+		return queryInfo{Name: s.Name, Disabled: s.Disabled}
 	case qbtypes.PromQuery:
 		return queryInfo{Name: s.Name, Disabled: s.Disabled, Step: s.Step}
 	case qbtypes.ClickHouseQuery:
@@ -85,6 +87,11 @@ func (q *querier) postProcessResults(ctx context.Context, orgID valuer.UUID, res
 				result = postProcessTraceOperator(q, result, spec, req)
 				typedResults[spec.Name] = result
 			}
+		case qbtypes.QueryBuilderJoin:
+			if result, ok := typedResults[spec.Name]; ok {
+				result = postProcessJoin(q, result, spec, req)
+				typedResults[spec.Name] = result
+			}
 		}
 	}
 
@@ -249,6 +256,31 @@ func postProcessTraceOperator(
 	return result
 }
 
+// postProcessJoin applies postprocessing to a join query result.
+func postProcessJoin(
+	q *querier,
+	result *qbtypes.Result,
+	query qbtypes.QueryBuilderJoin,
+	req *qbtypes.QueryRangeRequest,
+) *qbtypes.Result {
+
+	// scalar joins are limited in the query itself
+	if req.RequestType == qbtypes.RequestTypeTimeSeries {
+		result = q.applySeriesLimit(result, query.Limit, query.Order)
+	}
+
+	if len(query.Functions) > 0 {
+		step, err := req.StepIntervalForQuery(query.Name)
+		if err != nil {
+			return result
+		}
+		functions := q.prepareFillZeroArgsWithStep(query.Functions, req, step)
+		result = q.applyFunctions(result, functions)
+	}
+
+	return result
+}
+
 // applyMetricReduceTo applies reduce to operation using the metric's ReduceTo field.
 func (q *querier) applyMetricReduceTo(result *qbtypes.Result, reduceOp qbtypes.ReduceTo) *qbtypes.Result {
 	tsData, ok := result.Value.(*qbtypes.TimeSeriesData)
@@ -140,6 +140,41 @@ func (q *promqlQuery) Window() (uint64, uint64) {
 	return q.tr.From, q.tr.To
 }
 
+func (q *promqlQuery) signals() []telemetrytypes.Signal {
+	return []telemetrytypes.Signal{telemetrytypes.SignalMetrics}
+}
+
+// metricNames returns the metric names the selectors of the query match
+// exactly, nil if the query does not parse.
+func (q *promqlQuery) metricNames() []string {
+	query, err := q.renderVars(q.query.Query, q.vars, q.tr.From, q.tr.To)
+	if err != nil {
+		return nil
+	}
+
+	expr, err := q.parser.ParseExpr(query)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		vs, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+
+		for _, matcher := range vs.LabelMatchers {
+			if matcher.Name == labels.MetricName && matcher.Type == labels.MatchEqual {
+				names = append(names, matcher.Value)
+			}
+		}
+		return nil
+	})
+
+	return names
+}
+
 // removeAllVarMatchers removes label matchers from a PromQL query that reference variables with __all__ value.
 // This method parses the query, walks the AST to remove matching matchers, and returns the modified query string.
 // If parsing or walking fails, it returns an error.
@@ -190,6 +225,7 @@ func (q *promqlQuery) renderVars(query string, vars map[string]qbv5.VariableItem
 	}
 
 	querybuilder.AssignReservedVars(varsData, start, end)
+	querybuilder.AssignPromQLReservedVars(varsData, start, end, q.query.Step.Duration)
 
 	keys := make([]string, 0, len(varsData))
 	for k := range varsData {
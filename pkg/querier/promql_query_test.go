@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/prometheus"
@@ -176,6 +177,30 @@ func TestRemoveAllVarMatchers(t *testing.T) {
 	}
 }
 
+func TestRenderVarsGrafanaIntervals(t *testing.T) {
+	q := &promqlQuery{logger: slog.Default(), parser: prometheus.NewParser(), query: qbv5.PromQuery{Step: qbv5.Step{Duration: time.Minute}}}
+
+	query, err := q.renderVars(
+		`sum(rate(http_requests_total[$__rate_interval])) / sum(increase(http_requests_total[$__range])) + avg(avg_over_time(up[$__interval]))`,
+		nil, 1672531200000, 1672534800000,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, `sum(rate(http_requests_total[240s])) / sum(increase(http_requests_total[3600s])) + avg(avg_over_time(up[60s]))`, query)
+}
+
+func TestPromqlQueryMetricNames(t *testing.T) {
+	q := &promqlQuery{
+		logger: slog.Default(),
+		parser: prometheus.NewParser(),
+		query:  qbv5.PromQuery{Query: `sum(rate(http_requests_total[$__rate_interval])) / sum({"http.server.duration", job="api"}) + sum({__name__=~"up|down"})`, Step: qbv5.Step{Duration: time.Minute}},
+		tr:     qbv5.TimeRange{From: 1672531200000, To: 1672534800000},
+	}
+	assert.Equal(t, []string{"http_requests_total", "http.server.duration"}, q.metricNames())
+
+	q.query.Query = `sum(rate(`
+	assert.Nil(t, q.metricNames())
+}
+
 func TestEnhancePromQLError(t *testing.T) {
 	parseErr := errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "unexpected character: '.' at position 12")
 
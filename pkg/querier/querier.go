@@ -17,6 +17,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/query-service/utils"
 	"github.com/SigNoz/signoz/pkg/querybuilder"
@@ -46,7 +47,9 @@ type querier struct {
 	metricStmtBuilder        qbtypes.StatementBuilder[qbtypes.MetricAggregation]
 	meterStmtBuilder         qbtypes.StatementBuilder[qbtypes.MetricAggregation]
 	traceOperatorStmtBuilder qbtypes.TraceOperatorStatementBuilder
+	joinStmtBuilder          qbtypes.JoinStatementBuilder
 	bucketCache              BucketCache
+	lookupTableGetter        lookuptable.Getter
 	liveDataRefresh          time.Duration
 }
 
@@ -63,7 +66,9 @@ func New(
 	metricStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation],
 	meterStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation],
 	traceOperatorStmtBuilder qbtypes.TraceOperatorStatementBuilder,
+	joinStmtBuilder qbtypes.JoinStatementBuilder,
 	bucketCache BucketCache,
+	lookupTableGetter lookuptable.Getter,
 	flagger flagger.Flagger,
 ) *querier {
 	querierSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier")
@@ -79,7 +84,9 @@ func New(
 		metricStmtBuilder:        metricStmtBuilder,
 		meterStmtBuilder:         meterStmtBuilder,
 		traceOperatorStmtBuilder: traceOperatorStmtBuilder,
+		joinStmtBuilder:          joinStmtBuilder,
 		bucketCache:              bucketCache,
+		lookupTableGetter:        lookupTableGetter,
 		liveDataRefresh:          5 * time.Second,
 	}
 }
@@ -116,6 +123,9 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 	// We need to set if it is unspecified or adjust it if value is not within recommended range
 	intervalWarnings := q.adjustStepInterval(req.CompositeQuery.Queries, req.Start, req.End)
 
+	// Disabled queries referenced by a join are only computed as part of the join
+	joinDependencyQueries := q.constructJoinDependencyMap(req.CompositeQuery.Queries)
+
 	queries := make(map[string]qbtypes.Query)
 	steps := make(map[string]qbtypes.Step)
 
@@ -132,6 +142,18 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 		missingMetricQuerySet[name] = true
 	}
 
+	// Lookup tables are inlined in the statements of the queries joining them,
+	// before the sub queries are compiled as they may join lookup tables too
+	if err := q.resolveLookups(ctx, orgID, req.CompositeQuery.Queries); err != nil {
+		return nil, err
+	}
+
+	// Sub queries are compiled into the queries referencing them, after the
+	// metric metadata is resolved as they may reference metric queries
+	if err := q.resolveSubQueries(ctx, req.CompositeQuery.Queries, req.Start, req.End, tmplVars); err != nil {
+		return nil, err
+	}
+
 	for _, query := range req.CompositeQuery.Queries {
 		queryName := query.GetQueryName()
 
@@ -140,6 +162,11 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 			continue
 		}
 
+		// skip if it is a disabled dependency of joinQuery
+		if query.Type == qbtypes.QueryTypeBuilder && query.IsDisabled() && joinDependencyQueries[queryName] {
+			continue
+		}
+
 		switch query.Type {
 		case qbtypes.QueryTypePromQL:
 			promQuery, ok := query.Spec.(qbtypes.PromQuery)
@@ -172,6 +199,25 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 			}
 			queries[traceOpQuery.Name] = toq
 			steps[traceOpQuery.Name] = traceOpQuery.StepInterval
+		case qbtypes.QueryTypeJoin:
+			joinSpec, ok := query.Spec.(qbtypes.QueryBuilderJoin)
+			if !ok {
+				return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid join query spec %T", query.Spec)
+			}
+			step := joinStep(req.CompositeQuery.Queries, joinSpec)
+			jq := &joinQuery{
+				telemetryStore: q.telemetryStore,
+				stmtBuilder:    q.joinStmtBuilder,
+				spec:           joinSpec,
+				compositeQuery: &req.CompositeQuery,
+				variables:      tmplVars,
+				step:           step,
+				fromMS:         uint64(req.Start),
+				toMS:           uint64(req.End),
+				kind:           req.RequestType,
+			}
+			queries[joinSpec.Name] = jq
+			steps[joinSpec.Name] = step
 		case qbtypes.QueryTypeBuilder:
 			switch spec := query.Spec.(type) {
 			case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
@@ -274,6 +320,10 @@ func (q *querier) populateQBEvent(event *qbtypes.QBEvent, queries []qbtypes.Quer
 			event.MetricsUsed = true
 		case qbtypes.QueryTypeTraceOperator:
 			event.TracesUsed = true
+		case qbtypes.QueryTypeJoin:
+			filter := query.GetFilter()
+			event.FilterApplied = event.FilterApplied || (filter != nil && filter.Expression != "")
+			event.GroupByApplied = event.GroupByApplied || len(query.GetGroupBy()) > 0
 		case qbtypes.QueryTypeClickHouseSQL:
 			sql := query.GetQuery()
 			if strings.TrimSpace(sql) != "" {
@@ -528,6 +578,16 @@ func (q *querier) QueryRawStream(ctx context.Context, orgID valuer.UUID, req *qb
 	}
 }
 
+// PurgeCache drops the org's cached results of the signal for the time range,
+// so that the next queries over it fetch the data again.
+func (q *querier) PurgeCache(ctx context.Context, orgID valuer.UUID, req *qbtypes.PostableCachePurge) error {
+	if q.bucketCache == nil {
+		return nil
+	}
+
+	return q.bucketCache.Invalidate(ctx, orgID, req.Signal, qbtypes.TimeRange{From: req.Start, To: req.End})
+}
+
 func (q *querier) run(
 	ctx context.Context,
 	orgID valuer.UUID,
@@ -830,6 +890,19 @@ func (q *querier) createRangedQuery(originalQuery qbtypes.Query, timeRange qbtyp
 			compositeQuery: qt.compositeQuery,
 			kind:           qt.kind,
 		}
+	case *joinQuery:
+		specCopy := qt.spec.Copy()
+		return &joinQuery{
+			telemetryStore: q.telemetryStore,
+			stmtBuilder:    q.joinStmtBuilder,
+			spec:           specCopy,
+			compositeQuery: qt.compositeQuery,
+			variables:      qt.variables,
+			step:           qt.step,
+			fromMS:         uint64(timeRange.From),
+			toMS:           uint64(timeRange.To),
+			kind:           qt.kind,
+		}
 	default:
 		return nil
 	}
@@ -1159,6 +1232,24 @@ func (q *querier) adjustStepInterval(queries []qbtypes.QueryEnvelope, start, end
 			clampStep(qe, traceLogRecommended, traceLogMin, &warnings)
 		}
 	}
+
+	// Both sides of a join are aligned on ts, so they must use the same step.
+	for idx := range queries {
+		if queries[idx].Type != qbtypes.QueryTypeJoin {
+			continue
+		}
+		spec, ok := queries[idx].Spec.(qbtypes.QueryBuilderJoin)
+		if !ok {
+			continue
+		}
+		step := joinStep(queries, spec)
+		for jdx := range queries {
+			name := queries[jdx].GetQueryName()
+			if name == spec.Left.Name || name == spec.Right.Name {
+				queries[jdx].SetStepInterval(step)
+			}
+		}
+	}
 	return warnings
 }
 
@@ -1179,3 +1270,33 @@ func dedupeWarnings(warnings []string) []string {
 	}
 	return unique
 }
+
+func (q *querier) constructJoinDependencyMap(queries []qbtypes.QueryEnvelope) map[string]bool {
+	dependencyQueries := make(map[string]bool)
+
+	for _, query := range queries {
+		if query.Type == qbtypes.QueryTypeJoin {
+			if spec, ok := query.Spec.(qbtypes.QueryBuilderJoin); ok {
+				dependencyQueries[spec.Left.Name] = true
+				dependencyQueries[spec.Right.Name] = true
+			}
+		}
+	}
+
+	return dependencyQueries
+}
+
+// joinStep returns the larger step interval of the two sides of the join.
+func joinStep(queries []qbtypes.QueryEnvelope, spec qbtypes.QueryBuilderJoin) qbtypes.Step {
+	step := qbtypes.Step{}
+	for _, query := range queries {
+		name := query.GetQueryName()
+		if name != spec.Left.Name && name != spec.Right.Name {
+			continue
+		}
+		if s := query.GetStepInterval(); s.Duration > step.Duration {
+			step = s
+		}
+	}
+	return step
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/querybuilder/querybuildertest"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
 	"github.com/SigNoz/signoz/pkg/types/metrictypes"
@@ -52,7 +54,9 @@ func TestQueryRange_MetricTypeMissing(t *testing.T) {
 		nil,                // metricStmtBuilder
 		nil,                // meterStmtBuilder
 		nil,                // traceOperatorStmtBuilder
+		nil,                // joinStmtBuilder
 		nil,                // bucketCache
+		nil,                // lookupTableGetter
 		flaggertest.New(t), // flagger
 	)
 
@@ -117,7 +121,9 @@ func TestQueryRange_MetricTypeFromStore(t *testing.T) {
 		&mockMetricStmtBuilder{}, // metricStmtBuilder
 		nil,                      // meterStmtBuilder
 		nil,                      // traceOperatorStmtBuilder
+		nil,                      // joinStmtBuilder
 		nil,                      // bucketCache
+		nil,                      // lookupTableGetter
 		flaggertest.New(t),       // flagger
 	)
 
@@ -148,3 +154,108 @@ func TestQueryRange_MetricTypeFromStore(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 }
+
+func TestQueryRange_Join(t *testing.T) {
+	providerSettings := instrumentationtest.New().ToProviderSettings()
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &queryMatcherAny{})
+
+	end := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	start := end.Add(-10 * time.Minute)
+
+	cols := []cmock.ColumnType{
+		{Name: "ts", Type: "DateTime"},
+		{Name: "service.name", Type: "String"},
+		{Name: "__result_0", Type: "Float64"},
+		{Name: "__result_1", Type: "Float64"},
+	}
+	rows := cmock.NewRows(cols, [][]any{
+		{start, "frontend", float64(5), float64(50)},
+		{start.Add(time.Minute), "frontend", float64(2), float64(40)},
+	})
+	// the join is executed once, the second request is served from the cache
+	telemetryStore.Mock().
+		ExpectQuery("WITH __join_left").
+		WithArgs("A", "B").
+		WillReturnRows(rows)
+
+	joinStmtBuilder := querybuilder.NewJoinStatementBuilder(
+		providerSettings,
+		&querybuildertest.FixedStatementBuilder[qbtypes.TraceAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.LogAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.LogAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.MetricAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.MetricAggregation]{},
+	)
+	bucketCache := NewBucketCache(providerSettings, createTestCache(t), cacheTTL, defaultFluxInterval, nil, 0)
+
+	q := New(
+		providerSettings,
+		telemetryStore,
+		telemetrytypestest.NewMockMetadataStore(),
+		nil,                // prometheus
+		nil,                // traceStmtBuilder
+		nil,                // logStmtBuilder
+		nil,                // auditStmtBuilder
+		nil,                // metricStmtBuilder
+		nil,                // meterStmtBuilder
+		nil,                // traceOperatorStmtBuilder
+		joinStmtBuilder,    // joinStmtBuilder
+		bucketCache,        // bucketCache
+		nil,                // lookupTableGetter
+		flaggertest.New(t), // flagger
+	)
+
+	step := qbtypes.Step{Duration: time.Minute}
+	serviceName := qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}
+	newRequest := func() *qbtypes.QueryRangeRequest {
+		return &qbtypes.QueryRangeRequest{
+			Start:       uint64(start.UnixMilli()),
+			End:         uint64(end.UnixMilli()),
+			RequestType: qbtypes.RequestTypeTimeSeries,
+			CompositeQuery: qbtypes.CompositeQuery{
+				Queries: []qbtypes.QueryEnvelope{
+					{
+						Type: qbtypes.QueryTypeBuilder,
+						Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+							Name:         "A",
+							Signal:       telemetrytypes.SignalLogs,
+							StepInterval: step,
+							Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+							GroupBy:      []qbtypes.GroupByKey{serviceName},
+							Disabled:     true,
+						},
+					},
+					{
+						Type: qbtypes.QueryTypeBuilder,
+						Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+							Name:         "B",
+							Signal:       telemetrytypes.SignalTraces,
+							StepInterval: step,
+							Aggregations: []qbtypes.TraceAggregation{{Expression: "count()"}},
+							GroupBy:      []qbtypes.GroupByKey{serviceName},
+							Disabled:     true,
+						},
+					},
+					{
+						Type: qbtypes.QueryTypeJoin,
+						Spec: qbtypes.QueryBuilderJoin{
+							Name:  "J",
+							Left:  qbtypes.QueryRef{Name: "A"},
+							Right: qbtypes.QueryRef{Name: "B"},
+							Type:  qbtypes.JoinTypeInner,
+							On:    "service.name = service.name",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	orgID := valuer.GenerateUUID()
+	for range 2 {
+		resp, err := q.QueryRange(context.Background(), orgID, newRequest())
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+	}
+	require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+}
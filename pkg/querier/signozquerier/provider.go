@@ -6,6 +6,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/querybuilder"
@@ -23,6 +24,7 @@ func NewFactory(
 	telemetryStore telemetrystore.TelemetryStore,
 	prometheus prometheus.Prometheus,
 	cache cache.Cache,
+	lookupTableGetter lookuptable.Getter,
 	flagger flagger.Flagger,
 ) factory.ProviderFactory[querier.Querier, querier.Config] {
 	return factory.NewProviderFactory(
@@ -32,7 +34,7 @@ func NewFactory(
 			settings factory.ProviderSettings,
 			cfg querier.Config,
 		) (querier.Querier, error) {
-			return newProvider(ctx, settings, cfg, telemetryStore, prometheus, cache, flagger)
+			return newProvider(ctx, settings, cfg, telemetryStore, prometheus, cache, lookupTableGetter, flagger)
 		},
 	)
 }
@@ -44,6 +46,7 @@ func newProvider(
 	telemetryStore telemetrystore.TelemetryStore,
 	prometheus prometheus.Prometheus,
 	cache cache.Cache,
+	lookupTableGetter lookuptable.Getter,
 	flagger flagger.Flagger,
 ) (querier.Querier, error) {
 
@@ -170,12 +173,30 @@ func newProvider(
 		metricStmtBuilder,
 	)
 
+	// Create join statement builder
+	joinStmtBuilder := querybuilder.NewJoinStatementBuilder(
+		settings,
+		traceStmtBuilder,
+		logStmtBuilder,
+		auditStmtBuilder,
+		metricStmtBuilder,
+		meterStmtBuilder,
+	)
+
+	// Create the late data probe if enabled
+	var lateDataProbe querier.LateDataProbe
+	if cfg.LateData.Enabled {
+		lateDataProbe = querier.NewLateDataProbe(telemetryStore, cfg.FluxInterval, cfg.LateData.Lookback)
+	}
+
 	// Create bucket cache
 	bucketCache := querier.NewBucketCache(
 		settings,
 		cache,
 		cfg.CacheTTL,
 		cfg.FluxInterval,
+		lateDataProbe,
+		cfg.LateData.ProbeInterval,
 	)
 
 	// Create and return the querier
@@ -190,7 +211,9 @@ func newProvider(
 		metricStmtBuilder,
 		meterStmtBuilder,
 		traceOperatorStmtBuilder,
+		joinStmtBuilder,
 		bucketCache,
+		lookupTableGetter,
 		flagger,
 	), nil
 }
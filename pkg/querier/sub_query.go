@@ -0,0 +1,139 @@
+package querier
+
+import (
+	"context"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// resolveSubQueries compiles the builder_sub_query queries referenced by sub query
+// filters and attaches the statements to the filters of the referencing queries.
+//
+// Sub queries are evaluated over the whole request window, which keeps the
+// referencing queries out of the cache. They are never executed on their own.
+func (q *querier) resolveSubQueries(ctx context.Context, queries []qbtypes.QueryEnvelope, start, end uint64, variables map[string]qbtypes.VariableItem) error {
+	subQueries := make(map[string]qbtypes.QueryEnvelope)
+	for _, query := range queries {
+		if query.Type == qbtypes.QueryTypeSubQuery {
+			subQueries[query.GetQueryName()] = query
+		}
+	}
+
+	for idx := range queries {
+		if queries[idx].Type != qbtypes.QueryTypeBuilder {
+			continue
+		}
+
+		switch spec := queries[idx].Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			filters, err := q.resolveSubQueryFilters(ctx, spec.SubQueryFilters, subQueries, start, end, variables)
+			if err != nil {
+				return err
+			}
+			spec.SubQueryFilters = filters
+			queries[idx].Spec = spec
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			filters, err := q.resolveSubQueryFilters(ctx, spec.SubQueryFilters, subQueries, start, end, variables)
+			if err != nil {
+				return err
+			}
+			spec.SubQueryFilters = filters
+			queries[idx].Spec = spec
+		}
+	}
+
+	return nil
+}
+
+func (q *querier) resolveSubQueryFilters(
+	ctx context.Context,
+	filters []qbtypes.SubQueryFilter,
+	subQueries map[string]qbtypes.QueryEnvelope,
+	start, end uint64,
+	variables map[string]qbtypes.VariableItem,
+) ([]qbtypes.SubQueryFilter, error) {
+	if len(filters) == 0 {
+		return filters, nil
+	}
+
+	// raw sub queries project every column the filters match against
+	columns := make(map[string][]string)
+	for _, filter := range filters {
+		if !slices.Contains(columns[filter.Query], filter.ColumnName()) {
+			columns[filter.Query] = append(columns[filter.Query], filter.ColumnName())
+		}
+	}
+
+	resolved := make([]qbtypes.SubQueryFilter, len(filters))
+	for idx, filter := range filters {
+		subQuery, ok := subQueries[filter.Query]
+		if !ok {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "sub query '%s' does not exist", filter.Query)
+		}
+
+		stmt, err := q.buildSubQuery(ctx, subQuery, start, end, columns[filter.Query], variables)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Statement = stmt
+		resolved[idx] = filter
+	}
+
+	return resolved, nil
+}
+
+// buildSubQuery builds the statement for a sub query.
+// Aggregated sub queries are built as scalar queries and return their group by keys,
+// the others are built as raw queries and return the columns matched by the filters.
+func (q *querier) buildSubQuery(ctx context.Context, envelope qbtypes.QueryEnvelope, start, end uint64, columns []string, variables map[string]qbtypes.VariableItem) (*qbtypes.Statement, error) {
+	timeRange := qbtypes.TimeRange{From: start, To: end}
+
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		spec, kind := subQuerySpec(spec, columns)
+		return buildSubQueryStatement(ctx, newBuilderQuery(q.logger, q.telemetryStore, q.traceStmtBuilder, spec, timeRange, kind, variables))
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		spec, kind := subQuerySpec(spec, columns)
+		stmtBuilder := q.logStmtBuilder
+		if spec.Source == telemetrytypes.SourceAudit {
+			stmtBuilder = q.auditStmtBuilder
+		}
+		return buildSubQueryStatement(ctx, newBuilderQuery(q.logger, q.telemetryStore, stmtBuilder, spec, timeRange, kind, variables))
+	case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+		spec, kind := subQuerySpec(spec, columns)
+		stmtBuilder := q.metricStmtBuilder
+		if spec.Source == telemetrytypes.SourceMeter {
+			stmtBuilder = q.meterStmtBuilder
+		}
+		return buildSubQueryStatement(ctx, newBuilderQuery(q.logger, q.telemetryStore, stmtBuilder, spec, timeRange, kind, variables))
+	default:
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported sub query spec type %T", envelope.Spec)
+	}
+}
+
+// subQuerySpec applies the default limit to the sub query and returns the request type to build it with.
+// Raw sub queries select the columns matched by the filters, which are aliased by their name.
+func subQuerySpec[T any](spec qbtypes.QueryBuilderQuery[T], columns []string) (qbtypes.QueryBuilderQuery[T], qbtypes.RequestType) {
+	spec = spec.Copy()
+	if spec.Limit == 0 {
+		spec.Limit = qbtypes.DefaultSubQueryLimit
+	}
+
+	if len(spec.Aggregations) > 0 {
+		return spec, qbtypes.RequestTypeScalar
+	}
+
+	spec.SelectFields = make([]telemetrytypes.TelemetryFieldKey, 0, len(columns))
+	for _, column := range columns {
+		spec.SelectFields = append(spec.SelectFields, telemetrytypes.TelemetryFieldKey{Name: column})
+	}
+	return spec, qbtypes.RequestTypeRaw
+}
+
+func buildSubQueryStatement[T any](ctx context.Context, bq *builderQuery[T]) (*qbtypes.Statement, error) {
+	return bq.stmtBuilder.Build(ctx, bq.fromMS, bq.toMS, bq.kind, bq.spec, bq.variables)
+}
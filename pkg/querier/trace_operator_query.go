@@ -32,6 +32,10 @@ func (q *traceOperatorQuery) Window() (uint64, uint64) {
 	return q.fromMS, q.toMS
 }
 
+func (q *traceOperatorQuery) signals() []telemetrytypes.Signal {
+	return []telemetrytypes.Signal{telemetrytypes.SignalTraces}
+}
+
 func (q *traceOperatorQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
 	stmt, err := q.stmtBuilder.Build(
 		ctx,
@@ -93,7 +93,7 @@ func (r *Repo) GetLatestVersion(
 	ctx context.Context, orgId valuer.UUID, typ opamptypes.ElementType,
 ) (*opamptypes.AgentConfigVersion, error) {
 	var c opamptypes.AgentConfigVersion
-	err := r.store.BunDB().NewSelect().
+	err := r.store.BunDBCtx(ctx).NewSelect().
 		Model(&c).
 		ColumnExpr("id, version, element_type, deploy_status, deploy_result, created_at").
 		ColumnExpr("COALESCE(created_by, '') as created_by").
@@ -120,8 +120,8 @@ func (r *Repo) insertConfig(
 		return errors.NewInvalidInputf(CodeElementTypeRequired, "element type is required for creating agent config version")
 	}
 
-	// allowing empty elements for logs - use case is deleting all pipelines
-	if len(elements) == 0 && c.ElementType != opamptypes.ElementTypeLogPipelines {
+	// allowing empty elements for logs, sampling and drop rules and lookup tables - use case is deleting all of them
+	if len(elements) == 0 && !allowsEmptyElements(c.ElementType) {
 		slog.ErrorContext(ctx, "insert config called with no elements", "element_type", c.ElementType.StringValue())
 		return errors.NewInvalidInputf(CodeConfigElementsRequired, "config must have atleast one element")
 	}
@@ -154,11 +154,11 @@ func (r *Repo) insertConfig(
 		if !success {
 			// remove all the damage (invalid rows from db)
 			// Delete elements first, then version (to respect potential foreign key constraints)
-			_, delErr := r.store.BunDB().NewDelete().Model(new(opamptypes.AgentConfigElement)).Where("version_id = ?", c.ID).Exec(ctx)
+			_, delErr := r.store.BunDBCtx(ctx).NewDelete().Model(new(opamptypes.AgentConfigElement)).Where("version_id = ?", c.ID).Exec(ctx)
 			if delErr != nil {
 				slog.ErrorContext(ctx, "failed to delete config elements during cleanup", errors.Attr(delErr), "version_id", c.ID.String())
 			}
-			_, delErr = r.store.BunDB().NewDelete().Model(new(opamptypes.AgentConfigVersion)).Where("id = ?", c.ID).Where("org_id = ?", orgId).Exec(ctx)
+			_, delErr = r.store.BunDBCtx(ctx).NewDelete().Model(new(opamptypes.AgentConfigVersion)).Where("id = ?", c.ID).Where("org_id = ?", orgId).Exec(ctx)
 			if delErr != nil {
 				slog.ErrorContext(ctx, "failed to delete config version during cleanup", errors.Attr(delErr), "version_id", c.ID.String())
 			}
@@ -166,7 +166,7 @@ func (r *Repo) insertConfig(
 	}()
 
 	_, dbErr := r.store.
-		BunDB().
+		BunDBCtx(ctx).
 		NewInsert().
 		Model(c).
 		Exec(ctx)
@@ -186,7 +186,7 @@ func (r *Repo) insertConfig(
 			ElementType: c.ElementType.StringValue(),
 			ElementID:   e,
 		}
-		_, dbErr = r.store.BunDB().NewInsert().Model(agentConfigElement).Exec(ctx)
+		_, dbErr = r.store.BunDBCtx(ctx).NewInsert().Model(agentConfigElement).Exec(ctx)
 		if dbErr != nil {
 			return errors.WrapInternalf(dbErr, CodeConfigElementInsertFailed, "failed to insert config element")
 		}
@@ -196,6 +196,14 @@ func (r *Repo) insertConfig(
 	return nil
 }
 
+func allowsEmptyElements(typ opamptypes.ElementType) bool {
+	switch typ {
+	case opamptypes.ElementTypeLogPipelines, opamptypes.ElementTypeSamplingRules, opamptypes.ElementTypeDropRules, opamptypes.ElementTypeLookupTables:
+		return true
+	}
+	return false
+}
+
 func (r *Repo) updateDeployStatus(ctx context.Context,
 	orgId valuer.UUID,
 	elementType opamptypes.ElementType,
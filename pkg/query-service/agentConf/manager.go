@@ -7,16 +7,10 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
-	"sync/atomic"
 
 	"github.com/google/uuid"
-	yaml "gopkg.in/yaml.v3"
 
 	"github.com/SigNoz/signoz/pkg/errors"
-	"github.com/SigNoz/signoz/pkg/query-service/app/opamp"
-	filterprocessor "github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig/filterprocessor"
-	tsp "github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig/tailsampler"
-	"github.com/SigNoz/signoz/pkg/query-service/model"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/types/opamptypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
@@ -24,10 +18,6 @@ import (
 
 var m *Manager
 
-var (
-	CodeConfigVersionNoConfig = errors.MustNewCode("config_version_no_config")
-)
-
 func init() {
 	m = &Manager{}
 }
@@ -36,8 +26,6 @@ type AgentFeatureType string
 
 type Manager struct {
 	Repo
-	// lock to make sure only one update is sent to remote agents at a time
-	lock   uint32
 	logger *slog.Logger
 
 	// For AgentConfigProvider implementation
@@ -208,12 +196,16 @@ func GetConfigHistory(
 func StartNewVersion(
 	ctx context.Context, orgId valuer.UUID, userId valuer.UUID, eleType opamptypes.ElementType, elementIds []string,
 ) (*opamptypes.AgentConfigVersion, error) {
+	return StartNewVersionWithConfig(ctx, orgId, userId, eleType, elementIds, "")
+}
 
-	// create a new version
-	cfg := opamptypes.NewAgentConfigVersion(orgId, userId, eleType)
-
-	// insert new config and elements into database
-	err := m.insertConfig(ctx, orgId, userId, cfg, elementIds)
+// StartNewVersionWithConfig launches a new config version for given set of elements
+// and stores config as the settings of the version. Features whose elements are
+// updated in place use it to keep the settings of older versions around.
+func StartNewVersionWithConfig(
+	ctx context.Context, orgId valuer.UUID, userId valuer.UUID, eleType opamptypes.ElementType, elementIds []string, config string,
+) (*opamptypes.AgentConfigVersion, error) {
+	cfg, err := InsertNewVersionWithConfig(ctx, orgId, userId, eleType, elementIds, config)
 	if err != nil {
 		return nil, err
 	}
@@ -223,92 +215,31 @@ func StartNewVersion(
 	return cfg, nil
 }
 
-func NotifyConfigUpdate(ctx context.Context) {
-	m.notifyConfigUpdateSubscribers()
-}
-
-func Redeploy(ctx context.Context, orgId valuer.UUID, typ opamptypes.ElementType, version int) error {
-	configVersion, err := GetConfigVersion(ctx, orgId, typ, version)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to fetch config version during redeploy", errors.Attr(err))
-		return err
-	}
-
-	if configVersion == nil || (configVersion != nil && configVersion.Config == "") {
-		slog.DebugContext(ctx, "config version has no conf yaml", "config_version", configVersion)
-		return errors.NewInvalidInputf(CodeConfigVersionNoConfig, "the config version can not be redeployed")
-	}
-	switch typ {
-	case opamptypes.ElementTypeSamplingRules:
-		var config *tsp.Config
-		if err := yaml.Unmarshal([]byte(configVersion.Config), &config); err != nil {
-			slog.DebugContext(ctx, "failed to read last conf correctly", errors.Attr(err))
-			return model.BadRequest(fmt.Errorf("failed to read the stored config correctly"))
-		}
-
-		// merge current config with new filter params
-		processorConf := map[string]interface{}{
-			"signoz_tail_sampling": config,
-		}
-
-		opamp.AddToTracePipelineSpec("signoz_tail_sampling")
-		configHash, err := opamp.UpsertControlProcessors(ctx, "traces", processorConf, m.OnConfigUpdate)
-		if err != nil {
-			slog.ErrorContext(ctx, "failed to call agent config update for trace processor", errors.Attr(err))
-			return errors.WithAdditionalf(err, "failed to deploy the config")
-		}
-
-		m.updateDeployStatus(ctx, orgId, opamptypes.ElementTypeSamplingRules, version, opamptypes.DeployInitiated.StringValue(), "Deployment started", configHash, configVersion.Config)
-	case opamptypes.ElementTypeDropRules:
-		var filterConfig *filterprocessor.Config
-		if err := yaml.Unmarshal([]byte(configVersion.Config), &filterConfig); err != nil {
-			slog.ErrorContext(ctx, "failed to read last conf correctly", errors.Attr(err))
-			return model.InternalError(fmt.Errorf("failed to read the stored config correctly"))
-		}
-		processorConf := map[string]interface{}{
-			"filter": filterConfig,
-		}
-
-		opamp.AddToMetricsPipelineSpec("filter")
-		configHash, err := opamp.UpsertControlProcessors(ctx, "metrics", processorConf, m.OnConfigUpdate)
-		if err != nil {
-			slog.ErrorContext(ctx, "failed to call agent config update for trace processor", errors.Attr(err))
-			return err
-		}
-
-		m.updateDeployStatus(ctx, orgId, opamptypes.ElementTypeSamplingRules, version, opamptypes.DeployInitiated.StringValue(), "Deployment started", configHash, configVersion.Config)
-	}
-
-	return nil
-}
-
-// UpsertFilterProcessor updates the agent config with new filter processor params
-func UpsertFilterProcessor(ctx context.Context, orgId valuer.UUID, version int, config *filterprocessor.Config) error {
-	if !atomic.CompareAndSwapUint32(&m.lock, 0, 1) {
-		return fmt.Errorf("agent updater is busy")
-	}
-	defer atomic.StoreUint32(&m.lock, 0)
+// InsertNewVersionWithConfig inserts a new config version like StartNewVersionWithConfig
+// without rolling it out. It runs in the transaction of ctx if there is one, so that
+// the version is only kept along with the elements it snapshots. Callers roll it out
+// with NotifyConfigUpdate once the transaction is committed.
+func InsertNewVersionWithConfig(
+	ctx context.Context, orgId valuer.UUID, userId valuer.UUID, eleType opamptypes.ElementType, elementIds []string, config string,
+) (*opamptypes.AgentConfigVersion, error) {
 
-	// merge current config with new filter params
-	// merge current config with new filter params
-	processorConf := map[string]interface{}{
-		"filter": config,
+	// create a new version
+	cfg := opamptypes.NewAgentConfigVersion(orgId, userId, eleType)
+	if config != "" {
+		cfg.Config = config
 	}
 
-	opamp.AddToMetricsPipelineSpec("filter")
-	configHash, err := opamp.UpsertControlProcessors(ctx, "metrics", processorConf, m.OnConfigUpdate)
+	// insert new config and elements into database
+	err := m.insertConfig(ctx, orgId, userId, cfg, elementIds)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to call agent config update for trace processor", errors.Attr(err))
-		return err
+		return nil, err
 	}
 
-	processorConfYaml, yamlErr := yaml.Marshal(config)
-	if yamlErr != nil {
-		slog.WarnContext(ctx, "unexpected error while transforming processor config to yaml", errors.Attr(yamlErr))
-	}
+	return cfg, nil
+}
 
-	m.updateDeployStatus(ctx, orgId, opamptypes.ElementTypeDropRules, version, opamptypes.DeployInitiated.StringValue(), "Deployment started", configHash, string(processorConfYaml))
-	return nil
+func NotifyConfigUpdate(ctx context.Context) {
+	m.notifyConfigUpdateSubscribers()
 }
 
 // OnConfigUpdate is a callback function passed to opamp server.
@@ -333,31 +264,3 @@ func (m *Manager) OnConfigUpdate(orgId valuer.UUID, agentId string, hash string,
 
 	_ = m.updateDeployStatusByHash(context.Background(), orgId, hash, status, message)
 }
-
-// UpsertSamplingProcessor updates the agent config with new filter processor params
-func UpsertSamplingProcessor(ctx context.Context, orgId valuer.UUID, version int, config *tsp.Config) error {
-	if !atomic.CompareAndSwapUint32(&m.lock, 0, 1) {
-		return fmt.Errorf("agent updater is busy")
-	}
-	defer atomic.StoreUint32(&m.lock, 0)
-
-	// merge current config with new filter params
-	processorConf := map[string]interface{}{
-		"signoz_tail_sampling": config,
-	}
-
-	opamp.AddToTracePipelineSpec("signoz_tail_sampling")
-	configHash, err := opamp.UpsertControlProcessors(ctx, "traces", processorConf, m.OnConfigUpdate)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to call agent config update for trace processor", errors.Attr(err))
-		return err
-	}
-
-	processorConfYaml, yamlErr := yaml.Marshal(config)
-	if yamlErr != nil {
-		slog.WarnContext(ctx, "unexpected error while transforming processor config to yaml", errors.Attr(yamlErr))
-	}
-
-	m.updateDeployStatus(ctx, orgId, opamptypes.ElementTypeSamplingRules, version, opamptypes.DeployInitiated.StringValue(), "Deployment started", configHash, string(processorConfYaml))
-	return nil
-}
@@ -0,0 +1,121 @@
+package agentConf
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/opamptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Features whose elements are updated in place, such as ingestion rules and
+// lookup tables, store a snapshot of the elements as the config of every
+// version. The snapshot is what keeps older versions intact and what gets
+// rolled out.
+
+// snapshotHistoryLimit is the number of config versions listed along with a
+// snapshot, same as for log pipelines.
+const snapshotHistoryLimit = 10
+
+var CodeConfigSnapshotInvalid = errors.MustNewCode("config_snapshot_invalid")
+
+// Snapshot returns the IDs of the elements of a feature along with the value
+// stored as the config of a version.
+type Snapshot func(ctx context.Context) (elementIds []string, snapshot any, err error)
+
+// StartNewSnapshotVersion runs update and inserts a new config version with the
+// snapshot taken after it in one transaction, so that neither is kept without
+// the other, and rolls the version out once committed.
+func StartNewSnapshotVersion(
+	ctx context.Context, orgId valuer.UUID, userId valuer.UUID, eleType opamptypes.ElementType, update func(ctx context.Context) error, snapshot Snapshot,
+) error {
+	err := m.store.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		if err := update(ctx); err != nil {
+			return err
+		}
+
+		elementIds, value, err := snapshot(ctx)
+		if err != nil {
+			return err
+		}
+
+		config, err := json.Marshal(value)
+		if err != nil {
+			return errors.WrapInternalf(err, CodeConfigSnapshotInvalid, "failed to serialize the %s snapshot", eleType)
+		}
+
+		_, err = InsertNewVersionWithConfig(ctx, orgId, userId, eleType, elementIds, string(config))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	m.notifyConfigUpdateSubscribers()
+	return nil
+}
+
+// GetSnapshot reads the snapshot of a config version into snapshot, which is
+// left as is for versions without one. The latest version is passed to agent
+// features without its config, they fetch it by number.
+func GetSnapshot(
+	ctx context.Context, orgId valuer.UUID, eleType opamptypes.ElementType, version int, snapshot any,
+) (*opamptypes.AgentConfigVersion, error) {
+	configVersion, err := GetConfigVersion(ctx, orgId, eleType, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if configVersion.Config == "" || configVersion.Config == "{}" {
+		return configVersion, nil
+	}
+
+	if err := json.Unmarshal([]byte(configVersion.Config), snapshot); err != nil {
+		return nil, errors.WrapInternalf(err, CodeConfigSnapshotInvalid, "failed to read the %s snapshot of version %d", eleType, version)
+	}
+
+	return configVersion, nil
+}
+
+// GetLatestSnapshotVersion returns the latest config version of a feature, nil
+// when there is none yet, along with the history of its versions.
+func GetLatestSnapshotVersion(
+	ctx context.Context, orgId valuer.UUID, eleType opamptypes.ElementType,
+) (*opamptypes.AgentConfigVersion, []opamptypes.AgentConfigVersion, error) {
+	version, err := GetLatestVersion(ctx, orgId, eleType)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return nil, nil, err
+	}
+
+	history, err := GetSnapshotHistory(ctx, orgId, eleType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return version, history, nil
+}
+
+// GetLatestSnapshot reads the snapshot of the latest config version of a
+// feature into snapshot, which is left as is when there is none yet.
+func GetLatestSnapshot(
+	ctx context.Context, orgId valuer.UUID, eleType opamptypes.ElementType, snapshot any,
+) error {
+	version, err := GetLatestVersion(ctx, orgId, eleType)
+	if err != nil {
+		if errors.Ast(err, errors.TypeNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = GetSnapshot(ctx, orgId, eleType, version.Version, snapshot)
+	return err
+}
+
+// GetSnapshotHistory returns the latest config versions of a feature.
+func GetSnapshotHistory(
+	ctx context.Context, orgId valuer.UUID, eleType opamptypes.ElementType,
+) ([]opamptypes.AgentConfigVersion, error) {
+	return GetConfigHistory(ctx, orgId, eleType, snapshotHistoryLimit)
+}
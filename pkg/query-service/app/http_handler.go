@@ -925,7 +925,8 @@ func (aH *APIHandler) getRuleStateHistory(w http.ResponseWriter, r *http.Request
 	}
 
 	rule, err := aH.ruleManager.GetRule(r.Context(), id)
-	if err == nil {
+	// composite rules have no query to link to
+	if err == nil && rule.RuleCondition.CompositeQuery != nil {
 		for idx := range res.Items {
 			lbls := make(map[string]string)
 			err := json.Unmarshal([]byte(res.Items[idx].Labels), &lbls)
@@ -3284,6 +3285,7 @@ func (aH *APIHandler) RegisterLogsRoutes(router *mux.Router, am *middleware.Auth
 
 	// log pipelines
 	subRouter.HandleFunc("/pipelines/preview", am.ViewAccess(aH.PreviewLogsPipelinesHandler)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/pipelines/dryrun", am.ViewAccess(aH.DryRunLogsPipelinesHandler)).Methods(http.MethodPost)
 	subRouter.HandleFunc("/pipelines/{version}", am.ViewAccess(aH.ListLogsPipelinesHandler)).Methods(http.MethodGet)
 	subRouter.HandleFunc("/pipelines", am.EditAccess(aH.CreateLogsPipeline)).Methods(http.MethodPost)
 }
@@ -3349,6 +3351,18 @@ func parseAgentConfigVersion(r *http.Request) (int, error) {
 }
 
 func (aH *APIHandler) PreviewLogsPipelinesHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
 	req := logparsingpipeline.PipelinesPreviewRequest{}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -3356,7 +3370,7 @@ func (aH *APIHandler) PreviewLogsPipelinesHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	resultLogs, err := aH.LogsParsingPipelineController.PreviewLogsPipelines(r.Context(), &req)
+	resultLogs, err := aH.LogsParsingPipelineController.PreviewLogsPipelines(r.Context(), orgID, &req)
 	if err != nil {
 		render.Error(w, err)
 		return
@@ -3365,6 +3379,34 @@ func (aH *APIHandler) PreviewLogsPipelinesHandler(w http.ResponseWriter, r *http
 	aH.Respond(w, resultLogs)
 }
 
+func (aH *APIHandler) DryRunLogsPipelinesHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	req := logparsingpipeline.PipelinesDryRunRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Error(w, errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "failed to decode request body"))
+		return
+	}
+
+	result, err := aH.LogsParsingPipelineController.DryRunLogsPipelines(r.Context(), orgID, &req)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	aH.Respond(w, result)
+}
+
 func (aH *APIHandler) ListLogsPipelinesHandler(w http.ResponseWriter, r *http.Request) {
 	claims, err := authtypes.ClaimsFromContext(r.Context())
 	if err != nil {
@@ -4383,6 +4425,9 @@ func (aH *APIHandler) RegisterTraceFunnelsRoutes(router *mux.Router, am *middlew
 	traceFunnelsRouter.HandleFunc("/{funnel_id}/analytics/steps/overview", aH.handleFunnelStepAnalytics).Methods("POST")
 	traceFunnelsRouter.HandleFunc("/{funnel_id}/analytics/slow-traces", aH.handleFunnelSlowTraces).Methods("POST")
 	traceFunnelsRouter.HandleFunc("/{funnel_id}/analytics/error-traces", aH.handleFunnelErrorTraces).Methods("POST")
+	traceFunnelsRouter.HandleFunc("/{funnel_id}/analytics/breakdown",
+		am.ViewAccess(aH.Signoz.Handlers.TraceFunnel.Breakdown)).
+		Methods(http.MethodPost)
 
 	// Analytics endpoints
 	traceFunnelsRouter.HandleFunc("/analytics/validate", aH.handleValidateTracesWithPayload).Methods("POST")
@@ -4391,6 +4436,9 @@ func (aH *APIHandler) RegisterTraceFunnelsRoutes(router *mux.Router, am *middlew
 	traceFunnelsRouter.HandleFunc("/analytics/steps/overview", aH.handleFunnelStepAnalyticsWithPayload).Methods("POST")
 	traceFunnelsRouter.HandleFunc("/analytics/slow-traces", aH.handleFunnelSlowTracesWithPayload).Methods("POST")
 	traceFunnelsRouter.HandleFunc("/analytics/error-traces", aH.handleFunnelErrorTracesWithPayload).Methods("POST")
+	traceFunnelsRouter.HandleFunc("/analytics/breakdown",
+		am.ViewAccess(aH.Signoz.Handlers.TraceFunnel.BreakdownWithPayload)).
+		Methods(http.MethodPost)
 }
 
 func (aH *APIHandler) handleValidateTraces(w http.ResponseWriter, r *http.Request) {
@@ -4421,9 +4469,14 @@ func (aH *APIHandler) handleValidateTraces(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelValidation(w, r, funnel, timeRange)
+		return
+	}
+
 	chq, err := traceFunnelsModule.ValidateTraces(funnel, timeRange)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4458,9 +4511,14 @@ func (aH *APIHandler) handleFunnelAnalytics(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelTransition(w, r, funnel, stepTransition.TimeRange, 1, int64(len(funnel.Steps)))
+		return
+	}
+
 	chq, err := traceFunnelsModule.GetFunnelAnalytics(funnel, stepTransition.TimeRange)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4495,9 +4553,14 @@ func (aH *APIHandler) handleFunnelStepAnalytics(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelTransition(w, r, funnel, stepTransition.TimeRange, stepTransition.StepStart, stepTransition.StepEnd)
+		return
+	}
+
 	chq, err := traceFunnelsModule.GetFunnelStepAnalytics(funnel, stepTransition.TimeRange, stepTransition.StepStart, stepTransition.StepEnd)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4509,6 +4572,92 @@ func (aH *APIHandler) handleFunnelStepAnalytics(w http.ResponseWriter, r *http.R
 	aH.Respond(w, results)
 }
 
+// respondFunnelTransition serves the overview analytics of funnels over logs,
+// correlated by a custom key or using breakdown only steps, in the rows the
+// legacy queries return.
+func (aH *APIHandler) respondFunnelTransition(w http.ResponseWriter, r *http.Request, funnel *traceFunnels.StorableFunnel, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64) {
+	transition, err := aH.Signoz.Modules.TraceFunnel.GetTransition(r.Context(), funnel.FunnelSource, funnel.Steps, timeRange, stepStart, stepEnd)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	aH.Respond(w, []*v3.Row{{Data: map[string]interface{}{
+		"conversion_rate": transition.ConversionRate,
+		"avg_rate":        transition.AvgRate,
+		"errors":          transition.Errors,
+		"avg_duration":    transition.AvgDuration,
+		"latency":         transition.Latency,
+	}}})
+}
+
+// respondFunnelStepCounts serves the step analytics of funnels the legacy
+// queries cannot evaluate, from their breakdown, in the rows the legacy
+// queries return.
+func (aH *APIHandler) respondFunnelStepCounts(w http.ResponseWriter, r *http.Request, funnel *traceFunnels.StorableFunnel, timeRange traceFunnels.TimeRange) {
+	breakdown, err := aH.Signoz.Modules.TraceFunnel.GetBreakdown(r.Context(), funnel.FunnelSource, funnel.Steps, &traceFunnels.FunnelBreakdownRequest{TimeRange: timeRange})
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	data := map[string]interface{}{}
+	for i := range funnel.Steps {
+		data[fmt.Sprintf("total_s%d_spans", i+1)] = uint64(0)
+		data[fmt.Sprintf("total_s%d_errored_spans", i+1)] = uint64(0)
+	}
+	// without group by keys, the breakdown has a single group once a journey
+	// entered the funnel
+	if len(breakdown.Groups) > 0 {
+		for i, step := range breakdown.Groups[0].Steps {
+			data[fmt.Sprintf("total_s%d_spans", i+1)] = step.Traces
+			data[fmt.Sprintf("total_s%d_errored_spans", i+1)] = step.ErroredTraces
+		}
+	}
+
+	aH.Respond(w, []*v3.Row{{Data: data}})
+}
+
+// respondFunnelSlowestJourneys serves the slowest and errored traces of
+// funnels the legacy queries cannot evaluate, in the rows the legacy queries
+// return. Journeys of funnels correlated by a custom key are listed by its
+// value.
+func (aH *APIHandler) respondFunnelSlowestJourneys(w http.ResponseWriter, r *http.Request, funnel *traceFunnels.StorableFunnel, timeRange traceFunnels.TimeRange, stepStart int64, stepEnd int64, erroredOnly bool) {
+	journeys, err := aH.Signoz.Modules.TraceFunnel.GetSlowestJourneys(r.Context(), funnel.FunnelSource, funnel.Steps, timeRange, stepStart, stepEnd, erroredOnly)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	rows := make([]*v3.Row, len(journeys))
+	for i, journey := range journeys {
+		rows[i] = &v3.Row{Data: map[string]interface{}{
+			"trace_id":    journey.TraceID,
+			"duration_ms": journey.DurationMs,
+			"span_count":  journey.SpanCount,
+		}}
+	}
+
+	aH.Respond(w, rows)
+}
+
+// respondFunnelValidation serves the validation of funnels the legacy queries
+// cannot evaluate, in the rows the legacy queries return.
+func (aH *APIHandler) respondFunnelValidation(w http.ResponseWriter, r *http.Request, funnel *traceFunnels.StorableFunnel, timeRange traceFunnels.TimeRange) {
+	journeys, err := aH.Signoz.Modules.TraceFunnel.ValidateJourneys(r.Context(), funnel.FunnelSource, funnel.Steps, timeRange)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	rows := make([]*v3.Row, len(journeys))
+	for i, journey := range journeys {
+		rows[i] = &v3.Row{Data: map[string]interface{}{"trace_id": journey.TraceID}}
+	}
+
+	aH.Respond(w, rows)
+}
+
 func (aH *APIHandler) handleStepAnalytics(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	funnelID := vars["funnel_id"]
@@ -4532,9 +4681,14 @@ func (aH *APIHandler) handleStepAnalytics(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelStepCounts(w, r, funnel, timeRange)
+		return
+	}
+
 	chq, err := traceFunnelsModule.GetStepAnalytics(funnel, timeRange)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4569,9 +4723,14 @@ func (aH *APIHandler) handleFunnelSlowTraces(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelSlowestJourneys(w, r, funnel, req.TimeRange, req.StepStart, req.StepEnd, false)
+		return
+	}
+
 	chq, err := traceFunnelsModule.GetSlowestTraces(funnel, req.TimeRange, req.StepStart, req.StepEnd)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4606,9 +4765,14 @@ func (aH *APIHandler) handleFunnelErrorTraces(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelSlowestJourneys(w, r, funnel, req.TimeRange, req.StepStart, req.StepEnd, true)
+		return
+	}
+
 	chq, err := traceFunnelsModule.GetErroredTraces(funnel, req.TimeRange, req.StepStart, req.StepEnd)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4634,15 +4798,19 @@ func (aH *APIHandler) handleValidateTracesWithPayload(w http.ResponseWriter, r *
 
 	// Create a StorableFunnel from the request
 	funnel := &traceFunnels.StorableFunnel{
-		Steps: req.Steps,
+		Steps:        req.Steps,
+		FunnelSource: req.FunnelSource,
 	}
 
-	chq, err := traceFunnelsModule.ValidateTraces(funnel, traceFunnels.TimeRange{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-	})
+	timeRange := traceFunnels.TimeRange{StartTime: req.StartTime, EndTime: req.EndTime}
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelValidation(w, r, funnel, timeRange)
+		return
+	}
+
+	chq, err := traceFunnelsModule.ValidateTraces(funnel, timeRange)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4662,15 +4830,19 @@ func (aH *APIHandler) handleFunnelAnalyticsWithPayload(w http.ResponseWriter, r
 	}
 
 	funnel := &traceFunnels.StorableFunnel{
-		Steps: req.Steps,
+		Steps:        req.Steps,
+		FunnelSource: req.FunnelSource,
 	}
 
-	chq, err := traceFunnelsModule.GetFunnelAnalytics(funnel, traceFunnels.TimeRange{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-	})
+	timeRange := traceFunnels.TimeRange{StartTime: req.StartTime, EndTime: req.EndTime}
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelTransition(w, r, funnel, timeRange, 1, int64(len(funnel.Steps)))
+		return
+	}
+
+	chq, err := traceFunnelsModule.GetFunnelAnalytics(funnel, timeRange)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4690,15 +4862,19 @@ func (aH *APIHandler) handleStepAnalyticsWithPayload(w http.ResponseWriter, r *h
 	}
 
 	funnel := &traceFunnels.StorableFunnel{
-		Steps: req.Steps,
+		Steps:        req.Steps,
+		FunnelSource: req.FunnelSource,
 	}
 
-	chq, err := traceFunnelsModule.GetStepAnalytics(funnel, traceFunnels.TimeRange{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-	})
+	timeRange := traceFunnels.TimeRange{StartTime: req.StartTime, EndTime: req.EndTime}
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelStepCounts(w, r, funnel, timeRange)
+		return
+	}
+
+	chq, err := traceFunnelsModule.GetStepAnalytics(funnel, timeRange)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4718,15 +4894,19 @@ func (aH *APIHandler) handleFunnelStepAnalyticsWithPayload(w http.ResponseWriter
 	}
 
 	funnel := &traceFunnels.StorableFunnel{
-		Steps: req.Steps,
+		Steps:        req.Steps,
+		FunnelSource: req.FunnelSource,
+	}
+
+	timeRange := traceFunnels.TimeRange{StartTime: req.StartTime, EndTime: req.EndTime}
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelTransition(w, r, funnel, timeRange, req.StepStart, req.StepEnd)
+		return
 	}
 
-	chq, err := traceFunnelsModule.GetFunnelStepAnalytics(funnel, traceFunnels.TimeRange{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-	}, req.StepStart, req.StepEnd)
+	chq, err := traceFunnelsModule.GetFunnelStepAnalytics(funnel, timeRange, req.StepStart, req.StepEnd)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4746,15 +4926,19 @@ func (aH *APIHandler) handleFunnelSlowTracesWithPayload(w http.ResponseWriter, r
 	}
 
 	funnel := &traceFunnels.StorableFunnel{
-		Steps: req.Steps,
+		Steps:        req.Steps,
+		FunnelSource: req.FunnelSource,
 	}
 
-	chq, err := traceFunnelsModule.GetSlowestTraces(funnel, traceFunnels.TimeRange{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-	}, req.StepStart, req.StepEnd)
+	timeRange := traceFunnels.TimeRange{StartTime: req.StartTime, EndTime: req.EndTime}
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelSlowestJourneys(w, r, funnel, timeRange, req.StepStart, req.StepEnd, false)
+		return
+	}
+
+	chq, err := traceFunnelsModule.GetSlowestTraces(funnel, timeRange, req.StepStart, req.StepEnd)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
@@ -4774,15 +4958,19 @@ func (aH *APIHandler) handleFunnelErrorTracesWithPayload(w http.ResponseWriter,
 	}
 
 	funnel := &traceFunnels.StorableFunnel{
-		Steps: req.Steps,
+		Steps:        req.Steps,
+		FunnelSource: req.FunnelSource,
+	}
+
+	timeRange := traceFunnels.TimeRange{StartTime: req.StartTime, EndTime: req.EndTime}
+	if !traceFunnelsModule.IsLegacyFunnel(funnel) {
+		aH.respondFunnelSlowestJourneys(w, r, funnel, timeRange, req.StepStart, req.StepEnd, true)
+		return
 	}
 
-	chq, err := traceFunnelsModule.GetErroredTraces(funnel, traceFunnels.TimeRange{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-	}, req.StepStart, req.StepEnd)
+	chq, err := traceFunnelsModule.GetErroredTraces(funnel, timeRange, req.StepStart, req.StepEnd)
 	if err != nil {
-		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: fmt.Errorf("error building clickhouse query: %v", err)}, nil)
+		render.Error(w, err)
 		return
 	}
 
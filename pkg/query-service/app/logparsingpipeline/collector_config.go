@@ -2,12 +2,14 @@ package logparsingpipeline
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"sync"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig/filterprocessor"
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
 )
@@ -23,6 +25,9 @@ var (
 	CodeCollectorConfigLogsPipelineNotFound   = errors.MustNewCode("collector_config_logs_pipeline_not_found")
 )
 
+// dropProcessor drops the logs marked for dropping by the drop operators.
+const dropProcessor = "filter/signoz_logs_pipelines_drop"
+
 const (
 	memoryLimiterProcessor       = "memory_limiter"
 	memoryLimiterProcessorPrefix = "memory_limiter/"
@@ -136,6 +141,12 @@ func buildCollectorPipelineProcessorsList(
 }
 
 func GenerateCollectorConfigWithPipelines(config []byte, pipelines []pipelinetypes.GettablePipeline) ([]byte, error) {
+	return generateCollectorConfigWithPipelines(config, pipelines, true)
+}
+
+// generateCollectorConfigWithPipelines adds the processors for pipelines to the collector config,
+// followed by the processor dropping the logs marked by them if withDropProcessor is set.
+func generateCollectorConfigWithPipelines(config []byte, pipelines []pipelinetypes.GettablePipeline, withDropProcessor bool) ([]byte, error) {
 	var collectorConf map[string]interface{}
 	err := yaml.Unmarshal([]byte(config), &collectorConf)
 	if err != nil {
@@ -168,6 +179,16 @@ func GenerateCollectorConfigWithPipelines(config []byte, pipelines []pipelinetyp
 		signozPipelineProcessors[procName] = escapedConf
 	}
 
+	if withDropProcessor && pipelinesDropLogs(pipelines) {
+		signozPipelineProcessors[dropProcessor] = filterprocessor.Config{
+			ErrorMode: "ignore",
+			Logs: filterprocessor.LogFilters{
+				LogConditions: []string{fmt.Sprintf(`attributes["%s"] != nil`, pipelinetypes.DropMarkerAttribute)},
+			},
+		}
+		signozPipelineProcNames = append(signozPipelineProcNames, dropProcessor)
+	}
+
 	// Add processors to unmarshaled collector config `c`
 	updateProcessorConfigsInCollectorConf(collectorConf, signozPipelineProcessors)
 
@@ -195,5 +216,5 @@ func GenerateCollectorConfigWithPipelines(config []byte, pipelines []pipelinetyp
 }
 
 func hasSignozPipelineProcessorPrefix(procName string) bool {
-	return strings.HasPrefix(procName, constants.LogsPPLPfx) || strings.HasPrefix(procName, constants.OldLogsPPLPfx)
+	return strings.HasPrefix(procName, constants.LogsPPLPfx) || strings.HasPrefix(procName, constants.OldLogsPPLPfx) || strings.HasPrefix(procName, constants.LogsPPLTransformPfx) || procName == dropProcessor
 }
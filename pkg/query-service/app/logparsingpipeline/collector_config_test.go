@@ -2,15 +2,23 @@ package logparsingpipeline
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/SigNoz/signoz-otel-collector/processor/signozlogspipelineprocessor"
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
 	"github.com/SigNoz/signoz/pkg/query-service/model"
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logstransformprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/processor"
 	"gopkg.in/yaml.v3"
 )
 
@@ -437,3 +445,195 @@ func TestPipeCharInAliasDoesntBreakCollectorConfig(t *testing.T) {
 		}, result[0].Attributes_string,
 	)
 }
+
+func TestDropProcessorFollowsPipelinesDroppingLogs(t *testing.T) {
+	require := require.New(t)
+
+	baseConf := []byte(`
+        receivers:
+          memory:
+            id: in-memory-receiver
+        exporters:
+          memory:
+            id: in-memory-exporter
+        service:
+          pipelines:
+            logs:
+              receivers:
+                - memory
+              processors:
+                - batch
+              exporters:
+                - memory
+      `)
+
+	testPipelines := makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "drop",
+			"name": "Test drop",
+			"id": "test-drop",
+			"filter": {
+				"op": "AND",
+				"items": [
+					{
+						"key": {"key": "level", "dataType": "string", "type": "tag"},
+						"op": "=",
+						"value": "debug"
+					}
+				]
+			}
+		}
+	`)
+
+	logsProcessors := func(confYaml []byte) []any {
+		var conf map[string]interface{}
+		require.Nil(yaml.Unmarshal(confYaml, &conf), "couldn't unmarshal recommended config")
+		return conf["service"].(map[string]any)["pipelines"].(map[string]any)["logs"].(map[string]any)["processors"].([]any)
+	}
+
+	recommendedConfYaml, err := GenerateCollectorConfigWithPipelines(baseConf, testPipelines)
+	require.Nil(err)
+	require.Equal(
+		[]any{CollectorConfProcessorName(testPipelines[0]), dropProcessor, "batch"},
+		logsProcessors(recommendedConfYaml),
+	)
+	require.Contains(string(recommendedConfYaml), `attributes["__signoz_drop__"] != nil`)
+
+	// the drop processor is removed along with the last operator dropping logs.
+	testPipelines[0].Config[0].Enabled = false
+	recommendedConfYaml, err = GenerateCollectorConfigWithPipelines(recommendedConfYaml, testPipelines)
+	require.Nil(err)
+	require.Equal([]any{"batch"}, logsProcessors(recommendedConfYaml))
+	require.NotContains(string(recommendedConfYaml), dropProcessor)
+}
+
+func TestGeneratedProcessorsUseCollectorOperators(t *testing.T) {
+	require := require.New(t)
+
+	baseConf := []byte(`
+        receivers:
+          memory:
+            id: in-memory-receiver
+        exporters:
+          memory:
+            id: in-memory-exporter
+        service:
+          pipelines:
+            logs:
+              receivers:
+                - memory
+              processors:
+                - batch
+              exporters:
+                - memory
+      `)
+
+	testPipelines := makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "kv_parser",
+			"name": "Test kv parser",
+			"id": "test-kv-parser",
+			"parse_from": "body",
+			"parse_to": "attributes"
+		}
+	`)
+	for _, operatorJSON := range []string{`
+		{
+			"orderId": 2,
+			"enabled": true,
+			"type": "sample",
+			"name": "Test sample",
+			"id": "test-sample",
+			"field": "attributes.user_id",
+			"ratio": 0.5
+		}`, `
+		{
+			"orderId": 3,
+			"enabled": true,
+			"type": "redact",
+			"name": "Test redact",
+			"id": "test-redact",
+			"field": "attributes.user",
+			"patterns": ["email", "ssn"],
+			"action": "mask"
+		}`, `
+		{
+			"orderId": 4,
+			"enabled": true,
+			"type": "drop",
+			"name": "Test drop",
+			"id": "test-drop",
+			"filter": {
+				"op": "AND",
+				"items": [
+					{
+						"key": {"key": "level", "dataType": "string", "type": "tag"},
+						"op": "=",
+						"value": "debug"
+					}
+				]
+			}
+		}`, `
+		{
+			"orderId": 5,
+			"enabled": true,
+			"type": "lookup",
+			"name": "Test lookup",
+			"id": "test-lookup",
+			"field": "attributes.customer_id",
+			"lookup_table": "tenants"
+		}`,
+	} {
+		var operator pipelinetypes.PipelineOperator
+		require.Nil(json.Unmarshal([]byte(operatorJSON), &operator))
+		testPipelines[0].Config = append(testPipelines[0].Config, operator)
+	}
+	testPipelines[0].Config[len(testPipelines[0].Config)-1].Entries = map[string]map[string]string{
+		"c-1": {"tenant": "Acme"},
+	}
+
+	recommendedConfYaml, err := GenerateCollectorConfigWithPipelines(baseConf, testPipelines)
+	require.Nil(err)
+
+	var conf struct {
+		Processors map[string]map[string]any `yaml:"processors"`
+		Service    struct {
+			Pipelines struct {
+				Logs struct {
+					Processors []string `yaml:"processors"`
+				} `yaml:"logs"`
+			} `yaml:"pipelines"`
+		} `yaml:"service"`
+	}
+	require.Nil(yaml.Unmarshal(recommendedConfYaml, &conf))
+
+	name := CollectorConfProcessorName(testPipelines[0])
+	require.Equal([]string{
+		name,
+		constants.OldLogsPPLPfx + "pipeline1-1",
+		constants.LogsPPLTransformPfx + "pipeline1-2",
+		name + "-3",
+		dropProcessor,
+		"batch",
+	}, conf.Service.Pipelines.Logs.Processors)
+
+	// every pipeline processor is decoded by the collector component of its type,
+	// which fails for operators missing from the registry of the component.
+	factories := map[string]processor.Factory{
+		"signozlogspipeline": signozlogspipelineprocessor.NewFactory(),
+		"logstransform":      logstransformprocessor.NewFactory(),
+		"transform":          transformprocessor.NewFactory(),
+	}
+	for _, processorName := range conf.Service.Pipelines.Logs.Processors[:4] {
+		factory, ok := factories[strings.Split(processorName, "/")[0]]
+		require.True(ok, processorName)
+
+		processorConf := factory.CreateDefaultConfig()
+		require.Nil(confmap.NewFromStringMap(conf.Processors[processorName]).Unmarshal(processorConf), processorName)
+		require.Nil(xconfmap.Validate(processorConf), processorName)
+	}
+}
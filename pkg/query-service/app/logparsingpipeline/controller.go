@@ -12,6 +12,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
 	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
@@ -21,6 +22,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/featuretypes"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
 	"github.com/SigNoz/signoz/pkg/types/opamptypes"
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
@@ -37,22 +39,30 @@ type LogParsingPipelineController struct {
 	Repo
 
 	GetIntegrationPipelines func(context.Context, string) ([]pipelinetypes.GettablePipeline, error)
+	// GetLookupTableEntries returns the entries of the lookup tables of the org
+	// by name, for the lookup operators referencing them.
+	GetLookupTableEntries func(context.Context, valuer.UUID) (map[string]lookuptabletypes.Entries, error)
 	// TODO(Piyush): remove with qbv5 migration
-	reader interfaces.Reader
-	fl     flagger.Flagger
+	reader  interfaces.Reader
+	querier querier.Querier
+	fl      flagger.Flagger
 }
 
 func NewLogParsingPipelinesController(
 	sqlStore sqlstore.SQLStore,
 	getIntegrationPipelines func(context.Context, string) ([]pipelinetypes.GettablePipeline, error),
+	getLookupTableEntries func(context.Context, valuer.UUID) (map[string]lookuptabletypes.Entries, error),
 	reader interfaces.Reader,
+	querier querier.Querier,
 	fl flagger.Flagger,
 ) (*LogParsingPipelineController, error) {
 	repo := NewRepo(sqlStore)
 	return &LogParsingPipelineController{
 		Repo:                    repo,
 		GetIntegrationPipelines: getIntegrationPipelines,
+		GetLookupTableEntries:   getLookupTableEntries,
 		reader:                  reader,
+		querier:                 querier,
 		fl:                      fl,
 	}, nil
 }
@@ -135,6 +145,49 @@ func (pc *LogParsingPipelineController) enrichPipelinesFilters(
 	return pipelines, nil
 }
 
+// resolveLookupTables sets the entries of the tables referenced by the lookup
+// operators of the pipelines, at the versions rolled out by the lookup tables.
+// Operators referencing a table which doesn't exist get no entries and leave
+// logs as is.
+func (pc *LogParsingPipelineController) resolveLookupTables(
+	ctx context.Context, orgID valuer.UUID, pipelines []pipelinetypes.GettablePipeline,
+) ([]pipelinetypes.GettablePipeline, error) {
+	hasLookup := slices.ContainsFunc(pipelines, func(p pipelinetypes.GettablePipeline) bool {
+		return slices.ContainsFunc(p.Config, func(op pipelinetypes.PipelineOperator) bool {
+			return op.Type == "lookup"
+		})
+	})
+	if !hasLookup || pc.GetLookupTableEntries == nil {
+		return pipelines, nil
+	}
+
+	entries, err := pc.GetLookupTableEntries(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]pipelinetypes.GettablePipeline, 0, len(pipelines))
+	for _, p := range pipelines {
+		// operators are copied so that the entries don't end up in the
+		// pipelines of the caller.
+		p.Config = slices.Clone(p.Config)
+		for idx := range p.Config {
+			if p.Config[idx].Type != "lookup" {
+				continue
+			}
+
+			tableEntries, ok := entries[p.Config[idx].LookupTable]
+			if !ok {
+				slog.WarnContext(ctx, "lookup table referenced by pipeline not found", "pipeline", p.Name, "lookup_table", p.Config[idx].LookupTable)
+			}
+			p.Config[idx].Entries = tableEntries
+		}
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
 // PipelinesResponse is used to prepare http response for pipelines config related requests
 type PipelinesResponse struct {
 	*opamptypes.AgentConfigVersion
@@ -342,6 +395,7 @@ type PipelinesPreviewResponse struct {
 
 func (ic *LogParsingPipelineController) PreviewLogsPipelines(
 	ctx context.Context,
+	orgID valuer.UUID,
 	request *PipelinesPreviewRequest,
 ) (*PipelinesPreviewResponse, error) {
 	pipelines, err := ic.enrichPipelinesFilters(ctx, request.Pipelines)
@@ -349,6 +403,11 @@ func (ic *LogParsingPipelineController) PreviewLogsPipelines(
 		return nil, err
 	}
 
+	pipelines, err = ic.resolveLookupTables(ctx, orgID, pipelines)
+	if err != nil {
+		return nil, err
+	}
+
 	result, collectorLogs, err := SimulatePipelinesProcessing(ctx, pipelines, request.Logs)
 	if err != nil {
 		return nil, err
@@ -402,6 +461,11 @@ func (pc *LogParsingPipelineController) RecommendAgentConfig(
 		return nil, "", err
 	}
 
+	enrichedPipelines, err = pc.resolveLookupTables(ctx, orgId, enrichedPipelines)
+	if err != nil {
+		return nil, "", err
+	}
+
 	// TODO(Tushar): thread orgID here to evaluate correctly
 	if pc.fl.BooleanOrEmpty(ctx, flagger.FeatureUseJSONBody, featuretypes.NewFlaggerEvaluationContext(orgId)) {
 		// add default normalize pipeline at the beginning, only for sending to collector
@@ -0,0 +1,507 @@
+package logparsingpipeline
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	DefaultDryRunLogsLimit = 20
+	MaxDryRunLogsLimit     = 100
+
+	// dryRunMatchAttribute is added by the operator probing which logs match
+	// the filter of a pipeline.
+	dryRunMatchAttribute = "__signoz_dry_run_match__"
+)
+
+var (
+	CodeInvalidDryRunRequest = errors.MustNewCode("invalid_pipelines_dry_run_request")
+)
+
+// collectorLogEntry matches the first line of the entries logged by the
+// collector, the lines following it hold the stack trace of the entry.
+var collectorLogEntry = regexp.MustCompile(`^\S+\t(warn|error)\t`)
+
+type PipelinesDryRunRequest struct {
+	Pipelines []pipelinetypes.GettablePipeline `json:"pipelines"`
+	// Filter selects the stored logs to run the pipelines on.
+	Filter *qbtypes.Filter `json:"filter"`
+	// Start and End are epoch milliseconds.
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+	// Limit is the number of logs to run the pipelines on, 0 uses DefaultDryRunLogsLimit.
+	Limit int `json:"limit"`
+}
+
+func (request *PipelinesDryRunRequest) Validate() error {
+	if request.Start == 0 || request.End == 0 {
+		return errors.NewInvalidInputf(CodeInvalidDryRunRequest, "start and end are required")
+	}
+
+	if request.Start >= request.End {
+		return errors.NewInvalidInputf(CodeInvalidDryRunRequest, "start must be before end")
+	}
+
+	if request.Limit < 0 || request.Limit > MaxDryRunLogsLimit {
+		return errors.NewInvalidInputf(CodeInvalidDryRunRequest, "limit must be between 0 and %d, where 0 uses the default of %d", MaxDryRunLogsLimit, DefaultDryRunLogsLimit)
+	}
+
+	return nil
+}
+
+type PipelinesDryRunResponse struct {
+	Logs          []DryRunLog           `json:"logs"`
+	Pipelines     []DryRunPipelineStats `json:"pipelines"`
+	CollectorLogs []string              `json:"collectorLogs"`
+}
+
+type DryRunLog struct {
+	Input model.SignozLog `json:"input"`
+	// Output is nil when the log was dropped.
+	Output  *model.SignozLog `json:"output"`
+	Dropped bool             `json:"dropped"`
+	// Operators lists the operators that changed or dropped the log, in the
+	// order they ran.
+	Operators []DryRunOperatorDiff `json:"operators"`
+}
+
+type DryRunOperatorDiff struct {
+	PipelineID string              `json:"pipelineId"`
+	OperatorID string              `json:"operatorId"`
+	Changes    []DryRunFieldChange `json:"changes"`
+	Dropped    bool                `json:"dropped"`
+}
+
+// DryRunFieldChange is the change of a field of a log by an operator. Before
+// is nil for added fields and After is nil for removed ones.
+type DryRunFieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type DryRunPipelineStats struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// TotalLogs is the number of logs reaching the pipeline, i.e. not dropped
+	// by a pipeline before it.
+	TotalLogs   int                   `json:"totalLogs"`
+	MatchedLogs int                   `json:"matchedLogs"`
+	MatchRate   float64               `json:"matchRate"`
+	Operators   []DryRunOperatorStats `json:"operators"`
+}
+
+type DryRunOperatorStats struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	ChangedLogs int    `json:"changedLogs"`
+	DroppedLogs int    `json:"droppedLogs"`
+	// Errors is the number of errors and warnings the collector logged while
+	// running the operator on the logs.
+	Errors int `json:"errors"`
+}
+
+// DryRunLogsPipelines runs draft pipelines on a sample of stored logs and
+// reports what each operator did to each log.
+func (ic *LogParsingPipelineController) DryRunLogsPipelines(
+	ctx context.Context,
+	orgID valuer.UUID,
+	request *PipelinesDryRunRequest,
+) (*PipelinesDryRunResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	pipelines, err := ic.enrichPipelinesFilters(ctx, request.Pipelines)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines, err = ic.resolveLookupTables(ctx, orgID, pipelines)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := ic.getDryRunLogs(ctx, orgID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return DryRunPipelinesProcessing(ctx, pipelines, logs)
+}
+
+func (ic *LogParsingPipelineController) getDryRunLogs(
+	ctx context.Context, orgID valuer.UUID, request *PipelinesDryRunRequest,
+) ([]model.SignozLog, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "logparsingpipeline",
+		instrumentationtypes.CodeFunctionName: "getDryRunLogs",
+	})
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = DefaultDryRunLogsLimit
+	}
+
+	response, err := ic.querier.QueryRange(ctx, orgID, newDryRunQueryRangeRequest(request.Filter, request.Start, request.End, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []model.SignozLog{}
+	for _, result := range response.Data.Results {
+		rawData, ok := result.(*qbtypes.RawData)
+		if !ok {
+			return nil, errors.NewInternalf(errors.CodeInternal, "expected RawData, got %T", result)
+		}
+
+		for _, row := range rawData.Rows {
+			logs = append(logs, signozLogFromRawRow(row))
+		}
+	}
+
+	return logs, nil
+}
+
+func newDryRunQueryRangeRequest(filter *qbtypes.Filter, start uint64, end uint64, limit int) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:       start,
+		End:         end,
+		RequestType: qbtypes.RequestTypeRaw,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{{
+				Type: qbtypes.QueryTypeBuilder,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+					Name:   "A",
+					Signal: telemetrytypes.SignalLogs,
+					Filter: filter,
+					Order:  qbtypes.LogsListOrder(qbtypes.OrderDirectionDesc),
+					Limit:  limit,
+				},
+			}},
+		},
+	}
+}
+
+// signozLogFromRawRow reads a log selected with the default columns of the
+// logs table.
+func signozLogFromRawRow(row *qbtypes.RawRow) model.SignozLog {
+	log := model.SignozLog{
+		Timestamp:          uint64(row.Timestamp.UnixNano()),
+		ID:                 rawRowString(row.Data, "id"),
+		TraceID:            rawRowString(row.Data, "trace_id"),
+		SpanID:             rawRowString(row.Data, "span_id"),
+		SeverityText:       rawRowString(row.Data, "severity_text"),
+		Body:               rawRowString(row.Data, "body"),
+		Resources_string:   map[string]string{},
+		Attributes_string:  map[string]string{},
+		Attributes_int64:   map[string]int64{},
+		Attributes_float64: map[string]float64{},
+		Attributes_bool:    map[string]bool{},
+	}
+
+	if traceFlags, ok := row.Data["trace_flags"].(uint32); ok {
+		log.TraceFlags = traceFlags
+	}
+	if severityNumber, ok := row.Data["severity_number"].(uint8); ok {
+		log.SeverityNumber = severityNumber
+	}
+
+	if resources, ok := row.Data["resources_string"].(map[string]string); ok {
+		maps.Copy(log.Resources_string, resources)
+	}
+	if attributes, ok := row.Data["attributes_string"].(map[string]string); ok {
+		maps.Copy(log.Attributes_string, attributes)
+	}
+	if attributes, ok := row.Data["attributes_number"].(map[string]float64); ok {
+		maps.Copy(log.Attributes_float64, attributes)
+	}
+	if attributes, ok := row.Data["attributes_bool"].(map[string]bool); ok {
+		maps.Copy(log.Attributes_bool, attributes)
+	}
+
+	return log
+}
+
+func rawRowString(data map[string]any, key string) string {
+	switch value := data[key].(type) {
+	case string:
+		return value
+	case *string:
+		if value != nil {
+			return *value
+		}
+	case nil:
+	default:
+		return fmt.Sprint(value)
+	}
+
+	return ""
+}
+
+// DryRunPipelinesProcessing simulates the pipelines one operator at a time to
+// tell what each operator did to each log. Every enabled operator adds a
+// simulation of the pipelines up to it, and every enabled pipeline one more
+// to find the logs matching its filter, so this is meant for small samples.
+// The errors of an operator are the collector log entries its simulation has
+// on top of the previous one, which ran the same operators but it.
+func DryRunPipelinesProcessing(
+	ctx context.Context, pipelines []pipelinetypes.GettablePipeline, logs []model.SignozLog,
+) (*PipelinesDryRunResponse, error) {
+	response := &PipelinesDryRunResponse{
+		Logs:          make([]DryRunLog, len(logs)),
+		Pipelines:     []DryRunPipelineStats{},
+		CollectorLogs: []string{},
+	}
+	for idx, log := range logs {
+		response.Logs[idx] = DryRunLog{Input: log, Operators: []DryRunOperatorDiff{}}
+	}
+
+	// state holds the logs, by ID, as they were after the last simulated
+	// operator. Logs dropped along the way are missing from it.
+	var state map[string]model.SignozLog
+	collectorErrors := 0
+
+	for pipelineIdx, pipeline := range pipelines {
+		if !pipeline.Enabled || !slices.ContainsFunc(pipeline.Config, func(operator pipelinetypes.PipelineOperator) bool {
+			return operator.Enabled
+		}) {
+			continue
+		}
+
+		output, collectorLogs, err := SimulatePipelinesProcessing(ctx, dryRunMatchPipelines(pipelines, pipelineIdx), cloneSignozLogs(logs))
+		if err != nil {
+			return nil, err
+		}
+		response.CollectorLogs = collectorLogs
+		collectorErrors = countCollectorLogEntries(collectorLogs)
+
+		state = signozLogsByID(output)
+		pipelineStats := DryRunPipelineStats{
+			ID:        pipeline.ID.StringValue(),
+			Name:      pipeline.Name,
+			TotalLogs: len(state),
+			Operators: []DryRunOperatorStats{},
+		}
+		for id, log := range state {
+			if _, matched := log.Attributes_string[dryRunMatchAttribute]; matched {
+				pipelineStats.MatchedLogs++
+				delete(log.Attributes_string, dryRunMatchAttribute)
+				state[id] = log
+			}
+		}
+		if pipelineStats.TotalLogs > 0 {
+			pipelineStats.MatchRate = float64(pipelineStats.MatchedLogs) / float64(pipelineStats.TotalLogs)
+		}
+
+		for operatorIdx, operator := range pipeline.Config {
+			if !operator.Enabled {
+				continue
+			}
+
+			output, collectorLogs, err := SimulatePipelinesProcessing(ctx, dryRunPipelinesUpTo(pipelines, pipelineIdx, operatorIdx), cloneSignozLogs(logs))
+			if err != nil {
+				return nil, err
+			}
+			response.CollectorLogs = collectorLogs
+
+			operatorErrors := countCollectorLogEntries(collectorLogs)
+			operatorStats := DryRunOperatorStats{
+				ID:     operator.ID,
+				Name:   operator.Name,
+				Type:   operator.Type,
+				Errors: max(0, operatorErrors-collectorErrors),
+			}
+			collectorErrors = operatorErrors
+
+			nextState := signozLogsByID(output)
+			for idx, log := range logs {
+				before, exists := state[log.ID]
+				if !exists {
+					continue
+				}
+
+				diff := DryRunOperatorDiff{
+					PipelineID: pipeline.ID.StringValue(),
+					OperatorID: operator.ID,
+					Changes:    []DryRunFieldChange{},
+				}
+				if after, exists := nextState[log.ID]; exists {
+					diff.Changes = diffSignozLogs(before, after)
+					if len(diff.Changes) == 0 {
+						continue
+					}
+					operatorStats.ChangedLogs++
+				} else {
+					diff.Dropped = true
+					operatorStats.DroppedLogs++
+				}
+
+				response.Logs[idx].Operators = append(response.Logs[idx].Operators, diff)
+			}
+
+			state = nextState
+			pipelineStats.Operators = append(pipelineStats.Operators, operatorStats)
+		}
+
+		response.Pipelines = append(response.Pipelines, pipelineStats)
+	}
+
+	for idx, log := range logs {
+		if state == nil {
+			output := log
+			response.Logs[idx].Output = &output
+			continue
+		}
+
+		if output, exists := state[log.ID]; exists {
+			response.Logs[idx].Output = &output
+		} else {
+			response.Logs[idx].Dropped = true
+		}
+	}
+
+	return response, nil
+}
+
+// dryRunMatchPipelines returns the pipelines up to the pipeline at pipelineIdx,
+// with the operators of that pipeline replaced by one marking the logs its
+// filter matches.
+func dryRunMatchPipelines(pipelines []pipelinetypes.GettablePipeline, pipelineIdx int) []pipelinetypes.GettablePipeline {
+	result := slices.Clone(pipelines[:pipelineIdx+1])
+	result[pipelineIdx].Config = []pipelinetypes.PipelineOperator{
+		{
+			OrderId: 1,
+			ID:      "dry-run-match",
+			Type:    "add",
+			Field:   fmt.Sprintf("attributes.%s", dryRunMatchAttribute),
+			Value:   "true",
+			Enabled: true,
+		},
+	}
+
+	return result
+}
+
+// dryRunPipelinesUpTo returns the pipelines up to the operator at operatorIdx
+// of the pipeline at pipelineIdx.
+func dryRunPipelinesUpTo(pipelines []pipelinetypes.GettablePipeline, pipelineIdx int, operatorIdx int) []pipelinetypes.GettablePipeline {
+	result := slices.Clone(pipelines[:pipelineIdx+1])
+	result[pipelineIdx].Config = slices.Clone(result[pipelineIdx].Config)
+	for idx := operatorIdx + 1; idx < len(result[pipelineIdx].Config); idx++ {
+		result[pipelineIdx].Config[idx].Enabled = false
+	}
+
+	return result
+}
+
+// cloneSignozLogs copies logs before simulating, which writes to their
+// attribute maps.
+func cloneSignozLogs(logs []model.SignozLog) []model.SignozLog {
+	result := make([]model.SignozLog, 0, len(logs))
+	for _, log := range logs {
+		log.Resources_string = maps.Clone(log.Resources_string)
+		log.Attributes_string = maps.Clone(log.Attributes_string)
+		log.Attributes_int64 = maps.Clone(log.Attributes_int64)
+		log.Attributes_float64 = maps.Clone(log.Attributes_float64)
+		log.Attributes_bool = maps.Clone(log.Attributes_bool)
+		result = append(result, log)
+	}
+
+	return result
+}
+
+// countCollectorLogEntries counts the entries of the collector logs, without
+// the lines of their stack traces.
+func countCollectorLogEntries(collectorLogs []string) int {
+	count := 0
+	for _, line := range collectorLogs {
+		if collectorLogEntry.MatchString(line) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func signozLogsByID(logs []model.SignozLog) map[string]model.SignozLog {
+	result := make(map[string]model.SignozLog, len(logs))
+	for _, log := range logs {
+		result[log.ID] = log
+	}
+
+	return result
+}
+
+// diffSignozLogs lists the fields pipelines can change that differ between
+// the logs, sorted by field.
+func diffSignozLogs(before model.SignozLog, after model.SignozLog) []DryRunFieldChange {
+	changes := []DryRunFieldChange{}
+	if before.Body != after.Body {
+		changes = append(changes, DryRunFieldChange{Field: "body", Before: before.Body, After: after.Body})
+	}
+	if before.SeverityText != after.SeverityText {
+		changes = append(changes, DryRunFieldChange{Field: "severity_text", Before: before.SeverityText, After: after.SeverityText})
+	}
+	if before.SeverityNumber != after.SeverityNumber {
+		changes = append(changes, DryRunFieldChange{Field: "severity_number", Before: before.SeverityNumber, After: after.SeverityNumber})
+	}
+
+	fieldsBefore, fieldsAfter := signozLogFields(before), signozLogFields(after)
+	for field, valueBefore := range fieldsBefore {
+		valueAfter, exists := fieldsAfter[field]
+		if !exists {
+			changes = append(changes, DryRunFieldChange{Field: field, Before: valueBefore})
+		} else if valueAfter != valueBefore {
+			changes = append(changes, DryRunFieldChange{Field: field, Before: valueBefore, After: valueAfter})
+		}
+	}
+	for field, valueAfter := range fieldsAfter {
+		if _, exists := fieldsBefore[field]; !exists {
+			changes = append(changes, DryRunFieldChange{Field: field, After: valueAfter})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b DryRunFieldChange) int {
+		return cmp.Compare(a.Field, b.Field)
+	})
+
+	return changes
+}
+
+// signozLogFields flattens the attributes and resources of a log, keyed the
+// way pipeline operators address them.
+func signozLogFields(log model.SignozLog) map[string]any {
+	fields := map[string]any{}
+	for key, value := range log.Resources_string {
+		fields["resource."+key] = value
+	}
+	for key, value := range log.Attributes_string {
+		fields["attributes."+key] = value
+	}
+	for key, value := range log.Attributes_int64 {
+		fields["attributes."+key] = value
+	}
+	for key, value := range log.Attributes_float64 {
+		fields["attributes."+key] = value
+	}
+	for key, value := range log.Attributes_bool {
+		fields["attributes."+key] = value
+	}
+
+	return fields
+}
@@ -0,0 +1,224 @@
+package logparsingpipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunPipelinesProcessing(t *testing.T) {
+	require := require.New(t)
+
+	methodFilter := func(method string) *v3.FilterSet {
+		return &v3.FilterSet{
+			Operator: "AND",
+			Items: []v3.FilterItem{
+				{
+					Key: v3.AttributeKey{
+						Key:      "method",
+						DataType: v3.AttributeKeyDataTypeString,
+						Type:     v3.AttributeKeyTypeTag,
+					},
+					Operator: "=",
+					Value:    method,
+				},
+			},
+		}
+	}
+
+	testPipelines := []pipelinetypes.GettablePipeline{
+		{
+			StoreablePipeline: pipelinetypes.StoreablePipeline{
+				Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+				OrderID:      1,
+				Name:         "pipeline1",
+				Alias:        "pipeline1",
+				Enabled:      true,
+			},
+			Filter: methodFilter("GET"),
+			Config: []pipelinetypes.PipelineOperator{
+				{
+					OrderId: 1,
+					ID:      "add",
+					Type:    "add",
+					Field:   "attributes.test",
+					Value:   "val",
+					Enabled: true,
+					Name:    "test add",
+				}, {
+					OrderId: 2,
+					ID:      "disabled",
+					Type:    "add",
+					Field:   "attributes.disabled",
+					Value:   "val",
+					Enabled: false,
+					Name:    "test disabled add",
+				}, {
+					OrderId: 3,
+					ID:      "move",
+					Type:    "move",
+					From:    "attributes.test",
+					To:      "resource.test",
+					Enabled: true,
+					Name:    "test move",
+				}, {
+					OrderId: 4,
+					ID:      "failing",
+					Type:    "add",
+					Field:   "attributes.failing",
+					Value:   "EXPR(attributes.method + 1)",
+					Enabled: true,
+					Name:    "test failing add",
+				},
+			},
+		},
+		{
+			StoreablePipeline: pipelinetypes.StoreablePipeline{
+				Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+				OrderID:      2,
+				Name:         "pipeline2",
+				Alias:        "pipeline2",
+				Enabled:      true,
+			},
+			Filter: methodFilter("POST"),
+			Config: []pipelinetypes.PipelineOperator{
+				{
+					OrderId: 1,
+					ID:      "drop",
+					Type:    "drop",
+					Filter:  methodFilter("POST"),
+					Enabled: true,
+					Name:    "test drop",
+				},
+			},
+		},
+	}
+
+	getLog := makeTestSignozLog("GET /api", map[string]interface{}{"method": "GET"})
+	getLog.ID = "get"
+	postLog := makeTestSignozLog("POST /api", map[string]interface{}{"method": "POST"})
+	postLog.ID = "post"
+
+	result, err := DryRunPipelinesProcessing(context.Background(), testPipelines, []model.SignozLog{getLog, postLog})
+	require.Nil(err)
+
+	require.Equal(2, len(result.Pipelines))
+	require.Equal(testPipelines[0].ID.StringValue(), result.Pipelines[0].ID)
+	require.Equal(2, result.Pipelines[0].TotalLogs)
+	require.Equal(1, result.Pipelines[0].MatchedLogs)
+	require.Equal(0.5, result.Pipelines[0].MatchRate)
+	require.Equal([]DryRunOperatorStats{
+		{ID: "add", Name: "test add", Type: "add", ChangedLogs: 1},
+		{ID: "move", Name: "test move", Type: "move", ChangedLogs: 1},
+		{ID: "failing", Name: "test failing add", Type: "add", Errors: 1},
+	}, result.Pipelines[0].Operators)
+	require.Equal(1, result.Pipelines[1].MatchedLogs)
+	require.Equal([]DryRunOperatorStats{
+		{ID: "drop", Name: "test drop", Type: "drop", DroppedLogs: 1},
+	}, result.Pipelines[1].Operators)
+
+	require.Equal(2, len(result.Logs))
+	processedGetLog := result.Logs[0]
+	require.Equal(getLog, processedGetLog.Input)
+	require.False(processedGetLog.Dropped)
+	require.NotNil(processedGetLog.Output)
+	require.Equal("val", processedGetLog.Output.Resources_string["test"])
+	require.Equal([]DryRunOperatorDiff{
+		{
+			PipelineID: testPipelines[0].ID.StringValue(),
+			OperatorID: "add",
+			Changes:    []DryRunFieldChange{{Field: "attributes.test", After: "val"}},
+		}, {
+			PipelineID: testPipelines[0].ID.StringValue(),
+			OperatorID: "move",
+			Changes: []DryRunFieldChange{
+				{Field: "attributes.test", Before: "val"},
+				{Field: "resource.test", After: "val"},
+			},
+		},
+	}, processedGetLog.Operators)
+
+	processedPostLog := result.Logs[1]
+	require.True(processedPostLog.Dropped)
+	require.Nil(processedPostLog.Output)
+	require.Equal([]DryRunOperatorDiff{
+		{
+			PipelineID: testPipelines[1].ID.StringValue(),
+			OperatorID: "drop",
+			Changes:    []DryRunFieldChange{},
+			Dropped:    true,
+		},
+	}, processedPostLog.Operators)
+}
+
+func TestSignozLogFromRawRow(t *testing.T) {
+	timestamp := time.Unix(1700000000, 42)
+	log := signozLogFromRawRow(&qbtypes.RawRow{
+		Timestamp: timestamp,
+		Data: map[string]any{
+			"timestamp":         timestamp,
+			"id":                "2Y4w0hQ3XhzCNQbGYgyvXNAKTdD",
+			"trace_id":          "00000000000000000000000000000001",
+			"span_id":           "0000000000000001",
+			"trace_flags":       uint32(1),
+			"severity_text":     "ERROR",
+			"severity_number":   uint8(17),
+			"body":              "failed to connect",
+			"attributes_string": map[string]string{"code.function": "connect"},
+			"attributes_number": map[string]float64{"retries": 3},
+			"attributes_bool":   map[string]bool{"fatal": true},
+			"resources_string":  map[string]string{"service.name": "api"},
+		},
+	})
+
+	assert.Equal(t, model.SignozLog{
+		Timestamp:          uint64(timestamp.UnixNano()),
+		ID:                 "2Y4w0hQ3XhzCNQbGYgyvXNAKTdD",
+		TraceID:            "00000000000000000000000000000001",
+		SpanID:             "0000000000000001",
+		TraceFlags:         1,
+		SeverityText:       "ERROR",
+		SeverityNumber:     17,
+		Body:               "failed to connect",
+		Resources_string:   map[string]string{"service.name": "api"},
+		Attributes_string:  map[string]string{"code.function": "connect"},
+		Attributes_int64:   map[string]int64{},
+		Attributes_float64: map[string]float64{"retries": 3},
+		Attributes_bool:    map[string]bool{"fatal": true},
+	}, log)
+}
+
+func TestPipelinesDryRunRequestValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request PipelinesDryRunRequest
+		isValid bool
+	}{
+		{name: "Valid", request: PipelinesDryRunRequest{Start: 1, End: 2}, isValid: true},
+		{name: "ValidWithLimit", request: PipelinesDryRunRequest{Start: 1, End: 2, Limit: MaxDryRunLogsLimit}, isValid: true},
+		{name: "MissingTimeRange", request: PipelinesDryRunRequest{Limit: 10}, isValid: false},
+		{name: "StartAfterEnd", request: PipelinesDryRunRequest{Start: 2, End: 1}, isValid: false},
+		{name: "NegativeLimit", request: PipelinesDryRunRequest{Start: 1, End: 2, Limit: -1}, isValid: false},
+		{name: "LimitTooLarge", request: PipelinesDryRunRequest{Start: 1, End: 2, Limit: MaxDryRunLogsLimit + 1}, isValid: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.request.Validate()
+			if testCase.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import "github.com/SigNoz/signoz/pkg/errors"
 var (
 	CodeInvalidOperatorType       = errors.MustNewCode("operator_type_mismatch")
 	CodeFieldNilCheckType         = errors.MustNewCode("operator_field_nil_check")
+	CodeFieldOTTLPath             = errors.MustNewCode("operator_field_ottl_path")
 	CodePipelinesGetFailed        = errors.MustNewCode("pipelines_get_failed")
 	CodeProcessorFactoryMapFailed = errors.MustNewCode("processor_factory_map_failed")
 )
@@ -2,12 +2,17 @@ package logparsingpipeline
 
 import (
 	"fmt"
+	"maps"
+	"math"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
+	signozstanzaentry "github.com/SigNoz/signoz-otel-collector/processor/signozlogspipelineprocessor/stanza/entry"
 	signozstanzahelper "github.com/SigNoz/signoz-otel-collector/processor/signozlogspipelineprocessor/stanza/operator/helper"
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig/transformprocessor"
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
 	"github.com/SigNoz/signoz/pkg/query-service/queryBuilderToExpr"
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
@@ -15,12 +20,47 @@ import (
 	"github.com/antonmedv/expr/ast"
 	"github.com/antonmedv/expr/parser"
 	"github.com/google/uuid"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 )
 
 const (
 	NOOP = "noop"
 )
 
+// pipelineMarkerAttribute marks the logs matching the filter of a pipeline
+// split across processors, for its operators in the later processors.
+const pipelineMarkerAttribute = "__signoz_pipeline__"
+
+// logsTransformOperatorTypes are the operators of the logstransform processor
+// that the signozlogspipeline processor lacks.
+var logsTransformOperatorTypes = []string{"key_value_parser", "filter"}
+
+// transformOperatorType is the type of the operators translated to the OTTL
+// statements of a transform processor.
+const transformOperatorType = "transform"
+
+// Processors the operators of a pipeline run in.
+const (
+	signozLogsPipelineProcessor = "signozlogspipeline"
+	logsTransformProcessor      = "logstransform"
+	transformProcessor          = "transform"
+)
+
+// lookupRowAttribute holds the row found by a lookup operator until its
+// columns are moved under parse_to.
+const lookupRowAttribute = "__signoz_lookup_row__"
+
+// redactMask replaces the matches masked by the redact operator.
+const redactMask = "****"
+
+// redactPatterns are the regular expressions of the built-in redact patterns.
+var redactPatterns = map[string]string{
+	pipelinetypes.RedactPatternEmail:      `[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`,
+	pipelinetypes.RedactPatternCreditCard: `\b(?:\d[ \-]?){12,18}\d\b`,
+	pipelinetypes.RedactPatternIPv4:       `\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`,
+	pipelinetypes.RedactPatternSSN:        `\b\d{3}-\d{2}-\d{4}\b`,
+}
+
 // To ensure names used in generated collector config are never judged invalid,
 // only alphabets, digits and `-` are used when translating pipeline identifiers
 var badCharsForCollectorConfName = regexp.MustCompile("[^a-zA-Z0-9-]")
@@ -52,43 +92,175 @@ func PreparePipelineProcessor(gettablePipelines []pipelinetypes.GettablePipeline
 			return nil, nil, err
 		}
 
-		router := []pipelinetypes.PipelineOperator{
+		name := CollectorConfProcessorName(v)
+
+		// Ensure name is unique
+		if _, nameExists := processors[name]; nameExists {
+			name = fmt.Sprintf("%s-%d", name, pipelineIdx)
+		}
+
+		if slices.ContainsFunc(operators, func(operator pipelinetypes.PipelineOperator) bool {
+			return operatorProcessor(operator) != signozLogsPipelineProcessor
+		}) {
+			names = append(names, splitPipelineProcessor(processors, name, filterExpr, operators)...)
+			continue
+		}
+
+		processors[name] = pipelinetypes.Processor{
+			Operators: routedOperators(filterExpr, operators),
+		}
+		names = append(names, name)
+	}
+	return processors, names, nil
+}
+
+// routedOperators runs the operators on the logs matching expr only.
+func routedOperators(expr string, operators []pipelinetypes.PipelineOperator) []pipelinetypes.PipelineOperator {
+	router := pipelinetypes.PipelineOperator{
+		ID:   "router_signoz",
+		Type: "router",
+		Routes: &[]pipelinetypes.Route{
 			{
-				ID:   "router_signoz",
-				Type: "router",
-				Routes: &[]pipelinetypes.Route{
-					{
-						Output: operators[0].ID,
-						Expr:   filterExpr,
-					},
-				},
-				Default: NOOP,
+				Output: operators[0].ID,
+				Expr:   expr,
 			},
+		},
+		Default: NOOP,
+	}
+
+	// noop operator is needed as the default operator so that logs are not dropped
+	noop := pipelinetypes.PipelineOperator{
+		ID:   NOOP,
+		Type: NOOP,
+	}
+
+	return append(append([]pipelinetypes.PipelineOperator{router}, operators...), noop)
+}
+
+// operatorProcessor returns the processor the operator runs in, the operators
+// missing from the signozlogspipeline processor run in a logstransform or
+// transform processor.
+func operatorProcessor(operator pipelinetypes.PipelineOperator) string {
+	if operator.Type == transformOperatorType {
+		return transformProcessor
+	}
+	if slices.Contains(logsTransformOperatorTypes, operator.Type) {
+		return logsTransformProcessor
+	}
+	return signozLogsPipelineProcessor
+}
+
+// splitPipelineProcessor adds a processor for each run of consecutive operators
+// of the same processor type and returns their names in order. The logs
+// matching the pipeline filter are marked by the first processor, the
+// following ones only apply to marked logs and the marker is removed at the end.
+func splitPipelineProcessor(
+	processors map[string]interface{}, name string, filterExpr string, operators []pipelinetypes.PipelineOperator,
+) []string {
+	markerField := fmt.Sprintf("attributes.%s", pipelineMarkerAttribute)
+	markerCheck := fmt.Sprintf("%s != nil", markerField)
+
+	segments := [][]pipelinetypes.PipelineOperator{{
+		{ID: "signoz_pipeline_mark", Type: "add", Field: markerField, Value: "true"},
+	}}
+	for _, operator := range operators {
+		segment := segments[len(segments)-1]
+		if operatorProcessor(operator) != operatorProcessor(segment[0]) {
+			segments = append(segments, nil)
 		}
+		segments[len(segments)-1] = append(segments[len(segments)-1], operator)
+	}
 
-		v.Config = append(router, operators...)
+	unmark := pipelinetypes.PipelineOperator{ID: "signoz_pipeline_unmark", Type: "remove", Field: markerField}
+	if operatorProcessor(segments[len(segments)-1][0]) == transformProcessor {
+		unmark = pipelinetypes.PipelineOperator{
+			ID:         "signoz_pipeline_unmark",
+			Type:       transformOperatorType,
+			Statements: []string{fmt.Sprintf(`delete_key(attributes, %s)`, strconv.Quote(pipelineMarkerAttribute))},
+		}
+	}
+	segments[len(segments)-1] = append(segments[len(segments)-1], unmark)
 
-		// noop operator is needed as the default operator so that logs are not dropped
-		noop := pipelinetypes.PipelineOperator{
-			ID:   NOOP,
-			Type: NOOP,
+	names := []string{}
+	for segmentIdx, segment := range segments {
+		for idx := range segment {
+			segment[idx].Output = ""
+			if idx > 0 {
+				segment[idx-1].Output = segment[idx].ID
+			}
 		}
-		v.Config = append(v.Config, noop)
 
-		processor := pipelinetypes.Processor{
-			Operators: v.Config,
+		segmentName := name
+		if segmentIdx > 0 {
+			segmentName = fmt.Sprintf("%s-%d", name, segmentIdx)
 		}
-		name := CollectorConfProcessorName(v)
 
-		// Ensure name is unique
-		if _, nameExists := processors[name]; nameExists {
-			name = fmt.Sprintf("%s-%d", name, pipelineIdx)
+		switch operatorProcessor(segment[0]) {
+		case logsTransformProcessor:
+			for idx := range segment {
+				onMarkedLogs(&segment[idx], markerCheck)
+			}
+			// logstransform processors are named with the prefix they used before
+			// the signozlogspipeline processor, so stale ones are still removed.
+			segmentName = constants.OldLogsPPLPfx + strings.TrimPrefix(segmentName, constants.LogsPPLPfx)
+			processors[segmentName] = pipelinetypes.Processor{Operators: segment}
+		case transformProcessor:
+			statements := []string{}
+			for _, operator := range segment {
+				statements = append(statements, operator.Statements...)
+			}
+			segmentName = constants.LogsPPLTransformPfx + strings.TrimPrefix(segmentName, constants.LogsPPLPfx)
+			processors[segmentName] = transformprocessor.Config{
+				ErrorMode: "ignore",
+				LogStatements: []transformprocessor.ContextStatements{{
+					Context:    "log",
+					Conditions: []string{fmt.Sprintf(`attributes[%s] != nil`, strconv.Quote(pipelineMarkerAttribute))},
+					Statements: statements,
+				}},
+			}
+		default:
+			expr := markerCheck
+			if segmentIdx == 0 {
+				expr = filterExpr
+			}
+			processors[segmentName] = pipelinetypes.Processor{Operators: routedOperators(expr, segment)}
 		}
+		names = append(names, segmentName)
+	}
 
-		processors[name] = processor
-		names = append(names, name)
+	return names
+}
+
+// onMarkedLogs limits a logstransform operator to the logs marked by its pipeline.
+func onMarkedLogs(operator *pipelinetypes.PipelineOperator, markerCheck string) {
+	if operator.Type == "filter" {
+		operator.Expr = fmt.Sprintf("%s && (%s)", markerCheck, operator.Expr)
+		return
 	}
-	return processors, names, nil
+
+	if operator.If == "" {
+		operator.If = markerCheck
+		return
+	}
+	operator.If = fmt.Sprintf("%s && (%s)", markerCheck, operator.If)
+}
+
+// pipelinesDropLogs reports whether any of the enabled pipelines has an enabled
+// operator marking logs for dropping, i.e. a drop or sample operator.
+func pipelinesDropLogs(gettablePipelines []pipelinetypes.GettablePipeline) bool {
+	for _, pipeline := range gettablePipelines {
+		if !pipeline.Enabled {
+			continue
+		}
+
+		for _, operator := range pipeline.Config {
+			if operator.Enabled && (operator.Type == "drop" || operator.Type == "sample") {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 func getOperators(ops []pipelinetypes.PipelineOperator) ([]pipelinetypes.PipelineOperator, error) {
@@ -198,6 +370,114 @@ func getOperators(ops []pipelinetypes.PipelineOperator) ([]pipelinetypes.Pipelin
 				if err != nil {
 					return nil, err
 				}
+			} else if operator.Type == "kv_parser" {
+				parseFromNotNilCheck, err := fieldNotNilCheck(operator.ParseFrom)
+				if err != nil {
+					return nil, fmt.Errorf(
+						"couldn't generate nil check for parseFrom of kv parser op %s: %w", operator.Name, err,
+					)
+				}
+
+				delimiter := operator.Delimiter
+				if delimiter == "" {
+					delimiter = "="
+				}
+				operator.Type = "key_value_parser"
+				operator.If = fmt.Sprintf(
+					`%s && type(%s) == "string" && %s contains "%s"`,
+					parseFromNotNilCheck, operator.ParseFrom, operator.ParseFrom,
+					strings.ReplaceAll(strings.ReplaceAll(delimiter, `\`, `\\`), `"`, `\"`),
+				)
+			} else if operator.Type == "redact" {
+				patterns := []string{}
+				for _, pattern := range operator.Patterns {
+					patterns = append(patterns, redactPatterns[pattern])
+				}
+				if operator.Regex != "" {
+					patterns = append(patterns, operator.Regex)
+				}
+				pattern := strings.Join(patterns, "|")
+
+				if operator.Action == pipelinetypes.RedactActionRemove {
+					fieldNotNilCheck, err := fieldNotNilCheck(operator.Field)
+					if err != nil {
+						return nil, fmt.Errorf(
+							"couldn't generate nil check for field of redact op %s: %w", operator.Name, err,
+						)
+					}
+
+					// the field is removed as a whole when it contains a match.
+					operator.Type = "remove"
+					operator.If = fmt.Sprintf(
+						`%s && type(%s) == "string" && %s matches "%s"`,
+						fieldNotNilCheck, operator.Field, operator.Field,
+						strings.ReplaceAll(strings.ReplaceAll(pattern, `\`, `\\`), `"`, `\"`),
+					)
+				} else {
+					field, err := ottlLogField(operator.Field)
+					if err != nil {
+						return nil, fmt.Errorf("couldn't translate field of redact op %s: %w", operator.Name, err)
+					}
+
+					// only the matches are replaced, by the mask or their hash.
+					replacement := strconv.Quote(redactMask)
+					if operator.Action == pipelinetypes.RedactActionHash {
+						replacement = `"$0", SHA256`
+					}
+					operator.Type = transformOperatorType
+					operator.Statements = []string{fmt.Sprintf(
+						"replace_pattern(%s, %s, %s)", field, strconv.Quote(pattern), replacement,
+					)}
+				}
+				operator.Patterns = nil
+				operator.Regex = ""
+				operator.Action = ""
+			} else if operator.Type == "sample" {
+				// all the logs are kept.
+				if operator.Ratio >= 1 {
+					continue
+				}
+
+				field, err := ottlLogField(operator.Field)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't translate field of sample op %s: %w", operator.Name, err)
+				}
+
+				// the FNV hash of the value is spread over the int64 range, the
+				// logs whose hash falls past the ratio of it are marked for
+				// dropping like the logs matching a drop operator.
+				threshold := int64(float64(math.MinInt64) + operator.Ratio*math.Exp2(64))
+				operator.Type = transformOperatorType
+				operator.Statements = []string{fmt.Sprintf(
+					`set(attributes[%s], "true") where %s != nil and FNV(String(%s)) >= %d`,
+					strconv.Quote(pipelinetypes.DropMarkerAttribute), field, field, threshold,
+				)}
+				operator.Field = ""
+			} else if operator.Type == "lookup" {
+				operators, err := processLookup(&operator)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't process lookup op %s: %w", operator.Name, err)
+				}
+
+				filteredOp = append(filteredOp, operators...)
+				continue
+			} else if operator.Type == "drop" {
+				filterExpr, err := queryBuilderToExpr.Parse(operator.Filter)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't generate expr for filter of drop op %s: %w", operator.Name, err)
+				}
+				// an empty if expression would drop every log.
+				if filterExpr == "" {
+					return nil, fmt.Errorf("filter of drop op %s cannot be empty", operator.Name)
+				}
+
+				// logs are only marked here and get dropped by the filter processor
+				// following the pipeline processors.
+				operator.Type = "add"
+				operator.Field = fmt.Sprintf("attributes.%s", pipelinetypes.DropMarkerAttribute)
+				operator.Value = "true"
+				operator.Filter = nil
+				operator.If = filterExpr
 			}
 
 			filteredOp = append(filteredOp, operator)
@@ -391,6 +671,127 @@ func processJSONParser(parent *pipelinetypes.PipelineOperator) ([]pipelinetypes.
 	return append(append([]pipelinetypes.PipelineOperator{}, *parent), children...), nil
 }
 
+// ottlLogField translates the path of a field of a log entry to the OTTL path
+// of the field in the log context. Fields of the body are only found in map
+// bodies.
+func ottlLogField(fieldPath string) (string, error) {
+	field, err := signozstanzaentry.NewField(fieldPath)
+	if err != nil {
+		return "", errors.WrapInvalidInputf(err, CodeFieldOTTLPath, "invalid fieldPath %s", fieldPath)
+	}
+
+	// the fields are wrapped in the field types of both stanza packages.
+	var path string
+	var keys []string
+	for value := entry.FieldInterface(field); path == "" && value != nil; {
+		switch f := value.(type) {
+		case signozstanzaentry.Field:
+			value = f.FieldInterface
+		case entry.Field:
+			value = f.FieldInterface
+		case signozstanzaentry.BodyField:
+			path, keys = "body", f.Keys
+		case entry.AttributeField:
+			path, keys = "attributes", f.Keys
+		case entry.ResourceField:
+			path, keys = "resource.attributes", f.Keys
+		default:
+			value = nil
+		}
+	}
+	if path == "" {
+		return "", errors.NewInvalidInputf(CodeFieldOTTLPath, "invalid fieldPath %s", fieldPath)
+	}
+
+	for _, key := range keys {
+		path += fmt.Sprintf("[%s]", strconv.Quote(key))
+	}
+	return path, nil
+}
+
+// processLookup translates a lookup operator into an add operator setting the
+// row keyed by the value of the field in a temporary attribute, a move
+// operator per column of the table setting it under parse_to, and a remove
+// operator dropping the temporary attribute. A table without entries leaves
+// logs as is and gets no operators. The table is built for every log, which
+// lookuptabletypes.MaxRows keeps cheap.
+func processLookup(operator *pipelinetypes.PipelineOperator) ([]pipelinetypes.PipelineOperator, error) {
+	lookupFieldNotNilCheck, err := fieldNotNilCheck(operator.Field)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate nil check for field: %w", err)
+	}
+
+	parseTo := operator.ParseTo
+	if parseTo == "" {
+		parseTo = "attributes"
+	}
+
+	rows := []string{}
+	columns := []string{}
+	for _, key := range slices.Sorted(maps.Keys(operator.Entries)) {
+		values := []string{}
+		for _, column := range slices.Sorted(maps.Keys(operator.Entries[key])) {
+			values = append(values, fmt.Sprintf("%s: %s", strconv.Quote(column), strconv.Quote(operator.Entries[key][column])))
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+		rows = append(rows, fmt.Sprintf("%s: {%s}", strconv.Quote(key), strings.Join(values, ", ")))
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	slices.Sort(columns)
+
+	// keys which are not in the table get an empty row, so that none of the
+	// columns are moved.
+	row := fmt.Sprintf("attributes.%s", lookupRowAttribute)
+	operators := []pipelinetypes.PipelineOperator{{
+		Type:    "add",
+		ID:      operator.ID,
+		Name:    operator.Name,
+		OrderId: operator.OrderId,
+		Enabled: operator.Enabled,
+		If:      lookupFieldNotNilCheck,
+		Field:   row,
+		Value:   fmt.Sprintf("EXPR({%s}[string(%s)] ?? {})", strings.Join(rows, ", "), operator.Field),
+	}}
+
+	for idx, column := range columns {
+		from := fmt.Sprintf("%s[%s]", row, strconv.Quote(column))
+		fromNotNilCheck, err := fieldNotNilCheck(from)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate nil check for column %s: %w", column, err)
+		}
+
+		operators = append(operators, pipelinetypes.PipelineOperator{
+			Type:    "move",
+			ID:      fmt.Sprintf("%s-%d", operator.ID, idx),
+			Name:    operator.Name,
+			OrderId: operator.OrderId,
+			Enabled: operator.Enabled,
+			If:      fromNotNilCheck,
+			From:    from,
+			To:      fmt.Sprintf("%s[%s]", parseTo, strconv.Quote(column)),
+		})
+	}
+
+	rowNotNilCheck, err := fieldNotNilCheck(row)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate nil check for row: %w", err)
+	}
+
+	return append(operators, pipelinetypes.PipelineOperator{
+		Type:    "remove",
+		ID:      fmt.Sprintf("%s-%d", operator.ID, len(columns)),
+		Name:    operator.Name,
+		OrderId: operator.OrderId,
+		Enabled: operator.Enabled,
+		If:      rowNotNilCheck,
+		Field:   row,
+	}), nil
+}
+
 // TODO: (Piyush) remove this in future
 func cleanTraceParser(operator *pipelinetypes.PipelineOperator) {
 	if operator.TraceId != nil && len(operator.TraceId.ParseFrom) < 1 {
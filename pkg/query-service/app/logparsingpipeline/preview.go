@@ -2,6 +2,7 @@ package logparsingpipeline
 
 import (
 	"context"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -12,6 +13,8 @@ import (
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/query-service/model"
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logstransformprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -36,7 +39,9 @@ func SimulatePipelinesProcessing(ctx context.Context, pipelines []pipelinetypes.
 	}
 	simulatorInputPLogs := SignozLogsToPLogs(logs)
 
-	processorFactories, err := otelcol.MakeFactoryMap(signozlogspipelineprocessor.NewFactory())
+	processorFactories, err := otelcol.MakeFactoryMap(
+		signozlogspipelineprocessor.NewFactory(), logstransformprocessor.NewFactory(), transformprocessor.NewFactory(),
+	)
 	if err != nil {
 		return nil, nil, errors.WrapInternalf(err, CodeProcessorFactoryMapFailed, "could not construct processor factory map")
 	}
@@ -47,10 +52,17 @@ func SimulatePipelinesProcessing(ctx context.Context, pipelines []pipelinetypes.
 	// the number of logtransformprocessors involved.
 	// See defaultFlushInterval at https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/main/pkg/stanza/adapter/emitter.go
 	// TODO(Raj): Remove this after flushInterval is exposed in logtransformprocessor config
-	timeout := time.Millisecond * time.Duration(len(pipelines)*100+100)
+	processorCount := len(pipelines)
+	if _, processorNames, err := PreparePipelineProcessor(pipelines); err == nil {
+		// pipelines using logstransform operators are split across processors.
+		processorCount = max(processorCount, len(processorNames))
+	}
+	timeout := time.Millisecond * time.Duration(processorCount*100+100)
 
+	// The simulator only has the logs pipeline processors, logs marked for
+	// dropping are removed from its output instead of by a filter processor.
 	configGenerator := func(baseConf []byte) ([]byte, error) {
-		updatedConf, err := GenerateCollectorConfigWithPipelines(baseConf, pipelines)
+		updatedConf, err := generateCollectorConfigWithPipelines(baseConf, pipelines, false)
 		if err != nil {
 			return nil, err
 		}
@@ -71,7 +83,10 @@ func SimulatePipelinesProcessing(ctx context.Context, pipelines []pipelinetypes.
 		return nil, nil, errors.WrapInternalf(simulationErr, errors.CodeInternal, "could not simulate log pipelines processing")
 	}
 
-	outputSignozLogs := PLogsToSignozLogs(outputPLogs)
+	outputSignozLogs := slices.DeleteFunc(PLogsToSignozLogs(outputPLogs), func(log model.SignozLog) bool {
+		_, dropped := log.Attributes_string[pipelinetypes.DropMarkerAttribute]
+		return dropped
+	})
 
 	// Sort output logs by their order in the input and remove the temp ordering attribute
 	sort.Slice(outputSignozLogs, func(i, j int) bool {
@@ -144,6 +159,9 @@ func SignozLogsToPLogs(logs []model.SignozLog) []plog.Logs {
 		for k, v := range log.Attributes_string {
 			slAttribs.PutStr(k, v)
 		}
+		for k, v := range log.Attributes_bool {
+			slAttribs.PutBool(k, v)
+		}
 		slAttribs.PutStr(SignozLogIdAttr, log.ID)
 
 		result = append(result, pl)
@@ -190,6 +208,7 @@ func PLogsToSignozLogs(plogs []plog.Logs) []model.SignozLog {
 						Attributes_string:  map[string]string{},
 						Attributes_int64:   map[string]int64{},
 						Attributes_float64: map[string]float64{},
+						Attributes_bool:    map[string]bool{},
 					}
 
 					// Populate signozLog.Attributes_...
@@ -198,6 +217,8 @@ func PLogsToSignozLogs(plogs []plog.Logs) []model.SignozLog {
 							signozLog.Attributes_float64[k] = v.Double()
 						} else if v.Type() == pcommon.ValueTypeInt {
 							signozLog.Attributes_int64[k] = v.Int()
+						} else if v.Type() == pcommon.ValueTypeBool {
+							signozLog.Attributes_bool[k] = v.Bool()
 						} else {
 							signozLog.Attributes_string[k] = v.AsString()
 						}
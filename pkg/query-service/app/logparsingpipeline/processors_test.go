@@ -2,6 +2,8 @@ package logparsingpipeline
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -12,7 +14,9 @@ import (
 	"github.com/SigNoz/signoz/pkg/query-service/model"
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
 	"github.com/SigNoz/signoz/pkg/query-service/utils"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -589,3 +593,406 @@ func TestMoveProcessor(t *testing.T) {
 	require.Equal("", processed.Attributes_string["method"])
 	require.Equal("GET", processed.Attributes_string["moved_method"])
 }
+
+func TestKVParserProcessor(t *testing.T) {
+	require := require.New(t)
+
+	testPipelines := makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "kv_parser",
+			"name": "Test kv parser",
+			"id": "test-kv-parser",
+			"parse_from": "body",
+			"parse_to": "attributes"
+		}
+	`)
+
+	testLog := makeTestSignozLog(
+		`level=warn user="jane doe" status=503`,
+		map[string]interface{}{
+			"method": "GET",
+		},
+	)
+	noPairsLog := makeTestSignozLog(
+		"a log without pairs",
+		map[string]interface{}{
+			"method": "GET",
+		},
+	)
+
+	result, collectorWarnAndErrorLogs, err := SimulatePipelinesProcessing(
+		context.Background(),
+		testPipelines,
+		[]model.SignozLog{
+			testLog,
+			noPairsLog,
+		},
+	)
+	require.Nil(err)
+	require.Equal(2, len(result))
+	require.Equal(0, len(collectorWarnAndErrorLogs), strings.Join(collectorWarnAndErrorLogs, "\n"))
+
+	require.Equal("warn", result[0].Attributes_string["level"])
+	require.Equal("jane doe", result[0].Attributes_string["user"])
+	require.Equal("503", result[0].Attributes_string["status"])
+	require.NotContains(result[0].Attributes_string, pipelineMarkerAttribute)
+
+	require.Equal(noPairsLog.Attributes_string, result[1].Attributes_string)
+}
+
+func TestRedactProcessor(t *testing.T) {
+	require := require.New(t)
+
+	testPipelines := makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "redact",
+			"name": "Test redact",
+			"id": "test-redact",
+			"field": "body",
+			"patterns": ["email", "credit_card"],
+			"regex": "token-[a-z0-9]+",
+			"action": "mask"
+		}
+	`)
+
+	emailLog := makeTestSignozLog(
+		"payment by jane@example.com",
+		map[string]interface{}{
+			"method": "GET",
+		},
+	)
+	cardLog := makeTestSignozLog(
+		"payment with card 4111 1111 1111 1111",
+		map[string]interface{}{
+			"method": "GET",
+		},
+	)
+	tokenLog := makeTestSignozLog(
+		"payment using token-abc123",
+		map[string]interface{}{
+			"method": "GET",
+		},
+	)
+	cleanLog := makeTestSignozLog(
+		"payment for order 42",
+		map[string]interface{}{
+			"method": "GET",
+		},
+	)
+
+	result, collectorWarnAndErrorLogs, err := SimulatePipelinesProcessing(
+		context.Background(),
+		testPipelines,
+		[]model.SignozLog{
+			emailLog,
+			cardLog,
+			tokenLog,
+			cleanLog,
+		},
+	)
+	require.Nil(err)
+	require.Equal(4, len(result))
+	require.Equal(0, len(collectorWarnAndErrorLogs), strings.Join(collectorWarnAndErrorLogs, "\n"))
+	require.Equal("payment by "+redactMask, result[0].Body)
+	require.Equal("payment with card "+redactMask, result[1].Body)
+	require.Equal("payment using "+redactMask, result[2].Body)
+	require.Equal(cleanLog.Body, result[3].Body)
+
+	// hashing the matches in an attribute
+	testPipelines = makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "redact",
+			"name": "Test redact",
+			"id": "test-redact",
+			"field": "attributes.user",
+			"patterns": ["email"],
+			"action": "hash"
+		}
+	`)
+
+	emailLog = makeTestSignozLog(
+		"test log",
+		map[string]interface{}{
+			"method": "GET",
+			"user":   "jane@example.com (admin)",
+		},
+	)
+
+	result, collectorWarnAndErrorLogs, err = SimulatePipelinesProcessing(
+		context.Background(),
+		testPipelines,
+		[]model.SignozLog{emailLog},
+	)
+	require.Nil(err)
+	require.Equal(1, len(result))
+	require.Equal(0, len(collectorWarnAndErrorLogs), strings.Join(collectorWarnAndErrorLogs, "\n"))
+	hash := sha256.Sum256([]byte("jane@example.com"))
+	require.Equal(hex.EncodeToString(hash[:])+" (admin)", result[0].Attributes_string["user"])
+	require.NotContains(result[0].Attributes_string, pipelineMarkerAttribute)
+
+	// removing an attribute containing a match
+	testPipelines = makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "redact",
+			"name": "Test redact",
+			"id": "test-redact",
+			"field": "attributes.user",
+			"patterns": ["email"],
+			"action": "remove"
+		}
+	`)
+
+	emailLog = makeTestSignozLog(
+		"test log",
+		map[string]interface{}{
+			"method": "GET",
+			"user":   "jane@example.com",
+		},
+	)
+	nameLog := makeTestSignozLog(
+		"test log",
+		map[string]interface{}{
+			"method": "GET",
+			"user":   "jane",
+		},
+	)
+
+	result, collectorWarnAndErrorLogs, err = SimulatePipelinesProcessing(
+		context.Background(),
+		testPipelines,
+		[]model.SignozLog{
+			emailLog,
+			nameLog,
+		},
+	)
+	require.Nil(err)
+	require.Equal(2, len(result))
+	require.Equal(0, len(collectorWarnAndErrorLogs), strings.Join(collectorWarnAndErrorLogs, "\n"))
+	require.NotContains(result[0].Attributes_string, "user")
+	require.Equal("jane", result[1].Attributes_string["user"])
+}
+
+func TestDropProcessor(t *testing.T) {
+	require := require.New(t)
+
+	testPipelines := makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "drop",
+			"name": "Test drop",
+			"id": "test-drop",
+			"filter": {
+				"op": "AND",
+				"items": [
+					{
+						"key": {"key": "level", "dataType": "string", "type": "tag"},
+						"op": "=",
+						"value": "debug"
+					}
+				]
+			}
+		}
+	`)
+
+	debugLog := makeTestSignozLog(
+		"debug log",
+		map[string]interface{}{
+			"method": "GET",
+			"level":  "debug",
+		},
+	)
+	infoLog := makeTestSignozLog(
+		"info log",
+		map[string]interface{}{
+			"method": "GET",
+			"level":  "info",
+		},
+	)
+	unfilteredLog := makeTestSignozLog(
+		"debug log outside the pipeline",
+		map[string]interface{}{
+			"method": "POST",
+			"level":  "debug",
+		},
+	)
+
+	result, collectorWarnAndErrorLogs, err := SimulatePipelinesProcessing(
+		context.Background(),
+		testPipelines,
+		[]model.SignozLog{
+			debugLog,
+			infoLog,
+			unfilteredLog,
+		},
+	)
+	require.Nil(err)
+	require.Equal(0, len(collectorWarnAndErrorLogs), strings.Join(collectorWarnAndErrorLogs, "\n"))
+	require.Equal(2, len(result))
+	require.Equal(infoLog.Body, result[0].Body)
+	require.Equal(unfilteredLog.Body, result[1].Body)
+}
+
+func TestSampleProcessor(t *testing.T) {
+	require := require.New(t)
+
+	testPipelines := makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "sample",
+			"name": "Test sample",
+			"id": "test-sample",
+			"field": "attributes.user_id",
+			"ratio": 0.5
+		}
+	`)
+
+	testLogs := []model.SignozLog{}
+	for i := 0; i < 200; i++ {
+		for j := 0; j < 2; j++ {
+			testLogs = append(testLogs, makeTestSignozLog(
+				fmt.Sprintf("log %d of user-%d", j, i),
+				map[string]interface{}{
+					"method":  "GET",
+					"user_id": fmt.Sprintf("user-%d", i),
+				},
+			))
+		}
+	}
+	testLogs = append(testLogs, makeTestSignozLog(
+		"log without a user",
+		map[string]interface{}{
+			"method": "GET",
+		},
+	))
+
+	result, collectorWarnAndErrorLogs, err := SimulatePipelinesProcessing(
+		context.Background(),
+		testPipelines,
+		testLogs,
+	)
+	require.Nil(err)
+	require.Equal(0, len(collectorWarnAndErrorLogs), strings.Join(collectorWarnAndErrorLogs, "\n"))
+
+	// the logs having the field are sampled by the hash of its value, so the
+	// logs of a user are either all kept or all dropped. Others are all kept.
+	require.Greater(len(result), 100)
+	require.Less(len(result), 300)
+	require.Equal("log without a user", result[len(result)-1].Body)
+	kept := map[string]int{}
+	for _, processed := range result {
+		require.NotContains(processed.Attributes_string, pipelineMarkerAttribute)
+		kept[processed.Attributes_string["user_id"]]++
+	}
+	for user, count := range kept {
+		if user != "" {
+			require.Equal(2, count, user)
+		}
+	}
+
+	again, _, err := SimulatePipelinesProcessing(
+		context.Background(),
+		testPipelines,
+		testLogs,
+	)
+	require.Nil(err)
+	require.Equal(len(result), len(again))
+}
+
+func TestLookupProcessor(t *testing.T) {
+	require := require.New(t)
+
+	testPipelines := makeTestPipelinesWithOperator(t, `
+		{
+			"orderId": 1,
+			"enabled": true,
+			"type": "lookup",
+			"name": "Test lookup",
+			"id": "test-lookup",
+			"field": "attributes.customer_id",
+			"lookup_table": "tenants"
+		}
+	`)
+
+	controller := &LogParsingPipelineController{
+		GetLookupTableEntries: func(context.Context, valuer.UUID) (map[string]lookuptabletypes.Entries, error) {
+			return map[string]lookuptabletypes.Entries{
+				"tenants": {
+					"c-1": {"tenant": "Acme", "tenant.tier": "gold"},
+					"c-2": {"tenant": "Globex"},
+				},
+			}, nil
+		},
+	}
+	resolvedPipelines, err := controller.resolveLookupTables(context.Background(), valuer.GenerateUUID(), testPipelines)
+	require.Nil(err)
+	require.Nil(testPipelines[0].Config[0].Entries)
+
+	testLogs := []model.SignozLog{
+		makeTestSignozLog("acme log", map[string]interface{}{"method": "GET", "customer_id": "c-1"}),
+		makeTestSignozLog("globex log", map[string]interface{}{"method": "GET", "customer_id": "c-2"}),
+		makeTestSignozLog("unknown customer log", map[string]interface{}{"method": "GET", "customer_id": "c-9"}),
+		makeTestSignozLog("log without a customer", map[string]interface{}{"method": "GET"}),
+	}
+
+	result, collectorWarnAndErrorLogs, err := SimulatePipelinesProcessing(
+		context.Background(),
+		resolvedPipelines,
+		testLogs,
+	)
+	require.Nil(err)
+	require.Equal(0, len(collectorWarnAndErrorLogs), strings.Join(collectorWarnAndErrorLogs, "\n"))
+	require.Equal(4, len(result))
+
+	require.Equal("Acme", result[0].Attributes_string["tenant"])
+	require.Equal("gold", result[0].Attributes_string["tenant.tier"])
+	require.Equal("Globex", result[1].Attributes_string["tenant"])
+	require.NotContains(result[1].Attributes_string, "tenant.tier")
+	require.NotContains(result[2].Attributes_string, "tenant")
+	require.NotContains(result[3].Attributes_string, "tenant")
+	for _, log := range result {
+		require.NotContains(log.Attributes_string, lookupRowAttribute)
+	}
+}
+
+// makeTestPipelinesWithOperator returns a pipeline for logs with method GET
+// running the operator.
+func makeTestPipelinesWithOperator(t *testing.T, operatorJSON string) []pipelinetypes.GettablePipeline {
+	var op pipelinetypes.PipelineOperator
+	require.Nil(t, json.Unmarshal([]byte(operatorJSON), &op))
+
+	return []pipelinetypes.GettablePipeline{
+		{
+			StoreablePipeline: pipelinetypes.StoreablePipeline{
+				OrderID: 1,
+				Name:    "pipeline1",
+				Alias:   "pipeline1",
+				Enabled: true,
+			},
+			Filter: &v3.FilterSet{
+				Operator: "AND",
+				Items: []v3.FilterItem{
+					{
+						Key: v3.AttributeKey{
+							Key:      "method",
+							DataType: v3.AttributeKeyDataTypeString,
+							Type:     v3.AttributeKeyTypeTag,
+						},
+						Operator: "=",
+						Value:    "GET",
+					},
+				},
+			},
+			Config: []pipelinetypes.PipelineOperator{op},
+		},
+	}
+}
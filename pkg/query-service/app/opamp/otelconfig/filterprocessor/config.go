@@ -1,7 +1,24 @@
 package filterprocessor
 
 type Config struct {
-	Metrics MetricFilters `mapstructure:"metrics"`
+	// ErrorMode determines how the processor reacts to errors that occur while
+	// evaluating a condition: propagate, ignore or silent.
+	ErrorMode string `mapstructure:"error_mode" yaml:"error_mode,omitempty"`
+
+	Traces  TraceFilters  `mapstructure:"traces" yaml:"traces,omitempty"`
+	Logs    LogFilters    `mapstructure:"logs" yaml:"logs,omitempty"`
+	Metrics MetricFilters `mapstructure:"metrics" yaml:"metrics,omitempty"`
+}
+
+// TraceFilters filters by Span properties.
+type TraceFilters struct {
+	SpanConditions      []string `mapstructure:"span" yaml:"span,omitempty"`
+	SpanEventConditions []string `mapstructure:"spanevent" yaml:"spanevent,omitempty"`
+}
+
+// LogFilters filters by LogRecord properties.
+type LogFilters struct {
+	LogConditions []string `mapstructure:"log_record" yaml:"log_record,omitempty"`
 }
 
 // MetricFilters filters by Metric properties.
@@ -1,24 +1,43 @@
 package tailsampler
 
-import "time"
-
 type PolicyType string
 
+const (
+	Latency          PolicyType = "latency"
+	NumericAttribute PolicyType = "numeric_attribute"
+	Probabilistic    PolicyType = "probabilistic"
+	StatusCode       PolicyType = "status_code"
+	StringAttribute  PolicyType = "string_attribute"
+	And              PolicyType = "and"
+)
+
+// Config is the config of the tail_sampling processor.
 type Config struct {
-	DecisionWait            time.Duration `mapstructure:"decision_wait" yaml:"decision_wait"`
-	NumTraces               uint64        `mapstructure:"num_traces" yaml:"num_traces"`
-	ExpectedNewTracesPerSec uint64        `mapstructure:"expected_new_traces_per_sec" yaml:"expected_new_traces_per_sec"`
-	PolicyCfgs              []PolicyCfg   `mapstructure:"policies" yaml:"policies"`
+	// DecisionWait is the time, as a duration string, since the first span of
+	// a trace before a sampling decision is made.
+	DecisionWait            string      `mapstructure:"decision_wait" yaml:"decision_wait"`
+	NumTraces               uint64      `mapstructure:"num_traces" yaml:"num_traces"`
+	ExpectedNewTracesPerSec uint64      `mapstructure:"expected_new_traces_per_sec" yaml:"expected_new_traces_per_sec,omitempty"`
+	PolicyCfgs              []PolicyCfg `mapstructure:"policies" yaml:"policies"`
+}
+
+type LatencyCfg struct {
+	// ThresholdMs is the lower bound of the trace duration to be considered a match.
+	ThresholdMs int64 `mapstructure:"threshold_ms" yaml:"threshold_ms"`
+	// UpperThresholdMs is the upper bound of the trace duration, zero means no upper bound.
+	UpperThresholdMs int64 `mapstructure:"upper_threshold_ms" yaml:"upper_threshold_ms,omitempty"`
+}
 
-	// read only version number (optional)
-	Version int
+type StatusCodeCfg struct {
+	// StatusCodes are the span status codes, OK, ERROR or UNSET, to be considered a match.
+	StatusCodes []string `mapstructure:"status_codes" yaml:"status_codes"`
 }
 
 type ProbabilisticCfg struct {
 	// HashSalt allows one to configure the hashing salts. This is important in scenarios where multiple layers of collectors
 	// have different sampling rates: if they use the same salt all passing one layer may pass the other even if they have
 	// different sampling rates, configuring different salts avoids that.
-	HashSalt string `mapstructure:"hash_salt" yaml:"hash_salt"`
+	HashSalt string `mapstructure:"hash_salt" yaml:"hash_salt,omitempty"`
 	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
 	// Values greater or equal 100 are treated as "sample all traces".
 	SamplingPercentage float64 `mapstructure:"sampling_percentage" yaml:"sampling_percentage"`
@@ -31,6 +50,8 @@ type NumericAttributeCfg struct {
 	MinValue int64 `mapstructure:"min_value" yaml:"min_value"`
 	// MaxValue is the maximum value of the attribute to be considered a match.
 	MaxValue int64 `mapstructure:"max_value" yaml:"max_value"`
+	// InvertMatch indicates that values out of the range must be considered a match.
+	InvertMatch bool `mapstructure:"invert_match" yaml:"invert_match,omitempty"`
 }
 
 type StringAttributeCfg struct {
@@ -40,26 +61,23 @@ type StringAttributeCfg struct {
 	// StringAttribute Policy will apply exact value match on Values unless EnabledRegexMatching is true.
 	Values []string `mapstructure:"values" yaml:"values"`
 	// EnabledRegexMatching determines whether match attribute values by regexp string.
-	EnabledRegexMatching bool `mapstructure:"enabled_regex_matching" yaml:"enabled_regex_matching"`
+	EnabledRegexMatching bool `mapstructure:"enabled_regex_matching" yaml:"enabled_regex_matching,omitempty"`
 	// CacheMaxSize is the maximum number of attribute entries of LRU Cache that stores the matched result
 	// from the regular expressions defined in Values.
 	// CacheMaxSize will not be used if EnabledRegexMatching is set to false.
-	CacheMaxSize int `mapstructure:"cache_max_size" yaml:"cache_max_size"`
+	CacheMaxSize int `mapstructure:"cache_max_size" yaml:"cache_max_size,omitempty"`
 	// InvertMatch indicates that values or regular expressions must not match against attribute values.
 	// If InvertMatch is true and Values is equal to 'acme', all other values will be sampled except 'acme'.
 	// Also, if the specified Key does not match on any resource or span attributes, data will be sampled.
-	InvertMatch bool `mapstructure:"invert_match" yaml:"invert_match"`
+	InvertMatch bool `mapstructure:"invert_match" yaml:"invert_match,omitempty"`
 }
 
-type PolicyFilterCfg struct {
-	// values: AND | OR
-	FilterOp string `mapstructure:"filter_op" yaml:"filter_op"`
-
-	StringAttributeCfgs  []StringAttributeCfg  `mapstructure:"string_attributes" yaml:"string_attributes"`
-	NumericAttributeCfgs []NumericAttributeCfg `mapstructure:"numeric_attributes" yaml:"numeric_attributes"`
+// AndCfg samples a trace when all of the sub policies sample it.
+type AndCfg struct {
+	SubPolicyCfgs []PolicyCfg `mapstructure:"and_sub_policy" yaml:"and_sub_policy"`
 }
 
-// PolicyCfg identifies policy rules in policy group
+// PolicyCfg identifies a sampling policy, the config of its type is set.
 type PolicyCfg struct {
 	// name of the policy
 	Name string `mapstructure:"name" yaml:"name"`
@@ -67,16 +85,10 @@ type PolicyCfg struct {
 	// Type of the policy this will be used to match the proper configuration of the policy.
 	Type PolicyType `mapstructure:"type" yaml:"type"`
 
-	// Set to true for sampling rule (root) and false for conditions
-	Root bool `mapstructure:"root" yaml:"root"`
-
-	Priority int `mapstructure:"priority" yaml:"priority"`
-
-	// sampling applied when  PolicyFilter matches
-	ProbabilisticCfg `mapstructure:",squash" yaml:"sampling"`
-
-	// filter to activate policy
-	PolicyFilterCfg `mapstructure:",squash" yaml:"policy_filter"`
-
-	SubPolicies []PolicyCfg `mapstructure:"sub_policies" yaml:"sub_policies"`
+	LatencyCfg          *LatencyCfg          `mapstructure:"latency" yaml:"latency,omitempty"`
+	StatusCodeCfg       *StatusCodeCfg       `mapstructure:"status_code" yaml:"status_code,omitempty"`
+	StringAttributeCfg  *StringAttributeCfg  `mapstructure:"string_attribute" yaml:"string_attribute,omitempty"`
+	NumericAttributeCfg *NumericAttributeCfg `mapstructure:"numeric_attribute" yaml:"numeric_attribute,omitempty"`
+	ProbabilisticCfg    *ProbabilisticCfg    `mapstructure:"probabilistic" yaml:"probabilistic,omitempty"`
+	AndCfg              *AndCfg              `mapstructure:"and" yaml:"and,omitempty"`
 }
@@ -0,0 +1,18 @@
+package transformprocessor
+
+type Config struct {
+	// ErrorMode determines how the processor reacts to errors that occur while
+	// executing a statement: propagate, ignore or silent.
+	ErrorMode string `mapstructure:"error_mode" yaml:"error_mode,omitempty"`
+
+	TraceStatements []ContextStatements `mapstructure:"trace_statements" yaml:"trace_statements,omitempty"`
+	LogStatements   []ContextStatements `mapstructure:"log_statements" yaml:"log_statements,omitempty"`
+}
+
+// ContextStatements are the OTTL statements executed in a context, such as
+// span or resource, on the telemetry matching any of the conditions.
+type ContextStatements struct {
+	Context    string   `mapstructure:"context" yaml:"context"`
+	Conditions []string `mapstructure:"conditions" yaml:"conditions,omitempty"`
+	Statements []string `mapstructure:"statements" yaml:"statements"`
+}
@@ -86,7 +86,9 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 	logParsingPipelineController, err := logparsingpipeline.NewLogParsingPipelinesController(
 		signoz.SQLStore,
 		integrationsController.GetPipelinesForInstalledIntegrations,
+		signoz.Modules.LookupTable.ListSnapshotEntries,
 		reader,
+		signoz.Querier,
 		signoz.Flagger,
 	)
 	if err != nil {
@@ -126,9 +128,10 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 	agentConfMgr, err := agentConf.Initiate(
 		&agentConf.ManagerOptions{
 			Store: signoz.SQLStore,
-			AgentFeatures: []agentConf.AgentFeature{
-				logParsingPipelineController,
-			},
+			AgentFeatures: append(
+				[]agentConf.AgentFeature{logParsingPipelineController, signoz.Modules.LookupTable.AgentFeature()},
+				signoz.Modules.IngestionRule.AgentFeatures()...,
+			),
 		},
 	)
 	if err != nil {
@@ -239,6 +239,10 @@ var ReservedColumnTargetAliases = map[string]struct{}{
 const LogsPPLPfx = "signozlogspipeline/pipeline_"
 const OldLogsPPLPfx = "logstransform/pipeline_"
 
+// LogsPPLTransformPfx prefixes the transform processors running the OTTL
+// statements of the pipeline operators.
+const LogsPPLTransformPfx = "transform/pipeline_"
+
 const IntegrationPipelineIdPrefix = "integration"
 
 // The datatype present here doesn't represent the actual datatype of column in the logs table.
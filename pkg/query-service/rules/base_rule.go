@@ -146,9 +146,14 @@ func NewBaseRule(
 	externalURL *url.URL,
 	opts ...RuleOption,
 ) (*BaseRule, error) {
-	threshold, err := p.RuleCondition.Thresholds.GetRuleThreshold()
-	if err != nil {
-		return nil, err
+	// Recording rules raise no alerts and may come without thresholds.
+	var threshold ruletypes.RuleThreshold
+	if p.RuleType != ruletypes.RuleTypeRecording || p.RuleCondition.Thresholds != nil {
+		var err error
+		threshold, err = p.RuleCondition.Thresholds.GetRuleThreshold()
+		if err != nil {
+			return nil, err
+		}
 	}
 	evaluation, err := p.Evaluation.GetEvaluation()
 	if err != nil {
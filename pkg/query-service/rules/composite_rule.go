@@ -0,0 +1,404 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/rulestatehistorytypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// RuleLookup returns the rule with the given id that the manager is
+// currently evaluating.
+type RuleLookup func(id string) (Rule, bool)
+
+// ruleIndex indexes the manager's rules by id for composite rules. It has its
+// own lock as rules are evaluated while the manager lock is held to stop tasks.
+type ruleIndex struct {
+	mtx   sync.RWMutex
+	rules map[string]Rule
+}
+
+func newRuleIndex() *ruleIndex {
+	return &ruleIndex{rules: map[string]Rule{}}
+}
+
+func (i *ruleIndex) lookup(id string) (Rule, bool) {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+
+	rule, ok := i.rules[id]
+	return rule, ok
+}
+
+func (i *ruleIndex) set(rule Rule) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	i.rules[rule.ID()] = rule
+}
+
+func (i *ruleIndex) delete(id string) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	delete(i.rules, id)
+}
+
+// CompositeRule fires when a boolean expression over the states of other
+// rules holds. It runs no query of its own and reads the active alerts of its
+// child rules as of their last evaluation.
+type CompositeRule struct {
+	*BaseRule
+
+	expr     *ruletypes.CompositeExpr
+	ruleIDs  map[string]string
+	matchOn  []string
+	lookupFn RuleLookup
+}
+
+var _ Rule = (*CompositeRule)(nil)
+
+func NewCompositeRule(
+	id string,
+	orgID valuer.UUID,
+	p *ruletypes.PostableRule,
+	lookup RuleLookup,
+	logger *slog.Logger,
+	externalURL *url.URL,
+	opts ...RuleOption,
+) (*CompositeRule, error) {
+	logger.Info("creating new CompositeRule", slog.String("rule.id", id))
+
+	if p.RuleCondition == nil || p.RuleCondition.Composite == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite: field is required for ruleType %q", ruletypes.RuleTypeComposite.StringValue())
+	}
+
+	expr, err := ruletypes.ParseCompositeExpression(p.RuleCondition.Composite.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleIDs := p.RuleCondition.Composite.RuleIDs()
+	for alias, ruleID := range ruleIDs {
+		if ruleID == id {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.rules: %q refers to the composite rule itself", alias)
+		}
+	}
+
+	opts = append(opts, WithLogger(logger))
+
+	baseRule, err := NewBaseRule(id, orgID, p, externalURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompositeRule{
+		BaseRule: baseRule,
+		expr:     expr,
+		ruleIDs:  ruleIDs,
+		matchOn:  p.RuleCondition.Composite.MatchLabels,
+		lookupFn: lookup,
+	}, nil
+}
+
+func (r *CompositeRule) Type() ruletypes.RuleType {
+	return ruletypes.RuleTypeComposite
+}
+
+// compositeGroup is a set of values of the match labels with the aliases
+// whose rules have a firing alert for it.
+type compositeGroup struct {
+	labels ruletypes.Labels
+	holds  map[string]bool
+}
+
+// firingAlerts returns the alerts of each child rule that count as the rule
+// holding: firing or recovering alerts that aren't raised for missing data.
+func (r *CompositeRule) firingAlerts(ctx context.Context) map[string][]ruletypes.Labels {
+	firing := make(map[string][]ruletypes.Labels, len(r.ruleIDs))
+	for alias, ruleID := range r.ruleIDs {
+		var child Rule
+		var ok bool
+		if r.lookupFn != nil {
+			child, ok = r.lookupFn(ruleID)
+		}
+		if ok {
+			if owned, isOwned := child.(interface{ OrgID() valuer.UUID }); isOwned && owned.OrgID() != r.orgID {
+				ok = false
+			}
+		}
+		if !ok {
+			r.logger.WarnContext(ctx, "composite rule refers to a rule that is not being evaluated", slog.String("composite.alias", alias), slog.String("composite.rule_id", ruleID))
+			continue
+		}
+
+		for _, a := range child.ActiveAlerts() {
+			if a.Missing || (a.State != ruletypes.StateFiring && a.State != ruletypes.StateRecovering) {
+				continue
+			}
+			firing[alias] = append(firing[alias], a.Labels)
+		}
+	}
+	return firing
+}
+
+// groups joins the firing alerts of the child rules on the match labels.
+func (r *CompositeRule) groups(firing map[string][]ruletypes.Labels) []compositeGroup {
+	if len(r.matchOn) == 0 {
+		group := compositeGroup{labels: ruletypes.Labels{}, holds: map[string]bool{}}
+		for alias, alerts := range firing {
+			group.holds[alias] = len(alerts) > 0
+		}
+		return []compositeGroup{group}
+	}
+
+	// Every alert carrying all the match labels starts a group.
+	groups := map[uint64]*compositeGroup{}
+	for _, alerts := range firing {
+		for _, lbls := range alerts {
+			values := lbls.Map()
+			b := ruletypes.NewBuilder()
+			complete := true
+			for _, name := range r.matchOn {
+				value, ok := values[name]
+				if !ok {
+					complete = false
+					break
+				}
+				b.Set(name, value)
+			}
+			if !complete {
+				continue
+			}
+			key := b.Labels()
+			if _, ok := groups[key.Hash()]; !ok {
+				groups[key.Hash()] = &compositeGroup{labels: key, holds: map[string]bool{}}
+			}
+		}
+	}
+
+	result := make([]compositeGroup, 0, len(groups))
+	for _, group := range groups {
+		key := group.labels.Map()
+		for alias, alerts := range firing {
+			for _, lbls := range alerts {
+				if matchesGroup(lbls.Map(), key) {
+					group.holds[alias] = true
+					break
+				}
+			}
+		}
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].labels.String() < result[j].labels.String()
+	})
+	return result
+}
+
+// matchesGroup reports whether an alert matches a group. An alert that
+// doesn't carry one of the match labels matches every value of it.
+func matchesGroup(alert map[string]string, key map[string]string) bool {
+	for name, value := range key {
+		if v, ok := alert[name]; ok && v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *CompositeRule) Eval(ctx context.Context, ts time.Time) (int, error) {
+	prevState := r.State()
+
+	groups := r.groups(r.firingAlerts(ctx))
+	if len(groups) == 0 && r.ShouldSendUnmatched() {
+		// Nothing to join on yet, test notifications still need an alert.
+		groups = []compositeGroup{{labels: ruletypes.Labels{}, holds: map[string]bool{}}}
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	ruleReceivers := r.Threshold.GetRuleReceivers()
+	var thresholdName string
+	var receivers []string
+	if len(ruleReceivers) > 0 {
+		thresholdName = ruleReceivers[0].Name
+		receivers = ruleReceivers[0].Channels
+	}
+
+	matched := 0
+	resultFPs := map[uint64]struct{}{}
+	alerts := make(map[uint64]*ruletypes.Alert, len(groups))
+
+	for _, group := range groups {
+		holds := r.expr.Eval(func(alias string) bool { return group.holds[alias] })
+		if holds {
+			matched++
+		}
+		if !holds && !r.ShouldSendUnmatched() {
+			continue
+		}
+
+		var aliases []string
+		for _, alias := range r.expr.Aliases() {
+			if group.holds[alias] {
+				aliases = append(aliases, alias)
+			}
+		}
+
+		tmplData := ruletypes.AlertTemplateData(group.labels.Map(), strings.Join(aliases, ", "), r.expr.String())
+		defs := "{{$labels := .Labels}}{{$value := .Value}}{{$threshold := .Threshold}}"
+
+		expand := func(text string) string {
+			tmpl := ruletypes.NewTemplateExpander(
+				ctx,
+				defs+text,
+				"__alert_"+r.Name(),
+				tmplData,
+				nil,
+			)
+			result, err := tmpl.Expand()
+			if err != nil {
+				result = fmt.Sprintf("<error expanding template: %s>", err)
+				r.logger.ErrorContext(ctx, "expanding alert template failed", errors.Attr(err), slog.Any("alert.template_data", tmplData))
+			}
+			return result
+		}
+
+		lb := ruletypes.NewBuilder(group.labels...)
+		for name, value := range r.labels.Map() {
+			lb.Set(name, expand(value))
+		}
+		if thresholdName != "" {
+			lb.Set(ruletypes.LabelThresholdName, thresholdName)
+		}
+		lb.Set(ruletypes.AlertNameLabel, r.Name())
+		lb.Set(ruletypes.AlertRuleIDLabel, r.ID())
+		lb.Set(ruletypes.RuleSourceLabel, r.GeneratorURL())
+
+		annotations := make(ruletypes.Labels, 0, len(r.annotations.Map()))
+		for name, value := range r.annotations.Map() {
+			annotations = append(annotations, ruletypes.Label{Name: name, Value: expand(value)})
+		}
+
+		lbs := lb.Labels()
+		h := lbs.Hash()
+		resultFPs[h] = struct{}{}
+
+		alerts[h] = &ruletypes.Alert{
+			Labels:            lbs,
+			QueryResultLabels: group.labels,
+			Annotations:       annotations,
+			ActiveAt:          ts,
+			State:             ruletypes.StatePending,
+			Value:             float64(len(aliases)),
+			GeneratorURL:      r.GeneratorURL(),
+			Receivers:         receivers,
+		}
+	}
+
+	r.logger.InfoContext(ctx, "number of composite groups matched", slog.Int("group.count", len(groups)), slog.Int("alert.count", matched))
+
+	for h, a := range alerts {
+		if alert, ok := r.Active[h]; ok && alert.State != ruletypes.StateInactive {
+			alert.Value = a.Value
+			alert.Annotations = a.Annotations
+			alert.Receivers = a.Receivers
+			continue
+		}
+
+		r.Active[h] = a
+	}
+
+	itemsToAdd := []rulestatehistorytypes.RuleStateHistory{}
+
+	for fp, a := range r.Active {
+		labelsJSON, err := json.Marshal(a.QueryResultLabels)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error marshaling labels", errors.Attr(err), slog.Any("alert.labels", a.Labels))
+		}
+		if _, ok := resultFPs[fp]; !ok {
+			// If the alert was previously firing, keep it around for a given
+			// retention time so it is reported as resolved to the AlertManager.
+			if a.State == ruletypes.StatePending || (!a.ResolvedAt.IsZero() && ts.Sub(a.ResolvedAt) > ruletypes.ResolvedRetention) {
+				delete(r.Active, fp)
+			}
+			if a.State != ruletypes.StateInactive {
+				a.State = ruletypes.StateInactive
+				a.ResolvedAt = ts
+				itemsToAdd = append(itemsToAdd, rulestatehistorytypes.RuleStateHistory{
+					RuleID:       r.ID(),
+					RuleName:     r.Name(),
+					State:        ruletypes.StateInactive,
+					StateChanged: true,
+					UnixMilli:    ts.UnixMilli(),
+					Labels:       rulestatehistorytypes.LabelsString(labelsJSON),
+					Fingerprint:  a.QueryResultLabels.Hash(),
+					Value:        a.Value,
+				})
+			}
+			continue
+		}
+
+		if a.State == ruletypes.StatePending && ts.Sub(a.ActiveAt) >= r.holdDuration.Duration() {
+			a.State = ruletypes.StateFiring
+			a.FiredAt = ts
+			itemsToAdd = append(itemsToAdd, rulestatehistorytypes.RuleStateHistory{
+				RuleID:       r.ID(),
+				RuleName:     r.Name(),
+				State:        ruletypes.StateFiring,
+				StateChanged: true,
+				UnixMilli:    ts.UnixMilli(),
+				Labels:       rulestatehistorytypes.LabelsString(labelsJSON),
+				Fingerprint:  a.QueryResultLabels.Hash(),
+				Value:        a.Value,
+			})
+		}
+	}
+
+	currentState := r.State()
+
+	overallStateChanged := currentState != prevState
+	for idx, item := range itemsToAdd {
+		item.OverallStateChanged = overallStateChanged
+		item.OverallState = currentState
+		itemsToAdd[idx] = item
+	}
+
+	_ = r.RecordRuleStateHistory(ctx, itemsToAdd)
+
+	r.health = ruletypes.HealthGood
+	r.lastError = nil
+
+	return len(r.Active), nil
+}
+
+func (r *CompositeRule) String() string {
+	ar := ruletypes.PostableRule{
+		AlertName:         r.name,
+		RuleType:          ruletypes.RuleTypeComposite,
+		RuleCondition:     r.ruleCondition,
+		Labels:            r.labels.Map(),
+		Annotations:       r.annotations.Map(),
+		PreferredChannels: r.preferredChannels,
+	}
+
+	byt, err := json.Marshal(ar)
+	if err != nil {
+		return fmt.Sprintf("error marshaling alerting rule: %s", err.Error())
+	}
+
+	return string(byt)
+}
@@ -0,0 +1,235 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"
+	alertmanagermock "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/modules/rulestatehistory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/rulestatehistorytypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	latencyRuleID = "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a01"
+	errorsRuleID  = "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a02"
+	deployRuleID  = "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a03"
+)
+
+// childRule is a rule whose active alerts are set by the test.
+type childRule struct {
+	*BaseRule
+}
+
+func (r *childRule) Type() ruletypes.RuleType                     { return ruletypes.RuleTypeThreshold }
+func (r *childRule) Eval(context.Context, time.Time) (int, error) { return len(r.Active), nil }
+func (r *childRule) String() string                               { return r.id }
+
+func newChildRule(id string, orgID valuer.UUID) *childRule {
+	return &childRule{BaseRule: &BaseRule{id: id, orgID: orgID, Active: map[uint64]*ruletypes.Alert{}}}
+}
+
+func (r *childRule) setFiring(labels ...map[string]string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.Active = map[uint64]*ruletypes.Alert{}
+	for _, l := range labels {
+		lbls := ruletypes.FromMap(l)
+		r.Active[lbls.Hash()] = &ruletypes.Alert{Labels: lbls, State: ruletypes.StateFiring}
+	}
+}
+
+type recordingStateHistory struct {
+	rulestatehistory.Module
+	items []rulestatehistorytypes.RuleStateHistory
+}
+
+func (h *recordingStateHistory) RecordRuleStateHistory(_ context.Context, _ string, _ bool, items []rulestatehistorytypes.RuleStateHistory) error {
+	h.items = append(h.items, items...)
+	return nil
+}
+
+func compositeRuleJSON(expression string, matchLabels ...string) string {
+	rule := map[string]any{
+		"alert":    "CheckoutDegraded",
+		"version":  "v5",
+		"ruleType": "composite_rule",
+		"labels":   map[string]string{"severity": "critical"},
+		"condition": map[string]any{
+			"composite": map[string]any{
+				"expression": expression,
+				"rules": []map[string]string{
+					{"alias": "high_latency", "ruleId": latencyRuleID},
+					{"alias": "error_rate_up", "ruleId": errorsRuleID},
+					{"alias": "deploy_in_progress", "ruleId": deployRuleID},
+				},
+				"matchLabels": matchLabels,
+			},
+		},
+	}
+	out, _ := json.Marshal(rule)
+	return string(out)
+}
+
+func newTestCompositeRule(t *testing.T, orgID valuer.UUID, children map[string]Rule, history rulestatehistory.Module, matchLabels ...string) *CompositeRule {
+	t.Helper()
+
+	var p ruletypes.PostableRule
+	require.NoError(t, json.Unmarshal([]byte(compositeRuleJSON("high_latency AND error_rate_up AND NOT deploy_in_progress", matchLabels...)), &p))
+	require.NoError(t, p.Validate())
+
+	lookup := func(id string) (Rule, bool) {
+		r, ok := children[id]
+		return r, ok
+	}
+
+	rule, err := NewCompositeRule(
+		"0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0aff",
+		orgID,
+		&p,
+		lookup,
+		instrumentationtest.New().Logger(),
+		mustParseURL(t, "http://localhost:8080"),
+		WithRuleStateHistoryModule(history),
+	)
+	require.NoError(t, err)
+	return rule
+}
+
+func TestCompositeRuleEvalWithMatchLabels(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	latency := newChildRule(latencyRuleID, orgID)
+	errorsUp := newChildRule(errorsRuleID, orgID)
+	deploy := newChildRule(deployRuleID, orgID)
+	history := &recordingStateHistory{}
+
+	rule := newTestCompositeRule(t, orgID, map[string]Rule{
+		latencyRuleID: latency,
+		errorsRuleID:  errorsUp,
+		deployRuleID:  deploy,
+	}, history, "service.name")
+
+	latency.setFiring(map[string]string{"service.name": "checkout"}, map[string]string{"service.name": "cart"})
+	errorsUp.setFiring(map[string]string{"service.name": "checkout", "threshold.name": "warning"})
+	deploy.setFiring(map[string]string{"service.name": "cart"})
+
+	ts := time.Now()
+	count, err := rule.Eval(context.Background(), ts)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	alerts := rule.ActiveAlerts()
+	require.Len(t, alerts, 1)
+	labels := alerts[0].Labels.Map()
+	assert.Equal(t, "checkout", labels["service.name"])
+	assert.Equal(t, "critical", labels[ruletypes.LabelThresholdName])
+	assert.Equal(t, "CheckoutDegraded", labels[ruletypes.AlertNameLabel])
+	assert.Equal(t, ruletypes.StateFiring, alerts[0].State)
+	assert.Equal(t, ruletypes.StateFiring, rule.State())
+
+	require.Len(t, history.items, 1)
+	assert.Equal(t, ruletypes.StateFiring, history.items[0].State)
+	assert.Equal(t, rulestatehistorytypes.LabelsString(`{"service.name":"checkout"}`), history.items[0].Labels)
+	assert.True(t, history.items[0].OverallStateChanged)
+
+	// a deploy without a service.name label matches every service
+	deploy.setFiring(map[string]string{"env": "prod"})
+
+	count, err = rule.Eval(context.Background(), ts.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Empty(t, rule.ActiveAlerts())
+	assert.Equal(t, ruletypes.StateInactive, rule.State())
+
+	require.Len(t, history.items, 2)
+	assert.Equal(t, ruletypes.StateInactive, history.items[1].State)
+	assert.Equal(t, ruletypes.StateInactive, history.items[1].OverallState)
+}
+
+func TestCompositeRuleEvalWithoutMatchLabels(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	latency := newChildRule(latencyRuleID, orgID)
+	errorsUp := newChildRule(errorsRuleID, orgID)
+
+	// deploy_in_progress belongs to another org and is treated as not firing
+	deploy := newChildRule(deployRuleID, valuer.GenerateUUID())
+	deploy.setFiring(map[string]string{"service.name": "checkout"})
+
+	rule := newTestCompositeRule(t, orgID, map[string]Rule{
+		latencyRuleID: latency,
+		errorsRuleID:  errorsUp,
+		deployRuleID:  deploy,
+	}, nil)
+
+	latency.setFiring(map[string]string{"service.name": "checkout"})
+	errorsUp.setFiring(map[string]string{"service.name": "cart"})
+
+	count, err := rule.Eval(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	alerts := rule.ActiveAlerts()
+	require.Len(t, alerts, 1)
+	_, ok := alerts[0].Labels.Map()["service.name"]
+	assert.False(t, ok)
+}
+
+func TestCompositeRuleRejectsSelfReference(t *testing.T) {
+	var p ruletypes.PostableRule
+	require.NoError(t, json.Unmarshal([]byte(compositeRuleJSON("high_latency AND error_rate_up AND NOT deploy_in_progress")), &p))
+
+	_, err := NewCompositeRule(latencyRuleID, valuer.GenerateUUID(), &p, nil, instrumentationtest.New().Logger(), mustParseURL(t, "http://localhost:8080"))
+	require.Error(t, err)
+}
+
+func TestManager_TestNotification_CompositeRule(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	triggeredTestAlerts := []map[*alertmanagertypes.PostableAlert][]string{}
+
+	mgr := NewTestManager(t, &TestManagerOptions{
+		AlertmanagerHook: func(am alertmanager.Alertmanager) {
+			mockAM := am.(*alertmanagermock.MockAlertmanager)
+			mockAM.On("SetNotificationConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockAM.On("Config").Return(alertmanagerserver.Config{ExternalURL: mustParseURL(t, "http://localhost:8080")})
+			mockAM.On("TestAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				triggeredTestAlerts = append(triggeredTestAlerts, args.Get(3).(map[*alertmanagertypes.PostableAlert][]string))
+			}).Return(nil).Once()
+		},
+		SqlStoreHook: func(store sqlstore.SQLStore) {
+			mockStore := store.(*sqlstoretest.Provider)
+			orgRows := mockStore.Mock().NewRows([]string{"id"}).AddRow(orgID.StringValue())
+			mockStore.Mock().ExpectQuery("SELECT (.+) FROM (.+)organizations(.+) LIMIT (.+)").WillReturnRows(orgRows)
+		},
+	})
+
+	latency := newChildRule(latencyRuleID, orgID)
+	latency.setFiring(map[string]string{"service.name": "checkout"})
+	errorsUp := newChildRule(errorsRuleID, orgID)
+	errorsUp.setFiring(map[string]string{"service.name": "checkout"})
+	mgr.ruleIndex.set(latency)
+	mgr.ruleIndex.set(errorsUp)
+
+	count, err := mgr.TestNotification(context.Background(), orgID, compositeRuleJSON("high_latency AND error_rate_up AND NOT deploy_in_progress", "service.name"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.Len(t, triggeredTestAlerts, 1)
+	require.Len(t, triggeredTestAlerts[0], 1)
+	for alert := range triggeredTestAlerts[0] {
+		assert.Equal(t, "checkout", alert.Labels["service.name"])
+		assert.Equal(t, "true", alert.Labels[ruletypes.TestAlertLabel])
+	}
+}
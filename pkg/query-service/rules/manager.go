@@ -42,6 +42,8 @@ type PrepareTaskOptions struct {
 	NotifyFunc  NotifyFunc
 	SQLStore    sqlstore.SQLStore
 	OrgID       valuer.UUID
+	// LookupRule resolves the child rules of composite rules.
+	LookupRule RuleLookup
 }
 
 type PrepareTestRuleOptions struct {
@@ -54,6 +56,8 @@ type PrepareTestRuleOptions struct {
 	NotifyFunc  NotifyFunc
 	SQLStore    sqlstore.SQLStore
 	OrgID       valuer.UUID
+	// LookupRule resolves the child rules of composite rules.
+	LookupRule RuleLookup
 }
 
 const taskNameSuffix = "webAppEditor"
@@ -80,6 +84,10 @@ type ManagerOptions struct {
 
 	EvalDelay valuer.TextDuration
 
+	// SyncInterval is how often the manager loads the rules of the orgs the
+	// instance has come to own and drops the rules of the orgs it has lost.
+	SyncInterval time.Duration
+
 	RuleStateHistoryModule rulestatehistory.Module
 
 	PrepareTaskFunc     func(opts PrepareTaskOptions) (Task, error)
@@ -97,8 +105,10 @@ type Manager struct {
 	opts  *ManagerOptions
 	tasks map[string]Task
 	rules map[string]Rule
-	mtx   sync.RWMutex
-	block chan struct{}
+	// ruleIndex mirrors rules for composite rules to look up their children
+	ruleIndex *ruleIndex
+	mtx       sync.RWMutex
+	block     chan struct{}
 	// datastore to store alert definitions
 	ruleStore        ruletypes.RuleStore
 	maintenanceStore alertmanagertypes.MaintenanceStore
@@ -113,12 +123,20 @@ type Manager struct {
 	orgGetter    organization.Getter
 	// queryParser is used for parsing queries for rules
 	queryParser queryparser.QueryParser
+
+	// ownedOrgs are the orgs whose rules are loaded. It is only used by
+	// syncOwnedOrgs, which never runs concurrently.
+	ownedOrgs map[string]struct{}
+	stopC     chan struct{}
 }
 
 func defaultOptions(o *ManagerOptions) *ManagerOptions {
 	if o.ResendDelay == time.Duration(0) {
 		o.ResendDelay = 1 * time.Minute
 	}
+	if o.SyncInterval == time.Duration(0) {
+		o.SyncInterval = 1 * time.Minute
+	}
 	if o.Logger == nil {
 		o.Logger = slog.Default()
 	}
@@ -190,8 +208,58 @@ func defaultPrepareTaskFunc(opts PrepareTaskOptions) (Task, error) {
 		// create promql rule task for evaluation
 		task = newTask(TaskTypeProm, opts.TaskName, taskNameSuffix, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc)
 
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeComposite {
+
+		// create composite rule
+		cr, err := NewCompositeRule(
+			ruleID,
+			opts.OrgID,
+			opts.Rule,
+			opts.LookupRule,
+			opts.Logger,
+			opts.ManagerOpts.Alertmanager.Config().ExternalURL,
+			WithSQLStore(opts.SQLStore),
+			WithRuleStateHistoryModule(opts.ManagerOpts.RuleStateHistoryModule),
+		)
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, cr)
+
+		// composite rules run no queries, the ch task just drives evaluation
+		task = newTask(TaskTypeCh, opts.TaskName, taskNameSuffix, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc)
+
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeRecording {
+
+		// create recording rule
+		rr, err := NewRecordingRule(
+			ruleID,
+			opts.OrgID,
+			opts.Rule,
+			opts.Querier,
+			opts.ManagerOpts.Prometheus,
+			opts.ManagerOpts.TelemetryStore,
+			opts.Logger,
+			opts.ManagerOpts.Alertmanager.Config().ExternalURL,
+			WithEvalDelay(opts.ManagerOpts.EvalDelay),
+			WithSQLStore(opts.SQLStore),
+			WithRuleStateHistoryModule(opts.ManagerOpts.RuleStateHistoryModule),
+		)
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, rr)
+
+		var taskType TaskType = TaskTypeCh
+		if opts.Rule.RuleCondition.CompositeQuery.QueryType == ruletypes.QueryTypePromQL {
+			taskType = TaskTypeProm
+		}
+		task = newTask(taskType, opts.TaskName, taskNameSuffix, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc)
+
 	} else {
-		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported rule type %s. Supported types: %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold)
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported rule type %s. Supported types: %s, %s, %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold, ruletypes.RuleTypeComposite, ruletypes.RuleTypeRecording)
 	}
 
 	return task, nil
@@ -205,6 +273,7 @@ func NewManager(o *ManagerOptions) (*Manager, error) {
 	m := &Manager{
 		tasks:               map[string]Task{},
 		rules:               map[string]Rule{},
+		ruleIndex:           newRuleIndex(),
 		ruleStore:           o.RuleStore,
 		maintenanceStore:    o.MaintenanceStore,
 		opts:                o,
@@ -217,6 +286,8 @@ func NewManager(o *ManagerOptions) (*Manager, error) {
 		orgGetter:           o.OrgGetter,
 		sqlstore:            o.SQLStore,
 		queryParser:         o.QueryParser,
+		ownedOrgs:           map[string]struct{}{},
+		stopC:               make(chan struct{}),
 	}
 
 	m.logger.Debug("manager created successfully with notification group")
@@ -224,7 +295,7 @@ func NewManager(o *ManagerOptions) (*Manager, error) {
 }
 
 func (m *Manager) Start(ctx context.Context) {
-	if err := m.initiate(ctx); err != nil {
+	if err := m.syncOwnedOrgs(ctx); err != nil {
 		m.logger.ErrorContext(ctx, "failed to initialize alerting rules manager", errors.Attr(err))
 	}
 	m.run(ctx)
@@ -247,65 +318,124 @@ func (m *Manager) Pause(b bool) {
 	}
 }
 
-func (m *Manager) initiate(ctx context.Context) error {
+// syncOwnedOrgs loads the rules of the orgs the instance owns and has not
+// loaded yet, and drops the rules of the orgs it does not own anymore.
+func (m *Manager) syncOwnedOrgs(ctx context.Context) error {
 	orgs, err := m.orgGetter.ListByOwnedKeyRange(ctx)
 	if err != nil {
 		return err
 	}
 
-	var loadErrors []error
+	var syncErrors []error
+	owned := make(map[string]struct{}, len(orgs))
 	for _, org := range orgs {
+		owned[org.ID.StringValue()] = struct{}{}
+		if _, ok := m.ownedOrgs[org.ID.StringValue()]; ok {
+			continue
+		}
+
 		storedRules, err := m.ruleStore.GetStoredRules(ctx, org.ID.StringValue())
 		if err != nil {
-			return err
+			syncErrors = append(syncErrors, err)
+			continue
 		}
-		if len(storedRules) == 0 {
-			return nil
+
+		m.ownedOrgs[org.ID.StringValue()] = struct{}{}
+		syncErrors = append(syncErrors, m.loadRules(ctx, org.ID, storedRules)...)
+	}
+
+	for orgID := range m.ownedOrgs {
+		if _, ok := owned[orgID]; ok {
+			continue
 		}
 
+		storedRules, err := m.ruleStore.GetStoredRules(ctx, orgID)
+		if err != nil {
+			syncErrors = append(syncErrors, err)
+			continue
+		}
+
+		m.logger.InfoContext(ctx, "dropping the rules of an org owned by another instance", slog.String("org_id", orgID))
 		for _, rec := range storedRules {
-			taskName := fmt.Sprintf("%s-groupname", rec.ID.StringValue())
-			parsedRule := ruletypes.PostableRule{}
+			m.deleteTask(prepareTaskName(rec.ID.StringValue()))
+		}
+		delete(m.ownedOrgs, orgID)
+	}
+
+	if len(syncErrors) > 0 {
+		return errors.Join(syncErrors...)
+	}
+
+	return nil
+}
+
+func (m *Manager) loadRules(ctx context.Context, orgID valuer.UUID, storedRules []*ruletypes.StorableRule) []error {
+	var loadErrors []error
+	for _, rec := range storedRules {
+		taskName := prepareTaskName(rec.ID.StringValue())
+		parsedRule := ruletypes.PostableRule{}
+
+		err := json.Unmarshal([]byte(rec.Data), &parsedRule)
+		if err != nil {
+			m.logger.InfoContext(ctx, "failed to load rule in json format", "name", taskName)
+			loadErrors = append(loadErrors, err)
+			continue
+		}
 
-			err := json.Unmarshal([]byte(rec.Data), &parsedRule)
+		if parsedRule.NotificationSettings != nil {
+			config := parsedRule.NotificationSettings.GetAlertManagerNotificationConfig()
+			err = m.alertmanager.SetNotificationConfig(ctx, orgID, rec.ID.StringValue(), &config)
 			if err != nil {
-				m.logger.InfoContext(ctx, "failed to load rule in json format", "name", taskName)
 				loadErrors = append(loadErrors, err)
+				m.logger.WarnContext(ctx, "failed to set rule notification config", slog.String("rule.id", rec.ID.StringValue()), errors.Attr(err))
+			}
+		}
+		if !parsedRule.Disabled {
+			// Rules created after the org was owned by the instance run already.
+			m.mtx.RLock()
+			_, ok := m.tasks[taskName]
+			m.mtx.RUnlock()
+			if ok {
 				continue
 			}
 
-			if parsedRule.NotificationSettings != nil {
-				config := parsedRule.NotificationSettings.GetAlertManagerNotificationConfig()
-				err = m.alertmanager.SetNotificationConfig(ctx, org.ID, rec.ID.StringValue(), &config)
-				if err != nil {
-					loadErrors = append(loadErrors, err)
-					m.logger.WarnContext(ctx, "failed to set rule notification config", slog.String("rule.id", rec.ID.StringValue()), errors.Attr(err))
-				}
-			}
-			if !parsedRule.Disabled {
-				err := m.addTask(ctx, org.ID, &parsedRule, taskName)
-				if err != nil {
-					m.logger.ErrorContext(ctx, "failed to load the rule definition", "name", taskName, errors.Attr(err))
-				}
+			err := m.addTask(ctx, orgID, &parsedRule, taskName)
+			if err != nil {
+				m.logger.ErrorContext(ctx, "failed to load the rule definition", "name", taskName, errors.Attr(err))
 			}
 		}
 	}
 
-	if len(loadErrors) > 0 {
-		return errors.Join(loadErrors...)
-	}
-
-	return nil
+	return loadErrors
 }
 
 // Run starts processing of the rule manager.
-func (m *Manager) run(_ context.Context) {
+func (m *Manager) run(ctx context.Context) {
 	// initiate blocked tasks
 	close(m.block)
+
+	// The orgs owned by the instance change as instances join or leave.
+	go func() {
+		ticker := time.NewTicker(m.opts.SyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stopC:
+				return
+			case <-ticker.C:
+				if err := m.syncOwnedOrgs(ctx); err != nil {
+					m.logger.ErrorContext(ctx, "failed to sync the rules of owned orgs", errors.Attr(err))
+				}
+			}
+		}
+	}()
 }
 
 // Stop the rule manager's rule evaluation cycles.
 func (m *Manager) Stop(_ context.Context) {
+	close(m.stopC)
+
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
@@ -438,6 +568,7 @@ func (m *Manager) editTask(_ context.Context, orgID valuer.UUID, rule *ruletypes
 		NotifyFunc:  m.notifyFunc,
 		SQLStore:    m.sqlstore,
 		OrgID:       orgID,
+		LookupRule:  m.ruleIndex.lookup,
 	})
 	if err != nil {
 		m.logger.Error("loading tasks failed", errors.Attr(err))
@@ -446,6 +577,7 @@ func (m *Manager) editTask(_ context.Context, orgID valuer.UUID, rule *ruletypes
 
 	for _, r := range newTask.Rules() {
 		m.rules[r.ID()] = r
+		m.ruleIndex.set(r)
 	}
 
 	// If there is an old task with the same identifier, stop it and wait for
@@ -543,6 +675,7 @@ func (m *Manager) deleteTask(taskName string) {
 		oldg.Stop()
 		delete(m.tasks, taskName)
 		delete(m.rules, RuleIDFromTaskName(taskName))
+		m.ruleIndex.delete(RuleIDFromTaskName(taskName))
 		m.logger.Debug("rule task deleted", "name", taskName)
 	} else {
 		m.logger.Info("rule not found for deletion", "name", taskName)
@@ -649,6 +782,7 @@ func (m *Manager) addTask(_ context.Context, orgID valuer.UUID, rule *ruletypes.
 		NotifyFunc:  m.notifyFunc,
 		SQLStore:    m.sqlstore,
 		OrgID:       orgID,
+		LookupRule:  m.ruleIndex.lookup,
 	})
 	if err != nil {
 		m.logger.Error("creating rule task failed", "name", taskName, errors.Attr(err))
@@ -657,6 +791,7 @@ func (m *Manager) addTask(_ context.Context, orgID valuer.UUID, rule *ruletypes.
 
 	for _, r := range newTask.Rules() {
 		m.rules[r.ID()] = r
+		m.ruleIndex.set(r)
 	}
 
 	// If there is another task with the same identifier, raise an error
@@ -1030,6 +1165,7 @@ func (m *Manager) TestNotification(ctx context.Context, orgID valuer.UUID, ruleS
 		NotifyFunc:  m.testNotifyFunc,
 		SQLStore:    m.sqlstore,
 		OrgID:       orgID,
+		LookupRule:  m.ruleIndex.lookup,
 	})
 
 	return alertCount, err
@@ -12,14 +12,17 @@ import (
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"
 	alertmanagermock "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertest"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/prometheus/prometheustest"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -288,3 +291,82 @@ func TestManager_TestNotification_SendUnmatched_PromRule(t *testing.T) {
 		})
 	}
 }
+
+// ownedOrgGetter owns the orgs set by the test.
+type ownedOrgGetter struct {
+	organization.Getter
+	orgs []*types.Organization
+}
+
+func (g *ownedOrgGetter) ListByOwnedKeyRange(context.Context) ([]*types.Organization, error) {
+	return g.orgs, nil
+}
+
+type orgRuleStore struct {
+	ruletypes.RuleStore
+	rules map[string][]*ruletypes.StorableRule
+}
+
+func (s *orgRuleStore) GetStoredRules(_ context.Context, orgID string) ([]*ruletypes.StorableRule, error) {
+	return s.rules[orgID], nil
+}
+
+func TestManager_SyncOwnedOrgs(t *testing.T) {
+	checkout := &types.Organization{Identifiable: types.Identifiable{ID: valuer.GenerateUUID()}}
+	payments := &types.Organization{Identifiable: types.Identifiable{ID: valuer.GenerateUUID()}}
+
+	data, err := json.Marshal(ThresholdRuleAtLeastOnceValueAbove(10, nil))
+	require.NoError(t, err)
+
+	storedRule := func(orgID valuer.UUID) *ruletypes.StorableRule {
+		return &ruletypes.StorableRule{Identifiable: types.Identifiable{ID: valuer.GenerateUUID()}, Data: string(data), OrgID: orgID.StringValue()}
+	}
+	checkoutRules := []*ruletypes.StorableRule{storedRule(checkout.ID), storedRule(checkout.ID)}
+	paymentsRules := []*ruletypes.StorableRule{storedRule(payments.ID)}
+
+	orgGetter := &ownedOrgGetter{orgs: []*types.Organization{checkout}}
+	ruleStore := &orgRuleStore{rules: map[string][]*ruletypes.StorableRule{
+		checkout.ID.StringValue(): checkoutRules,
+		payments.ID.StringValue(): paymentsRules,
+	}}
+
+	mgr := NewTestManager(t, &TestManagerOptions{
+		AlertmanagerHook: func(am alertmanager.Alertmanager) {
+			mockAM := am.(*alertmanagermock.MockAlertmanager)
+			mockAM.On("SetNotificationConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockAM.On("Config").Return(alertmanagerserver.Config{ExternalURL: mustParseURL(t, "http://localhost:8080")}).Maybe()
+		},
+		ManagerOptionsHook: func(opts *ManagerOptions) {
+			opts.Context = context.Background()
+			opts.OrgGetter = orgGetter
+			opts.RuleStore = ruleStore
+		},
+	})
+
+	// Tasks only stop once they run.
+	mgr.run(context.Background())
+	defer mgr.Stop(context.Background())
+
+	taskNames := func() []string {
+		var names []string
+		for _, task := range mgr.RuleTasks() {
+			names = append(names, task.Name())
+		}
+		return names
+	}
+
+	require.NoError(t, mgr.syncOwnedOrgs(context.Background()))
+	assert.ElementsMatch(t, []string{prepareTaskName(checkoutRules[0].ID.StringValue()), prepareTaskName(checkoutRules[1].ID.StringValue())}, taskNames())
+
+	// Syncing again keeps the tasks of the orgs loaded already.
+	require.NoError(t, mgr.syncOwnedOrgs(context.Background()))
+	assert.Len(t, taskNames(), 2)
+
+	// The instance has taken payments over from another one and given
+	// checkout away.
+	orgGetter.orgs = []*types.Organization{payments}
+
+	require.NoError(t, mgr.syncOwnedOrgs(context.Background()))
+	assert.ElementsMatch(t, []string{prepareTaskName(paymentsRules[0].ID.StringValue())}, taskNames())
+	assert.Len(t, mgr.Rules(), 1)
+}
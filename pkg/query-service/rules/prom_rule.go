@@ -65,8 +65,12 @@ func (r *PromRule) Type() ruletypes.RuleType {
 }
 
 func (r *PromRule) getPqlQuery(ctx context.Context) (string, error) {
-	selectedQuery := r.SelectedQuery(ctx)
-	for _, item := range r.ruleCondition.CompositeQuery.Queries {
+	return selectedPromQuery(r.ruleCondition.CompositeQuery.Queries, r.SelectedQuery(ctx))
+}
+
+// selectedPromQuery returns the PromQL expression of the selected query.
+func selectedPromQuery(queries []qbtypes.QueryEnvelope, selectedQuery string) (string, error) {
+	for _, item := range queries {
 		switch item.Type {
 		case qbtypes.QueryTypePromQL:
 			promQuery, ok := item.Spec.(qbtypes.PromQuery)
@@ -376,7 +380,13 @@ func (r *PromRule) String() string {
 }
 
 func (r *PromRule) RunAlertQuery(ctx context.Context, qs string, start, end time.Time, interval time.Duration) (promql.Matrix, error) {
-	q, err := r.prometheus.Engine().NewRangeQuery(ctx, r.prometheus.Storage(), nil, qs, start, end, interval)
+	return runPromQuery(ctx, r.prometheus, qs, start, end, interval)
+}
+
+// runPromQuery runs a range query and returns its result as a matrix, instant
+// vectors and scalars become series of a single point.
+func runPromQuery(ctx context.Context, prom prometheus.Prometheus, qs string, start, end time.Time, interval time.Duration) (promql.Matrix, error) {
+	q, err := prom.Engine().NewRangeQuery(ctx, prom.Storage(), nil, qs, start, end, interval)
 	if err != nil {
 		return nil, err
 	}
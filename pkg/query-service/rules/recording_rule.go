@@ -0,0 +1,582 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	sqlbuilder "github.com/huandu/go-sqlbuilder"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/telemetrymetrics"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rulestatehistorytypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// recordingEnv is the env of the recorded metrics, the collector writes
+	// every metric with it.
+	recordingEnv     = "default"
+	temporalityLabel = "__temporality__"
+)
+
+var (
+	// recordingChunkSteps is the most steps a single query of a recording
+	// rule spans. Longer spans, as the backfill, are queried in chunks so
+	// every query keeps the step of the rule.
+	recordingChunkSteps = int64(300)
+
+	// recordingChunksPerEval is the most chunks an evaluation queries. A
+	// longer backfill carries on over the next evaluations.
+	recordingChunksPerEval = int64(4)
+)
+
+// RecordingRule materializes the results of a query into a new metric. On
+// every evaluation it runs the selected query of the rule and writes the
+// points it has not written before to the metric as a gauge, so dashboards
+// and other rules can read the precomputed series instead of running the
+// query. It raises no alerts.
+type RecordingRule struct {
+	*BaseRule
+
+	querier        querier.Querier
+	prometheus     prometheus.Prometheus
+	telemetryStore telemetrystore.TelemetryStore
+
+	recording *ruletypes.RecordingCondition
+	metric    recordedMetric
+
+	// resumed is set once the rule has looked up the latest point of its
+	// metric. recordedUntil is the time of that point and moves forward with
+	// every point written. queriedUntil is the end of the span queried last,
+	// the next evaluation carries on from it while backfilling.
+	resumed       bool
+	recordedUntil int64
+	queriedUntil  int64
+
+	// state is nodata while the query returns no series.
+	state ruletypes.AlertState
+}
+
+var _ Rule = (*RecordingRule)(nil)
+
+func NewRecordingRule(
+	id string,
+	orgID valuer.UUID,
+	p *ruletypes.PostableRule,
+	querier querier.Querier,
+	prometheus prometheus.Prometheus,
+	telemetryStore telemetrystore.TelemetryStore,
+	logger *slog.Logger,
+	externalURL *url.URL,
+	opts ...RuleOption,
+) (*RecordingRule, error) {
+	logger.Info("creating new RecordingRule", slog.String("rule.id", id))
+
+	if p.RuleCondition == nil || p.RuleCondition.Recording == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.recording: field is required for ruleType %q", ruletypes.RuleTypeRecording.StringValue())
+	}
+	if p.RuleCondition.CompositeQuery == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.compositeQuery: field is required")
+	}
+
+	opts = append(opts, WithLogger(logger))
+
+	baseRule, err := NewBaseRule(id, orgID, p, externalURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordingRule{
+		BaseRule:       baseRule,
+		querier:        querier,
+		prometheus:     prometheus,
+		telemetryStore: telemetryStore,
+		recording:      p.RuleCondition.Recording,
+		metric:         newRecordedMetric(p.RuleCondition),
+		state:          ruletypes.StateInactive,
+	}, nil
+}
+
+func (r *RecordingRule) Type() ruletypes.RuleType {
+	return ruletypes.RuleTypeRecording
+}
+
+func (r *RecordingRule) State() ruletypes.AlertState {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.state
+}
+
+// SendAlerts does nothing, recording rules raise no alerts.
+func (r *RecordingRule) SendAlerts(context.Context, time.Time, time.Duration, time.Duration, NotifyFunc) {
+}
+
+// step returns the interval between the points written to the metric in
+// milliseconds, one point per evaluation.
+func (r *RecordingRule) step() int64 {
+	if step := r.evaluation.GetFrequency().Milliseconds(); step > 0 {
+		return step
+	}
+	return time.Minute.Milliseconds()
+}
+
+// window returns the span of the evaluation at ts in milliseconds. Both ends
+// are aligned to the step so the query returns whole steps only.
+func (r *RecordingRule) window(ts time.Time) (int64, int64) {
+	step := r.step()
+	_, endTs := r.Timestamps(ts)
+	end := endTs.UnixMilli() - endTs.UnixMilli()%step
+	start := end - max(r.evalWindow.Milliseconds(), step)
+	return start - start%step, end
+}
+
+func (r *RecordingRule) Eval(ctx context.Context, ts time.Time) (int, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "rules",
+		instrumentationtypes.CodeFunctionName: "recordingRuleEval",
+	})
+
+	step := r.step()
+	start, end := r.window(ts)
+
+	if !r.resumed {
+		if r.recording.Backfill.IsPositive() {
+			backfillStart := end - r.recording.Backfill.Milliseconds()
+			start = min(start, backfillStart-backfillStart%step)
+		}
+		recordedUntil, err := r.lastRecorded(ctx, start)
+		if err != nil {
+			return 0, err
+		}
+		r.recordedUntil = recordedUntil
+		r.queriedUntil = start
+		r.resumed = true
+	}
+	start = min(start, r.queriedUntil)
+	if r.recordedUntil > start {
+		start = r.recordedUntil - r.recordedUntil%step
+	}
+	end = min(end, start+recordingChunksPerEval*recordingChunkSteps*step)
+
+	var found bool
+	var written int
+	for from := start; from < end; from += recordingChunkSteps * step {
+		to := min(end, from+recordingChunkSteps*step)
+
+		series, err := r.query(ctx, from, to)
+		if err != nil {
+			return 0, err
+		}
+		found = found || len(series) > 0
+
+		n, err := r.record(ctx, series)
+		if err != nil {
+			return 0, err
+		}
+		written += n
+		r.queriedUntil = to
+	}
+
+	r.logger.InfoContext(ctx, "recorded points", slog.String("recording.metric_name", r.recording.MetricName), slog.Int("recording.points", written))
+
+	if start < end {
+		state := ruletypes.StateInactive
+		if !found {
+			state = ruletypes.StateNoData
+		}
+		if prevState := r.State(); state != prevState {
+			r.mtx.Lock()
+			r.state = state
+			r.mtx.Unlock()
+
+			_ = r.RecordRuleStateHistory(ctx, []rulestatehistorytypes.RuleStateHistory{{
+				RuleID:              r.ID(),
+				RuleName:            r.Name(),
+				OverallState:        state,
+				OverallStateChanged: true,
+				State:               state,
+				StateChanged:        true,
+				UnixMilli:           ts.UnixMilli(),
+				Labels:              rulestatehistorytypes.LabelsString("{}"),
+				Value:               float64(written),
+			}})
+		}
+	}
+
+	r.SetHealth(ruletypes.HealthGood)
+	r.SetLastError(nil)
+
+	return 0, nil
+}
+
+// query runs the selected query of the rule over [start, end) and returns the
+// points of its series which are complete.
+func (r *RecordingRule) query(ctx context.Context, start, end int64) ([]*qbtypes.TimeSeries, error) {
+	if r.ruleCondition.CompositeQuery.QueryType == ruletypes.QueryTypePromQL {
+		return r.queryPromQL(ctx, start, end)
+	}
+	return r.queryBuilder(ctx, start, end)
+}
+
+func (r *RecordingRule) queryBuilder(ctx context.Context, start, end int64) ([]*qbtypes.TimeSeries, error) {
+	req := &qbtypes.QueryRangeRequest{
+		Start:       uint64(start),
+		End:         uint64(end),
+		RequestType: qbtypes.RequestTypeTimeSeries,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: withStepInterval(r.ruleCondition.CompositeQuery.Queries, time.Duration(r.step())*time.Millisecond),
+		},
+		NoCache: true,
+	}
+
+	resp, err := r.querier.QueryRange(ctx, r.orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedQuery := r.SelectedQuery(ctx)
+	for _, item := range resp.Data.Results {
+		tsData, ok := item.(*qbtypes.TimeSeriesData)
+		if !ok {
+			r.logger.WarnContext(ctx, "expected qbtypes.TimeSeriesData but got unexpected type", slog.String("item.type", reflect.TypeOf(item).String()))
+			continue
+		}
+		if tsData.QueryName != selectedQuery || len(tsData.Aggregations) == 0 || tsData.Aggregations[0] == nil {
+			continue
+		}
+
+		series := make([]*qbtypes.TimeSeries, 0, len(tsData.Aggregations[0].Series))
+		for _, s := range tsData.Aggregations[0].Series {
+			series = append(series, &qbtypes.TimeSeries{Labels: s.Labels, Values: s.EvaluableValues()})
+		}
+		return series, nil
+	}
+
+	return nil, nil
+}
+
+func (r *RecordingRule) queryPromQL(ctx context.Context, start, end int64) ([]*qbtypes.TimeSeries, error) {
+	q, err := selectedPromQuery(r.ruleCondition.CompositeQuery.Queries, r.SelectedQuery(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// The range query evaluates at both ends, the point at the end belongs
+	// to the next window.
+	res, err := runPromQuery(ctx, r.prometheus, q, time.UnixMilli(start), time.UnixMilli(end-1), time.Duration(r.step())*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]*qbtypes.TimeSeries, 0, len(res))
+	for _, s := range res {
+		ts := toCommonSeries(s)
+		ts.Values = ts.EvaluableValues()
+		series = append(series, ts)
+	}
+	return series, nil
+}
+
+// withStepInterval returns a copy of the queries in which the builder queries
+// without a step of their own use the given one.
+func withStepInterval(queries []qbtypes.QueryEnvelope, step time.Duration) []qbtypes.QueryEnvelope {
+	result := make([]qbtypes.QueryEnvelope, len(queries))
+	for i, query := range queries {
+		switch spec := query.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			query.Spec = defaultStepInterval(spec, step)
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			query.Spec = defaultStepInterval(spec, step)
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			query.Spec = defaultStepInterval(spec, step)
+		}
+		result[i] = query
+	}
+	return result
+}
+
+func defaultStepInterval[T any](spec qbtypes.QueryBuilderQuery[T], step time.Duration) qbtypes.QueryBuilderQuery[T] {
+	if spec.StepInterval.Duration == 0 {
+		spec.StepInterval = qbtypes.Step{Duration: step}
+	}
+	return spec
+}
+
+// lastRecorded returns the time of the latest point the rule wrote to the
+// metric since the given time, zero when there is none. Only the series
+// labelled with the id of the rule count, the metric name may be shared.
+func (r *RecordingRule) lastRecorded(ctx context.Context, since int64) (int64, error) {
+	fingerprints := sqlbuilder.NewSelectBuilder()
+	fingerprints.Select("fingerprint")
+	fingerprints.From(fmt.Sprintf("%s.%s", telemetrymetrics.DBName, telemetrymetrics.TimeseriesV4TableName))
+	fingerprints.Where(
+		fingerprints.E("metric_name", r.recording.MetricName),
+		fingerprints.GE("unix_milli", since-since%time.Hour.Milliseconds()),
+		fmt.Sprintf("JSONExtractString(labels, %s) = %s", fingerprints.Var(ruletypes.AlertRuleIDLabel), fingerprints.Var(r.ID())),
+	)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("max(unix_milli)")
+	sb.From(fmt.Sprintf("%s.%s", telemetrymetrics.DBName, telemetrymetrics.SamplesV4TableName))
+	sb.Where(
+		sb.E("metric_name", r.recording.MetricName),
+		sb.GE("unix_milli", since),
+		fmt.Sprintf("fingerprint GLOBAL IN (%s)", sb.Var(fingerprints)),
+	)
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	var last int64
+	if err := r.telemetryStore.ClickhouseDB().QueryRow(ctx, query, args...).Scan(&last); err != nil {
+		return 0, err
+	}
+	return last, nil
+}
+
+// recordedMetric describes the metric written by a recording rule in the
+// metrics tables.
+type recordedMetric struct {
+	typ         metrictypes.Type
+	temporality metrictypes.Temporality
+	monotonic   bool
+}
+
+// newRecordedMetric derives the metric written by the rule from the first
+// aggregation of its selected query. A count or a sum per step is a delta
+// sum, so that the metric can be summed up and rated over any span. Any
+// other value, as an average, a percentile or a rate, is a gauge.
+func newRecordedMetric(condition *ruletypes.RuleCondition) recordedMetric {
+	gauge := recordedMetric{typ: metrictypes.GaugeType, temporality: metrictypes.Unspecified}
+
+	selectedQuery := condition.SelectedQuery
+	if selectedQuery == "" {
+		selectedQuery = condition.SelectedQueryName()
+	}
+
+	for _, query := range condition.CompositeQuery.Queries {
+		if query.GetQueryName() != selectedQuery {
+			continue
+		}
+
+		var expression string
+		switch spec := query.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return gauge
+			}
+			aggregation := spec.Aggregations[0]
+			if aggregation.TimeAggregation == metrictypes.TimeAggregationIncrease && aggregation.SpaceAggregation == metrictypes.SpaceAggregationSum {
+				return recordedMetric{typ: metrictypes.SumType, temporality: metrictypes.Delta, monotonic: true}
+			}
+			return gauge
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return gauge
+			}
+			expression = spec.Aggregations[0].Expression
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return gauge
+			}
+			expression = spec.Aggregations[0].Expression
+		default:
+			return gauge
+		}
+
+		function, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(expression)), "(")
+		switch strings.TrimSpace(function) {
+		case "count", "countif":
+			return recordedMetric{typ: metrictypes.SumType, temporality: metrictypes.Delta, monotonic: true}
+		case "sum", "sumif":
+			return recordedMetric{typ: metrictypes.SumType, temporality: metrictypes.Delta}
+		}
+		return gauge
+	}
+
+	return gauge
+}
+
+// columns returns the values of the type and temporality columns of the
+// metrics tables for the metric.
+func (m recordedMetric) columns() (string, string) {
+	typ, _ := m.typ.Value()
+	temporality, _ := m.temporality.Value()
+	return typ.(string), temporality.(string)
+}
+
+type recordedSeries struct {
+	fingerprint uint64
+	unixMilli   int64
+	labels      string
+	attrs       map[string]string
+}
+
+type recordedSample struct {
+	fingerprint uint64
+	unixMilli   int64
+	value       float64
+}
+
+// recordedRows returns the rows of the time series and samples tables for the
+// points of the series after the given time. The labels of the rule replace
+// the labels of the series with the same name, and the id of the rule labels
+// every series. There is a time series row per hour with points, the
+// granularity of the time series table.
+func recordedRows(metricName string, ruleID string, ruleLabels ruletypes.Labels, temporality string, series []*qbtypes.TimeSeries, after int64) ([]recordedSeries, []recordedSample) {
+	var seriesRows []recordedSeries
+	var sampleRows []recordedSample
+
+	for _, s := range series {
+		attrs := make(map[string]string, len(s.Labels)+len(ruleLabels)+1)
+		for _, label := range s.Labels {
+			if label.Key.Name == ruletypes.MetricNameLabel {
+				continue
+			}
+			attrs[label.Key.Name] = fmt.Sprint(label.Value)
+		}
+		for _, label := range ruleLabels {
+			attrs[label.Name] = label.Value
+		}
+		attrs[ruletypes.AlertRuleIDLabel] = ruleID
+		attrs[temporalityLabel] = temporality
+
+		lb := ruletypes.NewBuilder(ruletypes.FromMap(attrs)...)
+		lb.Set(ruletypes.MetricNameLabel, metricName)
+		lbls := lb.Labels()
+		fingerprint := lbls.Hash()
+
+		labelsJSON, _ := json.Marshal(lbls.Map())
+
+		hours := map[int64]struct{}{}
+		for _, v := range s.Values {
+			if v.Timestamp <= after {
+				continue
+			}
+			sampleRows = append(sampleRows, recordedSample{fingerprint: fingerprint, unixMilli: v.Timestamp, value: v.Value})
+
+			hour := v.Timestamp - v.Timestamp%time.Hour.Milliseconds()
+			if _, ok := hours[hour]; ok {
+				continue
+			}
+			hours[hour] = struct{}{}
+			seriesRows = append(seriesRows, recordedSeries{fingerprint: fingerprint, unixMilli: hour, labels: string(labelsJSON), attrs: attrs})
+		}
+	}
+
+	return seriesRows, sampleRows
+}
+
+// record writes the points of the series after recordedUntil to the metric
+// and returns how many were written.
+func (r *RecordingRule) record(ctx context.Context, series []*qbtypes.TimeSeries) (int, error) {
+	typ, temporality := r.metric.columns()
+
+	seriesRows, sampleRows := recordedRows(r.recording.MetricName, r.ID(), r.labels, temporality, series, r.recordedUntil)
+	if len(sampleRows) == 0 {
+		return 0, nil
+	}
+
+	insertedAt := time.Now().UnixMilli()
+
+	// The time series go first, a sample is never without its series.
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(fmt.Sprintf("%s.%s", telemetrymetrics.DBName, telemetrymetrics.TimeseriesV4TableName))
+	ib.Cols("env", "temporality", "metric_name", "description", "unit", "type", "is_monotonic", "fingerprint", "unix_milli", "labels", "attrs", "scope_attrs", "resource_attrs", "__normalized", "inserted_at_unix_milli")
+	insertQuery, _ := ib.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	statement, err := r.telemetryStore.ClickhouseDB().PrepareBatch(ctx, insertQuery)
+	if err != nil {
+		return 0, err
+	}
+	defer statement.Abort() //nolint:errcheck
+
+	for _, row := range seriesRows {
+		if err := statement.Append(
+			recordingEnv,
+			temporality,
+			r.recording.MetricName,
+			r.Name(),
+			r.Unit(),
+			typ,
+			r.metric.monotonic,
+			row.fingerprint,
+			row.unixMilli,
+			row.labels,
+			row.attrs,
+			map[string]string{},
+			map[string]string{},
+			false,
+			insertedAt,
+		); err != nil {
+			return 0, err
+		}
+	}
+	if err := statement.Send(); err != nil {
+		return 0, err
+	}
+
+	ib = sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(fmt.Sprintf("%s.%s", telemetrymetrics.DBName, telemetrymetrics.SamplesV4TableName))
+	ib.Cols("env", "temporality", "metric_name", "fingerprint", "unix_milli", "value", "flags", "inserted_at_unix_milli")
+	insertQuery, _ = ib.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	samplesStatement, err := r.telemetryStore.ClickhouseDB().PrepareBatch(ctx, insertQuery)
+	if err != nil {
+		return 0, err
+	}
+	defer samplesStatement.Abort() //nolint:errcheck
+
+	recordedUntil := r.recordedUntil
+	for _, row := range sampleRows {
+		if err := samplesStatement.Append(
+			recordingEnv,
+			temporality,
+			r.recording.MetricName,
+			row.fingerprint,
+			row.unixMilli,
+			row.value,
+			uint32(0),
+			insertedAt,
+		); err != nil {
+			return 0, err
+		}
+		recordedUntil = max(recordedUntil, row.unixMilli)
+	}
+	if err := samplesStatement.Send(); err != nil {
+		return 0, err
+	}
+
+	r.recordedUntil = recordedUntil
+	return len(sampleRows), nil
+}
+
+func (r *RecordingRule) String() string {
+	ar := ruletypes.PostableRule{
+		AlertName:     r.name,
+		RuleType:      ruletypes.RuleTypeRecording,
+		RuleCondition: r.ruleCondition,
+		EvalWindow:    r.evalWindow,
+		Labels:        r.labels.Map(),
+		Annotations:   r.annotations.Map(),
+	}
+
+	byt, err := json.Marshal(ar)
+	if err != nil {
+		return fmt.Sprintf("error marshaling recording rule: %s", err.Error())
+	}
+
+	return string(byt)
+}
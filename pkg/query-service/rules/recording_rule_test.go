@@ -0,0 +1,356 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	cmock "github.com/SigNoz/clickhouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// stepQuerier returns a series with a point per step of the requested span
+// for every route, and keeps the requests it got.
+type stepQuerier struct {
+	querier.Querier
+	routes   []string
+	requests []*qbtypes.QueryRangeRequest
+}
+
+func (q *stepQuerier) QueryRange(_ context.Context, _ valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	q.requests = append(q.requests, req)
+
+	spec := req.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation])
+	step := spec.StepInterval.Milliseconds()
+
+	series := make([]*qbtypes.TimeSeries, 0, len(q.routes))
+	for _, route := range q.routes {
+		s := &qbtypes.TimeSeries{
+			Labels: []*qbtypes.Label{{Key: telemetrytypes.TelemetryFieldKey{Name: "http.route"}, Value: route}},
+		}
+		for ts := int64(req.Start); ts < int64(req.End); ts += step {
+			s.Values = append(s.Values, &qbtypes.TimeSeriesValue{Timestamp: ts, Value: 250})
+		}
+		series = append(series, s)
+	}
+
+	return &qbtypes.QueryRangeResponse{
+		Type: qbtypes.RequestTypeTimeSeries,
+		Data: qbtypes.QueryData{Results: []any{&qbtypes.TimeSeriesData{
+			QueryName:    "A",
+			Aggregations: []*qbtypes.AggregationBucket{{Series: series}},
+		}}},
+	}, nil
+}
+
+func newTestRecordingRule(t *testing.T, q querier.Querier, telemetryStore telemetrystore.TelemetryStore, history *recordingStateHistory, backfill string) *RecordingRule {
+	t.Helper()
+
+	rule := map[string]any{
+		"alert":      "CheckoutLatencyByRoute",
+		"version":    "v5",
+		"ruleType":   "recording_rule",
+		"labels":     map[string]string{"team": "checkout"},
+		"evalWindow": "5m",
+		"frequency":  "1m",
+		"condition": map[string]any{
+			"compositeQuery": map[string]any{
+				"queryType": "builder",
+				"queries": []map[string]any{{
+					"type": "builder_query",
+					"spec": map[string]any{
+						"name":         "A",
+						"signal":       "traces",
+						"aggregations": []map[string]string{{"expression": "p99(duration_nano)"}},
+						"groupBy":      []map[string]string{{"name": "http.route"}},
+					},
+				}},
+			},
+			"recording": map[string]string{"metricName": "checkout_latency_p99", "backfill": backfill},
+		},
+	}
+	out, err := json.Marshal(rule)
+	require.NoError(t, err)
+
+	var p ruletypes.PostableRule
+	require.NoError(t, json.Unmarshal(out, &p))
+	require.NoError(t, p.Validate())
+
+	r, err := NewRecordingRule(
+		"0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0b01",
+		valuer.GenerateUUID(),
+		&p,
+		q,
+		nil,
+		telemetryStore,
+		instrumentationtest.New().Logger(),
+		mustParseURL(t, "http://localhost:8080"),
+		WithRuleStateHistoryModule(history),
+	)
+	require.NoError(t, err)
+	return r
+}
+
+// expectRecord expects the time series and then the samples of one write.
+func expectRecord(mock cmock.ClickConnMockCommon) {
+	mock.ExpectPrepareBatch(regexp.QuoteMeta("INSERT INTO signoz_metrics.distributed_time_series_v4"))
+	mock.ExpectPrepareBatch(regexp.QuoteMeta("INSERT INTO signoz_metrics.distributed_samples_v4"))
+}
+
+func TestRecordingRuleEval(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, sqlmock.QueryMatcherRegexp)
+	mock := telemetryStore.Mock()
+	q := &stepQuerier{routes: []string{"/cart", "/checkout"}}
+	history := &recordingStateHistory{}
+
+	// 7h of backfill at a step of 1m is queried in two chunks of at most 300 steps.
+	rule := newTestRecordingRule(t, q, telemetryStore, history, "7h")
+
+	ts := time.Date(2026, 3, 2, 10, 0, 30, 0, time.UTC)
+	end := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC).UnixMilli()
+	start := end - (7 * time.Hour).Milliseconds()
+	chunkEnd := start + (300 * time.Minute).Milliseconds()
+
+	mock.ExpectQueryRow(regexp.QuoteMeta("SELECT max(unix_milli) FROM signoz_metrics.distributed_samples_v4 WHERE metric_name = ? AND unix_milli >= ? AND fingerprint GLOBAL IN (SELECT fingerprint FROM signoz_metrics.distributed_time_series_v4 WHERE metric_name = ? AND unix_milli >= ? AND JSONExtractString(labels, ?) = ?)")).
+		WillReturnRow(cmock.NewRow([]cmock.ColumnType{{Name: "max(unix_milli)", Type: "Int64"}}, []any{int64(0)}))
+	expectRecord(mock)
+	expectRecord(mock)
+
+	_, err := rule.Eval(context.Background(), ts)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, q.requests, 2)
+	assert.Equal(t, uint64(start), q.requests[0].Start)
+	assert.Equal(t, uint64(chunkEnd), q.requests[0].End)
+	assert.Equal(t, uint64(chunkEnd), q.requests[1].Start)
+	assert.Equal(t, uint64(end), q.requests[1].End)
+	assert.True(t, q.requests[0].NoCache)
+
+	spec := q.requests[0].CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation])
+	assert.Equal(t, time.Minute, spec.StepInterval.Duration)
+
+	assert.Equal(t, end-time.Minute.Milliseconds(), rule.recordedUntil)
+	assert.Equal(t, ruletypes.StateInactive, rule.State())
+	assert.Equal(t, ruletypes.HealthGood, rule.Health())
+	assert.Empty(t, history.items)
+
+	// The next evaluation only writes the step after the last point written.
+	expectRecord(mock)
+
+	_, err = rule.Eval(context.Background(), ts.Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, q.requests, 3)
+	assert.Equal(t, uint64(end-time.Minute.Milliseconds()), q.requests[2].Start)
+	assert.Equal(t, uint64(end+time.Minute.Milliseconds()), q.requests[2].End)
+	assert.Equal(t, end, rule.recordedUntil)
+
+	// Without series the rule has no data.
+	q.routes = nil
+
+	_, err = rule.Eval(context.Background(), ts.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, ruletypes.StateNoData, rule.State())
+	require.Len(t, history.items, 1)
+	assert.Equal(t, ruletypes.StateNoData, history.items[0].State)
+	assert.True(t, history.items[0].StateChanged)
+}
+
+func TestRecordingRuleEvalResumes(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, sqlmock.QueryMatcherRegexp)
+	mock := telemetryStore.Mock()
+	q := &stepQuerier{routes: []string{"/cart"}}
+
+	rule := newTestRecordingRule(t, q, telemetryStore, &recordingStateHistory{}, "1h")
+
+	ts := time.Date(2026, 3, 2, 10, 0, 30, 0, time.UTC)
+	end := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC).UnixMilli()
+	lastRecorded := end - (3 * time.Minute).Milliseconds()
+
+	// A restarted rule continues after the latest point of its metric.
+	mock.ExpectQueryRow(regexp.QuoteMeta("SELECT max(unix_milli) FROM signoz_metrics.distributed_samples_v4")).
+		WillReturnRow(cmock.NewRow([]cmock.ColumnType{{Name: "max(unix_milli)", Type: "Int64"}}, []any{lastRecorded}))
+	expectRecord(mock)
+
+	_, err := rule.Eval(context.Background(), ts)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, q.requests, 1)
+	assert.Equal(t, uint64(lastRecorded), q.requests[0].Start)
+	assert.Equal(t, uint64(end), q.requests[0].End)
+	assert.Equal(t, end-time.Minute.Milliseconds(), rule.recordedUntil)
+}
+
+func TestRecordingRuleEvalSpreadsBackfill(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, sqlmock.QueryMatcherRegexp)
+	mock := telemetryStore.Mock()
+	q := &stepQuerier{routes: []string{"/cart"}}
+
+	// 24h of backfill at a step of 1m is more than the 4 chunks of 300 steps
+	// an evaluation queries.
+	rule := newTestRecordingRule(t, q, telemetryStore, &recordingStateHistory{}, "24h")
+
+	ts := time.Date(2026, 3, 2, 10, 0, 30, 0, time.UTC)
+	end := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC).UnixMilli()
+	start := end - (24 * time.Hour).Milliseconds()
+	evalEnd := start + (1200 * time.Minute).Milliseconds()
+
+	mock.ExpectQueryRow(regexp.QuoteMeta("SELECT max(unix_milli) FROM signoz_metrics.distributed_samples_v4")).
+		WillReturnRow(cmock.NewRow([]cmock.ColumnType{{Name: "max(unix_milli)", Type: "Int64"}}, []any{int64(0)}))
+	for range 4 {
+		expectRecord(mock)
+	}
+
+	_, err := rule.Eval(context.Background(), ts)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, q.requests, 4)
+	assert.Equal(t, uint64(start), q.requests[0].Start)
+	assert.Equal(t, uint64(evalEnd), q.requests[3].End)
+	assert.Equal(t, evalEnd-time.Minute.Milliseconds(), rule.recordedUntil)
+
+	// The next evaluation carries on with the rest of the backfill.
+	expectRecord(mock)
+
+	_, err = rule.Eval(context.Background(), ts.Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, q.requests, 5)
+	assert.Equal(t, uint64(evalEnd), q.requests[4].Start)
+	assert.Equal(t, uint64(end+time.Minute.Milliseconds()), q.requests[4].End)
+	assert.Equal(t, end, rule.recordedUntil)
+}
+
+func TestNewRecordedMetric(t *testing.T) {
+	tests := []struct {
+		name  string
+		query qbtypes.QueryEnvelope
+		want  recordedMetric
+	}{
+		{
+			name: "trace count",
+			query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+				Name: "A", Signal: telemetrytypes.SignalTraces, Aggregations: []qbtypes.TraceAggregation{{Expression: "count()"}},
+			}},
+			want: recordedMetric{typ: metrictypes.SumType, temporality: metrictypes.Delta, monotonic: true},
+		},
+		{
+			name: "log sum",
+			query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Name: "A", Signal: telemetrytypes.SignalLogs, Aggregations: []qbtypes.LogAggregation{{Expression: "sumIf(bytes, level = 'error')"}},
+			}},
+			want: recordedMetric{typ: metrictypes.SumType, temporality: metrictypes.Delta},
+		},
+		{
+			name: "trace percentile",
+			query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+				Name: "A", Signal: telemetrytypes.SignalTraces, Aggregations: []qbtypes.TraceAggregation{{Expression: "p99(duration_nano)"}},
+			}},
+			want: recordedMetric{typ: metrictypes.GaugeType, temporality: metrictypes.Unspecified},
+		},
+		{
+			name: "metric increase",
+			query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name: "A", Signal: telemetrytypes.SignalMetrics, Aggregations: []qbtypes.MetricAggregation{{MetricName: "http_requests_total", TimeAggregation: metrictypes.TimeAggregationIncrease, SpaceAggregation: metrictypes.SpaceAggregationSum}},
+			}},
+			want: recordedMetric{typ: metrictypes.SumType, temporality: metrictypes.Delta, monotonic: true},
+		},
+		{
+			name: "metric rate",
+			query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name: "A", Signal: telemetrytypes.SignalMetrics, Aggregations: []qbtypes.MetricAggregation{{MetricName: "http_requests_total", TimeAggregation: metrictypes.TimeAggregationRate, SpaceAggregation: metrictypes.SpaceAggregationSum}},
+			}},
+			want: recordedMetric{typ: metrictypes.GaugeType, temporality: metrictypes.Unspecified},
+		},
+		{
+			name:  "promql",
+			query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypePromQL, Spec: qbtypes.PromQuery{Name: "A", Query: "sum(increase(http_requests_total[1m]))"}},
+			want:  recordedMetric{typ: metrictypes.GaugeType, temporality: metrictypes.Unspecified},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := &ruletypes.RuleCondition{
+				CompositeQuery: &ruletypes.AlertCompositeQuery{Queries: []qbtypes.QueryEnvelope{tt.query}},
+				SelectedQuery:  "A",
+			}
+			assert.Equal(t, tt.want, newRecordedMetric(condition))
+		})
+	}
+}
+
+func TestRecordedRows(t *testing.T) {
+	hour := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC).UnixMilli()
+	minute := time.Minute.Milliseconds()
+
+	series := []*qbtypes.TimeSeries{{
+		Labels: []*qbtypes.Label{
+			{Key: telemetrytypes.TelemetryFieldKey{Name: "__name__"}, Value: "http_requests_total"},
+			{Key: telemetrytypes.TelemetryFieldKey{Name: "http.route"}, Value: "/cart"},
+			{Key: telemetrytypes.TelemetryFieldKey{Name: "team"}, Value: "storefront"},
+			{Key: telemetrytypes.TelemetryFieldKey{Name: "status"}, Value: 500},
+		},
+		Values: []*qbtypes.TimeSeriesValue{
+			{Timestamp: hour - 2*minute, Value: 1},
+			{Timestamp: hour - minute, Value: 2},
+			{Timestamp: hour, Value: 3},
+			{Timestamp: hour + minute, Value: 4},
+		},
+	}}
+
+	seriesRows, sampleRows := recordedRows("http_requests:rate1m", "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0b01", ruletypes.FromMap(map[string]string{"team": "checkout"}), "Unspecified", series, hour-2*minute)
+
+	wantAttrs := map[string]string{
+		"http.route":      "/cart",
+		"status":          "500",
+		"team":            "checkout",
+		"ruleId":          "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0b01",
+		"__temporality__": "Unspecified",
+	}
+	wantLabels := ruletypes.FromMap(map[string]string{
+		"__name__":        "http_requests:rate1m",
+		"http.route":      "/cart",
+		"status":          "500",
+		"team":            "checkout",
+		"ruleId":          "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0b01",
+		"__temporality__": "Unspecified",
+	})
+	fingerprint := wantLabels.Hash()
+
+	require.Len(t, seriesRows, 2)
+	assert.Equal(t, hour-time.Hour.Milliseconds(), seriesRows[0].unixMilli)
+	assert.Equal(t, hour, seriesRows[1].unixMilli)
+	for _, row := range seriesRows {
+		assert.Equal(t, fingerprint, row.fingerprint)
+		assert.Equal(t, wantAttrs, row.attrs)
+		assert.JSONEq(t, `{"__name__": "http_requests:rate1m", "http.route": "/cart", "status": "500", "team": "checkout", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0b01", "__temporality__": "Unspecified"}`, row.labels)
+	}
+
+	assert.Equal(t, []recordedSample{
+		{fingerprint: fingerprint, unixMilli: hour - minute, value: 2},
+		{fingerprint: fingerprint, unixMilli: hour, value: 3},
+		{fingerprint: fingerprint, unixMilli: hour + minute, value: 4},
+	}, sampleRows)
+}
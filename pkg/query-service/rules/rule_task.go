@@ -278,6 +278,16 @@ func (g *RuleTask) CopyState(fromTask Task) error {
 		fi := indexes[0]
 		ruleMap[nameAndLabels] = indexes[1:]
 
+		if cr, ok := rule.(*CompositeRule); ok {
+			if fcr, ok := from.rules[fi].(*CompositeRule); ok {
+				for fp, a := range fcr.Active {
+					cr.Active[fp] = a
+				}
+				cr.handledRestart = fcr.handledRestart
+			}
+			continue
+		}
+
 		ar, ok := rule.(*ThresholdRule)
 		if !ok {
 			continue
@@ -52,7 +52,9 @@ func prepareQuerierForMetrics(t *testing.T, telemetryStore telemetrystore.Teleme
 		metricStmtBuilder,
 		nil, // meterStmtBuilder
 		nil, // traceOperatorStmtBuilder
+		nil, // joinStmtBuilder
 		nil, // bucketCache
+		nil, // lookupTableGetter
 		flagger,
 	), metadataStore
 }
@@ -105,7 +107,9 @@ func prepareQuerierForLogs(t *testing.T, telemetryStore telemetrystore.Telemetry
 		nil,            // metricStmtBuilder
 		nil,            // meterStmtBuilder
 		nil,            // traceOperatorStmtBuilder
+		nil,            // joinStmtBuilder
 		nil,            // bucketCache
+		nil,            // lookupTableGetter
 		fl,
 	)
 }
@@ -152,7 +156,9 @@ func prepareQuerierForTraces(t *testing.T, telemetryStore telemetrystore.Telemet
 		nil,              // metricStmtBuilder
 		nil,              // meterStmtBuilder
 		nil,              // traceOperatorStmtBuilder
+		nil,              // joinStmtBuilder
 		nil,              // bucketCache
+		nil,              // lookupTableGetter
 		fl,
 	)
 }
@@ -83,6 +83,27 @@ func defaultTestNotification(opts PrepareTestRuleOptions) (int, error) {
 			slog.Error("failed to prepare a new promql rule for test", errors.Attr(err))
 			return 0, err
 		}
+	} else if parsedRule.RuleType == ruletypes.RuleTypeComposite {
+
+		// create composite rule over the current state of its children
+		rule, err = NewCompositeRule(
+			alertname,
+			opts.OrgID,
+			parsedRule,
+			opts.LookupRule,
+			opts.Logger,
+			opts.ManagerOpts.Alertmanager.Config().ExternalURL,
+			WithSendAlways(),
+			WithSendUnmatched(),
+			WithSQLStore(opts.SQLStore),
+		)
+
+		if err != nil {
+			slog.Error("failed to prepare a new composite rule for test", errors.Attr(err))
+			return 0, err
+		}
+	} else if parsedRule.RuleType == ruletypes.RuleTypeRecording {
+		return 0, errors.NewInvalidInputf(errors.CodeInvalidInput, "recording rules send no notifications")
 	} else {
 		return 0, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid rule type")
 	}
@@ -69,6 +69,10 @@ func (r *ThresholdRule) prepareQueryRange(ctx context.Context, ts time.Time) (*q
 	)
 
 	startTs, endTs := r.Timestamps(ts)
+	// absent-data rules look for groups over the whole lookback window
+	if absent := r.Condition().AbsentData; absent != nil {
+		startTs = endTs.Add(-absent.Lookback.Duration())
+	}
 	start, end := startTs.UnixMilli(), endTs.UnixMilli()
 
 	req := &qbtypes.QueryRangeRequest{
@@ -218,6 +222,10 @@ func (r *ThresholdRule) buildAndRunQuery(ctx context.Context, orgID valuer.UUID,
 		return resultVector, nil
 	}
 
+	if absent := r.Condition().AbsentData; absent != nil {
+		return r.evalAbsentData(absent, queryResult.Aggregations[0].Series, time.UnixMilli(int64(params.End))), nil
+	}
+
 	// Filter out new series if newGroupEvalDelay is configured
 	seriesToProcess := queryResult.Aggregations[0].Series
 	if r.ShouldSkipNewGroups() {
@@ -252,6 +260,21 @@ func (r *ThresholdRule) buildAndRunQuery(ctx context.Context, orgID valuer.UUID,
 	return resultVector, nil
 }
 
+// evalAbsentData returns a missing sample for every group that stopped
+// reporting. The samples are named after the rule's threshold so they route
+// like any other alert of the rule.
+func (r *ThresholdRule) evalAbsentData(absent *ruletypes.AbsentDataCondition, series []*qbtypes.TimeSeries, end time.Time) ruletypes.Vector {
+	thresholdName := ruletypes.CriticalThresholdName
+	if receivers := r.Threshold.GetRuleReceivers(); len(receivers) > 0 {
+		thresholdName = receivers[0].Name
+	}
+
+	return absent.Eval(series, end, thresholdName, ruletypes.EvalData{
+		ActiveAlerts:  r.ActiveAlertsLabelFP(),
+		SendUnmatched: r.ShouldSendUnmatched(),
+	})
+}
+
 func (r *ThresholdRule) Eval(ctx context.Context, ts time.Time) (int, error) {
 	prevState := r.State()
 
@@ -1960,3 +1960,105 @@ func TestThresholdEval_RequireMinPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestThresholdRuleEval_AbsentData(t *testing.T) {
+	postableRule := ruletypes.PostableRule{
+		AlertName: "Service stopped logging",
+		AlertType: ruletypes.AlertTypeLogs,
+		RuleType:  ruletypes.RuleTypeThreshold,
+		Evaluation: &ruletypes.EvaluationEnvelope{Kind: ruletypes.RollingEvaluation, Spec: ruletypes.RollingWindow{
+			EvalWindow: valuer.MustParseTextDuration("5m"),
+			Frequency:  valuer.MustParseTextDuration("1m"),
+		}},
+		RuleCondition: &ruletypes.RuleCondition{
+			CompositeQuery: &ruletypes.AlertCompositeQuery{
+				QueryType: ruletypes.QueryTypeBuilder,
+				Queries: []qbtypes.QueryEnvelope{{
+					Type: qbtypes.QueryTypeBuilder,
+					Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+						Name:         "A",
+						StepInterval: qbtypes.Step{Duration: time.Minute},
+						Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+						Signal:       telemetrytypes.SignalLogs,
+						GroupBy: []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+							Name:          "service.name",
+							FieldContext:  telemetrytypes.FieldContextResource,
+							FieldDataType: telemetrytypes.FieldDataTypeString,
+						}}},
+					}},
+				},
+			},
+			AbsentData: &ruletypes.AbsentDataCondition{
+				For:      valuer.MustParseTextDuration("10m"),
+				Lookback: valuer.MustParseTextDuration("1h"),
+			},
+			Thresholds: &ruletypes.RuleThresholdData{
+				Kind: ruletypes.BasicThresholdKind,
+				Spec: ruletypes.BasicRuleThresholds{{Name: "critical", Channels: []string{"oncall"}}},
+			},
+		},
+	}
+
+	keysMap := map[string][]*telemetrytypes.TelemetryFieldKey{
+		"service.name": {{
+			Name:          "service.name",
+			FieldContext:  telemetrytypes.FieldContextResource,
+			FieldDataType: telemetrytypes.FieldDataTypeString,
+		}},
+	}
+
+	cols := []cmock.ColumnType{
+		{Name: "ts", Type: "DateTime"},
+		{Name: "service.name", Type: "String"},
+		{Name: "__result_0", Type: "UInt64"},
+	}
+
+	ts := time.Now().Truncate(time.Minute)
+	end := ts.Add(-time.Minute)
+
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &queryMatcherAny{})
+	telemetryStore.Mock().
+		ExpectQuery("SELECT any").
+		WithArgs(nil, nil, nil, nil, nil).
+		WillReturnRows(cmock.NewRows(cols, [][]any{
+			{end.Add(-40 * time.Minute), "checkout", uint64(12)},
+			{end.Add(-30 * time.Minute), "checkout", uint64(7)},
+			{end.Add(-40 * time.Minute), "cart", uint64(3)},
+			{end.Add(-2 * time.Minute), "cart", uint64(5)},
+		}))
+	telemetryStore.Mock().
+		ExpectQuery("SELECT any").
+		WithArgs(nil, nil, nil, nil, nil).
+		WillReturnRows(cmock.NewRows(cols, [][]any{
+			{end.Add(-2 * time.Minute), "checkout", uint64(4)},
+			{end.Add(-2 * time.Minute), "cart", uint64(5)},
+		}))
+
+	querier := prepareQuerierForLogs(t, telemetryStore, keysMap)
+
+	rule, err := NewThresholdRule(valuer.GenerateUUID().StringValue(), valuer.GenerateUUID(), &postableRule, querier, instrumentationtest.New().Logger(), mustParseURL(t, "http://localhost:8080"))
+	require.NoError(t, err)
+
+	params, err := rule.prepareQueryRange(context.Background(), ts)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour.Milliseconds(), int64(params.End-params.Start))
+
+	// checkout hasn't logged for 30m while cart logged 2m ago
+	alertsFound, err := rule.Eval(context.Background(), ts)
+	require.NoError(t, err)
+	require.Equal(t, 1, alertsFound)
+
+	alerts := rule.ActiveAlerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "checkout", alerts[0].Labels.Get("service.name"))
+	assert.Equal(t, "[No data] Service stopped logging", alerts[0].Labels.Get(ruletypes.AlertNameLabel))
+	assert.Equal(t, "true", alerts[0].Labels.Get(ruletypes.NoDataLabel))
+	assert.Equal(t, "critical", alerts[0].Labels.Get(ruletypes.LabelThresholdName))
+	assert.Equal(t, []string{"oncall"}, alerts[0].Receivers)
+	assert.True(t, alerts[0].Missing)
+
+	// checkout is back, the alert resolves
+	_, err = rule.Eval(context.Background(), ts.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, rule.ActiveAlerts())
+}
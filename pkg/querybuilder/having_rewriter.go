@@ -45,6 +45,17 @@ func (r *HavingExpressionRewriter) RewriteForMetrics(expression string, aggregat
 	return r.rewriteAndValidate(expression)
 }
 
+// RewriteForJoin rewrites and validates the HAVING expression for a join query.
+// The column map is provided by the join statement builder since the result
+// columns depend on both sides of the join.
+func (r *HavingExpressionRewriter) RewriteForJoin(expression string, columnMap map[string]string) (string, error) {
+	if len(strings.TrimSpace(expression)) == 0 {
+		return "", nil
+	}
+	r.columnMap = columnMap
+	return r.rewriteAndValidate(expression)
+}
+
 func (r *HavingExpressionRewriter) buildTraceColumnMap(aggregations []qbtypes.TraceAggregation) {
 	r.columnMap = make(map[string]string)
 
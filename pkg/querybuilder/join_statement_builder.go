@@ -0,0 +1,691 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	schema "github.com/SigNoz/signoz-otel-collector/cmd/signozschemamigrator/schema_migrator"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	joinLeftCTE    = "__join_left"
+	joinRightCTE   = "__join_right"
+	joinLeftAlias  = "l"
+	joinRightAlias = "r"
+)
+
+var joinReferenceRe = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z0-9_]+)?`)
+
+type joinStatementBuilder struct {
+	logger            *slog.Logger
+	traceStmtBuilder  qbtypes.StatementBuilder[qbtypes.TraceAggregation]
+	logStmtBuilder    qbtypes.StatementBuilder[qbtypes.LogAggregation]
+	auditStmtBuilder  qbtypes.StatementBuilder[qbtypes.LogAggregation]
+	metricStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation]
+	meterStmtBuilder  qbtypes.StatementBuilder[qbtypes.MetricAggregation]
+}
+
+var _ qbtypes.JoinStatementBuilder = (*joinStatementBuilder)(nil)
+
+// NewJoinStatementBuilder creates a statement builder for builder_join queries.
+// Each side of the join is rendered by the statement builder of its signal and
+// the results are joined in ClickHouse.
+func NewJoinStatementBuilder(
+	settings factory.ProviderSettings,
+	traceStmtBuilder qbtypes.StatementBuilder[qbtypes.TraceAggregation],
+	logStmtBuilder qbtypes.StatementBuilder[qbtypes.LogAggregation],
+	auditStmtBuilder qbtypes.StatementBuilder[qbtypes.LogAggregation],
+	metricStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation],
+	meterStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation],
+) *joinStatementBuilder {
+	set := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querybuilder/join")
+
+	return &joinStatementBuilder{
+		logger:            set.Logger(),
+		traceStmtBuilder:  traceStmtBuilder,
+		logStmtBuilder:    logStmtBuilder,
+		auditStmtBuilder:  auditStmtBuilder,
+		metricStmtBuilder: metricStmtBuilder,
+		meterStmtBuilder:  meterStmtBuilder,
+	}
+}
+
+// joinSide is a rendered side of the join.
+type joinSide struct {
+	ref      string
+	alias    string
+	stmt     *qbtypes.Statement
+	groupBy  []string
+	results  int
+	aliases  map[string]int
+	step     qbtypes.Step
+	isMetric bool
+	reduceTo qbtypes.ReduceTo
+	sideSQL  string
+}
+
+// Build builds a SQL query which joins the results of two builder queries.
+//
+// The rendered query has the following shape
+//
+//	WITH __join_left AS (SELECT ts, <keys>, __result_0, ... FROM (<left query>)),
+//	     __join_right AS (SELECT ts, <keys>, __result_0, ... FROM (<right query>))
+//	SELECT <ts>, <keys>, <results> FROM __join_left AS l <type> JOIN __join_right AS r ON ...
+//
+// Without post-join aggregations the result columns of the left query are
+// returned first followed by the result columns of the right query.
+func (b *joinStatementBuilder) Build(
+	ctx context.Context,
+	start uint64,
+	end uint64,
+	requestType qbtypes.RequestType,
+	query qbtypes.QueryBuilderJoin,
+	compositeQuery *qbtypes.CompositeQuery,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+	if requestType != qbtypes.RequestTypeTimeSeries && requestType != qbtypes.RequestTypeScalar {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join is only supported for time_series and scalar requests, got %s", requestType.StringValue())
+	}
+
+	if requestType == qbtypes.RequestTypeTimeSeries && query.Type == qbtypes.JoinTypeCross {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "cross join is not supported for time_series requests")
+	}
+
+	conditions, err := query.ParseOn()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregations, err := query.JoinAggregations()
+	if err != nil {
+		return nil, err
+	}
+
+	left, err := b.buildSide(ctx, start, end, requestType, query.Left.Name, joinLeftAlias, compositeQuery, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := b.buildSide(ctx, start, end, requestType, query.Right.Name, joinRightAlias, compositeQuery, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if requestType == qbtypes.RequestTypeTimeSeries && left.step.Duration != right.step.Duration {
+		return nil, errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"queries '%s' and '%s' must have the same step interval to be joined, got %s and %s",
+			left.ref, right.ref, left.step.String(), right.step.String(),
+		)
+	}
+
+	for _, cond := range conditions {
+		if !slices.Contains(left.groupBy, cond.Left) {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join key `%s` is not a group by key of query '%s'", cond.Left, left.ref)
+		}
+		if !slices.Contains(right.groupBy, cond.Right) {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join key `%s` is not a group by key of query '%s'", cond.Right, right.ref)
+		}
+	}
+
+	keyExprs := b.keyExpressions(query.Type, conditions, left, right)
+	refs := joinReferences(left, right)
+
+	sb := sqlbuilder.NewSelectBuilder()
+
+	isTimeSeries := requestType == qbtypes.RequestTypeTimeSeries
+	if isTimeSeries {
+		sb.SelectMore(fmt.Sprintf("%s AS ts", joinTimestampExpr(query.Type)))
+	}
+
+	var outputKeys []string
+	if len(aggregations) == 0 {
+		outputKeys = joinOutputKeys(conditions, left, right)
+	} else {
+		for _, gb := range query.GroupBy {
+			outputKeys = append(outputKeys, gb.Name)
+		}
+	}
+
+	for _, key := range outputKeys {
+		expr, ok := keyExprs[key]
+		if !ok {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "key `%s` is not a group by key of query '%s' or '%s'", key, left.ref, right.ref)
+		}
+		sb.SelectMore(fmt.Sprintf("%s AS `%s`", expr, key))
+	}
+
+	// column map used to resolve references in having and order by
+	resultColumns := map[string]string{}
+	if len(aggregations) == 0 {
+		idx := 0
+		for _, side := range []*joinSide{left, right} {
+			for i := 0; i < side.results; i++ {
+				column := fmt.Sprintf("__result_%d", idx)
+				sb.SelectMore(fmt.Sprintf("%s.__result_%d AS %s", side.alias, i, column))
+				resultColumns[column] = column
+				resultColumns[fmt.Sprintf("%s.__result_%d", side.ref, i)] = column
+				if i == 0 {
+					resultColumns[side.ref] = column
+				}
+				idx++
+			}
+			for alias, i := range side.aliases {
+				resultColumns[fmt.Sprintf("%s.%s", side.ref, alias)] = fmt.Sprintf("__result_%d", idx-side.results+i)
+			}
+		}
+	} else {
+		for idx, agg := range aggregations {
+			column := fmt.Sprintf("__result_%d", idx)
+			sb.SelectMore(fmt.Sprintf("%s AS %s", rewriteJoinReferences(agg.Expression, refs), column))
+			resultColumns[column] = column
+			resultColumns[fmt.Sprintf("__result%d", idx)] = column
+			resultColumns[agg.Expression] = column
+			if agg.Alias != "" {
+				resultColumns[agg.Alias] = column
+			}
+			if len(aggregations) == 1 {
+				resultColumns["__result"] = column
+			}
+		}
+	}
+
+	sb.From(fmt.Sprintf("%s AS %s", joinLeftCTE, joinLeftAlias))
+
+	onExprs := make([]string, 0, len(conditions)+1)
+	for _, cond := range conditions {
+		onExprs = append(onExprs, fmt.Sprintf("%s.`%s` = %s.`%s`", joinLeftAlias, cond.Left, joinRightAlias, cond.Right))
+	}
+	if isTimeSeries {
+		onExprs = append(onExprs, fmt.Sprintf("%s.ts = %s.ts", joinLeftAlias, joinRightAlias))
+	}
+	sb.JoinWithOption(joinOption(query.Type), fmt.Sprintf("%s AS %s", joinRightCTE, joinRightAlias), onExprs...)
+
+	var warnings []string
+	var warningsDocURL string
+	if query.Filter != nil && strings.TrimSpace(query.Filter.Expression) != "" {
+		preparedWhereClause, err := b.prepareFilter(ctx, start, end, query.Filter.Expression, keyExprs, refs, variables)
+		if err != nil {
+			return nil, err
+		}
+		if preparedWhereClause.WhereClause != nil {
+			sb.AddWhereClause(preparedWhereClause.WhereClause)
+		}
+		warnings = preparedWhereClause.Warnings
+		warningsDocURL = preparedWhereClause.WarningsDocURL
+	}
+
+	if len(aggregations) != 0 {
+		if isTimeSeries {
+			sb.GroupBy("ts")
+		}
+		for _, key := range outputKeys {
+			sb.GroupBy(fmt.Sprintf("`%s`", key))
+		}
+	}
+
+	if query.Having != nil && strings.TrimSpace(query.Having.Expression) != "" {
+		rewriter := NewHavingExpressionRewriter()
+		rewrittenExpr, err := rewriter.RewriteForJoin(query.Having.Expression, resultColumns)
+		if err != nil {
+			return nil, err
+		}
+		if len(aggregations) != 0 {
+			sb.Having(rewrittenExpr)
+		} else {
+			sb.Where(rewrittenExpr)
+		}
+	}
+
+	for _, orderBy := range query.Order {
+		if column, ok := resultColumns[orderBy.Key.Name]; ok {
+			sb.OrderBy(fmt.Sprintf("%s %s", column, orderBy.Direction.StringValue()))
+		} else if slices.Contains(outputKeys, orderBy.Key.Name) {
+			sb.OrderBy(fmt.Sprintf("`%s` %s", orderBy.Key.Name, orderBy.Direction.StringValue()))
+		}
+	}
+
+	if isTimeSeries {
+		sb.OrderBy("ts desc")
+	} else {
+		if len(query.Order) == 0 && len(resultColumns) != 0 {
+			sb.OrderBy("__result_0 DESC")
+		}
+		if query.Limit > 0 {
+			sb.Limit(query.Limit)
+		}
+	}
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	cteFragments := []string{
+		fmt.Sprintf("%s AS (%s)", joinLeftCTE, left.sideSQL),
+		fmt.Sprintf("%s AS (%s)", joinRightCTE, right.sideSQL),
+	}
+	cteArgs := [][]any{left.stmt.Args, right.stmt.Args}
+
+	warnings = append(warnings, left.stmt.Warnings...)
+	warnings = append(warnings, right.stmt.Warnings...)
+	if warningsDocURL == "" {
+		warningsDocURL = left.stmt.WarningsDocURL
+	}
+	if warningsDocURL == "" {
+		warningsDocURL = right.stmt.WarningsDocURL
+	}
+
+	return &qbtypes.Statement{
+		Query:          CombineCTEs(cteFragments) + mainSQL,
+		Args:           PrependArgs(cteArgs, mainArgs),
+		Warnings:       warnings,
+		WarningsDocURL: warningsDocURL,
+	}, nil
+}
+
+// buildSide renders the referenced builder query and wraps it so that the
+// side exposes a normalised set of columns: ts (time series only), the group
+// by keys and __result_N.
+func (b *joinStatementBuilder) buildSide(
+	ctx context.Context,
+	start uint64,
+	end uint64,
+	requestType qbtypes.RequestType,
+	ref string,
+	alias string,
+	compositeQuery *qbtypes.CompositeQuery,
+	variables map[string]qbtypes.VariableItem,
+) (*joinSide, error) {
+	var envelope *qbtypes.QueryEnvelope
+	for idx := range compositeQuery.Queries {
+		if compositeQuery.Queries[idx].GetQueryName() == ref {
+			envelope = &compositeQuery.Queries[idx]
+			break
+		}
+	}
+	if envelope == nil || envelope.Type != qbtypes.QueryTypeBuilder {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "query '%s' referenced in join does not exist or is not a builder query", ref)
+	}
+
+	side := &joinSide{ref: ref, alias: alias, aliases: map[string]int{}}
+
+	var err error
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		side.stmt, err = b.traceStmtBuilder.Build(ctx, start, end, requestType, spec, variables)
+		side.step = spec.StepInterval
+		side.results = len(spec.Aggregations)
+		for idx, agg := range spec.Aggregations {
+			if agg.Alias != "" {
+				side.aliases[agg.Alias] = idx
+			}
+		}
+		side.groupBy = groupByNames(spec.GroupBy)
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		stmtBuilder := b.logStmtBuilder
+		if spec.Source == telemetrytypes.SourceAudit {
+			stmtBuilder = b.auditStmtBuilder
+		}
+		side.stmt, err = stmtBuilder.Build(ctx, start, end, requestType, spec, variables)
+		side.step = spec.StepInterval
+		side.results = len(spec.Aggregations)
+		for idx, agg := range spec.Aggregations {
+			if agg.Alias != "" {
+				side.aliases[agg.Alias] = idx
+			}
+		}
+		side.groupBy = groupByNames(spec.GroupBy)
+	case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+		stmtBuilder := b.metricStmtBuilder
+		if spec.Source == telemetrytypes.SourceMeter {
+			stmtBuilder = b.meterStmtBuilder
+		}
+		side.stmt, err = stmtBuilder.Build(ctx, start, end, requestType, spec, variables)
+		side.step = spec.StepInterval
+		side.results = 1
+		side.isMetric = true
+		if len(spec.Aggregations) > 0 {
+			side.reduceTo = spec.Aggregations[0].ReduceTo
+		}
+		side.groupBy = groupByNames(spec.GroupBy)
+	default:
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported builder spec type %T for join", envelope.Spec)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if side.results == 0 {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "query '%s' referenced in join must have at least one aggregation", ref)
+	}
+
+	keyCols := make([]string, 0, len(side.groupBy))
+	for _, name := range side.groupBy {
+		keyCols = append(keyCols, fmt.Sprintf("`%s`", name))
+	}
+
+	columns := []string{}
+	if requestType == qbtypes.RequestTypeTimeSeries {
+		columns = append(columns, "toDateTime(ts) AS ts")
+	}
+	columns = append(columns, keyCols...)
+
+	switch {
+	case side.isMetric && requestType == qbtypes.RequestTypeScalar:
+		// metric statements are always time series, reduce them to a single value per series
+		columns = append(columns, fmt.Sprintf("%s AS __result_0", metricReduceExpr(side.reduceTo)))
+		side.sideSQL = fmt.Sprintf("SELECT %s FROM (%s)", strings.Join(columns, ", "), side.stmt.Query)
+		if len(keyCols) > 0 {
+			side.sideSQL += " GROUP BY " + strings.Join(keyCols, ", ")
+		}
+	case side.isMetric:
+		columns = append(columns, "value AS __result_0")
+		side.sideSQL = fmt.Sprintf("SELECT %s FROM (%s)", strings.Join(columns, ", "), side.stmt.Query)
+	default:
+		for idx := 0; idx < side.results; idx++ {
+			columns = append(columns, fmt.Sprintf("__result_%d", idx))
+		}
+		side.sideSQL = fmt.Sprintf("SELECT %s FROM (%s)", strings.Join(columns, ", "), side.stmt.Query)
+	}
+
+	return side, nil
+}
+
+// keyExpressions returns the expression for every key available after the join.
+// Join keys are resolved from the side that is guaranteed to be present for the join type.
+func (b *joinStatementBuilder) keyExpressions(joinType qbtypes.JoinType, conditions []qbtypes.JoinCondition, left, right *joinSide) map[string]string {
+	exprs := map[string]string{}
+	for _, name := range right.groupBy {
+		exprs[name] = fmt.Sprintf("%s.`%s`", right.alias, name)
+	}
+	for _, name := range left.groupBy {
+		exprs[name] = fmt.Sprintf("%s.`%s`", left.alias, name)
+	}
+
+	for _, cond := range conditions {
+		leftCol := fmt.Sprintf("%s.`%s`", left.alias, cond.Left)
+		rightCol := fmt.Sprintf("%s.`%s`", right.alias, cond.Right)
+
+		var expr string
+		switch joinType {
+		case qbtypes.JoinTypeRight:
+			expr = rightCol
+		case qbtypes.JoinTypeFull:
+			expr = fmt.Sprintf("if(%s != '', %s, %s)", leftCol, leftCol, rightCol)
+		default:
+			expr = leftCol
+		}
+		exprs[cond.Left] = expr
+		exprs[cond.Right] = expr
+	}
+
+	return exprs
+}
+
+// prepareFilter builds the post-join WHERE clause. Keys resolve to the joined
+// group by columns and references such as `A.__result_0` resolve to the result
+// columns of the referenced query.
+func (b *joinStatementBuilder) prepareFilter(
+	ctx context.Context,
+	start uint64,
+	end uint64,
+	expression string,
+	keyExprs map[string]string,
+	refs map[string]string,
+	variables map[string]qbtypes.VariableItem,
+) (PreparedWhereClause, error) {
+	columns := make(map[string]string, len(keyExprs)+len(refs))
+	fieldKeys := make(map[string][]*telemetrytypes.TelemetryFieldKey, len(keyExprs)+len(refs))
+	for name, expr := range keyExprs {
+		columns[name] = expr
+		fieldKeys[name] = []*telemetrytypes.TelemetryFieldKey{{Name: name, FieldDataType: telemetrytypes.FieldDataTypeString}}
+	}
+	for name, expr := range refs {
+		columns[name] = expr
+		fieldKeys[name] = []*telemetrytypes.TelemetryFieldKey{{Name: name, FieldDataType: telemetrytypes.FieldDataTypeFloat64}}
+	}
+
+	fm := &joinFieldMapper{columns: columns}
+	return PrepareWhereClause(expression, FilterExprVisitorOpts{
+		Context:            ctx,
+		Logger:             b.logger,
+		FieldMapper:        fm,
+		ConditionBuilder:   &joinConditionBuilder{fm: fm},
+		FieldKeys:          fieldKeys,
+		SkipResourceFilter: true,
+		SkipFullTextFilter: true,
+		SkipFunctionCalls:  true,
+		Variables:          variables,
+		StartNs:            ToNanoSecs(start),
+		EndNs:              ToNanoSecs(end),
+	})
+}
+
+// joinReferences maps references to the results of the joined queries, such as
+// `A`, `A.0`, `A.__result_0` or `A.<alias>`, to the columns of the joined sides.
+func joinReferences(left, right *joinSide) map[string]string {
+	refs := map[string]string{}
+	for _, side := range []*joinSide{left, right} {
+		for idx := 0; idx < side.results; idx++ {
+			column := fmt.Sprintf("%s.__result_%d", side.alias, idx)
+			refs[fmt.Sprintf("%s.%d", side.ref, idx)] = column
+			refs[fmt.Sprintf("%s.__result_%d", side.ref, idx)] = column
+		}
+		refs[side.ref] = fmt.Sprintf("%s.__result_0", side.alias)
+		for alias, idx := range side.aliases {
+			refs[fmt.Sprintf("%s.%s", side.ref, alias)] = fmt.Sprintf("%s.__result_%d", side.alias, idx)
+		}
+	}
+	return refs
+}
+
+// rewriteJoinReferences replaces references to the joined queries in an expression with their columns.
+func rewriteJoinReferences(expression string, refs map[string]string) string {
+	return joinReferenceRe.ReplaceAllStringFunc(expression, func(token string) string {
+		if column, ok := refs[token]; ok {
+			return column
+		}
+		return token
+	})
+}
+
+// joinOutputKeys returns the keys selected by a join without aggregations: the
+// join keys followed by the remaining group by keys of both sides.
+func joinOutputKeys(conditions []qbtypes.JoinCondition, left, right *joinSide) []string {
+	keys := []string{}
+	covered := map[string]bool{}
+	for _, cond := range conditions {
+		if !covered[cond.Left] {
+			keys = append(keys, cond.Left)
+			covered[cond.Left] = true
+		}
+		covered[cond.Right] = true
+	}
+	for _, side := range []*joinSide{left, right} {
+		for _, name := range side.groupBy {
+			if !covered[name] {
+				keys = append(keys, name)
+				covered[name] = true
+			}
+		}
+	}
+	return keys
+}
+
+func joinTimestampExpr(joinType qbtypes.JoinType) string {
+	switch joinType {
+	case qbtypes.JoinTypeRight:
+		return fmt.Sprintf("%s.ts", joinRightAlias)
+	case qbtypes.JoinTypeFull:
+		return fmt.Sprintf("greatest(%s.ts, %s.ts)", joinLeftAlias, joinRightAlias)
+	default:
+		return fmt.Sprintf("%s.ts", joinLeftAlias)
+	}
+}
+
+func joinOption(joinType qbtypes.JoinType) sqlbuilder.JoinOption {
+	switch joinType {
+	case qbtypes.JoinTypeLeft:
+		return sqlbuilder.LeftJoin
+	case qbtypes.JoinTypeRight:
+		return sqlbuilder.RightJoin
+	case qbtypes.JoinTypeFull:
+		return sqlbuilder.FullOuterJoin
+	case qbtypes.JoinTypeCross:
+		return sqlbuilder.JoinOption("CROSS")
+	default:
+		return sqlbuilder.InnerJoin
+	}
+}
+
+// metricReduceExpr returns the ClickHouse aggregation equivalent of the reduce to operator.
+func metricReduceExpr(reduceTo qbtypes.ReduceTo) string {
+	switch reduceTo {
+	case qbtypes.ReduceToSum:
+		return "sum(value)"
+	case qbtypes.ReduceToCount:
+		return "toFloat64(count(value))"
+	case qbtypes.ReduceToMin:
+		return "min(value)"
+	case qbtypes.ReduceToMax:
+		return "max(value)"
+	case qbtypes.ReduceToLast:
+		return "argMax(value, ts)"
+	case qbtypes.ReduceToMedian:
+		return "median(value)"
+	default:
+		return "avg(value)"
+	}
+}
+
+func groupByNames(keys []qbtypes.GroupByKey) []string {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.Name)
+	}
+	return names
+}
+
+// joinFieldMapper maps the keys of a post-join filter to the joined columns.
+type joinFieldMapper struct {
+	columns map[string]string
+}
+
+func (m *joinFieldMapper) FieldFor(_ context.Context, _, _ uint64, key *telemetrytypes.TelemetryFieldKey) (string, error) {
+	column, ok := m.columns[key.Name]
+	if !ok {
+		return "", qbtypes.ErrColumnNotFound
+	}
+	return column, nil
+}
+
+func (m *joinFieldMapper) ColumnFor(_ context.Context, _, _ uint64, _ *telemetrytypes.TelemetryFieldKey) ([]*schema.Column, error) {
+	return nil, qbtypes.ErrColumnNotFound
+}
+
+func (m *joinFieldMapper) ColumnExpressionFor(ctx context.Context, start, end uint64, key *telemetrytypes.TelemetryFieldKey, _ map[string][]*telemetrytypes.TelemetryFieldKey) (string, error) {
+	column, err := m.FieldFor(ctx, start, end, key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s AS `%s`", column, key.Name), nil
+}
+
+// joinConditionBuilder builds the conditions of a post-join filter.
+type joinConditionBuilder struct {
+	fm qbtypes.FieldMapper
+}
+
+func (c *joinConditionBuilder) ConditionFor(
+	ctx context.Context,
+	startNs uint64,
+	endNs uint64,
+	key *telemetrytypes.TelemetryFieldKey,
+	operator qbtypes.FilterOperator,
+	value any,
+	sb *sqlbuilder.SelectBuilder,
+) (string, error) {
+	if operator.IsStringSearchOperator() {
+		value = FormatValueForContains(value)
+	}
+
+	column, err := c.fm.FieldFor(ctx, startNs, endNs, key)
+	if err != nil {
+		return "", err
+	}
+
+	if key.FieldDataType == telemetrytypes.FieldDataTypeString {
+		if v, ok := value.(float64); ok {
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	switch operator {
+	case qbtypes.FilterOperatorEqual:
+		return sb.E(column, value), nil
+	case qbtypes.FilterOperatorNotEqual:
+		return sb.NE(column, value), nil
+	case qbtypes.FilterOperatorGreaterThan:
+		return sb.G(column, value), nil
+	case qbtypes.FilterOperatorGreaterThanOrEq:
+		return sb.GE(column, value), nil
+	case qbtypes.FilterOperatorLessThan:
+		return sb.LT(column, value), nil
+	case qbtypes.FilterOperatorLessThanOrEq:
+		return sb.LE(column, value), nil
+	case qbtypes.FilterOperatorLike:
+		return sb.Like(column, value), nil
+	case qbtypes.FilterOperatorNotLike:
+		return sb.NotLike(column, value), nil
+	case qbtypes.FilterOperatorILike:
+		return sb.ILike(column, value), nil
+	case qbtypes.FilterOperatorNotILike:
+		return sb.NotILike(column, value), nil
+	case qbtypes.FilterOperatorContains:
+		return sb.ILike(column, fmt.Sprintf("%%%s%%", value)), nil
+	case qbtypes.FilterOperatorNotContains:
+		return sb.NotILike(column, fmt.Sprintf("%%%s%%", value)), nil
+	case qbtypes.FilterOperatorRegexp:
+		return fmt.Sprintf(`match(%s, %s)`, sqlbuilder.Escape(column), sb.Var(value)), nil
+	case qbtypes.FilterOperatorNotRegexp:
+		return fmt.Sprintf(`NOT match(%s, %s)`, sqlbuilder.Escape(column), sb.Var(value)), nil
+	case qbtypes.FilterOperatorBetween, qbtypes.FilterOperatorNotBetween:
+		values, ok := value.([]any)
+		if !ok || len(values) != 2 {
+			return "", qbtypes.ErrBetweenValues
+		}
+		if operator == qbtypes.FilterOperatorBetween {
+			return sb.Between(column, values[0], values[1]), nil
+		}
+		return sb.NotBetween(column, values[0], values[1]), nil
+	case qbtypes.FilterOperatorIn, qbtypes.FilterOperatorNotIn:
+		values, ok := value.([]any)
+		if !ok {
+			return "", qbtypes.ErrInValues
+		}
+		if operator == qbtypes.FilterOperatorIn {
+			return sb.In(column, values), nil
+		}
+		return sb.NotIn(column, values), nil
+	case qbtypes.FilterOperatorExists:
+		if key.FieldDataType == telemetrytypes.FieldDataTypeString {
+			return sb.NE(column, ""), nil
+		}
+		return "true", nil
+	case qbtypes.FilterOperatorNotExists:
+		if key.FieldDataType == telemetrytypes.FieldDataTypeString {
+			return sb.E(column, ""), nil
+		}
+		return "false", nil
+	}
+
+	return "", qbtypes.ErrUnsupportedOperator
+}
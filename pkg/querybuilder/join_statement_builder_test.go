@@ -0,0 +1,182 @@
+package querybuilder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/querybuilder/querybuildertest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJoinStatementBuilder() *joinStatementBuilder {
+	return NewJoinStatementBuilder(
+		instrumentationtest.New().ToProviderSettings(),
+		&querybuildertest.FixedStatementBuilder[qbtypes.TraceAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.LogAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.LogAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.MetricAggregation]{},
+		&querybuildertest.FixedStatementBuilder[qbtypes.MetricAggregation]{},
+	)
+}
+
+func TestJoinStatementBuilder(t *testing.T) {
+	step := qbtypes.Step{Duration: time.Minute}
+
+	logsQuery := qbtypes.QueryEnvelope{
+		Type: qbtypes.QueryTypeBuilder,
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+			Name:         "A",
+			Signal:       telemetrytypes.SignalLogs,
+			StepInterval: step,
+			Aggregations: []qbtypes.LogAggregation{{Expression: "count()", Alias: "errors"}},
+			GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}},
+		},
+	}
+	tracesQuery := qbtypes.QueryEnvelope{
+		Type: qbtypes.QueryTypeBuilder,
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+			Name:         "B",
+			Signal:       telemetrytypes.SignalTraces,
+			StepInterval: step,
+			Aggregations: []qbtypes.TraceAggregation{{Expression: "count()"}, {Expression: "p99(duration_nano)"}},
+			GroupBy: []qbtypes.GroupByKey{
+				{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}},
+				{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "deployment.environment"}},
+			},
+		},
+	}
+	metricsQuery := qbtypes.QueryEnvelope{
+		Type: qbtypes.QueryTypeBuilder,
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+			Name:         "C",
+			Signal:       telemetrytypes.SignalMetrics,
+			StepInterval: qbtypes.Step{Duration: 5 * time.Minute},
+			Aggregations: []qbtypes.MetricAggregation{{MetricName: "cpu", ReduceTo: qbtypes.ReduceToMax}},
+			GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}},
+		},
+	}
+	compositeQuery := &qbtypes.CompositeQuery{Queries: []qbtypes.QueryEnvelope{logsQuery, tracesQuery, metricsQuery}}
+
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.QueryBuilderJoin
+		expected    qbtypes.Statement
+		expectedErr string
+	}{
+		{
+			name:        "time series inner join",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "J",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "B"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "service.name = service.name",
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __join_left AS (SELECT toDateTime(ts) AS ts, `service.name`, __result_0 FROM (SELECT A)), __join_right AS (SELECT toDateTime(ts) AS ts, `service.name`, `deployment.environment`, __result_0, __result_1 FROM (SELECT B)) " +
+					"SELECT l.ts AS ts, l.`service.name` AS `service.name`, r.`deployment.environment` AS `deployment.environment`, l.__result_0 AS __result_0, r.__result_0 AS __result_1, r.__result_1 AS __result_2 " +
+					"FROM __join_left AS l INNER JOIN __join_right AS r ON l.`service.name` = r.`service.name` AND l.ts = r.ts ORDER BY ts desc",
+				Args: []any{"A", "B"},
+			},
+		},
+		{
+			name:        "time series join with aggregation, filter and having",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query: qbtypes.QueryBuilderJoin{
+				Name:         "J",
+				Left:         qbtypes.QueryRef{Name: "A"},
+				Right:        qbtypes.QueryRef{Name: "B"},
+				Type:         qbtypes.JoinTypeLeft,
+				On:           "A.service.name = B.service.name",
+				Aggregations: []any{qbtypes.JoinAggregation{Expression: "sum(A.errors) / sum(B.0)", Alias: "error_rate"}},
+				GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}},
+				Filter:       &qbtypes.Filter{Expression: "deployment.environment = 'prod'"},
+				Having:       &qbtypes.Having{Expression: "error_rate > 0.1"},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __join_left AS (SELECT toDateTime(ts) AS ts, `service.name`, __result_0 FROM (SELECT A)), __join_right AS (SELECT toDateTime(ts) AS ts, `service.name`, `deployment.environment`, __result_0, __result_1 FROM (SELECT B)) " +
+					"SELECT l.ts AS ts, l.`service.name` AS `service.name`, sum(l.__result_0) / sum(r.__result_0) AS __result_0 " +
+					"FROM __join_left AS l LEFT JOIN __join_right AS r ON l.`service.name` = r.`service.name` AND l.ts = r.ts " +
+					"WHERE r.`deployment.environment` = ? GROUP BY ts, `service.name` HAVING __result_0 > 0.1 ORDER BY ts desc",
+				Args: []any{"A", "B", "prod"},
+			},
+		},
+		{
+			name:        "scalar join with metric side",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "J",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "C"},
+				Type:  qbtypes.JoinTypeFull,
+				On:    "service.name = service.name",
+				Limit: 10,
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __join_left AS (SELECT `service.name`, __result_0 FROM (SELECT A)), __join_right AS (SELECT `service.name`, max(value) AS __result_0 FROM (SELECT C) GROUP BY `service.name`) " +
+					"SELECT if(l.`service.name` != '', l.`service.name`, r.`service.name`) AS `service.name`, l.__result_0 AS __result_0, r.__result_0 AS __result_1 " +
+					"FROM __join_left AS l FULL OUTER JOIN __join_right AS r ON l.`service.name` = r.`service.name` ORDER BY __result_0 DESC LIMIT ?",
+				Args: []any{"A", "C", 10},
+			},
+		},
+		{
+			name:        "step interval mismatch",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "J",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "C"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "service.name = service.name",
+			},
+			expectedErr: "must have the same step interval",
+		},
+		{
+			name:        "join key not grouped",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "J",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "B"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "host.name = service.name",
+			},
+			expectedErr: "join key `host.name` is not a group by key of query 'A'",
+		},
+		{
+			name:        "raw request",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "J",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "B"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "service.name = service.name",
+			},
+			expectedErr: "join is only supported for time_series and scalar requests",
+		},
+	}
+
+	builder := newTestJoinStatementBuilder()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt, err := builder.Build(context.Background(), 1747947419000, 1747983448000, c.requestType, c.query, compositeQuery, nil)
+			if c.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), c.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, c.expected.Query, stmt.Query)
+			assert.Equal(t, c.expected.Args, stmt.Args)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	schema "github.com/SigNoz/signoz-otel-collector/cmd/signozschemamigrator/schema_migrator"
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const (
+	// MaxLookupBytes bounds the size of the rows of the lookup tables inlined
+	// in a statement.
+	MaxLookupBytes = 16 << 20
+)
+
+// AddLookupKeys adds a lookup context key for every column of the lookup
+// tables joined by the query, named `<table>.<column>`. The keys the tables are
+// joined on are resolved against the keys from the metadata.
+func AddLookupKeys(keys map[string][]*telemetrytypes.TelemetryFieldKey, lookups []qbtypes.LookupJoin) error {
+	for _, lookup := range lookups {
+		if !lookup.IsResolved() {
+			return errors.NewInternalf(errors.CodeInternal, "lookup table '%s' is not resolved", lookup.Table)
+		}
+
+		keyIdx := lookupKeyIndex(lookup)
+		if keyIdx < 0 {
+			return errors.NewInternalf(errors.CodeInternal, "key column '%s' of lookup table '%s' is not one of its columns", lookup.KeyColumn, lookup.Table)
+		}
+
+		key := subQueryFilterKey(lookup.Key, keys)
+		for idx, column := range lookup.Columns {
+			name := lookup.Table + "." + column
+			keys[name] = append(keys[name], &telemetrytypes.TelemetryFieldKey{
+				Name:          name,
+				FieldContext:  telemetrytypes.FieldContextLookup,
+				FieldDataType: telemetrytypes.FieldDataTypeString,
+				Lookup: &telemetrytypes.LookupColumn{
+					CTEName:  lookup.CTEName(),
+					KeyIndex: keyIdx,
+					Index:    idx,
+					Key:      key,
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+// LookupCTEs renders the CTE fragments holding the rows of the lookup tables
+// joined by the query. Every CTE is a single row with an array per column of
+// the table, so that fields can be looked up with transform.
+func LookupCTEs(lookups []qbtypes.LookupJoin) ([]string, [][]any, error) {
+	var (
+		fragments []string
+		args      [][]any
+		size      int
+	)
+
+	for _, lookup := range lookups {
+		if !lookup.IsResolved() {
+			return nil, nil, errors.NewInternalf(errors.CodeInternal, "lookup table '%s' is not resolved", lookup.Table)
+		}
+
+		columns := make([]string, len(lookup.Columns))
+		values := make([]any, len(lookup.Columns))
+		for idx := range lookup.Columns {
+			column := make([]string, len(lookup.Rows))
+			for jdx, row := range lookup.Rows {
+				column[jdx] = row[idx]
+				size += len(row[idx])
+			}
+			columns[idx] = fmt.Sprintf("CAST(? AS Array(String)) AS `%s`", lookupColumnName(idx))
+			values[idx] = column
+		}
+
+		if size > MaxLookupBytes {
+			return nil, nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "lookup tables joined by the query exceed %d bytes", MaxLookupBytes).
+				WithAdditional("Join fewer or smaller lookup tables, or enrich the data at ingestion with a lookup log pipeline operator instead")
+		}
+
+		fragments = append(fragments, fmt.Sprintf("%s AS (SELECT %s)", lookup.CTEName(), strings.Join(columns, ", ")))
+		args = append(args, values)
+	}
+
+	return fragments, args, nil
+}
+
+// LookupFieldFor returns the expression looking the value of the key of a
+// lookup context field up in the CTE rendered by LookupCTEs. Values which are
+// not in the table resolve to an empty string.
+func LookupFieldFor(
+	ctx context.Context,
+	fm qbtypes.FieldMapper,
+	start, end uint64,
+	key *telemetrytypes.TelemetryFieldKey,
+) (string, error) {
+	if key.Lookup == nil {
+		return "", qbtypes.ErrColumnNotFound
+	}
+
+	keyExpr, err := fm.FieldFor(ctx, start, end, key.Lookup.Key)
+	if err != nil {
+		return "", errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "failed to resolve the key `%s` of lookup field `%s`", key.Lookup.Key.Name, key.Name)
+	}
+
+	return fmt.Sprintf(
+		"transform(toString(%s), (SELECT `%s` FROM %s), (SELECT `%s` FROM %s), '')",
+		keyExpr,
+		lookupColumnName(key.Lookup.KeyIndex), key.Lookup.CTEName,
+		lookupColumnName(key.Lookup.Index), key.Lookup.CTEName,
+	), nil
+}
+
+// LookupColumnFor returns the column of a lookup context field, lookups
+// always resolve to strings.
+func LookupColumnFor(key *telemetrytypes.TelemetryFieldKey) ([]*schema.Column, error) {
+	if key.Lookup == nil {
+		return nil, qbtypes.ErrColumnNotFound
+	}
+	return []*schema.Column{{Name: key.Name, Type: schema.ColumnTypeString}}, nil
+}
+
+func lookupKeyIndex(lookup qbtypes.LookupJoin) int {
+	for idx, column := range lookup.Columns {
+		if column == lookup.KeyColumn {
+			return idx
+		}
+	}
+	return -1
+}
+
+func lookupColumnName(idx int) string {
+	return fmt.Sprintf("__column_%d", idx)
+}
@@ -0,0 +1,17 @@
+package querybuildertest
+
+import (
+	"context"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+var _ qbtypes.StatementBuilder[qbtypes.LogAggregation] = (*FixedStatementBuilder[qbtypes.LogAggregation])(nil)
+
+// FixedStatementBuilder returns a statement which only contains the name of
+// the query, both in the query and in the args.
+type FixedStatementBuilder[T any] struct{}
+
+func (b *FixedStatementBuilder[T]) Build(_ context.Context, _, _ uint64, _ qbtypes.RequestType, query qbtypes.QueryBuilderQuery[T], _ map[string]qbtypes.VariableItem) (*qbtypes.Statement, error) {
+	return &qbtypes.Statement{Query: "SELECT " + query.Name, Args: []any{query.Name}}, nil
+}
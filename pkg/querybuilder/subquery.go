@@ -0,0 +1,79 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// SubQueryCTEs renders the CTE fragments for the sub queries referenced by the filters.
+// A sub query referenced by more than one filter is rendered once.
+func SubQueryCTEs(filters []qbtypes.SubQueryFilter) ([]string, [][]any, error) {
+	var (
+		fragments []string
+		args      [][]any
+		seen      = make(map[string]bool)
+	)
+
+	for _, filter := range filters {
+		if seen[filter.Query] {
+			continue
+		}
+		seen[filter.Query] = true
+
+		if filter.Statement == nil {
+			return nil, nil, errors.NewInternalf(errors.CodeInternal, "sub query '%s' is not resolved", filter.Query)
+		}
+
+		fragments = append(fragments, fmt.Sprintf("%s AS (%s)", filter.CTEName(), filter.Statement.Query))
+		args = append(args, filter.Statement.Args)
+	}
+
+	return fragments, args, nil
+}
+
+// AddSubQueryConditions restricts the select builder to the values returned by
+// the sub queries. The CTEs must be rendered with SubQueryCTEs.
+func AddSubQueryConditions(
+	ctx context.Context,
+	fm qbtypes.FieldMapper,
+	sb *sqlbuilder.SelectBuilder,
+	start, end uint64,
+	filters []qbtypes.SubQueryFilter,
+	keys map[string][]*telemetrytypes.TelemetryFieldKey,
+) error {
+	for _, filter := range filters {
+		key := subQueryFilterKey(filter.Key, keys)
+
+		fieldName, err := fm.FieldFor(ctx, start, end, key)
+		if err != nil {
+			return errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "failed to resolve sub query filter key `%s`", filter.Key.Name)
+		}
+
+		op := "GLOBAL IN"
+		if filter.Not {
+			op = "GLOBAL NOT IN"
+		}
+		sb.Where(fmt.Sprintf("%s %s (SELECT `%s` FROM %s)", fieldName, op, filter.ColumnName(), filter.CTEName()))
+	}
+
+	return nil
+}
+
+// subQueryFilterKey returns the best matching key from the metadata for the filter key.
+func subQueryFilterKey(key telemetrytypes.TelemetryFieldKey, keys map[string][]*telemetrytypes.TelemetryFieldKey) *telemetrytypes.TelemetryFieldKey {
+	for _, candidate := range keys[key.Name] {
+		if key.FieldContext != telemetrytypes.FieldContextUnspecified && candidate.FieldContext != key.FieldContext {
+			continue
+		}
+		if key.FieldDataType != telemetrytypes.FieldDataTypeUnspecified && candidate.FieldDataType != key.FieldDataType {
+			continue
+		}
+		return candidate
+	}
+	return &key
+}
@@ -216,3 +216,15 @@ func AssignReservedVars(vars map[string]any, start, end uint64) {
 	vars["start_datetime"] = fmt.Sprintf("toDateTime(%d)", start/1_000_000_000)
 	vars["end_datetime"] = fmt.Sprintf("toDateTime(%d)", end/1_000_000_000)
 }
+
+// AssignPromQLReservedVars assigns the interval variables of Grafana, so that
+// the queries of imported Grafana dashboards render. $__rate_interval is
+// computed as Grafana does it, for a scrape interval of a minute.
+func AssignPromQLReservedVars(vars map[string]any, start, end uint64, step time.Duration) {
+	start = ToNanoSecs(start)
+	end = ToNanoSecs(end)
+
+	vars["__interval"] = fmt.Sprintf("%ds", int64(step.Seconds()))
+	vars["__rate_interval"] = fmt.Sprintf("%ds", int64(max(step+time.Minute, 4*time.Minute).Seconds()))
+	vars["__range"] = fmt.Sprintf("%ds", (end-start)/1_000_000_000)
+}
@@ -1,6 +1,10 @@
 package querybuilder
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+	"time"
+)
 
 func TestToNanoSecs(t *testing.T) {
 	tests := []struct {
@@ -108,3 +112,32 @@ func TestToMilliSecs(t *testing.T) {
 		})
 	}
 }
+
+func TestAssignPromQLReservedVars(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     time.Duration
+		expected map[string]any
+	}{
+		{
+			name:     "step of a minute",
+			step:     time.Minute,
+			expected: map[string]any{"__interval": "60s", "__rate_interval": "240s", "__range": "3600s"},
+		},
+		{
+			name:     "step longer than the rate interval floor",
+			step:     5 * time.Minute,
+			expected: map[string]any{"__interval": "300s", "__rate_interval": "360s", "__range": "3600s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars := map[string]any{}
+			AssignPromQLReservedVars(vars, 1672531200000, 1672534800000, tt.step)
+			if !reflect.DeepEqual(vars, tt.expected) {
+				t.Errorf("AssignPromQLReservedVars() = %v, want %v", vars, tt.expected)
+			}
+		})
+	}
+}
@@ -8,6 +8,10 @@ import (
 
 type Config struct {
 	EvalDelay time.Duration `mapstructure:"eval_delay"`
+
+	// SyncInterval is how often the ruler picks up the rules of the orgs the
+	// instance has come to own, see the sharder.
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -16,7 +20,8 @@ func NewConfigFactory() factory.ConfigFactory {
 
 func newConfig() factory.Config {
 	return Config{
-		EvalDelay: 2 * time.Minute,
+		EvalDelay:    2 * time.Minute,
+		SyncInterval: 1 * time.Minute,
 	}
 }
 
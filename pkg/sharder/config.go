@@ -1,6 +1,9 @@
 package sharder
 
 import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -8,12 +11,43 @@ import (
 type Config struct {
 	Provider string `mapstructure:"provider"`
 	Single   Single `mapstructure:"single"`
+	Ring     Ring   `mapstructure:"ring"`
 }
 
 type Single struct {
 	OrgID valuer.UUID `mapstructure:"org_id"`
 }
 
+type Ring struct {
+	// InstanceID identifies the instance on the ring. Defaults to the hostname.
+	InstanceID string `mapstructure:"instance_id"`
+
+	// Tokens is the number of points every instance takes on the ring. More
+	// tokens spread the keys more evenly across the instances.
+	Tokens int `mapstructure:"tokens"`
+
+	// Membership is how the instances on the ring find each other, either
+	// static or sqlstore.
+	Membership string `mapstructure:"membership"`
+
+	Static   RingStatic   `mapstructure:"static"`
+	SQLStore RingSQLStore `mapstructure:"sqlstore"`
+}
+
+type RingStatic struct {
+	// Peers are the instance ids of all the instances, this one included.
+	Peers []string `mapstructure:"peers"`
+}
+
+type RingSQLStore struct {
+	// HeartbeatInterval is how often the instance announces itself and
+	// looks up the other instances.
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+
+	// Timeout is how long after its last heartbeat an instance leaves the ring.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
 func NewConfigFactory() factory.ConfigFactory {
 	return factory.NewConfigFactory(factory.MustNewName("sharder"), newConfig)
 }
@@ -24,9 +58,41 @@ func newConfig() factory.Config {
 		Single: Single{
 			OrgID: valuer.UUID{},
 		},
+		Ring: Ring{
+			Tokens:     64,
+			Membership: "sqlstore",
+			SQLStore: RingSQLStore{
+				HeartbeatInterval: 10 * time.Second,
+				Timeout:           30 * time.Second,
+			},
+		},
 	}
 }
 
 func (c Config) Validate() error {
+	if c.Provider != "ring" {
+		return nil
+	}
+
+	if c.Ring.Tokens <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "sharder::ring::tokens must be greater than 0")
+	}
+
+	switch c.Ring.Membership {
+	case "static":
+		if len(c.Ring.Static.Peers) == 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "sharder::ring::static::peers must not be empty")
+		}
+	case "sqlstore":
+		if c.Ring.SQLStore.HeartbeatInterval <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "sharder::ring::sqlstore::heartbeat_interval must be greater than 0")
+		}
+		if c.Ring.SQLStore.Timeout <= c.Ring.SQLStore.HeartbeatInterval {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "sharder::ring::sqlstore::timeout must be greater than sharder::ring::sqlstore::heartbeat_interval")
+		}
+	default:
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "sharder::ring::membership must be one of static or sqlstore, got %q", c.Ring.Membership)
+	}
+
 	return nil
 }
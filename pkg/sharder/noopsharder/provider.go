@@ -10,6 +10,7 @@ import (
 
 type provider struct {
 	settings factory.ScopedProviderSettings
+	stopC    chan struct{}
 }
 
 func NewFactory() factory.ProviderFactory[sharder.Sharder, sharder.Config] {
@@ -21,11 +22,22 @@ func New(ctx context.Context, providerSettings factory.ProviderSettings, config
 
 	return &provider{
 		settings: settings,
+		stopC:    make(chan struct{}),
 	}, nil
 }
 
-func (provider *provider) GetMyOwnedKeyRange(ctx context.Context) (uint32, uint32, error) {
-	return 0, math.MaxUint32, nil
+func (provider *provider) Start(ctx context.Context) error {
+	<-provider.stopC
+	return nil
+}
+
+func (provider *provider) Stop(ctx context.Context) error {
+	close(provider.stopC)
+	return nil
+}
+
+func (provider *provider) GetMyOwnedKeyRanges(ctx context.Context) ([]sharder.KeyRange, error) {
+	return []sharder.KeyRange{{Start: 0, End: math.MaxUint32}}, nil
 }
 
 func (provider *provider) IsMyOwnedKey(ctx context.Context, key uint32) error {
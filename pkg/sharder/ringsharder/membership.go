@@ -0,0 +1,88 @@
+package ringsharder
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/shardertypes"
+)
+
+// membership tells the instances on the ring.
+type membership interface {
+	// heartbeat announces the instance as alive.
+	heartbeat(context.Context) error
+
+	// members returns the instance ids of the instances on the ring.
+	members(context.Context) ([]string, error)
+
+	// leave takes the instance off the ring.
+	leave(context.Context) error
+}
+
+// staticMembership is a fixed list of instances. Instances join or leave by
+// changing the list on all of them.
+type staticMembership struct {
+	peers []string
+}
+
+func (m *staticMembership) heartbeat(context.Context) error { return nil }
+
+func (m *staticMembership) members(context.Context) ([]string, error) { return m.peers, nil }
+
+func (m *staticMembership) leave(context.Context) error { return nil }
+
+// sqlMembership keeps the instances in the sql store. Every instance writes a
+// heartbeat periodically and the instances whose last heartbeat is older than
+// the timeout are off the ring.
+type sqlMembership struct {
+	sqlstore   sqlstore.SQLStore
+	instanceID string
+	timeout    time.Duration
+	now        func() time.Time
+}
+
+func (m *sqlMembership) heartbeat(ctx context.Context) error {
+	member := shardertypes.NewStorableMember(m.instanceID, m.now())
+
+	_, err := m.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(member).
+		On("CONFLICT (id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return err
+}
+
+func (m *sqlMembership) members(ctx context.Context) ([]string, error) {
+	members := make([]*shardertypes.StorableMember, 0)
+	err := m.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&members).
+		Where("updated_at > ?", m.now().Add(-m.timeout)).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.ID)
+	}
+	return ids, nil
+}
+
+func (m *sqlMembership) leave(ctx context.Context) error {
+	_, err := m.
+		sqlstore.
+		BunDB().
+		NewDelete().
+		Model(new(shardertypes.StorableMember)).
+		Where("id = ?", m.instanceID).
+		Exec(ctx)
+	return err
+}
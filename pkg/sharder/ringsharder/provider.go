@@ -0,0 +1,166 @@
+package ringsharder
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sharder"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+)
+
+type provider struct {
+	settings   factory.ScopedProviderSettings
+	instanceID string
+	tokens     int
+	membership membership
+	interval   time.Duration
+
+	// mtx protects the ring and the key ranges owned by the instance, both
+	// are replaced on every refresh.
+	mtx   sync.RWMutex
+	ring  *ring
+	owned []sharder.KeyRange
+
+	stopC chan struct{}
+}
+
+func NewFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[sharder.Sharder, sharder.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("ring"), func(ctx context.Context, providerSettings factory.ProviderSettings, config sharder.Config) (sharder.Sharder, error) {
+		return New(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config sharder.Config, sqlstore sqlstore.SQLStore) (sharder.Sharder, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/sharder/ringsharder")
+
+	instanceID := config.Ring.InstanceID
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "cannot use the hostname as sharder::ring::instance_id")
+		}
+		instanceID = hostname
+	}
+
+	var membership membership
+	var interval time.Duration
+	switch config.Ring.Membership {
+	case "static":
+		if !slices.Contains(config.Ring.Static.Peers, instanceID) {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "sharder::ring::static::peers must contain the instance id %q", instanceID)
+		}
+		membership = &staticMembership{peers: config.Ring.Static.Peers}
+	case "sqlstore":
+		membership = &sqlMembership{sqlstore: sqlstore, instanceID: instanceID, timeout: config.Ring.SQLStore.Timeout, now: time.Now}
+		interval = config.Ring.SQLStore.HeartbeatInterval
+	default:
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "sharder::ring::membership must be one of static or sqlstore, got %q", config.Ring.Membership)
+	}
+
+	provider := newProvider(settings, instanceID, config.Ring.Tokens, membership, interval)
+	if err := provider.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+func newProvider(settings factory.ScopedProviderSettings, instanceID string, tokens int, membership membership, interval time.Duration) *provider {
+	return &provider{
+		settings:   settings,
+		instanceID: instanceID,
+		tokens:     tokens,
+		membership: membership,
+		interval:   interval,
+		stopC:      make(chan struct{}),
+	}
+}
+
+func (provider *provider) Start(ctx context.Context) error {
+	// A static ring never changes.
+	if provider.interval == 0 {
+		<-provider.stopC
+		return nil
+	}
+
+	ticker := time.NewTicker(provider.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-provider.stopC:
+			return nil
+		case <-ticker.C:
+			if err := provider.refresh(ctx); err != nil {
+				provider.settings.Logger().ErrorContext(ctx, "failed to refresh the sharder ring", errors.Attr(err))
+			}
+		}
+	}
+}
+
+func (provider *provider) Stop(ctx context.Context) error {
+	close(provider.stopC)
+
+	// Leaving right away hands the keys of the instance to the others on
+	// their next refresh, without waiting for the heartbeat to time out.
+	return provider.membership.leave(ctx)
+}
+
+func (provider *provider) GetMyOwnedKeyRanges(ctx context.Context) ([]sharder.KeyRange, error) {
+	provider.mtx.RLock()
+	defer provider.mtx.RUnlock()
+
+	return slices.Clone(provider.owned), nil
+}
+
+func (provider *provider) IsMyOwnedKey(ctx context.Context, key uint32) error {
+	provider.mtx.RLock()
+	owner := provider.ring.owner(key)
+	provider.mtx.RUnlock()
+
+	if owner == provider.instanceID {
+		return nil
+	}
+
+	return errors.Newf(errors.TypeForbidden, errors.CodeForbidden, "key %d is owned by instance %s and not by my current instance %s", key, owner, provider.instanceID)
+}
+
+// refresh announces the instance and rebuilds the ring from the instances
+// on it.
+func (provider *provider) refresh(ctx context.Context) error {
+	if err := provider.membership.heartbeat(ctx); err != nil {
+		return err
+	}
+
+	members, err := provider.membership.members(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The instance is on the ring while it runs, even before its own
+	// heartbeat is visible.
+	if !slices.Contains(members, provider.instanceID) {
+		members = append(members, provider.instanceID)
+	}
+
+	ring := newRing(members, provider.tokens)
+	owned := ring.keyRanges(provider.instanceID)
+
+	provider.mtx.Lock()
+	prev := provider.ring
+	provider.ring = ring
+	provider.owned = owned
+	provider.mtx.Unlock()
+
+	if prev == nil || !slices.Equal(prev.members, ring.members) {
+		provider.settings.Logger().InfoContext(ctx, "sharder ring changed", slog.String("instance_id", provider.instanceID), slog.Any("members", ring.members), slog.Int("owned_key_ranges", len(owned)))
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package ringsharder
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/sharder"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	"github.com/SigNoz/signoz/pkg/types/shardertypes"
+)
+
+func newTestSQLStore(t *testing.T) sqlstore.SQLStore {
+	t.Helper()
+
+	store, err := sqlitesqlstore.New(context.Background(), factorytest.NewSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Connection: sqlstore.ConnectionConfig{
+			MaxOpenConns: 1,
+		},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:            filepath.Join(t.TempDir(), "test.db"),
+			Mode:            "wal",
+			BusyTimeout:     5 * time.Second,
+			TransactionMode: "deferred",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = store.BunDB().NewCreateTable().Model((*shardertypes.StorableMember)(nil)).IfNotExists().Exec(context.Background())
+	require.NoError(t, err)
+
+	return store
+}
+
+func ringConfig(instanceID string) sharder.Config {
+	return sharder.Config{
+		Provider: "ring",
+		Ring: sharder.Ring{
+			InstanceID: instanceID,
+			Tokens:     64,
+			Membership: "sqlstore",
+			SQLStore: sharder.RingSQLStore{
+				HeartbeatInterval: 10 * time.Second,
+				Timeout:           30 * time.Second,
+			},
+		},
+	}
+}
+
+// assertOwnedOnce checks that every key is owned by exactly one of the
+// members, both by its key ranges and by IsMyOwnedKey.
+func assertOwnedOnce(t *testing.T, members []sharder.Sharder, keys []uint32) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, key := range keys {
+		owners := 0
+		for _, member := range members {
+			keyRanges, err := member.GetMyOwnedKeyRanges(ctx)
+			require.NoError(t, err)
+
+			err = member.IsMyOwnedKey(ctx, key)
+			assert.Equal(t, err == nil, inKeyRanges(keyRanges, key))
+			if err == nil {
+				owners++
+			} else {
+				assert.True(t, errors.Ast(err, errors.TypeForbidden))
+			}
+		}
+		assert.Equal(t, 1, owners, "key %d", key)
+	}
+}
+
+func TestProviderSQLStoreMembership(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLStore(t)
+	keys := orgKeys(500)
+
+	var members []sharder.Sharder
+	for _, id := range []string{"signoz-0", "signoz-1", "signoz-2"} {
+		member, err := New(ctx, factorytest.NewSettings(), ringConfig(id), store)
+		require.NoError(t, err)
+		members = append(members, member)
+	}
+
+	// The members which were up before the others joined see them on their
+	// next heartbeat.
+	for _, member := range members {
+		require.NoError(t, member.(*provider).refresh(ctx))
+	}
+	assertOwnedOnce(t, members, keys)
+
+	for _, member := range members {
+		keyRanges, err := member.GetMyOwnedKeyRanges(ctx)
+		require.NoError(t, err)
+		assert.NotEmpty(t, keyRanges)
+	}
+
+	// A member leaving hands its keys to the others.
+	require.NoError(t, members[1].Stop(ctx))
+	members = []sharder.Sharder{members[0], members[2]}
+	for _, member := range members {
+		require.NoError(t, member.(*provider).refresh(ctx))
+	}
+	assertOwnedOnce(t, members, keys)
+}
+
+func TestProviderSQLStoreMembershipTimeout(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLStore(t)
+
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	newMember := func(id string) *sqlMembership {
+		return &sqlMembership{sqlstore: store, instanceID: id, timeout: 30 * time.Second, now: func() time.Time { return now }}
+	}
+
+	m0, m1 := newMember("signoz-0"), newMember("signoz-1")
+	require.NoError(t, m0.heartbeat(ctx))
+	require.NoError(t, m1.heartbeat(ctx))
+
+	members, err := m0.members(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"signoz-0", "signoz-1"}, members)
+
+	// signoz-1 stops sending heartbeats.
+	now = now.Add(20 * time.Second)
+	require.NoError(t, m0.heartbeat(ctx))
+	now = now.Add(20 * time.Second)
+
+	members, err = m0.members(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"signoz-0"}, members)
+
+	// and comes back.
+	require.NoError(t, m1.heartbeat(ctx))
+
+	members, err = m0.members(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"signoz-0", "signoz-1"}, members)
+}
+
+func TestProviderStaticMembership(t *testing.T) {
+	ctx := context.Background()
+	peers := []string{"signoz-0", "signoz-1", "signoz-2", "signoz-3"}
+
+	var members []sharder.Sharder
+	for _, id := range peers {
+		config := ringConfig(id)
+		config.Ring.Membership = "static"
+		config.Ring.Static.Peers = peers
+		require.NoError(t, config.Validate())
+
+		member, err := New(ctx, factorytest.NewSettings(), config, nil)
+		require.NoError(t, err)
+		members = append(members, member)
+	}
+
+	assertOwnedOnce(t, members, orgKeys(500))
+}
+
+func TestProviderStaticMembershipWithoutInstance(t *testing.T) {
+	config := ringConfig("signoz-9")
+	config.Ring.Membership = "static"
+	config.Ring.Static.Peers = []string{"signoz-0", "signoz-1"}
+
+	_, err := New(context.Background(), factorytest.NewSettings(), config, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestProviderStartStop(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLStore(t)
+
+	member, err := New(ctx, factorytest.NewSettings(), ringConfig("signoz-0"), store)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() { done <- member.Start(ctx) }()
+	require.NoError(t, member.Stop(ctx))
+	require.NoError(t, <-done)
+
+	count, err := store.BunDB().NewSelect().Model((*shardertypes.StorableMember)(nil)).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
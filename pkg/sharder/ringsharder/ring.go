@@ -0,0 +1,104 @@
+package ringsharder
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/SigNoz/signoz/pkg/sharder"
+)
+
+type token struct {
+	value  uint32
+	member string
+}
+
+// ring is a consistent hash ring over the key space. Every member takes a
+// number of tokens on the ring and owns the keys from the token before each
+// of its tokens, excluded, up to the token, included. A member joining or
+// leaving only moves the keys next to its own tokens.
+type ring struct {
+	tokens  []token
+	members []string
+}
+
+func newRing(members []string, tokensPerMember int) *ring {
+	members = slices.Clone(members)
+	slices.Sort(members)
+	members = slices.Compact(members)
+
+	tokens := make([]token, 0, len(members)*tokensPerMember)
+	for _, member := range members {
+		for i := range tokensPerMember {
+			tokens = append(tokens, token{value: uint32(xxhash.Sum64String(member + "-" + strconv.Itoa(i))), member: member})
+		}
+	}
+
+	// Ties between tokens of different members go to the smaller member, so
+	// every instance builds the same ring from the same members.
+	slices.SortFunc(tokens, func(a, b token) int {
+		return cmp.Or(cmp.Compare(a.value, b.value), cmp.Compare(a.member, b.member))
+	})
+
+	return &ring{tokens: tokens, members: members}
+}
+
+// owner returns the member owning the key, empty when the ring is empty.
+func (r *ring) owner(key uint32) string {
+	if len(r.tokens) == 0 {
+		return ""
+	}
+
+	i, _ := slices.BinarySearchFunc(r.tokens, key, func(t token, key uint32) int {
+		return cmp.Compare(t.value, key)
+	})
+	if i == len(r.tokens) {
+		i = 0
+	}
+	return r.tokens[i].member
+}
+
+// keyRanges returns the sorted ranges of keys owned by the member, with
+// adjacent ranges merged.
+func (r *ring) keyRanges(member string) []sharder.KeyRange {
+	var ranges []sharder.KeyRange
+	for i, t := range r.tokens {
+		if t.member != member {
+			continue
+		}
+
+		if i == 0 {
+			// The first token also owns the keys after the last token.
+			ranges = append(ranges, sharder.KeyRange{Start: 0, End: t.value})
+			if last := r.tokens[len(r.tokens)-1].value; last < math.MaxUint32 {
+				ranges = append(ranges, sharder.KeyRange{Start: last + 1, End: math.MaxUint32})
+			}
+			continue
+		}
+
+		prev := r.tokens[i-1].value
+		if prev == t.value {
+			// The tie went to the token before.
+			continue
+		}
+		ranges = append(ranges, sharder.KeyRange{Start: prev + 1, End: t.value})
+	}
+
+	slices.SortFunc(ranges, func(a, b sharder.KeyRange) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	merged := make([]sharder.KeyRange, 0, len(ranges))
+	for _, kr := range ranges {
+		if n := len(merged); n > 0 && merged[n-1].End != math.MaxUint32 && merged[n-1].End+1 >= kr.Start {
+			merged[n-1].End = max(merged[n-1].End, kr.End)
+			continue
+		}
+		merged = append(merged, kr)
+	}
+
+	return merged
+}
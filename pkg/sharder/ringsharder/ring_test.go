@@ -0,0 +1,118 @@
+package ringsharder
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz/pkg/sharder"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func orgKeys(n int) []uint32 {
+	keys := make([]uint32, n)
+	for i := range keys {
+		keys[i] = types.NewOrganizationKey(valuer.GenerateUUID())
+	}
+	return keys
+}
+
+func inKeyRanges(keyRanges []sharder.KeyRange, key uint32) bool {
+	for _, kr := range keyRanges {
+		if key >= kr.Start && key <= kr.End {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRingKeyRangesCoverKeySpaceOnce(t *testing.T) {
+	r := newRing([]string{"signoz-0", "signoz-1", "signoz-2"}, 64)
+
+	var all []sharder.KeyRange
+	for _, member := range r.members {
+		keyRanges := r.keyRanges(member)
+		for i, kr := range keyRanges {
+			require.LessOrEqual(t, kr.Start, kr.End)
+			if i > 0 {
+				// merged, so never adjacent
+				require.Greater(t, kr.Start, keyRanges[i-1].End+1)
+			}
+			assert.Equal(t, member, r.owner(kr.Start))
+			assert.Equal(t, member, r.owner(kr.End))
+		}
+		all = append(all, keyRanges...)
+	}
+
+	var size uint64
+	for _, kr := range all {
+		size += uint64(kr.End-kr.Start) + 1
+	}
+	assert.Equal(t, uint64(math.MaxUint32)+1, size)
+
+	for _, key := range orgKeys(1000) {
+		owners := 0
+		for _, member := range r.members {
+			if inKeyRanges(r.keyRanges(member), key) {
+				owners++
+				assert.Equal(t, member, r.owner(key))
+			}
+		}
+		assert.Equal(t, 1, owners)
+	}
+}
+
+func TestRingSingleMemberOwnsEverything(t *testing.T) {
+	r := newRing([]string{"signoz-0"}, 64)
+
+	assert.Equal(t, []sharder.KeyRange{{Start: 0, End: math.MaxUint32}}, r.keyRanges("signoz-0"))
+	assert.Equal(t, "signoz-0", r.owner(0))
+	assert.Equal(t, "signoz-0", r.owner(math.MaxUint32))
+	assert.Empty(t, r.keyRanges("signoz-1"))
+}
+
+func TestRingEmpty(t *testing.T) {
+	r := newRing(nil, 64)
+
+	assert.Equal(t, "", r.owner(42))
+	assert.Empty(t, r.keyRanges("signoz-0"))
+}
+
+func TestRingIsIndependentOfMemberOrder(t *testing.T) {
+	a := newRing([]string{"signoz-0", "signoz-1", "signoz-2"}, 16)
+	b := newRing([]string{"signoz-2", "signoz-0", "signoz-1", "signoz-0"}, 16)
+
+	assert.Equal(t, a.tokens, b.tokens)
+}
+
+func TestRingRebalancesOnJoinAndLeave(t *testing.T) {
+	keys := orgKeys(5000)
+	before := newRing([]string{"signoz-0", "signoz-1", "signoz-2"}, 64)
+	after := newRing([]string{"signoz-0", "signoz-1", "signoz-2", "signoz-3"}, 64)
+
+	owned := map[string]int{}
+	for _, key := range keys {
+		owner := after.owner(key)
+		owned[owner]++
+
+		// Only the keys taken by the joining member move.
+		if prev := before.owner(key); prev != owner {
+			assert.Equal(t, "signoz-3", owner)
+		}
+	}
+
+	// Every member owns a fair part of the keys.
+	for _, member := range after.members {
+		assert.Greater(t, owned[member], len(keys)/4/2, member)
+	}
+
+	// Leaving hands the keys of the member to the others and moves no other key.
+	for _, key := range keys {
+		if after.owner(key) != "signoz-3" {
+			assert.Equal(t, after.owner(key), before.owner(key))
+		}
+	}
+}
@@ -2,12 +2,22 @@ package sharder
 
 import (
 	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
 )
 
+// KeyRange is a range of keys, both ends included.
+type KeyRange struct {
+	Start uint32
+	End   uint32
+}
+
 type Sharder interface {
-	// Returns the keys owned by the current instance.
-	GetMyOwnedKeyRange(context.Context) (uint32, uint32, error)
+	factory.Service
+
+	// Returns the key ranges owned by the current instance.
+	GetMyOwnedKeyRanges(context.Context) ([]KeyRange, error)
 
-	// Returns true if the key is owned by the current instance.
+	// Returns nil if the key is owned by the current instance.
 	IsMyOwnedKey(context.Context, uint32) error
 }
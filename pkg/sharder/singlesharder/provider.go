@@ -14,6 +14,7 @@ type provider struct {
 	settings factory.ScopedProviderSettings
 	orgID    valuer.UUID
 	orgIDKey uint32
+	stopC    chan struct{}
 }
 
 func NewFactory() factory.ProviderFactory[sharder.Sharder, sharder.Config] {
@@ -27,11 +28,22 @@ func New(ctx context.Context, providerSettings factory.ProviderSettings, config
 		settings: settings,
 		orgID:    config.Single.OrgID,
 		orgIDKey: types.NewOrganizationKey(config.Single.OrgID),
+		stopC:    make(chan struct{}),
 	}, nil
 }
 
-func (provider *provider) GetMyOwnedKeyRange(ctx context.Context) (uint32, uint32, error) {
-	return provider.orgIDKey, provider.orgIDKey, nil
+func (provider *provider) Start(ctx context.Context) error {
+	<-provider.stopC
+	return nil
+}
+
+func (provider *provider) Stop(ctx context.Context) error {
+	close(provider.stopC)
+	return nil
+}
+
+func (provider *provider) GetMyOwnedKeyRanges(ctx context.Context) ([]sharder.KeyRange, error) {
+	return []sharder.KeyRange{{Start: provider.orgIDKey, End: provider.orgIDKey}}, nil
 }
 
 func (provider *provider) IsMyOwnedKey(ctx context.Context, key uint32) error {
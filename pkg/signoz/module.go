@@ -11,16 +11,22 @@ import (
 	"github.com/SigNoz/signoz/pkg/flagger"
 	"github.com/SigNoz/signoz/pkg/modules/apdex"
 	"github.com/SigNoz/signoz/pkg/modules/apdex/implapdex"
+	"github.com/SigNoz/signoz/pkg/modules/audit"
+	"github.com/SigNoz/signoz/pkg/modules/audit/implaudit"
 	"github.com/SigNoz/signoz/pkg/modules/authdomain"
 	"github.com/SigNoz/signoz/pkg/modules/authdomain/implauthdomain"
 	"github.com/SigNoz/signoz/pkg/modules/cloudintegration"
 	"github.com/SigNoz/signoz/pkg/modules/dashboard"
 	"github.com/SigNoz/signoz/pkg/modules/inframonitoring"
 	"github.com/SigNoz/signoz/pkg/modules/inframonitoring/implinframonitoring"
+	"github.com/SigNoz/signoz/pkg/modules/ingestionrule"
+	"github.com/SigNoz/signoz/pkg/modules/ingestionrule/implingestionrule"
 	"github.com/SigNoz/signoz/pkg/modules/llmpricingrule"
 	"github.com/SigNoz/signoz/pkg/modules/llmpricingrule/impllmpricingrule"
 	"github.com/SigNoz/signoz/pkg/modules/logspipeline"
 	"github.com/SigNoz/signoz/pkg/modules/logspipeline/impllogspipeline"
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable/impllookuptable"
 	"github.com/SigNoz/signoz/pkg/modules/metricsexplorer"
 	"github.com/SigNoz/signoz/pkg/modules/metricsexplorer/implmetricsexplorer"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
@@ -66,33 +72,37 @@ import (
 )
 
 type Modules struct {
-	OrgGetter        organization.Getter
-	OrgSetter        organization.Setter
-	Preference       preference.Module
-	UserSetter       user.Setter
-	UserGetter       user.Getter
-	RetentionGetter  retention.Getter
-	SavedView        savedview.Module
-	Apdex            apdex.Module
-	Dashboard        dashboard.Module
-	QuickFilter      quickfilter.Module
-	TraceFunnel      tracefunnel.Module
-	RawDataExport    rawdataexport.Module
-	AuthDomain       authdomain.Module
-	Session          session.Module
-	Services         services.Module
-	SpanPercentile   spanpercentile.Module
-	MetricsExplorer  metricsexplorer.Module
-	InfraMonitoring  inframonitoring.Module
-	Promote          promote.Module
-	ServiceAccount   serviceaccount.Module
-	CloudIntegration cloudintegration.Module
-	LogsPipeline     logspipeline.Module
-	RuleStateHistory rulestatehistory.Module
-	TraceDetail      tracedetail.Module
-	SpanMapper       spanmapper.Module
-	LLMPricingRule   llmpricingrule.Module
-	Tag              tag.Module
+	OrgGetter         organization.Getter
+	OrgSetter         organization.Setter
+	Preference        preference.Module
+	UserSetter        user.Setter
+	UserGetter        user.Getter
+	RetentionGetter   retention.Getter
+	LookupTableGetter lookuptable.Getter
+	SavedView         savedview.Module
+	Apdex             apdex.Module
+	Dashboard         dashboard.Module
+	QuickFilter       quickfilter.Module
+	TraceFunnel       tracefunnel.Module
+	RawDataExport     rawdataexport.Module
+	AuthDomain        authdomain.Module
+	Session           session.Module
+	Services          services.Module
+	SpanPercentile    spanpercentile.Module
+	MetricsExplorer   metricsexplorer.Module
+	InfraMonitoring   inframonitoring.Module
+	Promote           promote.Module
+	ServiceAccount    serviceaccount.Module
+	CloudIntegration  cloudintegration.Module
+	LogsPipeline      logspipeline.Module
+	RuleStateHistory  rulestatehistory.Module
+	TraceDetail       tracedetail.Module
+	SpanMapper        spanmapper.Module
+	LLMPricingRule    llmpricingrule.Module
+	Tag               tag.Module
+	Audit             audit.Module
+	IngestionRule     ingestionrule.Module
+	LookupTable       lookuptable.Module
 }
 
 func NewModules(
@@ -117,6 +127,7 @@ func NewModules(
 	serviceAccount serviceaccount.Module,
 	cloudIntegrationModule cloudintegration.Module,
 	retentionGetter retention.Getter,
+	lookupTableGetter lookuptable.Getter,
 	fl flagger.Flagger,
 	tagModule tag.Module,
 ) Modules {
@@ -124,34 +135,39 @@ func NewModules(
 	orgSetter := implorganization.NewSetter(implorganization.NewStore(sqlstore), alertmanager, quickfilter)
 	userSetter := impluser.NewSetter(impluser.NewStore(sqlstore, providerSettings), tokenizer, emailing, providerSettings, orgSetter, authz, analytics, config.User, userRoleStore, userGetter)
 	ruleStore := sqlrulestore.NewRuleStore(sqlstore, queryParser, providerSettings)
+	lookupTable := impllookuptable.NewModule(impllookuptable.NewStore(sqlstore))
 
 	return Modules{
-		OrgGetter:        orgGetter,
-		OrgSetter:        orgSetter,
-		Preference:       implpreference.NewModule(implpreference.NewStore(sqlstore), preferencetypes.NewAvailablePreference()),
-		SavedView:        implsavedview.NewModule(sqlstore),
-		Apdex:            implapdex.NewModule(sqlstore),
-		Dashboard:        dashboard,
-		UserSetter:       userSetter,
-		UserGetter:       userGetter,
-		RetentionGetter:  retentionGetter,
-		QuickFilter:      quickfilter,
-		TraceFunnel:      impltracefunnel.NewModule(impltracefunnel.NewStore(sqlstore)),
-		RawDataExport:    implrawdataexport.NewModule(querier),
-		AuthDomain:       implauthdomain.NewModule(implauthdomain.NewStore(sqlstore), authNs),
-		Session:          implsession.NewModule(providerSettings, authNs, userSetter, userGetter, implauthdomain.NewModule(implauthdomain.NewStore(sqlstore), authNs), tokenizer, orgGetter),
-		SpanPercentile:   implspanpercentile.NewModule(querier, providerSettings),
-		Services:         implservices.NewModule(querier, telemetryStore),
-		MetricsExplorer:  implmetricsexplorer.NewModule(telemetryStore, telemetryMetadataStore, cache, ruleStore, dashboard, providerSettings, config.MetricsExplorer),
-		InfraMonitoring:  implinframonitoring.NewModule(telemetryStore, telemetryMetadataStore, querier, providerSettings, config.InfraMonitoring),
-		Promote:          implpromote.NewModule(telemetryMetadataStore, telemetryStore),
-		ServiceAccount:   serviceAccount,
-		LogsPipeline:     impllogspipeline.NewModule(sqlstore),
-		RuleStateHistory: implrulestatehistory.NewModule(implrulestatehistory.NewStore(telemetryStore, telemetryMetadataStore, providerSettings.Logger)),
-		CloudIntegration: cloudIntegrationModule,
-		TraceDetail:      impltracedetail.NewModule(impltracedetail.NewTraceStore(telemetryStore), providerSettings, config.TraceDetail),
-		SpanMapper:       implspanmapper.NewModule(implspanmapper.NewStore(sqlstore)),
-		LLMPricingRule:   impllmpricingrule.NewModule(impllmpricingrule.NewStore(sqlstore)),
-		Tag:              tagModule,
+		OrgGetter:         orgGetter,
+		OrgSetter:         orgSetter,
+		Preference:        implpreference.NewModule(implpreference.NewStore(sqlstore), preferencetypes.NewAvailablePreference()),
+		SavedView:         implsavedview.NewModule(sqlstore),
+		Apdex:             implapdex.NewModule(sqlstore),
+		Dashboard:         dashboard,
+		UserSetter:        userSetter,
+		UserGetter:        userGetter,
+		RetentionGetter:   retentionGetter,
+		LookupTableGetter: lookupTableGetter,
+		QuickFilter:       quickfilter,
+		TraceFunnel:       impltracefunnel.NewModule(impltracefunnel.NewStore(sqlstore), telemetryStore, telemetryMetadataStore, fl, providerSettings),
+		RawDataExport:     implrawdataexport.NewModule(querier, implrawdataexport.NewStore(sqlstore), implrawdataexport.NewLocalSink(config.RawDataExport.Jobs.Local), providerSettings, config.RawDataExport),
+		AuthDomain:        implauthdomain.NewModule(implauthdomain.NewStore(sqlstore), authNs),
+		Session:           implsession.NewModule(providerSettings, authNs, userSetter, userGetter, implauthdomain.NewModule(implauthdomain.NewStore(sqlstore), authNs), tokenizer, orgGetter),
+		SpanPercentile:    implspanpercentile.NewModule(querier, providerSettings),
+		Services:          implservices.NewModule(querier, telemetryStore),
+		MetricsExplorer:   implmetricsexplorer.NewModule(telemetryStore, telemetryMetadataStore, cache, ruleStore, dashboard, providerSettings, config.MetricsExplorer),
+		InfraMonitoring:   implinframonitoring.NewModule(telemetryStore, telemetryMetadataStore, querier, providerSettings, config.InfraMonitoring),
+		Promote:           implpromote.NewModule(telemetryMetadataStore, telemetryStore),
+		ServiceAccount:    serviceAccount,
+		LogsPipeline:      impllogspipeline.NewModule(sqlstore),
+		RuleStateHistory:  implrulestatehistory.NewModule(implrulestatehistory.NewStore(telemetryStore, telemetryMetadataStore, providerSettings.Logger)),
+		CloudIntegration:  cloudIntegrationModule,
+		TraceDetail:       impltracedetail.NewModule(impltracedetail.NewTraceStore(telemetryStore), providerSettings, config.TraceDetail),
+		SpanMapper:        implspanmapper.NewModule(implspanmapper.NewStore(sqlstore), lookupTable),
+		LLMPricingRule:    impllmpricingrule.NewModule(impllmpricingrule.NewStore(sqlstore)),
+		Tag:               tagModule,
+		Audit:             implaudit.NewModule(querier),
+		IngestionRule:     implingestionrule.NewModule(implingestionrule.NewStore(sqlstore)),
+		LookupTable:       lookupTable,
 	}
 }
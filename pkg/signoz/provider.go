@@ -16,6 +16,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/cache/memorycache"
 	"github.com/SigNoz/signoz/pkg/cache/rediscache"
+	"github.com/SigNoz/signoz/pkg/cache/tieredcache"
 	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/emailing/noopemailing"
 	"github.com/SigNoz/signoz/pkg/emailing/smtpemailing"
@@ -31,6 +32,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/meterreporter"
 	"github.com/SigNoz/signoz/pkg/meterreporter/noopmeterreporter"
 	"github.com/SigNoz/signoz/pkg/modules/authdomain/implauthdomain"
+	"github.com/SigNoz/signoz/pkg/modules/lookuptable"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/modules/organization/implorganization"
 	"github.com/SigNoz/signoz/pkg/modules/preference/implpreference"
@@ -48,6 +50,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/querier/signozquerier"
 	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/sharder/noopsharder"
+	"github.com/SigNoz/signoz/pkg/sharder/ringsharder"
 	"github.com/SigNoz/signoz/pkg/sharder/singlesharder"
 	"github.com/SigNoz/signoz/pkg/sqlmigration"
 	"github.com/SigNoz/signoz/pkg/sqlschema"
@@ -84,6 +87,7 @@ func NewCacheProviderFactories() factory.NamedMap[factory.ProviderFactory[cache.
 	return factory.MustNewNamedMap(
 		memorycache.NewFactory(),
 		rediscache.NewFactory(),
+		tieredcache.NewFactory(),
 	)
 }
 
@@ -211,6 +215,14 @@ func NewSQLMigrationProviderFactories(
 		sqlmigration.NewAddDashboardNameFactory(sqlstore, sqlschema),
 		sqlmigration.NewFixChangelogOperationTypeFactory(sqlstore, sqlschema),
 		sqlmigration.NewCloudIntegrationRemoveCascadeDeleteFactory(sqlschema),
+		sqlmigration.NewAddExportJobFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddSLOFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddSharderMemberFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddDashboardRevisionFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddSourceToTraceFunnelFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddIngestionRuleFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddLookupTableFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddLeaseToExportJobFactory(sqlstore, sqlschema),
 	)
 }
 
@@ -255,10 +267,11 @@ func NewEmailingProviderFactories() factory.NamedMap[factory.ProviderFactory[ema
 	)
 }
 
-func NewSharderProviderFactories() factory.NamedMap[factory.ProviderFactory[sharder.Sharder, sharder.Config]] {
+func NewSharderProviderFactories(sqlstore sqlstore.SQLStore) factory.NamedMap[factory.ProviderFactory[sharder.Sharder, sharder.Config]] {
 	return factory.MustNewNamedMap(
 		singlesharder.NewFactory(),
 		noopsharder.NewFactory(),
+		ringsharder.NewFactory(sqlstore),
 	)
 }
 
@@ -269,9 +282,9 @@ func NewStatsReporterProviderFactories(telemetryStore telemetrystore.TelemetrySt
 	)
 }
 
-func NewQuerierProviderFactories(telemetryStore telemetrystore.TelemetryStore, prometheus prometheus.Prometheus, cache cache.Cache, flagger flagger.Flagger) factory.NamedMap[factory.ProviderFactory[querier.Querier, querier.Config]] {
+func NewQuerierProviderFactories(telemetryStore telemetrystore.TelemetryStore, prometheus prometheus.Prometheus, cache cache.Cache, lookupTableGetter lookuptable.Getter, flagger flagger.Flagger) factory.NamedMap[factory.ProviderFactory[querier.Querier, querier.Config]] {
 	return factory.MustNewNamedMap(
-		signozquerier.NewFactory(telemetryStore, prometheus, cache, flagger),
+		signozquerier.NewFactory(telemetryStore, prometheus, cache, lookupTableGetter, flagger),
 	)
 }
 
@@ -307,6 +320,10 @@ func NewAPIServerProviderFactories(orgGetter organization.Getter, authz authz.Au
 			handlers.LLMPricingRuleHandler,
 			handlers.TraceDetail,
 			handlers.RulerHandler,
+			handlers.SLOHandler,
+			handlers.AuditHandler,
+			handlers.IngestionRuleHandler,
+			handlers.LookupTableHandler,
 		),
 	)
 }
@@ -0,0 +1,81 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlschema"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type addSourceToTraceFunnel struct {
+	sqlstore  sqlstore.SQLStore
+	sqlschema sqlschema.SQLSchema
+}
+
+func NewAddSourceToTraceFunnelFactory(sqlstore sqlstore.SQLStore, sqlschema sqlschema.SQLSchema) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(
+		factory.MustNewName("add_source_to_trace_funnel"),
+		func(ctx context.Context, ps factory.ProviderSettings, c Config) (SQLMigration, error) {
+			return &addSourceToTraceFunnel{
+				sqlstore:  sqlstore,
+				sqlschema: sqlschema,
+			}, nil
+		},
+	)
+}
+
+func (migration *addSourceToTraceFunnel) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (migration *addSourceToTraceFunnel) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	table, uniqueConstraints, err := migration.sqlschema.GetTable(ctx, "trace_funnel")
+	if err != nil {
+		return err
+	}
+
+	// Existing funnels keep NULL for both columns, which reads back as a trace
+	// funnel correlated by trace_id.
+	columns := []*sqlschema.Column{
+		{
+			Name:     sqlschema.ColumnName("signal"),
+			DataType: sqlschema.DataTypeText,
+			Nullable: true,
+		},
+		{
+			Name:     sqlschema.ColumnName("correlation_key"),
+			DataType: sqlschema.DataTypeText,
+			Nullable: true,
+		},
+	}
+
+	for _, column := range columns {
+		sqls := migration.sqlschema.Operator().AddColumn(table, uniqueConstraints, column, nil)
+		for _, sql := range sqls {
+			if _, err := tx.ExecContext(ctx, string(sql)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (migration *addSourceToTraceFunnel) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
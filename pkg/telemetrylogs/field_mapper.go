@@ -9,6 +9,7 @@ import (
 	"github.com/SigNoz/signoz-otel-collector/utils"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
 	"github.com/SigNoz/signoz/pkg/types/featuretypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
@@ -105,6 +106,8 @@ func (m *fieldMapper) getColumn(ctx context.Context, key *telemetrytypes.Telemet
 		}
 		// Fall back to legacy body column
 		return []*schema.Column{logsV2Columns["body"]}, nil
+	case telemetrytypes.FieldContextLookup:
+		return querybuilder.LookupColumnFor(key)
 	case telemetrytypes.FieldContextLog, telemetrytypes.FieldContextUnspecified:
 		// TODO(Tushar): thread orgID here to evaluate correctly
 		if key.Name == LogsV2BodyColumn && m.fl.BooleanOrEmpty(ctx, flagger.FeatureUseJSONBody, featuretypes.NewFlaggerEvaluationContext(valuer.UUID{})) {
@@ -134,6 +137,10 @@ func (m *fieldMapper) getColumn(ctx context.Context, key *telemetrytypes.Telemet
 }
 
 func (m *fieldMapper) FieldFor(ctx context.Context, tsStart, tsEnd uint64, key *telemetrytypes.TelemetryFieldKey) (string, error) {
+	if key.FieldContext == telemetrytypes.FieldContextLookup {
+		return querybuilder.LookupFieldFor(ctx, m, tsStart, tsEnd, key)
+	}
+
 	columns, err := m.getColumn(ctx, key)
 	if err != nil {
 		return "", err
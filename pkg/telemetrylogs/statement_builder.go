@@ -99,6 +99,11 @@ func (b *logQueryStatementBuilder) Build(
 		return nil, err
 	}
 
+	// lookup table columns are resolved like any other key
+	if err := querybuilder.AddLookupKeys(keys, query.Lookups); err != nil {
+		return nil, err
+	}
+
 	query = b.adjustKeys(ctx, keys, query, requestType)
 
 	// Create SQL builder
@@ -149,6 +154,26 @@ func getKeySelectors(query qbtypes.QueryBuilderQuery[qbtypes.LogAggregation], bo
 		})
 	}
 
+	for idx := range query.SubQueryFilters {
+		key := query.SubQueryFilters[idx].Key
+		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
+			Name:          key.Name,
+			Signal:        telemetrytypes.SignalLogs,
+			FieldContext:  key.FieldContext,
+			FieldDataType: key.FieldDataType,
+		})
+	}
+
+	for idx := range query.Lookups {
+		key := query.Lookups[idx].Key
+		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
+			Name:          key.Name,
+			Signal:        telemetrytypes.SignalLogs,
+			FieldContext:  key.FieldContext,
+			FieldDataType: key.FieldDataType,
+		})
+	}
+
 	for idx := range query.SelectFields {
 		selectField := query.SelectFields[idx]
 		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
@@ -288,6 +313,20 @@ func (b *logQueryStatementBuilder) buildListQuery(
 		cteArgs = append(cteArgs, args)
 	}
 
+	subQueryFragments, subQueryArgs, err := querybuilder.SubQueryCTEs(query.SubQueryFilters)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, subQueryFragments...)
+	cteArgs = append(cteArgs, subQueryArgs...)
+
+	lookupFragments, lookupArgs, err := querybuilder.LookupCTEs(query.Lookups)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, lookupFragments...)
+	cteArgs = append(cteArgs, lookupArgs...)
+
 	// Select timestamp and id by default
 	sb.Select(LogsV2TimestampColumn)
 	sb.SelectMore(LogsV2IDColumn)
@@ -392,6 +431,20 @@ func (b *logQueryStatementBuilder) buildTimeSeriesQuery(
 		cteArgs = append(cteArgs, args)
 	}
 
+	subQueryFragments, subQueryArgs, err := querybuilder.SubQueryCTEs(query.SubQueryFilters)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, subQueryFragments...)
+	cteArgs = append(cteArgs, subQueryArgs...)
+
+	lookupFragments, lookupArgs, err := querybuilder.LookupCTEs(query.Lookups)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, lookupFragments...)
+	cteArgs = append(cteArgs, lookupArgs...)
+
 	sb.SelectMore(fmt.Sprintf(
 		"toStartOfInterval(fromUnixTimestamp64Nano(timestamp), INTERVAL %d SECOND) AS ts",
 		int64(query.StepInterval.Seconds()),
@@ -552,6 +605,23 @@ func (b *logQueryStatementBuilder) buildScalarQuery(
 		cteArgs = append(cteArgs, args)
 	}
 
+	// the outer query declares the sub query CTEs when this is the limit CTE
+	if !skipResourceCTE {
+		subQueryFragments, subQueryArgs, err := querybuilder.SubQueryCTEs(query.SubQueryFilters)
+		if err != nil {
+			return nil, err
+		}
+		cteFragments = append(cteFragments, subQueryFragments...)
+		cteArgs = append(cteArgs, subQueryArgs...)
+
+		lookupFragments, lookupArgs, err := querybuilder.LookupCTEs(query.Lookups)
+		if err != nil {
+			return nil, err
+		}
+		cteFragments = append(cteFragments, lookupFragments...)
+		cteArgs = append(cteArgs, lookupArgs...)
+	}
+
 	allAggChArgs := []any{}
 
 	var allGroupByArgs []any
@@ -688,6 +758,11 @@ func (b *logQueryStatementBuilder) addFilterCondition(
 		sb.AddWhereClause(preparedWhereClause.WhereClause)
 	}
 
+	// restrict to the values returned by the sub queries
+	if err := querybuilder.AddSubQueryConditions(ctx, b.fm, sb, start, end, query.SubQueryFilters, keys); err != nil {
+		return preparedWhereClause, err
+	}
+
 	// add time filter
 	startBucket := start/querybuilder.NsToSeconds - querybuilder.BucketAdjustment
 	var endBucket uint64
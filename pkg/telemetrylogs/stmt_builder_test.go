@@ -16,6 +16,7 @@ import (
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1305,3 +1306,283 @@ func newSkipResourceFingerprintLogsBuilder(
 		threshold,
 	)
 }
+
+func TestStatementBuilderSubQueryFilter(t *testing.T) {
+	servicesStmt := &qbtypes.Statement{
+		Query: "SELECT `service.name`, count() AS __result_0 FROM signoz_traces.distributed_signoz_index_v3 WHERE has_error = ? GROUP BY `service.name` ORDER BY __result_0 DESC LIMIT ?",
+		Args:  []any{true, 10},
+	}
+	traceIDsStmt := &qbtypes.Statement{
+		Query: "SELECT trace_id FROM signoz_traces.distributed_signoz_index_v3 WHERE duration_nano > ? LIMIT ?",
+		Args:  []any{float64(1000000000), 1000},
+	}
+
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]
+		expected    qbtypes.Statement
+		expectedErr error
+	}{
+		{
+			name:        "scalar query restricted to the services of a sub query",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+				SubQueryFilters: []qbtypes.SubQueryFilter{
+					{
+						Key:       telemetrytypes.TelemetryFieldKey{Name: "service.name"},
+						Query:     "B",
+						Statement: servicesStmt,
+					},
+				},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __sub_query_B AS (SELECT `service.name`, count() AS __result_0 FROM signoz_traces.distributed_signoz_index_v3 WHERE has_error = ? GROUP BY `service.name` ORDER BY __result_0 DESC LIMIT ?) SELECT count() AS __result_0 FROM signoz_logs.distributed_logs_v2 WHERE resource.`service.name`::String GLOBAL IN (SELECT `service.name` FROM __sub_query_B) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? ORDER BY __result_0 DESC",
+				Args:  []any{true, 10, "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448)},
+			},
+		},
+		{
+			name:        "list query excluding the traces of a sub query",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal: telemetrytypes.SignalLogs,
+				Limit:  10,
+				SubQueryFilters: []qbtypes.SubQueryFilter{
+					{
+						Key:       telemetrytypes.TelemetryFieldKey{Name: "trace_id"},
+						Query:     "B",
+						Not:       true,
+						Statement: traceIDsStmt,
+					},
+				},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __sub_query_B AS (SELECT trace_id FROM signoz_traces.distributed_signoz_index_v3 WHERE duration_nano > ? LIMIT ?) SELECT timestamp, id, trace_id, span_id, trace_flags, severity_text, severity_number, scope_name, scope_version, body, attributes_string, attributes_number, attributes_bool, resources_string, scope_string FROM signoz_logs.distributed_logs_v2 WHERE trace_id GLOBAL NOT IN (SELECT `trace_id` FROM __sub_query_B) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? LIMIT ?",
+				Args:  []any{float64(1000000000), 1000, "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448), 10},
+			},
+		},
+		{
+			name:        "unresolved sub query",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+				SubQueryFilters: []qbtypes.SubQueryFilter{
+					{
+						Key:   telemetrytypes.TelemetryFieldKey{Name: "service.name"},
+						Query: "B",
+					},
+				},
+			},
+			expectedErr: errors.NewInternalf(errors.CodeInternal, "sub query 'B' is not resolved"),
+		},
+	}
+
+	ctx := context.Background()
+	fl := flaggertest.New(t)
+
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = buildCompleteFieldKeyMap(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+
+	fm := NewFieldMapper(fl)
+	cb := NewConditionBuilder(fm, fl)
+
+	aggExprRewriter := querybuilder.NewAggExprRewriter(instrumentationtest.New().ToProviderSettings(), nil, fm, cb, nil, fl)
+
+	statementBuilder := NewLogQueryStatementBuilder(
+		instrumentationtest.New().ToProviderSettings(),
+		mockMetadataStore,
+		fm,
+		cb,
+		aggExprRewriter,
+		DefaultFullTextColumn,
+		GetBodyJSONKey,
+		fl,
+		nil,
+		false,
+		100000,
+	)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := statementBuilder.Build(ctx, 1747947419000, 1747983448000, c.requestType, c.query, nil)
+
+			if c.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expected.Query, q.Query)
+				require.Equal(t, c.expected.Args, q.Args)
+			}
+		})
+	}
+}
+
+func TestStatementBuilderLookup(t *testing.T) {
+	users := qbtypes.LookupJoin{
+		Table:     "users",
+		Key:       telemetrytypes.TelemetryFieldKey{Name: "user.id"},
+		KeyColumn: "id",
+		Columns:   []string{"id", "name", "tier"},
+		Rows:      [][]string{{"u-1", "Alice", "gold"}, {"u-2", "Bob", "silver"}},
+		VersionID: valuer.GenerateUUID(),
+	}
+
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]
+		expected    qbtypes.Statement
+		expectedErr error
+	}{
+		{
+			name:        "scalar query grouped and filtered by lookup columns",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+				Filter:       &qbtypes.Filter{Expression: "lookup.users.tier = 'gold'"},
+				GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "users.name", FieldContext: telemetrytypes.FieldContextLookup}}},
+				Lookups:      []qbtypes.LookupJoin{users},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH `__lookup_users` AS (SELECT CAST(? AS Array(String)) AS `__column_0`, CAST(? AS Array(String)) AS `__column_1`, CAST(? AS Array(String)) AS `__column_2`) SELECT toString(multiIf(transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_1` FROM `__lookup_users`), '') <> ?, transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_1` FROM `__lookup_users`), ''), NULL)) AS `users.name`, count() AS __result_0 FROM signoz_logs.distributed_logs_v2 WHERE (transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_2` FROM `__lookup_users`), '') = ? AND transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_2` FROM `__lookup_users`), '') <> ?) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? GROUP BY `users.name` ORDER BY __result_0 DESC",
+				Args:  []any{[]string{"u-1", "u-2"}, []string{"Alice", "Bob"}, []string{"gold", "silver"}, "", "gold", "", "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448)},
+			},
+		},
+		{
+			name:        "list query selecting a lookup column",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal:       telemetrytypes.SignalLogs,
+				Limit:        10,
+				SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "users.name"}},
+				Lookups:      []qbtypes.LookupJoin{users},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH `__lookup_users` AS (SELECT CAST(? AS Array(String)) AS `__column_0`, CAST(? AS Array(String)) AS `__column_1`, CAST(? AS Array(String)) AS `__column_2`) SELECT timestamp, id, transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_1` FROM `__lookup_users`), '') AS `users.name` FROM signoz_logs.distributed_logs_v2 WHERE timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? LIMIT ?",
+				Args:  []any{[]string{"u-1", "u-2"}, []string{"Alice", "Bob"}, []string{"gold", "silver"}, "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448), 10},
+			},
+		},
+		{
+			name:        "time series query grouped by a lookup column",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal:       telemetrytypes.SignalLogs,
+				StepInterval: qbtypes.Step{Duration: 30 * time.Second},
+				Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+				GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "users.name", FieldContext: telemetrytypes.FieldContextLookup}}},
+				Limit:        10,
+				Lookups:      []qbtypes.LookupJoin{users},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH `__lookup_users` AS (SELECT CAST(? AS Array(String)) AS `__column_0`, CAST(? AS Array(String)) AS `__column_1`, CAST(? AS Array(String)) AS `__column_2`), __limit_cte AS (SELECT toString(multiIf(transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_1` FROM `__lookup_users`), '') <> ?, transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_1` FROM `__lookup_users`), ''), NULL)) AS `users.name`, count() AS __result_0 FROM signoz_logs.distributed_logs_v2 WHERE timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? GROUP BY `users.name` ORDER BY __result_0 DESC LIMIT ?) SELECT toStartOfInterval(fromUnixTimestamp64Nano(timestamp), INTERVAL 30 SECOND) AS ts, toString(multiIf(transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_1` FROM `__lookup_users`), '') <> ?, transform(toString(attributes_string['user.id']), (SELECT `__column_0` FROM `__lookup_users`), (SELECT `__column_1` FROM `__lookup_users`), ''), NULL)) AS `users.name`, count() AS __result_0 FROM signoz_logs.distributed_logs_v2 WHERE timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? AND (`users.name`) GLOBAL IN (SELECT `users.name` FROM __limit_cte) GROUP BY ts, `users.name`",
+				Args:  []any{[]string{"u-1", "u-2"}, []string{"Alice", "Bob"}, []string{"gold", "silver"}, "", "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448), 10, "", "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448)},
+			},
+		},
+		{
+			name:        "unresolved lookup",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+				Lookups:      []qbtypes.LookupJoin{{Table: "users", Key: telemetrytypes.TelemetryFieldKey{Name: "user.id"}}},
+			},
+			expectedErr: errors.NewInternalf(errors.CodeInternal, "lookup table 'users' is not resolved"),
+		},
+	}
+
+	ctx := context.Background()
+	fl := flaggertest.New(t)
+
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = buildCompleteFieldKeyMap(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+
+	fm := NewFieldMapper(fl)
+	cb := NewConditionBuilder(fm, fl)
+
+	aggExprRewriter := querybuilder.NewAggExprRewriter(instrumentationtest.New().ToProviderSettings(), nil, fm, cb, nil, fl)
+
+	statementBuilder := NewLogQueryStatementBuilder(
+		instrumentationtest.New().ToProviderSettings(),
+		mockMetadataStore,
+		fm,
+		cb,
+		aggExprRewriter,
+		DefaultFullTextColumn,
+		GetBodyJSONKey,
+		fl,
+		nil,
+		false,
+		100000,
+	)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := statementBuilder.Build(ctx, 1747947419000, 1747983448000, c.requestType, c.query, nil)
+
+			if c.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expected.Query, q.Query)
+				require.Equal(t, c.expected.Args, q.Args)
+			}
+		})
+	}
+}
+
+func TestStatementBuilderRawSubQueryFilter(t *testing.T) {
+	ctx := context.Background()
+	fl := flaggertest.New(t)
+
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = buildCompleteFieldKeyMap(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+
+	fm := NewFieldMapper(fl)
+	cb := NewConditionBuilder(fm, fl)
+
+	aggExprRewriter := querybuilder.NewAggExprRewriter(instrumentationtest.New().ToProviderSettings(), nil, fm, cb, nil, fl)
+
+	statementBuilder := NewLogQueryStatementBuilder(
+		instrumentationtest.New().ToProviderSettings(),
+		mockMetadataStore,
+		fm,
+		cb,
+		aggExprRewriter,
+		DefaultFullTextColumn,
+		GetBodyJSONKey,
+		fl,
+		nil,
+		false,
+		100000,
+	)
+
+	// raw sub queries are built with the columns matched by the filters as select
+	// fields, so that the attribute is projected as a column of the CTE
+	subQuery, err := statementBuilder.Build(ctx, 1747947419000, 1747983448000, qbtypes.RequestTypeRaw, qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+		Signal:       telemetrytypes.SignalLogs,
+		Filter:       &qbtypes.Filter{Expression: "http.method = 'GET'"},
+		SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "http.method"}},
+		Limit:        qbtypes.DefaultSubQueryLimit,
+	}, nil)
+	require.NoError(t, err)
+
+	q, err := statementBuilder.Build(ctx, 1747947419000, 1747983448000, qbtypes.RequestTypeTimeSeries, qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+		Signal:       telemetrytypes.SignalLogs,
+		StepInterval: qbtypes.Step{Duration: 30 * time.Second},
+		Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+		SubQueryFilters: []qbtypes.SubQueryFilter{{
+			Key:       telemetrytypes.TelemetryFieldKey{Name: "http.method"},
+			Query:     "B",
+			Statement: subQuery,
+		}},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "WITH __sub_query_B AS (SELECT timestamp, id, attributes_string['http.method'] AS `http.method` FROM signoz_logs.distributed_logs_v2 WHERE (attributes_string['http.method'] = ? AND mapContains(attributes_string, 'http.method') = ?) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? LIMIT ?) SELECT toStartOfInterval(fromUnixTimestamp64Nano(timestamp), INTERVAL 30 SECOND) AS ts, count() AS __result_0 FROM signoz_logs.distributed_logs_v2 WHERE attributes_string['http.method'] GLOBAL IN (SELECT `http.method` FROM __sub_query_B) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? GROUP BY ts", q.Query)
+	require.Equal(t, []any{"GET", true, "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448), 1000, "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448)}, q.Args)
+}
@@ -0,0 +1,116 @@
+package telemetrymetadata
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// LookupTableLister lists the lookup tables of an org.
+type LookupTableLister interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*lookuptabletypes.LookupTable, error)
+}
+
+type lookupMetaStore struct {
+	telemetrytypes.MetadataStore
+
+	logger *slog.Logger
+	lister LookupTableLister
+}
+
+// NewLookupMetaStore returns a store which adds the columns of the lookup
+// tables of the org in the context to the keys returned by GetKeys. The store
+// is returned as is without a lister.
+func NewLookupMetaStore(settings factory.ProviderSettings, store telemetrytypes.MetadataStore, lister LookupTableLister) telemetrytypes.MetadataStore {
+	if lister == nil {
+		return store
+	}
+
+	metadataSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/telemetrymetadata")
+
+	return &lookupMetaStore{
+		MetadataStore: store,
+		logger:        metadataSettings.Logger(),
+		lister:        lister,
+	}
+}
+
+func (store *lookupMetaStore) GetKeys(ctx context.Context, fieldKeySelector *telemetrytypes.FieldKeySelector) (map[string][]*telemetrytypes.TelemetryFieldKey, bool, error) {
+	keys, complete, err := store.MetadataStore.GetKeys(ctx, fieldKeySelector)
+	if err != nil {
+		return nil, false, err
+	}
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return keys, complete, nil
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		return keys, complete, nil
+	}
+
+	// the keys are still useful without the lookup columns
+	tables, err := store.lister.List(ctx, orgID)
+	if err != nil {
+		store.logger.WarnContext(ctx, "failed to list the lookup tables", slog.String("org_id", orgID.StringValue()), slog.Any("error", err))
+		return keys, complete, nil
+	}
+
+	return enrichWithLookupKeys(keys, fieldKeySelector, tables), complete, nil
+}
+
+// enrichWithLookupKeys adds a lookup context key named `<table>.<column>` for
+// every column of the org lookup tables matching the selector, so that they
+// can be autocompleted in the logs and traces builder queries joining them.
+func enrichWithLookupKeys(keys map[string][]*telemetrytypes.TelemetryFieldKey, selector *telemetrytypes.FieldKeySelector, tables []*lookuptabletypes.LookupTable) map[string][]*telemetrytypes.TelemetryFieldKey {
+	if selector == nil || len(tables) == 0 {
+		return keys
+	}
+
+	switch selector.Signal {
+	case telemetrytypes.SignalLogs:
+		if selector.Source == telemetrytypes.SourceAudit {
+			return keys
+		}
+	case telemetrytypes.SignalTraces, telemetrytypes.SignalUnspecified:
+	default:
+		return keys
+	}
+
+	if selector.FieldContext != telemetrytypes.FieldContextUnspecified && selector.FieldContext != telemetrytypes.FieldContextLookup {
+		return keys
+	}
+
+	if selector.FieldDataType != telemetrytypes.FieldDataTypeUnspecified && selector.FieldDataType != telemetrytypes.FieldDataTypeString {
+		return keys
+	}
+
+	if keys == nil {
+		keys = make(map[string][]*telemetrytypes.TelemetryFieldKey)
+	}
+
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			name := table.Name + "." + column
+			if !matchesSelectorName(selector.Name, name, selector.SelectorMatchType) {
+				continue
+			}
+
+			keys[name] = append(keys[name], &telemetrytypes.TelemetryFieldKey{
+				Name:          name,
+				Signal:        selector.Signal,
+				FieldContext:  telemetrytypes.FieldContextLookup,
+				FieldDataType: telemetrytypes.FieldDataTypeString,
+			})
+		}
+	}
+
+	return keys
+}
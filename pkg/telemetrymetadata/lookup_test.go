@@ -0,0 +1,114 @@
+package telemetrymetadata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichWithLookupKeys(t *testing.T) {
+	tables := []*lookuptabletypes.LookupTable{
+		{Name: "tenants", KeyColumn: "customer_id", Columns: []string{"customer_id", "customer_name", "tier"}},
+		{Name: "regions", KeyColumn: "code", Columns: []string{"code", "name"}},
+	}
+
+	tests := []struct {
+		name     string
+		selector *telemetrytypes.FieldKeySelector
+		expected []string
+	}{
+		{
+			name:     "all columns for logs",
+			selector: &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalLogs},
+			expected: []string{"tenants.customer_id", "tenants.customer_name", "tenants.tier", "regions.code", "regions.name"},
+		},
+		{
+			name:     "fuzzy match on the table and column",
+			selector: &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalTraces, Name: "tenants.customer", SelectorMatchType: telemetrytypes.FieldSelectorMatchTypeFuzzy},
+			expected: []string{"tenants.customer_id", "tenants.customer_name"},
+		},
+		{
+			name:     "lookup context",
+			selector: &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalLogs, FieldContext: telemetrytypes.FieldContextLookup, Name: "name"},
+			expected: []string{"tenants.customer_name", "regions.name"},
+		},
+		{
+			name:     "other context",
+			selector: &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalLogs, FieldContext: telemetrytypes.FieldContextAttribute},
+		},
+		{
+			name:     "number data type",
+			selector: &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalLogs, FieldDataType: telemetrytypes.FieldDataTypeFloat64},
+		},
+		{
+			name:     "metrics",
+			selector: &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalMetrics},
+		},
+		{
+			name:     "audit logs",
+			selector: &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalLogs, Source: telemetrytypes.SourceAudit},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := enrichWithLookupKeys(map[string][]*telemetrytypes.TelemetryFieldKey{}, tt.selector, tables)
+
+			assert.Len(t, keys, len(tt.expected))
+			for _, name := range tt.expected {
+				if assert.Len(t, keys[name], 1, name) {
+					assert.Equal(t, telemetrytypes.FieldContextLookup, keys[name][0].FieldContext)
+					assert.Equal(t, telemetrytypes.FieldDataTypeString, keys[name][0].FieldDataType)
+				}
+			}
+		})
+	}
+}
+
+type lookupTableListerFunc func(ctx context.Context, orgID valuer.UUID) ([]*lookuptabletypes.LookupTable, error)
+
+func (f lookupTableListerFunc) List(ctx context.Context, orgID valuer.UUID) ([]*lookuptabletypes.LookupTable, error) {
+	return f(ctx, orgID)
+}
+
+func TestLookupMetaStoreGetKeys(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	ctx := authtypes.NewContextWithClaims(context.Background(), authtypes.Claims{OrgID: orgID.StringValue()})
+	selector := &telemetrytypes.FieldKeySelector{Signal: telemetrytypes.SignalLogs}
+
+	mockStore := telemetrytypestest.NewMockMetadataStore()
+	mockStore.KeysMap["service.name"] = []*telemetrytypes.TelemetryFieldKey{{Name: "service.name", Signal: telemetrytypes.SignalLogs, FieldContext: telemetrytypes.FieldContextResource, FieldDataType: telemetrytypes.FieldDataTypeString}}
+
+	t.Run("adds the lookup columns of the org", func(t *testing.T) {
+		store := NewLookupMetaStore(instrumentationtest.New().ToProviderSettings(), mockStore, lookupTableListerFunc(func(_ context.Context, listed valuer.UUID) ([]*lookuptabletypes.LookupTable, error) {
+			assert.Equal(t, orgID, listed)
+			return []*lookuptabletypes.LookupTable{{Name: "tenants", KeyColumn: "customer_id", Columns: []string{"customer_id", "tier"}}}, nil
+		}))
+
+		keys, complete, err := store.GetKeys(ctx, selector)
+		require.NoError(t, err)
+		assert.True(t, complete)
+		assert.Len(t, keys, 3)
+		assert.Contains(t, keys, "tenants.tier")
+	})
+
+	t.Run("keeps the keys when the tables cannot be listed", func(t *testing.T) {
+		store := NewLookupMetaStore(instrumentationtest.New().ToProviderSettings(), mockStore, lookupTableListerFunc(func(context.Context, valuer.UUID) ([]*lookuptabletypes.LookupTable, error) {
+			return nil, errors.Newf(errors.TypeInternal, errors.CodeInternal, "sqlstore is down")
+		}))
+
+		keys, _, err := store.GetKeys(ctx, selector)
+		require.NoError(t, err)
+		assert.Len(t, keys, 1)
+		assert.Contains(t, keys, "service.name")
+	})
+}
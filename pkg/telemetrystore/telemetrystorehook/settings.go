@@ -60,6 +60,12 @@ func (h *provider) BeforeQuery(ctx context.Context, _ *telemetrystore.QueryEvent
 		}
 	}
 
+	if ctx.Value("max_query_size") != nil {
+		if maxQuerySize, ok := ctx.Value("max_query_size").(int); ok {
+			settings["max_query_size"] = maxQuerySize
+		}
+	}
+
 	if ctx.Value("max_result_rows") != nil && ctx.Value("result_overflow_mode") != nil {
 		if maxResultRows, ok := ctx.Value("max_result_rows").(int); ok {
 			settings["max_result_rows"] = maxResultRows
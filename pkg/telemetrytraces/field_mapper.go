@@ -7,6 +7,7 @@ import (
 
 	schema "github.com/SigNoz/signoz-otel-collector/cmd/signozschemamigrator/schema_migrator"
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/huandu/go-sqlbuilder"
@@ -177,6 +178,8 @@ func (m *defaultFieldMapper) getColumn(
 		return []*schema.Column{indexV3Columns["resources_string"], indexV3Columns["resource"]}, nil
 	case telemetrytypes.FieldContextScope:
 		return []*schema.Column{}, qbtypes.ErrColumnNotFound
+	case telemetrytypes.FieldContextLookup:
+		return querybuilder.LookupColumnFor(key)
 	case telemetrytypes.FieldContextAttribute:
 		switch key.FieldDataType {
 		case telemetrytypes.FieldDataTypeString:
@@ -250,6 +253,10 @@ func (m *defaultFieldMapper) FieldFor(
 		return key.Name, nil
 	}
 
+	if key.FieldContext == telemetrytypes.FieldContextLookup {
+		return querybuilder.LookupFieldFor(ctx, m, startNs, endNs, key)
+	}
+
 	columns, err := m.getColumn(ctx, startNs, endNs, key)
 	if err != nil {
 		return "", err
@@ -130,6 +130,11 @@ func (b *traceQueryStatementBuilder) Build(
 		return nil, err
 	}
 
+	// lookup table columns are resolved like any other key
+	if err := querybuilder.AddLookupKeys(keys, query.Lookups); err != nil {
+		return nil, err
+	}
+
 	for _, action := range adjustTraceKeys(keys, &query, requestType) {
 		// TODO: change to debug level once we are confident about the behavior
 		b.logger.InfoContext(ctx, "key adjustment action", slog.String("action", action))
@@ -175,6 +180,26 @@ func getKeySelectors(query qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation])
 		})
 	}
 
+	for idx := range query.SubQueryFilters {
+		key := query.SubQueryFilters[idx].Key
+		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
+			Name:          key.Name,
+			Signal:        telemetrytypes.SignalTraces,
+			FieldContext:  key.FieldContext,
+			FieldDataType: key.FieldDataType,
+		})
+	}
+
+	for idx := range query.Lookups {
+		key := query.Lookups[idx].Key
+		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
+			Name:          key.Name,
+			Signal:        telemetrytypes.SignalTraces,
+			FieldContext:  key.FieldContext,
+			FieldDataType: key.FieldDataType,
+		})
+	}
+
 	for idx := range query.SelectFields {
 		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
 			Name:          query.SelectFields[idx].Name,
@@ -321,6 +346,20 @@ func (b *traceQueryStatementBuilder) buildListQuery(
 		cteArgs = append(cteArgs, args)
 	}
 
+	subQueryFragments, subQueryArgs, err := querybuilder.SubQueryCTEs(query.SubQueryFilters)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, subQueryFragments...)
+	cteArgs = append(cteArgs, subQueryArgs...)
+
+	lookupFragments, lookupArgs, err := querybuilder.LookupCTEs(query.Lookups)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, lookupFragments...)
+	cteArgs = append(cteArgs, lookupArgs...)
+
 	// TODO: should we deprecate `SelectFields` and return everything from a span like we do for logs?
 	for _, field := range query.SelectFields {
 		colExpr, err := b.fm.ColumnExpressionFor(ctx, start, end, &field, keys)
@@ -404,6 +443,20 @@ func (b *traceQueryStatementBuilder) buildTraceQuery(
 		cteArgs = append(cteArgs, args)
 	}
 
+	subQueryFragments, subQueryArgs, err := querybuilder.SubQueryCTEs(query.SubQueryFilters)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, subQueryFragments...)
+	cteArgs = append(cteArgs, subQueryArgs...)
+
+	lookupFragments, lookupArgs, err := querybuilder.LookupCTEs(query.Lookups)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, lookupFragments...)
+	cteArgs = append(cteArgs, lookupArgs...)
+
 	// Add filter conditions
 	preparedWhereClause, err := b.addFilterCondition(ctx, distSB, start, end, query, keys, variables, skipResourceFilter)
 	if err != nil {
@@ -515,6 +568,20 @@ func (b *traceQueryStatementBuilder) buildTimeSeriesQuery(
 		cteArgs = append(cteArgs, args)
 	}
 
+	subQueryFragments, subQueryArgs, err := querybuilder.SubQueryCTEs(query.SubQueryFilters)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, subQueryFragments...)
+	cteArgs = append(cteArgs, subQueryArgs...)
+
+	lookupFragments, lookupArgs, err := querybuilder.LookupCTEs(query.Lookups)
+	if err != nil {
+		return nil, err
+	}
+	cteFragments = append(cteFragments, lookupFragments...)
+	cteArgs = append(cteArgs, lookupArgs...)
+
 	sb.SelectMore(fmt.Sprintf(
 		"toStartOfInterval(timestamp, INTERVAL %d SECOND) AS ts",
 		int64(query.StepInterval.Seconds()),
@@ -669,6 +736,23 @@ func (b *traceQueryStatementBuilder) buildScalarQuery(
 		cteArgs = append(cteArgs, args)
 	}
 
+	// the outer query declares the sub query CTEs when this is the limit CTE
+	if !skipResourceCTE {
+		subQueryFragments, subQueryArgs, err := querybuilder.SubQueryCTEs(query.SubQueryFilters)
+		if err != nil {
+			return nil, err
+		}
+		cteFragments = append(cteFragments, subQueryFragments...)
+		cteArgs = append(cteArgs, subQueryArgs...)
+
+		lookupFragments, lookupArgs, err := querybuilder.LookupCTEs(query.Lookups)
+		if err != nil {
+			return nil, err
+		}
+		cteFragments = append(cteFragments, lookupFragments...)
+		cteArgs = append(cteArgs, lookupArgs...)
+	}
+
 	allAggChArgs := []any{}
 
 	var allGroupByArgs []any
@@ -798,6 +882,11 @@ func (b *traceQueryStatementBuilder) addFilterCondition(
 		sb.AddWhereClause(preparedWhereClause.WhereClause)
 	}
 
+	// restrict to the values returned by the sub queries
+	if err := querybuilder.AddSubQueryConditions(ctx, b.fm, sb, start, end, query.SubQueryFilters, keys); err != nil {
+		return preparedWhereClause, err
+	}
+
 	// add time filter
 	startBucket := start/querybuilder.NsToSeconds - querybuilder.BucketAdjustment
 	endBucket := end / querybuilder.NsToSeconds
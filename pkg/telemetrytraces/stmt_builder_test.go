@@ -16,6 +16,7 @@ import (
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1700,3 +1701,83 @@ func newSkipResourceFingerprintBuilder(
 		threshold,
 	)
 }
+
+func TestStatementBuilderLookup(t *testing.T) {
+	releaseTime := time.Date(2025, 5, 22, 22, 0, 0, 0, time.UTC)
+	tenants := qbtypes.LookupJoin{
+		Table:     "tenants",
+		Key:       telemetrytypes.TelemetryFieldKey{Name: "service.name"},
+		KeyColumn: "service",
+		Columns:   []string{"service", "team"},
+		Rows:      [][]string{{"redis-manual", "storage"}, {"frontend", "web"}},
+		VersionID: valuer.GenerateUUID(),
+	}
+
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]
+		expected    qbtypes.Statement
+		expectedErr error
+	}{
+		{
+			name:        "scalar query grouped by a lookup column",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+				Signal:       telemetrytypes.SignalTraces,
+				Aggregations: []qbtypes.TraceAggregation{{Expression: "count()"}},
+				GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "tenants.team", FieldContext: telemetrytypes.FieldContextLookup}}},
+				Lookups:      []qbtypes.LookupJoin{tenants},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH `__lookup_tenants` AS (SELECT CAST(? AS Array(String)) AS `__column_0`, CAST(? AS Array(String)) AS `__column_1`) SELECT toString(multiIf(transform(toString(multiIf(resource.`service.name` IS NOT NULL, resource.`service.name`::String, mapContains(resources_string, 'service.name'), resources_string['service.name'], NULL)), (SELECT `__column_0` FROM `__lookup_tenants`), (SELECT `__column_1` FROM `__lookup_tenants`), '') <> ?, transform(toString(multiIf(resource.`service.name` IS NOT NULL, resource.`service.name`::String, mapContains(resources_string, 'service.name'), resources_string['service.name'], NULL)), (SELECT `__column_0` FROM `__lookup_tenants`), (SELECT `__column_1` FROM `__lookup_tenants`), ''), NULL)) AS `tenants.team`, count() AS __result_0 FROM signoz_traces.distributed_signoz_index_v3 WHERE timestamp >= ? AND timestamp < ? AND ts_bucket_start >= ? AND ts_bucket_start <= ? GROUP BY `tenants.team` ORDER BY __result_0 DESC",
+				Args:  []any{[]string{"redis-manual", "frontend"}, []string{"storage", "web"}, "", "1747947419000000000", "1747983448000000000", uint64(1747945619), uint64(1747983448)},
+			},
+		},
+		{
+			name:        "lookup column of a table which is not joined",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+				Signal:       telemetrytypes.SignalTraces,
+				Aggregations: []qbtypes.TraceAggregation{{Expression: "count()"}},
+				GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "regions.name", FieldContext: telemetrytypes.FieldContextLookup}}},
+				Lookups:      []qbtypes.LookupJoin{tenants},
+			},
+			expectedErr: errors.NewInvalidInputf(errors.CodeInvalidInput, "field not found"),
+		},
+	}
+
+	fm := NewFieldMapper()
+	cb := NewConditionBuilder(fm)
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = buildCompleteFieldKeyMap(releaseTime)
+	fl := flaggertest.New(t)
+	aggExprRewriter := querybuilder.NewAggExprRewriter(instrumentationtest.New().ToProviderSettings(), nil, fm, cb, nil, fl)
+
+	statementBuilder := NewTraceQueryStatementBuilder(
+		instrumentationtest.New().ToProviderSettings(),
+		mockMetadataStore,
+		fm,
+		cb,
+		aggExprRewriter,
+		nil,
+		fl,
+		false,
+		100000,
+	)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := statementBuilder.Build(context.Background(), 1747947419000, 1747983448000, c.requestType, c.query, nil)
+
+			if c.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expected.Query, q.Query)
+				require.Equal(t, c.expected.Args, q.Args)
+			}
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 
 // TestConcurrentRender exercises every render entry point from many
 // goroutines. Run with `go test -race` to catch shared-state regressions
-// in the HTML, Block Kit or mrkdwn paths.
+// in the HTML, Block Kit, mrkdwn, Telegram or Discord paths.
 func TestConcurrentRender(t *testing.T) {
 	const goroutines = 32
 	const iterations = 20
@@ -25,6 +25,8 @@ func TestConcurrentRender(t *testing.T) {
 		"html":     RenderHTML,
 		"blockkit": RenderSlackBlockKit,
 		"mrkdwn":   RenderSlackMrkdwn,
+		"telegram": RenderTelegramHTML,
+		"discord":  RenderDiscordMarkdown,
 	}
 
 	var wg sync.WaitGroup
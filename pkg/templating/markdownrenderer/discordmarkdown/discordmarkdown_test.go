@@ -0,0 +1,75 @@
+package discordmarkdown
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func TestRenderer(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{
+			name:     "Headings are capped at level 3",
+			markdown: "# Title 1\n#### Title 4\n---\nthis is sometext",
+			expected: "# Title 1\n\n### Title 4\n\n———\n\nthis is sometext",
+		},
+		{
+			name:     "Nested Blockquote",
+			markdown: "> This is a quote\n>> It continues",
+			expected: "> This is a quote\n> \n> It continues",
+		},
+		{
+			name:     "Fenced Code Block",
+			markdown: "```go\npackage main\n```",
+			expected: "```go\npackage main\n```",
+		},
+		{
+			name:     "nested unordered list",
+			markdown: "- item 1\n- item 2\n\t- item 2.1\n- item 3",
+			expected: "- item 1\n- item 2\n  - item 2.1\n- item 3",
+		},
+		{
+			name:     "Ordered List",
+			markdown: "1. item 1\n2. item 2",
+			expected: "1. item 1\n2. item 2",
+		},
+		{
+			name:     "Links and AutoLinks",
+			markdown: "This is a [**link**](https://example.com) and an autolink <https://test.com>",
+			expected: "This is a [**link**](https://example.com) and an autolink https://test.com",
+		},
+		{
+			name:     "Images",
+			markdown: "An image ![alt text](https://example.com/image.png)",
+			expected: "An image [alt text](https://example.com/image.png)",
+		},
+		{
+			name:     "Inline styles",
+			markdown: "**bold** *italic* ~~strike~~ `code`",
+			expected: "**bold** *italic* ~~strike~~ `code`",
+		},
+		{
+			name:     "Table",
+			markdown: "| Service | Status |\n| --- | --- |\n| api | down |",
+			expected: "```\nService | Status\n--------|-------\napi     | down\n```",
+		},
+	}
+
+	md := goldmark.New(goldmark.WithExtensions(Extender))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := md.Convert([]byte(tt.markdown), &buf); err != nil {
+				t.Fatalf("failed to convert: %v", err)
+			}
+			if got := buf.String(); got != tt.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.expected, got)
+			}
+		})
+	}
+}
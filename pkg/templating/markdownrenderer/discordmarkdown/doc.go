@@ -0,0 +1,4 @@
+// Package discordmarkdown provides a goldmark extension that renders markdown
+// as the markdown flavour understood by Discord, which has no tables, images
+// or headings below level 3.
+package discordmarkdown
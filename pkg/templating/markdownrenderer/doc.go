@@ -1,3 +1,4 @@
 // Package markdownrenderer renders markdown to the formats that alert
-// notifications are delivered in: HTML, Slack Block Kit JSON, and Slack mrkdwn.
+// notifications are delivered in: HTML, Slack Block Kit JSON, Slack mrkdwn,
+// Telegram HTML and Discord markdown.
 package markdownrenderer
@@ -6,7 +6,9 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/templating/markdownrenderer/blockkit"
+	"github.com/SigNoz/signoz/pkg/templating/markdownrenderer/discordmarkdown"
 	"github.com/SigNoz/signoz/pkg/templating/markdownrenderer/mrkdwn"
+	"github.com/SigNoz/signoz/pkg/templating/markdownrenderer/telegramhtml"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 )
@@ -16,11 +18,11 @@ import (
 // is safe to Convert concurrently.
 var htmlRenderer = goldmark.New(goldmark.WithExtensions(extension.GFM, escapeNoValue))
 
-// The Slack renderers hold per-document state on the node renderer (list
-// context, table context, style stack, blockquote/list prefixes). Two
-// goroutines calling Convert on the same goldmark.Markdown would corrupt
-// that state. A sync.Pool gives each concurrent caller its own instance
-// while still amortising the cost of building the pipeline.
+// The Slack, Telegram and Discord renderers hold per-document state on the
+// node renderer (list context, table context, style stack, blockquote/list
+// prefixes). Two goroutines calling Convert on the same goldmark.Markdown
+// would corrupt that state. A sync.Pool gives each concurrent caller its own
+// instance while still amortising the cost of building the pipeline.
 var (
 	blockkitPool = sync.Pool{
 		New: func() any {
@@ -32,6 +34,16 @@ var (
 			return goldmark.New(goldmark.WithExtensions(mrkdwn.Extender))
 		},
 	}
+	telegramHTMLPool = sync.Pool{
+		New: func() any {
+			return goldmark.New(goldmark.WithExtensions(telegramhtml.Extender))
+		},
+	}
+	discordMarkdownPool = sync.Pool{
+		New: func() any {
+			return goldmark.New(goldmark.WithExtensions(discordmarkdown.Extender))
+		},
+	}
 )
 
 // RenderHTML converts markdown to HTML.
@@ -53,6 +65,20 @@ func RenderSlackMrkdwn(markdown string) (string, error) {
 	return render(md, markdown, "Slack mrkdwn")
 }
 
+// RenderTelegramHTML converts markdown to the HTML subset supported by Telegram.
+func RenderTelegramHTML(markdown string) (string, error) {
+	md := telegramHTMLPool.Get().(goldmark.Markdown)
+	defer telegramHTMLPool.Put(md)
+	return render(md, markdown, "Telegram HTML")
+}
+
+// RenderDiscordMarkdown converts markdown to Discord's markdown flavour.
+func RenderDiscordMarkdown(markdown string) (string, error) {
+	md := discordMarkdownPool.Get().(goldmark.Markdown)
+	defer discordMarkdownPool.Put(md)
+	return render(md, markdown, "Discord markdown")
+}
+
 func render(md goldmark.Markdown, markdown string, format string) (string, error) {
 	var buf bytes.Buffer
 	if err := md.Convert([]byte(markdown), &buf); err != nil {
@@ -0,0 +1,3 @@
+// Package telegramhtml provides a goldmark extension that renders markdown as
+// the HTML subset accepted by the Telegram Bot API with parse_mode HTML.
+package telegramhtml
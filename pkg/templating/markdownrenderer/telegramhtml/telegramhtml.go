@@ -0,0 +1,414 @@
+package telegramhtml
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extensionast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// Extender is a goldmark.Extender that registers the Telegram HTML node
+// renderer together with the GFM extensions it relies on (tables,
+// strikethrough).
+var Extender goldmark.Extender = &extender{}
+
+type extender struct{}
+
+func (e *extender) Extend(m goldmark.Markdown) {
+	extension.Table.Extend(m)
+	extension.Strikethrough.Extend(m)
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(util.Prioritized(newRenderer(), 1)),
+	)
+}
+
+// nodeRenderer renders nodes as Telegram HTML. Telegram only supports a few
+// inline tags (b, i, s, u, a, code, pre, blockquote), so block elements are
+// rendered as plain text separated by newlines.
+// https://core.telegram.org/bots/api#html-style
+type nodeRenderer struct {
+	prefixes []string
+	// quoteDepth tracks nested blockquotes, Telegram rejects nested blockquote tags.
+	quoteDepth int
+}
+
+func newRenderer() renderer.NodeRenderer {
+	return &nodeRenderer{}
+}
+
+// RegisterFuncs implements NodeRenderer.RegisterFuncs.
+func (r *nodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	// Blocks
+	reg.Register(ast.KindDocument, r.renderDocument)
+	reg.Register(ast.KindHeading, r.renderHeading)
+	reg.Register(ast.KindBlockquote, r.renderBlockquote)
+	reg.Register(ast.KindCodeBlock, r.renderCodeBlock)
+	reg.Register(ast.KindFencedCodeBlock, r.renderCodeBlock)
+	reg.Register(ast.KindHTMLBlock, r.renderHTMLBlock)
+	reg.Register(ast.KindList, r.renderList)
+	reg.Register(ast.KindListItem, r.renderListItem)
+	reg.Register(ast.KindParagraph, r.renderParagraph)
+	reg.Register(ast.KindTextBlock, r.renderTextBlock)
+	reg.Register(ast.KindThematicBreak, r.renderThematicBreak)
+
+	// Inlines
+	reg.Register(ast.KindAutoLink, r.renderAutoLink)
+	reg.Register(ast.KindCodeSpan, r.renderCodeSpan)
+	reg.Register(ast.KindEmphasis, r.renderEmphasis)
+	reg.Register(ast.KindImage, r.renderImage)
+	reg.Register(ast.KindLink, r.renderLink)
+	reg.Register(ast.KindRawHTML, r.renderRawHTML)
+	reg.Register(ast.KindText, r.renderText)
+	reg.Register(ast.KindString, r.renderString)
+
+	// Extensions
+	reg.Register(extensionast.KindStrikethrough, r.renderStrikethrough)
+	reg.Register(extensionast.KindTable, r.renderTable)
+}
+
+func (r *nodeRenderer) writePrefix(w util.BufWriter) {
+	for _, p := range r.prefixes {
+		_, _ = w.WriteString(p)
+	}
+}
+
+// writeLineSeparator writes a newline followed by the current prefix.
+func (r *nodeRenderer) writeLineSeparator(w util.BufWriter) {
+	_ = w.WriteByte('\n')
+	r.writePrefix(w)
+}
+
+// separateFromPrevious writes a blank line if the node has a previous sibling.
+func (r *nodeRenderer) separateFromPrevious(w util.BufWriter, n ast.Node) {
+	if n.PreviousSibling() != nil {
+		r.writeLineSeparator(w)
+		r.writeLineSeparator(w)
+	}
+}
+
+func (r *nodeRenderer) renderDocument(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		// The renderer is pooled, so wipe any state left over from a prior
+		// document before starting a fresh convert.
+		r.prefixes = r.prefixes[:0]
+		r.quoteDepth = 0
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderHeading(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		r.separateFromPrevious(w, node)
+		_, _ = w.WriteString("<b>")
+	} else {
+		_, _ = w.WriteString("</b>")
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderBlockquote(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		r.separateFromPrevious(w, n)
+		if r.quoteDepth == 0 {
+			_, _ = w.WriteString("<blockquote>")
+		}
+		r.quoteDepth++
+	} else {
+		r.quoteDepth--
+		if r.quoteDepth == 0 {
+			_, _ = w.WriteString("</blockquote>")
+		}
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderCodeBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	r.separateFromPrevious(w, n)
+	_, _ = w.WriteString("<pre>")
+	if fenced, ok := n.(*ast.FencedCodeBlock); ok && fenced.Language(source) != nil {
+		_, _ = fmt.Fprintf(w, `<code class="language-%s">`, html.EscapeString(string(fenced.Language(source))))
+	} else {
+		_, _ = w.WriteString("<code>")
+	}
+
+	var code bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		code.Write(line.Value(source))
+	}
+	_, _ = w.WriteString(html.EscapeString(strings.TrimSuffix(code.String(), "\n")))
+	_, _ = w.WriteString("</code></pre>")
+	return ast.WalkSkipChildren, nil
+}
+
+// renderHTMLBlock writes HTML blocks as escaped text, Telegram rejects unsupported tags.
+func (r *nodeRenderer) renderHTMLBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	r.separateFromPrevious(w, n)
+	var block bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		block.Write(line.Value(source))
+	}
+	_, _ = w.WriteString(html.EscapeString(strings.TrimSuffix(block.String(), "\n")))
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *nodeRenderer) renderList(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering && node.PreviousSibling() != nil {
+		r.writeLineSeparator(w)
+		// another line break if not a nested list and starting another block
+		if node.Parent() == nil || node.Parent().Kind() != ast.KindListItem {
+			r.writeLineSeparator(w)
+		}
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderListItem(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		if n.PreviousSibling() != nil {
+			r.writeLineSeparator(w)
+		}
+		parent := n.Parent().(*ast.List)
+		if parent.IsOrdered() {
+			index := parent.Start
+			for c := parent.FirstChild(); c != nil && c != n; c = c.NextSibling() {
+				index++
+			}
+			_, _ = fmt.Fprintf(w, "%d. ", index)
+		} else {
+			_, _ = w.WriteString("• ")
+		}
+		r.prefixes = append(r.prefixes, "    ") // indent nested list items
+	} else {
+		r.prefixes = r.prefixes[:len(r.prefixes)-1]
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderParagraph(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		r.separateFromPrevious(w, n)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderTextBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering && n.PreviousSibling() != nil {
+		r.writeLineSeparator(w)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderThematicBreak(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		r.separateFromPrevious(w, n)
+		_, _ = w.WriteString("———")
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderAutoLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.AutoLink)
+	url := string(n.URL(source))
+	if n.AutoLinkType == ast.AutoLinkEmail && !strings.HasPrefix(strings.ToLower(url), "mailto:") {
+		url = "mailto:" + url
+	}
+
+	_, _ = fmt.Fprintf(w, `<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(string(n.Label(source))))
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderCodeSpan(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	_, _ = w.WriteString("<code>")
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		value := c.(*ast.Text).Segment.Value(source)
+		// replace newline with space
+		_, _ = w.WriteString(html.EscapeString(strings.ReplaceAll(string(value), "\n", " ")))
+	}
+	_, _ = w.WriteString("</code>")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *nodeRenderer) renderEmphasis(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	tag := "i"
+	if node.(*ast.Emphasis).Level == 2 {
+		tag = "b"
+	}
+	if entering {
+		_, _ = fmt.Fprintf(w, "<%s>", tag)
+	} else {
+		_, _ = fmt.Fprintf(w, "</%s>", tag)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Link)
+	if entering {
+		_, _ = fmt.Fprintf(w, `<a href="%s">`, html.EscapeString(string(util.URLEscape(n.Destination, true))))
+	} else {
+		_, _ = w.WriteString("</a>")
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderImage writes images as links, Telegram messages cannot embed images.
+func (r *nodeRenderer) renderImage(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.Image)
+	_, _ = fmt.Fprintf(w, `<a href="%s">%s</a>`, html.EscapeString(string(util.URLEscape(n.Destination, true))), html.EscapeString(extractPlainText(n, source)))
+	return ast.WalkSkipChildren, nil
+}
+
+// renderRawHTML writes inline HTML as escaped text. This also keeps the
+// "<no value>" written by text/template for missing keys visible.
+func (r *nodeRenderer) renderRawHTML(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.RawHTML)
+	for i := 0; i < n.Segments.Len(); i++ {
+		segment := n.Segments.At(i)
+		_, _ = w.WriteString(html.EscapeString(string(segment.Value(source))))
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *nodeRenderer) renderText(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.Text)
+	_, _ = w.WriteString(html.EscapeString(string(n.Segment.Value(source))))
+	if n.HardLineBreak() || n.SoftLineBreak() {
+		r.writeLineSeparator(w)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderString(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(html.EscapeString(string(node.(*ast.String).Value)))
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderStrikethrough(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<s>")
+	} else {
+		_, _ = w.WriteString("</s>")
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderTable writes tables as aligned preformatted text.
+func (r *nodeRenderer) renderTable(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	r.separateFromPrevious(w, node)
+
+	// Collect cells and max widths
+	var rows [][]string
+	var colWidths []int
+	for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+		if c.Kind() != extensionast.KindTableHeader && c.Kind() != extensionast.KindTableRow {
+			continue
+		}
+		var row []string
+		colIdx := 0
+		for cc := c.FirstChild(); cc != nil; cc = cc.NextSibling() {
+			if cc.Kind() != extensionast.KindTableCell {
+				continue
+			}
+			cellText := extractPlainText(cc, source)
+			row = append(row, cellText)
+			runeLen := utf8.RuneCountInString(cellText)
+			if colIdx >= len(colWidths) {
+				colWidths = append(colWidths, runeLen)
+			} else if runeLen > colWidths[colIdx] {
+				colWidths[colIdx] = runeLen
+			}
+			colIdx++
+		}
+		rows = append(rows, row)
+	}
+
+	var table strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			table.WriteByte('\n')
+		}
+		for colIdx, cellText := range row {
+			table.WriteString(cellText)
+			if colIdx < len(row)-1 {
+				table.WriteString(strings.Repeat(" ", max(0, colWidths[colIdx]-utf8.RuneCountInString(cellText))))
+				table.WriteString(" | ")
+			}
+		}
+
+		// separator after header
+		if i == 0 {
+			table.WriteByte('\n')
+			for colIdx := range row {
+				table.WriteString(strings.Repeat("-", colWidths[colIdx]))
+				if colIdx < len(row)-1 {
+					table.WriteString("-|-")
+				}
+			}
+		}
+	}
+
+	_, _ = w.WriteString("<pre>")
+	_, _ = w.WriteString(html.EscapeString(table.String()))
+	_, _ = w.WriteString("</pre>")
+	return ast.WalkSkipChildren, nil
+}
+
+// extractPlainText extracts all the text content, including inline HTML, from
+// the given node. The caller escapes it.
+func extractPlainText(n ast.Node, source []byte) string {
+	var buf bytes.Buffer
+	_ = ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if textNode, ok := node.(*ast.Text); ok {
+			buf.Write(textNode.Segment.Value(source))
+		} else if strNode, ok := node.(*ast.String); ok {
+			buf.Write(strNode.Value)
+		} else if rawNode, ok := node.(*ast.RawHTML); ok {
+			buf.Write(rawNode.Segments.Value(source))
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,80 @@
+package telegramhtml
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func TestRenderer(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{
+			name:     "Heading with Thematic Break",
+			markdown: "# Title 1\n---\nthis is sometext",
+			expected: "<b>Title 1</b>\n\n———\n\nthis is sometext",
+		},
+		{
+			name:     "Nested Blockquote",
+			markdown: "> This is a quote\n>> It continues",
+			expected: "<blockquote>This is a quote\n\nIt continues</blockquote>",
+		},
+		{
+			name:     "Fenced Code Block",
+			markdown: "```go\nif a < b {}\n```",
+			expected: "<pre><code class=\"language-go\">if a &lt; b {}</code></pre>",
+		},
+		{
+			name:     "nested unordered list",
+			markdown: "- item 1\n- item 2\n\t- item 2.1\n- item 3",
+			expected: "• item 1\n• item 2\n    • item 2.1\n• item 3",
+		},
+		{
+			name:     "Ordered List",
+			markdown: "3. item 3\n4. item 4",
+			expected: "3. item 3\n4. item 4",
+		},
+		{
+			name:     "Links and AutoLinks",
+			markdown: "This is a [link](https://example.com?a=1&b=2) and an autolink <https://test.com>",
+			expected: "This is a <a href=\"https://example.com?a=1&amp;b=2\">link</a> and an autolink <a href=\"https://test.com\">https://test.com</a>",
+		},
+		{
+			name:     "Images",
+			markdown: "An image ![alt text](https://example.com/image.png)",
+			expected: "An image <a href=\"https://example.com/image.png\">alt text</a>",
+		},
+		{
+			name:     "Inline styles",
+			markdown: "**bold** *italic* ~~strike~~ `a < b`",
+			expected: "<b>bold</b> <i>italic</i> <s>strike</s> <code>a &lt; b</code>",
+		},
+		{
+			name:     "Raw HTML is escaped",
+			markdown: "value is <no value> and <span>html</span> & more",
+			expected: "value is &lt;no value&gt; and &lt;span&gt;html&lt;/span&gt; &amp; more",
+		},
+		{
+			name:     "Table",
+			markdown: "| Service | Status |\n| --- | --- |\n| api | <down> |",
+			expected: "<pre>Service | Status\n--------|-------\napi     | &lt;down&gt;</pre>",
+		},
+	}
+
+	md := goldmark.New(goldmark.WithExtensions(Extender))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := md.Convert([]byte(tt.markdown), &buf); err != nil {
+				t.Fatalf("failed to convert: %v", err)
+			}
+			if got := buf.String(); got != tt.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.expected, got)
+			}
+		})
+	}
+}
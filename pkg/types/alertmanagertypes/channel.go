@@ -61,6 +61,10 @@ func NewChannelFromReceiver(receiver *Receiver, orgID string) (*Channel, error)
 		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "cannot use %s name as a channel name", receiver.Name)
 	}
 
+	if err := validateNativeConfigs(receiver); err != nil {
+		return nil, err
+	}
+
 	// Initialize channel with common fields
 	channel := Channel{
 		Identifiable: types.Identifiable{
@@ -88,6 +92,33 @@ func NewChannelFromReceiver(receiver *Receiver, orgID string) (*Channel, error)
 	return &channel, nil
 }
 
+// validateNativeConfigs validates the native notifier configs of the
+// receiver. Upstream configs are validated when the config is loaded.
+func validateNativeConfigs(receiver *Receiver) error {
+	for _, c := range receiver.GoogleChatConfigs {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, c := range receiver.DiscordConfigs {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, c := range receiver.TelegramConfigs {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, c := range receiver.MattermostConfigs {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // receiverChannelType returns the channel.Type discriminator. Walks
 // Receiver's own fields first (native), then the embed (upstream); first
 // non-empty *_configs slice wins.
@@ -216,13 +247,16 @@ func (PostableChannel) JSONSchema() (jsonschema.Schema, error) {
 	schema.WithRequired("name")
 
 	var oneOf []jsonschema.SchemaOrBool
+	seen := make(map[string]bool)
 	// Walk both halves: native fields on Receiver, upstream on the embed.
+	// Native fields come first and shadow the upstream ones of the same name.
 	collect := func(t reflect.Type) {
 		for i := 0; i < t.NumField(); i++ {
 			jsonTag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
-			if !strings.HasSuffix(jsonTag, "_configs") {
+			if !strings.HasSuffix(jsonTag, "_configs") || seen[jsonTag] {
 				continue
 			}
+			seen[jsonTag] = true
 			branch := (&jsonschema.Schema{}).WithRequired(jsonTag)
 			oneOf = append(oneOf, branch.ToSchemaOrBool())
 		}
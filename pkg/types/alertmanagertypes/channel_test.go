@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewConfigFromChannels(t *testing.T) {
@@ -328,3 +329,90 @@ func TestNewChannelFromReceiverGoogleChat(t *testing.T) {
 		channel.Data,
 	)
 }
+
+// Discord, Telegram and Mattermost configs shadow their upstream
+// counterparts, so Type comes from the native fields.
+func TestNewChannelFromReceiverNative(t *testing.T) {
+	webhookURL, err := url.Parse("https://example.com/hooks/test")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		receiver     *Receiver
+		expectedType string
+		pass         bool
+	}{
+		{
+			name: "Discord",
+			receiver: &Receiver{
+				Receiver:       &config.Receiver{Name: "discord-receiver"},
+				DiscordConfigs: []*DiscordReceiverConfig{{WebhookURL: &config.SecretURL{URL: webhookURL}, ThreadID: "1234"}},
+			},
+			expectedType: "discord",
+			pass:         true,
+		},
+		{
+			name: "DiscordInvalidThreadID",
+			receiver: &Receiver{
+				Receiver:       &config.Receiver{Name: "discord-receiver"},
+				DiscordConfigs: []*DiscordReceiverConfig{{WebhookURL: &config.SecretURL{URL: webhookURL}, ThreadID: "general"}},
+			},
+			pass: false,
+		},
+		{
+			name: "Telegram",
+			receiver: &Receiver{
+				Receiver:        &config.Receiver{Name: "telegram-receiver"},
+				TelegramConfigs: []*TelegramReceiverConfig{{BotToken: "token", ChatID: 42, ParseMode: TelegramParseModeHTML}},
+			},
+			expectedType: "telegram",
+			pass:         true,
+		},
+		{
+			name: "TelegramMissingChatID",
+			receiver: &Receiver{
+				Receiver:        &config.Receiver{Name: "telegram-receiver"},
+				TelegramConfigs: []*TelegramReceiverConfig{{BotToken: "token"}},
+			},
+			pass: false,
+		},
+		{
+			name: "TelegramInvalidParseMode",
+			receiver: &Receiver{
+				Receiver:        &config.Receiver{Name: "telegram-receiver"},
+				TelegramConfigs: []*TelegramReceiverConfig{{BotToken: "token", ChatID: 42, ParseMode: "BBCode"}},
+			},
+			pass: false,
+		},
+		{
+			name: "Mattermost",
+			receiver: &Receiver{
+				Receiver:          &config.Receiver{Name: "mattermost-receiver"},
+				MattermostConfigs: []*MattermostReceiverConfig{{WebhookURL: &config.SecretURL{URL: webhookURL}}},
+			},
+			expectedType: "mattermost",
+			pass:         true,
+		},
+		{
+			name: "MattermostMissingWebhookURL",
+			receiver: &Receiver{
+				Receiver:          &config.Receiver{Name: "mattermost-receiver"},
+				MattermostConfigs: []*MattermostReceiverConfig{{}},
+			},
+			pass: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			channel, err := NewChannelFromReceiver(tc.receiver, "1")
+			if !tc.pass {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedType, channel.Type)
+		})
+	}
+}
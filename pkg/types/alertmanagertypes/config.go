@@ -67,18 +67,36 @@ type Config struct {
 
 // customReceiverConfigs is the per-receiver custom notifier
 // configs. To add another, mirror GoogleChat: a field here, a matching field
-// on Receiver, and extensions to customConfigsOf + isEmpty.
+// on Receiver, and extensions to customConfigsOf, isEmpty and
+// receiverWithCustomConfigs.
 type customReceiverConfigs struct {
 	GoogleChat []*GoogleChatReceiverConfig
+	Discord    []*DiscordReceiverConfig
+	Telegram   []*TelegramReceiverConfig
+	Mattermost []*MattermostReceiverConfig
 }
 
 func (c customReceiverConfigs) isEmpty() bool {
-	return len(c.GoogleChat) == 0
+	return len(c.GoogleChat) == 0 && len(c.Discord) == 0 && len(c.Telegram) == 0 && len(c.Mattermost) == 0
 }
 
 func customConfigsOf(receiver *Receiver) customReceiverConfigs {
 	return customReceiverConfigs{
 		GoogleChat: receiver.GoogleChatConfigs,
+		Discord:    receiver.DiscordConfigs,
+		Telegram:   receiver.TelegramConfigs,
+		Mattermost: receiver.MattermostConfigs,
+	}
+}
+
+// receiverWithCustomConfigs joins an upstream receiver with its custom configs.
+func receiverWithCustomConfigs(base *config.Receiver, custom customReceiverConfigs) *Receiver {
+	return &Receiver{
+		Receiver:          base,
+		GoogleChatConfigs: custom.GoogleChat,
+		DiscordConfigs:    custom.Discord,
+		TelegramConfigs:   custom.Telegram,
+		MattermostConfigs: custom.Mattermost,
 	}
 }
 
@@ -178,11 +196,7 @@ func newRawFromConfig(c *config.Config, customConfigs map[string]customReceiverC
 	receivers := make([]*Receiver, len(c.Receivers))
 	for i := range c.Receivers {
 		base := c.Receivers[i]
-		custom := customConfigs[base.Name]
-		receivers[i] = &Receiver{
-			Receiver:          &base,
-			GoogleChatConfigs: custom.GoogleChat,
-		}
+		receivers[i] = receiverWithCustomConfigs(&base, customConfigs[base.Name])
 	}
 
 	b, err := json.Marshal(storedConfig{Config: c, Receivers: receivers})
@@ -301,11 +315,7 @@ func (c *Config) GetReceiver(name string) (*Receiver, error) {
 	for i := range c.alertmanagerConfig.Receivers {
 		if c.alertmanagerConfig.Receivers[i].Name == name {
 			base := c.alertmanagerConfig.Receivers[i]
-			custom := c.customConfigs[name]
-			return &Receiver{
-				Receiver:          &base,
-				GoogleChatConfigs: custom.GoogleChat,
-			}, nil
+			return receiverWithCustomConfigs(&base, c.customConfigs[name]), nil
 		}
 	}
 
@@ -379,6 +389,24 @@ func (c *Config) applyNativeDefaults() {
 				gc.HTTPConfig = httpDefault
 			}
 		}
+		for _, dc := range custom.Discord {
+			if dc.HTTPConfig == nil {
+				dc.HTTPConfig = httpDefault
+			}
+		}
+		for _, tc := range custom.Telegram {
+			if tc.HTTPConfig == nil {
+				tc.HTTPConfig = httpDefault
+			}
+			if tc.APIURL == nil {
+				tc.APIURL = c.alertmanagerConfig.Global.TelegramAPIUrl
+			}
+		}
+		for _, mc := range custom.Mattermost {
+			if mc.HTTPConfig == nil {
+				mc.HTTPConfig = httpDefault
+			}
+		}
 	}
 }
 
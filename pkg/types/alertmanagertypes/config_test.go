@@ -384,3 +384,35 @@ func TestConfigPreservesGoogleChatConfigs(t *testing.T) {
 	require.Len(t, updated.GoogleChatConfigs, 1)
 	assert.Equal(t, "Updated", updated.GoogleChatConfigs[0].Title)
 }
+
+func TestConfigPreservesTelegramConfigs(t *testing.T) {
+	cfg, err := NewDefaultConfig(
+		GlobalConfig{SMTPSmarthost: config.HostPort{Host: "localhost", Port: "25"}, SMTPFrom: "test@example.com"},
+		RouteConfig{GroupInterval: time.Minute, GroupWait: time.Minute, RepeatInterval: time.Minute},
+		"1",
+	)
+	require.NoError(t, err)
+
+	receiver, err := NewReceiver(`{"name":"telegram-receiver","telegram_configs":[{"token":"bot-token","chat":42,"edit_messages":true}]}`)
+	require.NoError(t, err)
+	require.NoError(t, cfg.CreateReceiver(receiver))
+
+	// The upstream telegram_configs stay empty, the native config handles the channel.
+	assert.Empty(t, cfg.alertmanagerConfig.Receivers[len(cfg.alertmanagerConfig.Receivers)-1].TelegramConfigs)
+
+	reloaded, err := NewConfigFromStoreableConfig(cfg.StoreableConfig())
+	require.NoError(t, err)
+
+	got, err := reloaded.GetReceiver("telegram-receiver")
+	require.NoError(t, err)
+	require.Len(t, got.TelegramConfigs, 1)
+	assert.Equal(t, config.Secret("bot-token"), got.TelegramConfigs[0].BotToken)
+	assert.Equal(t, int64(42), got.TelegramConfigs[0].ChatID)
+	assert.True(t, got.TelegramConfigs[0].EditMessages)
+	assert.Equal(t, TelegramParseModeHTML, got.TelegramConfigs[0].ParseMode)
+
+	// APIURL and HTTPConfig threaded from Global by applyNativeDefaults.
+	require.NotNil(t, got.TelegramConfigs[0].APIURL)
+	assert.Equal(t, "https://api.telegram.org", got.TelegramConfigs[0].APIURL.String())
+	require.NotNil(t, got.TelegramConfigs[0].HTTPConfig)
+}
@@ -0,0 +1,61 @@
+package alertmanagertypes
+
+import (
+	"strconv"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/config"
+	commoncfg "github.com/prometheus/common/config"
+)
+
+// DiscordReceiverConfig replaces upstream's discord_configs. It keeps the
+// upstream fields and adds threading and message editing.
+type DiscordReceiverConfig struct {
+	config.NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	WebhookURL *config.SecretURL `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+	Content    string            `yaml:"content,omitempty" json:"content,omitempty"`
+	Title      string            `yaml:"title,omitempty" json:"title,omitempty"`
+	Message    string            `yaml:"message,omitempty" json:"message,omitempty"`
+	Username   string            `yaml:"username,omitempty" json:"username,omitempty"`
+	AvatarURL  string            `yaml:"avatar_url,omitempty" json:"avatar_url,omitempty"`
+
+	// ThreadID posts the notifications into an existing thread or forum post of the webhook's channel.
+	ThreadID string `yaml:"thread_id,omitempty" json:"thread_id,omitempty"`
+	// EditMessages updates the message already posted for an alert group instead of posting a new one.
+	EditMessages bool `yaml:"edit_messages,omitempty" json:"edit_messages,omitempty"`
+}
+
+var DefaultDiscordReceiverConfig = DiscordReceiverConfig{
+	NotifierConfig: config.NotifierConfig{
+		VSendResolved: false,
+	},
+	Title: `[{{ .Status | toUpper }}{{ if eq .Status "firing" }}:{{ .Alerts.Firing | len }}{{ end }}] {{ .CommonLabels.alertname }}`,
+	Message: `{{ range .Alerts -}}
+**Alert:** {{ .Labels.alertname }}{{ if .Labels.severity }} ({{ .Labels.severity }}){{ end }}{{ if .Annotations.summary }}
+**Summary:** {{ .Annotations.summary }}{{ end }}{{ if .Annotations.description }}
+**Description:** {{ .Annotations.description }}{{ end }}
+{{ end }}`,
+}
+
+func (c *DiscordReceiverConfig) UnmarshalYAML(unmarshal func(any) error) error {
+	*c = DefaultDiscordReceiverConfig
+	type plain DiscordReceiverConfig
+	return unmarshal((*plain)(c))
+}
+
+func (c *DiscordReceiverConfig) Validate() error {
+	if c.WebhookURL == nil {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "webhook_url is required for discord")
+	}
+
+	if c.ThreadID != "" {
+		if _, err := strconv.ParseUint(c.ThreadID, 10, 64); err != nil {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "thread_id %q of discord is not a valid id", c.ThreadID)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package alertmanagertypes
 
 import (
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/prometheus/alertmanager/config"
 	commoncfg "github.com/prometheus/common/config"
 )
@@ -32,3 +33,11 @@ func (c *GoogleChatReceiverConfig) UnmarshalYAML(unmarshal func(any) error) erro
 	type plain GoogleChatReceiverConfig
 	return unmarshal((*plain)(c))
 }
+
+func (c *GoogleChatReceiverConfig) Validate() error {
+	if c.WebhookURL == nil {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "webhook_url is required for googlechat")
+	}
+
+	return nil
+}
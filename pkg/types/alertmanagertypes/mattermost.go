@@ -0,0 +1,49 @@
+package alertmanagertypes
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/config"
+	commoncfg "github.com/prometheus/common/config"
+)
+
+// MattermostReceiverConfig replaces upstream's mattermost_configs with a
+// config that renders the title and text through the Templater.
+type MattermostReceiverConfig struct {
+	config.NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	WebhookURL *config.SecretURL `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+	Channel    string            `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Username   string            `yaml:"username,omitempty" json:"username,omitempty"`
+	IconURL    string            `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+	IconEmoji  string            `yaml:"icon_emoji,omitempty" json:"icon_emoji,omitempty"`
+	Title      string            `yaml:"title,omitempty" json:"title,omitempty"`
+	Text       string            `yaml:"text,omitempty" json:"text,omitempty"`
+}
+
+var DefaultMattermostReceiverConfig = MattermostReceiverConfig{
+	NotifierConfig: config.NotifierConfig{
+		VSendResolved: false,
+	},
+	Title: `[{{ .Status | toUpper }}{{ if eq .Status "firing" }}:{{ .Alerts.Firing | len }}{{ end }}] {{ .CommonLabels.alertname }}`,
+	Text: `{{ range .Alerts -}}
+**Alert:** {{ .Labels.alertname }}{{ if .Labels.severity }} ({{ .Labels.severity }}){{ end }}{{ if .Annotations.summary }}
+**Summary:** {{ .Annotations.summary }}{{ end }}{{ if .Annotations.description }}
+**Description:** {{ .Annotations.description }}{{ end }}
+{{ end }}`,
+}
+
+func (c *MattermostReceiverConfig) UnmarshalYAML(unmarshal func(any) error) error {
+	*c = DefaultMattermostReceiverConfig
+	type plain MattermostReceiverConfig
+	return unmarshal((*plain)(c))
+}
+
+func (c *MattermostReceiverConfig) Validate() error {
+	if c.WebhookURL == nil {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "webhook_url is required for mattermost")
+	}
+
+	return nil
+}
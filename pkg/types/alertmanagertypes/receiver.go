@@ -23,6 +23,12 @@ import (
 type Receiver struct {
 	*config.Receiver
 	GoogleChatConfigs []*GoogleChatReceiverConfig `json:"googlechat_configs,omitempty" yaml:"googlechat_configs,omitempty"`
+
+	// The fields below shadow upstream's configs of the same name
+	// (encoding/json: shallower-field-wins), so the native notifiers handle them.
+	DiscordConfigs    []*DiscordReceiverConfig    `json:"discord_configs,omitempty" yaml:"discord_configs,omitempty"`
+	TelegramConfigs   []*TelegramReceiverConfig   `json:"telegram_configs,omitempty" yaml:"telegram_configs,omitempty"`
+	MattermostConfigs []*MattermostReceiverConfig `json:"mattermost_configs,omitempty" yaml:"mattermost_configs,omitempty"`
 }
 
 // NewReceiver builds a Receiver from its JSON input, applying each notifier
@@ -47,6 +53,27 @@ func NewReceiver(input string) (*Receiver, error) {
 		}
 		receiver.GoogleChatConfigs[i] = defaulted
 	}
+	for i, dc := range receiver.DiscordConfigs {
+		defaulted, err := defaultedNotifierConfig(dc)
+		if err != nil {
+			return nil, err
+		}
+		receiver.DiscordConfigs[i] = defaulted
+	}
+	for i, tc := range receiver.TelegramConfigs {
+		defaulted, err := defaultedNotifierConfig(tc)
+		if err != nil {
+			return nil, err
+		}
+		receiver.TelegramConfigs[i] = defaulted
+	}
+	for i, mc := range receiver.MattermostConfigs {
+		defaulted, err := defaultedNotifierConfig(mc)
+		if err != nil {
+			return nil, err
+		}
+		receiver.MattermostConfigs[i] = defaulted
+	}
 
 	return receiver, nil
 }
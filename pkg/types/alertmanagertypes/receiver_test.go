@@ -17,8 +17,8 @@ func TestNewReceiver(t *testing.T) {
 	}{
 		{
 			name:     "TelegramConfig",
-			input:    `{"name":"telegram","telegram_configs":[{"chat":12345,"token":"1234567890"}]}`,
-			expected: `{"name":"telegram","telegram_configs":[{"send_resolved":false,"token":"1234567890","chat":12345,"message":"{{ template \"telegram.default.message\" . }}","parse_mode":"HTML"}]}`,
+			input:    `{"name":"telegram","telegram_configs":[{"chat":12345,"token":"1234567890","title":"Alert","message":"Body"}]}`,
+			expected: `{"name":"telegram","telegram_configs":[{"send_resolved":false,"token":"1234567890","chat":12345,"title":"Alert","message":"Body","parse_mode":"HTML"}]}`,
 			pass:     true,
 		},
 		{
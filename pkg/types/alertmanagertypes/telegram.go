@@ -0,0 +1,71 @@
+package alertmanagertypes
+
+import (
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/config"
+	commoncfg "github.com/prometheus/common/config"
+)
+
+const (
+	TelegramParseModeHTML       = "HTML"
+	TelegramParseModeMarkdownV2 = "MarkdownV2"
+	TelegramParseModeMarkdown   = "Markdown"
+)
+
+// TelegramReceiverConfig replaces upstream's telegram_configs. It keeps the
+// upstream fields, including their JSON names, and adds a title and message
+// editing.
+type TelegramReceiverConfig struct {
+	config.NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	APIURL               *config.URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	BotToken             config.Secret `yaml:"bot_token,omitempty" json:"token,omitempty"`
+	ChatID               int64         `yaml:"chat_id,omitempty" json:"chat,omitempty"`
+	MessageThreadID      int           `yaml:"message_thread_id,omitempty" json:"message_thread_id,omitempty"`
+	Title                string        `yaml:"title,omitempty" json:"title,omitempty"`
+	Message              string        `yaml:"message,omitempty" json:"message,omitempty"`
+	DisableNotifications bool          `yaml:"disable_notifications,omitempty" json:"disable_notifications,omitempty"`
+	ParseMode            string        `yaml:"parse_mode,omitempty" json:"parse_mode,omitempty"`
+
+	// EditMessages updates the message already sent for an alert group instead of replying to it.
+	EditMessages bool `yaml:"edit_messages,omitempty" json:"edit_messages,omitempty"`
+}
+
+var DefaultTelegramReceiverConfig = TelegramReceiverConfig{
+	NotifierConfig: config.NotifierConfig{
+		VSendResolved: false,
+	},
+	Title: `[{{ .Status | toUpper }}{{ if eq .Status "firing" }}:{{ .Alerts.Firing | len }}{{ end }}] {{ .CommonLabels.alertname }}`,
+	Message: `{{ range .Alerts -}}
+<b>Alert:</b> {{ .Labels.alertname | html }}{{ if .Labels.severity }} ({{ .Labels.severity | html }}){{ end }}{{ if .Annotations.summary }}
+<b>Summary:</b> {{ .Annotations.summary | html }}{{ end }}{{ if .Annotations.description }}
+<b>Description:</b> {{ .Annotations.description | html }}{{ end }}
+{{ end }}`,
+	ParseMode: TelegramParseModeHTML,
+}
+
+func (c *TelegramReceiverConfig) UnmarshalYAML(unmarshal func(any) error) error {
+	*c = DefaultTelegramReceiverConfig
+	type plain TelegramReceiverConfig
+	return unmarshal((*plain)(c))
+}
+
+func (c *TelegramReceiverConfig) Validate() error {
+	if c.BotToken == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "bot_token is required for telegram")
+	}
+
+	if c.ChatID == 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "chat_id is required for telegram")
+	}
+
+	if c.ParseMode != "" && !slices.Contains([]string{TelegramParseModeHTML, TelegramParseModeMarkdownV2, TelegramParseModeMarkdown}, c.ParseMode) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "unknown parse_mode %q for telegram, must be one of %s, %s or %s", c.ParseMode, TelegramParseModeHTML, TelegramParseModeMarkdownV2, TelegramParseModeMarkdown)
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package audittypes
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"strconv"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Resource attributes which chain the batches of an audit log file. Every
+// resource of a batch carries the same values.
+const (
+	ChainSequenceKey     = "signoz.audit.chain.sequence"
+	ChainPreviousHashKey = "signoz.audit.chain.previous_hash"
+	ChainSignatureKey    = "signoz.audit.chain.signature"
+)
+
+var (
+	ErrCodeAuditChainBroken     = errors.MustNewCode("audit_chain_broken")
+	ErrCodeAuditChainInvalidKey = errors.MustNewCode("audit_chain_invalid_key")
+)
+
+// ChainLink ties a batch to the one before it. PreviousHash is the SHA-256 of
+// the previous line of the file and is empty for the first batch of a chain.
+// A link with a signature is a checkpoint: the signature covers the records of
+// the batch along with the sequence and the previous hash, and with them every
+// batch before the checkpoint.
+type ChainLink struct {
+	Sequence     int64
+	PreviousHash string
+	Signature    []byte
+}
+
+// NewChainLinkFromPLogs returns the link of a batch, false if it is not chained.
+func NewChainLinkFromPLogs(logs plog.Logs) (ChainLink, bool) {
+	if logs.ResourceLogs().Len() == 0 {
+		return ChainLink{}, false
+	}
+
+	attrs := logs.ResourceLogs().At(0).Resource().Attributes()
+	sequence, ok := attrs.Get(ChainSequenceKey)
+	if !ok {
+		return ChainLink{}, false
+	}
+
+	link := ChainLink{Sequence: sequence.Int()}
+	if previousHash, ok := attrs.Get(ChainPreviousHashKey); ok {
+		link.PreviousHash = previousHash.Str()
+	}
+
+	if signature, ok := attrs.Get(ChainSignatureKey); ok {
+		decoded, err := base64.StdEncoding.DecodeString(signature.Str())
+		if err != nil {
+			// Kept as is so that the checkpoint fails verification.
+			decoded = []byte(signature.Str())
+		}
+		link.Signature = decoded
+	}
+
+	return link, true
+}
+
+// NextChainLink returns the link of the batch following the given line of the file.
+func NextChainLink(previous ChainLink, previousLine []byte) ChainLink {
+	return ChainLink{Sequence: previous.Sequence + 1, PreviousHash: HashChainLine(previousLine)}
+}
+
+// HashChainLine returns the hex encoded SHA-256 of a line of the file, without
+// its trailing newline.
+func HashChainLine(line []byte) string {
+	sum := sha256.Sum256(bytes.TrimSuffix(line, []byte{'\n'}))
+	return hex.EncodeToString(sum[:])
+}
+
+// Put sets the link on every resource of the batch.
+func (link ChainLink) Put(logs plog.Logs) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		attrs := logs.ResourceLogs().At(i).Resource().Attributes()
+		attrs.PutInt(ChainSequenceKey, link.Sequence)
+		attrs.PutStr(ChainPreviousHashKey, link.PreviousHash)
+		if len(link.Signature) > 0 {
+			attrs.PutStr(ChainSignatureKey, base64.StdEncoding.EncodeToString(link.Signature))
+		}
+	}
+}
+
+// Sign puts the link on the batch and turns it into a checkpoint, signing the
+// records of the batch along with the link.
+func (link ChainLink) Sign(key ed25519.PrivateKey, logs plog.Logs) (ChainLink, error) {
+	link.Signature = nil
+	link.Put(logs)
+
+	payload, err := link.signedPayload(logs)
+	if err != nil {
+		return ChainLink{}, err
+	}
+
+	link.Signature = ed25519.Sign(key, payload)
+	link.Put(logs)
+	return link, nil
+}
+
+func (link ChainLink) IsCheckpoint() bool {
+	return len(link.Signature) > 0
+}
+
+// VerifySignature reports whether the signature of the link covers the batch.
+func (link ChainLink) VerifySignature(key ed25519.PublicKey, logs plog.Logs) bool {
+	payload, err := link.signedPayload(logs)
+	if err != nil {
+		return false
+	}
+
+	return ed25519.Verify(key, payload, link.Signature)
+}
+
+// signedPayload is the link followed by the SHA-256 of the batch as marshaled
+// without its signature.
+func (link ChainLink) signedPayload(logs plog.Logs) ([]byte, error) {
+	unsigned := plog.NewLogs()
+	logs.CopyTo(unsigned)
+	for i := 0; i < unsigned.ResourceLogs().Len(); i++ {
+		unsigned.ResourceLogs().At(i).Resource().Attributes().Remove(ChainSignatureKey)
+	}
+
+	records, err := (&plog.JSONMarshaler{}).MarshalLogs(unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(records)
+	return []byte(strconv.FormatInt(link.Sequence, 10) + ":" + link.PreviousHash + ":" + hex.EncodeToString(sum[:])), nil
+}
+
+// ChainVerification is the outcome of walking an intact audit log file.
+type ChainVerification struct {
+	// Batches is the number of batches in the file.
+	Batches int
+
+	// Checkpoints is the number of signed batches. Signatures are only
+	// verified when a public key is given.
+	Checkpoints int
+
+	// Unsigned is the number of batches after the last checkpoint. They can be
+	// dropped from the end of the file without breaking the chain.
+	Unsigned int
+}
+
+// VerifyChain walks the lines of an audit log file and returns an error for the
+// first line which breaks the chain. Checkpoint signatures are verified when key
+// is not nil.
+func VerifyChain(reader io.Reader, key ed25519.PublicKey) (*ChainVerification, error) {
+	verification := &ChainVerification{}
+	unmarshaler := plog.JSONUnmarshaler{}
+	buffered := bufio.NewReader(reader)
+
+	var previous ChainLink
+	var previousLine []byte
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := buffered.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		if len(line) == 0 {
+			return verification, nil
+		}
+
+		if line[len(line)-1] != '\n' {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAuditChainBroken, "line %d: record is incomplete", lineNumber)
+		}
+
+		logs, unmarshalErr := unmarshaler.UnmarshalLogs(line)
+		if unmarshalErr != nil {
+			return nil, errors.Wrapf(unmarshalErr, errors.TypeInvalidInput, ErrCodeAuditChainBroken, "line %d: record is not valid OTLP JSON", lineNumber)
+		}
+
+		link, ok := NewChainLinkFromPLogs(logs)
+		if !ok {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAuditChainBroken, "line %d: record is not chained", lineNumber)
+		}
+
+		if previousLine == nil {
+			if link.Sequence != 0 || link.PreviousHash != "" {
+				return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAuditChainBroken, "line %d: chain starts at sequence %d, records before it are missing", lineNumber, link.Sequence)
+			}
+		} else {
+			expected := NextChainLink(previous, previousLine)
+			if link.Sequence != expected.Sequence {
+				return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAuditChainBroken, "line %d: expected sequence %d, got %d", lineNumber, expected.Sequence, link.Sequence)
+			}
+
+			if link.PreviousHash != expected.PreviousHash {
+				return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAuditChainBroken, "line %d: hash of line %d does not match, it was modified", lineNumber, lineNumber-1)
+			}
+		}
+
+		verification.Batches++
+		verification.Unsigned++
+		if link.IsCheckpoint() {
+			if key != nil && !link.VerifySignature(key, logs) {
+				return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAuditChainBroken, "line %d: checkpoint signature is invalid", lineNumber)
+			}
+
+			verification.Checkpoints++
+			verification.Unsigned = 0
+		}
+
+		previous = link
+		previousLine = line
+	}
+}
+
+// NewChainSigningKeyFromPEM parses a PEM encoded PKCS #8 ed25519 private key,
+// as written by `openssl genpkey -algorithm ed25519`.
+func NewChainSigningKeyFromPEM(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAuditChainInvalidKey, "signing key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAuditChainInvalidKey, "failed to parse signing key")
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAuditChainInvalidKey, "signing key is not an ed25519 key")
+	}
+
+	return privateKey, nil
+}
+
+// NewChainVerifyingKeyFromPEM parses a PEM encoded PKIX ed25519 public key, as
+// written by `openssl pkey -pubout`.
+func NewChainVerifyingKeyFromPEM(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAuditChainInvalidKey, "public key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAuditChainInvalidKey, "failed to parse public key")
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAuditChainInvalidKey, "public key is not an ed25519 key")
+	}
+
+	return publicKey, nil
+}
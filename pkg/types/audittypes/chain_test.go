@@ -0,0 +1,189 @@
+package audittypes
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// newTestChain returns the lines of a chain of n batches, signing those for
+// which sign returns true.
+func newTestChain(t *testing.T, key ed25519.PrivateKey, n int, sign func(int) bool) [][]byte {
+	t.Helper()
+
+	lines := make([][]byte, 0, n)
+	link := ChainLink{}
+	for i := 0; i < n; i++ {
+		logs := NewPLogsFromAuditEvents([]AuditEvent{
+			newTestEvent(testDashboardKind, "dashboard-1", coretypes.VerbUpdate),
+			newTestEvent(testDashboardKind, "dashboard-2", coretypes.VerbDelete),
+		}, "signoz", "1.0.0", "signoz.audit")
+
+		if sign(i) {
+			var err error
+			link, err = link.Sign(key, logs)
+			require.NoError(t, err)
+		} else {
+			link.Put(logs)
+		}
+
+		line, err := (&plog.JSONMarshaler{}).MarshalLogs(logs)
+		require.NoError(t, err)
+		line = append(line, '\n')
+		lines = append(lines, line)
+
+		link = NextChainLink(link, line)
+	}
+
+	return lines
+}
+
+func TestVerifyChain(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	lines := newTestChain(t, privateKey, 5, func(i int) bool { return i%2 == 0 })
+
+	testCases := []struct {
+		name         string
+		lines        func() [][]byte
+		key          ed25519.PublicKey
+		verification *ChainVerification
+		errContains  string
+	}{
+		{
+			name:         "Intact",
+			lines:        func() [][]byte { return lines },
+			key:          publicKey,
+			verification: &ChainVerification{Batches: 5, Checkpoints: 3, Unsigned: 0},
+		},
+		{
+			name:         "IntactWithoutKey",
+			lines:        func() [][]byte { return lines[:4] },
+			verification: &ChainVerification{Batches: 4, Checkpoints: 2, Unsigned: 1},
+		},
+		{
+			name:         "Empty",
+			lines:        func() [][]byte { return nil },
+			key:          publicKey,
+			verification: &ChainVerification{},
+		},
+		{
+			name: "Modified",
+			lines: func() [][]byte {
+				modified := bytes.Replace(lines[1], []byte("dashboard-2"), []byte("dashboard-3"), 1)
+				return [][]byte{lines[0], modified, lines[2], lines[3], lines[4]}
+			},
+			key:         publicKey,
+			errContains: "line 3: hash of line 2 does not match",
+		},
+		{
+			name:        "Deleted",
+			lines:       func() [][]byte { return [][]byte{lines[0], lines[1], lines[3], lines[4]} },
+			key:         publicKey,
+			errContains: "line 3: expected sequence 2, got 3",
+		},
+		{
+			name:        "HeadDeleted",
+			lines:       func() [][]byte { return lines[2:] },
+			key:         publicKey,
+			errContains: "line 1: chain starts at sequence 2",
+		},
+		{
+			name:        "Incomplete",
+			lines:       func() [][]byte { return [][]byte{lines[0], lines[1][:len(lines[1])-1]} },
+			key:         publicKey,
+			errContains: "line 2: record is incomplete",
+		},
+		{
+			name:        "OtherKey",
+			lines:       func() [][]byte { return lines },
+			key:         otherPublicKey,
+			errContains: "line 1: checkpoint signature is invalid",
+		},
+		{
+			name:        "NotChained",
+			lines:       func() [][]byte { return [][]byte{[]byte("{\"resourceLogs\":[]}\n")} },
+			key:         publicKey,
+			errContains: "line 1: record is not chained",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			verification, err := VerifyChain(bytes.NewReader(bytes.Join(testCase.lines(), nil)), testCase.key)
+			if testCase.errContains != "" {
+				require.Error(t, err)
+				assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+				assert.Contains(t, err.Error(), testCase.errContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.verification, verification)
+		})
+	}
+}
+
+func TestVerifyChainRewrittenWithoutKey(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, forgedKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// A chain rewritten from scratch has consistent hashes, only the
+	// signatures give it away.
+	forged := newTestChain(t, forgedKey, 3, func(i int) bool { return i == 0 })
+	_, err = VerifyChain(bytes.NewReader(bytes.Join(forged, nil)), publicKey)
+	assert.ErrorContains(t, err, "line 1: checkpoint signature is invalid")
+
+	genuine := newTestChain(t, privateKey, 3, func(i int) bool { return i == 0 })
+	_, err = VerifyChain(bytes.NewReader(bytes.Join(genuine, nil)), publicKey)
+	assert.NoError(t, err)
+}
+
+func TestVerifyChainRecordsRewrittenAfterLastCheckpoint(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// Rewriting the records of the last checkpoint and recomputing the hashes
+	// after it keeps the chain consistent, only the signature gives it away.
+	lines := newTestChain(t, privateKey, 3, func(i int) bool { return i == 2 })
+	modified := bytes.Replace(lines[2], []byte("dashboard-2"), []byte("dashboard-3"), 1)
+	_, err = VerifyChain(bytes.NewReader(bytes.Join([][]byte{lines[0], lines[1], modified}, nil)), publicKey)
+	assert.ErrorContains(t, err, "line 3: checkpoint signature is invalid")
+}
+
+func TestNewChainKeysFromPEM(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	parsedPrivate, err := NewChainSigningKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, parsedPrivate)
+
+	parsedPublic, err := NewChainVerifyingKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, parsedPublic)
+
+	_, err = NewChainSigningKeyFromPEM([]byte("not a key"))
+	assert.Error(t, err)
+	_, err = NewChainVerifyingKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,341 @@
+package audittypes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	DefaultAuditEventsLimit = 100
+	MaxAuditEventsLimit     = 1000
+
+	// MaxExportedAuditEvents caps the number of events in a single export.
+	MaxExportedAuditEvents = 10000
+)
+
+// Keys of the audit attributes queried by the audit trail. Resource kind and ID
+// are resource attributes, the rest are log attributes.
+const (
+	actionKey         = "signoz.audit.action"
+	actionCategoryKey = "signoz.audit.action_category"
+	outcomeKey        = "signoz.audit.outcome"
+	principalIDKey    = "signoz.audit.principal.id"
+	principalEmailKey = "signoz.audit.principal.email"
+	principalTypeKey  = "signoz.audit.principal.type"
+	principalOrgIDKey = "signoz.audit.principal.org_id"
+	resourceKindKey   = "signoz.audit.resource.kind"
+	resourceIDKey     = "signoz.audit.resource.id"
+	errorTypeKey      = "signoz.audit.error.type"
+	errorCodeKey      = "signoz.audit.error.code"
+)
+
+var (
+	AuditExportFormatCSV   = AuditExportFormat{valuer.NewString("csv")}
+	AuditExportFormatJSONL = AuditExportFormat{valuer.NewString("jsonl")}
+)
+
+type AuditExportFormat struct{ valuer.String }
+
+func (AuditExportFormat) Enum() []any {
+	return []any{
+		AuditExportFormatCSV,
+		AuditExportFormatJSONL,
+	}
+}
+
+// AuditEventsFilter defines the URL query params which select audit events.
+type AuditEventsFilter struct {
+	Start          int64          `query:"start" required:"true" description:"Start of the time range in epoch milliseconds."`
+	End            int64          `query:"end" required:"true" description:"End of the time range in epoch milliseconds."`
+	PrincipalID    string         `query:"principalId" description:"ID of the user or service account which performed the action."`
+	PrincipalEmail string         `query:"principalEmail" description:"Email of the principal which performed the action."`
+	PrincipalType  PrincipalType  `query:"principalType" description:"Type of the principal which performed the action."`
+	ResourceKind   string         `query:"resourceKind" description:"Kind of the resource acted on, for example dashboard."`
+	ResourceID     string         `query:"resourceId" description:"ID of the resource acted on."`
+	ActionCategory ActionCategory `query:"actionCategory" description:"Category of the action."`
+	Outcome        Outcome        `query:"outcome" description:"Outcome of the action."`
+}
+
+// ListAuditEventsParams defines the URL query params of the audit events search.
+type ListAuditEventsParams struct {
+	AuditEventsFilter
+	Limit  int `query:"limit" description:"Maximum number of events to return, defaults to 100 and cannot exceed 1000."`
+	Offset int `query:"offset" description:"Number of events to skip."`
+}
+
+// ExportAuditEventsParams defines the URL query params of the audit events export.
+type ExportAuditEventsParams struct {
+	AuditEventsFilter
+	Format AuditExportFormat `query:"format" description:"Format of the export, defaults to csv."`
+}
+
+// GetAuditResourceTimelineParams defines the URL query params of the timeline of a resource.
+type GetAuditResourceTimelineParams struct {
+	Start  int64 `query:"start" required:"true" description:"Start of the time range in epoch milliseconds."`
+	End    int64 `query:"end" required:"true" description:"End of the time range in epoch milliseconds."`
+	Limit  int   `query:"limit" description:"Maximum number of events to return, defaults to 100 and cannot exceed 1000."`
+	Offset int   `query:"offset" description:"Number of events to skip."`
+}
+
+type GettableAuditEvent struct {
+	Timestamp      time.Time `json:"timestamp" required:"true"`
+	ID             string    `json:"id" required:"true"`
+	EventName      string    `json:"eventName" required:"true"`
+	Body           string    `json:"body" required:"true"`
+	Action         string    `json:"action" required:"true"`
+	ActionCategory string    `json:"actionCategory" required:"true"`
+	Outcome        string    `json:"outcome" required:"true"`
+	PrincipalID    string    `json:"principalId" required:"true"`
+	PrincipalEmail string    `json:"principalEmail" required:"true"`
+	PrincipalType  string    `json:"principalType" required:"true"`
+	ResourceKind   string    `json:"resourceKind" required:"true"`
+	ResourceID     string    `json:"resourceId" required:"true"`
+	ErrorType      string    `json:"errorType,omitempty"`
+	ErrorCode      string    `json:"errorCode,omitempty"`
+	TraceID        string    `json:"traceId,omitempty"`
+}
+
+type GettableAuditEvents struct {
+	Events  []*GettableAuditEvent `json:"events" required:"true"`
+	HasMore bool                  `json:"hasMore" required:"true"`
+}
+
+func (filter *AuditEventsFilter) Validate() error {
+	if err := validateAuditTimeRange(filter.Start, filter.End); err != nil {
+		return err
+	}
+
+	if !filter.PrincipalType.IsZero() && !isAuditEnumValue(PrincipalType{}.Enum(), filter.PrincipalType.StringValue()) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid principalType %q", filter.PrincipalType.StringValue())
+	}
+
+	if !filter.ActionCategory.IsZero() && !isAuditEnumValue(ActionCategory{}.Enum(), filter.ActionCategory.StringValue()) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid actionCategory %q", filter.ActionCategory.StringValue())
+	}
+
+	if !filter.Outcome.IsZero() && !isAuditEnumValue(Outcome{}.Enum(), filter.Outcome.StringValue()) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid outcome %q", filter.Outcome.StringValue())
+	}
+
+	if filter.ResourceKind != "" {
+		if _, err := coretypes.NewKind(filter.ResourceKind); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewFilterExpression returns the filter expression selecting the events of
+// the organization which match the filter.
+func (filter *AuditEventsFilter) NewFilterExpression(orgID valuer.UUID) string {
+	conditions := []string{newAuditCondition("attribute", principalOrgIDKey, orgID.StringValue())}
+
+	optional := []struct {
+		context string
+		key     string
+		value   string
+	}{
+		{"attribute", principalIDKey, filter.PrincipalID},
+		{"attribute", principalEmailKey, filter.PrincipalEmail},
+		{"attribute", principalTypeKey, filter.PrincipalType.StringValue()},
+		{"resource", resourceKindKey, filter.ResourceKind},
+		{"resource", resourceIDKey, filter.ResourceID},
+		{"attribute", actionCategoryKey, filter.ActionCategory.StringValue()},
+		{"attribute", outcomeKey, filter.Outcome.StringValue()},
+	}
+
+	for _, condition := range optional {
+		if condition.value != "" {
+			conditions = append(conditions, newAuditCondition(condition.context, condition.key, condition.value))
+		}
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+func (params *ListAuditEventsParams) Validate() error {
+	if err := params.AuditEventsFilter.Validate(); err != nil {
+		return err
+	}
+
+	return validateAuditPage(&params.Limit, params.Offset)
+}
+
+func (params *ExportAuditEventsParams) Validate() error {
+	if err := params.AuditEventsFilter.Validate(); err != nil {
+		return err
+	}
+
+	if params.Format.IsZero() {
+		params.Format = AuditExportFormatCSV
+	}
+
+	if !isAuditEnumValue(AuditExportFormat{}.Enum(), params.Format.StringValue()) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format %q: must be one of csv or jsonl", params.Format.StringValue())
+	}
+
+	return nil
+}
+
+func (params *GetAuditResourceTimelineParams) Validate() error {
+	if err := validateAuditTimeRange(params.Start, params.End); err != nil {
+		return err
+	}
+
+	return validateAuditPage(&params.Limit, params.Offset)
+}
+
+// NewAuditResourceTimelineFilter returns the filter selecting the events of a
+// single resource.
+func NewAuditResourceTimelineFilter(kind coretypes.Kind, id string, params *GetAuditResourceTimelineParams) AuditEventsFilter {
+	return AuditEventsFilter{
+		Start:        params.Start,
+		End:          params.End,
+		ResourceKind: kind.String(),
+		ResourceID:   id,
+	}
+}
+
+// NewGettableAuditEventFromRawRow converts a row of the audit logs table
+// queried with its default columns.
+func NewGettableAuditEventFromRawRow(row *qbtypes.RawRow) *GettableAuditEvent {
+	attributes, _ := row.Data["attributes_string"].(map[string]string)
+	resource, _ := row.Data["resource"].(map[string]any)
+
+	return &GettableAuditEvent{
+		Timestamp:      row.Timestamp,
+		ID:             rawRowString(row.Data, "id"),
+		EventName:      rawRowString(row.Data, "event_name"),
+		Body:           rawRowString(row.Data, "body"),
+		Action:         attributes[actionKey],
+		ActionCategory: attributes[actionCategoryKey],
+		Outcome:        attributes[outcomeKey],
+		PrincipalID:    attributes[principalIDKey],
+		PrincipalEmail: attributes[principalEmailKey],
+		PrincipalType:  attributes[principalTypeKey],
+		ResourceKind:   lookupResourceAttribute(resource, resourceKindKey),
+		ResourceID:     lookupResourceAttribute(resource, resourceIDKey),
+		ErrorType:      attributes[errorTypeKey],
+		ErrorCode:      attributes[errorCodeKey],
+		TraceID:        rawRowString(row.Data, "trace_id"),
+	}
+}
+
+// AuditEventCSVHeader is the header of the CSV export, in the order of CSVRecord.
+var AuditEventCSVHeader = []string{
+	"timestamp",
+	"id",
+	"eventName",
+	"action",
+	"actionCategory",
+	"outcome",
+	"principalId",
+	"principalEmail",
+	"principalType",
+	"resourceKind",
+	"resourceId",
+	"errorType",
+	"errorCode",
+	"traceId",
+	"body",
+}
+
+func (event *GettableAuditEvent) CSVRecord() []string {
+	return []string{
+		event.Timestamp.UTC().Format(time.RFC3339Nano),
+		event.ID,
+		event.EventName,
+		event.Action,
+		event.ActionCategory,
+		event.Outcome,
+		event.PrincipalID,
+		event.PrincipalEmail,
+		event.PrincipalType,
+		event.ResourceKind,
+		event.ResourceID,
+		event.ErrorType,
+		event.ErrorCode,
+		event.TraceID,
+		event.Body,
+	}
+}
+
+func validateAuditTimeRange(start, end int64) error {
+	if start <= 0 || end <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start and end are required")
+	}
+
+	if start >= end {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start must be before end")
+	}
+
+	return nil
+}
+
+func validateAuditPage(limit *int, offset int) error {
+	if *limit == 0 {
+		*limit = DefaultAuditEventsLimit
+	}
+
+	if *limit < 0 || *limit > MaxAuditEventsLimit {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be between 1 and %d", MaxAuditEventsLimit)
+	}
+
+	if offset < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "offset must not be negative")
+	}
+
+	return nil
+}
+
+func isAuditEnumValue(enum []any, value string) bool {
+	for _, item := range enum {
+		if stringer, ok := item.(interface{ StringValue() string }); ok && stringer.StringValue() == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newAuditCondition returns an equality condition of the filter expression
+// language, with the value quoted.
+func newAuditCondition(context string, key string, value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return fmt.Sprintf("%s.%s = '%s'", context, key, value)
+}
+
+func rawRowString(data map[string]any, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
+
+// lookupResourceAttribute returns a resource attribute from the resource JSON
+// column, which splits dotted keys into nested objects.
+func lookupResourceAttribute(resource map[string]any, key string) string {
+	if value, ok := resource[key].(string); ok {
+		return value
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] != '.' {
+			continue
+		}
+
+		if nested, ok := resource[key[:i]].(map[string]any); ok {
+			if value := lookupResourceAttribute(nested, key[i+1:]); value != "" {
+				return value
+			}
+		}
+	}
+
+	return ""
+}
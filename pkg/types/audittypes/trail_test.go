@@ -0,0 +1,209 @@
+package audittypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditEventsParamsValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		params      ListAuditEventsParams
+		limit       int
+		errContains string
+	}{
+		{
+			name:   "DefaultLimit",
+			params: ListAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 1000, End: 2000}},
+			limit:  DefaultAuditEventsLimit,
+		},
+		{
+			name: "AllFilters",
+			params: ListAuditEventsParams{
+				AuditEventsFilter: AuditEventsFilter{
+					Start:          1000,
+					End:            2000,
+					PrincipalType:  PrincipalTypeServiceAccount,
+					ResourceKind:   "dashboard",
+					ActionCategory: ActionCategoryConfigurationChange,
+					Outcome:        OutcomeSuccess,
+				},
+				Limit: 10,
+			},
+			limit: 10,
+		},
+		{
+			name:        "MissingTimeRange",
+			params:      ListAuditEventsParams{},
+			errContains: "start and end are required",
+		},
+		{
+			name:        "InvertedTimeRange",
+			params:      ListAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 2000, End: 1000}},
+			errContains: "start must be before end",
+		},
+		{
+			name:        "LimitTooLarge",
+			params:      ListAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 1000, End: 2000}, Limit: MaxAuditEventsLimit + 1},
+			errContains: "limit must be between 1 and 1000",
+		},
+		{
+			name:        "NegativeOffset",
+			params:      ListAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 1000, End: 2000}, Offset: -1},
+			errContains: "offset must not be negative",
+		},
+		{
+			name:        "InvalidOutcome",
+			params:      ListAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 1000, End: 2000, Outcome: Outcome{String: valuer.NewString("partial")}}},
+			errContains: "invalid outcome",
+		},
+		{
+			name:        "InvalidActionCategory",
+			params:      ListAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 1000, End: 2000, ActionCategory: ActionCategory{valuer.NewString("billing")}}},
+			errContains: "invalid actionCategory",
+		},
+		{
+			name:        "InvalidResourceKind",
+			params:      ListAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 1000, End: 2000, ResourceKind: "Dashboard Panel"}},
+			errContains: "kind must conform",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.params.Validate()
+			if testCase.errContains != "" {
+				require.Error(t, err)
+				assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+				assert.Contains(t, err.Error(), testCase.errContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.limit, testCase.params.Limit)
+		})
+	}
+}
+
+func TestExportAuditEventsParamsValidate(t *testing.T) {
+	params := ExportAuditEventsParams{AuditEventsFilter: AuditEventsFilter{Start: 1000, End: 2000}}
+	require.NoError(t, params.Validate())
+	assert.Equal(t, AuditExportFormatCSV, params.Format)
+
+	params.Format = AuditExportFormat{valuer.NewString("parquet")}
+	assert.ErrorContains(t, params.Validate(), "invalid format")
+}
+
+func TestAuditEventsFilterNewFilterExpression(t *testing.T) {
+	orgID := valuer.MustNewUUID("0196f794-ff30-7bee-a5f4-ef5ad315715e")
+
+	testCases := []struct {
+		name       string
+		filter     AuditEventsFilter
+		expression string
+	}{
+		{
+			name:       "OrgOnly",
+			filter:     AuditEventsFilter{},
+			expression: "attribute.signoz.audit.principal.org_id = '0196f794-ff30-7bee-a5f4-ef5ad315715e'",
+		},
+		{
+			name: "AllFilters",
+			filter: AuditEventsFilter{
+				PrincipalID:    "019a1234-abcd-7000-8000-567800000001",
+				PrincipalEmail: "alice@acme.com",
+				PrincipalType:  PrincipalTypeUser,
+				ResourceKind:   "dashboard",
+				ResourceID:     "dashboard-1",
+				ActionCategory: ActionCategoryConfigurationChange,
+				Outcome:        OutcomeFailure,
+			},
+			expression: "attribute.signoz.audit.principal.org_id = '0196f794-ff30-7bee-a5f4-ef5ad315715e'" +
+				" AND attribute.signoz.audit.principal.id = '019a1234-abcd-7000-8000-567800000001'" +
+				" AND attribute.signoz.audit.principal.email = 'alice@acme.com'" +
+				" AND attribute.signoz.audit.principal.type = 'user'" +
+				" AND resource.signoz.audit.resource.kind = 'dashboard'" +
+				" AND resource.signoz.audit.resource.id = 'dashboard-1'" +
+				" AND attribute.signoz.audit.action_category = 'configuration_change'" +
+				" AND attribute.signoz.audit.outcome = 'failure'",
+		},
+		{
+			name:   "QuotesAreEscaped",
+			filter: AuditEventsFilter{ResourceID: `x' OR resource.signoz.audit.resource.id != '\`},
+			expression: "attribute.signoz.audit.principal.org_id = '0196f794-ff30-7bee-a5f4-ef5ad315715e'" +
+				` AND resource.signoz.audit.resource.id = 'x\' OR resource.signoz.audit.resource.id != \'\\'`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expression, testCase.filter.NewFilterExpression(orgID))
+		})
+	}
+}
+
+func TestNewGettableAuditEventFromRawRow(t *testing.T) {
+	timestamp := time.Unix(1747947419, 0)
+
+	testCases := []struct {
+		name     string
+		resource map[string]any
+	}{
+		{
+			name: "NestedResource",
+			resource: map[string]any{
+				"signoz": map[string]any{"audit": map[string]any{"resource": map[string]any{"kind": "dashboard", "id": "dashboard-1"}}},
+			},
+		},
+		{
+			name:     "FlatResource",
+			resource: map[string]any{"signoz.audit.resource.kind": "dashboard", "signoz.audit.resource.id": "dashboard-1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			event := NewGettableAuditEventFromRawRow(&qbtypes.RawRow{
+				Timestamp: timestamp,
+				Data: map[string]any{
+					"id":         "event-1",
+					"event_name": "dashboard.updated",
+					"body":       "alice@acme.com updated dashboard (dashboard-1)",
+					"trace_id":   "trace-1",
+					"attributes_string": map[string]string{
+						"signoz.audit.action":          "update",
+						"signoz.audit.action_category": "configuration_change",
+						"signoz.audit.outcome":         "success",
+						"signoz.audit.principal.id":    "019a1234-abcd-7000-8000-567800000001",
+						"signoz.audit.principal.email": "alice@acme.com",
+						"signoz.audit.principal.type":  "user",
+					},
+					"resource": testCase.resource,
+				},
+			})
+
+			assert.Equal(t, &GettableAuditEvent{
+				Timestamp:      timestamp,
+				ID:             "event-1",
+				EventName:      "dashboard.updated",
+				Body:           "alice@acme.com updated dashboard (dashboard-1)",
+				Action:         "update",
+				ActionCategory: "configuration_change",
+				Outcome:        "success",
+				PrincipalID:    "019a1234-abcd-7000-8000-567800000001",
+				PrincipalEmail: "alice@acme.com",
+				PrincipalType:  "user",
+				ResourceKind:   "dashboard",
+				ResourceID:     "dashboard-1",
+				TraceID:        "trace-1",
+			}, event)
+			assert.Len(t, event.CSVRecord(), len(AuditEventCSVHeader))
+		})
+	}
+}
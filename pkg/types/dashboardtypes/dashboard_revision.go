@@ -0,0 +1,333 @@
+package dashboardtypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+// MaxRevisionsPerDashboard is the number of revisions kept per dashboard; older
+// revisions are pruned when a new one is recorded.
+const MaxRevisionsPerDashboard = 50
+
+var (
+	ErrCodeDashboardRevisionNotFound = errors.MustNewCode("dashboard_revision_not_found")
+	ErrCodeDashboardRevisionConflict = errors.MustNewCode("dashboard_revision_conflict")
+)
+
+// DashboardRevisionKind is the kind of change which produced a revision.
+type DashboardRevisionKind struct{ valuer.String }
+
+var (
+	DashboardRevisionKindCreate  = DashboardRevisionKind{valuer.NewString("create")}
+	DashboardRevisionKindUpdate  = DashboardRevisionKind{valuer.NewString("update")}
+	DashboardRevisionKindPatch   = DashboardRevisionKind{valuer.NewString("patch")}
+	DashboardRevisionKindRestore = DashboardRevisionKind{valuer.NewString("restore")}
+)
+
+func (DashboardRevisionKind) Enum() []any {
+	return []any{DashboardRevisionKindCreate, DashboardRevisionKindUpdate, DashboardRevisionKindPatch, DashboardRevisionKindRestore}
+}
+
+// DashboardRevision is a snapshot of a dashboard taken after a change to it.
+type DashboardRevision struct {
+	types.Identifiable
+
+	DashboardID valuer.UUID
+	OrgID       valuer.UUID
+	Version     int
+	Kind        DashboardRevisionKind
+	CreatedAt   time.Time
+	CreatedBy   string
+
+	// Data is the document of the dashboard. For a v2 dashboard it is the
+	// postable view (metadata, name, tags, spec), the same document JSON
+	// patches are applied against, and for a v1 dashboard it is its data.
+	Data StorableDashboardData
+}
+
+// NewDashboardRevision snapshots the v2 dashboard. The revision is attributed
+// to the last update of the dashboard, its version is set when it is recorded.
+func NewDashboardRevision(dashboard *DashboardV2, kind DashboardRevisionKind) (*DashboardRevision, error) {
+	raw, err := json.Marshal(dashboard.toUpdatableDashboardV2())
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "marshal dashboard revision data")
+	}
+	data := StorableDashboardData{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "unmarshal dashboard revision data")
+	}
+
+	return newDashboardRevision(dashboard.ID, dashboard.OrgID, dashboard.UpdatedAt, dashboard.UpdatedBy, kind, data), nil
+}
+
+// NewDashboardRevisionFromStorableDashboard snapshots the stored v1 dashboard.
+// The revision is attributed to the last update of the dashboard, its version
+// is set when it is recorded.
+func NewDashboardRevisionFromStorableDashboard(dashboard *StorableDashboard, kind DashboardRevisionKind) *DashboardRevision {
+	return newDashboardRevision(dashboard.ID, dashboard.OrgID, dashboard.UpdatedAt, dashboard.UpdatedBy, kind, dashboard.Data)
+}
+
+// NewBaselineDashboardRevision snapshots a v2 dashboard which has no history
+// yet, e.g. because it was last changed before revisions were recorded.
+func NewBaselineDashboardRevision(dashboard *DashboardV2) (*DashboardRevision, error) {
+	revision, err := NewDashboardRevision(dashboard, baselineDashboardRevisionKind(dashboard.CreatedAt, dashboard.UpdatedAt))
+	if err != nil {
+		return nil, err
+	}
+	revision.Version = 1
+	return revision, nil
+}
+
+// NewBaselineDashboardRevisionFromStorableDashboard snapshots a stored v1
+// dashboard which has no history yet.
+func NewBaselineDashboardRevisionFromStorableDashboard(dashboard *StorableDashboard) *DashboardRevision {
+	revision := NewDashboardRevisionFromStorableDashboard(dashboard, baselineDashboardRevisionKind(dashboard.CreatedAt, dashboard.UpdatedAt))
+	revision.Version = 1
+	return revision
+}
+
+func newDashboardRevision(dashboardID valuer.UUID, orgID valuer.UUID, createdAt time.Time, createdBy string, kind DashboardRevisionKind, data StorableDashboardData) *DashboardRevision {
+	return &DashboardRevision{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		DashboardID:  dashboardID,
+		OrgID:        orgID,
+		Kind:         kind,
+		CreatedAt:    createdAt,
+		CreatedBy:    createdBy,
+		Data:         data,
+	}
+}
+
+func baselineDashboardRevisionKind(createdAt time.Time, updatedAt time.Time) DashboardRevisionKind {
+	if updatedAt.Equal(createdAt) {
+		return DashboardRevisionKindCreate
+	}
+	return DashboardRevisionKindUpdate
+}
+
+// UpdatableDashboardV2 returns the data of the revision of a v2 dashboard. It
+// is decoded without UpdatableDashboardV2.UnmarshalJSON so that revisions
+// stored before a validation rule was tightened can still be read.
+func (r *DashboardRevision) UpdatableDashboardV2() (UpdatableDashboardV2, error) {
+	raw, err := json.Marshal(r.Data)
+	if err != nil {
+		return UpdatableDashboardV2{}, errors.WrapInternalf(err, errors.CodeInternal, "marshal dashboard revision data")
+	}
+	type alias UpdatableDashboardV2
+	var data alias
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return UpdatableDashboardV2{}, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeDashboardInvalidData, "revision %d is not a v2 dashboard", r.Version)
+	}
+	return UpdatableDashboardV2(data), nil
+}
+
+// Diff returns the JSON patch operations which turn this revision into the other one.
+// The operations can be sent as is to the patch dashboard endpoint.
+func (r *DashboardRevision) Diff(other *DashboardRevision) ([]JSONPatchOperation, error) {
+	return NewJSONPatchDiff(r.Data, other.Data)
+}
+
+func (r *DashboardRevision) ToStorableDashboardRevision() *StorableDashboardRevision {
+	return &StorableDashboardRevision{
+		Identifiable: r.Identifiable,
+		DashboardID:  r.DashboardID,
+		OrgID:        r.OrgID,
+		Version:      r.Version,
+		Kind:         r.Kind,
+		Data:         r.Data,
+		CreatedAt:    r.CreatedAt,
+		CreatedBy:    r.CreatedBy,
+	}
+}
+
+func (r *DashboardRevision) ToGettableDashboardRevision() GettableDashboardRevision {
+	return GettableDashboardRevision{
+		Identifiable: r.Identifiable,
+		DashboardID:  r.DashboardID,
+		Version:      r.Version,
+		Kind:         r.Kind,
+		CreatedAt:    r.CreatedAt,
+		CreatedBy:    r.CreatedBy,
+	}
+}
+
+func (r *DashboardRevision) ToGettableDashboardRevisionWithData() GettableDashboardRevisionWithData {
+	return GettableDashboardRevisionWithData{
+		GettableDashboardRevision: r.ToGettableDashboardRevision(),
+		Data:                      r.Data,
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════
+// Storable
+// ════════════════════════════════════════════════════════════════════════
+
+type StorableDashboardRevision struct {
+	bun.BaseModel `bun:"table:dashboard_revision,alias:dashboard_revision"`
+
+	types.Identifiable
+	DashboardID valuer.UUID           `bun:"dashboard_id,type:text,notnull"`
+	OrgID       valuer.UUID           `bun:"org_id,type:text,notnull"`
+	Version     int                   `bun:"version,notnull"`
+	Kind        DashboardRevisionKind `bun:"kind,type:text,notnull"`
+	Data        StorableDashboardData `bun:"data,type:text,notnull"`
+	CreatedAt   time.Time             `bun:"created_at,notnull"`
+	CreatedBy   string                `bun:"created_by,type:text,notnull"`
+}
+
+func (storable *StorableDashboardRevision) ToDashboardRevision() *DashboardRevision {
+	return &DashboardRevision{
+		Identifiable: storable.Identifiable,
+		DashboardID:  storable.DashboardID,
+		OrgID:        storable.OrgID,
+		Version:      storable.Version,
+		Kind:         storable.Kind,
+		CreatedAt:    storable.CreatedAt,
+		CreatedBy:    storable.CreatedBy,
+		Data:         storable.Data,
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════
+// Gettable
+// ════════════════════════════════════════════════════════════════════════
+
+type GettableDashboardRevision struct {
+	types.Identifiable
+
+	DashboardID valuer.UUID           `json:"dashboardId" required:"true"`
+	Version     int                   `json:"version" required:"true"`
+	Kind        DashboardRevisionKind `json:"kind" required:"true"`
+	CreatedAt   time.Time             `json:"createdAt" required:"true"`
+	CreatedBy   string                `json:"createdBy" required:"true"`
+}
+
+type GettableDashboardRevisionWithData struct {
+	GettableDashboardRevision
+	// Data is the postable view of a v2 dashboard, or the data of a v1 dashboard.
+	Data StorableDashboardData `json:"data" required:"true"`
+}
+
+type GettableDashboardRevisionDiff struct {
+	From int                  `json:"from" required:"true"`
+	To   int                  `json:"to" required:"true"`
+	Ops  []JSONPatchOperation `json:"ops" required:"true" nullable:"false"`
+}
+
+type DashboardRevisionDiffParams struct {
+	From int `query:"from" required:"true" description:"Version of the revision the diff starts from."`
+	To   int `query:"to" required:"true" description:"Version of the revision the diff leads to."`
+}
+
+// ════════════════════════════════════════════════════════════════════════
+// Diff
+// ════════════════════════════════════════════════════════════════════════
+
+// NewJSONPatchDiff returns the RFC 6902 operations which turn the JSON form of
+// from into the JSON form of to. Objects are diffed key by key and arrays index
+// by index, so the operations are add, remove and replace only.
+func NewJSONPatchDiff(from any, to any) ([]JSONPatchOperation, error) {
+	fromDoc, err := toJSONDocument(from)
+	if err != nil {
+		return nil, err
+	}
+	toDoc, err := toJSONDocument(to)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]JSONPatchOperation, 0)
+	return diffJSONValues(ops, "", fromDoc, toDoc), nil
+}
+
+func toJSONDocument(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "marshal document for diff")
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "unmarshal document for diff")
+	}
+	return doc, nil
+}
+
+func diffJSONValues(ops []JSONPatchOperation, path string, from any, to any) []JSONPatchOperation {
+	switch fromValue := from.(type) {
+	case map[string]any:
+		if toValue, ok := to.(map[string]any); ok {
+			return diffJSONObjects(ops, path, fromValue, toValue)
+		}
+	case []any:
+		if toValue, ok := to.([]any); ok {
+			return diffJSONArrays(ops, path, fromValue, toValue)
+		}
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return ops
+	}
+	return append(ops, JSONPatchOperation{Op: PatchOpReplace, Path: path, Value: to})
+}
+
+func diffJSONObjects(ops []JSONPatchOperation, path string, from map[string]any, to map[string]any) []JSONPatchOperation {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		keyPath := path + "/" + escapeJSONPointerToken(key)
+
+		switch {
+		case !inTo:
+			ops = append(ops, JSONPatchOperation{Op: PatchOpRemove, Path: keyPath})
+		case !inFrom:
+			ops = append(ops, JSONPatchOperation{Op: PatchOpAdd, Path: keyPath, Value: toValue})
+		default:
+			ops = diffJSONValues(ops, keyPath, fromValue, toValue)
+		}
+	}
+
+	return ops
+}
+
+func diffJSONArrays(ops []JSONPatchOperation, path string, from []any, to []any) []JSONPatchOperation {
+	common := min(len(from), len(to))
+	for i := 0; i < common; i++ {
+		ops = diffJSONValues(ops, path+"/"+strconv.Itoa(i), from[i], to[i])
+	}
+
+	for i := common; i < len(to); i++ {
+		ops = append(ops, JSONPatchOperation{Op: PatchOpAdd, Path: path + "/" + strconv.Itoa(i), Value: to[i]})
+	}
+
+	// Removed from the end so that the indices of the elements still to be
+	// removed stay valid.
+	for i := len(from) - 1; i >= common; i-- {
+		ops = append(ops, JSONPatchOperation{Op: PatchOpRemove, Path: path + "/" + strconv.Itoa(i)})
+	}
+
+	return ops
+}
+
+func escapeJSONPointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
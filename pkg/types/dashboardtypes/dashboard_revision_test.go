@@ -0,0 +1,197 @@
+package dashboardtypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
+	"github.com/SigNoz/signoz/pkg/types/tagtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJSONPatchDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		from string
+		to   string
+		ops  []JSONPatchOperation
+	}{
+		{
+			name: "Equal",
+			from: `{"a": 1, "b": [1, {"c": "d"}]}`,
+			to:   `{"b": [1, {"c": "d"}], "a": 1}`,
+			ops:  []JSONPatchOperation{},
+		},
+		{
+			name: "ObjectKeys",
+			from: `{"a": 1, "b": {"c": true, "d": "x"}}`,
+			to:   `{"b": {"c": false, "e": null}, "f": [1]}`,
+			ops: []JSONPatchOperation{
+				{Op: PatchOpRemove, Path: "/a"},
+				{Op: PatchOpReplace, Path: "/b/c", Value: false},
+				{Op: PatchOpRemove, Path: "/b/d"},
+				{Op: PatchOpAdd, Path: "/b/e", Value: nil},
+				{Op: PatchOpAdd, Path: "/f", Value: []any{float64(1)}},
+			},
+		},
+		{
+			name: "ArrayGrows",
+			from: `{"a": [1, 2]}`,
+			to:   `{"a": [1, 3, 4, 5]}`,
+			ops: []JSONPatchOperation{
+				{Op: PatchOpReplace, Path: "/a/1", Value: float64(3)},
+				{Op: PatchOpAdd, Path: "/a/2", Value: float64(4)},
+				{Op: PatchOpAdd, Path: "/a/3", Value: float64(5)},
+			},
+		},
+		{
+			name: "ArrayShrinks",
+			from: `{"a": [1, 2, 3, 4]}`,
+			to:   `{"a": [0]}`,
+			ops: []JSONPatchOperation{
+				{Op: PatchOpReplace, Path: "/a/0", Value: float64(0)},
+				{Op: PatchOpRemove, Path: "/a/3"},
+				{Op: PatchOpRemove, Path: "/a/2"},
+				{Op: PatchOpRemove, Path: "/a/1"},
+			},
+		},
+		{
+			name: "TypeChange",
+			from: `{"a": {"b": 1}, "c": [1]}`,
+			to:   `{"a": [1], "c": "x"}`,
+			ops: []JSONPatchOperation{
+				{Op: PatchOpReplace, Path: "/a", Value: []any{float64(1)}},
+				{Op: PatchOpReplace, Path: "/c", Value: "x"},
+			},
+		},
+		{
+			name: "EscapedKeys",
+			from: `{"a/b": 1, "c~d": 1}`,
+			to:   `{"a/b": 2, "c~d": 2}`,
+			ops: []JSONPatchOperation{
+				{Op: PatchOpReplace, Path: "/a~1b", Value: float64(2)},
+				{Op: PatchOpReplace, Path: "/c~0d", Value: float64(2)},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var from, to any
+			require.NoError(t, json.Unmarshal([]byte(testCase.from), &from))
+			require.NoError(t, json.Unmarshal([]byte(testCase.to), &to))
+
+			ops, err := NewJSONPatchDiff(from, to)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ops, ops)
+
+			// Applying the diff strictly must lead to the target document.
+			raw, err := json.Marshal(ops)
+			require.NoError(t, err)
+			patch, err := jsonpatch.DecodePatch(raw)
+			require.NoError(t, err)
+			patched, err := patch.Apply([]byte(testCase.from))
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.to, string(patched))
+		})
+	}
+}
+
+func TestDashboardRevisionDiff(t *testing.T) {
+	var postable PostableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(basePostableJSON), &postable))
+	dashboard := postable.NewDashboardV2(valuer.GenerateUUID(), "creator@signoz.io", SourceUser)
+	dashboard.Tags = []*tagtypes.Tag{{Key: "team", Value: "alpha"}}
+	from, err := NewDashboardRevision(dashboard, DashboardRevisionKindCreate)
+	require.NoError(t, err)
+
+	var patch PatchableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"op": "replace", "path": "/spec/display/name", "value": "Service overview v2"},
+		{"op": "remove", "path": "/spec/panels/p2"},
+		{"op": "remove", "path": "/spec/layouts/0/spec/items/1"},
+		{"op": "add", "path": "/tags/-", "value": {"key": "env", "value": "prod"}}
+	]`), &patch))
+	updatable, err := patch.Apply(dashboard)
+	require.NoError(t, err)
+	require.NoError(t, dashboard.Update(*updatable, "editor@signoz.io", tagtypes.NewTagsFromPostableTags(dashboard.OrgID, coretypes.KindDashboard, updatable.Tags)))
+	to, err := NewDashboardRevision(dashboard, DashboardRevisionKindPatch)
+	require.NoError(t, err)
+	assert.Equal(t, "editor@signoz.io", to.CreatedBy)
+
+	ops, err := from.Diff(to)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ops)
+
+	// The diff goes through the patch endpoint's apply and leads back to the later revision.
+	raw, err := json.Marshal(ops)
+	require.NoError(t, err)
+	var diff PatchableDashboardV2
+	require.NoError(t, json.Unmarshal(raw, &diff))
+
+	var previous PostableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(basePostableJSON), &previous))
+	restored := previous.NewDashboardV2(dashboard.OrgID, "creator@signoz.io", SourceUser)
+	restored.Tags = []*tagtypes.Tag{{Key: "team", Value: "alpha"}}
+	out, err := diff.Apply(restored)
+	require.NoError(t, err)
+
+	expected, err := json.Marshal(to.Data)
+	require.NoError(t, err)
+	actual, err := json.Marshal(out)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	reverse, err := to.Diff(from)
+	require.NoError(t, err)
+	assert.NotEmpty(t, reverse)
+}
+
+func TestDashboardRevisionStorableRoundTrip(t *testing.T) {
+	var postable PostableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(basePostableJSON), &postable))
+	dashboard := postable.NewDashboardV2(valuer.GenerateUUID(), "creator@signoz.io", SourceUser)
+	dashboard.Tags = []*tagtypes.Tag{{Key: "team", Value: "alpha"}}
+
+	revision, err := NewDashboardRevision(dashboard, DashboardRevisionKindRestore)
+	require.NoError(t, err)
+	revision.Version = 3
+	storable := revision.ToStorableDashboardRevision()
+	assert.Equal(t, dashboard.ID, storable.DashboardID)
+	assert.Equal(t, 3, storable.Version)
+
+	actual := storable.ToDashboardRevision()
+	assert.Equal(t, revision.ToGettableDashboardRevision(), actual.ToGettableDashboardRevision())
+
+	ops, err := revision.Diff(actual)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+
+	updatable, err := actual.UpdatableDashboardV2()
+	require.NoError(t, err)
+	assert.Equal(t, dashboard.Spec.Display.Name, updatable.Spec.Display.Name)
+	require.Len(t, updatable.Tags, 1)
+	assert.Equal(t, "alpha", updatable.Tags[0].Value)
+}
+
+func TestNewBaselineDashboardRevision(t *testing.T) {
+	var postable PostableDashboardV2
+	require.NoError(t, json.Unmarshal([]byte(basePostableJSON), &postable))
+	dashboard := postable.NewDashboardV2(valuer.GenerateUUID(), "creator@signoz.io", SourceUser)
+
+	baseline, err := NewBaselineDashboardRevision(dashboard)
+	require.NoError(t, err)
+	assert.Equal(t, 1, baseline.Version)
+	assert.Equal(t, DashboardRevisionKindCreate, baseline.Kind)
+
+	dashboard.UpdatedAt = dashboard.UpdatedAt.Add(time.Minute)
+	dashboard.UpdatedBy = "editor@signoz.io"
+	baseline, err = NewBaselineDashboardRevision(dashboard)
+	require.NoError(t, err)
+	assert.Equal(t, DashboardRevisionKindUpdate, baseline.Kind)
+	assert.Equal(t, "editor@signoz.io", baseline.CreatedBy)
+}
@@ -0,0 +1,770 @@
+package dashboardtypes
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qb "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/tagtypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/perses/spec/go/common"
+	"github.com/perses/spec/go/dashboard"
+	"github.com/perses/spec/go/dashboard/variable"
+	"github.com/prometheus/prometheus/model/labels"
+	promparser "github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	// grafanaRowHeight is the height of a row header on the Grafana grid. The
+	// panels of a row start right below it.
+	grafanaRowHeight = 1
+
+	grafanaDatasourcePrometheus = "prometheus"
+	// grafanaDatasourceMixed is the type of the -- Mixed -- datasource, whose
+	// targets each carry their own datasource.
+	grafanaDatasourceMixed = "datasource"
+)
+
+var (
+	ErrCodeDashboardInvalidGrafanaDashboard = errors.MustNewCode("dashboard_invalid_grafana_dashboard")
+
+	grafanaLabelValuesRegex = regexp.MustCompile(`^\s*label_values\(\s*(?:(.+?)\s*,\s*)?([a-zA-Z_][a-zA-Z0-9_]*)\s*\)\s*$`)
+	grafanaTimeFromRegex    = regexp.MustCompile(`^now-([0-9]+[smhdwy])$`)
+	grafanaVariableRefRegex = regexp.MustCompile(`^(?:\$([a-zA-Z_][a-zA-Z0-9_]*)|\$\{([a-zA-Z_][a-zA-Z0-9_]*)(?::[a-z]+)?\}|\[\[([a-zA-Z_][a-zA-Z0-9_]*)\]\])$`)
+
+	grafanaPanelKinds = map[string]PanelPluginKind{
+		"timeseries": PanelKindTimeSeries,
+		"graph":      PanelKindTimeSeries,
+		"barchart":   PanelKindBarChart,
+		"stat":       PanelKindNumber,
+		"singlestat": PanelKindNumber,
+		"gauge":      PanelKindNumber,
+		"piechart":   PanelKindPieChart,
+		"table":      PanelKindTable,
+		"table-old":  PanelKindTable,
+		"heatmap":    PanelKindHistogram,
+		"histogram":  PanelKindHistogram,
+	}
+
+	grafanaLineInterpolations = map[string]LineInterpolation{
+		"linear":     LineInterpolationLinear,
+		"smooth":     LineInterpolationSpline,
+		"stepAfter":  LineInterpolationStepAfter,
+		"stepBefore": LineInterpolationStepBefore,
+	}
+
+	grafanaVariableSorts = map[int]variable.Sort{
+		1: variable.SortAlphabeticalAsc,
+		2: variable.SortAlphabeticalDesc,
+		3: variable.SortNumericalAsc,
+		4: variable.SortNumericalDesc,
+		5: variable.SortAlphabeticalCaseInsensitiveAsc,
+		6: variable.SortAlphabeticalCaseInsensitiveDesc,
+	}
+
+	grafanaPrecisionOptions = []PrecisionOption{PrecisionOption0, PrecisionOption1, PrecisionOption2, PrecisionOption3, PrecisionOption4}
+)
+
+// ════════════════════════════════════════════════════════════════════════
+// Grafana dashboard JSON
+// ════════════════════════════════════════════════════════════════════════
+
+// GrafanaDashboard is the part of the Grafana dashboard JSON model that can be
+// converted to a v2 dashboard. Unknown fields are ignored.
+type GrafanaDashboard struct {
+	Title       string            `json:"title" required:"true"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Time        GrafanaTimeRange  `json:"time"`
+	Panels      []*GrafanaPanel   `json:"panels"`
+	Templating  GrafanaTemplating `json:"templating"`
+}
+
+type GrafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type GrafanaPanel struct {
+	ID          int                `json:"id"`
+	Type        string             `json:"type"`
+	Title       string             `json:"title"`
+	Description string             `json:"description,omitempty"`
+	Datasource  *GrafanaDatasource `json:"datasource,omitempty"`
+	GridPos     GrafanaGridPos     `json:"gridPos"`
+	FieldConfig GrafanaFieldConfig `json:"fieldConfig"`
+	Options     GrafanaOptions     `json:"options"`
+	Targets     []*GrafanaTarget   `json:"targets,omitempty"`
+	// Collapsed and Panels are only set on rows. The panels of a collapsed row
+	// are nested in it, the ones of an expanded row follow it.
+	Collapsed bool            `json:"collapsed,omitempty"`
+	Panels    []*GrafanaPanel `json:"panels,omitempty"`
+}
+
+type GrafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type GrafanaFieldConfig struct {
+	Defaults GrafanaFieldDefaults `json:"defaults"`
+}
+
+type GrafanaFieldDefaults struct {
+	Unit       string            `json:"unit,omitempty"`
+	Decimals   *int              `json:"decimals,omitempty"`
+	Min        *float64          `json:"min,omitempty"`
+	Max        *float64          `json:"max,omitempty"`
+	Thresholds GrafanaThresholds `json:"thresholds"`
+	Custom     GrafanaCustom     `json:"custom"`
+}
+
+type GrafanaThresholds struct {
+	Mode  string                  `json:"mode"`
+	Steps []*GrafanaThresholdStep `json:"steps"`
+}
+
+type GrafanaThresholdStep struct {
+	Color string `json:"color"`
+	// Value is null for the base step.
+	Value *float64 `json:"value"`
+}
+
+type GrafanaCustom struct {
+	DrawStyle         string                 `json:"drawStyle,omitempty"`
+	LineInterpolation string                 `json:"lineInterpolation,omitempty"`
+	FillOpacity       float64                `json:"fillOpacity,omitempty"`
+	Stacking          GrafanaStacking        `json:"stacking"`
+	ThresholdsStyle   GrafanaThresholdsStyle `json:"thresholdsStyle"`
+}
+
+type GrafanaStacking struct {
+	Mode string `json:"mode"`
+}
+
+type GrafanaThresholdsStyle struct {
+	Mode string `json:"mode"`
+}
+
+type GrafanaOptions struct {
+	ColorMode string        `json:"colorMode,omitempty"`
+	Legend    GrafanaLegend `json:"legend"`
+}
+
+type GrafanaLegend struct {
+	Placement string `json:"placement"`
+}
+
+type GrafanaTarget struct {
+	RefID        string             `json:"refId"`
+	Datasource   *GrafanaDatasource `json:"datasource,omitempty"`
+	Expr         string             `json:"expr"`
+	LegendFormat string             `json:"legendFormat,omitempty"`
+	Interval     string             `json:"interval,omitempty"`
+	Hide         bool               `json:"hide,omitempty"`
+}
+
+// GrafanaDatasource is a datasource reference. Dashboards saved before
+// Grafana 8.3 refer to datasources by name, these have no type.
+type GrafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+func (d *GrafanaDatasource) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*d = GrafanaDatasource{UID: name}
+		return nil
+	}
+
+	type alias GrafanaDatasource
+	var tmp alias
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return errors.WrapInvalidInputf(err, ErrCodeDashboardInvalidGrafanaDashboard, "invalid datasource")
+	}
+	*d = GrafanaDatasource(tmp)
+	return nil
+}
+
+type GrafanaTemplating struct {
+	List []*GrafanaVariable `json:"list"`
+}
+
+type GrafanaVariable struct {
+	Name        string                 `json:"name"`
+	Label       string                 `json:"label,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type"`
+	Datasource  *GrafanaDatasource     `json:"datasource,omitempty"`
+	Query       GrafanaVariableQuery   `json:"query"`
+	Regex       string                 `json:"regex,omitempty"`
+	Sort        int                    `json:"sort,omitempty"`
+	Multi       bool                   `json:"multi"`
+	IncludeAll  bool                   `json:"includeAll"`
+	AllValue    string                 `json:"allValue,omitempty"`
+	Hide        int                    `json:"hide"`
+	Current     GrafanaVariableCurrent `json:"current"`
+}
+
+// GrafanaVariableQuery is the query of a variable, a string or an object with
+// the query in it depending on the datasource.
+type GrafanaVariableQuery string
+
+func (q *GrafanaVariableQuery) UnmarshalJSON(data []byte) error {
+	var query string
+	if err := json.Unmarshal(data, &query); err == nil {
+		*q = GrafanaVariableQuery(query)
+		return nil
+	}
+
+	var object struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return errors.WrapInvalidInputf(err, ErrCodeDashboardInvalidGrafanaDashboard, "invalid variable query")
+	}
+	*q = GrafanaVariableQuery(object.Query)
+	return nil
+}
+
+type GrafanaVariableCurrent struct {
+	// Value is a string, or a list of strings for multi value variables.
+	Value any `json:"value"`
+}
+
+// ════════════════════════════════════════════════════════════════════════
+// Import report
+// ════════════════════════════════════════════════════════════════════════
+
+type GrafanaImportReport struct {
+	UnconvertedPanels    []*UnconvertedGrafanaPanel    `json:"unconvertedPanels" required:"true"`
+	UnconvertedVariables []*UnconvertedGrafanaVariable `json:"unconvertedVariables" required:"true"`
+	UnconvertedTags      []string                      `json:"unconvertedTags" required:"true"`
+}
+
+type UnconvertedGrafanaPanel struct {
+	ID     int    `json:"id" required:"true"`
+	Title  string `json:"title" required:"true"`
+	Type   string `json:"type" required:"true"`
+	Reason string `json:"reason" required:"true"`
+}
+
+type UnconvertedGrafanaVariable struct {
+	Name   string `json:"name" required:"true"`
+	Type   string `json:"type" required:"true"`
+	Reason string `json:"reason" required:"true"`
+}
+
+type GettableGrafanaDashboardImport struct {
+	Dashboard GettableDashboardV2 `json:"dashboard" required:"true"`
+	Report    GrafanaImportReport `json:"report" required:"true"`
+}
+
+// ════════════════════════════════════════════════════════════════════════
+// Convertors
+// ════════════════════════════════════════════════════════════════════════
+
+// ToPostableDashboardV2 converts the Grafana dashboard to a v2 dashboard. The
+// dashboard keeps whatever can be converted, the rest is in the report.
+func (g *GrafanaDashboard) ToPostableDashboardV2() (PostableDashboardV2, *GrafanaImportReport, error) {
+	if strings.TrimSpace(g.Title) == "" {
+		return PostableDashboardV2{}, nil, errors.NewInvalidInputf(ErrCodeDashboardInvalidGrafanaDashboard, "title is required")
+	}
+
+	report := &GrafanaImportReport{
+		UnconvertedPanels:    []*UnconvertedGrafanaPanel{},
+		UnconvertedVariables: []*UnconvertedGrafanaVariable{},
+		UnconvertedTags:      []string{},
+	}
+
+	postable := PostableDashboardV2{
+		DashboardV2MetadataBase: DashboardV2MetadataBase{SchemaVersion: SchemaVersion},
+		GenerateName:            true,
+		Tags:                    g.toPostableTags(report),
+		Spec: DashboardSpec{
+			Display:   &common.Display{Name: g.Title, Description: g.Description},
+			Variables: g.toVariables(report),
+			Panels:    map[string]*Panel{},
+			Layouts:   []Layout{},
+			Duration:  g.toDuration(),
+		},
+	}
+	g.toPanelsAndLayouts(&postable.Spec, report)
+
+	if err := postable.Validate(); err != nil {
+		return PostableDashboardV2{}, nil, err
+	}
+
+	return postable, report, nil
+}
+
+// toDuration returns the relative time range of the dashboard, an hour when
+// the range is absolute or not expressible as a duration.
+func (g *GrafanaDashboard) toDuration() common.DurationString {
+	matches := grafanaTimeFromRegex.FindStringSubmatch(g.Time.From)
+	if matches == nil || (g.Time.To != "" && g.Time.To != "now") {
+		return "1h"
+	}
+	return common.DurationString(matches[1])
+}
+
+// toPostableTags converts the `key:value` tags to tags with that key and the
+// other tags to tags with the `tag` key.
+func (g *GrafanaDashboard) toPostableTags(report *GrafanaImportReport) []tagtypes.PostableTag {
+	tags := []tagtypes.PostableTag{}
+	for _, grafanaTag := range g.Tags {
+		tag := tagtypes.PostableTag{Key: "tag", Value: grafanaTag}
+		if key, value, ok := strings.Cut(grafanaTag, ":"); ok {
+			tag = tagtypes.PostableTag{Key: key, Value: value}
+		}
+
+		key, value, err := tagtypes.ValidatePostableTag(tag)
+		if err != nil || len(tags) == MaxTagsPerDashboard || validateDashboardTags([]tagtypes.PostableTag{tag}) != nil {
+			report.UnconvertedTags = append(report.UnconvertedTags, grafanaTag)
+			continue
+		}
+		tags = append(tags, tagtypes.PostableTag{Key: key, Value: value})
+	}
+	return tags
+}
+
+func (g *GrafanaDashboard) toVariables(report *GrafanaImportReport) []Variable {
+	variables := []Variable{}
+	for _, grafanaVariable := range g.Templating.List {
+		v, reason := grafanaVariable.toVariable()
+		if reason != "" {
+			report.UnconvertedVariables = append(report.UnconvertedVariables, &UnconvertedGrafanaVariable{Name: grafanaVariable.Name, Type: grafanaVariable.Type, Reason: reason})
+			continue
+		}
+		variables = append(variables, v)
+	}
+	return variables
+}
+
+// toVariable returns the variable, or the reason it cannot be converted.
+func (v *GrafanaVariable) toVariable() (Variable, string) {
+	if err := common.ValidateID(v.Name); err != nil {
+		return Variable{}, fmt.Sprintf("invalid variable name: %s", err.Error())
+	}
+
+	display := &variable.Display{Name: cmp.Or(v.Label, v.Name), Description: v.Description, Hidden: v.Hide == 2}
+
+	var plugin VariablePlugin
+	switch v.Type {
+	case "textbox", "constant":
+		return Variable{
+			Kind: variable.KindText,
+			Spec: &dashboard.TextVariableSpec{
+				TextSpec: variable.TextSpec{Display: display, Value: string(v.Query), Constant: v.Type == "constant"},
+				Name:     v.Name,
+			},
+		}, ""
+	case "custom":
+		plugin = VariablePlugin{Kind: VariableKindCustom, Spec: &CustomVariableSpec{CustomValue: string(v.Query)}}
+	case "query":
+		if !v.Datasource.isPrometheus() {
+			return Variable{}, fmt.Sprintf("datasource %q is not supported, only prometheus queries can be converted", v.Datasource.Type)
+		}
+		matches := grafanaLabelValuesRegex.FindStringSubmatch(string(v.Query))
+		if matches == nil {
+			return Variable{}, "only label_values() queries can be converted"
+		}
+		if matches[1] == "" {
+			plugin = VariablePlugin{Kind: VariableKindDynamic, Spec: &DynamicVariableSpec{Name: matches[2], Signal: telemetrytypes.SignalMetrics}}
+			break
+		}
+		// The values of a label of some series only are fetched with a query
+		// variable, the dynamic variables fetch the values of all the series.
+		query, reason := grafanaLabelValuesQuery(matches[1], matches[2])
+		if reason != "" {
+			return Variable{}, reason
+		}
+		plugin = VariablePlugin{Kind: VariableKindQuery, Spec: &QueryVariableSpec{QueryValue: query}}
+	default:
+		return Variable{}, fmt.Sprintf("variable type %q has no SigNoz equivalent", v.Type)
+	}
+
+	spec := &ListVariableSpec{
+		Display:         display,
+		DefaultValue:    v.Current.toDefaultValue(),
+		AllowAllValue:   v.IncludeAll,
+		AllowMultiple:   v.Multi,
+		CustomAllValue:  v.AllValue,
+		CapturingRegexp: strings.TrimSuffix(strings.TrimPrefix(v.Regex, "/"), "/"),
+		Plugin:          plugin,
+		Name:            v.Name,
+	}
+	if sort, ok := grafanaVariableSorts[v.Sort]; ok {
+		spec.Sort = &sort
+	}
+
+	return Variable{Kind: variable.KindList, Spec: spec}, ""
+}
+
+// grafanaLabelValuesQuery returns the ClickHouse query of the values of the
+// label over the series of the selector. Matchers on another variable filter
+// on its values. It returns the reason the selector cannot be converted
+// instead when it cannot.
+func grafanaLabelValuesQuery(selector string, label string) (string, string) {
+	matchers, err := promparser.NewParser(promparser.Options{}).ParseMetricSelector(selector)
+	if err != nil {
+		return "", fmt.Sprintf("invalid label_values() selector %q", selector)
+	}
+
+	// The metric name goes first, it is in the primary key of the table.
+	slices.SortStableFunc(matchers, func(a, b *labels.Matcher) int {
+		switch {
+		case a.Name == labels.MetricName && b.Name != labels.MetricName:
+			return -1
+		case a.Name != labels.MetricName && b.Name == labels.MetricName:
+			return 1
+		}
+		return 0
+	})
+
+	conditions := []string{}
+	for _, matcher := range matchers {
+		column := "JSONExtractString(labels, " + grafanaQuote(matcher.Name) + ")"
+		if matcher.Name == labels.MetricName {
+			column = "metric_name"
+		}
+
+		if ref := grafanaVariableRefRegex.FindStringSubmatch(matcher.Value); ref != nil {
+			name := cmp.Or(ref[1], ref[2], ref[3])
+			switch matcher.Type {
+			case labels.MatchEqual, labels.MatchRegexp:
+				conditions = append(conditions, column+" IN {{."+name+"}}")
+			case labels.MatchNotEqual, labels.MatchNotRegexp:
+				conditions = append(conditions, column+" NOT IN {{."+name+"}}")
+			}
+			continue
+		}
+		if strings.ContainsAny(matcher.Value, "$[") {
+			return "", fmt.Sprintf("label_values() matcher %q mixes a variable with other text", matcher.String())
+		}
+
+		switch matcher.Type {
+		case labels.MatchEqual:
+			conditions = append(conditions, column+" = "+grafanaQuote(matcher.Value))
+		case labels.MatchNotEqual:
+			conditions = append(conditions, column+" != "+grafanaQuote(matcher.Value))
+		case labels.MatchRegexp:
+			conditions = append(conditions, "match("+column+", "+grafanaQuote("^(?:"+matcher.Value+")$")+")")
+		case labels.MatchNotRegexp:
+			conditions = append(conditions, "NOT match("+column+", "+grafanaQuote("^(?:"+matcher.Value+")$")+")")
+		}
+	}
+
+	return fmt.Sprintf(
+		"SELECT DISTINCT JSONExtractString(labels, %s) AS %s FROM signoz_metrics.distributed_time_series_v4_1day WHERE %s ORDER BY %s",
+		grafanaQuote(label), label, strings.Join(conditions, " AND "), label,
+	), ""
+}
+
+// grafanaQuote returns s as a ClickHouse string literal.
+func grafanaQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// toDefaultValue returns the current value of the variable, nil when it is
+// the All value or empty.
+func (c GrafanaVariableCurrent) toDefaultValue() *variable.DefaultValue {
+	switch value := c.Value.(type) {
+	case string:
+		if value == "" || value == "$__all" {
+			return nil
+		}
+		return &variable.DefaultValue{SingleValue: value}
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			s, ok := item.(string)
+			if !ok || s == "$__all" {
+				return nil
+			}
+			values = append(values, s)
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		return &variable.DefaultValue{SliceValues: values}
+	}
+	return nil
+}
+
+// toPanelsAndLayouts converts the panels and lays them out in one grid per
+// Grafana row, the panels before the first row going in a grid without title.
+func (g *GrafanaDashboard) toPanelsAndLayouts(spec *DashboardSpec, report *GrafanaImportReport) {
+	panels := slices.Clone(g.Panels)
+	panels = slices.DeleteFunc(panels, func(p *GrafanaPanel) bool { return p == nil })
+	slices.SortStableFunc(panels, func(a, b *GrafanaPanel) int {
+		return cmp.Or(cmp.Compare(a.GridPos.Y, b.GridPos.Y), cmp.Compare(a.GridPos.X, b.GridPos.X))
+	})
+
+	var grid *dashboard.GridLayoutSpec
+	top := 0
+	addPanel := func(grafanaPanel *GrafanaPanel) {
+		panel, reason := grafanaPanel.toPanel()
+		if reason != "" {
+			report.UnconvertedPanels = append(report.UnconvertedPanels, &UnconvertedGrafanaPanel{ID: grafanaPanel.ID, Title: grafanaPanel.Title, Type: grafanaPanel.Type, Reason: reason})
+			return
+		}
+
+		key := grafanaPanelKey(spec.Panels, grafanaPanel.ID)
+		spec.Panels[key] = panel
+
+		if grid == nil {
+			grid = &dashboard.GridLayoutSpec{Items: []dashboard.GridItem{}}
+			spec.Layouts = append(spec.Layouts, Layout{Kind: dashboard.KindGridLayout, Spec: grid})
+		}
+		grid.Items = append(grid.Items, dashboard.GridItem{
+			X:       grafanaPanel.GridPos.X,
+			Y:       max(grafanaPanel.GridPos.Y-top, 0),
+			Width:   grafanaPanel.GridPos.W,
+			Height:  grafanaPanel.GridPos.H,
+			Content: &common.JSONRef{Ref: "#/spec/panels/" + key},
+		})
+	}
+
+	for _, grafanaPanel := range panels {
+		if grafanaPanel.Type != "row" {
+			addPanel(grafanaPanel)
+			continue
+		}
+
+		grid = &dashboard.GridLayoutSpec{
+			Display: &dashboard.GridLayoutDisplay{
+				Title:    grafanaPanel.Title,
+				Collapse: &dashboard.GridLayoutCollapse{Open: !grafanaPanel.Collapsed},
+			},
+			Items: []dashboard.GridItem{},
+		}
+		spec.Layouts = append(spec.Layouts, Layout{Kind: dashboard.KindGridLayout, Spec: grid})
+		top = grafanaPanel.GridPos.Y + grafanaRowHeight
+
+		// The panels of a collapsed row keep the position they had when
+		// the row was expanded.
+		for _, nested := range grafanaPanel.Panels {
+			if nested != nil {
+				addPanel(nested)
+			}
+		}
+	}
+}
+
+// grafanaPanelKey returns a key for the panel that is not yet taken.
+func grafanaPanelKey(panels map[string]*Panel, id int) string {
+	key := "panel-" + strconv.Itoa(id)
+	for i := 1; panels[key] != nil; i++ {
+		key = "panel-" + strconv.Itoa(id) + "-" + strconv.Itoa(i)
+	}
+	return key
+}
+
+// toPanel returns the panel, or the reason it cannot be converted.
+func (p *GrafanaPanel) toPanel() (*Panel, string) {
+	kind, ok := grafanaPanelKinds[p.Type]
+	if !ok {
+		return nil, fmt.Sprintf("panel type %q has no SigNoz equivalent", p.Type)
+	}
+	// Bars are drawn by the bar chart panel.
+	if kind == PanelKindTimeSeries && p.FieldConfig.Defaults.Custom.DrawStyle == "bars" {
+		kind = PanelKindBarChart
+	}
+	if !slices.Contains(allowedQueryKinds[kind], QueryKindPromQL) {
+		return nil, fmt.Sprintf("panel type %q does not support PromQL queries", p.Type)
+	}
+
+	// The targets without a ref id, or with the ref id of a previous target,
+	// get a name that no other target has.
+	names := map[string]bool{}
+	for _, target := range p.Targets {
+		if target != nil {
+			names[target.RefID] = true
+		}
+	}
+	converted := map[string]bool{}
+
+	queries := []QueryEnvelope{}
+	for _, target := range p.Targets {
+		if target == nil || strings.TrimSpace(target.Expr) == "" {
+			continue
+		}
+
+		datasource := p.Datasource
+		if target.Datasource != nil {
+			datasource = target.Datasource
+		}
+		if !datasource.isPrometheus() {
+			return nil, fmt.Sprintf("datasource %q is not supported, only prometheus targets can be converted", datasource.Type)
+		}
+
+		name := target.RefID
+		if name == "" || converted[name] {
+			name = grafanaQueryName(names)
+		}
+		names[name] = true
+		converted[name] = true
+		queries = append(queries, QueryEnvelope{Type: qb.QueryTypePromQL, Spec: target.toPromQuery(name)})
+	}
+	if len(queries) == 0 {
+		return nil, "panel has no PromQL targets"
+	}
+
+	plugin := QueryPlugin{Kind: QueryKindComposite, Spec: &CompositeQuerySpec{Queries: queries}}
+	if len(queries) == 1 {
+		plugin = QueryPlugin{Kind: QueryKindPromQL, Spec: queries[0].Spec}
+	}
+
+	return &Panel{
+		Kind: PanelKindPanel,
+		Spec: PanelSpec{
+			Display: &dashboard.PanelDisplay{Name: p.Title, Description: p.Description},
+			Plugin:  PanelPlugin{Kind: kind, Spec: p.toPanelPluginSpec(kind)},
+			Queries: []Query{{Kind: qb.RequestTypeTimeSeries, Spec: QuerySpec{Plugin: plugin}}},
+		},
+	}, ""
+}
+
+func (p *GrafanaPanel) toPanelPluginSpec(kind PanelPluginKind) any {
+	defaults := p.FieldConfig.Defaults
+	formatting := PanelFormatting{Unit: defaults.Unit}
+	if defaults.Decimals != nil && *defaults.Decimals >= 0 && *defaults.Decimals < len(grafanaPrecisionOptions) {
+		formatting.DecimalPrecision = grafanaPrecisionOptions[*defaults.Decimals]
+	}
+	axes := Axes{SoftMin: defaults.Min, SoftMax: defaults.Max}
+	legend := Legend{}
+	if p.Options.Legend.Placement == "right" {
+		legend.Position = LegendPositionRight
+	}
+
+	switch kind {
+	case PanelKindBarChart:
+		return &BarChartPanelSpec{
+			Visualization: BarChartVisualization{StackedBarChart: defaults.Custom.Stacking.Mode == "normal" || defaults.Custom.Stacking.Mode == "percent"},
+			Formatting:    formatting,
+			Axes:          axes,
+			Legend:        legend,
+			Thresholds:    p.toThresholdsWithLabel(),
+		}
+	case PanelKindNumber:
+		return &NumberPanelSpec{
+			Formatting: formatting,
+			Thresholds: p.toComparisonThresholds(),
+		}
+	case PanelKindHistogram:
+		return &HistogramPanelSpec{Legend: legend}
+	default:
+		fillMode := FillModeNone
+		if defaults.Custom.FillOpacity > 0 {
+			fillMode = FillModeSolid
+		}
+		return &TimeSeriesPanelSpec{
+			Formatting: formatting,
+			ChartAppearance: TimeSeriesChartAppearance{
+				LineInterpolation: grafanaLineInterpolations[defaults.Custom.LineInterpolation],
+				ShowPoints:        defaults.Custom.DrawStyle == "points",
+				FillMode:          fillMode,
+			},
+			Axes:       axes,
+			Legend:     legend,
+			Thresholds: p.toThresholdsWithLabel(),
+		}
+	}
+}
+
+// toThresholdsWithLabel returns the absolute thresholds drawn on the chart.
+// Grafana only draws them when the threshold style is set.
+func (p *GrafanaPanel) toThresholdsWithLabel() []ThresholdWithLabel {
+	defaults := p.FieldConfig.Defaults
+	mode := defaults.Custom.ThresholdsStyle.Mode
+	if mode == "" || mode == "off" {
+		return nil
+	}
+
+	thresholds := []ThresholdWithLabel{}
+	for _, step := range defaults.Thresholds.absoluteSteps() {
+		value := strconv.FormatFloat(*step.Value, 'f', -1, 64)
+		thresholds = append(thresholds, ThresholdWithLabel{Value: *step.Value, Unit: defaults.Unit, Color: step.Color, Label: value})
+	}
+	return thresholds
+}
+
+// toComparisonThresholds returns the absolute thresholds coloring the value,
+// each applying from its value up.
+func (p *GrafanaPanel) toComparisonThresholds() []ComparisonThreshold {
+	defaults := p.FieldConfig.Defaults
+	format := ThresholdFormatText
+	if p.Options.ColorMode == "background" {
+		format = ThresholdFormatBackground
+	}
+
+	thresholds := []ComparisonThreshold{}
+	for _, step := range defaults.Thresholds.absoluteSteps() {
+		thresholds = append(thresholds, ComparisonThreshold{Value: *step.Value, Operator: ComparisonOperatorAboveOrEqual, Unit: defaults.Unit, Color: step.Color, Format: format})
+	}
+	return thresholds
+}
+
+// absoluteSteps returns the steps with a value, leaving out the base step.
+// Zero is left out as well as thresholds require a value.
+func (t GrafanaThresholds) absoluteSteps() []*GrafanaThresholdStep {
+	if t.Mode == "percentage" {
+		return nil
+	}
+
+	steps := []*GrafanaThresholdStep{}
+	for _, step := range t.Steps {
+		if step == nil || step.Value == nil || *step.Value == 0 || step.Color == "" {
+			continue
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// grafanaQueryName returns the first of A to Z, then A1 to Z1 and so on, that
+// is not yet taken.
+func grafanaQueryName(names map[string]bool) string {
+	for i := 0; ; i++ {
+		name := string(rune('A' + i%26))
+		if i >= 26 {
+			name += strconv.Itoa(i / 26)
+		}
+		if !names[name] {
+			return name
+		}
+	}
+}
+
+func (t *GrafanaTarget) toPromQuery(name string) *PromQLQuerySpec {
+	query := &PromQLQuerySpec{
+		Name:     name,
+		Query:    t.Expr,
+		Disabled: t.Hide,
+	}
+	if t.LegendFormat != "__auto" {
+		query.Legend = t.LegendFormat
+	}
+	// The min interval can be a variable, such as $__interval.
+	if step, err := time.ParseDuration(t.Interval); err == nil {
+		query.Step = qb.Step{Duration: step}
+	}
+	return query
+}
+
+// isPrometheus tells whether the datasource can be a prometheus one. Name
+// references and the mixed datasource are taken as prometheus.
+func (d *GrafanaDatasource) isPrometheus() bool {
+	return d == nil || d.Type == "" || d.Type == grafanaDatasourcePrometheus || d.Type == grafanaDatasourceMixed
+}
@@ -0,0 +1,298 @@
+package dashboardtypes
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qb "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/tagtypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/perses/spec/go/common"
+	"github.com/perses/spec/go/dashboard"
+	"github.com/perses/spec/go/dashboard/variable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadGrafanaDashboard(t *testing.T) *GrafanaDashboard {
+	t.Helper()
+
+	raw, err := os.ReadFile("testdata/grafana.json")
+	require.NoError(t, err)
+
+	grafana := new(GrafanaDashboard)
+	require.NoError(t, json.Unmarshal(raw, grafana))
+	return grafana
+}
+
+func TestGrafanaDashboardToPostableDashboardV2(t *testing.T) {
+	postable, report, err := loadGrafanaDashboard(t).ToPostableDashboardV2()
+	require.NoError(t, err)
+
+	assert.Equal(t, SchemaVersion, postable.SchemaVersion)
+	assert.True(t, postable.GenerateName)
+	assert.Equal(t, &common.Display{Name: "Node exporter"}, postable.Spec.Display)
+	assert.Equal(t, common.DurationString("6h"), postable.Spec.Duration)
+	assert.Equal(t, []tagtypes.PostableTag{{Key: "tag", Value: "kubernetes"}, {Key: "team", Value: "infra"}}, postable.Tags)
+
+	assert.Equal(t, []*UnconvertedGrafanaPanel{
+		{ID: 3, Title: "Notes", Type: "text", Reason: `panel type "text" has no SigNoz equivalent`},
+		{ID: 6, Title: "Top instances", Type: "table", Reason: `panel type "table" does not support PromQL queries`},
+		{ID: 8, Title: "Errors", Type: "logs", Reason: `panel type "logs" has no SigNoz equivalent`},
+		{ID: 9, Title: "Error rate", Type: "timeseries", Reason: `datasource "loki" is not supported, only prometheus targets can be converted`},
+	}, report.UnconvertedPanels)
+	assert.Equal(t, []*UnconvertedGrafanaVariable{
+		{Name: "datasource", Type: "datasource", Reason: `variable type "datasource" has no SigNoz equivalent`},
+		{Name: "metric", Type: "query", Reason: "only label_values() queries can be converted"},
+		{Name: "interval", Type: "interval", Reason: `variable type "interval" has no SigNoz equivalent`},
+		{Name: "stream", Type: "query", Reason: `datasource "loki" is not supported, only prometheus queries can be converted`},
+	}, report.UnconvertedVariables)
+	assert.Equal(t, []string{"not a tag"}, report.UnconvertedTags)
+
+	t.Run("Panels", func(t *testing.T) {
+		require.Len(t, postable.Spec.Panels, 4)
+
+		memory := postable.Spec.Panels["panel-1"]
+		require.NotNil(t, memory)
+		assert.Equal(t, &dashboard.PanelDisplay{Name: "Memory", Description: "Resident memory per instance"}, memory.Spec.Display)
+		assert.Equal(t, PanelKindTimeSeries, memory.Spec.Plugin.Kind)
+		minimum := 0.0
+		assert.Equal(t, &TimeSeriesPanelSpec{
+			Formatting: PanelFormatting{Unit: "bytes"},
+			ChartAppearance: TimeSeriesChartAppearance{
+				LineInterpolation: LineInterpolationSpline,
+				FillMode:          FillModeSolid,
+			},
+			Axes:       Axes{SoftMin: &minimum},
+			Legend:     Legend{Position: LegendPositionRight},
+			Thresholds: []ThresholdWithLabel{{Value: 1073741824, Unit: "bytes", Color: "red", Label: "1073741824"}},
+		}, memory.Spec.Plugin.Spec)
+		require.Len(t, memory.Spec.Queries, 1)
+		assert.Equal(t, qb.RequestTypeTimeSeries, memory.Spec.Queries[0].Kind)
+		assert.Equal(t, QueryPlugin{
+			Kind: QueryKindComposite,
+			Spec: &CompositeQuerySpec{Queries: []QueryEnvelope{
+				{Type: qb.QueryTypePromQL, Spec: &PromQLQuerySpec{Name: "A", Query: `process_resident_memory_bytes{job=~"$job"}`, Legend: "{{instance}}", Step: qb.Step{Duration: 30 * time.Second}}},
+				{Type: qb.QueryTypePromQL, Spec: &PromQLQuerySpec{Name: "B", Query: `sum(process_resident_memory_bytes{job=~"$job"})`, Disabled: true}},
+			}},
+		}, memory.Spec.Queries[0].Spec.Plugin)
+
+		up := postable.Spec.Panels["panel-2"]
+		require.NotNil(t, up)
+		assert.Equal(t, PanelKindNumber, up.Spec.Plugin.Kind)
+		assert.Equal(t, &NumberPanelSpec{
+			Formatting: PanelFormatting{Unit: "percent", DecimalPrecision: PrecisionOption1},
+			Thresholds: []ComparisonThreshold{
+				{Value: 70, Operator: ComparisonOperatorAboveOrEqual, Unit: "percent", Color: "orange", Format: ThresholdFormatBackground},
+				{Value: 90, Operator: ComparisonOperatorAboveOrEqual, Unit: "percent", Color: "red", Format: ThresholdFormatBackground},
+			},
+		}, up.Spec.Plugin.Spec)
+		assert.Equal(t, QueryPlugin{Kind: QueryKindPromQL, Spec: &PromQLQuerySpec{Name: "A", Query: `avg(up{job=~"$job"}) * 100`}}, up.Spec.Queries[0].Spec.Plugin)
+
+		cpu := postable.Spec.Panels["panel-5"]
+		require.NotNil(t, cpu)
+		assert.Equal(t, PanelKindBarChart, cpu.Spec.Plugin.Kind)
+		assert.True(t, cpu.Spec.Plugin.Spec.(*BarChartPanelSpec).Visualization.StackedBarChart)
+		// $__rate_interval and $__interval are rendered by the querier, the
+		// step is left to it.
+		assert.Equal(t, &PromQLQuerySpec{Name: "A", Query: "sum by (mode) (rate(process_cpu_seconds_total[$__rate_interval]))"}, cpu.Spec.Queries[0].Spec.Plugin.Spec)
+
+		latency := postable.Spec.Panels["panel-10"]
+		require.NotNil(t, latency)
+		assert.Equal(t, PanelKindHistogram, latency.Spec.Plugin.Kind)
+	})
+
+	t.Run("Layouts", func(t *testing.T) {
+		item := func(key string, x, y, w, h int) dashboard.GridItem {
+			return dashboard.GridItem{X: x, Y: y, Width: w, Height: h, Content: &common.JSONRef{Ref: "#/spec/panels/" + key}}
+		}
+
+		assert.Equal(t, []Layout{
+			{Kind: dashboard.KindGridLayout, Spec: &dashboard.GridLayoutSpec{
+				Items: []dashboard.GridItem{item("panel-1", 0, 0, 12, 8), item("panel-2", 12, 0, 12, 8)},
+			}},
+			{Kind: dashboard.KindGridLayout, Spec: &dashboard.GridLayoutSpec{
+				Display: &dashboard.GridLayoutDisplay{Title: "Resources", Collapse: &dashboard.GridLayoutCollapse{Open: true}},
+				Items:   []dashboard.GridItem{item("panel-5", 0, 0, 12, 8)},
+			}},
+			{Kind: dashboard.KindGridLayout, Spec: &dashboard.GridLayoutSpec{
+				Display: &dashboard.GridLayoutDisplay{Title: "Logs", Collapse: &dashboard.GridLayoutCollapse{Open: false}},
+				Items:   []dashboard.GridItem{item("panel-10", 12, 8, 12, 8)},
+			}},
+		}, postable.Spec.Layouts)
+	})
+
+	t.Run("Variables", func(t *testing.T) {
+		all := variable.SortAlphabeticalAsc
+		assert.Equal(t, []Variable{
+			{Kind: variable.KindList, Spec: &ListVariableSpec{
+				Display:        &variable.Display{Name: "Job"},
+				AllowAllValue:  true,
+				AllowMultiple:  true,
+				CustomAllValue: ".*",
+				Sort:           &all,
+				Plugin: VariablePlugin{Kind: VariableKindQuery, Spec: &QueryVariableSpec{
+					QueryValue: "SELECT DISTINCT JSONExtractString(labels, 'job') AS job FROM signoz_metrics.distributed_time_series_v4_1day WHERE metric_name = 'up' ORDER BY job",
+				}},
+				Name: "job",
+			}},
+			{Kind: variable.KindList, Spec: &ListVariableSpec{
+				Display:         &variable.Display{Name: "instance"},
+				DefaultValue:    &variable.DefaultValue{SingleValue: "node-1:9100"},
+				CapturingRegexp: ".*:9100",
+				Plugin: VariablePlugin{Kind: VariableKindQuery, Spec: &QueryVariableSpec{
+					QueryValue: "SELECT DISTINCT JSONExtractString(labels, 'instance') AS instance FROM signoz_metrics.distributed_time_series_v4_1day WHERE metric_name = 'up' AND JSONExtractString(labels, 'job') IN {{.job}} ORDER BY instance",
+				}},
+				Name: "instance",
+			}},
+			{Kind: variable.KindList, Spec: &ListVariableSpec{
+				Display:       &variable.Display{Name: "env"},
+				DefaultValue:  &variable.DefaultValue{SliceValues: []string{"prod", "staging"}},
+				AllowMultiple: true,
+				Plugin:        VariablePlugin{Kind: VariableKindCustom, Spec: &CustomVariableSpec{CustomValue: "prod,staging"}},
+				Name:          "env",
+			}},
+			{Kind: variable.KindText, Spec: &dashboard.TextVariableSpec{
+				TextSpec: variable.TextSpec{Display: &variable.Display{Name: "filter"}, Value: "api"},
+				Name:     "filter",
+			}},
+			{Kind: variable.KindText, Spec: &dashboard.TextVariableSpec{
+				TextSpec: variable.TextSpec{Display: &variable.Display{Name: "cluster", Hidden: true}, Value: "eu-west-1", Constant: true},
+				Name:     "cluster",
+			}},
+		}, postable.Spec.Variables)
+	})
+
+	t.Run("StorageRoundTrip", func(t *testing.T) {
+		marshaled, err := json.Marshal(postable)
+		require.NoError(t, err)
+
+		var roundtripped PostableDashboardV2
+		require.NoError(t, json.Unmarshal(marshaled, &roundtripped))
+		assert.Len(t, roundtripped.Spec.Panels, 4)
+		assert.Len(t, roundtripped.Spec.Variables, 5)
+		assert.Len(t, roundtripped.Spec.Layouts, 3)
+	})
+}
+
+func TestGrafanaDashboardToPostableDashboardV2Duration(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     GrafanaTimeRange
+		expected common.DurationString
+	}{
+		{name: "Relative", time: GrafanaTimeRange{From: "now-7d", To: "now"}, expected: "7d"},
+		{name: "NoTo", time: GrafanaTimeRange{From: "now-15m"}, expected: "15m"},
+		{name: "Empty", time: GrafanaTimeRange{}, expected: "1h"},
+		{name: "Absolute", time: GrafanaTimeRange{From: "2024-01-01T00:00:00.000Z", To: "2024-01-02T00:00:00.000Z"}, expected: "1h"},
+		{name: "EndingInThePast", time: GrafanaTimeRange{From: "now-2d", To: "now-1d"}, expected: "1h"},
+		{name: "Months", time: GrafanaTimeRange{From: "now-1M", To: "now"}, expected: "1h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grafana := &GrafanaDashboard{Title: "Durations", Time: tt.time}
+			postable, _, err := grafana.ToPostableDashboardV2()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, postable.Spec.Duration)
+		})
+	}
+}
+
+func TestGrafanaDashboardToPostableDashboardV2WithoutPanels(t *testing.T) {
+	grafana := &GrafanaDashboard{}
+	require.NoError(t, json.Unmarshal([]byte(`{"title": "Empty", "panels": [{"id": 1, "type": "row", "title": "Nothing here", "gridPos": {"y": 0}}]}`), grafana))
+
+	postable, report, err := grafana.ToPostableDashboardV2()
+	require.NoError(t, err)
+	assert.Empty(t, postable.Spec.Panels)
+	require.Len(t, postable.Spec.Layouts, 1)
+	assert.Empty(t, postable.Spec.Layouts[0].Spec.(*dashboard.GridLayoutSpec).Items)
+	assert.Empty(t, report.UnconvertedPanels)
+}
+
+func TestGrafanaDashboardToPostableDashboardV2DuplicatePanelIDs(t *testing.T) {
+	grafana := &GrafanaDashboard{Title: "Duplicates", Panels: []*GrafanaPanel{
+		{ID: 1, Type: "timeseries", GridPos: GrafanaGridPos{X: 0, Y: 0, W: 12, H: 8}, Targets: []*GrafanaTarget{{RefID: "A", Expr: "up"}}},
+		{ID: 1, Type: "timeseries", GridPos: GrafanaGridPos{X: 12, Y: 0, W: 12, H: 8}, Targets: []*GrafanaTarget{{Expr: "up"}}},
+	}}
+
+	postable, _, err := grafana.ToPostableDashboardV2()
+	require.NoError(t, err)
+	assert.Contains(t, postable.Spec.Panels, "panel-1")
+	assert.Contains(t, postable.Spec.Panels, "panel-1-1")
+	assert.Equal(t, "A", postable.Spec.Panels["panel-1-1"].Spec.Queries[0].Spec.Plugin.Spec.(*PromQLQuerySpec).Name)
+}
+
+func TestGrafanaDashboardToPostableDashboardV2QueryNames(t *testing.T) {
+	grafana := &GrafanaDashboard{Title: "Names", Panels: []*GrafanaPanel{
+		{ID: 1, Type: "timeseries", GridPos: GrafanaGridPos{W: 12, H: 8}, Targets: []*GrafanaTarget{
+			{Expr: "up"},
+			{RefID: "A", Expr: "up"},
+			{RefID: "A", Expr: "up"},
+			{Expr: "up"},
+		}},
+	}}
+
+	postable, _, err := grafana.ToPostableDashboardV2()
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, query := range postable.Spec.Panels["panel-1"].Spec.Queries[0].Spec.Plugin.Spec.(*CompositeQuerySpec).Queries {
+		names = append(names, query.Spec.(*PromQLQuerySpec).Name)
+	}
+	// The targets without a ref id do not take the ref id of a later target.
+	assert.Equal(t, []string{"B", "A", "C", "D"}, names)
+}
+
+func TestGrafanaVariableToVariableLabelValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected VariablePlugin
+		reason   string
+	}{
+		{
+			name:     "Label",
+			query:    "label_values(job)",
+			expected: VariablePlugin{Kind: VariableKindDynamic, Spec: &DynamicVariableSpec{Name: "job", Signal: telemetrytypes.SignalMetrics}},
+		},
+		{
+			name:  "Matchers",
+			query: `label_values({__name__="http.server.duration", env!="dev", route=~"/api/.*", pod!~"$pod"}, service)`,
+			expected: VariablePlugin{Kind: VariableKindQuery, Spec: &QueryVariableSpec{
+				QueryValue: "SELECT DISTINCT JSONExtractString(labels, 'service') AS service FROM signoz_metrics.distributed_time_series_v4_1day WHERE metric_name = 'http.server.duration' AND JSONExtractString(labels, 'env') != 'dev' AND match(JSONExtractString(labels, 'route'), '^(?:/api/.*)$') AND JSONExtractString(labels, 'pod') NOT IN {{.pod}} ORDER BY service",
+			}},
+		},
+		{
+			name:   "VariableWithinValue",
+			query:  `label_values(up{job="$env-api"}, instance)`,
+			reason: `label_values() matcher "job=\"$env-api\"" mixes a variable with other text`,
+		},
+		{
+			name:   "InvalidSelector",
+			query:  `label_values(up{, instance)`,
+			reason: `invalid label_values() selector "up{"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grafanaVariable := &GrafanaVariable{Name: "v", Type: "query", Query: GrafanaVariableQuery(tt.query)}
+			v, reason := grafanaVariable.toVariable()
+			assert.Equal(t, tt.reason, reason)
+			if tt.reason == "" {
+				assert.Equal(t, tt.expected, v.Spec.(*ListVariableSpec).Plugin)
+			}
+		})
+	}
+}
+
+func TestGrafanaDashboardToPostableDashboardV2WithoutTitle(t *testing.T) {
+	_, _, err := (&GrafanaDashboard{}).ToPostableDashboardV2()
+	require.Error(t, err)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
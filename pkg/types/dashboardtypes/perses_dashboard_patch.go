@@ -37,6 +37,19 @@ type JSONPatchOperation struct {
 	From string `json:"from,omitempty" description:"Source JSON Pointer for move/copy ops; ignored for other ops."`
 }
 
+// MarshalJSON keeps a null `value` for the ops which require one, which omitempty would drop.
+func (op JSONPatchOperation) MarshalJSON() ([]byte, error) {
+	type alias JSONPatchOperation
+	if op.Value == nil && (op.Op == PatchOpAdd || op.Op == PatchOpReplace || op.Op == PatchOpTest) {
+		return json.Marshal(struct {
+			alias
+			Value any `json:"value"`
+		}{alias: alias(op)})
+	}
+
+	return json.Marshal(alias(op))
+}
+
 // PatchOp covers the six RFC 6902 JSON Patch verbs.
 type PatchOp struct{ valuer.String }
 
@@ -31,5 +31,17 @@ type Store interface {
 
 	DeletePublic(context.Context, string) error
 
+	CreateRevision(context.Context, *StorableDashboardRevision) error
+
+	GetRevision(context.Context, valuer.UUID, valuer.UUID, int) (*StorableDashboardRevision, error)
+
+	GetLatestRevision(context.Context, valuer.UUID, valuer.UUID) (*StorableDashboardRevision, error)
+
+	// ListRevisions returns the revisions of the dashboard, latest first.
+	ListRevisions(context.Context, valuer.UUID, valuer.UUID) ([]*StorableDashboardRevision, error)
+
+	// DeleteRevisionsBefore deletes the revisions of the dashboard older than the given version.
+	DeleteRevisionsBefore(context.Context, valuer.UUID, valuer.UUID, int) error
+
 	RunInTx(context.Context, func(context.Context) error) error
 }
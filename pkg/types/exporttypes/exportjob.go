@@ -0,0 +1,150 @@
+package exporttypes
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeExportJobNotFound      = errors.MustNewCode("export_job_not_found")
+	ErrCodeExportJobAlreadyExists = errors.MustNewCode("export_job_already_exists")
+	ErrCodeExportJobInvalidInput  = errors.MustNewCode("export_job_invalid_input")
+	ErrCodeExportJobNotFinished   = errors.MustNewCode("export_job_not_finished")
+)
+
+type ExportJobStatus struct {
+	valuer.String
+}
+
+var (
+	ExportJobStatusQueued    = ExportJobStatus{valuer.NewString("queued")}
+	ExportJobStatusRunning   = ExportJobStatus{valuer.NewString("running")}
+	ExportJobStatusSucceeded = ExportJobStatus{valuer.NewString("succeeded")}
+	ExportJobStatusFailed    = ExportJobStatus{valuer.NewString("failed")}
+	ExportJobStatusCancelled = ExportJobStatus{valuer.NewString("cancelled")}
+)
+
+func (ExportJobStatus) Enum() []any {
+	return []any{ExportJobStatusQueued, ExportJobStatusRunning, ExportJobStatusSucceeded, ExportJobStatusFailed, ExportJobStatusCancelled}
+}
+
+// IsFinished returns true if the job has reached a terminal status.
+func (status ExportJobStatus) IsFinished() bool {
+	return status == ExportJobStatusSucceeded || status == ExportJobStatusFailed || status == ExportJobStatusCancelled
+}
+
+type ExportJob struct {
+	bun.BaseModel `bun:"table:export_job,alias:export_job" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID   valuer.UUID               `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	Status  ExportJobStatus           `bun:"status,type:text,notnull" json:"status" required:"true"`
+	Format  string                    `bun:"format,type:text,notnull" json:"format" required:"true"`
+	Request qbtypes.QueryRangeRequest `bun:"request,type:text,notnull" json:"request" required:"true"`
+	// RowLimit is the maximum number of rows exported by the job, progress is reported against it.
+	RowLimit int   `bun:"row_limit,notnull" json:"rowLimit" required:"true"`
+	RowCount int64 `bun:"row_count,notnull,default:0" json:"rowCount" required:"true"`
+	Size     int64 `bun:"size,notnull,default:0" json:"size" required:"true"`
+	// Complete is false when the export stopped at the size limit before all the rows were exported.
+	Complete   bool       `bun:"complete,notnull,default:false" json:"complete" required:"true"`
+	Error      string     `bun:"error,type:text" json:"error,omitempty"`
+	StartedAt  *time.Time `bun:"started_at" json:"startedAt,omitempty"`
+	FinishedAt *time.Time `bun:"finished_at" json:"finishedAt,omitempty"`
+	// ExpiresAt is the time after which the job and its result are deleted.
+	ExpiresAt *time.Time `bun:"expires_at" json:"expiresAt,omitempty"`
+	// Owner is the instance which queues or runs the job, it holds the job until LeaseExpiresAt.
+	Owner          string     `bun:"owner,type:text" json:"-"`
+	LeaseExpiresAt *time.Time `bun:"lease_expires_at" json:"-"`
+}
+
+type GettableExportJob = ExportJob
+
+type GettableExportJobs struct {
+	Jobs []*GettableExportJob `json:"jobs" required:"true"`
+}
+
+func NewExportJob(orgID valuer.UUID, createdBy string, format string, request qbtypes.QueryRangeRequest, rowLimit int) *ExportJob {
+	now := time.Now()
+	return &ExportJob{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID:    orgID,
+		Status:   ExportJobStatusQueued,
+		Format:   format,
+		Request:  request,
+		RowLimit: rowLimit,
+	}
+}
+
+// Start marks the job as running.
+func (job *ExportJob) Start() {
+	now := time.Now()
+	job.Status = ExportJobStatusRunning
+	job.StartedAt = &now
+	job.UpdatedAt = now
+}
+
+// Lease marks the job as held by the owner for the given duration. The owner renews the lease while it
+// queues or runs the job, a queued or running job whose lease expired was interrupted.
+func (job *ExportJob) Lease(owner string, duration time.Duration) {
+	expiresAt := time.Now().Add(duration)
+	job.Owner = owner
+	job.LeaseExpiresAt = &expiresAt
+}
+
+// Finish moves the job to the given terminal status. The job expires after the retention.
+func (job *ExportJob) Finish(status ExportJobStatus, err error, retention time.Duration) {
+	now := time.Now()
+	expiresAt := now.Add(retention)
+	job.Status = status
+	job.FinishedAt = &now
+	job.ExpiresAt = &expiresAt
+	job.UpdatedAt = now
+	if err != nil {
+		job.Error = err.Error()
+	}
+}
+
+// CanAccess returns an error unless the job was created by the given user or the user is an admin.
+func (job *ExportJob) CanAccess(accessedBy string, isAdmin bool) error {
+	if job.CreatedBy != accessedBy && !isAdmin {
+		return errors.Newf(errors.TypeForbidden, errors.CodeForbidden, "you are not authorized to access this export job")
+	}
+	return nil
+}
+
+// Filename returns the name of the file the result of the job is downloaded as.
+func (job *ExportJob) Filename() string {
+	return "data_exported_" + job.CreatedAt.Format("2006-01-02_150405") + "." + job.Format
+}
+
+type ExportJobStore interface {
+	Create(ctx context.Context, job *ExportJob) error
+	Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*ExportJob, error)
+	List(ctx context.Context, orgID valuer.UUID) ([]*ExportJob, error)
+	// ListLeaseExpired lists the queued or running jobs of all the orgs whose lease expired before the
+	// given time or which have no lease.
+	ListLeaseExpired(ctx context.Context, before time.Time) ([]*ExportJob, error)
+	// ListExpired lists the jobs of all the orgs which expired before the given time.
+	ListExpired(ctx context.Context, before time.Time) ([]*ExportJob, error)
+	// Update updates the job only if its stored status is one of the given statuses.
+	// It returns false if the job was not updated.
+	Update(ctx context.Context, job *ExportJob, from ...ExportJobStatus) (bool, error)
+	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+}
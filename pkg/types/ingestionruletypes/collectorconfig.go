@@ -0,0 +1,323 @@
+package ingestionruletypes
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig/filterprocessor"
+	"github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig/tailsampler"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SamplingProcessorName is the tail_sampling processor the enabled
+	// sampling rules are rolled out as.
+	SamplingProcessorName = "tail_sampling/signoz_sampling_policies"
+	// DropProcessorName is the filter processor the enabled drop rules are
+	// rolled out as.
+	DropProcessorName = "filter/signoz_drop_filters"
+
+	samplingDecisionWait = "10s"
+	samplingNumTraces    = 50000
+)
+
+// DefaultCollectorConfig is the collector config rules are previewed against
+// when no config is given.
+const DefaultCollectorConfig = `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+      http: {}
+processors:
+  memory_limiter:
+    check_interval: 1s
+    limit_percentage: 75
+  batch: {}
+exporters:
+  clickhousetraces: {}
+  clickhouselogsexporter: {}
+  signozclickhousemetrics: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [clickhousetraces]
+    logs:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [clickhouselogsexporter]
+    metrics:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [signozclickhousemetrics]
+`
+
+// GenerateCollectorConfig injects (or replaces) the processor of the kind in
+// the collector YAML with one built from the enabled rules and wires it into
+// the pipelines of the signals it applies to. The processor is removed when
+// none of the rules are enabled.
+func GenerateCollectorConfig(currentConfYaml []byte, kind Kind, rules []*IngestionRule) ([]byte, error) {
+	if len(bytes.TrimSpace(currentConfYaml)) == 0 {
+		return currentConfYaml, nil
+	}
+
+	var collectorConf map[string]any
+	if err := yaml.Unmarshal(currentConfYaml, &collectorConf); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidCollectorConfig, "failed to unmarshal collector config")
+	}
+	// rare but don't do anything in this case, also means it's just comments.
+	if collectorConf == nil {
+		return currentConfYaml, nil
+	}
+
+	processors := map[string]any{}
+	if existing, ok := collectorConf["processors"]; ok && existing != nil {
+		p, ok := existing.(map[string]any)
+		if !ok {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidCollectorConfig, "collector config 'processors' must be a mapping, got %T", existing)
+		}
+		processors = p
+	}
+
+	enabled := make([]*IngestionRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled {
+			enabled = append(enabled, rule)
+		}
+	}
+
+	var (
+		name    string
+		config  any
+		signals []telemetrytypes.Signal
+	)
+	switch kind {
+	case KindSampling:
+		name = SamplingProcessorName
+		if len(enabled) > 0 {
+			config = buildTailSamplingConfig(enabled)
+			signals = []telemetrytypes.Signal{telemetrytypes.SignalTraces}
+		}
+	case KindDrop:
+		name = DropProcessorName
+		if len(enabled) > 0 {
+			config, signals = buildFilterConfig(enabled)
+		}
+	default:
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeIngestionRuleInvalidInput, "unknown ingestion rule kind %q", kind.StringValue())
+	}
+
+	if config != nil {
+		processors[name] = config
+	} else {
+		delete(processors, name)
+	}
+	collectorConf["processors"] = processors
+
+	if err := updatePipelines(collectorConf, kind, name, signals); err != nil {
+		return nil, err
+	}
+
+	out, err := yaml.Marshal(collectorConf)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, ErrCodeBuildIngestionRuleConfig, "failed to marshal collector config")
+	}
+	return out, nil
+}
+
+func buildTailSamplingConfig(rules []*IngestionRule) *tailsampler.Config {
+	policies := make([]tailsampler.PolicyCfg, 0, len(rules))
+	for _, rule := range rules {
+		policies = append(policies, newTailSamplingPolicy(rule.Name, rule.Sampling))
+	}
+
+	return &tailsampler.Config{
+		DecisionWait: samplingDecisionWait,
+		NumTraces:    samplingNumTraces,
+		PolicyCfgs:   policies,
+	}
+}
+
+// newTailSamplingPolicy maps the policy to a tail_sampling policy. A policy
+// keeping a share of the matching traces becomes an and policy of the
+// condition and a probabilistic sub policy.
+func newTailSamplingPolicy(name string, policy *SamplingPolicy) tailsampler.PolicyCfg {
+	probabilistic := tailsampler.PolicyCfg{
+		Type:             tailsampler.Probabilistic,
+		ProbabilisticCfg: &tailsampler.ProbabilisticCfg{SamplingPercentage: policy.SamplingPercentage},
+	}
+	if policy.Type == SamplingPolicyTypeProbabilistic {
+		probabilistic.Name = name
+		return probabilistic
+	}
+
+	condition := tailsampler.PolicyCfg{}
+	switch policy.Type {
+	case SamplingPolicyTypeLatency:
+		condition.Type = tailsampler.Latency
+		condition.LatencyCfg = &tailsampler.LatencyCfg{
+			ThresholdMs:      policy.Latency.ThresholdMs,
+			UpperThresholdMs: policy.Latency.UpperThresholdMs,
+		}
+	case SamplingPolicyTypeStatusCode:
+		condition.Type = tailsampler.StatusCode
+		condition.StatusCodeCfg = &tailsampler.StatusCodeCfg{StatusCodes: policy.StatusCodes}
+	case SamplingPolicyTypeAttribute:
+		if policy.Attribute.IsNumeric() {
+			condition.Type = tailsampler.NumericAttribute
+			condition.NumericAttributeCfg = &tailsampler.NumericAttributeCfg{
+				Key:         escape(policy.Attribute.Key),
+				MinValue:    *policy.Attribute.MinValue,
+				MaxValue:    *policy.Attribute.MaxValue,
+				InvertMatch: policy.Attribute.Invert,
+			}
+		} else {
+			condition.Type = tailsampler.StringAttribute
+			condition.StringAttributeCfg = &tailsampler.StringAttributeCfg{
+				Key:                  escape(policy.Attribute.Key),
+				Values:               escapeAll(policy.Attribute.Values),
+				EnabledRegexMatching: policy.Attribute.Regex,
+				InvertMatch:          policy.Attribute.Invert,
+			}
+		}
+	}
+
+	if policy.SamplingPercentage >= 100 {
+		condition.Name = name
+		return condition
+	}
+
+	condition.Name = name + "/condition"
+	probabilistic.Name = name + "/sampling"
+	return tailsampler.PolicyCfg{
+		Name:   name,
+		Type:   tailsampler.And,
+		AndCfg: &tailsampler.AndCfg{SubPolicyCfgs: []tailsampler.PolicyCfg{condition, probabilistic}},
+	}
+}
+
+// buildFilterConfig merges the conditions of the rules per signal, the
+// telemetry matching any of them is dropped. It returns the signals having
+// conditions along with the config.
+func buildFilterConfig(rules []*IngestionRule) (*filterprocessor.Config, []telemetrytypes.Signal) {
+	config := &filterprocessor.Config{ErrorMode: "ignore"}
+	for _, rule := range rules {
+		conditions := escapeAll(rule.Drop.Conditions)
+		switch rule.Drop.Signal {
+		case telemetrytypes.SignalTraces:
+			config.Traces.SpanConditions = append(config.Traces.SpanConditions, conditions...)
+		case telemetrytypes.SignalLogs:
+			config.Logs.LogConditions = append(config.Logs.LogConditions, conditions...)
+		case telemetrytypes.SignalMetrics:
+			config.Metrics.MetricConditions = append(config.Metrics.MetricConditions, conditions...)
+		}
+	}
+
+	var signals []telemetrytypes.Signal
+	if len(config.Traces.SpanConditions) > 0 {
+		signals = append(signals, telemetrytypes.SignalTraces)
+	}
+	if len(config.Logs.LogConditions) > 0 {
+		signals = append(signals, telemetrytypes.SignalLogs)
+	}
+	if len(config.Metrics.MetricConditions) > 0 {
+		signals = append(signals, telemetrytypes.SignalMetrics)
+	}
+	return config, signals
+}
+
+// updatePipelines removes the processor from all the pipelines and adds it
+// back to the pipelines of the signals. Drop filters run right after the
+// memory limiters so dropped telemetry is not processed any further, tail
+// sampling runs right before batching.
+func updatePipelines(collectorConf map[string]any, kind Kind, name string, signals []telemetrytypes.Signal) error {
+	service, ok := collectorConf["service"].(map[string]any)
+	if !ok {
+		if len(signals) == 0 {
+			return nil
+		}
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidCollectorConfig, "collector config has no service")
+	}
+
+	pipelines, ok := service["pipelines"].(map[string]any)
+	if !ok {
+		if len(signals) == 0 {
+			return nil
+		}
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidCollectorConfig, "collector config has no pipelines")
+	}
+
+	for key, value := range pipelines {
+		pipeline, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		current, _ := pipeline["processors"].([]any)
+		processors := make([]string, 0, len(current)+1)
+		for _, p := range current {
+			if p, ok := p.(string); ok && p != name {
+				processors = append(processors, p)
+			}
+		}
+
+		signal, _, _ := strings.Cut(key, "/")
+		if slices.ContainsFunc(signals, func(s telemetrytypes.Signal) bool { return s.StringValue() == signal }) {
+			var idx int
+			if kind == KindDrop {
+				idx = afterMemoryLimiters(processors)
+			} else {
+				idx = beforeBatch(processors)
+			}
+			processors = slices.Insert(processors, idx, name)
+		}
+
+		if len(current) == 0 && len(processors) == 0 {
+			continue
+		}
+		pipeline["processors"] = processors
+	}
+
+	return nil
+}
+
+func afterMemoryLimiters(processors []string) int {
+	idx := 0
+	for i, p := range processors {
+		if isProcessor(p, "memory_limiter") {
+			idx = i + 1
+		}
+	}
+	return idx
+}
+
+func beforeBatch(processors []string) int {
+	for i, p := range processors {
+		if isProcessor(p, "batch") {
+			return i
+		}
+	}
+	return len(processors)
+}
+
+func isProcessor(name, typ string) bool {
+	return name == typ || strings.HasPrefix(name, typ+"/")
+}
+
+// escape keeps the collector from expanding $ in user values as config
+// variables.
+func escape(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
+
+func escapeAll(values []string) []string {
+	escaped := make([]string, 0, len(values))
+	for _, value := range values {
+		escaped = append(escaped, escape(value))
+	}
+	return escaped
+}
@@ -0,0 +1,163 @@
+package ingestionruletypes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// assertYAMLEqualToFile decodes both sides into any and compares structurally,
+// so map key ordering is irrelevant.
+func assertYAMLEqualToFile(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	expected, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	var e, a any
+	require.NoError(t, yaml.Unmarshal(expected, &e))
+	require.NoError(t, yaml.Unmarshal(actual, &a))
+	assert.Equal(t, e, a)
+}
+
+func makeRule(name string, enabled bool, sampling *SamplingPolicy, drop *DropFilter) *IngestionRule {
+	return &IngestionRule{Name: name, Enabled: enabled, Sampling: sampling, Drop: drop}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestGenerateCollectorConfig(t *testing.T) {
+	samplingRules := []*IngestionRule{
+		makeRule("slow", true, &SamplingPolicy{
+			Type:               SamplingPolicyTypeLatency,
+			SamplingPercentage: 100,
+			Latency:            &LatencyCondition{ThresholdMs: 2000},
+		}, nil),
+		makeRule("errors", true, &SamplingPolicy{
+			Type:               SamplingPolicyTypeStatusCode,
+			SamplingPercentage: 50,
+			StatusCodes:        []string{"ERROR"},
+		}, nil),
+		makeRule("checkout", true, &SamplingPolicy{
+			Type:               SamplingPolicyTypeAttribute,
+			SamplingPercentage: 100,
+			Attribute:          &AttributeCondition{Key: "http.route", Values: []string{"^/checkout$"}, Regex: true},
+		}, nil),
+		makeRule("server errors", true, &SamplingPolicy{
+			Type:               SamplingPolicyTypeAttribute,
+			SamplingPercentage: 100,
+			Attribute:          &AttributeCondition{Key: "http.status_code", MinValue: int64Ptr(500), MaxValue: int64Ptr(599)},
+		}, nil),
+		makeRule("baseline", true, &SamplingPolicy{
+			Type:               SamplingPolicyTypeProbabilistic,
+			SamplingPercentage: 10,
+		}, nil),
+		makeRule("disabled", false, &SamplingPolicy{
+			Type:               SamplingPolicyTypeProbabilistic,
+			SamplingPercentage: 100,
+		}, nil),
+	}
+
+	dropRules := []*IngestionRule{
+		makeRule("health checks", true, nil, &DropFilter{
+			Signal:     telemetrytypes.SignalTraces,
+			Conditions: []string{`attributes["http.route"] == "/health"`, `name == "GET /ready"`},
+		}),
+		makeRule("debug logs", true, nil, &DropFilter{
+			Signal:     telemetrytypes.SignalLogs,
+			Conditions: []string{`severity_text == "DEBUG"`},
+		}),
+		makeRule("disabled", false, nil, &DropFilter{
+			Signal:     telemetrytypes.SignalMetrics,
+			Conditions: []string{`name == "unused"`},
+		}),
+	}
+
+	tests := []struct {
+		name         string
+		inputFile    string
+		kind         Kind
+		rules        []*IngestionRule
+		expectedFile string
+	}{
+		{
+			name:         "sampling_rules",
+			inputFile:    "collector_baseline.yaml",
+			kind:         KindSampling,
+			rules:        samplingRules,
+			expectedFile: "collector_sampling_rules.yaml",
+		},
+		{
+			name:         "drop_rules",
+			inputFile:    "collector_baseline.yaml",
+			kind:         KindDrop,
+			rules:        dropRules,
+			expectedFile: "collector_drop_rules.yaml",
+		},
+		{
+			name:         "replaces_stale_sampling_rules",
+			inputFile:    "collector_with_stale_rules.yaml",
+			kind:         KindSampling,
+			rules:        samplingRules,
+			expectedFile: "collector_replaced_sampling_rules.yaml",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			input, err := os.ReadFile(filepath.Join("testdata", tc.inputFile))
+			require.NoError(t, err)
+
+			out, err := GenerateCollectorConfig(input, tc.kind, tc.rules)
+			require.NoError(t, err)
+			assertYAMLEqualToFile(t, tc.expectedFile, out)
+		})
+	}
+}
+
+func TestGenerateCollectorConfig_NoEnabledRulesRemovesProcessors(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "collector_with_stale_rules.yaml"))
+	require.NoError(t, err)
+
+	out, err := GenerateCollectorConfig(input, KindSampling, nil)
+	require.NoError(t, err)
+
+	out, err = GenerateCollectorConfig(out, KindDrop, []*IngestionRule{
+		makeRule("disabled", false, nil, &DropFilter{Signal: telemetrytypes.SignalLogs, Conditions: []string{"true"}}),
+	})
+	require.NoError(t, err)
+
+	assertYAMLEqualToFile(t, "collector_baseline.yaml", out)
+}
+
+func TestGenerateCollectorConfig_EmptyInputPassthrough(t *testing.T) {
+	for _, input := range [][]byte{nil, []byte(""), []byte("  \n")} {
+		out, err := GenerateCollectorConfig(input, KindSampling, nil)
+		require.NoError(t, err)
+		assert.Equal(t, input, out)
+	}
+}
+
+func TestGenerateCollectorConfig_DefaultCollectorConfig(t *testing.T) {
+	out, err := GenerateCollectorConfig([]byte(DefaultCollectorConfig), KindDrop, []*IngestionRule{
+		makeRule("debug logs", true, nil, &DropFilter{Signal: telemetrytypes.SignalLogs, Conditions: []string{`severity_text == "DEBUG"`}}),
+	})
+	require.NoError(t, err)
+
+	var conf struct {
+		Service struct {
+			Pipelines map[string]struct {
+				Processors []string `yaml:"processors"`
+			} `yaml:"pipelines"`
+		} `yaml:"service"`
+	}
+	require.NoError(t, yaml.Unmarshal(out, &conf))
+	assert.Equal(t, []string{"memory_limiter", DropProcessorName, "batch"}, conf.Service.Pipelines["logs"].Processors)
+	assert.Equal(t, []string{"memory_limiter", "batch"}, conf.Service.Pipelines["traces"].Processors)
+}
@@ -0,0 +1,221 @@
+package ingestionruletypes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/opamptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	SamplingRulesFeatureType agentConf.AgentFeatureType = "sampling_rules"
+	DropRulesFeatureType     agentConf.AgentFeatureType = "drop_rules"
+
+	maxNameLength = 128
+)
+
+var (
+	ErrCodeIngestionRuleNotFound      = errors.MustNewCode("ingestion_rule_not_found")
+	ErrCodeIngestionRuleAlreadyExists = errors.MustNewCode("ingestion_rule_already_exists")
+	ErrCodeIngestionRuleInvalidInput  = errors.MustNewCode("ingestion_rule_invalid_input")
+	ErrCodeInvalidCollectorConfig     = errors.MustNewCode("invalid_collector_config")
+	ErrCodeBuildIngestionRuleConfig   = errors.MustNewCode("build_ingestion_rule_config")
+)
+
+// Kind tells apart the rules rolled out as tail sampling policies from the
+// ones rolled out as drop filters. Each kind is a separate agent feature with
+// its own config versions.
+type Kind struct{ valuer.String }
+
+var (
+	KindSampling = Kind{valuer.NewString("sampling")}
+	KindDrop     = Kind{valuer.NewString("drop")}
+)
+
+// Enum returns the acceptable values for Kind.
+func (Kind) Enum() []any {
+	return []any{
+		KindSampling,
+		KindDrop,
+	}
+}
+
+func NewKind(kind string) (Kind, error) {
+	switch kind {
+	case KindSampling.StringValue():
+		return KindSampling, nil
+	case KindDrop.StringValue():
+		return KindDrop, nil
+	}
+	return Kind{}, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "kind must be one of %s or %s, got %q", KindSampling.StringValue(), KindDrop.StringValue(), kind)
+}
+
+// ElementType is the agent config element type the versions of the kind are
+// stored under.
+func (kind Kind) ElementType() opamptypes.ElementType {
+	if kind == KindDrop {
+		return opamptypes.ElementTypeDropRules
+	}
+	return opamptypes.ElementTypeSamplingRules
+}
+
+func (kind Kind) FeatureType() agentConf.AgentFeatureType {
+	if kind == KindDrop {
+		return DropRulesFeatureType
+	}
+	return SamplingRulesFeatureType
+}
+
+type IngestionRule struct {
+	bun.BaseModel `bun:"table:ingestion_rule,alias:ingestion_rule" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID       valuer.UUID `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	Kind        Kind        `bun:"kind,type:text,notnull" json:"kind" required:"true"`
+	Name        string      `bun:"name,type:text,notnull" json:"name" required:"true"`
+	Description string      `bun:"description,type:text" json:"description,omitempty"`
+	Enabled     bool        `bun:"enabled,notnull" json:"enabled" required:"true"`
+	// Sampling is set on the rules of the sampling kind.
+	Sampling *SamplingPolicy `bun:"sampling_policy,type:text" json:"sampling,omitempty"`
+	// Drop is set on the rules of the drop kind.
+	Drop *DropFilter `bun:"drop_filter,type:text" json:"drop,omitempty"`
+}
+
+type GettableIngestionRule = IngestionRule
+
+// GettableIngestionRules lists the rules of a kind along with the agent config
+// version they were rolled out with, like the log pipelines response.
+type GettableIngestionRules struct {
+	Rules   []*GettableIngestionRule        `json:"rules" required:"true"`
+	Version *opamptypes.AgentConfigVersion  `json:"version" required:"true" nullable:"true"`
+	History []opamptypes.AgentConfigVersion `json:"history" required:"true"`
+}
+
+type PostableIngestionRule struct {
+	Name        string          `json:"name" required:"true"`
+	Description string          `json:"description,omitempty"`
+	Enabled     bool            `json:"enabled" required:"true"`
+	Sampling    *SamplingPolicy `json:"sampling,omitempty"`
+	Drop        *DropFilter     `json:"drop,omitempty"`
+}
+
+func (rule *PostableIngestionRule) Validate(kind Kind) error {
+	var errs []error
+
+	name := strings.TrimSpace(rule.Name)
+	if name == "" {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "name: field is required"))
+	} else if len(name) > maxNameLength {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "name: must be at most %d characters", maxNameLength))
+	}
+
+	switch kind {
+	case KindSampling:
+		if rule.Drop != nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "drop: not allowed on a sampling rule"))
+		}
+		if rule.Sampling == nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling: field is required"))
+		} else if err := rule.Sampling.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	case KindDrop:
+		if rule.Sampling != nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling: not allowed on a drop rule"))
+		}
+		if rule.Drop == nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "drop: field is required"))
+		} else if err := rule.Drop.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PostableIngestionRulesPreview carries a draft of the rules of a kind to
+// preview the collector config they would be rolled out as.
+type PostableIngestionRulesPreview struct {
+	Rules []*PostableIngestionRule `json:"rules" required:"true"`
+	// CollectorConfig is the collector config to apply the rules to. A minimal
+	// config with a pipeline per signal is used when it is empty.
+	CollectorConfig string `json:"collectorConfig,omitempty"`
+}
+
+func (preview *PostableIngestionRulesPreview) Validate(kind Kind) error {
+	var errs []error
+
+	names := make(map[string]struct{}, len(preview.Rules))
+	for i, rule := range preview.Rules {
+		if rule == nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "rules[%d]: rule is null", i))
+			continue
+		}
+		if err := rule.Validate(kind); err != nil {
+			errs = append(errs, errors.WithAdditionalf(err, "rules[%d]", i))
+			continue
+		}
+		if _, ok := names[strings.TrimSpace(rule.Name)]; ok {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "rules[%d]: name %q is used by another rule", i, rule.Name))
+		}
+		names[strings.TrimSpace(rule.Name)] = struct{}{}
+	}
+
+	return errors.Join(errs...)
+}
+
+type GettableIngestionRulesPreview struct {
+	CollectorConfig string `json:"collectorConfig" required:"true"`
+}
+
+func NewIngestionRule(orgID valuer.UUID, kind Kind, createdBy string, postable *PostableIngestionRule) *IngestionRule {
+	now := time.Now()
+	rule := &IngestionRule{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID: orgID,
+		Kind:  kind,
+	}
+	rule.set(postable)
+	return rule
+}
+
+func (rule *IngestionRule) Update(updatedBy string, postable *PostableIngestionRule) {
+	rule.set(postable)
+	rule.UpdatedAt = time.Now()
+	rule.UpdatedBy = updatedBy
+}
+
+func (rule *IngestionRule) set(postable *PostableIngestionRule) {
+	rule.Name = strings.TrimSpace(postable.Name)
+	rule.Description = postable.Description
+	rule.Enabled = postable.Enabled
+	rule.Sampling = postable.Sampling
+	rule.Drop = postable.Drop
+}
+
+type Store interface {
+	// List returns the rules of the kind in the order they were created in,
+	// which is the order they are rolled out in.
+	List(ctx context.Context, orgID valuer.UUID, kind Kind) ([]*IngestionRule, error)
+	Get(ctx context.Context, orgID valuer.UUID, kind Kind, id valuer.UUID) (*IngestionRule, error)
+	Create(ctx context.Context, rule *IngestionRule) error
+	Update(ctx context.Context, rule *IngestionRule) error
+	Delete(ctx context.Context, orgID valuer.UUID, kind Kind, id valuer.UUID) error
+}
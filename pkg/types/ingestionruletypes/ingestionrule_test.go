@@ -0,0 +1,130 @@
+package ingestionruletypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostableIngestionRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    Kind
+		rule    PostableIngestionRule
+		wantErr bool
+	}{
+		{
+			name: "latency",
+			kind: KindSampling,
+			rule: PostableIngestionRule{Name: "slow", Sampling: &SamplingPolicy{
+				Type: SamplingPolicyTypeLatency, SamplingPercentage: 100, Latency: &LatencyCondition{ThresholdMs: 500},
+			}},
+		},
+		{
+			name: "latency_upper_threshold_below_threshold",
+			kind: KindSampling,
+			rule: PostableIngestionRule{Name: "slow", Sampling: &SamplingPolicy{
+				Type: SamplingPolicyTypeLatency, SamplingPercentage: 100, Latency: &LatencyCondition{ThresholdMs: 500, UpperThresholdMs: 100},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "unknown_status_code",
+			kind: KindSampling,
+			rule: PostableIngestionRule{Name: "errors", Sampling: &SamplingPolicy{
+				Type: SamplingPolicyTypeStatusCode, SamplingPercentage: 100, StatusCodes: []string{"500"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "attribute_range_missing_max",
+			kind: KindSampling,
+			rule: PostableIngestionRule{Name: "5xx", Sampling: &SamplingPolicy{
+				Type: SamplingPolicyTypeAttribute, SamplingPercentage: 100, Attribute: &AttributeCondition{Key: "http.status_code", MinValue: int64Ptr(500)},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "attribute_without_values",
+			kind: KindSampling,
+			rule: PostableIngestionRule{Name: "route", Sampling: &SamplingPolicy{
+				Type: SamplingPolicyTypeAttribute, SamplingPercentage: 100, Attribute: &AttributeCondition{Key: "http.route"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "zero_sampling_percentage",
+			kind: KindSampling,
+			rule: PostableIngestionRule{Name: "none", Sampling: &SamplingPolicy{
+				Type: SamplingPolicyTypeProbabilistic,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "drop_filter_on_sampling_rule",
+			kind: KindSampling,
+			rule: PostableIngestionRule{Name: "health", Drop: &DropFilter{
+				Signal: telemetrytypes.SignalTraces, Conditions: []string{`name == "health"`},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "drop",
+			kind: KindDrop,
+			rule: PostableIngestionRule{Name: "health", Drop: &DropFilter{
+				Signal: telemetrytypes.SignalTraces, Conditions: []string{`name == "health"`},
+			}},
+		},
+		{
+			name: "drop_without_conditions",
+			kind: KindDrop,
+			rule: PostableIngestionRule{Name: "health", Drop: &DropFilter{
+				Signal: telemetrytypes.SignalLogs,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "drop_with_metric_condition",
+			kind: KindDrop,
+			rule: PostableIngestionRule{Name: "k8s", Drop: &DropFilter{
+				Signal: telemetrytypes.SignalMetrics, Conditions: []string{`IsMatch(name, "^k8s\\.pod\\.")`},
+			}},
+		},
+		{
+			name: "drop_with_invalid_condition",
+			kind: KindDrop,
+			rule: PostableIngestionRule{Name: "health", Drop: &DropFilter{
+				Signal: telemetrytypes.SignalTraces, Conditions: []string{`attributes["http.route"] = "/health"`},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "drop_with_condition_of_other_signal",
+			kind: KindDrop,
+			rule: PostableIngestionRule{Name: "debug", Drop: &DropFilter{
+				Signal: telemetrytypes.SignalTraces, Conditions: []string{`severity_text == "DEBUG"`},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "drop_without_name",
+			kind: KindDrop,
+			rule: PostableIngestionRule{Name: " ", Drop: &DropFilter{
+				Signal: telemetrytypes.SignalLogs, Conditions: []string{`severity_text == "DEBUG"`},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rule.Validate(tc.kind)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
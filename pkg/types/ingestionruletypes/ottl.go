@@ -0,0 +1,49 @@
+package ingestionruletypes
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// validateConditions parses the conditions in the OTTL context the filter
+// processor evaluates them in for the signal, so that a condition the
+// collector would reject never reaches the agents.
+func validateConditions(signal telemetrytypes.Signal, conditions []string) []error {
+	settings := component.TelemetrySettings{Logger: zap.NewNop()}
+
+	switch signal {
+	case telemetrytypes.SignalTraces:
+		parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[*ottlspan.TransformContext](), settings)
+		return parseConditions(parser, err, conditions)
+	case telemetrytypes.SignalLogs:
+		parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[*ottllog.TransformContext](), settings)
+		return parseConditions(parser, err, conditions)
+	case telemetrytypes.SignalMetrics:
+		parser, err := ottlmetric.NewParser(ottlfuncs.StandardConverters[*ottlmetric.TransformContext](), settings)
+		return parseConditions(parser, err, conditions)
+	}
+
+	return nil
+}
+
+func parseConditions[K any](parser ottl.Parser[K], err error, conditions []string) []error {
+	if err != nil {
+		return []error{errors.WrapInternalf(err, errors.CodeInternal, "failed to create the OTTL parser")}
+	}
+
+	var errs []error
+	for i, condition := range conditions {
+		if _, err := parser.ParseCondition(condition); err != nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "drop.conditions[%d]: %s", i, err.Error()))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,178 @@
+package ingestionruletypes
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type SamplingPolicyType struct{ valuer.String }
+
+var (
+	// SamplingPolicyTypeLatency samples the traces lasting longer than a threshold.
+	SamplingPolicyTypeLatency = SamplingPolicyType{valuer.NewString("latency")}
+	// SamplingPolicyTypeStatusCode samples the traces having a span with one of the status codes.
+	SamplingPolicyTypeStatusCode = SamplingPolicyType{valuer.NewString("status_code")}
+	// SamplingPolicyTypeAttribute samples the traces having a span with a matching attribute.
+	SamplingPolicyTypeAttribute = SamplingPolicyType{valuer.NewString("attribute")}
+	// SamplingPolicyTypeProbabilistic samples a share of all the traces.
+	SamplingPolicyTypeProbabilistic = SamplingPolicyType{valuer.NewString("probabilistic")}
+)
+
+// Enum returns the acceptable values for SamplingPolicyType.
+func (SamplingPolicyType) Enum() []any {
+	return []any{
+		SamplingPolicyTypeLatency,
+		SamplingPolicyTypeStatusCode,
+		SamplingPolicyTypeAttribute,
+		SamplingPolicyTypeProbabilistic,
+	}
+}
+
+var statusCodes = []string{"OK", "ERROR", "UNSET"}
+
+// SamplingPolicy is a tail sampling policy. A trace is kept when any of the
+// enabled policies samples it and dropped otherwise, so a probabilistic policy
+// is needed to keep a share of the traces no other policy matches.
+type SamplingPolicy struct {
+	Type SamplingPolicyType `json:"type" required:"true"`
+	// SamplingPercentage is the percentage of the matching traces to keep.
+	SamplingPercentage float64 `json:"samplingPercentage" required:"true"`
+	// Latency is required by the latency policies.
+	Latency *LatencyCondition `json:"latency,omitempty"`
+	// StatusCodes are required by the status code policies, out of OK, ERROR and UNSET.
+	StatusCodes []string `json:"statusCodes,omitempty"`
+	// Attribute is required by the attribute policies.
+	Attribute *AttributeCondition `json:"attribute,omitempty"`
+}
+
+type LatencyCondition struct {
+	// ThresholdMs is the duration of the trace above which it matches.
+	ThresholdMs int64 `json:"thresholdMs" required:"true"`
+	// UpperThresholdMs bounds the duration of the matching traces when set.
+	UpperThresholdMs int64 `json:"upperThresholdMs,omitempty"`
+}
+
+// AttributeCondition matches the spans or resources having the attribute
+// either set to one of the values or, for numeric attributes, within
+// [MinValue, MaxValue].
+type AttributeCondition struct {
+	Key    string   `json:"key" required:"true"`
+	Values []string `json:"values,omitempty"`
+	// Regex matches the values as regular expressions.
+	Regex    bool   `json:"regex,omitempty"`
+	MinValue *int64 `json:"minValue,omitempty"`
+	MaxValue *int64 `json:"maxValue,omitempty"`
+	// Invert matches the spans whose attribute does not match.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// IsNumeric reports whether the condition matches a numeric range rather
+// than string values.
+func (condition *AttributeCondition) IsNumeric() bool {
+	return condition.MinValue != nil || condition.MaxValue != nil
+}
+
+func (policy *SamplingPolicy) Validate() error {
+	var errs []error
+
+	if policy.SamplingPercentage <= 0 || policy.SamplingPercentage > 100 {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.samplingPercentage: must be greater than 0 and at most 100, got %v", policy.SamplingPercentage))
+	}
+
+	switch policy.Type {
+	case SamplingPolicyTypeLatency:
+		if policy.Latency == nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.latency: field is required for %s policies", policy.Type.StringValue()))
+			break
+		}
+		if policy.Latency.ThresholdMs <= 0 {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.latency.thresholdMs: must be positive"))
+		}
+		if policy.Latency.UpperThresholdMs != 0 && policy.Latency.UpperThresholdMs <= policy.Latency.ThresholdMs {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.latency.upperThresholdMs: must be greater than thresholdMs"))
+		}
+	case SamplingPolicyTypeStatusCode:
+		if len(policy.StatusCodes) == 0 {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.statusCodes: field is required for %s policies", policy.Type.StringValue()))
+		}
+		for _, code := range policy.StatusCodes {
+			if !slices.Contains(statusCodes, code) {
+				errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.statusCodes: must be one of %s, got %q", strings.Join(statusCodes, ", "), code))
+			}
+		}
+	case SamplingPolicyTypeAttribute:
+		if policy.Attribute == nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.attribute: field is required for %s policies", policy.Type.StringValue()))
+			break
+		}
+		if err := policy.Attribute.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	case SamplingPolicyTypeProbabilistic:
+	default:
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.type: must be one of latency, status_code, attribute or probabilistic, got %q", policy.Type.StringValue()))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (condition *AttributeCondition) validate() error {
+	var errs []error
+
+	if strings.TrimSpace(condition.Key) == "" {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.attribute.key: field is required"))
+	}
+
+	if condition.IsNumeric() {
+		if len(condition.Values) > 0 || condition.Regex {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.attribute: values and a numeric range can not be used together"))
+		}
+		if condition.MinValue == nil || condition.MaxValue == nil {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.attribute: both minValue and maxValue are required for a numeric range"))
+		} else if *condition.MinValue > *condition.MaxValue {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.attribute.minValue: must be at most maxValue"))
+		}
+	} else if len(condition.Values) == 0 {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "sampling.attribute: either values or a numeric range is required"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DropFilter drops the telemetry of a signal matching any of the conditions
+// before it is exported.
+type DropFilter struct {
+	Signal telemetrytypes.Signal `json:"signal" required:"true"`
+	// Conditions are OTTL boolean expressions evaluated against spans, log
+	// records or metrics depending on the signal, such as
+	// attributes["http.route"] == "/health".
+	Conditions []string `json:"conditions" required:"true"`
+}
+
+func (filter *DropFilter) Validate() error {
+	var errs []error
+
+	switch filter.Signal {
+	case telemetrytypes.SignalTraces, telemetrytypes.SignalLogs, telemetrytypes.SignalMetrics:
+	default:
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "drop.signal: must be one of traces, logs or metrics, got %q", filter.Signal.StringValue()))
+	}
+
+	if len(filter.Conditions) == 0 {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "drop.conditions: at least one condition is required"))
+	}
+	for i, condition := range filter.Conditions {
+		if strings.TrimSpace(condition) == "" {
+			errs = append(errs, errors.NewInvalidInputf(ErrCodeIngestionRuleInvalidInput, "drop.conditions[%d]: must not be empty", i))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return errors.Join(validateConditions(filter.Signal, filter.Conditions)...)
+}
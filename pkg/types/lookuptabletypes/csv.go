@@ -0,0 +1,95 @@
+package lookuptabletypes
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+const (
+	// MaxRows bounds the rows of a table. The tables referenced by log
+	// pipelines and span mappers are rolled out as part of the collector config,
+	// and log pipelines build the table of a lookup for every log record.
+	MaxRows    = 1000
+	MaxColumns = 64
+
+	previewRows = 20
+)
+
+// ParseCSV reads the columns named by the header row and the rows of a table.
+// Every row must have a distinct, non-empty value of the key column.
+func ParseCSV(content string, keyColumn string) ([]string, [][]string, error) {
+	if keyColumn == "" {
+		return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "keyColumn: field is required")
+	}
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "csv: a header row naming the columns is required")
+	}
+	if err != nil {
+		return nil, nil, errors.WrapInvalidInputf(err, ErrCodeLookupTableInvalidInput, "csv: failed to read the header row")
+	}
+
+	if len(header) > MaxColumns {
+		return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "csv: must have at most %d columns, got %d", MaxColumns, len(header))
+	}
+
+	keyIdx := -1
+	columns := make([]string, 0, len(header))
+	seenColumns := make(map[string]struct{}, len(header))
+	for idx, column := range header {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "csv: column %d has no name", idx+1)
+		}
+		if _, ok := seenColumns[column]; ok {
+			return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "csv: column %q is named more than once", column)
+		}
+		seenColumns[column] = struct{}{}
+
+		if column == keyColumn {
+			keyIdx = idx
+		}
+		columns = append(columns, column)
+	}
+
+	if keyIdx < 0 {
+		return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "keyColumn: column %q is not in the csv header", keyColumn)
+	}
+
+	rows := [][]string{}
+	seenKeys := map[string]int{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, errors.WrapInvalidInputf(err, ErrCodeLookupTableInvalidInput, "csv: failed to read row %d", len(rows)+2)
+		}
+
+		if len(rows) == MaxRows {
+			return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "csv: must have at most %d rows", MaxRows)
+		}
+
+		line := len(rows) + 2
+		key := row[keyIdx]
+		if key == "" {
+			return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "csv: row %d has no value for the key column %q", line, keyColumn)
+		}
+		if previous, ok := seenKeys[key]; ok {
+			return nil, nil, errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "csv: rows %d and %d have the same key %q", previous, line, key)
+		}
+		seenKeys[key] = line
+
+		rows = append(rows, row)
+	}
+
+	return columns, rows, nil
+}
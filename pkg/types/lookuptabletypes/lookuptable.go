@@ -0,0 +1,265 @@
+package lookuptabletypes
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/opamptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	LookupTablesFeatureType agentConf.AgentFeatureType = "lookup_tables"
+
+	maxNameLength = 128
+)
+
+var (
+	ErrCodeLookupTableNotFound      = errors.MustNewCode("lookup_table_not_found")
+	ErrCodeLookupTableAlreadyExists = errors.MustNewCode("lookup_table_already_exists")
+	ErrCodeLookupTableInvalidInput  = errors.MustNewCode("lookup_table_invalid_input")
+)
+
+// Names are how log pipeline operators and span mappers refer to a table.
+var nameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// LookupTable is a table uploaded as CSV to enrich logs and spans at
+// ingestion with the columns of the row keyed by one of their attributes.
+// Every upload is a new version of the table, the latest is rolled out.
+type LookupTable struct {
+	bun.BaseModel `bun:"table:lookup_table,alias:lookup_table" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID       valuer.UUID `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	Name        string      `bun:"name,type:text,notnull" json:"name" required:"true"`
+	Description string      `bun:"description,type:text" json:"description,omitempty"`
+	// Version, KeyColumn, Columns and RowCount are the ones of the latest version.
+	Version   int      `bun:"version,notnull" json:"version" required:"true"`
+	KeyColumn string   `bun:"key_column,type:text,notnull" json:"keyColumn" required:"true"`
+	Columns   []string `bun:"columns,type:text,notnull" json:"columns" required:"true"`
+	RowCount  int      `bun:"row_count,notnull" json:"rowCount" required:"true"`
+}
+
+type LookupTableVersion struct {
+	bun.BaseModel `bun:"table:lookup_table_version,alias:lookup_table_version" json:"-"`
+
+	types.Identifiable
+
+	OrgID     valuer.UUID `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	TableID   valuer.UUID `bun:"table_id,type:text,notnull" json:"tableId" required:"true"`
+	Version   int         `bun:"version,notnull" json:"version" required:"true"`
+	KeyColumn string      `bun:"key_column,type:text,notnull" json:"keyColumn" required:"true"`
+	Columns   []string    `bun:"columns,type:text,notnull" json:"columns" required:"true"`
+	// Rows is left out when listing the versions of a table.
+	Rows      [][]string `bun:"rows,type:text,notnull" json:"rows,omitempty"`
+	RowCount  int        `bun:"row_count,notnull" json:"rowCount" required:"true"`
+	CreatedAt time.Time  `bun:"created_at,notnull" json:"createdAt" required:"true"`
+	CreatedBy string     `bun:"created_by,type:text,notnull" json:"createdBy" required:"true"`
+}
+
+type GettableLookupTable = LookupTable
+
+// GettableLookupTables lists the tables of the org along with the agent config
+// version they were rolled out with, like the log pipelines response.
+type GettableLookupTables struct {
+	Tables  []*GettableLookupTable          `json:"tables" required:"true"`
+	Version *opamptypes.AgentConfigVersion  `json:"version" required:"true" nullable:"true"`
+	History []opamptypes.AgentConfigVersion `json:"history" required:"true"`
+}
+
+type GettableLookupTableVersion = LookupTableVersion
+
+type GettableLookupTableVersions struct {
+	Versions []*GettableLookupTableVersion `json:"versions" required:"true"`
+}
+
+type PostableLookupTable struct {
+	Name        string `json:"name" required:"true"`
+	Description string `json:"description,omitempty"`
+	PostableLookupTableVersion
+}
+
+func (postable *PostableLookupTable) Validate() error {
+	name := strings.TrimSpace(postable.Name)
+	if name == "" {
+		return errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "name: field is required")
+	}
+	if len(name) > maxNameLength {
+		return errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "name: must be at most %d characters", maxNameLength)
+	}
+	if !nameRegex.MatchString(name) {
+		return errors.NewInvalidInputf(ErrCodeLookupTableInvalidInput, "name: must only contain letters, digits, '_', '.' and '-'")
+	}
+
+	return nil
+}
+
+// PostableLookupTableVersion is an upload of the content of a table.
+type PostableLookupTableVersion struct {
+	// KeyColumn is the column looked up by the value of an attribute.
+	KeyColumn string `json:"keyColumn" required:"true"`
+	// CSV is the content of the uploaded file, its first row names the columns.
+	CSV string `json:"csv" required:"true"`
+}
+
+// PostableLookupTablePreview is an upload to check before storing it, along
+// with keys to look up in it.
+type PostableLookupTablePreview struct {
+	PostableLookupTableVersion
+	Keys []string `json:"keys,omitempty"`
+}
+
+type GettableLookupTablePreview struct {
+	KeyColumn string   `json:"keyColumn" required:"true"`
+	Columns   []string `json:"columns" required:"true"`
+	RowCount  int      `json:"rowCount" required:"true"`
+	// Rows holds the first rows of the table.
+	Rows [][]string `json:"rows" required:"true"`
+	// Matches holds the columns enriched for each of the keys found in the table.
+	Matches map[string]map[string]string `json:"matches" required:"true"`
+}
+
+// Entries maps the keys of a table to the values of its other columns. Empty
+// values are left out so that they don't get set as attributes.
+type Entries map[string]map[string]string
+
+func NewLookupTable(orgID valuer.UUID, createdBy string, postable *PostableLookupTable) (*LookupTable, *LookupTableVersion, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	table := &LookupTable{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID:       orgID,
+		Name:        strings.TrimSpace(postable.Name),
+		Description: postable.Description,
+	}
+
+	version, err := table.NewVersion(createdBy, &postable.PostableLookupTableVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return table, version, nil
+}
+
+// NewVersion parses an upload into the next version of the table and makes it
+// the latest one.
+func (table *LookupTable) NewVersion(createdBy string, postable *PostableLookupTableVersion) (*LookupTableVersion, error) {
+	columns, rows, err := ParseCSV(postable.CSV, postable.KeyColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	version := &LookupTableVersion{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		OrgID:        table.OrgID,
+		TableID:      table.ID,
+		Version:      table.Version + 1,
+		KeyColumn:    postable.KeyColumn,
+		Columns:      columns,
+		Rows:         rows,
+		RowCount:     len(rows),
+		CreatedAt:    now,
+		CreatedBy:    createdBy,
+	}
+
+	table.Version = version.Version
+	table.KeyColumn = version.KeyColumn
+	table.Columns = version.Columns
+	table.RowCount = version.RowCount
+	table.UpdatedAt = now
+	table.UpdatedBy = createdBy
+
+	return version, nil
+}
+
+func (version *LookupTableVersion) Entries() Entries {
+	return newEntries(version.KeyColumn, version.Columns, version.Rows)
+}
+
+func NewGettableLookupTablePreview(postable *PostableLookupTablePreview) (*GettableLookupTablePreview, error) {
+	columns, rows, err := ParseCSV(postable.CSV, postable.KeyColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := newEntries(postable.KeyColumn, columns, rows)
+	matches := make(map[string]map[string]string, len(postable.Keys))
+	for _, key := range postable.Keys {
+		if entry, ok := entries[key]; ok {
+			matches[key] = entry
+		}
+	}
+
+	return &GettableLookupTablePreview{
+		KeyColumn: postable.KeyColumn,
+		Columns:   columns,
+		RowCount:  len(rows),
+		Rows:      rows[:min(len(rows), previewRows)],
+		Matches:   matches,
+	}, nil
+}
+
+func newEntries(keyColumn string, columns []string, rows [][]string) Entries {
+	keyIdx := 0
+	for idx, column := range columns {
+		if column == keyColumn {
+			keyIdx = idx
+		}
+	}
+
+	entries := make(Entries, len(rows))
+	for _, row := range rows {
+		entry := make(map[string]string, len(columns)-1)
+		for idx, value := range row {
+			if idx == keyIdx || value == "" {
+				continue
+			}
+			entry[columns[idx]] = value
+		}
+		entries[row[keyIdx]] = entry
+	}
+
+	return entries
+}
+
+type Store interface {
+	// List returns the tables of the org, by name.
+	List(ctx context.Context, orgID valuer.UUID) ([]*LookupTable, error)
+	Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*LookupTable, error)
+	GetByName(ctx context.Context, orgID valuer.UUID, name string) (*LookupTable, error)
+
+	// Create stores the table along with its first version.
+	Create(ctx context.Context, table *LookupTable, version *LookupTableVersion) error
+
+	// CreateVersion stores a new version of the table and makes it the latest.
+	CreateVersion(ctx context.Context, table *LookupTable, version *LookupTableVersion) error
+
+	// Delete deletes the table along with all its versions.
+	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+
+	// ListVersions returns the versions of the table, latest first and without
+	// their rows.
+	ListVersions(ctx context.Context, orgID valuer.UUID, tableID valuer.UUID) ([]*LookupTableVersion, error)
+	GetVersion(ctx context.Context, orgID valuer.UUID, tableID valuer.UUID, version int) (*LookupTableVersion, error)
+}
@@ -0,0 +1,169 @@
+package lookuptabletypes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenantsCSV = `customer_id,tenant,tier,team
+c-1,Acme,gold,payments
+c-2,"Globex, Inc.",silver,
+c-3,Initech,bronze,search
+`
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		keyColumn string
+		columns   []string
+		rows      [][]string
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			content:   tenantsCSV,
+			keyColumn: "customer_id",
+			columns:   []string{"customer_id", "tenant", "tier", "team"},
+			rows: [][]string{
+				{"c-1", "Acme", "gold", "payments"},
+				{"c-2", "Globex, Inc.", "silver", ""},
+				{"c-3", "Initech", "bronze", "search"},
+			},
+		},
+		{
+			name:      "header_only",
+			content:   "pod_ip,team\n",
+			keyColumn: "pod_ip",
+			columns:   []string{"pod_ip", "team"},
+			rows:      [][]string{},
+		},
+		{
+			name:      "empty",
+			content:   "",
+			keyColumn: "pod_ip",
+			wantErr:   true,
+		},
+		{
+			name:      "missing_key_column",
+			content:   tenantsCSV,
+			keyColumn: "pod_ip",
+			wantErr:   true,
+		},
+		{
+			name:      "key_column_required",
+			content:   tenantsCSV,
+			keyColumn: "",
+			wantErr:   true,
+		},
+		{
+			name:      "duplicate_column",
+			content:   "pod_ip,team,team\n10.0.0.1,a,b\n",
+			keyColumn: "pod_ip",
+			wantErr:   true,
+		},
+		{
+			name:      "unnamed_column",
+			content:   "pod_ip,\n10.0.0.1,a\n",
+			keyColumn: "pod_ip",
+			wantErr:   true,
+		},
+		{
+			name:      "duplicate_key",
+			content:   "pod_ip,team\n10.0.0.1,a\n10.0.0.1,b\n",
+			keyColumn: "pod_ip",
+			wantErr:   true,
+		},
+		{
+			name:      "empty_key",
+			content:   "pod_ip,team\n,a\n",
+			keyColumn: "pod_ip",
+			wantErr:   true,
+		},
+		{
+			name:      "wrong_number_of_fields",
+			content:   "pod_ip,team\n10.0.0.1\n",
+			keyColumn: "pod_ip",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rows, err := ParseCSV(tt.content, tt.keyColumn)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.columns, columns)
+			assert.Equal(t, tt.rows, rows)
+		})
+	}
+}
+
+func TestParseCSVMaxRows(t *testing.T) {
+	var content strings.Builder
+	content.WriteString("pod_ip,team\n")
+	for i := 0; i <= MaxRows; i++ {
+		fmt.Fprintf(&content, "10.0.%d.%d,team\n", i/256, i%256)
+	}
+
+	_, _, err := ParseCSV(content.String(), "pod_ip")
+	assert.Error(t, err)
+}
+
+func TestLookupTableNewVersion(t *testing.T) {
+	table, version, err := NewLookupTable(valuer.GenerateUUID(), "admin@example.com", &PostableLookupTable{
+		Name:                       "tenants",
+		PostableLookupTableVersion: PostableLookupTableVersion{KeyColumn: "customer_id", CSV: tenantsCSV},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, table.Version)
+	assert.Equal(t, 1, version.Version)
+	assert.Equal(t, table.ID, version.TableID)
+	assert.Equal(t, 3, table.RowCount)
+	assert.Equal(t, Entries{
+		"c-1": {"tenant": "Acme", "tier": "gold", "team": "payments"},
+		"c-2": {"tenant": "Globex, Inc.", "tier": "silver"},
+		"c-3": {"tenant": "Initech", "tier": "bronze", "team": "search"},
+	}, version.Entries())
+
+	version, err = table.NewVersion("editor@example.com", &PostableLookupTableVersion{KeyColumn: "pod_ip", CSV: "team,pod_ip\nsearch,10.0.0.1\n"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, table.Version)
+	assert.Equal(t, 2, version.Version)
+	assert.Equal(t, "pod_ip", table.KeyColumn)
+	assert.Equal(t, []string{"team", "pod_ip"}, table.Columns)
+	assert.Equal(t, "editor@example.com", table.UpdatedBy)
+	assert.Equal(t, Entries{"10.0.0.1": {"team": "search"}}, version.Entries())
+
+	_, err = table.NewVersion("editor@example.com", &PostableLookupTableVersion{KeyColumn: "pod_ip", CSV: "team\nsearch\n"})
+	assert.Error(t, err)
+	assert.Equal(t, 2, table.Version)
+}
+
+func TestPostableLookupTableValidate(t *testing.T) {
+	assert.NoError(t, (&PostableLookupTable{Name: "k8s.pods-by_ip"}).Validate())
+	assert.Error(t, (&PostableLookupTable{Name: " "}).Validate())
+	assert.Error(t, (&PostableLookupTable{Name: "pods by ip"}).Validate())
+	assert.Error(t, (&PostableLookupTable{Name: strings.Repeat("a", maxNameLength+1)}).Validate())
+}
+
+func TestNewGettableLookupTablePreview(t *testing.T) {
+	preview, err := NewGettableLookupTablePreview(&PostableLookupTablePreview{
+		PostableLookupTableVersion: PostableLookupTableVersion{KeyColumn: "customer_id", CSV: tenantsCSV},
+		Keys:                       []string{"c-1", "c-9"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, preview.RowCount)
+	assert.Len(t, preview.Rows, 3)
+	assert.Equal(t, map[string]map[string]string{
+		"c-1": {"tenant": "Acme", "tier": "gold", "team": "payments"},
+	}, preview.Matches)
+}
@@ -47,6 +47,7 @@ var (
 	ElementTypeDropRules     = ElementType{valuer.NewString("drop_rules")}
 	ElementTypeLogPipelines  = ElementType{valuer.NewString("log_pipelines")}
 	ElementTypeLbExporter    = ElementType{valuer.NewString("lb_exporter")}
+	ElementTypeLookupTables  = ElementType{valuer.NewString("lookup_tables")}
 )
 
 type DeployStatus struct{ valuer.String }
@@ -133,6 +134,8 @@ func NewElementType(value string) ElementType {
 		return ElementTypeLogPipelines
 	case ElementTypeLbExporter.String:
 		return ElementTypeLbExporter
+	case ElementTypeLookupTables.String:
+		return ElementTypeLookupTables
 	default:
 		return ElementType{valuer.NewString("")}
 	}
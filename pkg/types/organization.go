@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/uptrace/bun"
 )
@@ -78,7 +79,7 @@ type OrganizationStore interface {
 	Get(context.Context, valuer.UUID) (*Organization, error)
 	GetByName(context.Context, string) (*Organization, error)
 	GetAll(context.Context) ([]*Organization, error)
-	ListByKeyRange(context.Context, uint32, uint32) ([]*Organization, error)
+	ListByKeyRanges(context.Context, []sharder.KeyRange) ([]*Organization, error)
 	Update(context.Context, *Organization) error
 	Delete(context.Context, valuer.UUID) error
 }
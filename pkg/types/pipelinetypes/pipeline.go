@@ -39,6 +39,30 @@ var DefaultSeverityMapping = map[string][]string{
 var validMappingLevels = []string{"trace", "debug", "info", "warn", "error", "fatal"}
 var validMappingVariableTypes = []string{Host, Service, Environment, Severity, TraceID, SpanID, TraceFlags, Message}
 
+// Built-in PII patterns of the redact operator.
+const (
+	RedactPatternEmail      = "email"
+	RedactPatternCreditCard = "credit_card"
+	RedactPatternIPv4       = "ipv4"
+	RedactPatternSSN        = "ssn"
+)
+
+// Actions of the redact operator, the matches in a field are either masked or
+// replaced by their SHA-256 hash, or a field containing a match is removed.
+const (
+	RedactActionMask   = "mask"
+	RedactActionHash   = "hash"
+	RedactActionRemove = "remove"
+)
+
+// DropMarkerAttribute is set on logs by the drop operator. The logs pipelines
+// processor can't drop logs itself, a filter processor after it drops the logs
+// having the attribute.
+const DropMarkerAttribute = "__signoz_drop__"
+
+var validRedactPatterns = []string{RedactPatternEmail, RedactPatternCreditCard, RedactPatternIPv4, RedactPatternSSN}
+var validRedactActions = []string{RedactActionMask, RedactActionHash, RedactActionRemove}
+
 type StoreablePipeline struct {
 	bun.BaseModel `bun:"table:pipelines,alias:p"`
 
@@ -126,6 +150,33 @@ type PipelineOperator struct {
 	Mapping map[string][]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
 	// severity parser fields
 	OverwriteSeverityText bool `json:"overwrite_text,omitempty" yaml:"overwrite_text,omitempty"`
+
+	// kv_parser fields
+	Delimiter     string `json:"delimiter,omitempty" yaml:"delimiter,omitempty"`
+	PairDelimiter string `json:"pair_delimiter,omitempty" yaml:"pair_delimiter,omitempty"`
+
+	// redact fields, the custom pattern is read from Regex.
+	Patterns []string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	Action   string   `json:"action,omitempty" yaml:"action,omitempty"`
+
+	// drop fields, the filter gets translated to the if expression of the operator
+	Filter *v3.FilterSet `json:"filter,omitempty" yaml:"-"`
+
+	// sample fields, the ratio of logs having Field that are kept. Logs are
+	// kept by the hash of the value of Field, so logs with the same value are
+	// either all kept or all dropped.
+	Ratio float64 `json:"ratio,omitempty" yaml:"-"`
+
+	// lookup fields, the columns of the LookupTable row keyed by the value of
+	// Field are set under ParseTo. Entries is resolved from the version of the
+	// table rolled out when the config is generated, the operator gets
+	// translated to add, move and remove operators setting them.
+	LookupTable string                       `json:"lookup_table,omitempty" yaml:"-"`
+	Entries     map[string]map[string]string `json:"-" yaml:"-"`
+
+	// Statements are the OTTL statements of the operators translated to a
+	// transform processor, such as redact and sample.
+	Statements []string `json:"-" yaml:"-"`
 }
 
 func (op PipelineOperator) MarshalJSON() ([]byte, error) {
@@ -389,10 +440,74 @@ func isValidOperator(op PipelineOperator) error {
 			}
 		}
 
+	case "kv_parser":
+		if op.ParseFrom == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "parse from of %s kv operator cannot be empty", op.ID)
+		}
+
+		delimiter, pairDelimiter := op.Delimiter, op.PairDelimiter
+		if delimiter == "" {
+			delimiter = "="
+		}
+		if pairDelimiter == "" {
+			pairDelimiter = " "
+		}
+		if delimiter == pairDelimiter {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "delimiter and pair delimiter of %s kv operator cannot be the same", op.ID)
+		}
+
+	case "redact":
+		if op.Field == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "field of %s redact operator cannot be empty", op.ID)
+		}
+		if len(op.Patterns) == 0 && op.Regex == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "one of patterns or regex of %s redact operator must be present", op.ID)
+		}
+		for _, pattern := range op.Patterns {
+			if !slices.Contains(validRedactPatterns, pattern) {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "%s is not a valid pattern in redact operator %s, use one of (%s)", pattern, op.ID, strings.Join(validRedactPatterns, ", "))
+			}
+		}
+		if op.Regex != "" {
+			if _, err := regexp.Compile(op.Regex); err != nil {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "error compiling regex expression of %s redact operator", op.ID)
+			}
+		}
+		if !slices.Contains(validRedactActions, op.Action) {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid action '%s' of redact operator %s, use one of (%s)", op.Action, op.ID, strings.Join(validRedactActions, ", "))
+		}
+
+	case "drop":
+		if op.Filter == nil || len(op.Filter.Items) == 0 {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "filter of %s drop operator cannot be empty", op.ID)
+		}
+		if _, err := queryBuilderToExpr.Parse(op.Filter); err != nil {
+			return errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "invalid filter of %s drop operator", op.ID)
+		}
+
+	case "sample":
+		if op.Field == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "field of %s sample operator cannot be empty", op.ID)
+		}
+		if op.Ratio <= 0 || op.Ratio > 1 {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "ratio of %s sample operator must be greater than 0 and at most 1", op.ID)
+		}
+
+	case "lookup":
+		if op.Field == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "field of %s lookup operator cannot be empty", op.ID)
+		}
+		if op.LookupTable == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "lookup table of %s lookup operator cannot be empty", op.ID)
+		}
+		if op.ParseTo != "" && op.ParseTo != "attributes" && op.ParseTo != "resource" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "parse to of %s lookup operator must be attributes or resource", op.ID)
+		}
+
 	default:
 		return errors.NewInvalidInputf(
 			errors.CodeInvalidInput,
-			"operator type %s not supported for %s, use one of (grok_parser, regex_parser, copy, move, add, remove, trace_parser, retain)",
+			"operator type %s not supported for %s, use one of (grok_parser, regex_parser, copy, move, add, remove, trace_parser, retain, kv_parser, redact, drop, sample, lookup)",
 			op.Type, op.ID,
 		)
 	}
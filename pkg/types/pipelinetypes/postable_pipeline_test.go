@@ -360,6 +360,157 @@ var operatorTest = []struct {
 			OverwriteSeverityText: true,
 		},
 		IsValid: false,
+	}, {
+		Name: "KV Parser - valid",
+		Operator: PipelineOperator{
+			ID:        "kv",
+			Type:      "kv_parser",
+			ParseFrom: "body",
+			ParseTo:   "attributes",
+		},
+		IsValid: true,
+	}, {
+		Name: "KV Parser - Parse from is required",
+		Operator: PipelineOperator{
+			ID:      "kv",
+			Type:    "kv_parser",
+			ParseTo: "attributes",
+		},
+		IsValid: false,
+	}, {
+		Name: "KV Parser - delimiters must differ",
+		Operator: PipelineOperator{
+			ID:            "kv",
+			Type:          "kv_parser",
+			ParseFrom:     "body",
+			Delimiter:     ":",
+			PairDelimiter: ":",
+		},
+		IsValid: false,
+	}, {
+		Name: "Redact - valid",
+		Operator: PipelineOperator{
+			ID:       "redact",
+			Type:     "redact",
+			Field:    "body",
+			Patterns: []string{RedactPatternEmail, RedactPatternCreditCard},
+			Regex:    "token-[a-z0-9]+",
+			Action:   RedactActionRemove,
+		},
+		IsValid: true,
+	}, {
+		Name: "Redact - hash",
+		Operator: PipelineOperator{
+			ID:       "redact",
+			Type:     "redact",
+			Field:    "attributes.user",
+			Patterns: []string{RedactPatternEmail},
+			Action:   RedactActionHash,
+		},
+		IsValid: true,
+	}, {
+		Name: "Redact - unknown pattern",
+		Operator: PipelineOperator{
+			ID:       "redact",
+			Type:     "redact",
+			Field:    "body",
+			Patterns: []string{"passport"},
+			Action:   RedactActionMask,
+		},
+		IsValid: false,
+	}, {
+		Name: "Redact - a pattern or regex is required",
+		Operator: PipelineOperator{
+			ID:     "redact",
+			Type:   "redact",
+			Field:  "body",
+			Action: RedactActionMask,
+		},
+		IsValid: false,
+	}, {
+		Name: "Redact - invalid action",
+		Operator: PipelineOperator{
+			ID:       "redact",
+			Type:     "redact",
+			Field:    "attributes.user",
+			Patterns: []string{RedactPatternEmail},
+			Action:   "encrypt",
+		},
+		IsValid: false,
+	}, {
+		Name: "Drop - valid",
+		Operator: PipelineOperator{
+			ID:   "drop",
+			Type: "drop",
+			Filter: &v3.FilterSet{
+				Operator: "AND",
+				Items: []v3.FilterItem{
+					{
+						Key: v3.AttributeKey{
+							Key:      "level",
+							DataType: v3.AttributeKeyDataTypeString,
+							Type:     v3.AttributeKeyTypeTag,
+						},
+						Operator: "=",
+						Value:    "debug",
+					},
+				},
+			},
+		},
+		IsValid: true,
+	}, {
+		Name: "Drop - filter is required",
+		Operator: PipelineOperator{
+			ID:     "drop",
+			Type:   "drop",
+			Filter: &v3.FilterSet{Operator: "AND"},
+		},
+		IsValid: false,
+	}, {
+		Name: "Sample - valid",
+		Operator: PipelineOperator{
+			ID:    "sample",
+			Type:  "sample",
+			Field: "attributes.user_id",
+			Ratio: 0.25,
+		},
+		IsValid: true,
+	}, {
+		Name: "Sample - ratio out of range",
+		Operator: PipelineOperator{
+			ID:    "sample",
+			Type:  "sample",
+			Field: "attributes.user_id",
+			Ratio: 1.5,
+		},
+		IsValid: false,
+	}, {
+		Name: "Lookup - valid",
+		Operator: PipelineOperator{
+			ID:          "lookup",
+			Type:        "lookup",
+			Field:       "attributes.customer_id",
+			LookupTable: "tenants",
+		},
+		IsValid: true,
+	}, {
+		Name: "Lookup - table is required",
+		Operator: PipelineOperator{
+			ID:    "lookup",
+			Type:  "lookup",
+			Field: "attributes.customer_id",
+		},
+		IsValid: false,
+	}, {
+		Name: "Lookup - parse to must be attributes or resource",
+		Operator: PipelineOperator{
+			ID:          "lookup",
+			Type:        "lookup",
+			Field:       "attributes.customer_id",
+			ParseTo:     "body.tenant",
+			LookupTable: "tenants",
+		},
+		IsValid: false,
 	},
 }
 
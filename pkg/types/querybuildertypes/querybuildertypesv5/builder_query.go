@@ -30,6 +30,12 @@ type QueryBuilderQuery[T any] struct {
 	// search query is simple string
 	Filter *Filter `json:"filter,omitempty"`
 
+	// sub query filters restrict the query to the values returned by builder_sub_query queries
+	SubQueryFilters []SubQueryFilter `json:"subQueryFilters,omitempty"`
+
+	// lookups join org lookup tables to make their columns available as lookup.<table>.<column>
+	Lookups []LookupJoin `json:"lookups,omitempty"`
+
 	// group by keys to group by
 	GroupBy []GroupByKey `json:"groupBy,omitempty"`
 
@@ -116,6 +122,20 @@ func (q QueryBuilderQuery[T]) Copy() QueryBuilderQuery[T] {
 		c.Filter = q.Filter.Copy()
 	}
 
+	if q.SubQueryFilters != nil {
+		c.SubQueryFilters = make([]SubQueryFilter, len(q.SubQueryFilters))
+		for i, f := range q.SubQueryFilters {
+			c.SubQueryFilters[i] = f.Copy()
+		}
+	}
+
+	if q.Lookups != nil {
+		c.Lookups = make([]LookupJoin, len(q.Lookups))
+		for i, l := range q.Lookups {
+			c.Lookups[i] = l.Copy()
+		}
+	}
+
 	if q.LimitBy != nil {
 		c.LimitBy = q.LimitBy.Copy()
 	}
@@ -164,6 +184,16 @@ func (q *QueryBuilderQuery[T]) Normalize() {
 		q.Order[idx].Key.Normalize()
 	}
 
+	// normalize sub query filter keys
+	for idx := range q.SubQueryFilters {
+		q.SubQueryFilters[idx].Key.Normalize()
+	}
+
+	// normalize lookup keys
+	for idx := range q.Lookups {
+		q.Lookups[idx].Key.Normalize()
+	}
+
 	// normalize secondary aggregations
 	for idx := range q.SecondaryAggregations {
 		for jdx := range q.SecondaryAggregations[idx].Order {
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"maps"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 )
 
 var _ cachetypes.Cacheable = (*CachedData)(nil)
@@ -16,6 +18,9 @@ type CachedBucket struct {
 	Type    RequestType     `json:"type"`
 	Value   json.RawMessage `json:"value"`
 	Stats   ExecStats       `json:"stats"`
+	// CachedAtMs is when the bucket was cached, it is 0 for buckets cached
+	// before it was recorded.
+	CachedAtMs uint64 `json:"cachedAtMs,omitempty"`
 }
 
 func (c *CachedBucket) Clone() *CachedBucket {
@@ -30,6 +35,7 @@ func (c *CachedBucket) Clone() *CachedBucket {
 			DurationMS:    c.Stats.DurationMS,
 			StepIntervals: maps.Clone(c.Stats.StepIntervals),
 		},
+		CachedAtMs: c.CachedAtMs,
 	}
 }
 
@@ -77,3 +83,70 @@ func (c *CachedData) Cost() int64 {
 	}
 	return size
 }
+
+var _ cachetypes.Cacheable = (*CachedInvalidation)(nil)
+
+// CachedInvalidation marks the buckets of a signal which overlap [StartMs, EndMs)
+// as stale if they were cached at or before InvalidatedAtMs, e.g. because late
+// data was ingested for that time range.
+type CachedInvalidation struct {
+	Signal          telemetrytypes.Signal `json:"signal"`
+	StartMs         uint64                `json:"startMs"`
+	EndMs           uint64                `json:"endMs"`
+	InvalidatedAtMs uint64                `json:"invalidatedAtMs"`
+}
+
+func (c *CachedInvalidation) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+func (c *CachedInvalidation) MarshalBinary() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Invalidates reports whether the bucket of a query reading the given signals
+// is stale. A query with no known signals is invalidated by every signal.
+func (c *CachedInvalidation) Invalidates(signals []telemetrytypes.Signal, bucket *CachedBucket) bool {
+	if bucket.CachedAtMs > c.InvalidatedAtMs {
+		return false
+	}
+
+	if bucket.StartMs >= c.EndMs || c.StartMs >= bucket.EndMs {
+		return false
+	}
+
+	if len(signals) == 0 {
+		return true
+	}
+
+	for _, signal := range signals {
+		if signal == c.Signal {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PostableCachePurge is the request to purge the cached query results of an org
+// for a signal and time range.
+type PostableCachePurge struct {
+	Signal telemetrytypes.Signal `json:"signal" required:"true"`
+	// Start and End are epoch milliseconds.
+	Start uint64 `json:"start" required:"true"`
+	End   uint64 `json:"end" required:"true"`
+}
+
+func (p *PostableCachePurge) Validate() error {
+	switch p.Signal {
+	case telemetrytypes.SignalTraces, telemetrytypes.SignalLogs, telemetrytypes.SignalMetrics:
+	default:
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "signal must be one of traces, logs or metrics, got %q", p.Signal.StringValue())
+	}
+
+	if p.Start >= p.End {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start must be before end")
+	}
+
+	return nil
+}
@@ -1,10 +1,17 @@
 package querybuildertypesv5
 
 import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
+var joinConditionSplitRe = regexp.MustCompile(`(?i)\s+and\s+`)
+
 // JoinType is the SQL‐style join operator.
 type JoinType struct{ valuer.String }
 
@@ -120,3 +127,261 @@ func (q QueryBuilderJoin) Copy() QueryBuilderJoin {
 
 	return c
 }
+
+// JoinCondition is a single equality predicate of the join ON clause.
+// Left is the column of the left query and Right the column of the right query.
+type JoinCondition struct {
+	Left  string
+	Right string
+}
+
+// JoinAggregation is a post-join aggregation expression.
+type JoinAggregation struct {
+	Expression string
+	Alias      string
+}
+
+// ParseOn parses the ON clause into a list of equality conditions.
+//
+// The ON clause is a conjunction of equalities between the group by keys of
+// the referenced queries, e.g. `service.name = service.name` or
+// `A.service.name = B.service` AND `A.env = B.deployment.environment`.
+// When a side is prefixed with the name of a referenced query, the prefix
+// decides which query the key belongs to, otherwise the left hand side of the
+// equality refers to the left query.
+func (q QueryBuilderJoin) ParseOn() ([]JoinCondition, error) {
+	on := strings.TrimSpace(q.On)
+	if on == "" {
+		return nil, nil
+	}
+
+	parts := joinConditionSplitRe.Split(on, -1)
+	conditions := make([]JoinCondition, 0, len(parts))
+	for _, part := range parts {
+		sides := strings.Split(part, "=")
+		if len(sides) != 2 {
+			return nil, errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid join condition %q, expected an equality of the form `left_key = right_key`",
+				strings.TrimSpace(part),
+			)
+		}
+
+		lhs, lhsRef := q.splitJoinKey(sides[0])
+		rhs, rhsRef := q.splitJoinKey(sides[1])
+		if lhs == "" || rhs == "" {
+			return nil, errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid join condition %q, both sides must reference a key",
+				strings.TrimSpace(part),
+			)
+		}
+
+		if lhsRef == q.Right.Name && rhsRef != q.Right.Name {
+			lhs, rhs = rhs, lhs
+		} else if lhsRef != "" && lhsRef == rhsRef {
+			return nil, errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid join condition %q, both sides reference query '%s'",
+				strings.TrimSpace(part),
+				lhsRef,
+			)
+		}
+
+		conditions = append(conditions, JoinCondition{Left: lhs, Right: rhs})
+	}
+
+	return conditions, nil
+}
+
+// splitJoinKey strips the optional query name prefix from a key of the ON clause
+// and returns the key along with the name of the query it was prefixed with.
+func (q QueryBuilderJoin) splitJoinKey(key string) (string, string) {
+	key = strings.Trim(strings.TrimSpace(key), "`")
+	for _, ref := range []string{q.Left.Name, q.Right.Name} {
+		if ref != "" && strings.HasPrefix(key, ref+".") {
+			return strings.Trim(strings.TrimPrefix(key, ref+"."), "`"), ref
+		}
+	}
+	return key, ""
+}
+
+// JoinAggregations returns the post-join aggregations.
+//
+// Aggregations are decoded into []any, so each item is either a typed
+// aggregation or the generic JSON object with `expression` and `alias` keys.
+func (q QueryBuilderJoin) JoinAggregations() ([]JoinAggregation, error) {
+	aggregations := make([]JoinAggregation, 0, len(q.Aggregations))
+	for idx, item := range q.Aggregations {
+		var agg JoinAggregation
+		switch v := item.(type) {
+		case JoinAggregation:
+			agg = v
+		case TraceAggregation:
+			agg = JoinAggregation{Expression: v.Expression, Alias: v.Alias}
+		case LogAggregation:
+			agg = JoinAggregation{Expression: v.Expression, Alias: v.Alias}
+		case map[string]any:
+			expression, _ := v["expression"].(string)
+			alias, _ := v["alias"].(string)
+			agg = JoinAggregation{Expression: expression, Alias: alias}
+		default:
+			return nil, errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"unsupported join aggregation at index %d of type %T",
+				idx,
+				item,
+			)
+		}
+
+		if strings.TrimSpace(agg.Expression) == "" {
+			return nil, errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"join aggregation expression at index %d cannot be blank",
+				idx,
+			)
+		}
+		aggregations = append(aggregations, agg)
+	}
+	return aggregations, nil
+}
+
+// Validate checks if the QueryBuilderJoin fields are valid.
+func (q QueryBuilderJoin) Validate() error {
+	if strings.TrimSpace(q.Name) == "" {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"join name cannot be blank",
+		)
+	}
+
+	if q.Left.Name == "" || q.Right.Name == "" {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"join requires both left and right query references",
+		)
+	}
+
+	if q.Left.Name == q.Right.Name {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"join cannot reference query '%s' on both sides",
+			q.Left.Name,
+		)
+	}
+
+	if q.Left.Name == q.Name || q.Right.Name == q.Name {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"join '%s' cannot reference itself",
+			q.Name,
+		)
+	}
+
+	if !slices.Contains(JoinType{}.Enum(), any(q.Type)) {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid join type %q",
+			q.Type.StringValue(),
+		).WithAdditional(
+			"Valid join types are: inner, left, right, full, cross",
+		)
+	}
+
+	conditions, err := q.ParseOn()
+	if err != nil {
+		return err
+	}
+
+	if q.Type == JoinTypeCross && len(conditions) != 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"cross join does not accept an ON condition",
+		)
+	}
+
+	if q.Type != JoinTypeCross && len(conditions) == 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"%s join requires an ON condition",
+			q.Type.StringValue(),
+		)
+	}
+
+	aggregations, err := q.JoinAggregations()
+	if err != nil {
+		return err
+	}
+
+	if len(aggregations) == 0 && len(q.GroupBy) > 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"join group by requires at least one aggregation",
+		)
+	}
+
+	for idx, item := range q.GroupBy {
+		if item.Name == "" {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput, "invalid empty key name for group by at index %d", idx,
+			)
+		}
+	}
+
+	if q.Limit < 0 || q.Limit > MaxQueryLimit {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"limit must be between 0 and %d, got %d",
+			MaxQueryLimit,
+			q.Limit,
+		)
+	}
+
+	for i, fn := range q.Functions {
+		if err := fn.Validate(); err != nil {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid function #%d in join '%s': %s",
+				i+1,
+				q.Name,
+				err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateJoin validates that the queries referenced by the join exist and are builder queries.
+func (q QueryBuilderJoin) ValidateJoin(queries []QueryEnvelope) error {
+	for _, ref := range []QueryRef{q.Left, q.Right} {
+		found := false
+		for _, query := range queries {
+			if query.GetQueryName() != ref.Name {
+				continue
+			}
+			if query.Type != QueryTypeBuilder {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"query '%s' referenced in join '%s' must be a builder query, got %s",
+					ref.Name,
+					q.Name,
+					query.Type.StringValue(),
+				)
+			}
+			found = true
+			break
+		}
+
+		if !found {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"query '%s' referenced in join '%s' does not exist",
+				ref.Name,
+				q.Name,
+			)
+		}
+	}
+
+	return nil
+}
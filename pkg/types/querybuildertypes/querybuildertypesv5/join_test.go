@@ -0,0 +1,131 @@
+package querybuildertypesv5
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilderJoin_ParseOn(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       string
+		expected []JoinCondition
+		wantErr  bool
+	}{
+		{
+			name:     "single condition",
+			on:       "service.name = service.name",
+			expected: []JoinCondition{{Left: "service.name", Right: "service.name"}},
+		},
+		{
+			name:     "query prefixed keys",
+			on:       "A.service.name = B.service",
+			expected: []JoinCondition{{Left: "service.name", Right: "service"}},
+		},
+		{
+			name:     "query prefixed keys in reverse order",
+			on:       "B.service = A.service.name",
+			expected: []JoinCondition{{Left: "service.name", Right: "service"}},
+		},
+		{
+			name: "multiple conditions",
+			on:   "service.name = service.name AND `deployment.environment` = env",
+			expected: []JoinCondition{
+				{Left: "service.name", Right: "service.name"},
+				{Left: "deployment.environment", Right: "env"},
+			},
+		},
+		{
+			name:     "empty condition",
+			on:       "",
+			expected: nil,
+		},
+		{
+			name:    "non equality condition",
+			on:      "service.name > service.name",
+			wantErr: true,
+		},
+		{
+			name:    "missing right key",
+			on:      "service.name = ",
+			wantErr: true,
+		},
+		{
+			name:    "both sides from the same query",
+			on:      "A.service.name = A.env",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			join := QueryBuilderJoin{
+				Name:  "J",
+				Left:  QueryRef{Name: "A"},
+				Right: QueryRef{Name: "B"},
+				Type:  JoinTypeInner,
+				On:    tt.on,
+			}
+			conditions, err := join.ParseOn()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conditions)
+		})
+	}
+}
+
+func TestQueryBuilderJoin_JoinAggregations(t *testing.T) {
+	join := QueryBuilderJoin{
+		Aggregations: []any{
+			map[string]any{"expression": "sum(A) / sum(B)", "alias": "ratio"},
+			LogAggregation{Expression: "max(A.1)"},
+		},
+	}
+
+	aggregations, err := join.JoinAggregations()
+	require.NoError(t, err)
+	assert.Equal(t, []JoinAggregation{
+		{Expression: "sum(A) / sum(B)", Alias: "ratio"},
+		{Expression: "max(A.1)"},
+	}, aggregations)
+
+	join.Aggregations = []any{map[string]any{"alias": "blank"}}
+	_, err = join.JoinAggregations()
+	require.Error(t, err)
+}
+
+func TestCompositeQuery_ValidateJoinReferences(t *testing.T) {
+	logQuery := QueryEnvelope{
+		Type: QueryTypeBuilder,
+		Spec: QueryBuilderQuery[LogAggregation]{
+			Name:         "A",
+			Signal:       telemetrytypes.SignalLogs,
+			Aggregations: []LogAggregation{{Expression: "count()"}},
+		},
+	}
+	join := QueryEnvelope{
+		Type: QueryTypeJoin,
+		Spec: QueryBuilderJoin{
+			Name:  "J",
+			Left:  QueryRef{Name: "A"},
+			Right: QueryRef{Name: "B"},
+			Type:  JoinTypeInner,
+			On:    "service.name = service.name",
+		},
+	}
+
+	err := (&CompositeQuery{Queries: []QueryEnvelope{logQuery, join}}).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query 'B' referenced in join 'J' does not exist")
+
+	promQuery := QueryEnvelope{Type: QueryTypePromQL, Spec: PromQuery{Name: "B", Query: "up"}}
+	err = (&CompositeQuery{Queries: []QueryEnvelope{logQuery, promQuery, join}}).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a builder query")
+}
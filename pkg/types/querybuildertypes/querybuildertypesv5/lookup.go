@@ -0,0 +1,105 @@
+package querybuildertypesv5
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// LookupJoin enriches a builder query at query time with the columns of an org
+// lookup table, matching the key column of the table against the value of a key.
+//
+// For example, to group logs by customer but display the name of the customer
+//
+//	{"table": "tenants", "key": {"name": "customer_id"}}
+//
+// makes the columns of the tenants table available as `lookup.tenants.<column>`
+// in the filter, group by, order by and select fields of the query.
+type LookupJoin struct {
+	// name of the org lookup table
+	Table string `json:"table"`
+	// key of the query whose value is looked up in the key column of the table
+	Key telemetrytypes.TelemetryFieldKey `json:"key"`
+
+	// KeyColumn, Columns and Rows are the ones of the latest version of the
+	// table, resolved by the querier
+	// These fields are not serialized to JSON
+	KeyColumn string     `json:"-"`
+	Columns   []string   `json:"-"`
+	Rows      [][]string `json:"-"`
+	// VersionID identifies the version of the table for caching, resolved by the querier
+	// This field is not serialized to JSON
+	VersionID valuer.UUID `json:"-"`
+}
+
+// Copy creates a copy of the LookupJoin.
+// The resolved rows are shared as they are never modified after resolution.
+func (l LookupJoin) Copy() LookupJoin {
+	return l
+}
+
+// CTEName returns the quoted name of the CTE the rows of the table are inlined
+// into, table names may contain '.' and '-'.
+func (l LookupJoin) CTEName() string {
+	return "`__lookup_" + l.Table + "`"
+}
+
+// IsResolved reports whether the content of the table was resolved by the querier.
+func (l LookupJoin) IsResolved() bool {
+	return !l.VersionID.IsZero()
+}
+
+func (l LookupJoin) Validate() error {
+	if l.Table == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "lookup table is required")
+	}
+	if l.Key.Name == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "lookup key for table '%s' is required", l.Table)
+	}
+	if l.Key.FieldContext == telemetrytypes.FieldContextLookup {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "lookup key for table '%s' cannot be a lookup column", l.Table)
+	}
+	return nil
+}
+
+// validateLookupJoins checks that the lookups of every builder query are
+// supported by its signal and join every table at most once.
+func validateLookupJoins(queries []QueryEnvelope) error {
+	for idx := range queries {
+		envelope := queries[idx]
+		if envelope.Type != QueryTypeBuilder && envelope.Type != QueryTypeSubQuery {
+			continue
+		}
+
+		lookups := envelope.GetLookups()
+		if len(lookups) == 0 {
+			continue
+		}
+
+		queryId := getQueryIdentifier(envelope, idx)
+
+		if signal := envelope.GetSignal(); (signal != telemetrytypes.SignalLogs && signal != telemetrytypes.SignalTraces) || envelope.GetSource() == telemetrytypes.SourceAudit {
+			return wrapValidationError(
+				errors.NewInvalidInputf(errors.CodeInvalidInput, "lookups are only supported for logs and traces queries"),
+				queryId, "invalid %s: %s",
+			)
+		}
+
+		tables := make(map[string]bool, len(lookups))
+		for _, lookup := range lookups {
+			if err := lookup.Validate(); err != nil {
+				return wrapValidationError(err, queryId, "invalid %s: %s")
+			}
+
+			if tables[lookup.Table] {
+				return wrapValidationError(
+					errors.NewInvalidInputf(errors.CodeInvalidInput, "lookup table '%s' is joined more than once", lookup.Table),
+					queryId, "invalid %s: %s",
+				)
+			}
+			tables[lookup.Table] = true
+		}
+	}
+
+	return nil
+}
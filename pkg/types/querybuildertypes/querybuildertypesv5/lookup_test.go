@@ -0,0 +1,108 @@
+package querybuildertypesv5
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupJoin_UnmarshalJSON(t *testing.T) {
+	var query QueryBuilderQuery[LogAggregation]
+	err := json.Unmarshal([]byte(`{
+		"name": "A",
+		"signal": "logs",
+		"lookups": [{"table": "tenants", "key": {"name": "attribute.customer_id"}}],
+		"groupBy": [{"name": "lookup.tenants.customer_name"}]
+	}`), &query)
+	require.NoError(t, err)
+
+	require.Len(t, query.Lookups, 1)
+	assert.Equal(t, "tenants", query.Lookups[0].Table)
+	assert.Equal(t, "`__lookup_tenants`", query.Lookups[0].CTEName())
+	assert.Equal(t, "customer_id", query.Lookups[0].Key.Name)
+	assert.Equal(t, telemetrytypes.FieldContextAttribute, query.Lookups[0].Key.FieldContext)
+	assert.False(t, query.Lookups[0].IsResolved())
+
+	require.Len(t, query.GroupBy, 1)
+	assert.Equal(t, "tenants.customer_name", query.GroupBy[0].Name)
+	assert.Equal(t, telemetrytypes.FieldContextLookup, query.GroupBy[0].FieldContext)
+}
+
+func TestCompositeQuery_ValidateLookupJoins(t *testing.T) {
+	tenants := LookupJoin{Table: "tenants", Key: telemetrytypes.TelemetryFieldKey{Name: "customer_id"}}
+	logQuery := func(lookups ...LookupJoin) QueryEnvelope {
+		return QueryEnvelope{
+			Type: QueryTypeBuilder,
+			Spec: QueryBuilderQuery[LogAggregation]{
+				Name:         "A",
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []LogAggregation{{Expression: "count()"}},
+				Lookups:      lookups,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		queries []QueryEnvelope
+		wantErr string
+	}{
+		{
+			name:    "valid lookup",
+			queries: []QueryEnvelope{logQuery(tenants)},
+		},
+		{
+			name:    "missing table",
+			queries: []QueryEnvelope{logQuery(LookupJoin{Key: telemetrytypes.TelemetryFieldKey{Name: "customer_id"}})},
+			wantErr: "lookup table is required",
+		},
+		{
+			name:    "missing key",
+			queries: []QueryEnvelope{logQuery(LookupJoin{Table: "tenants"})},
+			wantErr: "lookup key for table 'tenants' is required",
+		},
+		{
+			name: "lookup column as key",
+			queries: []QueryEnvelope{logQuery(LookupJoin{
+				Table: "regions",
+				Key:   telemetrytypes.TelemetryFieldKey{Name: "tenants.region", FieldContext: telemetrytypes.FieldContextLookup},
+			})},
+			wantErr: "cannot be a lookup column",
+		},
+		{
+			name:    "table joined twice",
+			queries: []QueryEnvelope{logQuery(tenants, tenants)},
+			wantErr: "lookup table 'tenants' is joined more than once",
+		},
+		{
+			name: "metrics query with lookup",
+			queries: []QueryEnvelope{
+				{
+					Type: QueryTypeBuilder,
+					Spec: QueryBuilderQuery[MetricAggregation]{
+						Name:         "A",
+						Signal:       telemetrytypes.SignalMetrics,
+						Aggregations: []MetricAggregation{{MetricName: "cpu"}},
+						Lookups:      []LookupJoin{tenants},
+					},
+				},
+			},
+			wantErr: "only supported for logs and traces queries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLookupJoins(tt.queries)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -58,3 +58,8 @@ type TraceOperatorStatementBuilder interface {
 	// Build builds the trace operator query.
 	Build(ctx context.Context, start, end uint64, requestType RequestType, query QueryBuilderTraceOperator, compositeQuery *CompositeQuery) (*Statement, error)
 }
+
+type JoinStatementBuilder interface {
+	// Build builds the join query.
+	Build(ctx context.Context, start, end uint64, requestType RequestType, query QueryBuilderJoin, compositeQuery *CompositeQuery, variables map[string]VariableItem) (*Statement, error)
+}
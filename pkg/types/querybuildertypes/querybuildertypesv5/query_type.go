@@ -22,9 +22,8 @@ func (QueryType) Enum() []any {
 	return []any{
 		QueryTypeBuilder,
 		QueryTypeFormula,
-		// Not yet supported.
-		// QueryTypeSubQuery,
-		// QueryTypeJoin,
+		QueryTypeSubQuery,
+		QueryTypeJoin,
 		QueryTypeTraceOperator,
 		QueryTypeClickHouseSQL,
 		QueryTypePromQL,
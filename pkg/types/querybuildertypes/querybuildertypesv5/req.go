@@ -44,10 +44,10 @@ type queryEnvelopeFormula struct {
 }
 
 // queryEnvelopeJoin is the OpenAPI schema for a QueryEnvelope with type=builder_join.
-// type queryEnvelopeJoin struct {
-// 	Type QueryType        `json:"type" description:"The type of the query."`
-// 	Spec QueryBuilderJoin `json:"spec" description:"The join specification."`
-// }
+type queryEnvelopeJoin struct {
+	Type QueryType        `json:"type" description:"The type of the query."`
+	Spec QueryBuilderJoin `json:"spec" description:"The join specification."`
+}
 
 // queryEnvelopeTraceOperator is the OpenAPI schema for a QueryEnvelope with type=builder_trace_operator.
 type queryEnvelopeTraceOperator struct {
@@ -77,7 +77,7 @@ func (QueryEnvelope) JSONSchemaOneOf() []any {
 		queryEnvelopeBuilderLog{},
 		queryEnvelopeBuilderMetric{},
 		queryEnvelopeFormula{},
-		// queryEnvelopeJoin{},
+		queryEnvelopeJoin{},
 		queryEnvelopeTraceOperator{},
 		queryEnvelopePromQL{},
 		queryEnvelopeClickHouseSQL{},
@@ -335,6 +335,13 @@ func (r *QueryRangeRequest) StepIntervalForQuery(name string) (int64, error) {
 		return step, nil
 	}
 
+	// join results are aligned on the larger step of the two sides
+	for _, query := range r.CompositeQuery.Queries {
+		if spec, ok := query.Spec.(QueryBuilderJoin); ok && spec.Name == name {
+			return max(stepsMap[spec.Left.Name], stepsMap[spec.Right.Name]), nil
+		}
+	}
+
 	exprStr := ""
 
 	for _, query := range r.CompositeQuery.Queries {
@@ -377,6 +384,10 @@ func (r *QueryRangeRequest) NumAggregationForQuery(name string) int64 {
 			if spec.Name == name {
 				numAgg += 1
 			}
+		case QueryBuilderJoin:
+			if spec.Name == name {
+				numAgg += 1
+			}
 		}
 	}
 	return int64(numAgg)
@@ -449,32 +460,36 @@ func (q *QueryEnvelope) UseDefaultOrderByForListQuery() {
 			},
 		)
 	case QueryBuilderQuery[LogAggregation]:
-		q.SetOrder(
-			[]OrderBy{
-				{
-					Key: OrderByKey{
-						TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
-							Name:          "timestamp",
-							Signal:        telemetrytypes.SignalLogs,
-							FieldContext:  telemetrytypes.FieldContextLog,
-							FieldDataType: telemetrytypes.FieldDataTypeNumber,
-						},
-					},
-					Direction: OrderDirectionDesc,
+		q.SetOrder(LogsListOrder(OrderDirectionDesc))
+	}
+}
+
+// LogsListOrder orders logs by timestamp, and by id for logs with the same
+// timestamp, in the given direction.
+func LogsListOrder(direction OrderDirection) []OrderBy {
+	return []OrderBy{
+		{
+			Key: OrderByKey{
+				TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+					Name:          "timestamp",
+					Signal:        telemetrytypes.SignalLogs,
+					FieldContext:  telemetrytypes.FieldContextLog,
+					FieldDataType: telemetrytypes.FieldDataTypeNumber,
 				},
-				{
-					Key: OrderByKey{
-						TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
-							Name:          "id",
-							Signal:        telemetrytypes.SignalLogs,
-							FieldContext:  telemetrytypes.FieldContextLog,
-							FieldDataType: telemetrytypes.FieldDataTypeString,
-						},
-					},
-					Direction: OrderDirectionDesc,
+			},
+			Direction: direction,
+		},
+		{
+			Key: OrderByKey{
+				TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+					Name:          "id",
+					Signal:        telemetrytypes.SignalLogs,
+					FieldContext:  telemetrytypes.FieldContextLog,
+					FieldDataType: telemetrytypes.FieldDataTypeString,
 				},
 			},
-		)
+			Direction: direction,
+		},
 	}
 }
 
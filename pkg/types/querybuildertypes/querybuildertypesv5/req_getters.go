@@ -377,3 +377,29 @@ func (q *QueryEnvelope) GetLimitBy() *LimitBy {
 	}
 	return nil
 }
+
+// GetSubQueryFilters returns the sub query filters.
+func (q *QueryEnvelope) GetSubQueryFilters() []SubQueryFilter {
+	switch spec := q.Spec.(type) {
+	case QueryBuilderQuery[TraceAggregation]:
+		return spec.SubQueryFilters
+	case QueryBuilderQuery[LogAggregation]:
+		return spec.SubQueryFilters
+	case QueryBuilderQuery[MetricAggregation]:
+		return spec.SubQueryFilters
+	}
+	return nil
+}
+
+// GetLookups returns the lookup tables joined by the query.
+func (q *QueryEnvelope) GetLookups() []LookupJoin {
+	switch spec := q.Spec.(type) {
+	case QueryBuilderQuery[TraceAggregation]:
+		return spec.Lookups
+	case QueryBuilderQuery[LogAggregation]:
+		return spec.Lookups
+	case QueryBuilderQuery[MetricAggregation]:
+		return spec.Lookups
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package querybuildertypesv5
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const (
+	// DefaultSubQueryLimit is the number of rows a sub query returns when no limit is set.
+	DefaultSubQueryLimit = 1000
+)
+
+// SubQueryFilter restricts a builder query to the values of a key that are
+// returned by a builder_sub_query.
+//
+// For example, to only look at the logs of the top 10 services by p99 latency
+//
+//	{"key": {"name": "service.name"}, "query": "B"}
+//
+// where B is a builder_sub_query grouping traces by service.name, ordered by
+// p99(duration_nano) and limited to 10.
+type SubQueryFilter struct {
+	// key of the query to filter on
+	Key telemetrytypes.TelemetryFieldKey `json:"key"`
+	// name of the builder_sub_query providing the values
+	Query string `json:"query"`
+	// column of the sub query result to match against, defaults to the name of the key
+	Column string `json:"column,omitempty"`
+	// exclude the values returned by the sub query instead of including them
+	Not bool `json:"not,omitempty"`
+
+	// Statement is the compiled sub query, resolved by the querier
+	// This field is not serialized to JSON
+	Statement *Statement `json:"-"`
+}
+
+// Copy creates a copy of the SubQueryFilter.
+// The resolved statement is shared as it is never modified after resolution.
+func (f SubQueryFilter) Copy() SubQueryFilter {
+	return f
+}
+
+// ColumnName returns the column of the sub query result the key is matched against.
+func (f SubQueryFilter) ColumnName() string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return f.Key.Name
+}
+
+// CTEName returns the name of the CTE the sub query is compiled into.
+func (f SubQueryFilter) CTEName() string {
+	return "__sub_query_" + f.Query
+}
+
+func (f SubQueryFilter) Validate() error {
+	if f.Key.Name == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "sub query filter key is required")
+	}
+	if f.Query == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "sub query filter for key `%s` must reference a query", f.Key.Name)
+	}
+	return nil
+}
+
+// validateSubQueryFilters checks that the sub query filters of every builder query
+// reference an existing builder_sub_query which can provide the requested column.
+func validateSubQueryFilters(queries []QueryEnvelope) error {
+	subQueries := make(map[string]QueryEnvelope)
+	for _, envelope := range queries {
+		if envelope.Type == QueryTypeSubQuery {
+			subQueries[envelope.GetQueryName()] = envelope
+		}
+	}
+
+	for idx := range queries {
+		envelope := queries[idx]
+		if envelope.Type != QueryTypeBuilder && envelope.Type != QueryTypeSubQuery {
+			continue
+		}
+
+		filters := envelope.GetSubQueryFilters()
+		if len(filters) == 0 {
+			continue
+		}
+
+		queryId := getQueryIdentifier(envelope, idx)
+
+		if envelope.Type == QueryTypeSubQuery {
+			return wrapValidationError(
+				errors.NewInvalidInputf(errors.CodeInvalidInput, "sub queries cannot use sub query filters"),
+				queryId, "invalid %s: %s",
+			)
+		}
+
+		if signal := envelope.GetSignal(); (signal != telemetrytypes.SignalLogs && signal != telemetrytypes.SignalTraces) || envelope.GetSource() == telemetrytypes.SourceAudit {
+			return wrapValidationError(
+				errors.NewInvalidInputf(errors.CodeInvalidInput, "sub query filters are only supported for logs and traces queries"),
+				queryId, "invalid %s: %s",
+			)
+		}
+
+		for _, filter := range filters {
+			if err := filter.Validate(); err != nil {
+				return wrapValidationError(err, queryId, "invalid %s: %s")
+			}
+
+			subQuery, ok := subQueries[filter.Query]
+			if !ok {
+				return wrapValidationError(
+					errors.NewInvalidInputf(errors.CodeInvalidInput, "sub query '%s' does not exist", filter.Query).
+						WithAdditional("Sub query filters must reference a query of type builder_sub_query"),
+					queryId, "invalid %s: %s",
+				)
+			}
+
+			if limit := subQuery.GetLimit(); limit > MaxQueryLimit {
+				return wrapValidationError(
+					errors.NewInvalidInputf(errors.CodeInvalidInput, "sub query '%s' limit exceeds maximum allowed value of %d", filter.Query, MaxQueryLimit),
+					queryId, "invalid %s: %s",
+				)
+			}
+
+			// aggregated sub queries only return their group by keys
+			groupBy := subQuery.GetGroupBy()
+			if hasAggregations(subQuery) {
+				found := false
+				for _, key := range groupBy {
+					if key.Name == filter.ColumnName() {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return wrapValidationError(
+						errors.NewInvalidInputf(errors.CodeInvalidInput, "sub query '%s' does not group by `%s`", filter.Query, filter.ColumnName()),
+						queryId, "invalid %s: %s",
+					)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasAggregations(envelope QueryEnvelope) bool {
+	switch spec := envelope.Spec.(type) {
+	case QueryBuilderQuery[TraceAggregation]:
+		return len(spec.Aggregations) > 0
+	case QueryBuilderQuery[LogAggregation]:
+		return len(spec.Aggregations) > 0
+	case QueryBuilderQuery[MetricAggregation]:
+		return len(spec.Aggregations) > 0
+	}
+	return false
+}
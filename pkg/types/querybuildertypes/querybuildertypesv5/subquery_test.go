@@ -0,0 +1,146 @@
+package querybuildertypesv5
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubQueryFilter_ColumnName(t *testing.T) {
+	filter := SubQueryFilter{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Query: "B"}
+	assert.Equal(t, "service.name", filter.ColumnName())
+	assert.Equal(t, "__sub_query_B", filter.CTEName())
+
+	filter.Column = "service"
+	assert.Equal(t, "service", filter.ColumnName())
+}
+
+func TestCompositeQuery_ValidateSubQueryFilters(t *testing.T) {
+	subQuery := QueryEnvelope{
+		Type: QueryTypeSubQuery,
+		Spec: QueryBuilderQuery[TraceAggregation]{
+			Name:         "B",
+			Signal:       telemetrytypes.SignalTraces,
+			Aggregations: []TraceAggregation{{Expression: "p99(duration_nano)"}},
+			GroupBy:      []GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}},
+			Limit:        10,
+		},
+	}
+	logQuery := func(filters ...SubQueryFilter) QueryEnvelope {
+		return QueryEnvelope{
+			Type: QueryTypeBuilder,
+			Spec: QueryBuilderQuery[LogAggregation]{
+				Name:            "A",
+				Signal:          telemetrytypes.SignalLogs,
+				Aggregations:    []LogAggregation{{Expression: "count()"}},
+				SubQueryFilters: filters,
+			},
+		}
+	}
+	serviceFilter := SubQueryFilter{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Query: "B"}
+
+	tests := []struct {
+		name    string
+		queries []QueryEnvelope
+		wantErr string
+	}{
+		{
+			name:    "valid sub query filter",
+			queries: []QueryEnvelope{logQuery(serviceFilter), subQuery},
+		},
+		{
+			name:    "missing sub query",
+			queries: []QueryEnvelope{logQuery(SubQueryFilter{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Query: "C"}), subQuery},
+			wantErr: "sub query 'C' does not exist",
+		},
+		{
+			name: "reference to a builder query",
+			queries: []QueryEnvelope{
+				logQuery(serviceFilter),
+				{
+					Type: QueryTypeBuilder,
+					Spec: QueryBuilderQuery[TraceAggregation]{Name: "B", Signal: telemetrytypes.SignalTraces},
+				},
+			},
+			wantErr: "sub query 'B' does not exist",
+		},
+		{
+			name:    "column not grouped by the sub query",
+			queries: []QueryEnvelope{logQuery(SubQueryFilter{Key: telemetrytypes.TelemetryFieldKey{Name: "host.name"}, Query: "B"}), subQuery},
+			wantErr: "sub query 'B' does not group by `host.name`",
+		},
+		{
+			name:    "missing key",
+			queries: []QueryEnvelope{logQuery(SubQueryFilter{Query: "B"}), subQuery},
+			wantErr: "sub query filter key is required",
+		},
+		{
+			name: "sub query limit above maximum",
+			queries: []QueryEnvelope{
+				logQuery(serviceFilter),
+				{
+					Type: QueryTypeSubQuery,
+					Spec: QueryBuilderQuery[TraceAggregation]{Name: "B", Signal: telemetrytypes.SignalTraces, Limit: MaxQueryLimit + 1},
+				},
+			},
+			wantErr: "limit exceeds maximum allowed value",
+		},
+		{
+			name: "nested sub query filter",
+			queries: []QueryEnvelope{
+				logQuery(serviceFilter),
+				subQuery,
+				{
+					Type: QueryTypeSubQuery,
+					Spec: QueryBuilderQuery[LogAggregation]{
+						Name:            "C",
+						Signal:          telemetrytypes.SignalLogs,
+						SubQueryFilters: []SubQueryFilter{serviceFilter},
+					},
+				},
+			},
+			wantErr: "sub queries cannot use sub query filters",
+		},
+		{
+			name: "metrics query with sub query filter",
+			queries: []QueryEnvelope{
+				{
+					Type: QueryTypeBuilder,
+					Spec: QueryBuilderQuery[MetricAggregation]{
+						Name:            "A",
+						Signal:          telemetrytypes.SignalMetrics,
+						Aggregations:    []MetricAggregation{{MetricName: "cpu"}},
+						SubQueryFilters: []SubQueryFilter{serviceFilter},
+					},
+				},
+				subQuery,
+			},
+			wantErr: "only supported for logs and traces queries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSubQueryFilters(tt.queries)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestQueryBuilderQuery_CopySubQueryFilters(t *testing.T) {
+	query := QueryBuilderQuery[LogAggregation]{
+		Name:            "A",
+		SubQueryFilters: []SubQueryFilter{{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Query: "B"}},
+	}
+
+	copied := query.Copy()
+	copied.SubQueryFilters[0].Query = "C"
+	assert.Equal(t, "B", query.SubQueryFilters[0].Query)
+}
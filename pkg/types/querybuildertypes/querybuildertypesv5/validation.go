@@ -538,6 +538,10 @@ func (r *QueryRangeRequest) Validate(opts ...ValidationOption) error {
 // validateAllQueriesNotDisabled validates that at least one query in the composite query is enabled.
 func (r *QueryRangeRequest) validateAllQueriesNotDisabled() error {
 	for _, envelope := range r.CompositeQuery.Queries {
+		// sub queries only run as part of the queries referencing them
+		if envelope.Type == QueryTypeSubQuery {
+			continue
+		}
 		if !envelope.IsDisabled() {
 			return nil
 		}
@@ -583,6 +587,26 @@ func (c *CompositeQuery) Validate(opts ...ValidationOption) error {
 		}
 	}
 
+	// Check that sub query filters reference existing sub queries
+	if err := validateSubQueryFilters(c.Queries); err != nil {
+		return err
+	}
+
+	// Check that lookups are supported by the queries joining them
+	if err := validateLookupJoins(c.Queries); err != nil {
+		return err
+	}
+
+	// Check that joins reference existing builder queries
+	for i, envelope := range c.Queries {
+		if spec, ok := envelope.Spec.(QueryBuilderJoin); ok {
+			if err := spec.ValidateJoin(c.Queries); err != nil {
+				queryId := getQueryIdentifier(envelope, i)
+				return wrapValidationError(err, queryId, "invalid %s: %s")
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -612,14 +636,14 @@ func validateQueryEnvelope(envelope QueryEnvelope, opts ...ValidationOption) err
 		}
 		return spec.Validate()
 	case QueryTypeJoin:
-		_, ok := envelope.Spec.(QueryBuilderJoin)
+		spec, ok := envelope.Spec.(QueryBuilderJoin)
 		if !ok {
 			return errors.NewInvalidInputf(
 				errors.CodeInvalidInput,
 				"invalid join spec",
 			)
 		}
-		return nil
+		return spec.Validate()
 	case QueryTypeTraceOperator:
 		spec, ok := envelope.Spec.(QueryBuilderTraceOperator)
 		if !ok {
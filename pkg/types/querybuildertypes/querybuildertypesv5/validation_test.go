@@ -844,12 +844,47 @@ func TestValidateQueryEnvelope(t *testing.T) {
 			envelope: QueryEnvelope{
 				Type: QueryTypeJoin,
 				Spec: QueryBuilderJoin{
-					Name: "J1",
+					Name:  "J1",
+					Left:  QueryRef{Name: "A"},
+					Right: QueryRef{Name: "B"},
+					Type:  JoinTypeInner,
+					On:    "service.name = service.name",
 				},
 			},
 			requestType: RequestTypeTimeSeries,
 			wantErr:     false,
 		},
+		{
+			name: "join without ON condition should fail",
+			envelope: QueryEnvelope{
+				Type: QueryTypeJoin,
+				Spec: QueryBuilderJoin{
+					Name:  "J1",
+					Left:  QueryRef{Name: "A"},
+					Right: QueryRef{Name: "B"},
+					Type:  JoinTypeLeft,
+				},
+			},
+			requestType: RequestTypeTimeSeries,
+			wantErr:     true,
+			errMsg:      "left join requires an ON condition",
+		},
+		{
+			name: "join referencing the same query on both sides should fail",
+			envelope: QueryEnvelope{
+				Type: QueryTypeJoin,
+				Spec: QueryBuilderJoin{
+					Name:  "J1",
+					Left:  QueryRef{Name: "A"},
+					Right: QueryRef{Name: "A"},
+					Type:  JoinTypeInner,
+					On:    "service.name = service.name",
+				},
+			},
+			requestType: RequestTypeTimeSeries,
+			wantErr:     true,
+			errMsg:      "cannot reference query 'A' on both sides",
+		},
 		{
 			name: "valid trace operator",
 			envelope: QueryEnvelope{
@@ -0,0 +1,99 @@
+package ruletypes
+
+import (
+	"math"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// AbsentDataCondition turns a rule into an absent-data (heartbeat) rule. Instead
+// of comparing values against the thresholds, the rule fires for every group
+// of the selected query that reported data within Lookback but has not
+// reported for at least For, and resolves once the group reports again.
+type AbsentDataCondition struct {
+	// For is how long a group must go without data before the rule fires.
+	// Absence is detected at the granularity of the query step and of the
+	// evaluation frequency, so For must be at least as long as both.
+	For valuer.TextDuration `json:"for" required:"true"`
+
+	// Lookback is how far back the rule looks for groups. A group that has not
+	// reported within Lookback is forgotten and its alert resolves.
+	Lookback valuer.TextDuration `json:"lookback" required:"true"`
+}
+
+func (c *AbsentDataCondition) Validate() error {
+	var errs []error
+
+	if !c.For.IsPositive() {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.absentData.for: must be a positive duration"))
+	}
+
+	if !c.Lookback.IsPositive() {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.absentData.lookback: must be a positive duration"))
+	} else if c.For.IsPositive() && c.Lookback.Duration() <= c.For.Duration() {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.absentData.lookback: must be longer than condition.absentData.for (%s), got %s", c.For.StringValue(), c.Lookback.StringValue()))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateStep rejects a For shorter than step, a group would be reported
+// absent between two of its points.
+func (c *AbsentDataCondition) ValidateStep(name string, step time.Duration) error {
+	if !c.For.IsPositive() || c.For.Duration() >= step {
+		return nil
+	}
+	return errors.NewInvalidInputf(errors.CodeInvalidInput,
+		"condition.absentData.for: must be at least the %s (%s), got %s", name, step, c.For.StringValue())
+}
+
+// Eval returns a missing sample for every series whose last data point is at
+// least For before end. The series are expected to cover the lookback window
+// ending at end. With evalData.SendUnmatched, series that are still reporting
+// are returned too.
+func (c *AbsentDataCondition) Eval(series []*qbtypes.TimeSeries, end time.Time, thresholdName string, evalData EvalData) Vector {
+	var resultVector Vector
+	for _, s := range series {
+		lastSeen, value, ok := lastDataPoint(s)
+		if !ok {
+			continue
+		}
+
+		absent := end.Sub(lastSeen) >= c.For.Duration()
+		if !absent && !evalData.SendUnmatched {
+			continue
+		}
+
+		lb := NewBuilder(PrepareSampleLabelsForRule(s.Labels, thresholdName)...)
+		lb.Set(LabelLastSeen, lastSeen.Format(AlertTimeFormat))
+		resultVector = append(resultVector, Sample{
+			Point:     Point{T: lastSeen.UnixMilli(), V: value},
+			Metric:    lb.Labels(),
+			IsMissing: true,
+		})
+	}
+	return resultVector
+}
+
+// lastDataPoint returns the time and value of the latest point of the series.
+// Partial points count, a group that reported part way through a step is
+// still reporting.
+func lastDataPoint(series *qbtypes.TimeSeries) (time.Time, float64, bool) {
+	var last *qbtypes.TimeSeriesValue
+	for _, v := range series.Values {
+		if v == nil || math.IsNaN(v.Value) || math.IsInf(v.Value, 0) {
+			continue
+		}
+		if last == nil || v.Timestamp > last.Timestamp {
+			last = v
+		}
+	}
+	if last == nil {
+		return time.Time{}, 0, false
+	}
+	return time.UnixMilli(last.Timestamp), last.Value, true
+}
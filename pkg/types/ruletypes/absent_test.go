@@ -0,0 +1,165 @@
+package ruletypes
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func absentSeries(service string, points ...*qbtypes.TimeSeriesValue) *qbtypes.TimeSeries {
+	return &qbtypes.TimeSeries{
+		Labels: []*qbtypes.Label{{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Value: service}},
+		Values: points,
+	}
+}
+
+func TestAbsentDataConditionEval(t *testing.T) {
+	end := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration, value float64) *qbtypes.TimeSeriesValue {
+		return &qbtypes.TimeSeriesValue{Timestamp: end.Add(-ago).UnixMilli(), Value: value}
+	}
+
+	series := []*qbtypes.TimeSeries{
+		absentSeries("checkout", at(40*time.Minute, 3), at(20*time.Minute, 5)),
+		absentSeries("cart", at(40*time.Minute, 1), at(2*time.Minute, 2)),
+		absentSeries("payments", at(30*time.Minute, 7), at(time.Minute, math.NaN())),
+		absentSeries("search", &qbtypes.TimeSeriesValue{Timestamp: end.Add(-time.Minute).UnixMilli(), Value: 1, Partial: true}),
+		absentSeries("empty"),
+	}
+
+	condition := AbsentDataCondition{
+		For:      valuer.MustParseTextDuration("10m"),
+		Lookback: valuer.MustParseTextDuration("1h"),
+	}
+
+	t.Run("absent groups", func(t *testing.T) {
+		vector := condition.Eval(series, end, "critical", EvalData{})
+		require.Len(t, vector, 2)
+
+		assert.Equal(t, "checkout", vector[0].Metric.Get("service.name"))
+		assert.Equal(t, "critical", vector[0].Metric.Get(LabelThresholdName))
+		assert.Equal(t, end.Add(-20*time.Minute).Format(AlertTimeFormat), vector[0].Metric.Get(LabelLastSeen))
+		assert.Equal(t, 5.0, vector[0].V)
+		assert.True(t, vector[0].IsMissing)
+
+		// the NaN point doesn't count as data
+		assert.Equal(t, "payments", vector[1].Metric.Get("service.name"))
+		assert.Equal(t, 7.0, vector[1].V)
+	})
+
+	t.Run("send unmatched", func(t *testing.T) {
+		vector := condition.Eval(series, end, "critical", EvalData{SendUnmatched: true})
+		assert.Len(t, vector, 4)
+	})
+}
+
+func TestValidate_AbsentData(t *testing.T) {
+	absentV1 := func(absentData string) string {
+		return `{
+			"alert": "ServiceStoppedLogging",
+			"version": "v5",
+			"ruleType": "threshold_rule",
+			"labels": {"severity": "warning"},
+			"preferredChannels": ["oncall"],
+			"condition": {
+				"compositeQuery": {"queryType": "builder", "queries": [{"type": "builder_query", "spec": {"name": "A", "signal": "logs", "aggregations": [{"expression": "count()"}], "groupBy": [{"name": "service.name"}], "stepInterval": "1m"}}]},
+				"absentData": ` + absentData + `
+			}
+		}`
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr string
+	}{
+		{
+			name: "valid without target",
+			rule: absentV1(`{"for": "10m", "lookback": "24h"}`),
+		},
+		{
+			name:    "missing for",
+			rule:    absentV1(`{"lookback": "24h"}`),
+			wantErr: "condition.absentData.for: must be a positive duration",
+		},
+		{
+			name:    "lookback not longer than for",
+			rule:    absentV1(`{"for": "1h", "lookback": "30m"}`),
+			wantErr: "condition.absentData.lookback: must be longer than",
+		},
+		{
+			name:    "for shorter than the step",
+			rule:    absentV1(`{"for": "30s", "lookback": "24h"}`),
+			wantErr: "condition.absentData.for: must be at least the stepInterval of query A",
+		},
+		{
+			name:    "for shorter than the evaluation frequency",
+			rule:    patchJSON(absentV1(`{"for": "2m", "lookback": "24h"}`), `{"frequency": "5m"}`),
+			wantErr: "condition.absentData.for: must be at least the evaluation frequency",
+		},
+		{
+			name:    "query without step",
+			rule:    strings.Replace(absentV1(`{"for": "10m", "lookback": "24h"}`), `, "stepInterval": "1m"`, "", 1),
+			wantErr: "condition.absentData: query A must set stepInterval",
+		},
+		{
+			name: "promql query",
+			rule: patchJSON(validV1Promql(), `{"condition": {
+				"compositeQuery": {"queryType": "promql", "queries": [{"type": "promql", "spec": {"name": "A", "query": "up"}}]},
+				"absentData": {"for": "10m", "lookback": "24h"}
+			}}`),
+			wantErr: `condition.absentData: field is only supported for ruleType "threshold_rule"`,
+		},
+		{
+			name:    "anomaly rule",
+			rule:    patchJSON(absentV1(`{"for": "10m", "lookback": "24h"}`), `{"ruleType": "anomaly_rule"}`),
+			wantErr: `condition.absentData: field is only supported for ruleType "threshold_rule"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unmarshalErr, validateErr := unmarshalAndValidate(tt.rule)
+			require.NoError(t, unmarshalErr)
+			if tt.wantErr == "" {
+				assert.NoError(t, validateErr)
+				return
+			}
+			require.Error(t, validateErr)
+			assert.Contains(t, validateErr.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestProcessRuleDefaults_AbsentData(t *testing.T) {
+	j := `{
+		"alert": "ServiceStoppedLogging",
+		"version": "v5",
+		"preferredChannels": ["oncall"],
+		"condition": {
+			"compositeQuery": {"queryType": "builder", "queries": [{"type": "builder_query", "spec": {"name": "A", "signal": "logs", "aggregations": [{"expression": "count()"}], "stepInterval": "1m"}}]},
+			"absentData": {"for": "10m", "lookback": "24h"}
+		}
+	}`
+
+	var rule PostableRule
+	require.NoError(t, json.Unmarshal([]byte(j), &rule))
+	require.NoError(t, rule.Validate())
+
+	assert.Equal(t, RuleTypeThreshold, rule.RuleType)
+	require.NotNil(t, rule.NotificationSettings)
+	assert.Contains(t, rule.NotificationSettings.Renotify.AlertStates, StateNoData)
+
+	threshold, err := rule.RuleCondition.Thresholds.GetRuleThreshold()
+	require.NoError(t, err)
+	assert.Equal(t, []RuleReceivers{{Name: CriticalThresholdName, Channels: []string{"oncall"}}}, threshold.GetRuleReceivers())
+}
@@ -117,12 +117,15 @@ type RuleCondition struct {
 	AbsentFor         uint64               `json:"absentFor,omitempty"`
 	MatchType         MatchType            `json:"matchType,omitzero"`
 	TargetUnit        string               `json:"targetUnit,omitempty"`
-	Algorithm         string               `json:"algorithm,omitempty"`
+	Algorithm         AnomalyAlgorithm     `json:"algorithm,omitzero"`
 	Seasonality       Seasonality          `json:"seasonality,omitzero"`
 	SelectedQuery     string               `json:"selectedQueryName,omitempty"`
 	RequireMinPoints  bool                 `json:"requireMinPoints,omitempty"`
 	RequiredNumPoints int                  `json:"requiredNumPoints,omitempty"`
 	Thresholds        *RuleThresholdData   `json:"thresholds,omitempty"`
+	Composite         *CompositeCondition  `json:"composite,omitempty"`
+	AbsentData        *AbsentDataCondition `json:"absentData,omitempty"`
+	Recording         *RecordingCondition  `json:"recording,omitempty"`
 }
 
 func (rc *RuleCondition) SelectedQueryName() string {
@@ -0,0 +1,47 @@
+package ruletypes
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// AnomalyAlgorithm is the algorithm an anomaly rule predicts the expected
+// value of a series with.
+type AnomalyAlgorithm struct {
+	valuer.String
+}
+
+var (
+	// AnomalyAlgorithmStandard predicts from seasonal averages and scores
+	// with the standard deviation of the current season.
+	AnomalyAlgorithmStandard = AnomalyAlgorithm{valuer.NewString("standard")}
+	// AnomalyAlgorithmRobust predicts with the median of past seasons and
+	// scores with the median absolute deviation, spikes do not skew either.
+	AnomalyAlgorithmRobust = AnomalyAlgorithm{valuer.NewString("robust")}
+	// AnomalyAlgorithmHoltWinters predicts with additive triple exponential
+	// smoothing, following level and trend changes.
+	AnomalyAlgorithmHoltWinters = AnomalyAlgorithm{valuer.NewString("holt_winters")}
+	// AnomalyAlgorithmSTL predicts from a seasonal-trend decomposition of
+	// past seasons.
+	AnomalyAlgorithmSTL = AnomalyAlgorithm{valuer.NewString("stl")}
+)
+
+func (AnomalyAlgorithm) Enum() []any {
+	return []any{
+		AnomalyAlgorithmStandard,
+		AnomalyAlgorithmRobust,
+		AnomalyAlgorithmHoltWinters,
+		AnomalyAlgorithmSTL,
+	}
+}
+
+func (a AnomalyAlgorithm) Validate() error {
+	switch a {
+	case AnomalyAlgorithmStandard, AnomalyAlgorithmRobust, AnomalyAlgorithmHoltWinters, AnomalyAlgorithmSTL:
+		return nil
+	default:
+		return errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.algorithm: unsupported value %q; must be one of standard, robust, holt_winters, stl",
+			a.StringValue())
+	}
+}
@@ -232,17 +232,27 @@ func (r *PostableRule) processRuleDefaults() {
 		}
 	}
 
-	if r.RuleCondition != nil && r.RuleCondition.CompositeQuery != nil {
-		switch r.RuleCondition.CompositeQuery.QueryType {
-		case QueryTypeBuilder:
-			if r.RuleType.IsZero() {
-				r.RuleType = RuleTypeThreshold
+	// Composite rules have no query of their own but still need thresholds
+	// to route their alerts.
+	if r.RuleCondition != nil && (r.RuleCondition.CompositeQuery != nil || r.RuleType == RuleTypeComposite) {
+		if r.RuleCondition.CompositeQuery != nil && r.RuleType != RuleTypeComposite {
+			switch r.RuleCondition.CompositeQuery.QueryType {
+			case QueryTypeBuilder:
+				if r.RuleType.IsZero() {
+					r.RuleType = RuleTypeThreshold
+				}
+			case QueryTypePromQL:
+				if r.RuleType != RuleTypeRecording {
+					r.RuleType = RuleTypeProm
+				}
 			}
-		case QueryTypePromQL:
-			r.RuleType = RuleTypeProm
 		}
 
-		if r.SchemaVersion == DefaultSchemaVersion {
+		// Recording rules raise no alerts, they need neither thresholds nor
+		// notification settings.
+		if r.SchemaVersion == DefaultSchemaVersion && r.RuleType == RuleTypeRecording {
+			r.Evaluation = &EvaluationEnvelope{RollingEvaluation, RollingWindow{EvalWindow: r.EvalWindow, Frequency: r.Frequency}}
+		} else if r.SchemaVersion == DefaultSchemaVersion {
 			thresholdName := CriticalThresholdName
 			if r.Labels != nil {
 				if severity, ok := r.Labels["severity"]; ok {
@@ -277,7 +287,7 @@ func (r *PostableRule) processRuleDefaults() {
 					AlertStates:      []AlertState{StateFiring},
 				},
 			}
-			if r.RuleCondition.AlertOnAbsent {
+			if r.RuleCondition.AlertOnAbsent || r.RuleCondition.AbsentData != nil {
 				r.NotificationSettings.Renotify.AlertStates = append(r.NotificationSettings.Renotify.AlertStates, StateNoData)
 			}
 		}
@@ -414,8 +424,34 @@ func (r *PostableRule) Validate() error {
 		}
 	}
 
+	if r.RuleType == RuleTypeAnomaly && !r.RuleCondition.Algorithm.IsZero() {
+		if err := r.RuleCondition.Algorithm.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.RuleType == RuleTypeComposite {
+		errs = append(errs, r.validateComposite()...)
+	} else if r.RuleCondition.Composite != nil {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.composite: field is only supported for ruleType %q", RuleTypeComposite.StringValue()))
+	}
+
+	if r.RuleType == RuleTypeRecording {
+		errs = append(errs, r.validateRecording()...)
+	} else if r.RuleCondition.Recording != nil {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.recording: field is only supported for ruleType %q", RuleTypeRecording.StringValue()))
+	}
+
+	if r.RuleCondition.AbsentData != nil {
+		errs = append(errs, r.validateAbsentData()...)
+	}
+
 	if r.RuleCondition.CompositeQuery == nil {
-		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.compositeQuery: field is required"))
+		if r.RuleType != RuleTypeComposite {
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.compositeQuery: field is required"))
+		}
 	} else {
 		if len(r.RuleCondition.CompositeQuery.Queries) == 0 {
 			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.compositeQuery.queries: must have at least one query"))
@@ -489,6 +525,12 @@ func (r *PostableRule) validateSchemaVersion() []error {
 func (r *PostableRule) validateV1() []error {
 	var errs []error
 
+	// Composite, absent-data and recording rules have nothing to compare
+	// against a target.
+	if r.RuleType == RuleTypeComposite || r.RuleType == RuleTypeRecording || r.RuleCondition.AbsentData != nil {
+		return errs
+	}
+
 	if r.RuleCondition.Target == nil {
 		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
 			"condition.target: field is required for schemaVersion %q", DefaultSchemaVersion))
@@ -534,8 +576,11 @@ func (r *PostableRule) validateV2Alpha1() []error {
 	// 		SchemaVersionV2Alpha1))
 	// }
 
+	// Recording rules raise no alerts, only the evaluation is required.
+	recording := r.RuleType == RuleTypeRecording
+
 	// Require v2alpha1-specific fields
-	if r.RuleCondition.Thresholds == nil {
+	if r.RuleCondition.Thresholds == nil && !recording {
 		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
 			"condition.thresholds: field is required for schemaVersion %q", SchemaVersionV2Alpha1))
 	}
@@ -544,6 +589,9 @@ func (r *PostableRule) validateV2Alpha1() []error {
 		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
 			"evaluation: field is required for schemaVersion %q", SchemaVersionV2Alpha1))
 	}
+	if recording {
+		return errs
+	}
 	if r.NotificationSettings == nil {
 		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
 			"notificationSettings: field is required for schemaVersion %q", SchemaVersionV2Alpha1))
@@ -557,6 +605,115 @@ func (r *PostableRule) validateV2Alpha1() []error {
 	return errs
 }
 
+func (r *PostableRule) validateComposite() []error {
+	var errs []error
+
+	if r.RuleCondition.CompositeQuery != nil {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.compositeQuery: field is not used by ruleType %q; reference other rules in condition.composite instead", RuleTypeComposite.StringValue()))
+	}
+
+	if r.RuleCondition.Composite == nil {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.composite: field is required for ruleType %q", RuleTypeComposite.StringValue()))
+	} else if err := r.RuleCondition.Composite.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := r.validateSingleThreshold(fmt.Sprintf("ruleType %q", RuleTypeComposite.StringValue())); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+func (r *PostableRule) validateRecording() []error {
+	var errs []error
+
+	if r.RuleCondition.Recording == nil {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.recording: field is required for ruleType %q", RuleTypeRecording.StringValue()))
+	} else if err := r.RuleCondition.Recording.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if r.RuleCondition.CompositeQuery != nil {
+		switch r.RuleCondition.CompositeQuery.QueryType {
+		case QueryTypeBuilder, QueryTypePromQL:
+		default:
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+				"condition.compositeQuery.queryType: ruleType %q supports %q and %q queries only",
+				RuleTypeRecording.StringValue(), QueryTypeBuilder.StringValue(), QueryTypePromQL.StringValue()))
+		}
+	}
+
+	return errs
+}
+
+func (r *PostableRule) validateAbsentData() []error {
+	var errs []error
+
+	if r.RuleType != RuleTypeThreshold {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.absentData: field is only supported for ruleType %q", RuleTypeThreshold.StringValue()))
+	}
+
+	if r.RuleCondition.CompositeQuery != nil && r.RuleCondition.CompositeQuery.QueryType != QueryTypeBuilder {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.absentData: field is only supported for %q queries", QueryTypeBuilder.StringValue()))
+	}
+
+	if err := r.RuleCondition.AbsentData.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if r.Evaluation != nil {
+		if evaluation, err := r.Evaluation.GetEvaluation(); err == nil {
+			if err := r.RuleCondition.AbsentData.ValidateStep("evaluation frequency", evaluation.GetFrequency().Duration()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if r.RuleCondition.CompositeQuery != nil {
+		for _, query := range r.RuleCondition.CompositeQuery.Queries {
+			if query.Type != qbtypes.QueryTypeBuilder {
+				continue
+			}
+			// the querier picks the step of a query without one from the
+			// lookback window, the step has to be known to validate for
+			step := query.GetStepInterval()
+			if step.Duration <= 0 {
+				errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+					"condition.absentData: query %s must set stepInterval", query.GetQueryName()))
+				continue
+			}
+			if err := r.RuleCondition.AbsentData.ValidateStep("stepInterval of query "+query.GetQueryName(), step.Duration); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := r.validateSingleThreshold("condition.absentData"); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// validateSingleThreshold rejects more than one threshold for rules whose
+// threshold only names the severity and channels of their alerts; there is
+// no value to rank several thresholds by.
+func (r *PostableRule) validateSingleThreshold(usedBy string) error {
+	if r.RuleCondition.Thresholds == nil {
+		return nil
+	}
+	if thresholds, ok := r.RuleCondition.Thresholds.Spec.(BasicRuleThresholds); ok && len(thresholds) != 1 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.thresholds.spec: %s takes exactly one threshold", usedBy)
+	}
+	return nil
+}
+
 func testTemplateParsing(rl *PostableRule) (errs []error) {
 	if rl.AlertName == "" {
 		// Not an alerting rule.
@@ -0,0 +1,344 @@
+package ruletypes
+
+import (
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// CompositeCondition is the condition of a composite rule. A composite rule
+// fires when a boolean expression over the states of other rules holds, e.g.
+// "high_latency AND error_rate_up AND NOT deploy_in_progress".
+type CompositeCondition struct {
+	// Expression combines the aliases of Rules with AND, OR, NOT and parentheses.
+	Expression string `json:"expression" required:"true"`
+
+	// Rules maps the aliases used in Expression to the rules they stand for.
+	Rules []CompositeRuleRef `json:"rules" required:"true"`
+
+	// MatchLabels lists the labels the alerts of the child rules are joined on.
+	// The expression is evaluated once per distinct set of values of these
+	// labels seen on a firing child alert, and an alias holds for a set when
+	// its rule has a firing alert with the same values. Alerts that don't carry
+	// a label match every value of it. When empty, an alias holds if its rule
+	// has any firing alert. When set, the expression must not hold when none
+	// of the rules fire, e.g. "NOT a".
+	MatchLabels []string `json:"matchLabels,omitempty"`
+}
+
+// CompositeRuleRef binds an alias of a composite expression to a rule.
+type CompositeRuleRef struct {
+	Alias  string `json:"alias" required:"true"`
+	RuleID string `json:"ruleId" required:"true"`
+}
+
+func (c *CompositeCondition) Validate() error {
+	var errs []error
+
+	if strings.TrimSpace(c.Expression) == "" {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.expression: field is required"))
+	}
+
+	if len(c.Rules) == 0 {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.rules: must reference at least one rule"))
+	}
+
+	defined := make(map[string]struct{}, len(c.Rules))
+	for i, ref := range c.Rules {
+		if !isCompositeIdent(ref.Alias) {
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+				"condition.composite.rules[%d].alias: %q must start with a letter or underscore, contain only letters, digits and underscores, and not be AND, OR or NOT", i, ref.Alias))
+		} else if _, ok := defined[ref.Alias]; ok {
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.rules[%d].alias: %q is defined more than once", i, ref.Alias))
+		}
+		defined[ref.Alias] = struct{}{}
+
+		if _, err := valuer.NewUUID(ref.RuleID); err != nil {
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.rules[%d].ruleId: %q is not a valid rule id", i, ref.RuleID))
+		}
+	}
+
+	for _, label := range c.MatchLabels {
+		if !isValidLabelName(label) {
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.matchLabels: invalid label name %q", label))
+		}
+	}
+
+	if strings.TrimSpace(c.Expression) != "" {
+		expr, err := ParseCompositeExpression(c.Expression)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			used := make(map[string]struct{}, len(expr.Aliases()))
+			for _, alias := range expr.Aliases() {
+				used[alias] = struct{}{}
+				if _, ok := defined[alias]; !ok {
+					errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.expression: %q is not defined in condition.composite.rules", alias))
+				}
+			}
+			for _, ref := range c.Rules {
+				if _, ok := used[ref.Alias]; !ok && isCompositeIdent(ref.Alias) {
+					errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.rules: %q is not used in condition.composite.expression", ref.Alias))
+				}
+			}
+			// With match labels, a group only exists for values seen on a firing
+			// child alert, so an expression holding when no rule fires never fires.
+			if len(c.MatchLabels) > 0 && expr.Eval(func(string) bool { return false }) {
+				errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.expression: with condition.composite.matchLabels, %q must not hold when none of the rules fire", c.Expression))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RuleIDs returns the ids of the rules keyed by their alias.
+func (c *CompositeCondition) RuleIDs() map[string]string {
+	ids := make(map[string]string, len(c.Rules))
+	for _, ref := range c.Rules {
+		ids[ref.Alias] = ref.RuleID
+	}
+	return ids
+}
+
+// CompositeExpr is a parsed composite rule expression.
+type CompositeExpr struct {
+	root    compositeNode
+	aliases []string
+}
+
+// Eval reports whether the expression holds, given whether each alias holds.
+func (e *CompositeExpr) Eval(holds func(alias string) bool) bool {
+	return e.root.eval(holds)
+}
+
+// Aliases returns the aliases referenced by the expression in order of first use.
+func (e *CompositeExpr) Aliases() []string {
+	return e.aliases
+}
+
+func (e *CompositeExpr) String() string {
+	return e.root.String()
+}
+
+// ParseCompositeExpression parses a boolean expression over rule aliases.
+// NOT binds tighter than AND, which binds tighter than OR; keywords are case
+// insensitive.
+func ParseCompositeExpression(expression string) (*CompositeExpr, error) {
+	tokens, err := tokenizeComposite(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &compositeParser{tokens: tokens, seen: map[string]struct{}{}}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != compositeTokenEOF {
+		return nil, p.unexpected(tok)
+	}
+
+	return &CompositeExpr{root: root, aliases: p.aliases}, nil
+}
+
+type compositeNode interface {
+	eval(holds func(alias string) bool) bool
+	String() string
+}
+
+type compositeAlias string
+
+func (n compositeAlias) eval(holds func(alias string) bool) bool { return holds(string(n)) }
+func (n compositeAlias) String() string                          { return string(n) }
+
+type compositeNot struct{ operand compositeNode }
+
+func (n compositeNot) eval(holds func(alias string) bool) bool { return !n.operand.eval(holds) }
+func (n compositeNot) String() string                          { return "NOT " + n.operand.String() }
+
+type compositeAnd struct{ left, right compositeNode }
+
+func (n compositeAnd) eval(holds func(alias string) bool) bool {
+	return n.left.eval(holds) && n.right.eval(holds)
+}
+func (n compositeAnd) String() string {
+	return "(" + n.left.String() + " AND " + n.right.String() + ")"
+}
+
+type compositeOr struct{ left, right compositeNode }
+
+func (n compositeOr) eval(holds func(alias string) bool) bool {
+	return n.left.eval(holds) || n.right.eval(holds)
+}
+func (n compositeOr) String() string {
+	return "(" + n.left.String() + " OR " + n.right.String() + ")"
+}
+
+type compositeTokenKind int
+
+const (
+	compositeTokenEOF compositeTokenKind = iota
+	compositeTokenIdent
+	compositeTokenAnd
+	compositeTokenOr
+	compositeTokenNot
+	compositeTokenLParen
+	compositeTokenRParen
+)
+
+type compositeToken struct {
+	kind compositeTokenKind
+	text string
+	pos  int
+}
+
+func tokenizeComposite(expression string) ([]compositeToken, error) {
+	var tokens []compositeToken
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, compositeToken{kind: compositeTokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, compositeToken{kind: compositeTokenRParen, text: ")", pos: i})
+			i++
+		case isCompositeIdentStart(c):
+			start := i
+			for i < len(expression) && isCompositeIdentPart(expression[i]) {
+				i++
+			}
+			text := expression[start:i]
+			kind := compositeTokenIdent
+			switch strings.ToUpper(text) {
+			case "AND":
+				kind = compositeTokenAnd
+			case "OR":
+				kind = compositeTokenOr
+			case "NOT":
+				kind = compositeTokenNot
+			}
+			tokens = append(tokens, compositeToken{kind: kind, text: text, pos: start})
+		default:
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.expression: unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(tokens, compositeToken{kind: compositeTokenEOF, pos: len(expression)}), nil
+}
+
+type compositeParser struct {
+	tokens  []compositeToken
+	pos     int
+	aliases []string
+	seen    map[string]struct{}
+}
+
+func (p *compositeParser) peek() compositeToken {
+	return p.tokens[p.pos]
+}
+
+func (p *compositeParser) next() compositeToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != compositeTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *compositeParser) unexpected(tok compositeToken) error {
+	if tok.kind == compositeTokenEOF {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.expression: unexpected end of expression")
+	}
+	return errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.composite.expression: unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func (p *compositeParser) parseOr() (compositeNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == compositeTokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = compositeOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *compositeParser) parseAnd() (compositeNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == compositeTokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = compositeAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *compositeParser) parseUnary() (compositeNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case compositeTokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return compositeNot{operand: operand}, nil
+	case compositeTokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != compositeTokenRParen {
+			return nil, p.unexpected(closing)
+		}
+		return inner, nil
+	case compositeTokenIdent:
+		if _, ok := p.seen[tok.text]; !ok {
+			p.seen[tok.text] = struct{}{}
+			p.aliases = append(p.aliases, tok.text)
+		}
+		return compositeAlias(tok.text), nil
+	default:
+		return nil, p.unexpected(tok)
+	}
+}
+
+func isCompositeIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isCompositeIdentPart(c byte) bool {
+	return isCompositeIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// isCompositeIdent reports whether s can be used as an alias in a composite
+// expression.
+func isCompositeIdent(s string) bool {
+	if s == "" || !isCompositeIdentStart(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isCompositeIdentPart(s[i]) {
+			return false
+		}
+	}
+	switch strings.ToUpper(s) {
+	case "AND", "OR", "NOT":
+		return false
+	}
+	return true
+}
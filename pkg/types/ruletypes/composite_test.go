@@ -0,0 +1,250 @@
+package ruletypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompositeExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       string
+		aliases    []string
+	}{
+		{
+			name:       "single alias",
+			expression: "high_latency",
+			want:       "high_latency",
+			aliases:    []string{"high_latency"},
+		},
+		{
+			name:       "and with not",
+			expression: "high_latency AND error_rate_up AND NOT deploy_in_progress",
+			want:       "((high_latency AND error_rate_up) AND NOT deploy_in_progress)",
+			aliases:    []string{"high_latency", "error_rate_up", "deploy_in_progress"},
+		},
+		{
+			name:       "and binds tighter than or",
+			expression: "a OR b AND c",
+			want:       "(a OR (b AND c))",
+			aliases:    []string{"a", "b", "c"},
+		},
+		{
+			name:       "parentheses",
+			expression: "(a OR b) AND c",
+			want:       "((a OR b) AND c)",
+			aliases:    []string{"a", "b", "c"},
+		},
+		{
+			name:       "case insensitive keywords and repeated alias",
+			expression: "not a and (b or a)",
+			want:       "(NOT a AND (b OR a))",
+			aliases:    []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseCompositeExpression(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.String())
+			assert.Equal(t, tt.aliases, expr.Aliases())
+		})
+	}
+}
+
+func TestParseCompositeExpressionErrors(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"a AND",
+		"a b",
+		"(a OR b",
+		"a OR b)",
+		"NOT",
+		"a && b",
+		"AND a",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := ParseCompositeExpression(expression)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompositeExprEval(t *testing.T) {
+	expr, err := ParseCompositeExpression("high_latency AND error_rate_up AND NOT deploy_in_progress")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		firing map[string]bool
+		want   bool
+	}{
+		{name: "all conditions met", firing: map[string]bool{"high_latency": true, "error_rate_up": true}, want: true},
+		{name: "deploy in progress", firing: map[string]bool{"high_latency": true, "error_rate_up": true, "deploy_in_progress": true}, want: false},
+		{name: "missing error rate", firing: map[string]bool{"high_latency": true}, want: false},
+		{name: "nothing firing", firing: map[string]bool{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expr.Eval(func(alias string) bool { return tt.firing[alias] }))
+		})
+	}
+}
+
+func validComposite() string {
+	return `{
+		"alert": "CheckoutDegraded",
+		"version": "v5",
+		"ruleType": "composite_rule",
+		"labels": {"severity": "warning"},
+		"preferredChannels": ["oncall"],
+		"condition": {
+			"composite": {
+				"expression": "high_latency AND NOT deploy_in_progress",
+				"rules": [
+					{"alias": "high_latency", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"},
+					{"alias": "deploy_in_progress", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1c"}
+				],
+				"matchLabels": ["service.name"]
+			}
+		}
+	}`
+}
+
+func TestValidate_Composite(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			condition: "",
+		},
+		{
+			name:      "missing composite",
+			condition: `{}`,
+			wantErr:   "condition.composite: field is required",
+		},
+		{
+			name:      "with compositeQuery",
+			condition: `{"compositeQuery": {"queryType": "promql", "queries": [{"type": "promql", "spec": {"name": "A", "query": "up"}}]}, "composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}]}}`,
+			wantErr:   "condition.compositeQuery: field is not used",
+		},
+		{
+			name:      "undefined alias",
+			condition: `{"composite": {"expression": "a AND b", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}]}}`,
+			wantErr:   `"b" is not defined`,
+		},
+		{
+			name:      "unused alias",
+			condition: `{"composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}, {"alias": "b", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1c"}]}}`,
+			wantErr:   `"b" is not used`,
+		},
+		{
+			name:      "duplicate alias",
+			condition: `{"composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}, {"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1c"}]}}`,
+			wantErr:   "defined more than once",
+		},
+		{
+			name:      "keyword alias",
+			condition: `{"composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}, {"alias": "not", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1c"}]}}`,
+			wantErr:   "condition.composite.rules[1].alias",
+		},
+		{
+			name:      "invalid rule id",
+			condition: `{"composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "not-a-uuid"}]}}`,
+			wantErr:   "is not a valid rule id",
+		},
+		{
+			name:      "invalid expression",
+			condition: `{"composite": {"expression": "a AND", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}]}}`,
+			wantErr:   "unexpected end of expression",
+		},
+		{
+			name:      "invalid match label",
+			condition: `{"composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}], "matchLabels": ["1abc"]}}`,
+			wantErr:   "invalid label name",
+		},
+		{
+			name:      "match labels with negated expression",
+			condition: `{"composite": {"expression": "NOT a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}], "matchLabels": ["service"]}}`,
+			wantErr:   "must not hold when none of the rules fire",
+		},
+		{
+			name:      "match labels with negated alias",
+			condition: `{"composite": {"expression": "a AND NOT b", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}, {"alias": "b", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1c"}], "matchLabels": ["service"]}}`,
+		},
+		{
+			name:      "negated expression without match labels",
+			condition: `{"composite": {"expression": "NOT a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := validComposite()
+			if tt.condition != "" {
+				j = patchJSON(j, `{"condition": `+tt.condition+`}`)
+			}
+			unmarshalErr, validateErr := unmarshalAndValidate(j)
+			require.NoError(t, unmarshalErr)
+			if tt.wantErr == "" {
+				assert.NoError(t, validateErr)
+				return
+			}
+			require.Error(t, validateErr)
+			assert.Contains(t, validateErr.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidate_CompositeOnlyForCompositeRules(t *testing.T) {
+	j := patchJSON(validV1Promql(), `{"condition": {
+		"compositeQuery": {"queryType": "promql", "queries": [{"type": "promql", "spec": {"name": "A", "query": "up == 0"}}]},
+		"target": 1.0, "matchType": "1", "op": "1",
+		"composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}]}
+	}}`)
+	unmarshalErr, validateErr := unmarshalAndValidate(j)
+	require.NoError(t, unmarshalErr)
+	require.Error(t, validateErr)
+	assert.Contains(t, validateErr.Error(), "condition.composite: field is only supported")
+}
+
+func TestProcessRuleDefaults_Composite(t *testing.T) {
+	var rule PostableRule
+	require.NoError(t, json.Unmarshal([]byte(validComposite()), &rule))
+
+	assert.Equal(t, RuleTypeComposite, rule.RuleType)
+	require.NotNil(t, rule.Evaluation)
+	require.NotNil(t, rule.NotificationSettings)
+
+	threshold, err := rule.RuleCondition.Thresholds.GetRuleThreshold()
+	require.NoError(t, err)
+	assert.Equal(t, []RuleReceivers{{Name: "warning", Channels: []string{"oncall"}}}, threshold.GetRuleReceivers())
+}
+
+func TestValidate_CompositeTakesOneThreshold(t *testing.T) {
+	j := patchJSON(validComposite(), `{
+		"schemaVersion": "v2alpha1",
+		"condition": {
+			"composite": {"expression": "a", "rules": [{"alias": "a", "ruleId": "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a1b"}]},
+			"thresholds": {"kind": "basic", "spec": [
+				{"name": "critical", "target": 1.0, "matchType": "1", "op": "1", "channels": ["oncall"]},
+				{"name": "warning", "target": 1.0, "matchType": "1", "op": "1", "channels": ["slack"]}
+			]}
+		},
+		"evaluation": {"kind": "rolling", "spec": {"evalWindow": "5m", "frequency": "1m"}},
+		"notificationSettings": {"renotify": {"enabled": false}}
+	}`)
+	unmarshalErr, validateErr := unmarshalAndValidate(j)
+	require.NoError(t, unmarshalErr)
+	require.Error(t, validateErr)
+	assert.Contains(t, validateErr.Error(), "takes exactly one threshold")
+}
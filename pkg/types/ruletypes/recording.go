@@ -0,0 +1,63 @@
+package ruletypes
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// MaxRecordingBackfill bounds how far back a recording rule fills its metric.
+// A long backfill is spread over the first evaluations of the rule.
+const MaxRecordingBackfill = 7 * 24 * time.Hour
+
+// RecordingCondition turns a rule into a recording rule. Instead of comparing
+// values against thresholds, the rule writes the series of the selected query
+// to a new metric on every evaluation, with one point per evaluation
+// frequency. The metric is a delta sum when the query counts or sums per step,
+// a gauge otherwise. The labels of the rule and its id are added to every
+// series.
+type RecordingCondition struct {
+	// MetricName is the name of the metric the series are written to.
+	MetricName string `json:"metricName" required:"true"`
+
+	// Backfill is how far back the rule writes the series on its first
+	// evaluations, so the metric has history from the start. Points the rule
+	// has already written are not written again.
+	Backfill valuer.TextDuration `json:"backfill,omitzero"`
+}
+
+func (c *RecordingCondition) Validate() error {
+	var errs []error
+
+	if c.MetricName == "" {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.recording.metricName: field is required"))
+	} else if !isValidMetricName(c.MetricName) {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.recording.metricName: %q must start with a letter, '_' or ':' and contain only letters, digits, '_', '.' and ':'", c.MetricName))
+	}
+
+	if c.Backfill.Duration() < 0 {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "condition.recording.backfill: must not be negative"))
+	} else if c.Backfill.Duration() > MaxRecordingBackfill {
+		errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput,
+			"condition.recording.backfill: must be at most %s, got %s", MaxRecordingBackfill, c.Backfill.StringValue()))
+	}
+
+	return errors.Join(errs...)
+}
+
+func isValidMetricName(name string) bool {
+	for i, b := range name {
+		switch {
+		case (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b == '_' || b == ':':
+		case (b >= '0' && b <= '9') || b == '.':
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,118 @@
+package ruletypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_Recording(t *testing.T) {
+	const (
+		builderQuery    = `{"queryType": "builder", "queries": [{"type": "builder_query", "spec": {"name": "A", "signal": "traces", "aggregations": [{"expression": "p99(duration_nano)"}], "groupBy": [{"name": "http.route"}]}}]}`
+		promqlQuery     = `{"queryType": "promql", "queries": [{"type": "promql", "spec": {"name": "A", "query": "sum(rate(http_requests_total[5m])) by (route)"}}]}`
+		clickhouseQuery = `{"queryType": "clickhouse_sql", "queries": [{"type": "clickhouse_sql", "spec": {"name": "A", "query": "SELECT 1"}}]}`
+	)
+
+	recordingRule := func(compositeQuery, recording string) string {
+		return `{
+			"alert": "CheckoutLatencyByRoute",
+			"version": "v5",
+			"ruleType": "recording_rule",
+			"labels": {"team": "checkout"},
+			"condition": {"compositeQuery": ` + compositeQuery + `, "recording": ` + recording + `}
+		}`
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr string
+	}{
+		{
+			name: "valid builder without target",
+			rule: recordingRule(builderQuery, `{"metricName": "checkout:latency_p99:by_route", "backfill": "24h"}`),
+		},
+		{
+			name: "valid promql",
+			rule: recordingRule(promqlQuery, `{"metricName": "http.requests.rate"}`),
+		},
+		{
+			name: "valid v2alpha1 without thresholds and notification settings",
+			rule: patchJSON(recordingRule(builderQuery, `{"metricName": "checkout_latency_p99"}`), `{
+				"schemaVersion": "v2alpha1",
+				"evaluation": {"kind": "rolling", "spec": {"evalWindow": "5m", "frequency": "1m"}}
+			}`),
+		},
+		{
+			name:    "missing recording",
+			rule:    patchJSON(validV1Builder(), `{"ruleType": "recording_rule"}`),
+			wantErr: `condition.recording: field is required for ruleType "recording_rule"`,
+		},
+		{
+			name:    "missing metric name",
+			rule:    recordingRule(builderQuery, `{"backfill": "1h"}`),
+			wantErr: "condition.recording.metricName: field is required",
+		},
+		{
+			name:    "invalid metric name",
+			rule:    recordingRule(builderQuery, `{"metricName": "9lives"}`),
+			wantErr: `condition.recording.metricName: "9lives" must start with a letter`,
+		},
+		{
+			name:    "backfill too long",
+			rule:    recordingRule(builderQuery, `{"metricName": "checkout_latency_p99", "backfill": "720h"}`),
+			wantErr: "condition.recording.backfill: must be at most 168h0m0s, got 720h",
+		},
+		{
+			name:    "clickhouse query",
+			rule:    recordingRule(clickhouseQuery, `{"metricName": "checkout_latency_p99"}`),
+			wantErr: `condition.compositeQuery.queryType: ruleType "recording_rule" supports "builder" and "promql" queries only`,
+		},
+		{
+			name:    "recording on threshold rule",
+			rule:    patchJSON(recordingRule(builderQuery, `{"metricName": "checkout_latency_p99"}`), `{"ruleType": "threshold_rule"}`),
+			wantErr: `condition.recording: field is only supported for ruleType "recording_rule"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unmarshalErr, validateErr := unmarshalAndValidate(tt.rule)
+			require.NoError(t, unmarshalErr)
+			if tt.wantErr == "" {
+				assert.NoError(t, validateErr)
+				return
+			}
+			require.Error(t, validateErr)
+			assert.Contains(t, validateErr.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestProcessRuleDefaults_Recording(t *testing.T) {
+	j := `{
+		"alert": "HTTPRequestRate",
+		"version": "v5",
+		"ruleType": "recording_rule",
+		"condition": {
+			"compositeQuery": {"queryType": "promql", "queries": [{"type": "promql", "spec": {"name": "A", "query": "sum(rate(http_requests_total[5m]))"}}]},
+			"recording": {"metricName": "http_requests:rate5m"}
+		}
+	}`
+
+	var rule PostableRule
+	require.NoError(t, json.Unmarshal([]byte(j), &rule))
+	require.NoError(t, rule.Validate())
+
+	// promql queries don't turn a recording rule into a promql rule
+	assert.Equal(t, RuleTypeRecording, rule.RuleType)
+	assert.Nil(t, rule.RuleCondition.Thresholds)
+	assert.Nil(t, rule.NotificationSettings)
+
+	evaluation, err := rule.Evaluation.GetEvaluation()
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, evaluation.GetFrequency().Duration())
+}
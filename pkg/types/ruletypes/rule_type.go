@@ -13,6 +13,8 @@ var (
 	RuleTypeThreshold = RuleType{valuer.NewString("threshold_rule")}
 	RuleTypeProm      = RuleType{valuer.NewString("promql_rule")}
 	RuleTypeAnomaly   = RuleType{valuer.NewString("anomaly_rule")}
+	RuleTypeComposite = RuleType{valuer.NewString("composite_rule")}
+	RuleTypeRecording = RuleType{valuer.NewString("recording_rule")}
 )
 
 func (RuleType) Enum() []any {
@@ -20,6 +22,8 @@ func (RuleType) Enum() []any {
 		RuleTypeThreshold,
 		RuleTypeProm,
 		RuleTypeAnomaly,
+		RuleTypeComposite,
+		RuleTypeRecording,
 	}
 }
 
@@ -28,9 +32,11 @@ func (r RuleType) Validate() error {
 	case
 		RuleTypeThreshold,
 		RuleTypeProm,
-		RuleTypeAnomaly:
+		RuleTypeAnomaly,
+		RuleTypeComposite,
+		RuleTypeRecording:
 		return nil
 	default:
-		return errors.NewInvalidInputf(errors.CodeInvalidInput, "ruleType: unsupported value %q; must be one of threshold_rule, promql_rule, anomaly_rule", r.StringValue())
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "ruleType: unsupported value %q; must be one of threshold_rule, promql_rule, anomaly_rule, composite_rule, recording_rule", r.StringValue())
 	}
 }
@@ -462,6 +462,32 @@ func TestValidate_PostableRule_Common(t *testing.T) {
 			}`,
 		},
 
+		// anomaly algorithm
+		{
+			name: "valid algorithm holt_winters for anomaly rule",
+			json: `{
+				"alert": "Test", "version": "v5", "ruleType": "anomaly_rule",
+				"condition": {
+					"compositeQuery": {"queryType": "builder", "queries": [{"type": "builder_query", "spec": {"name": "A", "signal": "metrics", "aggregations": [{"metricName": "cpu", "spaceAggregation": "p50"}], "stepInterval": "5m"}}]},
+					"target": 2.0, "matchType": "1", "op": "1",
+					"algorithm": "holt_winters", "seasonality": "daily"
+				}
+			}`,
+		},
+		{
+			name: "invalid algorithm for anomaly rule",
+			json: `{
+				"alert": "Test", "version": "v5", "ruleType": "anomaly_rule",
+				"condition": {
+					"compositeQuery": {"queryType": "builder", "queries": [{"type": "builder_query", "spec": {"name": "A", "signal": "metrics", "aggregations": [{"metricName": "cpu", "spaceAggregation": "p50"}], "stepInterval": "5m"}}]},
+					"target": 2.0, "matchType": "1", "op": "1",
+					"algorithm": "prophet"
+				}
+			}`,
+			wantErr:   true,
+			errSubstr: "algorithm",
+		},
+
 		// scheme version, v1, and v2alpha1
 		{
 			name:      "unsupported schemaVersion v2",
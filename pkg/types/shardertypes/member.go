@@ -0,0 +1,27 @@
+package shardertypes
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/uptrace/bun"
+)
+
+// StorableMember is an instance on the sharder ring. UpdatedAt is the time of
+// its last heartbeat.
+type StorableMember struct {
+	bun.BaseModel `bun:"table:sharder_member,alias:sharder_member"`
+
+	types.TimeAuditable
+	ID string `bun:"id,pk,type:text"`
+}
+
+func NewStorableMember(id string, now time.Time) *StorableMember {
+	return &StorableMember{
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		ID: id,
+	}
+}
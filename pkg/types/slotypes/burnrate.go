@@ -0,0 +1,162 @@
+package slotypes
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	LabelSLOID     = "slo_id"
+	LabelSLOWindow = "slo_window"
+
+	burnRateQueryName = "F1"
+)
+
+// BurnRateAlert is one multi-window alert of the burn rate policy. It fires
+// when both the long and the short window burn the error budget faster than
+// a rate that would use up BudgetConsumed of it within the long window. The
+// short window makes the alert resolve soon after the burn stops.
+type BurnRateAlert struct {
+	Long           valuer.TextDuration
+	Short          valuer.TextDuration
+	BudgetConsumed float64
+	Severity       string
+}
+
+// BurnRatePolicy is the multi-window multi-burn-rate policy from the Google
+// SRE workbook. For a 30 day window the thresholds are 14.4, 6, 3 and 1.
+var BurnRatePolicy = []BurnRateAlert{
+	{Long: valuer.MustParseTextDuration("1h"), Short: valuer.MustParseTextDuration("5m"), BudgetConsumed: 0.02, Severity: ruletypes.CriticalThresholdName},
+	{Long: valuer.MustParseTextDuration("6h"), Short: valuer.MustParseTextDuration("30m"), BudgetConsumed: 0.05, Severity: ruletypes.CriticalThresholdName},
+	{Long: valuer.MustParseTextDuration("24h"), Short: valuer.MustParseTextDuration("2h"), BudgetConsumed: 0.1, Severity: ruletypes.WarningThresholdName},
+	{Long: valuer.MustParseTextDuration("72h"), Short: valuer.MustParseTextDuration("6h"), BudgetConsumed: 0.1, Severity: ruletypes.WarningThresholdName},
+}
+
+// BurnRateWindows returns the distinct windows of the burn rate policy.
+func BurnRateWindows() []valuer.TextDuration {
+	seen := map[time.Duration]struct{}{}
+	windows := make([]valuer.TextDuration, 0, 2*len(BurnRatePolicy))
+	for _, alert := range BurnRatePolicy {
+		for _, window := range []valuer.TextDuration{alert.Long, alert.Short} {
+			if _, ok := seen[window.Duration()]; ok {
+				continue
+			}
+			seen[window.Duration()] = struct{}{}
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+// Threshold returns the burn rate the alert fires above for the SLO.
+func (alert BurnRateAlert) Threshold(slo *SLO) float64 {
+	return alert.BudgetConsumed * float64(slo.Window.Duration()) / float64(alert.Long.Duration())
+}
+
+// NewBurnRateRule returns a threshold rule firing while the burn rate of the
+// SLO over window is above the threshold of the alert. It feeds the composite
+// rule of the alert and notifies the same channels.
+func (alert BurnRateAlert) NewBurnRateRule(slo *SLO, window valuer.TextDuration) *ruletypes.PostableRule {
+	threshold := alert.Threshold(slo)
+	step := qbtypes.Step{Duration: max(time.Minute, (window.Duration() / 12).Truncate(time.Minute))}
+
+	// The counts are summed up across the window so that the last point of the
+	// formula is the burn rate of the whole window, sum(bad)/sum(total), rather
+	// than an average of per-step ratios skewed by steps with little traffic.
+	good, total := slo.Indicator.Good, slo.Indicator.Total
+	for _, query := range []*qbtypes.QueryEnvelope{&good, &total} {
+		query.SetDisabled(true)
+		query.SetStepInterval(step)
+		query.SetFunctions([]qbtypes.Function{{Name: qbtypes.FunctionNameFillZero}, {Name: qbtypes.FunctionNameCumulativeSum}})
+	}
+
+	return &ruletypes.PostableRule{
+		AlertName:   fmt.Sprintf("%s burn rate over %s", slo.Name, window.StringValue()),
+		AlertType:   alertType(good.GetSignal()),
+		Description: fmt.Sprintf("Error budget of SLO %q burning faster than %s over %s", slo.Name, formatFloat(threshold), window.StringValue()),
+		RuleType:    ruletypes.RuleTypeThreshold,
+		EvalWindow:  window,
+		Frequency:   valuer.MustParseTextDuration("1m"),
+		RuleCondition: &ruletypes.RuleCondition{
+			CompositeQuery: &ruletypes.AlertCompositeQuery{
+				Queries: []qbtypes.QueryEnvelope{
+					good,
+					total,
+					{
+						Type: qbtypes.QueryTypeFormula,
+						Spec: qbtypes.QueryBuilderFormula{
+							Name:       burnRateQueryName,
+							Expression: fmt.Sprintf("(%s - %s) / (%s * %s)", TotalQueryName, GoodQueryName, TotalQueryName, formatFloat(slo.ErrorBudget())),
+						},
+					},
+				},
+				PanelType: ruletypes.PanelTypeGraph,
+				QueryType: ruletypes.QueryTypeBuilder,
+			},
+			CompareOperator: ruletypes.ValueIsAbove,
+			Target:          &threshold,
+			MatchType:       ruletypes.Last,
+			SelectedQuery:   burnRateQueryName,
+		},
+		Labels: map[string]string{
+			LabelSLOID:     slo.ID.StringValue(),
+			LabelSLOWindow: window.StringValue(),
+		},
+		PreferredChannels: slo.Alerting.Channels,
+		Version:           "v5",
+	}
+}
+
+// NewCompositeRule returns the rule notifying the channels of the SLO while
+// both burn rate rules of the alert fire.
+func (alert BurnRateAlert) NewCompositeRule(slo *SLO, longRuleID, shortRuleID string) *ruletypes.PostableRule {
+	return &ruletypes.PostableRule{
+		AlertName:   fmt.Sprintf("%s burn rate (%s/%s)", slo.Name, alert.Long.StringValue(), alert.Short.StringValue()),
+		Description: fmt.Sprintf("SLO %q is on track to use %s%% of its error budget within %s", slo.Name, formatFloat(alert.BudgetConsumed*100), alert.Long.StringValue()),
+		RuleType:    ruletypes.RuleTypeComposite,
+		RuleCondition: &ruletypes.RuleCondition{
+			Composite: &ruletypes.CompositeCondition{
+				Expression: "long AND short",
+				Rules: []ruletypes.CompositeRuleRef{
+					{Alias: "long", RuleID: longRuleID},
+					{Alias: "short", RuleID: shortRuleID},
+				},
+			},
+		},
+		Labels: map[string]string{
+			LabelSLOID:     slo.ID.StringValue(),
+			LabelSLOWindow: alert.Long.StringValue(),
+			"severity":     alert.Severity,
+		},
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf("SLO %s is burning its error budget too fast", slo.Name),
+			"description": fmt.Sprintf("The error budget of SLO %s is burning faster than %s over both the last %s and the last %s.", slo.Name, formatFloat(alert.Threshold(slo)), alert.Long.StringValue(), alert.Short.StringValue()),
+		},
+		PreferredChannels: slo.Alerting.Channels,
+		Version:           "v5",
+	}
+}
+
+func alertType(signal telemetrytypes.Signal) ruletypes.AlertType {
+	switch signal {
+	case telemetrytypes.SignalLogs:
+		return ruletypes.AlertTypeLogs
+	case telemetrytypes.SignalTraces:
+		return ruletypes.AlertTypeTraces
+	default:
+		return ruletypes.AlertTypeMetric
+	}
+}
+
+// formatFloat formats f without the noise of floating point arithmetic,
+// 1 - 0.999 is formatted as 0.001.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1e12)/1e12, 'f', -1, 64)
+}
@@ -0,0 +1,277 @@
+package slotypes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeSLONotFound     = errors.MustNewCode("slo_not_found")
+	ErrCodeSLOInvalidInput = errors.MustNewCode("slo_invalid_input")
+)
+
+const (
+	// GoodQueryName and TotalQueryName are the names the indicator queries are
+	// stored and run under, whatever they were named in the request.
+	GoodQueryName  = "A"
+	TotalQueryName = "B"
+)
+
+var (
+	minWindow = 7 * 24 * time.Hour
+	maxWindow = 90 * 24 * time.Hour
+)
+
+// Indicator is the service level indicator of an SLO: the ratio of good events
+// to total events. Both queries must be builder queries with a single
+// aggregation counting the events.
+type Indicator struct {
+	Good  qbtypes.QueryEnvelope `json:"good" required:"true"`
+	Total qbtypes.QueryEnvelope `json:"total" required:"true"`
+}
+
+// Alerting configures the burn rate alerts generated for an SLO.
+type Alerting struct {
+	Enabled  bool     `json:"enabled" required:"true"`
+	Channels []string `json:"channels,omitempty"`
+}
+
+type SLO struct {
+	bun.BaseModel `bun:"table:slo,alias:slo" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID       valuer.UUID `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	Name        string      `bun:"name,type:text,notnull" json:"name" required:"true"`
+	Description string      `bun:"description,type:text" json:"description,omitempty"`
+	Indicator   Indicator   `bun:"indicator,type:text,notnull" json:"indicator" required:"true"`
+	// Target is the objective as a fraction of good events, 0.999 for 99.9%.
+	Target float64 `bun:"target,type:float,notnull" json:"target" required:"true"`
+	// Window is the rolling window the objective is measured over.
+	Window   valuer.TextDuration `bun:"time_window,type:text,notnull" json:"window" required:"true"`
+	Alerting Alerting            `bun:"alerting,type:text,notnull" json:"alerting" required:"true"`
+	// RuleIDs are the ids of the rules generated for the burn rate alerts.
+	RuleIDs []string `bun:"rule_ids,type:text,notnull" json:"ruleIds" required:"true"`
+}
+
+type GettableSLO = SLO
+
+type GettableSLOs struct {
+	SLOs []*GettableSLO `json:"slos" required:"true"`
+}
+
+type PostableSLO struct {
+	Name        string              `json:"name" required:"true"`
+	Description string              `json:"description,omitempty"`
+	Indicator   Indicator           `json:"indicator" required:"true"`
+	Target      float64             `json:"target" required:"true"`
+	Window      valuer.TextDuration `json:"window" required:"true"`
+	Alerting    Alerting            `json:"alerting" required:"true"`
+}
+
+func (slo *PostableSLO) Validate() error {
+	var errs []error
+
+	if strings.TrimSpace(slo.Name) == "" {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeSLOInvalidInput, "name: field is required"))
+	}
+
+	if err := validateIndicatorQuery("indicator.good", slo.Indicator.Good); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateIndicatorQuery("indicator.total", slo.Indicator.Total); err != nil {
+		errs = append(errs, err)
+	}
+
+	if slo.Target <= 0 || slo.Target >= 1 {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeSLOInvalidInput, "target: must be a fraction between 0 and 1 exclusive, got %v", slo.Target))
+	}
+
+	if slo.Window.Duration() < minWindow || slo.Window.Duration() > maxWindow {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeSLOInvalidInput, "window: must be between %s and %s, got %s", minWindow, maxWindow, slo.Window.StringValue()))
+	}
+
+	if slo.Alerting.Enabled && len(slo.Alerting.Channels) == 0 {
+		errs = append(errs, errors.NewInvalidInputf(ErrCodeSLOInvalidInput, "alerting.channels: at least one channel is required when alerting is enabled"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateIndicatorQuery(field string, query qbtypes.QueryEnvelope) error {
+	if query.Type != qbtypes.QueryTypeBuilder {
+		return errors.NewInvalidInputf(ErrCodeSLOInvalidInput, "%s: must be a %q query", field, qbtypes.QueryTypeBuilder.StringValue())
+	}
+
+	if count := aggregationCount(query); count != 1 {
+		return errors.NewInvalidInputf(ErrCodeSLOInvalidInput, "%s: must have exactly one aggregation, got %d", field, count)
+	}
+
+	return nil
+}
+
+func aggregationCount(query qbtypes.QueryEnvelope) int {
+	switch spec := query.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		return len(spec.Aggregations)
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		return len(spec.Aggregations)
+	case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+		return len(spec.Aggregations)
+	}
+	return 0
+}
+
+// normalizeQuery names the query and strips what would split the events into
+// several series; an SLO measures the events as a whole.
+func normalizeQuery(query qbtypes.QueryEnvelope, name string) qbtypes.QueryEnvelope {
+	query.SetQueryName(name)
+	query.SetDisabled(false)
+	query.SetGroupBy(nil)
+	query.SetLimit(0)
+	query.SetOrder(nil)
+	query.SetHaving(nil)
+	return query
+}
+
+func NewSLO(orgID valuer.UUID, createdBy string, postable *PostableSLO) *SLO {
+	now := time.Now()
+	slo := &SLO{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID:   orgID,
+		RuleIDs: []string{},
+	}
+	slo.set(postable)
+	return slo
+}
+
+// Update replaces the definition of the SLO. The generated rules are not
+// touched, the caller updates them.
+func (slo *SLO) Update(updatedBy string, postable *PostableSLO) {
+	slo.set(postable)
+	slo.UpdatedAt = time.Now()
+	slo.UpdatedBy = updatedBy
+}
+
+func (slo *SLO) set(postable *PostableSLO) {
+	slo.Name = postable.Name
+	slo.Description = postable.Description
+	slo.Indicator = Indicator{
+		Good:  normalizeQuery(postable.Indicator.Good, GoodQueryName),
+		Total: normalizeQuery(postable.Indicator.Total, TotalQueryName),
+	}
+	slo.Target = postable.Target
+	slo.Window = postable.Window
+	slo.Alerting = postable.Alerting
+}
+
+// ErrorBudget is the fraction of events allowed to be bad.
+func (slo *SLO) ErrorBudget() float64 {
+	return 1 - slo.Target
+}
+
+// NewQueryRangeRequest returns a scalar request counting the good and total
+// events between start and end.
+func (slo *SLO) NewQueryRangeRequest(start, end time.Time) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:       uint64(start.UnixMilli()),
+		End:         uint64(end.UnixMilli()),
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{slo.Indicator.Good, slo.Indicator.Total},
+		},
+	}
+}
+
+// GettableErrorBudget reports how much of the error budget of an SLO is left
+// over its window.
+type GettableErrorBudget struct {
+	Start int64   `json:"start" required:"true"`
+	End   int64   `json:"end" required:"true"`
+	Good  float64 `json:"good" required:"true"`
+	Total float64 `json:"total" required:"true"`
+	// SLI is the ratio of good to total events, 1 when there were no events.
+	SLI    float64 `json:"sli" required:"true"`
+	Target float64 `json:"target" required:"true"`
+	// Consumed is the fraction of the error budget used up. It exceeds 1 once
+	// the objective is missed.
+	Consumed float64 `json:"consumed" required:"true"`
+	// Remaining is 1 - Consumed and goes negative once the objective is missed.
+	Remaining float64 `json:"remaining" required:"true"`
+}
+
+func NewGettableErrorBudget(slo *SLO, start, end time.Time, good, total float64) *GettableErrorBudget {
+	consumed := slo.badRatio(good, total) / slo.ErrorBudget()
+	return &GettableErrorBudget{
+		Start:     start.UnixMilli(),
+		End:       end.UnixMilli(),
+		Good:      good,
+		Total:     total,
+		SLI:       1 - slo.badRatio(good, total),
+		Target:    slo.Target,
+		Consumed:  consumed,
+		Remaining: 1 - consumed,
+	}
+}
+
+// BurnRate is the rate the error budget was spent at over a window, relative
+// to the rate that spends exactly the whole budget over the SLO window.
+type BurnRate struct {
+	Window   valuer.TextDuration `json:"window" required:"true"`
+	Good     float64             `json:"good" required:"true"`
+	Total    float64             `json:"total" required:"true"`
+	BurnRate float64             `json:"burnRate" required:"true"`
+}
+
+type GettableBurnRates struct {
+	End       int64       `json:"end" required:"true"`
+	BurnRates []*BurnRate `json:"burnRates" required:"true"`
+}
+
+func NewBurnRate(slo *SLO, window valuer.TextDuration, good, total float64) *BurnRate {
+	return &BurnRate{
+		Window:   window,
+		Good:     good,
+		Total:    total,
+		BurnRate: slo.badRatio(good, total) / slo.ErrorBudget(),
+	}
+}
+
+func (slo *SLO) badRatio(good, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return (total - good) / total
+}
+
+type BurnRateQuery struct {
+	// Window restricts the result to a single window. All the windows of the
+	// burn rate policy are returned when it is not set.
+	Window valuer.TextDuration `query:"window" json:"window"`
+}
+
+type Store interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*SLO, error)
+	Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*SLO, error)
+	Create(ctx context.Context, slo *SLO) error
+	Update(ctx context.Context, slo *SLO) error
+	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+}
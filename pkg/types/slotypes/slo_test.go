@@ -0,0 +1,223 @@
+package slotypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func postableSLO(t *testing.T, patch string) *PostableSLO {
+	t.Helper()
+
+	j := `{
+		"name": "Checkout availability",
+		"indicator": {
+			"good": {"type": "builder_query", "spec": {"name": "good", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "service.name = 'checkout' AND has_error = false"}, "groupBy": [{"name": "http.route"}]}},
+			"total": {"type": "builder_query", "spec": {"name": "total", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "service.name = 'checkout'"}}}
+		},
+		"target": 0.999,
+		"window": "720h",
+		"alerting": {"enabled": true, "channels": ["oncall"]}
+	}`
+
+	postable := new(PostableSLO)
+	require.NoError(t, json.Unmarshal([]byte(j), postable))
+	if patch != "" {
+		require.NoError(t, json.Unmarshal([]byte(patch), postable))
+	}
+	return postable
+}
+
+func TestPostableSLOValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:    "missing name",
+			patch:   `{"name": " "}`,
+			wantErr: "name: field is required",
+		},
+		{
+			name:    "promql indicator",
+			patch:   `{"indicator": {"total": {"type": "promql", "spec": {"name": "B", "query": "sum(http_requests_total)"}}}}`,
+			wantErr: `indicator.total: must be a "builder_query" query`,
+		},
+		{
+			name:    "two aggregations",
+			patch:   `{"indicator": {"good": {"type": "builder_query", "spec": {"name": "A", "signal": "logs", "aggregations": [{"expression": "count()"}, {"expression": "count_distinct(trace_id)"}]}}}}`,
+			wantErr: "indicator.good: must have exactly one aggregation, got 2",
+		},
+		{
+			name:    "target as percentage",
+			patch:   `{"target": 99.9}`,
+			wantErr: "target: must be a fraction between 0 and 1",
+		},
+		{
+			name:    "window too short",
+			patch:   `{"window": "24h"}`,
+			wantErr: "window: must be between",
+		},
+		{
+			name:    "alerting without channels",
+			patch:   `{"alerting": {"enabled": true, "channels": []}}`,
+			wantErr: "alerting.channels: at least one channel is required",
+		},
+		{
+			name:  "alerting disabled without channels",
+			patch: `{"alerting": {"enabled": false, "channels": []}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postableSLO(t, tt.patch).Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNewSLONormalizesQueries(t *testing.T) {
+	slo := NewSLO(valuer.GenerateUUID(), "user@signoz.io", postableSLO(t, ""))
+
+	assert.Equal(t, GoodQueryName, slo.Indicator.Good.GetQueryName())
+	assert.Equal(t, TotalQueryName, slo.Indicator.Total.GetQueryName())
+	assert.Empty(t, slo.Indicator.Good.GetGroupBy())
+	assert.Equal(t, []string{}, slo.RuleIDs)
+}
+
+func TestErrorBudgetAndBurnRate(t *testing.T) {
+	slo := NewSLO(valuer.GenerateUUID(), "user@signoz.io", postableSLO(t, ""))
+	end := time.Now()
+	start := end.Add(-slo.Window.Duration())
+
+	t.Run("half the budget consumed", func(t *testing.T) {
+		budget := NewGettableErrorBudget(slo, start, end, 99950, 100000)
+		assert.InDelta(t, 0.9995, budget.SLI, 1e-9)
+		assert.InDelta(t, 0.5, budget.Consumed, 1e-9)
+		assert.InDelta(t, 0.5, budget.Remaining, 1e-9)
+	})
+
+	t.Run("objective missed", func(t *testing.T) {
+		budget := NewGettableErrorBudget(slo, start, end, 99800, 100000)
+		assert.InDelta(t, 2, budget.Consumed, 1e-9)
+		assert.InDelta(t, -1, budget.Remaining, 1e-9)
+	})
+
+	t.Run("no events", func(t *testing.T) {
+		budget := NewGettableErrorBudget(slo, start, end, 0, 0)
+		assert.Equal(t, 1.0, budget.SLI)
+		assert.Equal(t, 1.0, budget.Remaining)
+	})
+
+	t.Run("burn rate", func(t *testing.T) {
+		burnRate := NewBurnRate(slo, valuer.MustParseTextDuration("1h"), 9856, 10000)
+		assert.InDelta(t, 14.4, burnRate.BurnRate, 1e-9)
+	})
+}
+
+func TestBurnRatePolicyThresholds(t *testing.T) {
+	slo := NewSLO(valuer.GenerateUUID(), "user@signoz.io", postableSLO(t, ""))
+
+	thresholds := make([]float64, 0, len(BurnRatePolicy))
+	for _, alert := range BurnRatePolicy {
+		thresholds = append(thresholds, alert.Threshold(slo))
+	}
+	assert.InDeltaSlice(t, []float64{14.4, 6, 3, 1}, thresholds, 1e-9)
+
+	assert.Equal(t, []string{"1h", "5m", "6h", "30m", "24h", "2h", "72h"}, func() []string {
+		windows := []string{}
+		for _, window := range BurnRateWindows() {
+			windows = append(windows, window.StringValue())
+		}
+		return windows
+	}())
+}
+
+// roundTrip sends the rule through JSON the way the ruler receives it.
+func roundTrip(t *testing.T, rule *ruletypes.PostableRule) *ruletypes.PostableRule {
+	t.Helper()
+
+	data, err := json.Marshal(rule)
+	require.NoError(t, err)
+
+	out := new(ruletypes.PostableRule)
+	require.NoError(t, json.Unmarshal(data, out))
+	require.NoError(t, out.Validate())
+	return out
+}
+
+func TestNewBurnRateRule(t *testing.T) {
+	slo := NewSLO(valuer.GenerateUUID(), "user@signoz.io", postableSLO(t, ""))
+	alert := BurnRatePolicy[0]
+
+	rule := roundTrip(t, alert.NewBurnRateRule(slo, alert.Short))
+
+	assert.Equal(t, ruletypes.RuleTypeThreshold, rule.RuleType)
+	assert.Equal(t, ruletypes.AlertTypeTraces, rule.AlertType)
+	assert.Equal(t, "5m", rule.EvalWindow.StringValue())
+	assert.Equal(t, []string{"oncall"}, rule.PreferredChannels)
+	assert.Equal(t, slo.ID.StringValue(), rule.Labels[LabelSLOID])
+	assert.Equal(t, "5m", rule.Labels[LabelSLOWindow])
+
+	condition := rule.RuleCondition
+	assert.Equal(t, "F1", condition.SelectedQuery)
+	assert.Equal(t, ruletypes.ValueIsAbove, condition.CompareOperator)
+	assert.Equal(t, ruletypes.Last, condition.MatchType)
+	require.NotNil(t, condition.Target)
+	assert.InDelta(t, 14.4, *condition.Target, 1e-9)
+
+	queries := condition.CompositeQuery.Queries
+	require.Len(t, queries, 3)
+	assert.True(t, queries[0].IsDisabled())
+	assert.True(t, queries[1].IsDisabled())
+	assert.Equal(t, time.Minute, queries[0].GetStepInterval().Duration)
+	for _, query := range queries[:2] {
+		functions := query.GetFunctions()
+		require.Len(t, functions, 2)
+		assert.Equal(t, qbtypes.FunctionNameFillZero, functions[0].Name)
+		assert.Equal(t, qbtypes.FunctionNameCumulativeSum, functions[1].Name)
+	}
+	formula, ok := queries[2].Spec.(qbtypes.QueryBuilderFormula)
+	require.True(t, ok)
+	assert.Equal(t, "(B - A) / (B * 0.001)", formula.Expression)
+
+	// the stored indicator is left untouched
+	assert.False(t, slo.Indicator.Good.IsDisabled())
+}
+
+func TestNewCompositeRule(t *testing.T) {
+	slo := NewSLO(valuer.GenerateUUID(), "user@signoz.io", postableSLO(t, ""))
+	alert := BurnRatePolicy[2]
+
+	rule := roundTrip(t, alert.NewCompositeRule(slo, "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a01", "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a02"))
+
+	assert.Equal(t, ruletypes.RuleTypeComposite, rule.RuleType)
+	assert.Equal(t, []string{"oncall"}, rule.PreferredChannels)
+	assert.Equal(t, ruletypes.WarningThresholdName, rule.Labels["severity"])
+
+	threshold, err := rule.RuleCondition.Thresholds.GetRuleThreshold()
+	require.NoError(t, err)
+	assert.Equal(t, []ruletypes.RuleReceivers{{Name: ruletypes.WarningThresholdName, Channels: []string{"oncall"}}}, threshold.GetRuleReceivers())
+
+	composite := rule.RuleCondition.Composite
+	require.NotNil(t, composite)
+	assert.Equal(t, "long AND short", composite.Expression)
+	assert.Equal(t, "0196a1b2-7c3d-7e4f-8a5b-6c7d8e9f0a01", composite.Rules[0].RuleID)
+}
@@ -46,6 +46,17 @@ type SpanMapperSource struct {
 // It implements driver.Valuer and sql.Scanner for JSON text column storage.
 type SpanMapperConfig struct {
 	Sources []SpanMapperSource `json:"sources" required:"true" nullable:"true"`
+	Lookup  *SpanMapperLookup  `json:"lookup,omitempty"`
+}
+
+// SpanMapperLookup sets the target from a column of the lookup table row keyed
+// by the value of an attribute. It applies to the spans having the attribute
+// when none of the sources is present, whatever the condition of the group.
+type SpanMapperLookup struct {
+	Table   string       `json:"table" required:"true"`
+	Key     string       `json:"key" required:"true"`
+	Context FieldContext `json:"context" required:"true"`
+	Column  string       `json:"column" required:"true"`
 }
 
 // SpanMapper is the domain model for a span attribute mapper.
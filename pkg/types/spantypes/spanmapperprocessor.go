@@ -1,10 +1,17 @@
 package spantypes
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
+	"github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig/transformprocessor"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
 	"gopkg.in/yaml.v3"
 )
 
@@ -12,6 +19,9 @@ const (
 	SpanAttrMappingFeatureType agentConf.AgentFeatureType = "span_attr_mapping"
 
 	ProcessorName = "signozspanmapper"
+	// LookupProcessorName is the transform processor the lookups of the
+	// mappers are rolled out as, it runs right after signozspanmapper.
+	LookupProcessorName = "transform/signoz_span_lookups"
 )
 
 var (
@@ -51,7 +61,11 @@ type spanMapperProcessorSource struct {
 	Action string `yaml:"action,omitempty" json:"action,omitempty"`
 }
 
-func GenerateCollectorConfigWithSpanMapperProcessor(currentConfYaml []byte, groups []*SpanMapperGroupWithMappers) ([]byte, error) {
+// GenerateCollectorConfigWithSpanMapperProcessor sets the signozspanmapper
+// processor config for the groups. lookupTables holds the entries of the lookup
+// tables of the org by name, the lookups of the mappers are set as the
+// transform processor following signozspanmapper in the pipelines.
+func GenerateCollectorConfigWithSpanMapperProcessor(currentConfYaml []byte, groups []*SpanMapperGroupWithMappers, lookupTables map[string]lookuptabletypes.Entries) ([]byte, error) {
 	var collectorConf map[string]any
 	if err := yaml.Unmarshal(currentConfYaml, &collectorConf); err != nil {
 		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidCollectorConfig, "failed to unmarshal collector config")
@@ -73,8 +87,17 @@ func GenerateCollectorConfigWithSpanMapperProcessor(currentConfYaml []byte, grou
 	procConfig := buildProcessorConfig(groups)
 
 	processors[ProcessorName] = procConfig
+
+	lookupConfig := buildLookupProcessorConfig(groups, lookupTables)
+	if lookupConfig != nil {
+		processors[LookupProcessorName] = lookupConfig
+	} else {
+		delete(processors, LookupProcessorName)
+	}
 	collectorConf["processors"] = processors
 
+	updateLookupPipelines(collectorConf, lookupConfig != nil)
+
 	out, err := yaml.Marshal(collectorConf)
 	if err != nil {
 		return nil, errors.Wrapf(err, errors.TypeInternal, ErrCodeBuildMappingProcessorConfig, "failed to marshal collector config")
@@ -138,3 +161,101 @@ func buildAttributeRule(m *SpanMapper) spanMapperProcessorAttribute {
 		Sources: out,
 	}
 }
+
+// buildLookupProcessorConfig maps the lookups of the mappers to OTTL
+// statements parsing the column of the table into the cache of the span and
+// setting the target to the value of the key in it when none of the sources
+// set it. It returns nil when none of the rows apply.
+func buildLookupProcessorConfig(groups []*SpanMapperGroupWithMappers, lookupTables map[string]lookuptabletypes.Entries) *transformprocessor.Config {
+	var statements []string
+	for _, gm := range groups {
+		for _, m := range gm.Mappers {
+			l := m.Config.Lookup
+			if l == nil {
+				continue
+			}
+
+			target := ottlAttributePath(m.FieldContext, m.Name)
+			key := ottlAttributePath(l.Context, l.Key)
+
+			// tables which don't exist have no rows and leave spans as is.
+			column := map[string]string{}
+			for value, row := range lookupTables[l.Table] {
+				if v, ok := row[l.Column]; ok {
+					column[value] = v
+				}
+			}
+			if len(column) == 0 {
+				continue
+			}
+
+			// the column is looked up in a single map by the value of the key,
+			// keys which are not in the table leave the target unset. OTTL only
+			// indexes the result of a converter with literals, so the map goes
+			// through the cache.
+			table, err := json.Marshal(column)
+			if err != nil {
+				continue
+			}
+
+			condition := fmt.Sprintf("%s == nil and %s != nil", target, key)
+			statements = append(statements,
+				fmt.Sprintf(`set(cache["lookup"], ParseJSON(%s)) where %s`, ottlString(string(table)), condition),
+				fmt.Sprintf(`set(%s, cache["lookup"][%s]) where %s`, target, key, condition),
+			)
+		}
+	}
+
+	if len(statements) == 0 {
+		return nil
+	}
+
+	return &transformprocessor.Config{
+		ErrorMode:       "ignore",
+		TraceStatements: []transformprocessor.ContextStatements{{Context: "span", Statements: statements}},
+	}
+}
+
+// updateLookupPipelines removes the lookup processor from all the pipelines
+// and, when enabled, adds it back right after signozspanmapper.
+func updateLookupPipelines(collectorConf map[string]any, enabled bool) {
+	service, _ := collectorConf["service"].(map[string]any)
+	pipelines, _ := service["pipelines"].(map[string]any)
+
+	for _, value := range pipelines {
+		pipeline, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		current, _ := pipeline["processors"].([]any)
+		processors := make([]string, 0, len(current)+1)
+		for _, p := range current {
+			if p, ok := p.(string); ok && p != LookupProcessorName {
+				processors = append(processors, p)
+			}
+		}
+
+		if idx := slices.Index(processors, ProcessorName); enabled && idx >= 0 {
+			processors = slices.Insert(processors, idx+1, LookupProcessorName)
+		}
+
+		if len(current) == 0 && len(processors) == 0 {
+			continue
+		}
+		pipeline["processors"] = processors
+	}
+}
+
+func ottlAttributePath(context FieldContext, key string) string {
+	if context == FieldContextResource {
+		return fmt.Sprintf("resource.attributes[%s]", ottlString(key))
+	}
+	return fmt.Sprintf("attributes[%s]", ottlString(key))
+}
+
+// ottlString quotes the value as an OTTL string, escaping $ which the
+// collector would otherwise expand as a config variable.
+func ottlString(value string) string {
+	return strings.ReplaceAll(strconv.Quote(value), "$", "$$")
+}
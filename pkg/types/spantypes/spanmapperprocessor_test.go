@@ -1,13 +1,20 @@
 package spantypes
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/lookuptabletypes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
 
@@ -17,9 +24,10 @@ func TestGenerateCollectorConfigWithSpanMapperProcessor(t *testing.T) {
 	baseline := loadFixture(t, "collector_baseline.yaml")
 
 	tests := []struct {
-		name   string
-		groups []*SpanMapperGroupWithMappers
-		want   string
+		name         string
+		groups       []*SpanMapperGroupWithMappers
+		lookupTables map[string]lookuptabletypes.Entries
+		want         string
 	}{
 		{
 			name: "no_groups",
@@ -71,12 +79,45 @@ func TestGenerateCollectorConfigWithSpanMapperProcessor(t *testing.T) {
 			},
 			want: "collector_with_groups.yaml",
 		},
+		{
+			name: "with_lookup",
+			groups: []*SpanMapperGroupWithMappers{
+				{
+					Group: newGroup("tenant", nil, []string{"customer.id"}),
+					Mappers: []*SpanMapper{
+						withLookup(newMapper("tenant.name", FieldContextResource), &SpanMapperLookup{
+							Table:   "tenants",
+							Key:     "customer.id",
+							Context: FieldContextResource,
+							Column:  "tenant",
+						}),
+						withLookup(newMapper("team", FieldContextSpanAttribute,
+							attrSrc("team", SpanMapperOperationCopy, 1),
+						), &SpanMapperLookup{
+							Table:   "pods",
+							Key:     "net.peer.ip",
+							Context: FieldContextSpanAttribute,
+							Column:  "team",
+						}),
+					},
+				},
+			},
+			lookupTables: map[string]lookuptabletypes.Entries{
+				"tenants": {
+					"c-1": {"tenant": "Acme", "tier": "gold"},
+					"c-2": {"tenant": "Globex $ Co"},
+					"c-3": {"tier": "silver"},
+				},
+				"hostname": {"h-1": {"owner": "infra"}},
+			},
+			want: "collector_with_lookup.yaml",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := GenerateCollectorConfigWithSpanMapperProcessor(baseline, tc.groups)
+			got, err := GenerateCollectorConfigWithSpanMapperProcessor(baseline, tc.groups, tc.lookupTables)
 			require.NoError(t, err)
 			assertYAMLEqual(t, loadFixture(t, tc.want), got)
 		})
@@ -97,7 +138,7 @@ func TestGenerateCollectorConfigWithSpanMapperProcessor_Errors(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			_, err := GenerateCollectorConfigWithSpanMapperProcessor(tc.in, nil)
+			_, err := GenerateCollectorConfigWithSpanMapperProcessor(tc.in, nil, nil)
 			require.Error(t, err)
 			assert.True(t, errors.Ast(err, errors.TypeInvalidInput), "want TypeInvalidInput, got %v", err)
 			assert.True(t, errors.Asc(err, ErrCodeInvalidCollectorConfig), "want ErrCodeInvalidCollectorConfig, got %v", err)
@@ -105,6 +146,82 @@ func TestGenerateCollectorConfigWithSpanMapperProcessor_Errors(t *testing.T) {
 	}
 }
 
+func TestGenerateCollectorConfigWithSpanMapperProcessor_RemovesStaleLookups(t *testing.T) {
+	t.Parallel()
+
+	got, err := GenerateCollectorConfigWithSpanMapperProcessor(loadFixture(t, "collector_with_lookup.yaml"), nil, nil)
+	require.NoError(t, err)
+	assertYAMLEqual(t, loadFixture(t, "collector_no_groups.yaml"), got)
+}
+
+// The lookup statements are parsed the way the transform processor parses
+// them, so that a value of the table can't break the collector config.
+func TestBuildLookupProcessorConfig(t *testing.T) {
+	t.Parallel()
+
+	groups := []*SpanMapperGroupWithMappers{
+		{
+			Group: newGroup("tenant", nil, []string{"customer.id"}),
+			Mappers: []*SpanMapper{
+				withLookup(newMapper("tenant.name", FieldContextSpanAttribute), &SpanMapperLookup{
+					Table:   "tenants",
+					Key:     `customer "id"`,
+					Context: FieldContextSpanAttribute,
+					Column:  "tenant",
+				}),
+			},
+		},
+	}
+
+	config := buildLookupProcessorConfig(groups, map[string]lookuptabletypes.Entries{
+		"tenants": {`c\1`: {"tenant": "Acme \"Inc\"\n"}},
+	})
+	require.NotNil(t, config)
+	require.Len(t, config.TraceStatements, 1)
+	require.Len(t, config.TraceStatements[0].Statements, 2)
+
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardFuncs[*ottlspan.TransformContext](), component.TelemetrySettings{Logger: zap.NewNop()})
+	require.NoError(t, err)
+	statements, err := parser.ParseStatements(config.TraceStatements[0].Statements)
+	require.NoError(t, err)
+
+	lookup := func(key string, existing string) (string, bool) {
+		span := ptrace.NewSpan()
+		if key != "" {
+			span.Attributes().PutStr(`customer "id"`, key)
+		}
+		if existing != "" {
+			span.Attributes().PutStr("tenant.name", existing)
+		}
+		tCtx := ottlspan.NewTransformContextPtr(ptrace.NewResourceSpans(), ptrace.NewScopeSpans(), span)
+		defer tCtx.Close()
+		for _, statement := range statements {
+			_, _, err := statement.Execute(context.Background(), tCtx)
+			require.NoError(t, err)
+		}
+		value, ok := span.Attributes().Get("tenant.name")
+		if !ok {
+			return "", false
+		}
+		return value.AsString(), true
+	}
+
+	value, ok := lookup(`c\1`, "")
+	assert.True(t, ok)
+	assert.Equal(t, "Acme \"Inc\"\n", value)
+
+	_, ok = lookup("c-2", "")
+	assert.False(t, ok)
+
+	_, ok = lookup("", "")
+	assert.False(t, ok)
+
+	value, _ = lookup(`c\1`, "Initech")
+	assert.Equal(t, "Initech", value)
+
+	assert.Nil(t, buildLookupProcessorConfig(groups, nil))
+}
+
 func TestBuildAttributeRule(t *testing.T) {
 	t.Parallel()
 
@@ -189,6 +306,11 @@ func newMapper(name string, target FieldContext, sources ...SpanMapperSource) *S
 	}
 }
 
+func withLookup(m *SpanMapper, lookup *SpanMapperLookup) *SpanMapper {
+	m.Config.Lookup = lookup
+	return m
+}
+
 func attrSrc(key string, op SpanMapperOperation, priority int) SpanMapperSource {
 	return SpanMapperSource{Key: key, Context: FieldContextSpanAttribute, Operation: op, Priority: priority}
 }
@@ -42,6 +42,22 @@ type TelemetryFieldKey struct {
 	Materialized bool                         `json:"-"` // refers to promoted in case of body.... fields
 
 	Evolutions []*EvolutionEntry `json:"-"`
+
+	// Lookup is set for lookup context fields joined by the query
+	Lookup *LookupColumn `json:"-"`
+}
+
+// LookupColumn is the column of a lookup table a lookup context field refers to.
+// The rows of the table are inlined in a CTE with a column per column of the table.
+type LookupColumn struct {
+	// CTEName is the name of the CTE holding the rows of the table
+	CTEName string
+	// KeyIndex is the index of the key column of the table
+	KeyIndex int
+	// Index is the index of the column of the table
+	Index int
+	// Key is the field whose value is looked up in the key column of the table
+	Key *TelemetryFieldKey
 }
 
 func (f *TelemetryFieldKey) KeyNameContainsArray() bool {
@@ -122,6 +138,7 @@ func (f *TelemetryFieldKey) OverrideMetadataFrom(src *TelemetryFieldKey) {
 	f.Materialized = src.Materialized
 	f.JSONPlan = src.JSONPlan
 	f.Evolutions = src.Evolutions
+	f.Lookup = src.Lookup
 }
 
 func (f *TelemetryFieldKey) Equal(key *TelemetryFieldKey) bool {
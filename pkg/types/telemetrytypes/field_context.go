@@ -39,6 +39,9 @@ import (
 //
 // - Use `body.` to indicate and enforce body context
 //   - `body.key` will look for `key` in the body field
+//
+// - Use `lookup.` to refer to a column of an org lookup table joined by the query
+//   - `lookup.tenants.tier` will resolve to the `tier` column of the `tenants` table
 type FieldContext struct {
 	valuer.String
 }
@@ -53,6 +56,7 @@ var (
 	FieldContextAttribute   = FieldContext{valuer.NewString("attribute")}
 	FieldContextEvent       = FieldContext{valuer.NewString("event")}
 	FieldContextBody        = FieldContext{valuer.NewString("body")}
+	FieldContextLookup      = FieldContext{valuer.NewString("lookup")}
 	FieldContextUnspecified = FieldContext{valuer.NewString("")}
 
 	// Map string representations to FieldContext values
@@ -76,6 +80,7 @@ var (
 		"log":        FieldContextLog,
 		"metric":     FieldContextMetric,
 		"tracefield": FieldContextTrace,
+		"lookup":     FieldContextLookup,
 	}
 )
 
@@ -151,6 +156,8 @@ func (f FieldContext) TagType() string {
 		return "eventfield"
 	case FieldContextBody:
 		return "body"
+	case FieldContextLookup:
+		return "lookup"
 	}
 	return ""
 }
@@ -164,9 +171,9 @@ func isContextValidForSignal(ctx FieldContext, signal Signal) bool {
 
 	switch signal.StringValue() {
 	case SignalLogs.StringValue():
-		return ctx == FieldContextLog || ctx == FieldContextBody
+		return ctx == FieldContextLog || ctx == FieldContextBody || ctx == FieldContextLookup
 	case SignalTraces.StringValue():
-		return ctx == FieldContextSpan || ctx == FieldContextEvent || ctx == FieldContextTrace
+		return ctx == FieldContextSpan || ctx == FieldContextEvent || ctx == FieldContextTrace || ctx == FieldContextLookup
 	case SignalMetrics.StringValue():
 		return ctx == FieldContextMetric
 	}
@@ -185,5 +192,6 @@ func (FieldContext) Enum() []any {
 		FieldContextAttribute,
 		// FieldContextEvent,
 		FieldContextBody,
+		FieldContextLookup,
 	}
 }
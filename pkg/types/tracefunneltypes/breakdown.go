@@ -0,0 +1,168 @@
+package tracefunneltypes
+
+import (
+	"math"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+const (
+	DefaultFunnelBreakdownLimit = 20
+	MaxFunnelBreakdownLimit     = 100
+	MaxFunnelBreakdownGroupBy   = 3
+)
+
+// FunnelBreakdownRequest represents a request for per-step conversion and
+// latency, optionally broken down by the values of the group by keys taken
+// from the span or log record that matched the first step.
+type FunnelBreakdownRequest struct {
+	TimeRange
+	GroupBy []qbtypes.GroupByKey `json:"group_by,omitempty"`
+	Limit   int                  `json:"limit,omitempty"`
+}
+
+// PostableFunnelBreakdown is a breakdown request for steps that have not been
+// saved to a funnel yet.
+type PostableFunnelBreakdown struct {
+	FunnelBreakdownRequest
+	FunnelSource
+	Steps []*FunnelStep `json:"steps"`
+}
+
+// GettableFunnelBreakdown is the response of a breakdown request. Without a
+// group by it has a single group with no labels.
+type GettableFunnelBreakdown struct {
+	Groups []*FunnelBreakdownGroup `json:"groups"`
+}
+
+type FunnelBreakdownGroup struct {
+	Labels map[string]string  `json:"labels,omitempty"`
+	Steps  []*FunnelStepStats `json:"steps"`
+}
+
+// FunnelStepStats holds the conversion and latency of a single step. Traces
+// counts journeys, which are correlation key values for funnels that set one.
+// Rates are percentages; conversion times are measured from the step's anchor
+// (the closest previous required step) and are zero for the first step.
+type FunnelStepStats struct {
+	StepOrder           int64   `json:"step_order"`
+	Traces              uint64  `json:"traces"`
+	ErroredTraces       uint64  `json:"errored_traces"`
+	ConversionRate      float64 `json:"conversion_rate"`
+	StepConversionRate  float64 `json:"step_conversion_rate"`
+	AvgConversionTimeMs float64 `json:"avg_conversion_time_ms"`
+	ConversionTimeMs    float64 `json:"conversion_time_ms"`
+	LatencyType         string  `json:"latency_type"`
+}
+
+// GettableFunnelTransition holds the conversion between two steps in the
+// columns of the overview analytics. Errors counts the journeys which reached
+// and errored in the step with the most of them.
+type GettableFunnelTransition struct {
+	ConversionRate float64 `json:"conversion_rate"`
+	AvgRate        float64 `json:"avg_rate"`
+	Errors         uint64  `json:"errors"`
+	AvgDuration    float64 `json:"avg_duration"`
+	Latency        float64 `json:"latency"`
+}
+
+// GettableFunnelJourney is a journey in the columns of the slowest traces
+// analytics. TraceID holds the correlation key value for funnels that set one
+// and SpanCount the number of records matching any step.
+type GettableFunnelJourney struct {
+	TraceID    string  `json:"trace_id"`
+	DurationMs float64 `json:"duration_ms"`
+	SpanCount  uint64  `json:"span_count"`
+}
+
+func (req *FunnelBreakdownRequest) Validate() error {
+	if err := req.TimeRange.Validate(); err != nil {
+		return err
+	}
+
+	if req.Limit < 0 || req.Limit > MaxFunnelBreakdownLimit {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be between 0 and %d", MaxFunnelBreakdownLimit)
+	}
+	if req.Limit == 0 {
+		req.Limit = DefaultFunnelBreakdownLimit
+	}
+
+	if len(req.GroupBy) > MaxFunnelBreakdownGroupBy {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "at most %d group by keys are supported", MaxFunnelBreakdownGroupBy)
+	}
+	for i, key := range req.GroupBy {
+		if strings.TrimSpace(key.Name) == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "group_by[%d]: name is required", i)
+		}
+	}
+
+	return nil
+}
+
+// LatencyQuantile returns the quantile for the step's latency type,
+// defaulting to p99.
+func (step *FunnelStep) LatencyQuantile() (string, float64) {
+	switch strings.ToLower(step.LatencyType) {
+	case "p90":
+		return "p90", 0.90
+	case "p95":
+		return "p95", 0.95
+	default:
+		return "p99", 0.99
+	}
+}
+
+// NewFunnelBreakdownGroup builds a group from the per-step aggregates of a
+// breakdown row. The slices are indexed by step and must match steps in length.
+func NewFunnelBreakdownGroup(steps []*FunnelStep, groupBy []qbtypes.GroupByKey, labels []string, traces []uint64, erroredTraces []uint64, avgConversionTimeMs []float64, conversionTimeMs []float64) (*FunnelBreakdownGroup, error) {
+	if len(labels) != len(groupBy) {
+		return nil, errors.NewInternalf(errors.CodeInternal, "expected %d labels, got %d", len(groupBy), len(labels))
+	}
+	for _, values := range [][]float64{avgConversionTimeMs, conversionTimeMs} {
+		if len(values) != len(steps) {
+			return nil, errors.NewInternalf(errors.CodeInternal, "expected %d step values, got %d", len(steps), len(values))
+		}
+	}
+	if len(traces) != len(steps) || len(erroredTraces) != len(steps) {
+		return nil, errors.NewInternalf(errors.CodeInternal, "expected %d step counts, got %d and %d", len(steps), len(traces), len(erroredTraces))
+	}
+
+	group := &FunnelBreakdownGroup{Steps: make([]*FunnelStepStats, len(steps))}
+	if len(groupBy) > 0 {
+		group.Labels = make(map[string]string, len(groupBy))
+		for i, key := range groupBy {
+			group.Labels[key.Name] = labels[i]
+		}
+	}
+
+	anchors := FunnelStepAnchors(steps)
+	for i, step := range steps {
+		latencyType, _ := step.LatencyQuantile()
+		stats := &FunnelStepStats{
+			StepOrder:           step.Order,
+			Traces:              traces[i],
+			ErroredTraces:       erroredTraces[i],
+			ConversionRate:      percentage(traces[i], traces[0]),
+			StepConversionRate:  100,
+			AvgConversionTimeMs: avgConversionTimeMs[i],
+			ConversionTimeMs:    conversionTimeMs[i],
+			LatencyType:         latencyType,
+		}
+		if anchors[i] >= 0 {
+			stats.StepConversionRate = percentage(traces[i], traces[anchors[i]])
+		}
+		group.Steps[i] = stats
+	}
+
+	return group, nil
+}
+
+func percentage(part uint64, whole uint64) float64 {
+	if whole == 0 {
+		return 0
+	}
+
+	return math.Round(float64(part)*10000/float64(whole)) / 100
+}
@@ -0,0 +1,121 @@
+package tracefunneltypes
+
+import (
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunnelBreakdownRequestValidate(t *testing.T) {
+	groupBy := func(names ...string) []qbtypes.GroupByKey {
+		keys := make([]qbtypes.GroupByKey, len(names))
+		for i, name := range names {
+			keys[i] = qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: name}}
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name          string
+		req           FunnelBreakdownRequest
+		expectError   bool
+		expectedLimit int
+	}{
+		{
+			name:          "defaults limit",
+			req:           FunnelBreakdownRequest{TimeRange: TimeRange{StartTime: 1, EndTime: 2}},
+			expectedLimit: DefaultFunnelBreakdownLimit,
+		},
+		{
+			name:          "keeps limit and group by",
+			req:           FunnelBreakdownRequest{TimeRange: TimeRange{StartTime: 1, EndTime: 2}, GroupBy: groupBy("deployment.environment"), Limit: 5},
+			expectedLimit: 5,
+		},
+		{
+			name:        "missing start time",
+			req:         FunnelBreakdownRequest{TimeRange: TimeRange{EndTime: 2}},
+			expectError: true,
+		},
+		{
+			name:        "start after end",
+			req:         FunnelBreakdownRequest{TimeRange: TimeRange{StartTime: 3, EndTime: 2}},
+			expectError: true,
+		},
+		{
+			name:        "limit too large",
+			req:         FunnelBreakdownRequest{TimeRange: TimeRange{StartTime: 1, EndTime: 2}, Limit: MaxFunnelBreakdownLimit + 1},
+			expectError: true,
+		},
+		{
+			name:        "too many group by keys",
+			req:         FunnelBreakdownRequest{TimeRange: TimeRange{StartTime: 1, EndTime: 2}, GroupBy: groupBy("a", "b", "c", "d")},
+			expectError: true,
+		},
+		{
+			name:        "blank group by key",
+			req:         FunnelBreakdownRequest{TimeRange: TimeRange{StartTime: 1, EndTime: 2}, GroupBy: groupBy(" ")},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, tt.req.Limit)
+		})
+	}
+}
+
+func TestFunnelStepAnchors(t *testing.T) {
+	steps := []*FunnelStep{
+		{Order: 1},
+		{Order: 2, Optional: true},
+		{Order: 3},
+		{Order: 4, Optional: true},
+		{Order: 5, Optional: true},
+		{Order: 6},
+	}
+
+	assert.Equal(t, []int{-1, 0, 0, 2, 2, 2}, FunnelStepAnchors(steps))
+}
+
+func TestNewFunnelBreakdownGroup(t *testing.T) {
+	steps := []*FunnelStep{
+		{Order: 1},
+		{Order: 2, Optional: true, LatencyType: "p90"},
+		{Order: 3, LatencyType: "P95"},
+	}
+	groupBy := []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "deployment.environment"}}}
+
+	group, err := NewFunnelBreakdownGroup(steps, groupBy, []string{"prod"}, []uint64{200, 50, 150}, []uint64{4, 1, 3}, []float64{0, 12.5, 40}, []float64{0, 20, 90})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"deployment.environment": "prod"}, group.Labels)
+	assert.Equal(t, []*FunnelStepStats{
+		{StepOrder: 1, Traces: 200, ErroredTraces: 4, ConversionRate: 100, StepConversionRate: 100, LatencyType: "p99"},
+		{StepOrder: 2, Traces: 50, ErroredTraces: 1, ConversionRate: 25, StepConversionRate: 25, AvgConversionTimeMs: 12.5, ConversionTimeMs: 20, LatencyType: "p90"},
+		{StepOrder: 3, Traces: 150, ErroredTraces: 3, ConversionRate: 75, StepConversionRate: 75, AvgConversionTimeMs: 40, ConversionTimeMs: 90, LatencyType: "p95"},
+	}, group.Steps)
+
+	t.Run("NoTraces", func(t *testing.T) {
+		group, err := NewFunnelBreakdownGroup(steps, nil, []string{}, []uint64{0, 0, 0}, []uint64{0, 0, 0}, []float64{0, 0, 0}, []float64{0, 0, 0})
+		require.NoError(t, err)
+		assert.Nil(t, group.Labels)
+		for _, stats := range group.Steps {
+			assert.Zero(t, stats.ConversionRate)
+		}
+	})
+
+	t.Run("MismatchedLengths", func(t *testing.T) {
+		_, err := NewFunnelBreakdownGroup(steps, nil, []string{}, []uint64{1, 1}, []uint64{0, 0, 0}, []float64{0, 0, 0}, []float64{0, 0, 0})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,55 @@
+package tracefunneltypes
+
+import (
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// Validate defaults the signal to traces and checks that the correlation key
+// is usable for it.
+func (source *FunnelSource) Validate() error {
+	switch source.Signal {
+	case telemetrytypes.SignalUnspecified:
+		source.Signal = telemetrytypes.SignalTraces
+	case telemetrytypes.SignalTraces, telemetrytypes.SignalLogs:
+	default:
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "signal must be one of %s or %s", telemetrytypes.SignalTraces.StringValue(), telemetrytypes.SignalLogs.StringValue())
+	}
+
+	if source.CorrelationKey != nil && strings.TrimSpace(source.CorrelationKey.Name) == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "correlation_key: name is required")
+	}
+	if source.Signal == telemetrytypes.SignalLogs && source.CorrelationKey == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "correlation_key is required for log funnels")
+	}
+
+	return nil
+}
+
+// IsTraceCorrelated reports whether the funnel matches spans correlated by
+// trace_id, which is what the original funnel analytics assume.
+func (source FunnelSource) IsTraceCorrelated() bool {
+	return source.Signal != telemetrytypes.SignalLogs && source.CorrelationKey == nil
+}
+
+// ValidateFunnelStepsForSource checks the signal specific constraints on top
+// of ValidateFunnelSteps. Log funnel steps can only be selected by filter
+// expressions.
+func ValidateFunnelStepsForSource(source FunnelSource, steps []*FunnelStep) error {
+	if source.Signal != telemetrytypes.SignalLogs {
+		return nil
+	}
+
+	for i, step := range steps {
+		if step == nil || !step.HasFilterExpression() {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: filter expression is required for log funnels", i+1)
+		}
+		if step.LatencyPointer == "end" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: log records have no duration, latency pointer must be start", i+1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package tracefunneltypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunnelSourceValidate(t *testing.T) {
+	sessionID := &telemetrytypes.TelemetryFieldKey{Name: "session.id"}
+
+	tests := []struct {
+		name           string
+		source         FunnelSource
+		expectError    bool
+		expectedSignal telemetrytypes.Signal
+	}{
+		{
+			name:           "defaults to traces",
+			source:         FunnelSource{},
+			expectedSignal: telemetrytypes.SignalTraces,
+		},
+		{
+			name:           "traces with correlation key",
+			source:         FunnelSource{Signal: telemetrytypes.SignalTraces, CorrelationKey: sessionID},
+			expectedSignal: telemetrytypes.SignalTraces,
+		},
+		{
+			name:           "logs with correlation key",
+			source:         FunnelSource{Signal: telemetrytypes.SignalLogs, CorrelationKey: sessionID},
+			expectedSignal: telemetrytypes.SignalLogs,
+		},
+		{
+			name:        "logs without correlation key",
+			source:      FunnelSource{Signal: telemetrytypes.SignalLogs},
+			expectError: true,
+		},
+		{
+			name:        "correlation key without name",
+			source:      FunnelSource{Signal: telemetrytypes.SignalLogs, CorrelationKey: &telemetrytypes.TelemetryFieldKey{}},
+			expectError: true,
+		},
+		{
+			name:        "unsupported signal",
+			source:      FunnelSource{Signal: telemetrytypes.SignalMetrics},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.source.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSignal, tt.source.Signal)
+		})
+	}
+}
+
+func TestFunnelSourceIsTraceCorrelated(t *testing.T) {
+	assert.True(t, FunnelSource{}.IsTraceCorrelated())
+	assert.True(t, FunnelSource{Signal: telemetrytypes.SignalTraces}.IsTraceCorrelated())
+	assert.False(t, FunnelSource{Signal: telemetrytypes.SignalTraces, CorrelationKey: &telemetrytypes.TelemetryFieldKey{Name: "session.id"}}.IsTraceCorrelated())
+	assert.False(t, FunnelSource{Signal: telemetrytypes.SignalLogs, CorrelationKey: &telemetrytypes.TelemetryFieldKey{Name: "session.id"}}.IsTraceCorrelated())
+}
+
+func TestValidateFunnelStepsForSource(t *testing.T) {
+	logs := FunnelSource{Signal: telemetrytypes.SignalLogs, CorrelationKey: &telemetrytypes.TelemetryFieldKey{Name: "session.id"}}
+	legacySteps := []*FunnelStep{
+		{Order: 1, ServiceName: "frontend", SpanName: "checkout"},
+		{Order: 2, ServiceName: "payment", SpanName: "charge"},
+	}
+	filterSteps := []*FunnelStep{
+		{Order: 1, Filter: &qbtypes.Filter{Expression: "event.name = 'cart_viewed'"}},
+		{Order: 2, Filter: &qbtypes.Filter{Expression: "event.name = 'order_placed'"}},
+	}
+
+	assert.NoError(t, ValidateFunnelStepsForSource(FunnelSource{}, legacySteps))
+	assert.NoError(t, ValidateFunnelStepsForSource(logs, filterSteps))
+	assert.Error(t, ValidateFunnelStepsForSource(logs, legacySteps))
+
+	endPointer := []*FunnelStep{
+		filterSteps[0],
+		{Order: 2, Filter: &qbtypes.Filter{Expression: "event.name = 'order_placed'"}, LatencyPointer: "end"},
+	}
+	assert.Error(t, ValidateFunnelStepsForSource(logs, endPointer))
+}
+
+func TestFunnelSourceJSON(t *testing.T) {
+	var funnel PostableFunnel
+	require.NoError(t, json.Unmarshal([]byte(`{"funnel_name":"checkout","signal":"logs","correlation_key":{"name":"session.id","fieldContext":"attribute"}}`), &funnel))
+	assert.Equal(t, telemetrytypes.SignalLogs, funnel.Signal)
+	require.NotNil(t, funnel.CorrelationKey)
+	assert.Equal(t, "session.id", funnel.CorrelationKey.Name)
+	assert.Equal(t, telemetrytypes.FieldContextAttribute, funnel.CorrelationKey.FieldContext)
+
+	data, err := json.Marshal(GettableFunnel{FunnelName: "checkout"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "signal")
+	assert.NotContains(t, string(data), "correlation_key")
+}
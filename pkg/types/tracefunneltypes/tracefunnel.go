@@ -4,6 +4,8 @@ import (
 	"github.com/SigNoz/signoz/pkg/errors"
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
 	"github.com/SigNoz/signoz/pkg/types"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/uptrace/bun"
 )
@@ -24,6 +26,15 @@ type StorableFunnel struct {
 	Steps         []*FunnelStep `json:"steps" bun:"steps,type:text,notnull"`
 	Tags          string        `json:"tags" bun:"tags,type:text"`
 	CreatedByUser *types.User   `json:"user" bun:"rel:belongs-to,join:created_by=id"`
+	FunnelSource
+}
+
+// FunnelSource describes which signal the funnel steps match and which key
+// correlates matches into one journey. Trace funnels correlate by trace_id
+// unless a correlation key is set; log funnels always need one.
+type FunnelSource struct {
+	Signal         telemetrytypes.Signal             `json:"signal,omitzero" bun:"signal,type:text"`
+	CorrelationKey *telemetrytypes.TelemetryFieldKey `json:"correlation_key,omitempty" bun:"correlation_key,type:text"`
 }
 
 type FunnelStep struct {
@@ -37,6 +48,18 @@ type FunnelStep struct {
 	LatencyPointer string        `json:"latency_pointer,omitempty"`
 	LatencyType    string        `json:"latency_type,omitempty"`
 	HasErrors      bool          `json:"has_errors"`
+
+	// Filter is a v5 filter expression selecting the spans of this step. When
+	// set, it replaces ServiceName, SpanName and Filters.
+	Filter *qbtypes.Filter `json:"filter,omitempty"`
+	// MaxConversionTimeMs bounds the time between the previous required step
+	// and this one. Zero means unbounded.
+	MaxConversionTimeMs int64 `json:"max_conversion_time_ms,omitempty"`
+	// Optional steps are reported but do not have to be reached for the trace
+	// to progress to later steps.
+	Optional bool `json:"optional,omitempty"`
+	// Unordered steps may happen before or after the previous required step.
+	Unordered bool `json:"unordered,omitempty"`
 }
 
 // PostableFunnel represents all possible funnel-related requests.
@@ -53,6 +76,8 @@ type PostableFunnel struct {
 	EndTime   int64 `json:"end_time,omitempty"`
 	StepStart int64 `json:"step_start,omitempty"`
 	StepEnd   int64 `json:"step_end,omitempty"`
+
+	FunnelSource
 }
 
 // GettableFunnel represents all possible funnel-related responses.
@@ -68,6 +93,7 @@ type GettableFunnel struct {
 	UserEmail   string          `json:"user_email,omitempty"`
 	Funnel      *StorableFunnel `json:"funnel,omitempty"`
 	Steps       []*FunnelStep   `json:"steps,omitempty"`
+	FunnelSource
 }
 
 // TimeRange represents a time range for analytics.
@@ -76,6 +102,20 @@ type TimeRange struct {
 	EndTime   int64 `json:"end_time"`
 }
 
+func (timeRange TimeRange) Validate() error {
+	if err := ValidateTimestamp(timeRange.StartTime, "start_time"); err != nil {
+		return err
+	}
+	if err := ValidateTimestamp(timeRange.EndTime, "end_time"); err != nil {
+		return err
+	}
+	if timeRange.StartTime >= timeRange.EndTime {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start_time must be before end_time")
+	}
+
+	return nil
+}
+
 // StepTransitionRequest represents a request for step transition analytics.
 type StepTransitionRequest struct {
 	TimeRange
@@ -2,6 +2,7 @@ package tracefunneltypes
 
 import (
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
@@ -30,21 +31,68 @@ func ValidateFunnelSteps(steps []*FunnelStep) error {
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "funnel must have at least 2 steps")
 	}
 
+	first := 0
 	for i, step := range steps {
-		if step.ServiceName == "" {
-			return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: service name is required", i+1)
-		}
-		if step.SpanName == "" {
-			return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: span name is required", i+1)
+		if step.HasFilterExpression() {
+			if step.Filters != nil && len(step.Filters.Items) > 0 {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: filters and filter expression cannot be used together", i+1)
+			}
+		} else {
+			if step.ServiceName == "" {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: service name is required", i+1)
+			}
+			if step.SpanName == "" {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: span name is required", i+1)
+			}
 		}
 		if step.Order < 0 {
 			return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: order must be non-negative", i+1)
 		}
+		if step.MaxConversionTimeMs < 0 {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: max conversion time must be non-negative", i+1)
+		}
+		if step.Order < steps[first].Order {
+			first = i
+		}
+	}
+
+	// The first step is where traces enter the funnel, so it cannot be skipped
+	// and has no previous step to be ordered against.
+	if steps[first].Optional || steps[first].Unordered || steps[first].MaxConversionTimeMs > 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d: the first step cannot be optional, unordered or have a max conversion time", first+1)
 	}
 
 	return nil
 }
 
+// HasFilterExpression reports whether the step is selected by a v5 filter
+// expression instead of service and span name.
+func (step *FunnelStep) HasFilterExpression() bool {
+	return step.Filter != nil && strings.TrimSpace(step.Filter.Expression) != ""
+}
+
+// IsLegacy reports whether the step only uses the service, span and v3 filter
+// conditions understood by the original funnel analytics queries.
+func (step *FunnelStep) IsLegacy() bool {
+	return !step.HasFilterExpression() && step.MaxConversionTimeMs == 0 && !step.Optional && !step.Unordered
+}
+
+// FunnelStepAnchors returns, for each of the ordered steps, the index of the
+// step it is measured against: the closest previous step that is not
+// optional. The first step has no anchor and gets -1.
+func FunnelStepAnchors(steps []*FunnelStep) []int {
+	anchors := make([]int, len(steps))
+	anchor := -1
+	for i, step := range steps {
+		anchors[i] = anchor
+		if !step.Optional {
+			anchor = i
+		}
+	}
+
+	return anchors
+}
+
 // NormalizeFunnelSteps normalizes step orders to be sequential starting from 1.
 // The function takes a slice of pointers to FunnelStep and returns a new slice with normalized step orders.
 // The input slice is left unchanged. If the input slice contains nil pointers, they will be filtered out.
@@ -96,15 +144,16 @@ func ValidateAndConvertTimestamp(timestamp int64) (time.Time, error) {
 
 func ConstructFunnelResponse(funnel *StorableFunnel, claims *authtypes.Claims) GettableFunnel {
 	resp := GettableFunnel{
-		FunnelName:  funnel.Name,
-		FunnelID:    funnel.ID.String(),
-		Steps:       funnel.Steps,
-		CreatedAt:   funnel.CreatedAt.UnixNano() / 1000000,
-		CreatedBy:   funnel.CreatedBy,
-		OrgID:       funnel.OrgID.String(),
-		UpdatedBy:   funnel.UpdatedBy,
-		UpdatedAt:   funnel.UpdatedAt.UnixNano() / 1000000,
-		Description: funnel.Description,
+		FunnelName:   funnel.Name,
+		FunnelID:     funnel.ID.String(),
+		Steps:        funnel.Steps,
+		CreatedAt:    funnel.CreatedAt.UnixNano() / 1000000,
+		CreatedBy:    funnel.CreatedBy,
+		OrgID:        funnel.OrgID.String(),
+		UpdatedBy:    funnel.UpdatedBy,
+		UpdatedAt:    funnel.UpdatedAt.UnixNano() / 1000000,
+		Description:  funnel.Description,
+		FunnelSource: funnel.FunnelSource,
 	}
 
 	if funnel.CreatedByUser != nil {
@@ -4,8 +4,10 @@ import (
 	"testing"
 	"time"
 
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/assert"
 )
@@ -182,6 +184,109 @@ func TestValidateFunnelSteps(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "filter expression instead of service and span name",
+			steps: []*FunnelStep{
+				{
+					ID:     valuer.GenerateUUID(),
+					Name:   "Step 1",
+					Filter: &qbtypes.Filter{Expression: "service.name = 'frontend' AND name = 'checkout'"},
+					Order:  1,
+				},
+				{
+					ID:                  valuer.GenerateUUID(),
+					Name:                "Step 2",
+					Filter:              &qbtypes.Filter{Expression: "http.status_code = 200"},
+					MaxConversionTimeMs: 5000,
+					Optional:            true,
+					Order:               2,
+				},
+				{
+					ID:          valuer.GenerateUUID(),
+					Name:        "Step 3",
+					ServiceName: "payment",
+					SpanName:    "charge",
+					Unordered:   true,
+					Order:       3,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "filter expression and v3 filters together",
+			steps: []*FunnelStep{
+				{
+					ID:      valuer.GenerateUUID(),
+					Name:    "Step 1",
+					Filter:  &qbtypes.Filter{Expression: "name = 'checkout'"},
+					Filters: &v3.FilterSet{Operator: "AND", Items: []v3.FilterItem{{Key: v3.AttributeKey{Key: "http.method"}, Operator: v3.FilterOperatorEqual, Value: "GET"}}},
+					Order:   1,
+				},
+				{
+					ID:     valuer.GenerateUUID(),
+					Name:   "Step 2",
+					Filter: &qbtypes.Filter{Expression: "name = 'pay'"},
+					Order:  2,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "negative max conversion time",
+			steps: []*FunnelStep{
+				{
+					ID:     valuer.GenerateUUID(),
+					Name:   "Step 1",
+					Filter: &qbtypes.Filter{Expression: "name = 'checkout'"},
+					Order:  1,
+				},
+				{
+					ID:                  valuer.GenerateUUID(),
+					Name:                "Step 2",
+					Filter:              &qbtypes.Filter{Expression: "name = 'pay'"},
+					MaxConversionTimeMs: -1,
+					Order:               2,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "optional first step",
+			steps: []*FunnelStep{
+				{
+					ID:     valuer.GenerateUUID(),
+					Name:   "Step 2",
+					Filter: &qbtypes.Filter{Expression: "name = 'pay'"},
+					Order:  2,
+				},
+				{
+					ID:       valuer.GenerateUUID(),
+					Name:     "Step 1",
+					Filter:   &qbtypes.Filter{Expression: "name = 'checkout'"},
+					Optional: true,
+					Order:    1,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "blank filter expression without service name",
+			steps: []*FunnelStep{
+				{
+					ID:     valuer.GenerateUUID(),
+					Name:   "Step 1",
+					Filter: &qbtypes.Filter{Expression: "  "},
+					Order:  1,
+				},
+				{
+					ID:     valuer.GenerateUUID(),
+					Name:   "Step 2",
+					Filter: &qbtypes.Filter{Expression: "name = 'pay'"},
+					Order:  2,
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {